@@ -0,0 +1,138 @@
+// Command backfill builds newly added projections from the stored reservations.
+// A projection deployed after reservations were made only receives the events
+// published from then on; the backfill projects the current state of every
+// reservation into it. Progress is printed after every batch and saved to a
+// checkpoint, so an interrupted backfill resumes where it stopped. Run it once
+// the server with the new projection is running, so no event is missed.
+//
+// Usage:
+//
+//	go run ./cmd/backfill [-restart] [-batch 100] search housekeeping
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/env"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/backfill"
+	"github.com/andygeiss/hotel-booking/internal/domain/housekeeping"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/search"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func main() {
+	ctx, cancel := service.Context()
+	defer cancel()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "backfill failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run backfills the projections named in args, one after the other.
+func run(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	restart := flags.Bool("restart", false, "discard the checkpoints and start over")
+	batch := flags.Int("batch", backfill.DefaultBatchSize, "reservations projected between two checkpoints")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	svc, closeDB, err := newBackfillService(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+	svc.WithBatchSize(*batch)
+
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: backfill [-restart] [-batch n] <projection>... (available: %s)", strings.Join(svc.Projections(), ", "))
+	}
+
+	for _, name := range flags.Args() {
+		if *restart {
+			if err := svc.Reset(ctx, name); err != nil {
+				return err
+			}
+		}
+		progress, err := svc.Run(ctx, name, func(p backfill.Progress) {
+			fmt.Fprintf(out, "%s: %d/%d reservations projected (last %s)\n", p.Projection, p.Projected, p.Total, p.LastID)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w (run again to resume)", name, err)
+		}
+		fmt.Fprintf(out, "%s: done, %d reservations projected\n", name, progress.Projected)
+	}
+	return nil
+}
+
+// newBackfillService wires the reservation database configured via RESERVATION_DB_*
+// variables and the projections that are configured like in cmd/server.
+// The returned function closes the database.
+func newBackfillService(ctx context.Context) (*backfill.Service, func(), error) {
+	codec, err := outbound.NewCodec(env.Get("CODEC", "json"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		env.Get("RESERVATION_DB_HOST", "localhost"),
+		env.Get("RESERVATION_DB_PORT", "5432"),
+		env.Get("RESERVATION_DB_USER", "reservation"),
+		env.Get("RESERVATION_DB_PASSWORD", "reservation_secret"),
+		env.Get("RESERVATION_DB_NAME", "reservation_db"),
+		env.Get("RESERVATION_DB_SSLMODE", "disable"),
+	)
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to reservation database: %w", err)
+	}
+	closeDB := func() { _ = db.Close() }
+
+	// Guest PII is decrypted, so the projections see the same data as the server
+	var repo reservation.ReservationRepository = outbound.NewPostgresReservationRepository(db)
+	if keys := env.Get("PII_ENCRYPTION_KEYS", ""); keys != "" {
+		encryptor, err := outbound.NewAESGCMEncryptorFromSpec(keys)
+		if err != nil {
+			closeDB()
+			return nil, nil, fmt.Errorf("failed to create encryptor: %w", err)
+		}
+		repo = outbound.NewEncryptedReservationRepository(repo, encryptor)
+	}
+
+	svc := backfill.NewService(repo,
+		outbound.NewFileAccess[string, backfill.Checkpoint](env.Get("BACKFILL_CHECKPOINTS_PATH", "backfill_checkpoints.json"), codec),
+	)
+
+	// The projections are written from the stored reservations only,
+	// so their services need no reservation service.
+	if openSearchURL := env.Get("OPENSEARCH_URL", ""); openSearchURL != "" {
+		index := outbound.NewOpenSearchIndex(openSearchURL,
+			env.Get("OPENSEARCH_INDEX", "reservations"),
+			env.Get("OPENSEARCH_USERNAME", ""),
+			env.Get("OPENSEARCH_PASSWORD", ""),
+			&http.Client{Timeout: env.Get("SERVICE_TIMEOUT", 5*time.Second)},
+		)
+		if err := index.EnsureIndex(ctx); err != nil {
+			closeDB()
+			return nil, nil, err
+		}
+		svc.WithProjection("search", search.NewService(nil, index).IndexReservation)
+	}
+	svc.WithProjection("housekeeping", housekeeping.NewService(nil,
+		outbound.NewFileAccess[housekeeping.TaskID, housekeeping.Task](env.Get("HOUSEKEEPING_TASKS_PATH", "housekeeping_tasks.json"), codec),
+	).BackfillReservation)
+	return svc, closeDB, nil
+}
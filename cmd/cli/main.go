@@ -0,0 +1,220 @@
+// Command cli runs the booking saga against in-memory adapters and prints a
+// step-by-step trace of the events it publishes. The services are wired like
+// cmd/server wires them, which makes the demo a runnable example of the
+// hexagonal architecture that needs neither Postgres nor Kafka.
+//
+// The event simulator publishes a crafted event onto the same wiring, so the
+// event handlers and projections can be exercised without driving the saga.
+// With -url, it publishes onto a running server via its admin API instead
+// (EVENT_SIMULATOR_ENABLED, bearer token from -token or EVENT_SIMULATOR_TOKEN).
+//
+// The replay republishes the events a server recorded (EVENT_RECORDING_FILE) in
+// their original order and timing, onto the same wiring or, with -brokers, onto
+// Kafka, e.g. to reproduce an incident of production locally.
+//
+// Usage:
+//
+//	go run ./cmd/cli booking demo
+//	go run ./cmd/cli simulate event -topic payment.captured -file payload.json [-url http://localhost:8080]
+//	go run ./cmd/cli replay events -file events.jsonl [-speed 10] [-topics payment.captured] [-brokers localhost:9092]
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "cli failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches the cli subcommands.
+func run(ctx context.Context, args []string, out io.Writer) error {
+	switch {
+	case len(args) == 2 && args[0] == "booking" && args[1] == "demo":
+		return runBookingDemo(ctx, out)
+	case len(args) >= 2 && args[0] == "simulate" && args[1] == "event":
+		return runSimulateEvent(ctx, args[2:], out)
+	case len(args) >= 2 && args[0] == "replay" && args[1] == "events":
+		return runReplayEvents(ctx, args[2:], out)
+	default:
+		return errors.New("usage: cli booking demo | cli simulate event -topic <topic> -file <payload.json> [-url <server>] | cli replay events -file <recording.jsonl> [-speed 1] [-brokers <brokers>]")
+	}
+}
+
+// runBookingDemo books one stay that is paid and confirmed and one whose payment
+// is declined, so the saga compensates by cancelling the reservation.
+func runBookingDemo(ctx context.Context, out io.Writer) error {
+	demo, err := newBookingDemo(ctx, out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "== Booking res-demo-001: the payment gateway accepts the payment")
+	if err := demo.book(ctx, "res-demo-001", "room-101"); err != nil {
+		return err
+	}
+
+	demo.gateway.ShouldFail = true
+	fmt.Fprintln(out, "\n== Booking res-demo-002: the payment gateway declines, the saga compensates")
+	return demo.book(ctx, "res-demo-002", "room-102")
+}
+
+// tracingDispatcher prints every published message before it is dispatched,
+// so the trace lists each event before the events its handlers publish.
+type tracingDispatcher struct {
+	messaging.Dispatcher
+	out io.Writer
+
+	mu   sync.Mutex
+	step int
+}
+
+// Publish prints the message topic and dispatches the message.
+func (d *tracingDispatcher) Publish(ctx context.Context, message messaging.Message) error {
+	d.mu.Lock()
+	d.step++
+	fmt.Fprintf(d.out, "  %2d. %s\n", d.step, message.Topic)
+	d.mu.Unlock()
+	return d.Dispatcher.Publish(ctx, message)
+}
+
+// Subscribe subscribes the handler and prints its errors instead of returning
+// them to the publisher, as a broker keeps handler errors from the publisher.
+func (d *tracingDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return d.Dispatcher.Subscribe(ctx, topic, func(ctx context.Context, message messaging.Message) (messaging.MessageState, error) {
+		state, err := fn(ctx, message)
+		if err != nil {
+			d.mu.Lock()
+			fmt.Fprintf(d.out, "      %s handler: %v\n", message.Topic, err)
+			d.mu.Unlock()
+		}
+		return state, nil
+	})
+}
+
+// reset restarts the step numbering for the next booking.
+func (d *tracingDispatcher) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.step = 0
+}
+
+// bookingDemo holds the services of the wired booking saga.
+type bookingDemo struct {
+	out          io.Writer
+	dispatcher   *tracingDispatcher
+	gateway      *outbound.MockPaymentGateway
+	reservations *reservation.Service
+	payments     *payment.Service
+	bookings     *orchestration.BookingService
+	sagas        *orchestration.SagaTracker
+}
+
+// newBookingDemo wires the event-driven booking saga with in-memory adapters.
+func newBookingDemo(ctx context.Context, out io.Writer) (*bookingDemo, error) {
+	logger := slog.New(slog.DiscardHandler)
+	dispatcher := &tracingDispatcher{Dispatcher: messaging.NewInternalDispatcher(), out: out}
+	publisher := outbound.NewEventPublisher(dispatcher)
+
+	// Reservation context
+	reservationRepo := outbound.NewReservationRepository(resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]())
+	guestProfiles := outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]())
+	reservationService := reservation.NewService(reservationRepo, outbound.NewRepositoryAvailabilityChecker(reservationRepo), publisher).
+		WithGuestProfiles(guestProfiles)
+
+	// Payment context
+	gateway := outbound.NewMockPaymentGateway()
+	paymentService := payment.NewService(
+		outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]()),
+		gateway,
+		publisher,
+	)
+
+	// Orchestration
+	notifications := outbound.NewMockNotificationService(logger).WithGuestProfiles(guestProfiles)
+	bookingService := orchestration.NewBookingService(reservationService, paymentService, notifications).
+		WithEventPublisher(publisher)
+	handlers := orchestration.NewEventHandlers(bookingService, reservationService, paymentService)
+	if err := handlers.RegisterHandlers(ctx, dispatcher); err != nil {
+		return nil, fmt.Errorf("failed to register event handlers: %w", err)
+	}
+	sagas := orchestration.NewSagaTracker(resource.NewInMemoryAccess[shared.ReservationID, orchestration.SagaState]())
+	if err := sagas.RegisterHandlers(ctx, dispatcher); err != nil {
+		return nil, fmt.Errorf("failed to register saga tracker: %w", err)
+	}
+
+	return &bookingDemo{
+		out:          out,
+		dispatcher:   dispatcher,
+		gateway:      gateway,
+		reservations: reservationService,
+		payments:     paymentService,
+		bookings:     bookingService,
+		sagas:        sagas,
+	}, nil
+}
+
+// book starts the saga of a three-night stay in two days and prints its outcome.
+// A declined payment is an expected outcome of the saga and not an error.
+func (d *bookingDemo) book(ctx context.Context, id shared.ReservationID, roomID reservation.RoomID) error {
+	d.dispatcher.reset()
+	checkIn := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
+	_, err := d.bookings.InitiateBooking(ctx, id, "guest-demo", roomID,
+		reservation.NewDateRange(checkIn, checkIn.Add(72*time.Hour)),
+		shared.NewMoney(30000, "EUR"),
+		[]reservation.GuestInfo{{Name: "Jane Doe", Email: "jane@example.com", PhoneNumber: "+15551234567"}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initiate booking %s: %w", id, err)
+	}
+	return d.printOutcome(ctx, id)
+}
+
+// printOutcome prints the reservation and payment status and the saga steps.
+func (d *bookingDemo) printOutcome(ctx context.Context, id shared.ReservationID) error {
+	res, err := d.reservations.GetReservation(ctx, reservation.ToReservationID(id))
+	if err != nil {
+		return fmt.Errorf("failed to read reservation %s: %w", id, err)
+	}
+	fmt.Fprintf(d.out, "  reservation:        %s", res.Status)
+	if res.CancellationReason != "" {
+		fmt.Fprintf(d.out, " (%s)", res.CancellationReason)
+	}
+	fmt.Fprintln(d.out)
+
+	if pay, err := d.payments.GetPaymentByReservation(ctx, payment.ToReservationID(id)); err == nil {
+		fmt.Fprintf(d.out, "  payment:            %s\n", pay.Status)
+	}
+
+	state, err := d.sagas.GetSagaState(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read saga state %s: %w", id, err)
+	}
+	for _, step := range state.Steps {
+		fmt.Fprintf(d.out, "  saga %-15s %s", step.Step+":", step.Status)
+		if step.Error != "" {
+			fmt.Fprintf(d.out, " (%s)", step.Error)
+		}
+		fmt.Fprintln(d.out)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+)
+
+func Test_Run_With_Unknown_Command_Should_Return_Error(t *testing.T) {
+	// Act
+	err := run(context.Background(), []string{"booking"}, &bytes.Buffer{})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_Run_Booking_Demo_Should_Confirm_First_And_Compensate_Second_Booking(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+
+	// Act
+	err := run(context.Background(), []string{"booking", "demo"}, &out)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	first, second, found := strings.Cut(out.String(), "res-demo-002")
+	assert.That(t, "second booking must be traced", found, true)
+	assert.That(t, "first booking must be confirmed", strings.Contains(first, "reservation.confirmed"), true)
+	assert.That(t, "second booking must fail the payment", strings.Contains(second, "payment.failed"), true)
+	assert.That(t, "second booking must be cancelled", strings.Contains(second, "reservation.cancelled"), true)
+	assert.That(t, "reservation step must be compensated", strings.Contains(second, "compensated"), true)
+}
+
+func writePayload(t *testing.T, payload string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "payload.json")
+	if err := os.WriteFile(file, []byte(payload), 0o600); err != nil {
+		t.Fatalf("failed to write payload: %v", err)
+	}
+	return file
+}
+
+func Test_Run_Simulate_Event_With_Unknown_Topic_Should_Return_Error(t *testing.T) {
+	// Arrange
+	file := writePayload(t, `{}`)
+
+	// Act
+	err := run(context.Background(), []string{"simulate", "event", "-topic", "payment.unknown", "-file", file}, &bytes.Buffer{})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_Run_Simulate_Event_With_Invalid_JSON_Should_Return_Error(t *testing.T) {
+	// Arrange
+	file := writePayload(t, `{"reservation_id":`)
+
+	// Act
+	err := run(context.Background(), []string{"simulate", "event", "-topic", "payment.captured", "-file", file}, &bytes.Buffer{})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_Run_Simulate_Event_Should_Trace_Event_And_Handler_Errors(t *testing.T) {
+	// Arrange
+	file := writePayload(t, `{"reservation_id":"res-sim-001","payment_id":"pay-sim-001"}`)
+	var out bytes.Buffer
+
+	// Act
+	err := run(context.Background(), []string{"simulate", "event", "-topic", "payment.captured", "-file", file}, &out)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "event must be traced", strings.Contains(out.String(), "1. payment.captured"), true)
+	assert.That(t, "handler error must be printed", strings.Contains(out.String(), "payment.captured handler:"), true)
+}
+
+func Test_Run_Simulate_Event_With_URL_Should_Post_To_Admin_API(t *testing.T) {
+	// Arrange
+	file := writePayload(t, `{"reservation_id":"res-sim-001"}`)
+	var path, auth, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.Path, r.Header.Get("Authorization")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	// Act
+	err := run(context.Background(), []string{"simulate", "event", "-topic", "reservation.cancelled", "-file", file, "-url", server.URL, "-token", "dev-token"}, &bytes.Buffer{})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "path must name the topic", path, "/api/admin/events/reservation.cancelled")
+	assert.That(t, "bearer token must be sent", auth, "Bearer dev-token")
+	assert.That(t, "payload must be sent", body, `{"reservation_id":"res-sim-001"}`)
+}
+
+func Test_Run_Replay_Events_Should_Republish_Recorded_Events_In_Order(t *testing.T) {
+	// Arrange
+	file := filepath.Join(t.TempDir(), "events.jsonl")
+	recording := `{"time":"2026-01-01T12:00:00Z","topic":"payment.captured","data":{"reservation_id":"res-rec-001"}}
+{"time":"2026-01-01T12:00:01Z","topic":"reservation.cancelled","data":{"reservation_id":"res-rec-001"}}
+`
+	_ = os.WriteFile(file, []byte(recording), 0o600)
+	var out bytes.Buffer
+
+	// Act
+	err := run(context.Background(), []string{"replay", "events", "-file", file, "-speed", "0"}, &out)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "replay must be announced", strings.Contains(out.String(), "Replaying 2 events"), true)
+	assert.That(t, "first event must be traced first", strings.Contains(out.String(), "1. payment.captured"), true)
+	assert.That(t, "second event must be traced", strings.Contains(out.String(), ". reservation.cancelled"), true)
+}
+
+func Test_Run_Replay_Events_With_Topics_Should_Replay_Selected_Topics(t *testing.T) {
+	// Arrange
+	file := filepath.Join(t.TempDir(), "events.jsonl")
+	recording := `{"time":"2026-01-01T12:00:00Z","topic":"payment.captured","data":{"reservation_id":"res-rec-001"}}
+{"time":"2026-01-01T12:00:01Z","topic":"reservation.cancelled","data":{"reservation_id":"res-rec-001"}}
+`
+	_ = os.WriteFile(file, []byte(recording), 0o600)
+	var out bytes.Buffer
+
+	// Act
+	err := run(context.Background(), []string{"replay", "events", "-file", file, "-speed", "0", "-topics", "reservation.cancelled"}, &out)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "only the selected topic must be replayed", strings.Contains(out.String(), "Replaying 1 events"), true)
+	assert.That(t, "other topics must be skipped", strings.Contains(out.String(), "payment.captured"), false)
+}
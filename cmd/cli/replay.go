@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// runReplayEvents republishes the events of a recording (EVENT_RECORDING_FILE of the
+// server) in their recorded order and with their recorded gaps, onto the in-memory
+// wiring of the booking demo or, with -brokers, onto Kafka.
+func runReplayEvents(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("replay events", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	file := flags.String("file", "", "recording written by a server with EVENT_RECORDING_FILE")
+	speed := flags.Float64("speed", 1, "speed of the replay, e.g. 10 for ten times faster, 0 without waiting")
+	topics := flags.String("topics", "", "comma-separated topics to replay, e.g. reservation.created (default all)")
+	brokers := flags.String("brokers", "", "comma-separated Kafka brokers to replay onto, e.g. localhost:9092")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("usage: cli replay events -file <recording.jsonl> [-speed 1] [-topics <topics>] [-brokers <brokers>]")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	messages, err := inbound.ReadRecording(f)
+	if err != nil {
+		return err
+	}
+	if *topics != "" {
+		selected := strings.Split(*topics, ",")
+		messages = slices.DeleteFunc(messages, func(message inbound.RecordedMessage) bool {
+			return !slices.Contains(selected, message.Topic)
+		})
+	}
+
+	var dispatcher messaging.Dispatcher
+	if *brokers != "" {
+		hostname, _ := os.Hostname()
+		kafka := outbound.NewKafkaDispatcher(strings.Split(*brokers, ","), "hotel-booking-replay", hostname)
+		defer func() { _ = kafka.Close() }()
+		dispatcher = kafka
+	} else {
+		// Handlers of the in-memory wiring publish events of their own, so replaying
+		// both an event and the events it caused shows the latter twice.
+		demo, err := newBookingDemo(ctx, out)
+		if err != nil {
+			return err
+		}
+		dispatcher = demo.dispatcher
+	}
+
+	fmt.Fprintf(out, "== Replaying %d events of %s\n", len(messages), *file)
+	return inbound.Replay(ctx, dispatcher, messages, *speed)
+}
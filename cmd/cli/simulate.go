@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/domain/admin"
+)
+
+// runSimulateEvent publishes the payload file as an event of the topic, onto the
+// in-memory wiring of the booking demo or, with -url, onto a running server.
+func runSimulateEvent(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("simulate event", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	topic := flags.String("topic", "", "topic of the event, e.g. payment.captured")
+	file := flags.String("file", "", "JSON file with the event payload")
+	url := flags.String("url", "", "base URL of a server with EVENT_SIMULATOR_ENABLED, e.g. http://localhost:8080")
+	token := flags.String("token", os.Getenv("EVENT_SIMULATOR_TOKEN"), "bearer token for the admin API of the server")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if !slices.Contains(admin.EventTopics, *topic) {
+		return fmt.Errorf("unknown topic %q (available: %s)", *topic, strings.Join(admin.EventTopics, ", "))
+	}
+	if *file == "" {
+		return errors.New("usage: cli simulate event -topic <topic> -file <payload.json> [-url <server>]")
+	}
+	payload, err := os.ReadFile(*file)
+	if err != nil {
+		return fmt.Errorf("failed to read payload: %w", err)
+	}
+	if !json.Valid(payload) {
+		return fmt.Errorf("payload of %s is not valid JSON", *file)
+	}
+
+	if *url != "" {
+		return publishToServer(ctx, *url, *token, *topic, payload, out)
+	}
+
+	// The in-memory stores start empty, so handlers of events that refer to an
+	// unknown reservation report it like they would on a server.
+	demo, err := newBookingDemo(ctx, out)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "== Simulating %s\n", *topic)
+	if err := demo.dispatcher.Publish(ctx, messaging.NewMessage(*topic, payload)); err != nil {
+		return fmt.Errorf("failed to publish %s: %w", *topic, err)
+	}
+	return nil
+}
+
+// publishToServer posts the event to the event simulator of the server's admin API.
+func publishToServer(ctx context.Context, baseURL, token, topic string, payload []byte, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(baseURL, "/")+"/api/admin/events/"+topic, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish %s: %w", topic, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("failed to publish %s: %s: %s", topic, resp.Status, strings.TrimSpace(string(body)))
+	}
+	fmt.Fprintf(out, "published %s to %s\n", topic, baseURL)
+	return nil
+}
@@ -0,0 +1,47 @@
+// Command fingerprint writes the asset manifest that maps the CSS and JS files
+// below <dir>/static to names containing a hash of their content. The server
+// resolves the assetPath helpers of its templates with the manifest and serves
+// the fingerprinted names with immutable cache headers.
+//
+// Usage (run via go generate ./cmd/server after changing an asset):
+//
+//	go run ./cmd/fingerprint -dir cmd/server/assets
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+func main() {
+	dir := flag.String("dir", "assets", "directory containing the static directory")
+	flag.Parse()
+
+	count, err := run(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fingerprint failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("fingerprinted %d assets\n", count)
+}
+
+// run writes the asset manifest of the directory and returns the number of assets.
+func run(dir string) (int, error) {
+	manifest, err := inbound.BuildAssetManifest(os.DirFS(dir))
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode asset manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, inbound.AssetManifestFile), append(data, '\n'), 0o644); err != nil {
+		return 0, fmt.Errorf("failed to write asset manifest: %w", err)
+	}
+	return len(manifest), nil
+}
@@ -0,0 +1,58 @@
+// Command reencrypt rewrites all reservations whose guest PII is stored in
+// plaintext or encrypted with a rotated key, using the active key from
+// PII_ENCRYPTION_KEYS. Run it after adding a new key in front of the old ones.
+//
+// Usage:
+//
+//	PII_ENCRYPTION_KEYS="k2:<base64>,k1:<base64>" go run ./cmd/reencrypt
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/env"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func main() {
+	count, err := run(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reencrypt failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("re-encrypted %d reservations\n", count)
+}
+
+// run re-encrypts the reservation database configured via RESERVATION_DB_* variables.
+func run(ctx context.Context) (int, error) {
+	keys := env.Get("PII_ENCRYPTION_KEYS", "")
+	if keys == "" {
+		return 0, errors.New("PII_ENCRYPTION_KEYS must be set")
+	}
+	encryptor, err := outbound.NewAESGCMEncryptorFromSpec(keys)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create encryptor: %w", err)
+	}
+
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		env.Get("RESERVATION_DB_HOST", "localhost"),
+		env.Get("RESERVATION_DB_PORT", "5432"),
+		env.Get("RESERVATION_DB_USER", "reservation"),
+		env.Get("RESERVATION_DB_PASSWORD", "reservation_secret"),
+		env.Get("RESERVATION_DB_NAME", "reservation_db"),
+		env.Get("RESERVATION_DB_SSLMODE", "disable"),
+	)
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to reservation database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := outbound.NewEncryptedReservationRepository(outbound.NewPostgresReservationRepository(db), encryptor)
+	return repo.ReEncrypt(ctx)
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Adapter errors.
+var (
+	ErrInvalidKind     = errors.New("adapter kind must be one of memory, file, postgres")
+	ErrPortNotFound    = errors.New("port not found")
+	ErrAmbiguousPort   = errors.New("port name is ambiguous, qualify it as <context>.<Port>")
+	ErrUnsupportedPort = errors.New("port must be an interface or a resource.Access type")
+	ErrAdapterExists   = errors.New("adapter already exists")
+)
+
+// adapterKinds maps adapter kinds to their type name prefix.
+var adapterKinds = map[string]string{
+	"memory":   "Memory",
+	"file":     "File",
+	"postgres": "Postgres",
+}
+
+// accessConstructors maps adapter kinds to the cloud-native-utils access they embed.
+var accessConstructors = map[string]struct{ Type, Param, Call string }{
+	"memory":   {Type: "InMemoryAccess", Param: "", Call: "resource.NewInMemoryAccess[%s, %s]()"},
+	"file":     {Type: "JsonFileAccess", Param: "path string", Call: "resource.NewJsonFileAccess[%s, %s](path)"},
+	"postgres": {Type: "PostgresAccess", Param: "db *sql.DB", Call: "resource.NewPostgresAccess[%s, %s](db)"},
+}
+
+// port describes a port interface found in internal/domain/<context>/ports.go.
+type port struct {
+	Package   string
+	Name      string
+	AccessKey string // Qualified key type if the port is backed by resource.Access
+	AccessVal string // Qualified value type if the port is backed by resource.Access
+	Methods   []method
+	imports   map[string]string
+}
+
+// method is a port method rendered as Go source.
+type method struct {
+	Name    string
+	Params  string
+	Results string
+	Body    string
+}
+
+// adapterData holds the template values for a new adapter.
+type adapterData struct {
+	Module       string
+	Port         port
+	Adapter      string
+	Receiver     string
+	Kind         string
+	Imports      [][]string
+	TestImports  [][]string
+	Embed        string
+	CtorParam    string
+	CtorArg      string
+	CtorTestArg  string
+	CtorBody     string
+	RoundTripKey string
+}
+
+// generateAdapter renders an outbound adapter implementing the given port below root.
+func generateAdapter(root, portName, kind string) ([]string, error) {
+	prefix, ok := adapterKinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidKind, kind)
+	}
+
+	p, err := findPort(root, portName)
+	if err != nil {
+		return nil, err
+	}
+
+	data := adapterData{
+		Module:   module,
+		Port:     *p,
+		Adapter:  prefix + p.Name,
+		Receiver: strings.ToLower(prefix[:1]),
+		Kind:     kind,
+	}
+	data.Imports, data.TestImports = adapterImports(p, kind)
+
+	if p.AccessKey != "" {
+		ctor := accessConstructors[kind]
+		data.Embed = fmt.Sprintf("*resource.%s[%s, %s]", ctor.Type, p.AccessKey, p.AccessVal)
+		data.CtorParam = ctor.Param
+		data.CtorBody = fmt.Sprintf("%s: %s,", ctor.Type, fmt.Sprintf(ctor.Call, p.AccessKey, p.AccessVal))
+		data.RoundTripKey = p.AccessKey
+		switch kind {
+		case "file":
+			data.CtorTestArg = `filepath.Join(t.TempDir(), "` + strings.ToLower(p.Name) + `.json")`
+		case "postgres":
+			data.CtorTestArg = "nil"
+		}
+	}
+
+	base := snakeCase(kind + p.Name)
+	outputs := map[string]string{
+		"adapter.go.tmpl":      filepath.Join(root, "internal", "adapters", "outbound", base+".go"),
+		"adapter_test.go.tmpl": filepath.Join(root, "internal", "adapters", "outbound", base+"_test.go"),
+	}
+	for _, path := range outputs {
+		if _, err := os.Stat(path); err == nil {
+			return nil, fmt.Errorf("%w: %s", ErrAdapterExists, path)
+		}
+	}
+
+	var created []string
+	for _, tmplName := range []string{"adapter.go.tmpl", "adapter_test.go.tmpl"} {
+		path := outputs[tmplName]
+		if err := renderGoFile(tmplName, path, data); err != nil {
+			return nil, err
+		}
+		created = append(created, path)
+	}
+	return created, nil
+}
+
+// findPort locates a port by name ("Port" or "context.Port") in the domain ports files.
+func findPort(root, portName string) (*port, error) {
+	wantPkg, wantName, qualified := strings.Cut(portName, ".")
+	if !qualified {
+		wantPkg, wantName = "", portName
+	}
+
+	files, err := filepath.Glob(filepath.Join(root, "internal", "domain", "*", "ports.go"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ports: %w", err)
+	}
+
+	var found []*port
+	for _, file := range files {
+		pkg := filepath.Base(filepath.Dir(file))
+		if wantPkg != "" && pkg != wantPkg {
+			continue
+		}
+		p, err := parsePort(file, pkg, wantName)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			found = append(found, p)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrPortNotFound, portName)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrAmbiguousPort, portName)
+	}
+}
+
+// parsePort parses a ports file and returns the named port, or nil if it is not declared there.
+func parsePort(file, pkg, name string) (*port, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	imports := map[string]string{}
+	for _, imp := range f.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		imports[filepath.Base(path)] = path
+	}
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Name.Name != name {
+				continue
+			}
+			p := &port{Package: pkg, Name: name, imports: imports}
+			if key, val, ok := accessTypes(ts.Type); ok {
+				p.AccessKey, p.AccessVal = render(fset, qualify(key, pkg)), render(fset, qualify(val, pkg))
+				return p, nil
+			}
+			iface, ok := ts.Type.(*ast.InterfaceType)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s.%s", ErrUnsupportedPort, pkg, name)
+			}
+			for _, field := range iface.Methods.List {
+				if key, val, ok := accessTypes(field.Type); ok && len(field.Names) == 0 {
+					p.AccessKey, p.AccessVal = render(fset, qualify(key, pkg)), render(fset, qualify(val, pkg))
+					continue
+				}
+				fn, ok := field.Type.(*ast.FuncType)
+				if !ok || len(field.Names) == 0 {
+					return nil, fmt.Errorf("%w: %s.%s embeds an unsupported interface", ErrUnsupportedPort, pkg, name)
+				}
+				p.Methods = append(p.Methods, renderMethod(fset, field.Names[0].Name, fn, pkg))
+			}
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+// accessTypes reports whether expr is resource.Access[K, V] and returns K and V.
+func accessTypes(expr ast.Expr) (ast.Expr, ast.Expr, bool) {
+	idx, ok := expr.(*ast.IndexListExpr)
+	if !ok || len(idx.Indices) != 2 {
+		return nil, nil, false
+	}
+	sel, ok := idx.X.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Access" {
+		return nil, nil, false
+	}
+	if x, ok := sel.X.(*ast.Ident); !ok || x.Name != "resource" {
+		return nil, nil, false
+	}
+	return idx.Indices[0], idx.Indices[1], true
+}
+
+// renderMethod renders a port method with named results and a not-implemented body.
+func renderMethod(fset *token.FileSet, name string, fn *ast.FuncType, pkg string) method {
+	var params []string
+	for i, field := range fieldList(fn.Params) {
+		name := fieldNames(field, fmt.Sprintf("arg%d", i))
+		if name == pkg {
+			// Avoid shadowing the domain package, e.g. "payment *payment.Payment".
+			name = name[:3]
+		}
+		params = append(params, name+" "+render(fset, qualify(field.Type, pkg)))
+	}
+
+	var results []string
+	returnsError := false
+	for i, field := range fieldList(fn.Results) {
+		typ := render(fset, qualify(field.Type, pkg))
+		fallback := fmt.Sprintf("r%d", i)
+		if typ == "error" {
+			fallback, returnsError = "err", true
+		}
+		results = append(results, fieldNames(field, fallback)+" "+typ)
+	}
+
+	body := "return"
+	if returnsError {
+		body = fmt.Sprintf("err = errors.New(%q)\n\treturn", strings.ToLower(name[:1])+name[1:]+" not implemented")
+	}
+
+	m := method{Name: name, Params: strings.Join(params, ", "), Body: body}
+	if len(results) > 0 {
+		m.Results = "(" + strings.Join(results, ", ") + ")"
+	}
+	return m
+}
+
+// fieldList flattens a field list so that every field has at most one name.
+func fieldList(list *ast.FieldList) []*ast.Field {
+	if list == nil {
+		return nil
+	}
+	var fields []*ast.Field
+	for _, field := range list.List {
+		if len(field.Names) <= 1 {
+			fields = append(fields, field)
+			continue
+		}
+		for _, n := range field.Names {
+			fields = append(fields, &ast.Field{Names: []*ast.Ident{n}, Type: field.Type})
+		}
+	}
+	return fields
+}
+
+// fieldNames returns the declared field name or the fallback for unnamed fields.
+func fieldNames(field *ast.Field, fallback string) string {
+	if len(field.Names) == 1 && field.Names[0].Name != "_" {
+		return field.Names[0].Name
+	}
+	return fallback
+}
+
+// qualify prefixes identifiers declared in the domain package with the package name.
+func qualify(expr ast.Expr, pkg string) ast.Expr {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.SelectorExpr:
+			return false
+		case *ast.Field:
+			// Only qualify field types, never field names.
+			qualify(node.Type, pkg)
+			return false
+		case *ast.Ident:
+			if ast.IsExported(node.Name) {
+				node.Name = pkg + "." + node.Name
+			}
+		}
+		return true
+	})
+	return expr
+}
+
+// render prints an AST expression as Go source.
+func render(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, expr)
+	return buf.String()
+}
+
+// adapterImports collects the import paths needed by the adapter and its test.
+func adapterImports(p *port, kind string) ([][]string, [][]string) {
+	domain := module + "/internal/domain/" + p.Package
+	var imports []string
+	tests := []string{"testing", "github.com/andygeiss/cloud-native-utils/assert", module + "/internal/adapters/outbound", domain}
+
+	source := p.AccessKey + " " + p.AccessVal
+	for _, m := range p.Methods {
+		source += " " + m.Params + " " + m.Results
+		if strings.Contains(m.Body, "errors.New") && !slices.Contains(imports, "errors") {
+			imports = append(imports, "errors")
+		}
+	}
+	if strings.Contains(source, p.Package+".") {
+		imports = append(imports, domain)
+	}
+	for name, path := range p.imports {
+		if name != p.Package && strings.Contains(source, name+".") {
+			imports = append(imports, path)
+		}
+	}
+
+	if p.AccessKey != "" {
+		imports = append(imports, "github.com/andygeiss/cloud-native-utils/resource")
+		if kind == "postgres" {
+			imports = append(imports, "database/sql")
+		} else {
+			tests = append(tests, "context")
+		}
+		if kind == "file" {
+			tests = append(tests, "path/filepath")
+		}
+	}
+
+	return groupImports(imports), groupImports(tests)
+}
+
+// groupImports sorts imports into a standard library group and a third-party group.
+func groupImports(paths []string) [][]string {
+	var std, other []string
+	for _, path := range paths {
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(other)
+
+	var groups [][]string
+	for _, group := range [][]string{slices.Compact(std), slices.Compact(other)} {
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// renderGoFile executes a template, formats the result and writes it to path.
+func renderGoFile(tmplName, path string, data any) error {
+	tmpl, err := parseTemplates()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, tmplName, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", tmplName, err)
+	}
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", tmplName, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// snakeCase converts a CamelCase name to snake_case.
+func snakeCase(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,180 @@
+// Command scaffold generates the skeleton of a new bounded context following
+// the conventions of the reservation and payment contexts.
+//
+// Usage:
+//
+//	go run ./cmd/scaffold new-context [-root dir] <name>
+//	go run ./cmd/scaffold new-adapter [-root dir] -port=<Port> -kind=memory|file|postgres
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// module is the Go module path used in generated test imports.
+const module = "github.com/andygeiss/hotel-booking"
+
+// contextNamePattern restricts names to valid, lowercase Go package names.
+var contextNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// Scaffold errors.
+var (
+	ErrInvalidName   = errors.New("context name must be lowercase alphanumeric and start with a letter")
+	ErrContextExists = errors.New("context already exists")
+)
+
+// contextData holds the template values for a new bounded context.
+type contextData struct {
+	Module   string
+	Package  string
+	Receiver string
+	Type     string
+}
+
+// contextFiles maps template names to their output paths relative to the repository root.
+// %s is replaced by the context name.
+var contextFiles = map[string]string{
+	"aggregate.go.tmpl":      "internal/domain/%s/aggregate.go",
+	"aggregate_test.go.tmpl": "internal/domain/%s/aggregate_test.go",
+	"entities.go.tmpl":       "internal/domain/%s/entities.go",
+	"events.go.tmpl":         "internal/domain/%s/events.go",
+	"ports.go.tmpl":          "internal/domain/%s/ports.go",
+	"service.go.tmpl":        "internal/domain/%s/service.go",
+	"service_test.go.tmpl":   "internal/domain/%s/service_test.go",
+	"tools.go.tmpl":          "internal/domain/%s/tools.go",
+	"init.sql.tmpl":          "migrations/%s/init.sql",
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "scaffold failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches the scaffold subcommands.
+func run(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: scaffold new-context|new-adapter [flags]")
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	root := fs.String("root", ".", "repository root directory")
+
+	var files []string
+	var next string
+	switch args[0] {
+	case "new-context":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return errors.New("usage: scaffold new-context [-root dir] <name>")
+		}
+		name := fs.Arg(0)
+		created, err := generateContext(*root, name)
+		if err != nil {
+			return err
+		}
+		files = created
+		next = fmt.Sprintf("wire %s.NewService in cmd/server/main.go and register %s.RegisterTools in buildMCPServer.", name, name)
+	case "new-adapter":
+		portName := fs.String("port", "", "port to implement, e.g. ReservationRepository or payment.PaymentGateway")
+		kind := fs.String("kind", "memory", "adapter kind: memory, file or postgres")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *portName == "" {
+			return errors.New("usage: scaffold new-adapter [-root dir] -port=<Port> -kind=memory|file|postgres")
+		}
+		created, err := generateAdapter(*root, *portName, *kind)
+		if err != nil {
+			return err
+		}
+		files = created
+		next = "implement the generated method stubs and wire the adapter in cmd/server/main.go."
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	for _, file := range files {
+		fmt.Println("created", file)
+	}
+	fmt.Println("\nNext steps:", next)
+	return nil
+}
+
+// generateContext renders all context templates below root and returns the created files.
+func generateContext(root, name string) ([]string, error) {
+	if !contextNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+
+	domainDir := filepath.Join(root, "internal", "domain", name)
+	if _, err := os.Stat(domainDir); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrContextExists, domainDir)
+	}
+
+	data := contextData{
+		Module:   module,
+		Package:  name,
+		Receiver: name[:1],
+		Type:     strings.ToUpper(name[:1]) + name[1:],
+	}
+
+	tmpl, err := parseTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	var created []string
+	for _, tmplName := range slices.Sorted(maps.Keys(contextFiles)) {
+		pathPattern := contextFiles[tmplName]
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, tmplName, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", tmplName, err)
+		}
+
+		content := buf.Bytes()
+		if strings.HasSuffix(pathPattern, ".go") {
+			if content, err = format.Source(content); err != nil {
+				return nil, fmt.Errorf("failed to format %s: %w", tmplName, err)
+			}
+		}
+
+		path := filepath.Join(root, fmt.Sprintf(pathPattern, name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		created = append(created, path)
+	}
+
+	return created, nil
+}
+
+// parseTemplates parses all embedded templates.
+func parseTemplates() (*template.Template, error) {
+	tmpl, err := template.ParseFS(templates, "templates/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+)
+
+func Test_GenerateContext_Should_Create_All_Files(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+
+	// Act
+	files, err := generateContext(root, "housekeeping")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "all files must be created", len(files), len(contextFiles))
+	for _, file := range files {
+		_, statErr := os.Stat(file)
+		assert.That(t, "file must exist: "+file, statErr == nil, true)
+	}
+}
+
+func Test_GenerateContext_Should_Apply_Naming_Conventions(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+
+	// Act
+	_, err := generateContext(root, "housekeeping")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	data, _ := os.ReadFile(filepath.Join(root, "internal", "domain", "housekeeping", "events.go"))
+	assert.That(t, "topic must be prefixed", strings.Contains(string(data), `"housekeeping.created"`), true)
+	data, _ = os.ReadFile(filepath.Join(root, "internal", "domain", "housekeeping", "aggregate.go"))
+	assert.That(t, "aggregate type must be exported", strings.Contains(string(data), "type Housekeeping struct"), true)
+}
+
+func Test_GenerateContext_With_Invalid_Name_Should_Return_Error(t *testing.T) {
+	// Act
+	_, err := generateContext(t.TempDir(), "House-Keeping")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidName", errors.Is(err, ErrInvalidName), true)
+}
+
+func Test_GenerateContext_With_Existing_Context_Should_Return_Error(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	_, _ = generateContext(root, "housekeeping")
+
+	// Act
+	_, err := generateContext(root, "housekeeping")
+
+	// Assert
+	assert.That(t, "error must be ErrContextExists", errors.Is(err, ErrContextExists), true)
+}
+
+// ============================================================================
+// new-adapter Tests
+// ============================================================================
+
+const testPorts = `package housekeeping
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+type TaskRepository resource.Access[TaskID, Task]
+
+type Cleaner interface {
+	Clean(ctx context.Context, room string) error
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, topic string) error
+}
+`
+
+func writeTestPorts(t *testing.T, root, pkg string) {
+	t.Helper()
+	dir := filepath.Join(root, "internal", "domain", pkg)
+	_ = os.MkdirAll(dir, 0755)
+	_ = os.WriteFile(filepath.Join(dir, "ports.go"), []byte(strings.ReplaceAll(testPorts, "housekeeping", pkg)), 0644)
+}
+
+func Test_GenerateAdapter_With_Access_Port_Should_Embed_Access(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	writeTestPorts(t, root, "housekeeping")
+
+	// Act
+	files, err := generateAdapter(root, "TaskRepository", "postgres")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "adapter and test must be created", len(files), 2)
+	data, _ := os.ReadFile(filepath.Join(root, "internal", "adapters", "outbound", "postgres_task_repository.go"))
+	assert.That(t, "adapter must embed PostgresAccess", strings.Contains(string(data), "*resource.PostgresAccess[housekeeping.TaskID, housekeeping.Task]"), true)
+}
+
+func Test_GenerateAdapter_With_Interface_Port_Should_Stub_Methods(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	writeTestPorts(t, root, "housekeeping")
+
+	// Act
+	_, err := generateAdapter(root, "Cleaner", "memory")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	data, _ := os.ReadFile(filepath.Join(root, "internal", "adapters", "outbound", "memory_cleaner.go"))
+	assert.That(t, "method must be stubbed", strings.Contains(string(data), "func (m *MemoryCleaner) Clean(ctx context.Context, room string) (err error)"), true)
+}
+
+func Test_GenerateAdapter_With_Invalid_Kind_Should_Return_Error(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	writeTestPorts(t, root, "housekeeping")
+
+	// Act
+	_, err := generateAdapter(root, "TaskRepository", "redis")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidKind", errors.Is(err, ErrInvalidKind), true)
+}
+
+func Test_GenerateAdapter_With_Unknown_Port_Should_Return_Error(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	writeTestPorts(t, root, "housekeeping")
+
+	// Act
+	_, err := generateAdapter(root, "LaundryService", "memory")
+
+	// Assert
+	assert.That(t, "error must be ErrPortNotFound", errors.Is(err, ErrPortNotFound), true)
+}
+
+func Test_GenerateAdapter_With_Ambiguous_Port_Should_Return_Error(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	writeTestPorts(t, root, "housekeeping")
+	writeTestPorts(t, root, "laundry")
+
+	// Act
+	_, err := generateAdapter(root, "EventPublisher", "memory")
+
+	// Assert
+	assert.That(t, "error must be ErrAmbiguousPort", errors.Is(err, ErrAmbiguousPort), true)
+}
+
+func Test_GenerateAdapter_With_Existing_Adapter_Should_Return_Error(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	writeTestPorts(t, root, "housekeeping")
+	_, _ = generateAdapter(root, "housekeeping.Cleaner", "file")
+
+	// Act
+	_, err := generateAdapter(root, "housekeeping.Cleaner", "file")
+
+	// Assert
+	assert.That(t, "error must be ErrAdapterExists", errors.Is(err, ErrAdapterExists), true)
+}
@@ -0,0 +1,19 @@
+// Live updates via server-sent events, without inline JavaScript in the templates.
+// An element with data-sse-src subscribes to the event stream at that URL and
+// replaces its content with the data of every event named in data-sse-swap
+// (default "message"). A "done" event closes the stream.
+document.addEventListener('DOMContentLoaded', () => {
+  document.querySelectorAll('[data-sse-src]').forEach((el) => {
+    const source = new EventSource(el.dataset.sseSrc);
+    source.addEventListener(el.dataset.sseSwap || 'message', (event) => {
+      el.innerHTML = event.data;
+      if (window.htmx) {
+        window.htmx.process(el);
+      }
+    });
+    source.addEventListener('done', () => {
+      source.close();
+      el.removeAttribute('data-sse-src');
+    });
+  });
+});
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/admin"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Booking Saga Scenario Tests
+// ============================================================================
+// The scenarios wire the event-driven booking saga like main does, with the same
+// dispatcher decorators, adapters and handlers, but with in-memory repositories and
+// an in-memory event bus instead of Postgres and Kafka. The same saga runs against
+// Postgres and Kafka containers in the outbound integration tests.
+
+// memoryBus is an in-memory event bus that delivers every message asynchronously,
+// like a broker does, so handlers may publish events of the reservation they handle.
+type memoryBus struct {
+	mu        sync.Mutex
+	handlers  map[string][]service.Function[messaging.Message, messaging.MessageState]
+	published []messaging.Message
+	delivered int
+	pending   sync.WaitGroup
+}
+
+func newMemoryBus() *memoryBus {
+	return &memoryBus{handlers: make(map[string][]service.Function[messaging.Message, messaging.MessageState])}
+}
+
+func (b *memoryBus) Publish(ctx context.Context, message messaging.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.published = append(b.published, message)
+	for _, fn := range b.handlers[message.Topic] {
+		b.pending.Add(1)
+		go func() {
+			defer b.pending.Done()
+			_, _ = fn(context.WithoutCancel(ctx), message)
+			b.mu.Lock()
+			b.delivered++
+			b.mu.Unlock()
+		}()
+	}
+	return nil
+}
+
+func (b *memoryBus) Subscribe(_ context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[topic] = append(b.handlers[topic], fn)
+	return nil
+}
+
+// settle waits until no message is in flight anymore and returns the published topics
+// in order and the number of deliveries expected for them.
+func (b *memoryBus) settle(t *testing.T) (topics []string, expected, delivered int) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		b.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("saga must settle")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, msg := range b.published {
+		topics = append(topics, msg.Topic)
+		expected += len(b.handlers[msg.Topic])
+	}
+	return topics, expected, b.delivered
+}
+
+// recordingNotifications records the notifications sent by the saga.
+type recordingNotifications struct {
+	orchestration.NotificationService
+	mu   sync.Mutex
+	sent []string
+}
+
+func (n *recordingNotifications) record(kind string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, kind)
+}
+
+func (n *recordingNotifications) SendReservationConfirmation(ctx context.Context, r *reservation.Reservation) error {
+	n.record("confirmation")
+	return n.NotificationService.SendReservationConfirmation(ctx, r)
+}
+
+func (n *recordingNotifications) SendCancellationNotice(ctx context.Context, r *reservation.Reservation, reason string) error {
+	n.record("cancellation")
+	return n.NotificationService.SendCancellationNotice(ctx, r, reason)
+}
+
+func (n *recordingNotifications) SendPaymentReceipt(ctx context.Context, p *payment.Payment, attachments ...orchestration.Attachment) error {
+	n.record("receipt")
+	return n.NotificationService.SendPaymentReceipt(ctx, p, attachments...)
+}
+
+// bookingScenario holds the services of the wired booking saga.
+type bookingScenario struct {
+	bus           *memoryBus
+	gateway       *outbound.MockPaymentGateway
+	notifications *recordingNotifications
+	reservations  *reservation.Service
+	payments      *payment.Service
+	bookings      *orchestration.BookingService
+	sagas         *orchestration.SagaTracker
+	events        *admin.EventLog
+}
+
+// newBookingScenario wires the event-driven booking saga as main does.
+func newBookingScenario(t *testing.T) *bookingScenario {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	logger := slog.New(slog.DiscardHandler)
+	codec, err := outbound.NewCodec("json")
+	assert.That(t, "codec error must be nil", err == nil, true)
+	retryPolicy := outbound.NewRetryPolicy().WithMaxAttempts(3).WithInitialDelay(time.Millisecond)
+
+	bus := newMemoryBus()
+	keyed := inbound.NewKeyedDispatcher(inbound.NewDrainingDispatcher(bus), outbound.ReservationKey, 4)
+	t.Cleanup(keyed.Close)
+	publisher := func() *outbound.RetryEventPublisher {
+		return outbound.NewRetryEventPublisher(outbound.NewEventPublisher(keyed).WithCodec(codec), retryPolicy)
+	}
+
+	reservationRepo := outbound.NewReservationRepository(resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]())
+	guestProfiles := outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]())
+	reservationService := reservation.NewService(reservationRepo, outbound.NewRepositoryAvailabilityChecker(reservationRepo), publisher()).
+		WithGuestProfiles(guestProfiles)
+
+	gateway := outbound.NewMockPaymentGateway()
+	paymentService := payment.NewService(
+		outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]()),
+		outbound.NewRetryPaymentGateway(gateway, retryPolicy),
+		publisher(),
+	).WithProcessedCommands(resource.NewInMemoryAccess[payment.CommandID, payment.ProcessedCommand]())
+
+	notifications := &recordingNotifications{NotificationService: outbound.NewMockNotificationService(logger).WithGuestProfiles(guestProfiles)}
+	bookingService := orchestration.NewBookingService(reservationService, paymentService, notifications).
+		WithSagaBudget(5 * time.Second).
+		WithCompensationQueue(resource.NewInMemoryAccess[orchestration.CompensationID, orchestration.FailedCompensation]()).
+		WithEventPublisher(publisher())
+
+	handlers := orchestration.NewEventHandlers(bookingService, reservationService, paymentService)
+	assert.That(t, "event handlers must register", handlers.RegisterHandlers(ctx, inbound.NewConsumerGroup(keyed, "orchestration")) == nil, true)
+	sagas := orchestration.NewSagaTracker(resource.NewInMemoryAccess[shared.ReservationID, orchestration.SagaState]())
+	assert.That(t, "saga tracker must register", sagas.RegisterHandlers(ctx, keyed) == nil, true)
+	events := admin.NewEventLog(100)
+	assert.That(t, "event log must register", events.RegisterHandlers(ctx, keyed) == nil, true)
+
+	return &bookingScenario{
+		bus:           bus,
+		gateway:       gateway,
+		notifications: notifications,
+		reservations:  reservationService,
+		payments:      paymentService,
+		bookings:      bookingService,
+		sagas:         sagas,
+		events:        events,
+	}
+}
+
+// initiateBooking starts the saga of a three-night stay in two days.
+func (s *bookingScenario) initiateBooking(t *testing.T, id shared.ReservationID) error {
+	t.Helper()
+	checkIn := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
+	_, err := s.bookings.InitiateBooking(context.Background(), id, "guest-001", "room-101",
+		reservation.NewDateRange(checkIn, checkIn.Add(72*time.Hour)),
+		shared.NewMoney(30000, "EUR"),
+		[]reservation.GuestInfo{{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+15551234567"}},
+	)
+	return err
+}
+
+func Test_BookingSaga_Should_Confirm_Reservation_And_Capture_Payment(t *testing.T) {
+	// Arrange
+	scenario := newBookingScenario(t)
+	ctx := context.Background()
+
+	// Act
+	err := scenario.initiateBooking(t, "res-001")
+	topics, expected, delivered := scenario.bus.settle(t)
+
+	// Assert
+	assert.That(t, "initiate error must be nil", err == nil, true)
+	res, _ := scenario.reservations.GetReservation(ctx, "res-001")
+	assert.That(t, "reservation must be confirmed", res.Status, reservation.StatusConfirmed)
+	pay, _ := scenario.payments.GetPaymentByReservation(ctx, "res-001")
+	assert.That(t, "payment must be captured", pay.Status, payment.StatusCaptured)
+	assert.That(t, "guest must be notified", scenario.notifications.sent, []string{"confirmation", "receipt"})
+	assert.That(t, "events must be published in saga order", topics, []string{
+		reservation.EventTopicCreated, payment.EventTopicAuthorized, payment.EventTopicCaptured, reservation.EventTopicConfirmed,
+	})
+	assert.That(t, "every event must be delivered to every subscriber", delivered, expected)
+	assert.That(t, "event log must record every event", len(scenario.events.Recent()), len(topics))
+	state, _ := scenario.sagas.GetSagaState(ctx, "res-001")
+	assert.That(t, "saga must be done", state.Done(), true)
+	assert.That(t, "saga must not fail", state.Failed(), false)
+	compensations, _ := scenario.bookings.ListFailedCompensations(ctx)
+	assert.That(t, "no compensation must be pending", len(compensations), 0)
+}
+
+func Test_BookingSaga_With_Declined_Payment_Should_Cancel_Reservation(t *testing.T) {
+	// Arrange
+	scenario := newBookingScenario(t)
+	scenario.gateway.ShouldFail = true
+	ctx := context.Background()
+
+	// Act
+	err := scenario.initiateBooking(t, "res-001")
+	topics, expected, delivered := scenario.bus.settle(t)
+
+	// Assert
+	assert.That(t, "initiate error must be nil", err == nil, true)
+	res, _ := scenario.reservations.GetReservation(ctx, "res-001")
+	assert.That(t, "reservation must be cancelled", res.Status, reservation.StatusCancelled)
+	assert.That(t, "guest must not be sent a confirmation", len(scenario.notifications.sent), 0)
+	assert.That(t, "events must be published in saga order", topics, []string{
+		reservation.EventTopicCreated, payment.EventTopicFailed, reservation.EventTopicCancelled,
+	})
+	assert.That(t, "every event must be delivered to every subscriber", delivered, expected)
+	state, _ := scenario.sagas.GetSagaState(ctx, "res-001")
+	assert.That(t, "saga must be done", state.Done(), true)
+	compensations, _ := scenario.bookings.ListFailedCompensations(ctx)
+	assert.That(t, "no compensation must be pending", len(compensations), 0)
+}
+
+func Test_BookingSaga_With_Redelivered_Event_Should_Charge_Once(t *testing.T) {
+	// Arrange
+	scenario := newBookingScenario(t)
+	ctx := context.Background()
+	_ = scenario.initiateBooking(t, "res-001")
+	before, _, _ := scenario.bus.settle(t)
+	created := scenario.bus.published[0]
+
+	// Act
+	err := scenario.bus.Publish(ctx, created)
+	after, expected, delivered := scenario.bus.settle(t)
+
+	// Assert
+	assert.That(t, "redelivery error must be nil", err == nil, true)
+	payments, _ := scenario.payments.ListPaymentsByReservation(ctx, "res-001")
+	assert.That(t, "guest must be charged once", len(payments), 1)
+	assert.That(t, "payment must stay captured", payments[0].Status, payment.StatusCaptured)
+	assert.That(t, "every event must be delivered to every subscriber", delivered, expected)
+	assert.That(t, "redelivery must not publish events", after, append(before, reservation.EventTopicCreated))
+	assert.That(t, "guest must be notified once", scenario.notifications.sent, []string{"confirmation", "receipt"})
+	res, _ := scenario.reservations.GetReservation(ctx, "res-001")
+	assert.That(t, "reservation must stay confirmed", res.Status, reservation.StatusConfirmed)
+}
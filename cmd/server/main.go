@@ -2,28 +2,54 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"embed"
 	"fmt"
+	"log/slog"
+	"math"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/andygeiss/cloud-native-utils/env"
 	"github.com/andygeiss/cloud-native-utils/logging"
 	"github.com/andygeiss/cloud-native-utils/mcp"
 	"github.com/andygeiss/cloud-native-utils/messaging"
-	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/security"
 	"github.com/andygeiss/cloud-native-utils/service"
 	"github.com/andygeiss/cloud-native-utils/web"
 	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/admin"
+	"github.com/andygeiss/hotel-booking/internal/domain/calendar"
+	"github.com/andygeiss/hotel-booking/internal/domain/channel"
+	"github.com/andygeiss/hotel-booking/internal/domain/housekeeping"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoicing"
 	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/privacy"
+	"github.com/andygeiss/hotel-booking/internal/domain/reconciliation"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/review"
+	"github.com/andygeiss/hotel-booking/internal/domain/search"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 	"github.com/coreos/go-oidc/v3/oidc"
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/acme/autocert"
 )
 
+// The asset manifest maps the CSS and JS files to their fingerprinted names.
+// Run go generate ./cmd/server after changing an asset.
+//go:generate go run ../fingerprint -dir assets
+
 //go:embed assets
 var efs embed.FS
 
@@ -32,6 +58,8 @@ func buildMCPServer(
 	reservationService *reservation.Service,
 	availabilityChecker reservation.AvailabilityChecker,
 	paymentService *payment.Service,
+	bookingService *orchestration.BookingService,
+	confirmationSecret []byte,
 ) *mcp.Server {
 	server := mcp.NewServer(
 		env.Get("APP_SHORTNAME", "mcp-server"),
@@ -41,10 +69,402 @@ func buildMCPServer(
 	// Register tools from each bounded context.
 	reservation.RegisterTools(server, reservationService, availabilityChecker)
 	payment.RegisterTools(server, paymentService)
+	orchestration.RegisterTools(server, bookingService, inbound.RoomRates(), confirmationSecret)
 
 	return server
 }
 
+// buildIDGenerator selects the generator for new aggregate IDs.
+// Supported values are "uuidv7" (default) and "ulid".
+func buildIDGenerator(kind string) shared.IDGenerator {
+	if kind == "ulid" {
+		return shared.NewULIDGenerator()
+	}
+	return shared.NewUUIDv7Generator()
+}
+
+// buildFeatureFlags returns the feature flag provider for the given kind.
+// Flags are read from FEATURE_* environment variables unless flagd is selected,
+// whose evaluations are cached for cacheTTL.
+func buildFeatureFlags(kind, flagdURL string, cacheTTL time.Duration) shared.FeatureFlags {
+	if kind == "flagd" {
+		return outbound.NewFlagdFeatureFlags(flagdURL, &http.Client{Timeout: time.Second}).WithCacheTTL(cacheTTL)
+	}
+	return outbound.NewEnvFeatureFlags()
+}
+
+// buildSecretsProvider returns the secrets provider for the given kind.
+// Secrets are read from environment variables unless file or vault is selected.
+func buildSecretsProvider(kind string) outbound.SecretsProvider {
+	switch kind {
+	case "file":
+		return outbound.NewFileSecretsProvider(env.Get("SECRETS_DIR", "/run/secrets"))
+	case "vault":
+		return outbound.NewVaultSecretsProvider(
+			env.Get("VAULT_ADDR", "http://localhost:8200"),
+			env.Get("VAULT_TOKEN", ""),
+			env.Get("VAULT_MOUNT", "secret"),
+			env.Get("VAULT_SECRET_PATH", "hotel-booking"),
+			&http.Client{Timeout: 5 * time.Second},
+		)
+	default:
+		return outbound.NewEnvSecretsProvider()
+	}
+}
+
+// buildLeaderElector returns the leader elector for the given kind, or nil if every
+// replica runs the singleton jobs, which is fine for a single replica.
+// With kubernetes, the replicas campaign for a Lease named leaseName in their namespace.
+func buildLeaderElector(kind, leaseName, identity string, leaseDuration, retryPeriod time.Duration, logger *slog.Logger) (*outbound.LeaderElector, error) {
+	if kind != "kubernetes" {
+		return nil, nil
+	}
+	lock, err := outbound.NewInClusterLeaseLock(leaseName, retryPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lease lock: %w", err)
+	}
+	return outbound.NewLeaderElector(lock, identity).
+		WithLeaseDuration(leaseDuration).
+		WithRetryPeriod(retryPeriod).
+		WithLogger(logger), nil
+}
+
+// checkSchema returns a startup check that passes once the migrations have created
+// the relations (tables and indexes) in the database.
+func checkSchema(db *sql.DB, relations ...string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		for _, relation := range relations {
+			var exists bool
+			if err := db.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", relation).Scan(&exists); err != nil {
+				return fmt.Errorf("failed to check relation %s: %w", relation, err)
+			}
+			if !exists {
+				return fmt.Errorf("relation %s does not exist", relation)
+			}
+		}
+		return nil
+	}
+}
+
+// warmConnections returns a startup check that opens n connections to the database,
+// so the first requests don't pay for the connection setup.
+func warmConnections(db *sql.DB, n int) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		conns := make([]*sql.Conn, 0, n)
+		defer func() {
+			for _, conn := range conns {
+				_ = conn.Close()
+			}
+		}()
+		for range n {
+			conn, err := db.Conn(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to open connection: %w", err)
+			}
+			conns = append(conns, conn)
+		}
+		return nil
+	}
+}
+
+// mustLookupSecret resolves a secret or terminates the program if the provider fails.
+func mustLookupSecret(ctx context.Context, secrets outbound.SecretsProvider, name, fallback string, logger *slog.Logger) string {
+	value, err := outbound.LookupSecret(ctx, secrets, name, fallback)
+	if err != nil {
+		logger.Error("failed to resolve secret", "name", name, "error", err)
+		os.Exit(1)
+	}
+	return value
+}
+
+// buildServer creates the HTTP server. Timeouts are read from SERVER_*_TIMEOUT by
+// web.NewServer; header size, keep-alives and protocols are tuned here.
+func buildServer(mux *http.ServeMux, maxHeaderBytes int, keepAlives, h2c bool) *http.Server {
+	srv := web.NewServer(mux)
+	srv.MaxHeaderBytes = maxHeaderBytes
+	srv.SetKeepAlivesEnabled(keepAlives)
+
+	// HTTP/2 is negotiated via ALPN with TLS. h2c serves HTTP/2 over plaintext,
+	// e.g. for a gRPC-gateway co-hosted behind a TLS-terminating proxy.
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(h2c)
+	srv.Protocols = protocols
+
+	return srv
+}
+
+// buildCompression returns the response compression for a comma-separated list of
+// media types, or nil if compression is disabled.
+func buildCompression(enabled bool, minSize int, types string) *inbound.Compression {
+	if !enabled {
+		return nil
+	}
+	compression := inbound.NewCompression().WithMinSize(minSize)
+	if mediaTypes := strings.FieldsFunc(types, func(r rune) bool { return r == ',' }); len(mediaTypes) > 0 {
+		compression = compression.WithTypes(mediaTypes...)
+	}
+	return compression
+}
+
+// buildSessionStore returns the external session store for the given kind,
+// or nil to keep sessions in memory only.
+func buildSessionStore(kind, redisURL string, db *sql.DB) (inbound.SessionStore, error) {
+	switch kind {
+	case "redis":
+		options, err := redis.ParseURL(redisURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse redis URL: %w", err)
+		}
+		return outbound.NewRedisSessionStore(redis.NewClient(options)), nil
+	case "postgres":
+		return outbound.NewPostgresSessionStore(db), nil
+	default:
+		return nil, nil
+	}
+}
+
+// buildDocumentRepository returns the store for generated documents such as invoices.
+func buildDocumentRepository(ctx context.Context, kind string, secrets outbound.SecretsProvider, logger *slog.Logger) invoicing.DocumentRepository {
+	switch kind {
+	case "s3":
+		return outbound.NewS3DocumentRepository(
+			env.Get("S3_ENDPOINT", "http://localhost:9000"),
+			env.Get("S3_REGION", "us-east-1"),
+			env.Get("S3_BUCKET", "hotel-booking"),
+			mustLookupSecret(ctx, secrets, "S3_ACCESS_KEY_ID", "", logger),
+			mustLookupSecret(ctx, secrets, "S3_SECRET_ACCESS_KEY", "", logger),
+			&http.Client{Timeout: 30 * time.Second},
+		)
+	default:
+		return outbound.NewFileDocumentRepository(env.Get("DOCUMENT_DIR", "documents"))
+	}
+}
+
+// buildTLSConfig returns the TLS configuration of the HTTP server, or nil to serve plaintext HTTP.
+// Certificates are issued by Let's Encrypt if autocert domains are given, otherwise they are
+// loaded from files and reloaded on SIGHUP. A client CA enables mTLS for the API routes.
+func buildTLSConfig(ctx context.Context, certFile, keyFile, autocertDomains, autocertCacheDir, clientCAFile string, logger *slog.Logger) (*tls.Config, error) {
+	var config *tls.Config
+	switch {
+	case autocertDomains != "":
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(strings.Split(autocertDomains, ",")...),
+			Cache:      autocert.DirCache(autocertCacheDir),
+		}
+		config = manager.TLSConfig()
+	case certFile != "" && keyFile != "":
+		reloader, err := inbound.NewCertificateReloader(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		reloadCertificateOnSIGHUP(ctx, reloader, logger)
+		config = &tls.Config{GetCertificate: reloader.GetCertificate}
+	default:
+		return nil, nil
+	}
+	config.MinVersion = tls.VersionTLS12
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse client CA: %s", clientCAFile)
+		}
+		// Client certificates are verified if given; the API routes require them,
+		// while browsers can still reach the UI without one.
+		config.ClientCAs = pool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return config, nil
+}
+
+// reloadCertificateOnSIGHUP reloads the server certificate whenever the process
+// receives SIGHUP, until the context is done.
+func reloadCertificateOnSIGHUP(ctx context.Context, reloader *inbound.CertificateReloader, logger *slog.Logger) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				if err := reloader.Reload(); err != nil {
+					logger.Error("failed to reload certificate", "error", err)
+					continue
+				}
+				logger.Info("certificate reloaded")
+			}
+		}
+	}()
+}
+
+// buildEncryptor returns the AES-GCM encryptor for guest PII,
+// or nil if no encryption keys are given.
+func buildEncryptor(encryptionKeys string) (outbound.Encryptor, error) {
+	if encryptionKeys == "" {
+		return nil, nil
+	}
+	encryptor, err := outbound.NewAESGCMEncryptorFromSpec(encryptionKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encryptor: %w", err)
+	}
+	return encryptor, nil
+}
+
+// buildReservationRepository returns the Postgres reservation repository.
+// If an encryptor is given, guest PII is encrypted at rest.
+func buildReservationRepository(db *sql.DB, encryptor outbound.Encryptor) reservation.ReservationRepository {
+	repo := outbound.NewPostgresReservationRepository(db)
+	if encryptor == nil {
+		return repo
+	}
+	return outbound.NewEncryptedReservationRepository(repo, encryptor)
+}
+
+// buildGuestProfileRepository returns the Postgres guest profile repository.
+// If an encryptor is given, the profile's PII is encrypted at rest.
+func buildGuestProfileRepository(db *sql.DB, encryptor outbound.Encryptor) reservation.GuestProfileRepository {
+	repo := outbound.NewPostgresGuestProfileRepository(db)
+	if encryptor == nil {
+		return repo
+	}
+	return outbound.NewEncryptedGuestProfileRepository(repo, encryptor)
+}
+
+// buildRegistrationRepository returns the JSON file registration repository.
+// If an encryptor is given, the document reference is encrypted at rest.
+func buildRegistrationRepository(path string, codec outbound.Codec, encryptor outbound.Encryptor) reservation.RegistrationRepository {
+	repo := outbound.NewFileAccess[reservation.ReservationID, reservation.Registration](path, codec)
+	if encryptor == nil {
+		return repo
+	}
+	return outbound.NewEncryptedRegistrationRepository(repo, encryptor)
+}
+
+// buildAddOnPricing returns the prices of the add-ons in ADD_ON_CURRENCY, in the smallest
+// currency unit: breakfast per guest and night, parking per night and late checkout per stay.
+// A price of 0 takes the add-on off the offer.
+func buildAddOnPricing() *reservation.AddOnPricing {
+	currency := env.Get("ADD_ON_CURRENCY", "USD")
+	prices := map[reservation.AddOnKind]int{
+		reservation.AddOnBreakfast:    env.Get("ADD_ON_BREAKFAST_PRICE", 1500),
+		reservation.AddOnParking:      env.Get("ADD_ON_PARKING_PRICE", 2000),
+		reservation.AddOnLateCheckout: env.Get("ADD_ON_LATE_CHECKOUT_PRICE", 3000),
+	}
+
+	pricing := reservation.NewAddOnPricing()
+	for kind, price := range prices {
+		if price > 0 {
+			pricing.WithPrice(kind, shared.NewMoney(int64(price), currency))
+		}
+	}
+	return pricing
+}
+
+// buildNotificationDispatcher returns the dispatcher of guest notifications.
+// Emails are logged; SMS is sent if SMS_ACCOUNT_SID is set and push notifications
+// if PUSH_WEBHOOK_URL is set. Guests preferring a channel that is not configured
+// are notified on the next channel of their preferences.
+func buildNotificationDispatcher(ctx context.Context, secrets outbound.SecretsProvider, profiles reservation.GuestProfileRepository, logger *slog.Logger) *outbound.NotificationDispatcher {
+	dispatcher := outbound.NewNotificationDispatcher(outbound.NewMockNotificationService(logger).WithGuestProfiles(profiles), logger).
+		WithGuestProfiles(profiles)
+	client := &http.Client{Timeout: env.Get("SERVICE_TIMEOUT", 5*time.Second)}
+	if accountSID := env.Get("SMS_ACCOUNT_SID", ""); accountSID != "" {
+		dispatcher.WithChannel(reservation.ChannelSMS, outbound.NewTwilioSMSSender(
+			env.Get("SMS_API_URL", "https://api.twilio.com"),
+			accountSID,
+			mustLookupSecret(ctx, secrets, "SMS_AUTH_TOKEN", "", logger),
+			env.Get("SMS_FROM", ""),
+			client,
+		))
+	}
+	if pushURL := env.Get("PUSH_WEBHOOK_URL", ""); pushURL != "" {
+		dispatcher.WithChannel(reservation.ChannelPush, outbound.NewWebhookPushSender(pushURL,
+			[]byte(mustLookupSecret(ctx, secrets, "PUSH_WEBHOOK_SECRET", "", logger)),
+			client,
+		))
+	}
+	return dispatcher
+}
+
+// buildPrivacyService returns the privacy service with the erasers of all stores
+// that keep personal data of guests. Reviews and the recording are optional.
+func buildPrivacyService(
+	reservationService *reservation.Service,
+	paymentService *payment.Service,
+	notifications *orchestration.NotificationTracker,
+	reviews *review.Service,
+	eventLog *admin.EventLog,
+	recorder *inbound.RecordingDispatcher,
+) *privacy.Service {
+	svc := privacy.NewService(reservationService, paymentService).
+		WithEraser("registrations", privacy.GuestDataEraserFunc(func(ctx context.Context, _ reservation.GuestID, ids []reservation.ReservationID) (int, error) {
+			return reservationService.DeleteRegistrations(ctx, ids)
+		})).
+		WithEraser("payment_methods", privacy.GuestDataEraserFunc(func(ctx context.Context, guestID reservation.GuestID, _ []reservation.ReservationID) (int, error) {
+			return paymentService.RemoveGuestPaymentMethods(ctx, payment.GuestID(guestID))
+		})).
+		WithEraser("notifications", privacy.GuestDataEraserFunc(func(ctx context.Context, _ reservation.GuestID, ids []reservation.ReservationID) (int, error) {
+			return notifications.DeleteNotifications(ctx, sharedReservationIDs(ids))
+		})).
+		WithEraser("event_log", privacy.GuestDataEraserFunc(func(_ context.Context, _ reservation.GuestID, ids []reservation.ReservationID) (int, error) {
+			return eventLog.Forget(sharedReservationIDs(ids)), nil
+		}))
+	if reviews != nil {
+		svc.WithEraser("reviews", privacy.GuestDataEraserFunc(reviews.AnonymizeReviews))
+	}
+	if recorder != nil {
+		svc.WithEraser("event_recording", privacy.GuestDataEraserFunc(func(_ context.Context, guestID reservation.GuestID, ids []reservation.ReservationID) (int, error) {
+			refs := make([]string, 0, len(ids))
+			for _, id := range ids {
+				refs = append(refs, string(id))
+			}
+			return recorder.Erase(string(guestID), refs)
+		}))
+	}
+	return svc
+}
+
+// sharedReservationIDs converts reservation IDs to the IDs shared between the contexts.
+func sharedReservationIDs(ids []reservation.ReservationID) []shared.ReservationID {
+	result := make([]shared.ReservationID, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.Shared())
+	}
+	return result
+}
+
+// runEvery runs fn at every interval until the context is done. The jobs only run
+// on the leader replica, and an error is logged with the name of the job.
+func runEvery(ctx context.Context, name string, interval time.Duration, leader *outbound.LeaderElector, logger *slog.Logger, fn func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !leader.IsLeader() {
+					continue
+				}
+				if err := fn(ctx); err != nil {
+					logger.Error("failed to run scheduled job", "job", name, "error", err)
+				}
+			}
+		}
+	}()
+}
+
 func main() {
 	// Create a new context with a cancel function.
 	ctx, cancel := service.Context()
@@ -54,16 +474,31 @@ func main() {
 	// We use the logging.NewJsonLogger function from the cloud-native-utils/logging package.
 	logger := logging.NewJsonLogger()
 
+	// Resolve credentials via the configured secrets provider (env, file or vault),
+	// so production deployments don't need secrets in the environment.
+	secrets := buildSecretsProvider(env.Get("SECRETS_PROVIDER", "env"))
+
+	// Tune the connection pools of both databases. The pgxpool driver replaces the
+	// database/sql pool with a pgxpool.Pool. Each connection caches its prepared statements.
+	poolConfig := outbound.PostgresPoolConfig{
+		Driver:                 env.Get("DB_DRIVER", "stdlib"),
+		MaxOpenConns:           env.Get("DB_MAX_OPEN_CONNS", 20),
+		MaxIdleConns:           env.Get("DB_MAX_IDLE_CONNS", 10),
+		ConnMaxLifetime:        env.Get("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+		ConnMaxIdleTime:        env.Get("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+		StatementCacheCapacity: env.Get("DB_STATEMENT_CACHE_CAPACITY", 512),
+	}
+
 	// Initialize Reservation Database connection.
 	reservationDSN := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		env.Get("RESERVATION_DB_HOST", "localhost"),
 		env.Get("RESERVATION_DB_PORT", "5432"),
 		env.Get("RESERVATION_DB_USER", "reservation"),
-		env.Get("RESERVATION_DB_PASSWORD", "reservation_secret"),
+		mustLookupSecret(ctx, secrets, "RESERVATION_DB_PASSWORD", "reservation_secret", logger),
 		env.Get("RESERVATION_DB_NAME", "reservation_db"),
 		env.Get("RESERVATION_DB_SSLMODE", "disable"),
 	)
-	reservationDB, err := sql.Open("pgx", reservationDSN)
+	reservationDB, err := outbound.OpenPostgres(ctx, "reservation", reservationDSN, poolConfig)
 	if err != nil {
 		logger.Error("failed to connect to reservation database", "error", err)
 		os.Exit(1)
@@ -75,44 +510,496 @@ func main() {
 		env.Get("PAYMENT_DB_HOST", "localhost"),
 		env.Get("PAYMENT_DB_PORT", "5433"),
 		env.Get("PAYMENT_DB_USER", "payment"),
-		env.Get("PAYMENT_DB_PASSWORD", "payment_secret"),
+		mustLookupSecret(ctx, secrets, "PAYMENT_DB_PASSWORD", "payment_secret", logger),
 		env.Get("PAYMENT_DB_NAME", "payment_db"),
 		env.Get("PAYMENT_DB_SSLMODE", "disable"),
 	)
-	paymentDB, err := sql.Open("pgx", paymentDSN)
+	paymentDB, err := outbound.OpenPostgres(ctx, "payment", paymentDSN, poolConfig)
 	if err != nil {
 		logger.Error("failed to connect to payment database", "error", err)
 		os.Exit(1)
 	}
 	defer paymentDB.Close()
 
+	// Retry policy for outbound port calls (event publishing, payment gateway) and event handlers.
+	// Transient broker or gateway blips are retried with exponential backoff.
+	retryPolicy := outbound.NewRetryPolicy().
+		WithMaxAttempts(env.Get("SERVICE_RETRY_MAX", 3)).
+		WithInitialDelay(env.Get("SERVICE_RETRY_DELAY", 100*time.Millisecond)).
+		WithMaxDelay(env.Get("SERVICE_RETRY_MAX_DELAY", 5*time.Second))
+
+	// Identity of this replica, used for its consumer group and the leader election.
+	podName := env.Get("POD_NAME", "")
+	if podName == "" {
+		podName, _ = os.Hostname()
+	}
+
+	// Events and the file repositories are encoded with the codec selected by CODEC.
+	// The faster go-json codec is compiled in with the gojson build tag.
+	codec, err := outbound.NewCodec(env.Get("CODEC", "json"))
+	if err != nil {
+		logger.Error("failed to initialize codec", "error", err)
+		os.Exit(1)
+	}
+
 	// Shared event dispatcher using Kafka for distributed event messaging.
-	dispatcher := messaging.NewExternalDispatcher()
+	// Events are keyed by reservation ID, so the events of a reservation stay in order.
+	// Bounded contexts consume in consumer groups (see inbound.NewConsumerGroup).
+	// On SIGTERM the consumers stop reading, and the shutdown drains the messages in flight.
+	kafkaDispatcher := outbound.NewKafkaDispatcher(
+		strings.Split(env.Get("KAFKA_BROKERS", "localhost:9092"), ","),
+		env.Get("KAFKA_CONSUMER_GROUP_ID", "hotel-booking"),
+		podName,
+	).WithRetryPolicy(retryPolicy).WithLogger(logger)
+
+	// With EVENT_PUBLISH_BATCH_SIZE, events are published write-behind: requests do not
+	// wait for the broker, and the events are written in batches of up to the size at least
+	// every EVENT_PUBLISH_BATCH_INTERVAL. Batches that fail after the retries are logged.
+	var external messaging.Dispatcher = kafkaDispatcher
+	var batching *outbound.BatchingPublisher
+	if size := env.Get("EVENT_PUBLISH_BATCH_SIZE", 0); size > 0 {
+		batching = outbound.NewBatchingPublisher(kafkaDispatcher, size, env.Get("EVENT_PUBLISH_BATCH_INTERVAL", 10*time.Millisecond)).
+			WithRetryPolicy(retryPolicy).
+			WithErrorHandler(func(messages []messaging.Message, err error) {
+				for _, message := range messages {
+					logger.Error("failed to publish event", "topic", message.Topic, "key", string(outbound.ReservationKey(message)), "error", err)
+				}
+			})
+		external = batching
+		logger.Info("event batching enabled", "size", size)
+	}
+
+	// Events of EVENT_PRIORITY_TOPICS, or published with shared.WithPriority, go to priority
+	// topics with readers of their own, so a compensation does not wait behind the backlog.
+	external = outbound.NewPriorityDispatcher(external, strings.Split(env.Get("EVENT_PRIORITY_TOPICS", "payment.failed"), ",")...)
 
-	// Initialize reservation bounded context using PostgresAccess from cloud-native-utils.
+	// With EVENT_RECORDING_FILE, every published event is appended to the file,
+	// so an incident can be replayed locally with "cli replay events".
+	// The recording is opened for reading as well, so an erasure request can rewrite it.
+	var recorder *inbound.RecordingDispatcher
+	if path := env.Get("EVENT_RECORDING_FILE", ""); path != "" {
+		recording, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o600)
+		if err != nil {
+			logger.Error("failed to open event recording", "path", path, "error", err)
+			os.Exit(1)
+		}
+		defer func() { _ = recording.Close() }()
+		recorder = inbound.NewRecordingDispatcher(external, recording)
+		external = recorder
+		logger.Info("event recording enabled", "path", path)
+	}
+
+	// Events published with shared.WithDeliverAt are held back in the delayed_events table
+	// of the reservation database until due, since Kafka has no delayed delivery.
+	// The table is shared, so the leader releases the events delayed on every replica.
+	delayQueue := outbound.NewDelayQueue(external,
+		outbound.NewPostgresTableAccess[string, outbound.DelayedMessage](reservationDB.DB, "delayed_events"),
+	)
+	external = delayQueue
+	draining := inbound.NewDrainingDispatcher(external)
+
+	// Handle the events of one reservation sequentially across all topics, while
+	// the events of different reservations are handled in parallel by the workers.
+	keyed := inbound.NewKeyedDispatcher(draining, outbound.ReservationKey, env.Get("EVENT_HANDLER_WORKERS", 16))
+
+	// Report the outcome and latency of every handler to the event log of the admin
+	// dashboard, and carry the correlation ID of an event on to the events its handlers publish.
+	eventLog := admin.NewEventLog(env.Get("ADMIN_EVENT_LOG_SIZE", 100))
+	dispatcher := inbound.NewTracingDispatcher(keyed, eventLog)
+
+	// Check that the migrations have been applied and warm up the connection pools
+	// in the background. Kubernetes waits for the startup probe (/startup) to pass.
+	warmup := env.Get("STARTUP_WARMUP_CONNECTIONS", 2)
+	startupProbe := inbound.NewStartupProbe().
+		WithRetryInterval(env.Get("STARTUP_RETRY_INTERVAL", time.Second)).
+		WithCheck("reservation migrations", checkSchema(reservationDB.DB, "kv_store", "idx_kv_store_guest_id", "sessions", "login_nonces", "login_attempts", "delayed_events", "room_blocks", "compensation_queue", "saga_states", "notification_jobs", "guest_profiles")).
+		WithCheck("payment migrations", checkSchema(paymentDB.DB, "kv_store", "idx_kv_store_reservation_id", "processed_commands", "payment_methods")).
+		WithCheck("reservation connections", warmConnections(reservationDB.DB, warmup)).
+		WithCheck("payment connections", warmConnections(paymentDB.DB, warmup))
+	go func() {
+		if err := startupProbe.Run(ctx); err == nil {
+			logger.Info("startup checks passed")
+		}
+	}()
+
+	// Elect a leader among the replicas, so the singleton jobs (compensation retries,
+	// delayed events, pre-arrival reminders, calendar sync, reconciliation, no-shows, balances, deposit releases) run on exactly one of them.
+	leader, err := buildLeaderElector(
+		env.Get("LEADER_ELECTION", "none"),
+		env.Get("LEADER_ELECTION_LEASE_NAME", "hotel-booking"),
+		podName,
+		env.Get("LEADER_ELECTION_LEASE_DURATION", 15*time.Second),
+		env.Get("LEADER_ELECTION_RETRY_PERIOD", 5*time.Second),
+		logger,
+	)
+	if err != nil {
+		logger.Error("failed to initialize leader election", "error", err)
+		os.Exit(1)
+	}
+	if leader != nil {
+		go leader.Run(ctx)
+	}
+	runEvery(ctx, "delayed events", env.Get("DELAYED_EVENTS_INTERVAL", 10*time.Second), leader, logger, func(ctx context.Context) error {
+		released, err := delayQueue.Release(ctx, time.Now())
+		if released > 0 {
+			logger.Info("delayed events released", "count", released)
+		}
+		return err
+	})
+
+	// Initialize reservation bounded context using PostgresAccess from cloud-native-utils,
+	// extended with an indexed lookup of reservations by guest ID and guest profiles.
+	// Guest PII is encrypted at rest when PII_ENCRYPTION_KEYS is set.
 	// Schema is created by Docker init scripts (migrations/reservation/init.sql).
-	reservationRepo := resource.NewPostgresAccess[reservation.ReservationID, reservation.Reservation](reservationDB)
-	availabilityChecker := outbound.NewRepositoryAvailabilityChecker(reservationRepo)
-	reservationPublisher := outbound.NewEventPublisher(dispatcher)
-	reservationService := reservation.NewService(reservationRepo, availabilityChecker, reservationPublisher)
+	encryptor, err := buildEncryptor(mustLookupSecret(ctx, secrets, "PII_ENCRYPTION_KEYS", "", logger))
+	if err != nil {
+		logger.Error("failed to initialize PII encryption", "error", err)
+		os.Exit(1)
+	}
+	// Maintenance and renovation blocks are stored in the room_blocks table and count as occupancy.
+	// Registration cards captured at check-in are persisted to a JSON file as well,
+	// with the document reference encrypted like the other guest PII.
+	// With PAYMENT_PLAN_DEPOSIT_PERCENT set, bookings made well ahead pay a deposit at booking
+	// and the balance PAYMENT_PLAN_BALANCE_DUE_BEFORE ahead of check-in.
+	// Add-ons of a stay are priced in ADD_ON_CURRENCY (see buildAddOnPricing).
+	reservationRepo := buildReservationRepository(reservationDB.DB, encryptor)
+	roomBlocks := outbound.NewPostgresTableAccess[reservation.RoomBlockID, reservation.RoomBlock](reservationDB.DB, "room_blocks")
+	registrations := buildRegistrationRepository(env.Get("REGISTRATIONS_PATH", "registrations.json"), codec, encryptor)
+	availabilityChecker := outbound.NewRepositoryAvailabilityChecker(reservationRepo).WithRoomBlocks(roomBlocks)
+	reservationPublisher := outbound.NewRetryEventPublisher(outbound.NewEventPublisher(dispatcher).WithCodec(codec), retryPolicy)
+	guestProfiles := buildGuestProfileRepository(reservationDB.DB, encryptor)
+	reservationService := reservation.NewService(reservationRepo, availabilityChecker, reservationPublisher).
+		WithGuestProfiles(guestProfiles).
+		WithPolicies(outbound.NewEnvBookingPolicies()).
+		WithRoomBlocks(roomBlocks).
+		WithRegistrations(registrations).
+		WithPaymentPlan(reservation.PaymentPlan{
+			DepositPercent:   env.Get("PAYMENT_PLAN_DEPOSIT_PERCENT", 0),
+			BalanceDueBefore: env.Get("PAYMENT_PLAN_BALANCE_DUE_BEFORE", 30*24*time.Hour),
+		}).
+		WithAddOnPricing(buildAddOnPricing())
+
+	// Initialize payment bounded context using PostgresAccess from cloud-native-utils,
+	// extended with an indexed lookup of payments by reservation ID.
+	// Handled commands are stored in the processed_commands table, so redelivered events
+	// do not charge twice, whichever replica receives them.
+	// Evidence contesting disputes is forwarded to the gateway.
+	// Cards stored by guests are stored in the payment_methods table as gateway tokens.
+	paymentRepo := outbound.NewPostgresPaymentRepository(paymentDB.DB)
+	mockGateway := outbound.NewMockPaymentGateway()
+	paymentGateway := outbound.NewRetryPaymentGateway(mockGateway, retryPolicy)
+	paymentPublisher := outbound.NewRetryEventPublisher(outbound.NewEventPublisher(dispatcher).WithCodec(codec), retryPolicy)
+	processedCommands := outbound.NewPostgresTableAccess[payment.CommandID, payment.ProcessedCommand](paymentDB.DB, "processed_commands")
+	paymentMethods := outbound.NewPostgresTableAccess[payment.PaymentMethodID, payment.PaymentMethod](paymentDB.DB, "payment_methods")
+	paymentService := payment.NewService(paymentRepo, paymentGateway, paymentPublisher).
+		WithProcessedCommands(processedCommands).
+		WithDisputeGateway(mockGateway).
+		WithPaymentMethods(paymentMethods)
 
-	// Initialize payment bounded context using PostgresAccess from cloud-native-utils.
-	paymentRepo := resource.NewPostgresAccess[payment.PaymentID, payment.Payment](paymentDB)
-	paymentGateway := outbound.NewMockPaymentGateway()
-	paymentPublisher := outbound.NewEventPublisher(dispatcher)
-	paymentService := payment.NewService(paymentRepo, paymentGateway, paymentPublisher)
+	// Generator for new aggregate IDs (time-ordered UUIDv7 or ULID).
+	ids := buildIDGenerator(env.Get("ID_GENERATOR", "uuidv7"))
+
+	// Feature flags toggle behavior per environment without rebuilds.
+	flags := buildFeatureFlags(env.Get("FEATURE_FLAGS_PROVIDER", "env"), env.Get("FLAGD_URL", "http://localhost:8016"), env.Get("FLAGD_CACHE_TTL", 10*time.Second))
 
 	// Initialize orchestration layer.
-	notificationService := outbound.NewMockNotificationService(logger)
-	bookingService := orchestration.NewBookingService(reservationService, paymentService, notificationService)
+	// Failed compensations are stored in the compensation_queue table and retried in the background.
+	// Notifications are localized in the locale saved in the guest's profile and sent over
+	// the channels the guest prefers for the message type, falling back to the next one.
+	notificationService := buildNotificationDispatcher(ctx, secrets, guestProfiles, logger)
+
+	// Record the delivery of every guest notification in the notification_jobs table
+	// and retry failed deliveries in the background.
+	notificationTracker := orchestration.NewNotificationTracker(reservationService, paymentService, notificationService,
+		outbound.NewPostgresTableAccess[orchestration.NotificationJobID, orchestration.NotificationJob](reservationDB.DB, "notification_jobs"),
+	).WithMaxAttempts(env.Get("NOTIFICATION_MAX_ATTEMPTS", orchestration.DefaultNotificationAttempts))
+	runEvery(ctx, "notification retries", env.Get("NOTIFICATION_RETRY_INTERVAL", 5*time.Minute), leader, logger, func(ctx context.Context) error {
+		sent, err := notificationTracker.RetryNotifications(ctx)
+		if sent > 0 {
+			logger.Info("notifications sent on retry", "count", sent)
+		}
+		return err
+	})
+	compensationQueue := outbound.NewPostgresTableAccess[orchestration.CompensationID, orchestration.FailedCompensation](reservationDB.DB, "compensation_queue")
+	bookingPublisher := outbound.NewRetryEventPublisher(outbound.NewEventPublisher(dispatcher).WithCodec(codec), retryPolicy)
 
-	// Register cross-context event handlers.
+	// Invoices are rendered as PDF and stored on disk or in S3-compatible object storage.
+	// They are attached to payment receipts and can be downloaded via the API.
+	// INVOICE_TAX_RATE is the tax percentage included in room prices (e.g. 19 for 19%).
+	invoiceService := invoicing.NewService(reservationService, paymentService,
+		outbound.NewPDFInvoiceRenderer(),
+		buildDocumentRepository(ctx, env.Get("DOCUMENT_STORE", "file"), secrets, logger),
+	).WithTaxRate(int(math.Round(env.Get("INVOICE_TAX_RATE", 0.0) * 100)))
+	// The saga states are a read model built from the domain events and stored in the saga_states table.
+	// They also record the mode each saga started in, so switching the flag does not strand it.
+	sagaTracker := orchestration.NewSagaTracker(
+		outbound.NewPostgresTableAccess[shared.ReservationID, orchestration.SagaState](reservationDB.DB, "saga_states"),
+	)
+	bookingService := orchestration.NewBookingService(reservationService, paymentService, notificationTracker).
+		WithSagaBudget(env.Get("SERVICE_SAGA_BUDGET", 30*time.Second)).
+		WithCompensationQueue(compensationQueue).
+		WithEventPublisher(bookingPublisher).
+		WithIDGenerator(ids).
+		WithFeatureFlags(flags).
+		WithSagaTracker(sagaTracker).
+		WithInvoices(invoiceService)
+	runEvery(ctx, "compensation retries", env.Get("SERVICE_COMPENSATION_RETRY_INTERVAL", time.Minute), leader, logger, func(ctx context.Context) error {
+		resolved, err := bookingService.RetryCompensations(ctx)
+		if resolved > 0 {
+			logger.Info("compensations resolved", "count", resolved)
+		}
+		return err
+	})
+
+	// Mark confirmed reservations as no-show once the guest missed the check-in date.
+	// The fee is kept from the payment, the rest is refunded and the guest is notified.
+	noShowService := orchestration.NewNoShowService(reservationService, paymentService, notificationTracker).
+		WithFeeNights(env.Get("NO_SHOW_FEE_NIGHTS", 1)).
+		WithGracePeriod(env.Get("NO_SHOW_GRACE_PERIOD", 24*time.Hour))
+	runEvery(ctx, "no-shows", env.Get("NO_SHOW_INTERVAL", time.Hour), leader, logger, func(ctx context.Context) error {
+		marked, err := noShowService.ProcessNoShows(ctx, time.Now())
+		if len(marked) > 0 {
+			logger.Info("reservations marked as no-show", "count", len(marked))
+		}
+		return err
+	})
+
+	// Remind guests PRE_ARRIVAL_DAYS_BEFORE their check-in with the check-in instructions
+	// and a link to book extras, unless they opted out of "pre_arrival" notifications.
+	// Confirming a booking publishes a booking.pre_arrival_due event delivered at the
+	// reminder time; the scheduled job reminds the guests the event missed.
+	preArrivalService := orchestration.NewPreArrivalService(reservationService, notificationTracker).
+		WithEventPublisher(bookingPublisher).
+		WithDaysBefore(env.Get("PRE_ARRIVAL_DAYS_BEFORE", 3)).
+		WithInstructions(env.Get("PRE_ARRIVAL_INSTRUCTIONS", "Check-in starts at 3 pm at the front desk.")).
+		WithUpsellURL(env.Get("PRE_ARRIVAL_UPSELL_URL", "http://localhost:8080/ui/reservations/{reservation_id}"))
+	if err := preArrivalService.RegisterHandlers(ctx, inbound.NewConsumerGroup(dispatcher, "pre-arrival")); err != nil {
+		logger.Error("failed to register pre-arrival handlers", "error", err)
+		os.Exit(1)
+	}
+	runEvery(ctx, "pre-arrival reminders", env.Get("PRE_ARRIVAL_INTERVAL", time.Hour), leader, logger, func(ctx context.Context) error {
+		report, err := preArrivalService.SendReminders(ctx, time.Now())
+		if len(report.Reminded) > 0 || len(report.OptedOut) > 0 {
+			logger.Info("pre-arrival reminders sent", "reminded", len(report.Reminded), "opted_out", len(report.OptedOut))
+		}
+		return err
+	})
+
+	// Staff preview the guest notifications of a reservation per locale without sending them.
+	notificationPreview := orchestration.NewNotificationPreviewService(noShowService, notificationService).
+		WithPreArrivalService(preArrivalService)
+
+	// Collect the balance of bookings paid in installments: remind the guest ahead of the
+	// due date, charge the balance when due and cancel the booking if it keeps failing.
+	// The job also runs with the payment plan disabled, so open balances are still collected.
+	paymentScheduleService := orchestration.NewPaymentScheduleService(reservationService, paymentService, notificationTracker).
+		WithReminderBefore(env.Get("PAYMENT_PLAN_REMINDER_BEFORE", 7*24*time.Hour)).
+		WithRetryAfter(env.Get("PAYMENT_PLAN_RETRY_AFTER", 24*time.Hour)).
+		WithIDGenerator(ids)
+	runEvery(ctx, "balances", env.Get("PAYMENT_PLAN_INTERVAL", time.Hour), leader, logger, func(ctx context.Context) error {
+		report, err := paymentScheduleService.ProcessBalances(ctx, time.Now())
+		if len(report.Reminded)+len(report.Paid)+len(report.Cancelled) > 0 {
+			logger.Info("balances processed",
+				"reminded", len(report.Reminded),
+				"paid", len(report.Paid),
+				"cancelled", len(report.Cancelled),
+			)
+		}
+		return err
+	})
+
+	// Reconcile captured and refunded payments with the gateway's settlement reports.
+	// Discrepancies are persisted to a JSON file until a later run finds them in order.
+	reconciliationService := reconciliation.NewService(paymentService, mockGateway,
+		outbound.NewFileAccess[reconciliation.DiscrepancyID, reconciliation.Discrepancy](
+			env.Get("RECONCILIATION_DISCREPANCIES_PATH", "discrepancies.json"),
+			codec,
+		),
+	).WithSettlementDelay(env.Get("RECONCILIATION_SETTLEMENT_DELAY", time.Hour))
+	// The window should be longer than the interval, so late settlements are still matched.
+	reconciliationWindow := env.Get("RECONCILIATION_WINDOW", 48*time.Hour)
+	runEvery(ctx, "reconciliation", env.Get("RECONCILIATION_INTERVAL", 24*time.Hour), leader, logger, func(ctx context.Context) error {
+		to := time.Now()
+		run, err := reconciliationService.Reconcile(ctx, to.Add(-reconciliationWindow), to)
+		if err != nil {
+			return err
+		}
+		if len(run.Discrepancies) > 0 {
+			logger.Warn("payment discrepancies found", "count", len(run.Discrepancies), "matched", run.Matched)
+		}
+		return nil
+	})
+
+	// Register cross-context event handlers. With several replicas, each event is
+	// handled by one replica of the orchestration consumer group.
 	eventHandlers := orchestration.NewEventHandlers(bookingService, reservationService, paymentService)
-	if err := eventHandlers.RegisterHandlers(ctx, dispatcher); err != nil {
+	if err := eventHandlers.RegisterHandlers(ctx, inbound.NewConsumerGroup(dispatcher, "orchestration")); err != nil {
 		logger.Error("failed to register event handlers", "error", err)
 		os.Exit(1)
 	}
 
+	// Track the progress of booking sagas for the live booking status page.
+	// Local read models subscribe without a consumer group, so every replica receives every event.
+	if err := sagaTracker.RegisterHandlers(ctx, dispatcher.Named("saga-tracker")); err != nil {
+		logger.Error("failed to register saga tracker", "error", err)
+		os.Exit(1)
+	}
+
+	// Compose the admin dashboard from the read models. The most recent events
+	// are kept in memory for the activity feed and the message trace. The event log
+	// subscribes without tracing, so it does not report itself as a handler.
+	if err := eventLog.RegisterHandlers(ctx, keyed); err != nil {
+		logger.Error("failed to register admin event log", "error", err)
+		os.Exit(1)
+	}
+
+	// Cache availability queries for AVAILABILITY_CACHE_TTL. Concurrent queries of the same
+	// room and dates share one repository read, and reservation events invalidate the room on
+	// every replica. Bookings still check the repository, so a cached entry never double-books.
+	availabilityCache := outbound.NewCachedAvailabilityChecker(availabilityChecker, env.Get("AVAILABILITY_CACHE_TTL", 5*time.Second))
+	if err := availabilityCache.RegisterHandlers(ctx, dispatcher.Named("availability-cache")); err != nil {
+		logger.Error("failed to register availability cache", "error", err)
+		os.Exit(1)
+	}
+	adminService := admin.NewService(reservationService, paymentService, bookingService, eventLog).
+		WithSagaTracker(sagaTracker).
+		WithStaleSagaAfter(env.Get("ADMIN_STALE_SAGA_AFTER", 15*time.Minute))
+	// ADMIN_EMAILS lists the staff allowed to open the dashboard; without it, the dashboard is disabled.
+	adminEmails := strings.FieldsFunc(env.Get("ADMIN_EMAILS", ""), func(r rune) bool { return r == ',' })
+
+	// The event simulator publishes crafted events onto the dispatcher. It is meant for
+	// local development only, because handlers act on the events like on real ones.
+	var eventSimulator messaging.Dispatcher
+	if env.Get("EVENT_SIMULATOR_ENABLED", false) {
+		eventSimulator = dispatcher
+		logger.Warn("event simulator enabled, do not use in production")
+	}
+
+	// Export room calendars as iCal feeds and import external feeds (e.g. Airbnb)
+	// as holds that block the booked dates. CALENDAR_FEEDS lists "roomID:source:url" entries.
+	calendarService := calendar.NewService(reservationService,
+		outbound.NewICalFeedFetcher(&http.Client{Timeout: env.Get("SERVICE_TIMEOUT", 5*time.Second)}),
+	).WithIDGenerator(ids)
+	calendarFeeds, err := calendar.ParseFeeds(env.Get("CALENDAR_FEEDS", ""))
+	if err != nil {
+		logger.Error("failed to parse calendar feeds", "error", err)
+		os.Exit(1)
+	}
+	if len(calendarFeeds) > 0 {
+		runEvery(ctx, "calendar sync", env.Get("CALENDAR_SYNC_INTERVAL", 15*time.Minute), leader, logger, func(ctx context.Context) error {
+			reports, err := calendarService.SyncFeeds(ctx, calendarFeeds)
+			for _, report := range reports {
+				if report.Placed > 0 || report.Released > 0 || len(report.Conflicts) > 0 {
+					logger.Info("calendar feed synced", "room", report.RoomID, "source", report.Source,
+						"placed", report.Placed, "released", report.Released, "conflicts", report.Conflicts)
+				}
+			}
+			return err
+		})
+	}
+
+	// Synchronize availability with a channel manager, which distributes it to the OTAs
+	// (e.g. Booking.com, Expedia). OTA bookings arrive via the signed channel webhook.
+	var channelService *channel.Service
+	if channelManagerURL := env.Get("CHANNEL_MANAGER_URL", ""); channelManagerURL != "" {
+		channelSync := outbound.NewChannelManagerSync(channelManagerURL,
+			mustLookupSecret(ctx, secrets, "CHANNEL_MANAGER_API_KEY", "", logger),
+			&http.Client{Timeout: env.Get("SERVICE_TIMEOUT", 5*time.Second)},
+		)
+		channelService = channel.NewService(reservationService, channelSync)
+		if err := channelService.RegisterHandlers(ctx, inbound.NewConsumerGroup(dispatcher, "channel")); err != nil {
+			logger.Error("failed to register channel handlers", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Index reservation summaries into OpenSearch for the staff search by guest name or email.
+	// Each event is indexed by one replica. OPENSEARCH_REBUILD indexes the stored reservations
+	// at startup, e.g. after the index was deleted.
+	var searchService *search.Service
+	if openSearchURL := env.Get("OPENSEARCH_URL", ""); openSearchURL != "" {
+		searchIndex := outbound.NewOpenSearchIndex(openSearchURL,
+			env.Get("OPENSEARCH_INDEX", "reservations"),
+			env.Get("OPENSEARCH_USERNAME", ""),
+			mustLookupSecret(ctx, secrets, "OPENSEARCH_PASSWORD", "", logger),
+			&http.Client{Timeout: env.Get("SERVICE_TIMEOUT", 5*time.Second)},
+		)
+		if err := searchIndex.EnsureIndex(ctx); err != nil {
+			logger.Error("failed to create search index", "error", err)
+		}
+		searchService = search.NewService(reservationService, searchIndex)
+		if err := searchService.RegisterHandlers(ctx, inbound.NewConsumerGroup(dispatcher, "search")); err != nil {
+			logger.Error("failed to register search handlers", "error", err)
+			os.Exit(1)
+		}
+		if env.Get("OPENSEARCH_REBUILD", false) {
+			go func() {
+				indexed, err := searchService.Rebuild(ctx)
+				if err != nil {
+					logger.Error("failed to rebuild search index", "error", err, "indexed", indexed)
+					return
+				}
+				logger.Info("search index rebuilt", "indexed", indexed)
+			}()
+		}
+	}
+
+	// Generate the cleaning tasks of housekeeping from check-ins and check-outs.
+	// The tasks are persisted to a JSON file; each replica group handles an event once.
+	housekeepingService := housekeeping.NewService(reservationService,
+		outbound.NewFileAccess[housekeeping.TaskID, housekeeping.Task](env.Get("HOUSEKEEPING_TASKS_PATH", "housekeeping_tasks.json"), codec),
+	).WithTurnaround(env.Get("HOUSEKEEPING_TURNAROUND", 3*time.Hour))
+	if err := housekeepingService.RegisterHandlers(ctx, inbound.NewConsumerGroup(dispatcher, "housekeeping")); err != nil {
+		logger.Error("failed to register housekeeping handlers", "error", err)
+		os.Exit(1)
+	}
+
+	// Ask guests for a review after check-out, unless they opted out of "review_request"
+	// notifications. The links to the review form are signed with REVIEW_LINK_SECRET;
+	// without it, no reviews are collected.
+	var reviewService *review.Service
+	if secret := mustLookupSecret(ctx, secrets, "REVIEW_LINK_SECRET", "", logger); secret != "" {
+		reviewService = review.NewService(reservationService,
+			outbound.NewFileAccess[review.ReviewID, review.Review](env.Get("REVIEWS_PATH", "reviews.json"), codec),
+			notificationTracker, []byte(secret),
+		).WithLinkURL(env.Get("REVIEW_LINK_URL", "http://localhost:8080/api/reviews/{review_id}?token={token}")).
+			WithTTL(env.Get("REVIEW_LINK_TTL", 30*24*time.Hour)).
+			WithIDGenerator(ids)
+		if err := reviewService.RegisterHandlers(ctx, inbound.NewConsumerGroup(dispatcher, "reviews")); err != nil {
+			logger.Error("failed to register review handlers", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Initialize privacy module for data subject requests (export and erasure).
+	// Every store that keeps personal data of guests registers its eraser.
+	privacyService := buildPrivacyService(reservationService, paymentService, notificationTracker, reviewService, eventLog, recorder)
+
+	// Hold a security deposit for incidentals when a booking is confirmed. After check-out,
+	// the incidentals are captured from it, or the authorization is voided if there are none.
+	// DEPOSIT_AMOUNT is in the smallest currency unit; without it, no deposits are held.
+	var depositService *orchestration.DepositService
+	if depositAmount := env.Get("DEPOSIT_AMOUNT", 0); depositAmount > 0 {
+		depositService = orchestration.NewDepositService(reservationService, paymentService, int64(depositAmount)).
+			WithReleaseAfter(env.Get("DEPOSIT_RELEASE_AFTER", 72*time.Hour)).
+			WithIDGenerator(ids)
+		if err := depositService.RegisterHandlers(ctx, inbound.NewConsumerGroup(dispatcher, "deposits")); err != nil {
+			logger.Error("failed to register deposit handlers", "error", err)
+			os.Exit(1)
+		}
+		runEvery(ctx, "deposit releases", env.Get("DEPOSIT_RELEASE_INTERVAL", time.Hour), leader, logger, func(ctx context.Context) error {
+			released, err := depositService.ReleaseDeposits(ctx, time.Now())
+			if len(released) > 0 {
+				logger.Info("deposits released", "count", len(released))
+			}
+			return err
+		})
+	}
+
+	// Let guests book add-ons before check-in. Add-ons booked after the booking was paid
+	// are charged on their own and refunded when they are removed.
+	addOnService := orchestration.NewAddOnService(reservationService, paymentService).WithIDGenerator(ids)
+
 	// Initialize OIDC provider for MCP token verification.
 	// This connects to Keycloak to validate Bearer tokens for the MCP endpoint.
 	// Reuses the existing OIDC_ISSUER environment variable for consistency.
@@ -129,37 +1016,171 @@ func main() {
 	verifier := provider.Verifier(&oidc.Config{ClientID: mcpClientID})
 
 	// Build the MCP server with all tools registered.
-	mcpServer := buildMCPServer(reservationService, availabilityChecker, paymentService)
+	// Booking summaries carry a token signed with BOOKING_CONFIRMATION_SECRET that the
+	// confirmed booking must present. Without it, a key is generated at startup, so
+	// the tokens are only valid on this replica.
+	confirmationSecret := []byte(mustLookupSecret(ctx, secrets, "BOOKING_CONFIRMATION_SECRET", "", logger))
+	if len(confirmationSecret) == 0 {
+		logger.Warn("BOOKING_CONFIRMATION_SECRET is not set, booking confirmations are only valid on this replica")
+		confirmationSecret = []byte(security.GenerateID())
+	}
+	mcpServer := buildMCPServer(reservationService, availabilityCache, paymentService, bookingService, confirmationSecret)
+
+	// Configure TLS with certificate files or autocert, and optional mTLS for the API routes.
+	clientCAFile := env.Get("TLS_CLIENT_CA_FILE", "")
+	tlsConfig, err := buildTLSConfig(ctx,
+		env.Get("TLS_CERT_FILE", ""),
+		env.Get("TLS_KEY_FILE", ""),
+		env.Get("TLS_AUTOCERT_DOMAINS", ""),
+		env.Get("TLS_AUTOCERT_CACHE_DIR", "autocert"),
+		clientCAFile,
+		logger,
+	)
+	if err != nil {
+		logger.Error("failed to configure TLS", "error", err)
+		os.Exit(1)
+	}
+
+	// Persist login sessions in Redis or Postgres, so they survive restarts and are shared between replicas.
+	// The Postgres store uses the sessions table of the reservation database.
+	sessionStore, err := buildSessionStore(
+		env.Get("SESSION_STORE", "memory"),
+		mustLookupSecret(ctx, secrets, "REDIS_URL", "redis://localhost:6379/0", logger),
+		reservationDB.DB,
+	)
+	if err != nil {
+		logger.Error("failed to initialize session store", "error", err)
+		os.Exit(1)
+	}
+
+	// The OIDC identity provider from cloud-native-utils reads its client secret
+	// from the process environment, so a resolved secret is exported before routing.
+	if clientSecret := mustLookupSecret(ctx, secrets, "OIDC_CLIENT_SECRET", "", logger); clientSecret != "" {
+		_ = os.Setenv("OIDC_CLIENT_SECRET", clientSecret)
+	}
+
+	// Offer passwordless sign-in by email when a signing secret is configured.
+	// The sign-in links are delivered by the notification service.
+	var magicLink *inbound.MagicLinkAuth
+	if secret := mustLookupSecret(ctx, secrets, "MAGIC_LINK_SECRET", "", logger); secret != "" {
+		magicLink = inbound.NewMagicLinkAuth([]byte(secret), notificationService, env.Get("MAGIC_LINK_BASE_URL", "http://localhost:8080")).
+			WithTTL(env.Get("MAGIC_LINK_TTL", 15*time.Minute)).
+			WithRateLimit(env.Get("MAGIC_LINK_RATE_LIMIT", 5), env.Get("MAGIC_LINK_RATE_WINDOW", time.Hour)).
+			WithClientIPHeader(env.Get("MAGIC_LINK_CLIENT_IP_HEADER", "")).
+			WithLogger(logger)
+		// With a shared session store, used links and rate limits hold across replicas.
+		if sessionStore != nil {
+			magicLink.WithStore(sessionStore)
+		}
+	}
+
+	// Export the runtime, connection pool and cache metrics to Prometheus.
+	var metrics http.Handler
+	if env.Get("METRICS_ENABLED", true) {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+		registry.MustRegister(reservationDB.Collectors()...)
+		registry.MustRegister(paymentDB.Collectors()...)
+		registry.MustRegister(availabilityCache)
+		metrics = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
+
+	compression := buildCompression(
+		env.Get("COMPRESSION_ENABLED", true),
+		env.Get("COMPRESSION_MIN_SIZE", 1024),
+		env.Get("COMPRESSION_TYPES", ""),
+	)
 
 	// Create router with all dependencies via RouterConfig.
 	mux := inbound.Route(inbound.RouterConfig{
-		Ctx:                ctx,
-		EFS:                efs,
-		Logger:             logger,
-		ReservationService: reservationService,
-		MCPServer:          mcpServer,
-		Verifier:           verifier,
+		AddOnService:            addOnService,
+		AdminEmails:             adminEmails,
+		AdminService:            adminService,
+		BookingService:          bookingService,
+		CalendarFeedToken:       mustLookupSecret(ctx, secrets, "CALENDAR_FEED_TOKEN", "", logger),
+		CalendarService:         calendarService,
+		ChannelService:          channelService,
+		ChannelWebhookSecret:    []byte(mustLookupSecret(ctx, secrets, "CHANNEL_WEBHOOK_SECRET", "", logger)),
+		Compression:             compression,
+		Ctx:                     ctx,
+		DepositService:          depositService,
+		EFS:                     efs,
+		EventSimulator:          eventSimulator,
+		HousekeepingService:     housekeepingService,
+		IDGenerator:             ids,
+		InvoiceService:          invoiceService,
+		Logger:                  logger,
+		MagicLink:               magicLink,
+		NotificationDelivery:    notificationTracker,
+		NotificationPreferences: true,
+		NotificationPreview:     notificationPreview,
+		ReservationService:      reservationService,
+		ReviewService:           reviewService,
+		RoomBlocks:              true,
+		PrivacyService:          privacyService,
+		ReconciliationService:   reconciliationService,
+		RequireClientCert:       tlsConfig != nil && clientCAFile != "",
+		SagaTracker:             sagaTracker,
+		SearchService:           searchService,
+		SessionStore:            sessionStore,
+		SessionTTL:              env.Get("SESSION_TTL", 24*time.Hour),
+		StartupProbe:            startupProbe,
+		StaticMaxAge:            env.Get("STATIC_MAX_AGE", time.Duration(0)),
+		MCPServer:               mcpServer,
+		Metrics:                 metrics,
+		PaymentMethods:          true,
+		PaymentService:          paymentService,
+		PaymentWebhookSecret:    []byte(mustLookupSecret(ctx, secrets, "PAYMENT_WEBHOOK_SECRET", "", logger)),
+		Verifier:                verifier,
 	})
 
-	srv := web.NewServer(mux)
+	srv := buildServer(mux,
+		env.Get("SERVER_MAX_HEADER_BYTES", 1<<20),
+		env.Get("SERVER_KEEP_ALIVES", true),
+		env.Get("SERVER_H2C", false),
+	)
+	srv.TLSConfig = tlsConfig
 	defer func() { _ = srv.Close() }()
 
 	// Register the server shutdown function on the context done function.
 	// We use the RegisterOnContextDone function from the cloud-native-utils/service package.
-	// The server.Shutdown function waits for 5 seconds before shutting down the server.
+	// The server.Shutdown function drains open connections for up to SERVER_SHUTDOWN_TIMEOUT,
+	// while the event handlers in flight finish within the same timeout.
+	shutdownTimeout := env.Get("SERVER_SHUTDOWN_TIMEOUT", 5*time.Second)
+	stopped := make(chan struct{})
 	service.RegisterOnContextDone(ctx, func() {
-		_ = srv.Shutdown(context.Background())
+		defer close(stopped)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+		if err := draining.Drain(shutdownCtx); err != nil {
+			logger.Warn("event handlers still running at shutdown", "error", err)
+		}
+		keyed.Close()
+		if batching != nil {
+			if err := batching.Close(shutdownCtx); err != nil {
+				logger.Warn("events still queued at shutdown", "error", err)
+			}
+		}
+		_ = kafkaDispatcher.Close()
 	})
 
 	// The server implementation from the cloud-native-utils/web package uses
 	// It uses the PORT environment variable to determine the port to listen on.
 	// If the PORT environment variable is not set, it defaults to port 8080.
-	logger.Info("server initialized", "port", env.Get("PORT", "8080"))
+	logger.Info("server initialized", "port", env.Get("PORT", "8080"), "tls", tlsConfig != nil)
 
 	// Start the HTTP server in the main goroutine.
-	if err := srv.ListenAndServe(); err != nil {
+	// With TLS, certificates come from the TLS config, so no files are passed here.
+	serve := srv.ListenAndServe
+	if tlsConfig != nil {
+		serve = func() error { return srv.ListenAndServeTLS("", "") }
+	}
+	if err := serve(); err != nil {
 		// Check if the server was closed intentionally.
 		if err == http.ErrServerClosed {
+			// Wait for the shutdown to drain the connections and event handlers.
+			<-stopped
 			logger.Error("server closed", "reason", "server closed intentionally")
 			return
 		}
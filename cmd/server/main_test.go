@@ -66,6 +66,16 @@ func (m *mockReservationRepository) ReadAll(ctx context.Context) ([]reservation.
 	return result, nil
 }
 
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	var result []reservation.Reservation
+	for _, res := range m.reservations {
+		if res.GuestID == guestID {
+			result = append(result, res)
+		}
+	}
+	return result, nil
+}
+
 func createBenchReservationService() *reservation.Service {
 	reservationRepo := newMockReservationRepository()
 	availabilityChecker := outbound.NewRepositoryAvailabilityChecker(reservationRepo)
@@ -154,9 +164,10 @@ func Benchmark_Server_Integration_MCP_Tools_List_Should_Be_Fast(b *testing.B) {
 	reservationService := createBenchReservationService()
 	paymentService := createBenchPaymentService()
 	availabilityChecker := outbound.NewRepositoryAvailabilityChecker(newMockReservationRepository())
+	bookingService := orchestration.NewBookingService(reservationService, paymentService, outbound.NewMockNotificationService(logger))
 
 	// Build MCP server with tools registered.
-	mcpServer := buildMCPServer(reservationService, availabilityChecker, paymentService)
+	mcpServer := buildMCPServer(reservationService, availabilityChecker, paymentService, bookingService, []byte("secret"))
 
 	mux := inbound.Route(inbound.RouterConfig{
 		Ctx:                ctx,
@@ -203,7 +214,7 @@ func benchValidDateRange() reservation.DateRange {
 
 func benchValidGuests() []reservation.GuestInfo {
 	return []reservation.GuestInfo{
-		reservation.NewGuestInfo("John Doe", "john@example.com", "+1234567890"),
+		{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+1234567890"},
 	}
 }
 
@@ -306,6 +317,16 @@ func (m *mockPaymentRepository) ReadAll(ctx context.Context) ([]payment.Payment,
 	return result, nil
 }
 
+func (m *mockPaymentRepository) FindByReservationID(ctx context.Context, reservationID payment.ReservationID) ([]payment.Payment, error) {
+	var result []payment.Payment
+	for _, p := range m.payments {
+		if p.ReservationID == reservationID {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
 // mockPaymentGateway for benchmarking (instant responses)
 type mockPaymentGateway struct{}
 
@@ -321,6 +342,10 @@ func (m *mockPaymentGateway) Refund(ctx context.Context, transactionID string, a
 	return nil
 }
 
+func (m *mockPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	return nil
+}
+
 func createBenchPaymentService() *payment.Service {
 	paymentRepo := newMockPaymentRepository()
 	gateway := &mockPaymentGateway{}
@@ -385,7 +410,7 @@ func (m *mockNotificationService) SendCancellationNotice(ctx context.Context, r
 	return nil
 }
 
-func (m *mockNotificationService) SendPaymentReceipt(ctx context.Context, p *payment.Payment) error {
+func (m *mockNotificationService) SendPaymentReceipt(ctx context.Context, p *payment.Payment, attachments ...orchestration.Attachment) error {
 	return nil
 }
 
@@ -0,0 +1,236 @@
+package inbound
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Compression configures the content-negotiated compression of responses.
+// Brotli is preferred over gzip if the client accepts both.
+type Compression struct {
+	minSize int
+	types   []string
+}
+
+// NewCompression creates a new compression configuration. By default, HTML, JSON and
+// server-sent events of at least 1 KiB are compressed.
+func NewCompression() *Compression {
+	return &Compression{
+		minSize: 1024,
+		types:   []string{"text/html", "application/json", "text/event-stream"},
+	}
+}
+
+// WithMinSize sets the size below which responses are sent uncompressed,
+// because the compression would cost more than it saves.
+func (c *Compression) WithMinSize(size int) *Compression {
+	c.minSize = size
+	return c
+}
+
+// WithTypes sets the media types that are compressed, e.g. "text/html".
+func (c *Compression) WithTypes(types ...string) *Compression {
+	c.types = types
+	return c
+}
+
+// compresses reports whether responses of the content type are compressed.
+func (c *Compression) compresses(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(c.types, mediaType)
+}
+
+// brotliLevel trades the compression ratio of brotli for speed, since responses
+// are compressed on every request. Higher levels cost far more CPU for little gain.
+const brotliLevel = 4
+
+// brotliWriters and gzipWriters reuse the writers, which allocate large buffers.
+var (
+	brotliWriters = sync.Pool{
+		New: func() any { return brotli.NewWriterLevel(io.Discard, brotliLevel) },
+	}
+	gzipWriters = sync.Pool{
+		New: func() any { return gzip.NewWriter(io.Discard) },
+	}
+)
+
+// WithCompression compresses the responses of next with the encoding the client
+// prefers. Responses that are already encoded (e.g. the static assets), too small or
+// of other content types are sent as they are. Flushed responses, such as
+// server-sent events, are compressed regardless of their size and flushed through.
+func WithCompression(c *Compression, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Range") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, config: c, encoding: encoding, status: http.StatusOK}
+		defer func() { _ = cw.Close() }()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding returns "br", "gzip" or "" from an Accept-Encoding header.
+func negotiateEncoding(header string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+		accepted[strings.ToLower(strings.TrimSpace(name))] = quality > 0
+	}
+	switch {
+	case accepted["br"]:
+		return "br"
+	case accepted["gzip"], accepted["*"]:
+		return "gzip"
+	default:
+		return ""
+	}
+}
+
+// compressWriter buffers the start of a response until it is large enough to be
+// compressed, then decides once whether to compress it.
+type compressWriter struct {
+	http.ResponseWriter
+	config   *Compression
+	encoding string
+	status   int
+	buf      []byte
+	decided  bool
+	encoder  io.WriteCloser
+}
+
+// WriteHeader records the status code until the response is decided.
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		return
+	}
+	w.status = code
+	if code == http.StatusNoContent || code == http.StatusNotModified {
+		w.decide(false)
+	}
+}
+
+// Write buffers the body until the minimum size is reached.
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, p...)
+		if len(w.buf) < w.config.minSize {
+			return len(p), nil
+		}
+		if err := w.decide(false); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush sends the buffered body, compressed if its content type is, and flushes
+// the encoder, so each server-sent event reaches the client at once.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(true)
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the original writer for http.ResponseController, e.g. to clear
+// the write deadline of an event stream.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Close sends a response that stayed below the minimum size and finishes the encoding.
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		if err := w.decide(false); err != nil {
+			return err
+		}
+	}
+	if w.encoder == nil {
+		return nil
+	}
+	err := w.encoder.Close()
+	switch encoder := w.encoder.(type) {
+	case *brotli.Writer:
+		brotliWriters.Put(encoder)
+	case *gzip.Writer:
+		gzipWriters.Put(encoder)
+	}
+	return err
+}
+
+// decide writes the header and the buffered body. The body is compressed if the
+// content type is allowed, it is not encoded yet, and it reached the minimum size
+// or is flushed (streamed).
+func (w *compressWriter) decide(streaming bool) error {
+	w.decided = true
+	header := w.Header()
+	if header.Get("Content-Type") == "" && len(w.buf) > 0 {
+		header.Set("Content-Type", http.DetectContentType(w.buf))
+	}
+
+	eligible := header.Get("Content-Encoding") == "" &&
+		w.status != http.StatusNoContent && w.status != http.StatusNotModified &&
+		w.config.compresses(header.Get("Content-Type"))
+	if eligible {
+		header.Add("Vary", "Accept-Encoding")
+	}
+	if eligible && (streaming || len(w.buf) >= w.config.minSize) {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		w.encoder = w.newEncoder()
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// newEncoder creates the encoder of the negotiated encoding.
+func (w *compressWriter) newEncoder() io.WriteCloser {
+	if w.encoding == "br" {
+		br := brotliWriters.Get().(*brotli.Writer)
+		br.Reset(w.ResponseWriter)
+		return br
+	}
+	gz := gzipWriters.Get().(*gzip.Writer)
+	gz.Reset(w.ResponseWriter)
+	return gz
+}
@@ -0,0 +1,186 @@
+package inbound_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Compression Tests
+// ============================================================================
+
+func compressionTestHandler(contentType, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = io.WriteString(w, body)
+	})
+}
+
+func Test_WithCompression_With_Gzip_Should_Compress_HTML(t *testing.T) {
+	// Arrange
+	body := strings.Repeat("<p>Hotel Booking</p>", 100)
+	handler := inbound.WithCompression(inbound.NewCompression(), compressionTestHandler("text/html; charset=utf-8", body))
+	req := httptest.NewRequest(http.MethodGet, "/ui/login", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be gzip", rec.Header().Get("Content-Encoding"), "gzip")
+	assert.That(t, "vary must be set", rec.Header().Get("Vary"), "Accept-Encoding")
+	reader, err := gzip.NewReader(rec.Body)
+	assert.That(t, "body must be gzip", err == nil, true)
+	decoded, _ := io.ReadAll(reader)
+	assert.That(t, "body must match", string(decoded), body)
+}
+
+func Test_WithCompression_With_Brotli_Should_Prefer_Brotli(t *testing.T) {
+	// Arrange
+	body := strings.Repeat(`{"reservation_id":"res-001"}`, 100)
+	handler := inbound.WithCompression(inbound.NewCompression(), compressionTestHandler("application/json", body))
+	req := httptest.NewRequest(http.MethodGet, "/api/reconciliation/report", nil)
+	req.Header.Set("Accept-Encoding", "gzip, br")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be br", rec.Header().Get("Content-Encoding"), "br")
+	decoded, _ := io.ReadAll(brotli.NewReader(rec.Body))
+	assert.That(t, "body must match", string(decoded), body)
+}
+
+func Test_WithCompression_Below_Min_Size_Should_Not_Compress(t *testing.T) {
+	// Arrange
+	handler := inbound.WithCompression(inbound.NewCompression(), compressionTestHandler("text/html", "<p>OK</p>"))
+	req := httptest.NewRequest(http.MethodGet, "/ui/login", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be empty", rec.Header().Get("Content-Encoding"), "")
+	assert.That(t, "body must be plain", rec.Body.String(), "<p>OK</p>")
+}
+
+func Test_WithCompression_With_Other_Type_Should_Not_Compress(t *testing.T) {
+	// Arrange
+	body := strings.Repeat("%PDF", 1000)
+	handler := inbound.WithCompression(inbound.NewCompression(), compressionTestHandler("application/pdf", body))
+	req := httptest.NewRequest(http.MethodGet, "/api/reservations/res-001/invoice.pdf", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be empty", rec.Header().Get("Content-Encoding"), "")
+	assert.That(t, "body must be plain", rec.Body.String(), body)
+}
+
+func Test_WithCompression_With_Refused_Encodings_Should_Not_Compress(t *testing.T) {
+	// Arrange
+	body := strings.Repeat("<p>Hotel Booking</p>", 100)
+	handler := inbound.WithCompression(inbound.NewCompression(), compressionTestHandler("text/html", body))
+	req := httptest.NewRequest(http.MethodGet, "/ui/login", nil)
+	req.Header.Set("Accept-Encoding", "br;q=0, gzip;q=0, identity")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be empty", rec.Header().Get("Content-Encoding"), "")
+	assert.That(t, "body must be plain", rec.Body.String(), body)
+}
+
+func Test_WithCompression_With_Flushed_Event_Stream_Should_Compress_Each_Event(t *testing.T) {
+	// Arrange
+	handler := inbound.WithCompression(inbound.NewCompression(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "event: saga\ndata: pending\n\n")
+		w.(http.Flusher).Flush()
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/ui/bookings/res-001/status/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be gzip", rec.Header().Get("Content-Encoding"), "gzip")
+	assert.That(t, "response must be flushed", rec.Flushed, true)
+	reader, _ := gzip.NewReader(rec.Body)
+	decoded, _ := io.ReadAll(reader)
+	assert.That(t, "event must match", string(decoded), "event: saga\ndata: pending\n\n")
+}
+
+func Test_WithCompression_With_Encoded_Response_Should_Not_Compress_Again(t *testing.T) {
+	// Arrange
+	handler := inbound.WithCompression(inbound.NewCompression().WithMinSize(0), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = io.WriteString(w, "already compressed")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/static/css/base.css", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "body must be unchanged", rec.Body.String(), "already compressed")
+}
+
+// ============================================================================
+// Compression Benchmarks
+// ============================================================================
+
+func benchmarkCompressedLogin(b *testing.B, acceptEncoding string) {
+	b.Setenv("APP_NAME", "TestApp")
+	b.Setenv("APP_DESCRIPTION", strings.Repeat("Hotel reservation and payment management system. ", 40))
+
+	e := templating.NewEngine(loginTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.WithCompression(inbound.NewCompression(), inbound.HttpViewLogin(e, true))
+	req := httptest.NewRequest(http.MethodGet, "/ui/login", nil)
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+
+	b.ReportAllocs()
+	var size int
+	for b.Loop() {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		size = rec.Body.Len()
+	}
+	b.ReportMetric(float64(size), "bytes/response")
+}
+
+func Benchmark_WithCompression_Login_Identity(b *testing.B) {
+	benchmarkCompressedLogin(b, "identity")
+}
+
+func Benchmark_WithCompression_Login_Gzip(b *testing.B) {
+	benchmarkCompressedLogin(b, "gzip")
+}
+
+func Benchmark_WithCompression_Login_Brotli(b *testing.B) {
+	benchmarkCompressedLogin(b, "br")
+}
@@ -0,0 +1,41 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+)
+
+// GroupSubscriber is implemented by dispatchers that support consumer groups.
+// Each message of a topic is handled by one subscriber of a group.
+type GroupSubscriber interface {
+	SubscribeGroup(ctx context.Context, group, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error
+}
+
+// ConsumerGroup is a messaging.Dispatcher that subscribes the handlers of a bounded
+// context in the context's consumer group. With several replicas, each event is then
+// handled once by the context instead of once per replica.
+// Dispatchers without consumer groups (e.g. the internal dispatcher) subscribe as usual.
+type ConsumerGroup struct {
+	dispatcher messaging.Dispatcher
+	group      string
+}
+
+// NewConsumerGroup creates a new consumer group of the dispatcher, e.g. "orchestration".
+func NewConsumerGroup(dispatcher messaging.Dispatcher, group string) *ConsumerGroup {
+	return &ConsumerGroup{dispatcher: dispatcher, group: group}
+}
+
+// Publish publishes a message with the dispatcher.
+func (g *ConsumerGroup) Publish(ctx context.Context, message messaging.Message) error {
+	return g.dispatcher.Publish(ctx, message)
+}
+
+// Subscribe subscribes the handler in the consumer group.
+func (g *ConsumerGroup) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	if subscriber, ok := g.dispatcher.(GroupSubscriber); ok {
+		return subscriber.SubscribeGroup(ctx, g.group, topic, fn)
+	}
+	return g.dispatcher.Subscribe(ctx, topic, fn)
+}
@@ -0,0 +1,85 @@
+package inbound_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// groupRecordingDispatcher records the groups of the subscriptions.
+type groupRecordingDispatcher struct {
+	messaging.Dispatcher
+	groups map[string]string
+}
+
+func newGroupRecordingDispatcher() *groupRecordingDispatcher {
+	return &groupRecordingDispatcher{Dispatcher: messaging.NewInternalDispatcher(), groups: make(map[string]string)}
+}
+
+func (d *groupRecordingDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	d.groups[topic] = ""
+	return d.Dispatcher.Subscribe(ctx, topic, fn)
+}
+
+func (d *groupRecordingDispatcher) SubscribeGroup(ctx context.Context, group, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	d.groups[topic] = group
+	return d.Dispatcher.Subscribe(ctx, topic, fn)
+}
+
+func completed(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	return messaging.MessageStateCompleted, nil
+}
+
+// ============================================================================
+// ConsumerGroup Tests
+// ============================================================================
+
+func Test_ConsumerGroup_Subscribe_Should_Join_The_Group(t *testing.T) {
+	// Arrange
+	dispatcher := newGroupRecordingDispatcher()
+	group := inbound.NewConsumerGroup(dispatcher, "orchestration")
+
+	// Act
+	err := group.Subscribe(context.Background(), "reservation.created", completed)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "subscription must use the group", dispatcher.groups["reservation.created"], "orchestration")
+}
+
+func Test_ConsumerGroup_Without_Group_Support_Should_Subscribe_As_Usual(t *testing.T) {
+	// Arrange
+	group := inbound.NewConsumerGroup(messaging.NewInternalDispatcher(), "orchestration")
+	called := false
+	_ = group.Subscribe(context.Background(), "reservation.created", func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		called = true
+		return messaging.MessageStateCompleted, nil
+	})
+
+	// Act
+	err := group.Publish(context.Background(), messaging.NewMessage("reservation.created", nil))
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "handler must be called", called, true)
+}
+
+func Test_ConsumerGroup_Over_DrainingDispatcher_Should_Keep_The_Group(t *testing.T) {
+	// Arrange
+	dispatcher := newGroupRecordingDispatcher()
+	group := inbound.NewConsumerGroup(inbound.NewDrainingDispatcher(dispatcher), "channel")
+
+	// Act
+	_ = group.Subscribe(context.Background(), "reservation.confirmed", completed)
+
+	// Assert
+	assert.That(t, "subscription must use the group", dispatcher.groups["reservation.confirmed"], "channel")
+}
@@ -0,0 +1,52 @@
+package inbound
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// CorrelationIDHeader is the request and response header of the correlation ID.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// maxCorrelationIDLength limits the correlation IDs accepted from clients.
+const maxCorrelationIDLength = 64
+
+// WithCorrelationID adds a correlation ID to the context and the response headers of each request.
+// The ID of the X-Correlation-ID request header is kept, e.g. from a proxy or another service;
+// otherwise a new one is created. Error responses include it, so support requests can be traced,
+// and so do the events published while handling the request (see shared.WithCorrelationID).
+func WithCorrelationID(ids shared.IDGenerator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(CorrelationIDHeader)
+		if !validCorrelationID(id) {
+			id = ids.NewID()
+		}
+		w.Header().Set(CorrelationIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(shared.WithCorrelationID(r.Context(), id)))
+	})
+}
+
+// CorrelationID returns the correlation ID added by WithCorrelationID or TracingDispatcher,
+// or an empty string.
+func CorrelationID(ctx context.Context) string {
+	return shared.CorrelationID(ctx)
+}
+
+// validCorrelationID reports whether a client's correlation ID is safe to log and echo:
+// at most 64 letters, digits, dashes, underscores, dots and colons.
+func validCorrelationID(id string) bool {
+	if id == "" || len(id) > maxCorrelationIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
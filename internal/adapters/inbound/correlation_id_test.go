@@ -0,0 +1,56 @@
+package inbound_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// WithCorrelationID Tests
+// ============================================================================
+
+func serveCorrelationID(header string) (string, *httptest.ResponseRecorder) {
+	var got string
+	handler := inbound.WithCorrelationID(shared.NewUUIDv7Generator(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = inbound.CorrelationID(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
+	if header != "" {
+		req.Header.Set(inbound.CorrelationIDHeader, header)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return got, rec
+}
+
+func Test_WithCorrelationID_Without_Header_Should_Create_ID(t *testing.T) {
+	// Arrange & Act
+	id, rec := serveCorrelationID("")
+
+	// Assert
+	assert.That(t, "correlation ID must not be empty", id != "", true)
+	assert.That(t, "response header must contain the ID", rec.Header().Get(inbound.CorrelationIDHeader), id)
+}
+
+func Test_WithCorrelationID_With_Header_Should_Keep_ID(t *testing.T) {
+	// Arrange & Act
+	id, rec := serveCorrelationID("proxy-4711")
+
+	// Assert
+	assert.That(t, "correlation ID must be kept", id, "proxy-4711")
+	assert.That(t, "response header must contain the ID", rec.Header().Get(inbound.CorrelationIDHeader), "proxy-4711")
+}
+
+func Test_WithCorrelationID_With_Invalid_Header_Should_Create_ID(t *testing.T) {
+	// Arrange & Act
+	id, _ := serveCorrelationID("<script>" + strings.Repeat("x", 64))
+
+	// Assert
+	assert.That(t, "invalid correlation ID must be replaced", strings.Contains(id, "script"), false)
+}
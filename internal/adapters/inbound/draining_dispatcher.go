@@ -0,0 +1,85 @@
+package inbound
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+)
+
+// ErrDraining is returned for messages that arrive after draining has started.
+var ErrDraining = errors.New("dispatcher is draining")
+
+// DrainingDispatcher decorates a messaging.Dispatcher so that a shutdown does not
+// abort the messages in flight. The consumers stop reading when the subscription
+// context is done (SIGTERM), while the handlers of messages already received keep
+// running with a context that is not cancelled. Drain waits for them to finish.
+type DrainingDispatcher struct {
+	dispatcher messaging.Dispatcher
+	mutex      sync.Mutex
+	draining   bool
+	inflight   sync.WaitGroup
+}
+
+// NewDrainingDispatcher creates a new draining dispatcher.
+func NewDrainingDispatcher(dispatcher messaging.Dispatcher) *DrainingDispatcher {
+	return &DrainingDispatcher{dispatcher: dispatcher}
+}
+
+// Publish publishes a message with the decorated dispatcher.
+func (d *DrainingDispatcher) Publish(ctx context.Context, message messaging.Message) error {
+	return d.dispatcher.Publish(ctx, message)
+}
+
+// Subscribe subscribes the handler with the decorated dispatcher and tracks its calls.
+func (d *DrainingDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return d.dispatcher.Subscribe(ctx, topic, d.track(fn))
+}
+
+// SubscribeGroup subscribes the handler in a consumer group and tracks its calls.
+// Without consumer groups in the decorated dispatcher, it subscribes as usual.
+func (d *DrainingDispatcher) SubscribeGroup(ctx context.Context, group, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	if subscriber, ok := d.dispatcher.(GroupSubscriber); ok {
+		return subscriber.SubscribeGroup(ctx, group, topic, d.track(fn))
+	}
+	return d.dispatcher.Subscribe(ctx, topic, d.track(fn))
+}
+
+// track counts the calls of the handler in flight and rejects messages while draining.
+func (d *DrainingDispatcher) track(fn service.Function[messaging.Message, messaging.MessageState]) service.Function[messaging.Message, messaging.MessageState] {
+	return func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		d.mutex.Lock()
+		if d.draining {
+			d.mutex.Unlock()
+			return messaging.MessageStateFailed, ErrDraining
+		}
+		d.inflight.Add(1)
+		d.mutex.Unlock()
+		defer d.inflight.Done()
+
+		return fn(context.WithoutCancel(ctx), msg)
+	}
+}
+
+// Drain rejects new messages and waits until the handlers in flight have finished
+// or the context is done.
+func (d *DrainingDispatcher) Drain(ctx context.Context) error {
+	d.mutex.Lock()
+	d.draining = true
+	d.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
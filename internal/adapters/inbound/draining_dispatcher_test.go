@@ -0,0 +1,92 @@
+package inbound_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// DrainingDispatcher Tests
+// ============================================================================
+
+func Test_DrainingDispatcher_Drain_Should_Wait_For_Handlers_In_Flight(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	dispatcher := inbound.NewDrainingDispatcher(messaging.NewInternalDispatcher())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handlerErr error
+	_ = dispatcher.Subscribe(ctx, "test.topic", func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		close(started)
+		<-release
+		handlerErr = ctx.Err()
+		return messaging.MessageStateCompleted, nil
+	})
+	go func() { _ = dispatcher.Publish(ctx, messaging.NewMessage("test.topic", nil)) }()
+	<-started
+
+	// Act
+	cancel()
+	drained := make(chan error)
+	go func() { drained <- dispatcher.Drain(context.Background()) }()
+	select {
+	case <-drained:
+		t.Fatal("drain must wait for the handler")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	err := <-drained
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "handler context must not be cancelled", handlerErr == nil, true)
+}
+
+func Test_DrainingDispatcher_After_Drain_Should_Reject_New_Messages(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := inbound.NewDrainingDispatcher(messaging.NewInternalDispatcher())
+	called := false
+	_ = dispatcher.Subscribe(ctx, "test.topic", service.Wrap(func(msg messaging.Message) (messaging.MessageState, error) {
+		called = true
+		return messaging.MessageStateCompleted, nil
+	}))
+	_ = dispatcher.Drain(ctx)
+
+	// Act
+	err := dispatcher.Publish(ctx, messaging.NewMessage("test.topic", nil))
+
+	// Assert
+	assert.That(t, "handler must not be called", called, false)
+	assert.That(t, "err must be ErrDraining", errors.Is(err, inbound.ErrDraining), true)
+}
+
+func Test_DrainingDispatcher_Drain_When_Timeout_Expires_Should_Return_Error(t *testing.T) {
+	// Arrange
+	dispatcher := inbound.NewDrainingDispatcher(messaging.NewInternalDispatcher())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	_ = dispatcher.Subscribe(context.Background(), "test.topic", func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		close(started)
+		<-release
+		return messaging.MessageStateCompleted, nil
+	})
+	go func() { _ = dispatcher.Publish(context.Background(), messaging.NewMessage("test.topic", nil)) }()
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := dispatcher.Drain(ctx)
+
+	// Assert
+	assert.That(t, "err must be a deadline error", errors.Is(err, context.DeadlineExceeded), true)
+}
@@ -0,0 +1,64 @@
+package inbound
+
+import "strings"
+
+// FormField is the view data of a form input, rendered by the form_field template.
+// The template links the label and input by ID, describes the input by its hint and
+// error message (aria-describedby) and marks inputs with an error as aria-invalid.
+type FormField struct {
+	ID           string
+	Label        string
+	Type         string // Input type, e.g. "email"; "select" renders the options
+	Value        string // Submitted value, echoed when the form is rendered again
+	Required     bool
+	Autocomplete string
+	Placeholder  string
+	Min          string
+	Hint         string
+	Error        string
+	Options      []FormOption
+}
+
+// FormOption is an option of a select field.
+type FormOption struct {
+	Value    string
+	Label    string
+	Selected bool
+}
+
+// HintID returns the ID of the hint element.
+func (f FormField) HintID() string {
+	return f.ID + "-hint"
+}
+
+// ErrorID returns the ID of the error element.
+func (f FormField) ErrorID() string {
+	return f.ID + "-error"
+}
+
+// DescribedBy returns the aria-describedby value of the input: the IDs of
+// the hint and the error message, if any.
+func (f FormField) DescribedBy() string {
+	var ids []string
+	if f.Hint != "" {
+		ids = append(ids, f.HintID())
+	}
+	if f.Error != "" {
+		ids = append(ids, f.ErrorID())
+	}
+	return strings.Join(ids, " ")
+}
+
+// FormFields holds the fields of a form by input name, e.g. {{ template "form_field" .Fields.guest_email }}.
+type FormFields map[string]FormField
+
+// withErrors returns the fields with the messages of fieldErrors, keyed by input name.
+func (fields FormFields) withErrors(fieldErrors map[string]string) FormFields {
+	for name, msg := range fieldErrors {
+		if field, ok := fields[name]; ok {
+			field.Error = msg
+			fields[name] = field
+		}
+	}
+	return fields
+}
@@ -0,0 +1,34 @@
+package inbound_test
+
+import (
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// FormField Tests
+// ============================================================================
+
+func Test_FormField_DescribedBy_With_Hint_And_Error_Should_Return_Both_IDs(t *testing.T) {
+	// Arrange
+	field := inbound.FormField{ID: "guest_phone", Hint: "International format", Error: "invalid phone number"}
+
+	// Act
+	describedBy := field.DescribedBy()
+
+	// Assert
+	assert.That(t, "ids must be hint and error", describedBy, "guest_phone-hint guest_phone-error")
+}
+
+func Test_FormField_DescribedBy_Without_Hint_Or_Error_Should_Be_Empty(t *testing.T) {
+	// Arrange
+	field := inbound.FormField{ID: "guest_name"}
+
+	// Act
+	describedBy := field.DescribedBy()
+
+	// Assert
+	assert.That(t, "ids must be empty", describedBy, "")
+}
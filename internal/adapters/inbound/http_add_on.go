@@ -0,0 +1,58 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// AddOnRequest is the payload of an add-on booking.
+type AddOnRequest struct {
+	Kind string `json:"kind"` // breakfast, parking or late_checkout
+}
+
+// HttpAddAddOn handles POST /api/reservations/{id}/add-ons.
+// It responds with the reservation and its recalculated total.
+func HttpAddAddOn(addOnService *orchestration.AddOnService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req AddOnRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+		kind, err := reservation.ParseAddOnKind(req.Kind)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to book add-on")
+			return
+		}
+
+		res, err := addOnService.AddAddOn(r.Context(), reservation.ReservationID(r.PathValue("id")), kind, time.Now())
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to book add-on")
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, res)
+	}
+}
+
+// HttpRemoveAddOn handles DELETE /api/reservations/{id}/add-ons/{addOn}.
+// It responds with the reservation and its recalculated total.
+func HttpRemoveAddOn(addOnService *orchestration.AddOnService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := addOnService.RemoveAddOn(r.Context(),
+			reservation.ReservationID(r.PathValue("id")),
+			reservation.AddOnID(r.PathValue("addOn")),
+			time.Now(),
+		)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to remove add-on")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, res)
+	}
+}
@@ -0,0 +1,102 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// createAddOnTestService returns an add-on service with the confirmed reservation res-001,
+// which offers breakfast at 15.00 USD per guest and night.
+func createAddOnTestService(t *testing.T) *orchestration.AddOnService {
+	t.Helper()
+	ctx := context.Background()
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	reservationRepo := newMockReservationRepository()
+	reservationService := reservation.NewService(reservationRepo, outbound.NewRepositoryAvailabilityChecker(reservationRepo), publisher).
+		WithAddOnPricing(reservation.NewAddOnPricing().WithPrice(reservation.AddOnBreakfast, payment.NewMoney(1500, "USD")))
+	paymentRepo := outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]())
+	paymentService := payment.NewService(paymentRepo, outbound.NewMockPaymentGateway(), publisher)
+
+	checkIn := time.Now().AddDate(0, 0, 7)
+	guests := []reservation.GuestInfo{{Name: "Test Guest", Email: "guest@example.com"}}
+	_, err := reservationService.CreateReservation(ctx, "res-001", "guest@example.com", "room-101",
+		reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2)), payment.NewMoney(19800, "USD"), guests)
+	assert.That(t, "reservation must be created", err == nil, true)
+	assert.That(t, "reservation must be confirmed", reservationService.ConfirmReservation(ctx, "res-001"), nil)
+	return orchestration.NewAddOnService(reservationService, paymentService)
+}
+
+func newAddOnRequest(id, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/reservations/"+id+"/add-ons", strings.NewReader(body))
+	req.SetPathValue("id", id)
+	return req
+}
+
+// ============================================================================
+// HttpAddAddOn Tests
+// ============================================================================
+
+func Test_HttpAddAddOn_Should_Return_Recalculated_Reservation(t *testing.T) {
+	// Arrange
+	service := createAddOnTestService(t)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAddAddOn(service)(rec, newAddOnRequest("res-001", `{"kind":"breakfast"}`))
+
+	// Assert
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	var res reservation.Reservation
+	_ = json.Unmarshal(rec.Body.Bytes(), &res)
+	assert.That(t, "total must include two breakfasts", res.TotalAmount, payment.NewMoney(22800, "USD"))
+	assert.That(t, "add-on must be booked", len(res.AddOns), 1)
+}
+
+func Test_HttpAddAddOn_With_Unknown_Kind_Should_Return_400(t *testing.T) {
+	// Arrange
+	service := createAddOnTestService(t)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAddAddOn(service)(rec, newAddOnRequest("res-001", `{"kind":"spa"}`))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// HttpRemoveAddOn Tests
+// ============================================================================
+
+func Test_HttpRemoveAddOn_Without_Add_On_Should_Return_404(t *testing.T) {
+	// Arrange
+	service := createAddOnTestService(t)
+	req := httptest.NewRequest(http.MethodDelete, "/api/reservations/res-001/add-ons/addon-999", nil)
+	req.SetPathValue("id", "res-001")
+	req.SetPathValue("addOn", "addon-999")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpRemoveAddOn(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
@@ -0,0 +1,384 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/admin"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
+)
+
+// Panels of the admin dashboard. Each panel is rendered by the template "admin_<panel>".
+const (
+	AdminPanelMovements     = "movements"
+	AdminPanelPayments      = "payments"
+	AdminPanelCompensations = "compensations"
+	AdminPanelEvents        = "events"
+	AdminPanelIndexes       = "indexes"
+)
+
+// adminPanels lists the panels in the order of the dashboard.
+var adminPanels = []string{AdminPanelMovements, AdminPanelPayments, AdminPanelCompensations, AdminPanelEvents, AdminPanelIndexes}
+
+// AdminReservationItem represents an arriving or departing reservation.
+type AdminReservationItem struct {
+	ID          string
+	RoomID      string
+	Guest       string
+	Dates       string
+	Status      string
+	StatusClass string
+	CanCheckIn  bool
+}
+
+// AdminPaymentItem represents a payment that is not captured yet.
+type AdminPaymentItem struct {
+	ID            string
+	ReservationID string
+	Amount        string
+	Status        string
+	StatusClass   string
+	CreatedAt     string
+}
+
+// AdminCompensationItem represents a failed compensation waiting for a retry.
+type AdminCompensationItem struct {
+	ReservationID string
+	Action        string
+	Reason        string
+	LastError     string
+	Attempts      int
+	UpdatedAt     string
+}
+
+// AdminEventItem represents a recent domain event.
+type AdminEventItem struct {
+	Topic         string
+	ReservationID string
+	CorrelationID string
+	ReceivedAt    string
+	Latency       string
+	Failed        bool
+	Handlers      []AdminHandlerItem
+}
+
+// AdminHandlerItem represents the outcome of a handler of an event.
+type AdminHandlerItem struct {
+	Name     string
+	Failed   bool
+	Error    string
+	Duration string
+}
+
+// AdminIndexItem represents the health of a lookup index.
+type AdminIndexItem struct {
+	Name    string
+	Entries int
+	Healthy bool
+	Status  string
+	Issues  []string
+}
+
+// HttpViewAdminResponse specifies the view data for the admin dashboard and its panels.
+type HttpViewAdminResponse struct {
+	AppName             string
+	Title               string
+	SessionID           string
+	I18n                *i18n.Localizer
+	Theme               reservation.Theme
+	Today               string
+	Arrivals            []AdminReservationItem
+	Departures          []AdminReservationItem
+	PendingPayments     []AdminPaymentItem
+	FailedCompensations []AdminCompensationItem
+	RecentEvents        []AdminEventItem
+	Indexes             []AdminIndexItem
+	CorrelationID       string
+}
+
+// WithAdmin restricts a handler to the staff members with the given email addresses.
+// It must run after WithSessionContext, which provides the email of the signed-in user.
+func WithAdmin(emails []string, next http.HandlerFunc) http.HandlerFunc {
+	allowed := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			allowed[email] = true
+		}
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		email := SessionFromContext(r.Context()).Email
+		if email == "" {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+		if !allowed[strings.ToLower(email)] {
+			writeProblem(w, r, http.StatusForbidden, "Access denied")
+			return
+		}
+		next.ServeHTTP(w, r)
+	}
+}
+
+// HttpViewAdminDashboard handles GET /ui/admin.
+// It renders all panels; the page refreshes them by htmx polling and the event stream.
+func HttpViewAdminDashboard(e *templating.Engine, adminService *admin.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		loc := localizer(r)
+		data := HttpViewAdminResponse{
+			AppName:   appName,
+			Title:     appName + " - " + loc.T("admin.title"),
+			SessionID: SessionFromContext(r.Context()).SessionID,
+			I18n:      loc,
+			Theme:     theme(r),
+		}
+
+		for _, panel := range adminPanels {
+			if err := loadAdminPanel(r.Context(), adminService, panel, loc, time.Now(), &data); err != nil {
+				writeProblem(w, r, http.StatusInternalServerError, "Failed to load dashboard")
+				return
+			}
+		}
+
+		HttpView(e, "admin", data)(w, r)
+	}
+}
+
+// HttpViewAdminPanel handles GET /ui/admin/panels/{panel}.
+// It renders a single panel as an HTML fragment for htmx polling.
+func HttpViewAdminPanel(e *templating.Engine, adminService *admin.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		panel := r.PathValue("panel")
+		loc := localizer(r)
+		data := HttpViewAdminResponse{I18n: loc}
+
+		if err := loadAdminPanel(r.Context(), adminService, panel, loc, time.Now(), &data); err != nil {
+			if errors.Is(err, errUnknownAdminPanel) {
+				writeProblem(w, r, http.StatusNotFound, "Panel not found")
+				return
+			}
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to load panel")
+			return
+		}
+
+		HttpView(e, "admin_"+panel, data)(w, r)
+	}
+}
+
+// HttpStreamAdminEvents handles GET /ui/admin/events/stream.
+// It sends the rendered events panel as a server-sent "events" event whenever new events arrive.
+func HttpStreamAdminEvents(e *templating.Engine, adminService *admin.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		loc := localizer(r)
+		updates := adminService.WatchEvents(ctx)
+
+		// The stream outlives the server's write timeout.
+		rc := http.NewResponseController(w)
+		_ = rc.SetWriteDeadline(time.Time{})
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		_ = rc.Flush()
+
+		heartbeat := time.NewTicker(sseHeartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-updates:
+				if !ok {
+					return
+				}
+				var fragment bytes.Buffer
+				data := HttpViewAdminResponse{I18n: loc, RecentEvents: buildAdminEventItems(adminService.RecentEvents(), loc)}
+				if err := e.Render(&fragment, "admin_events", data); err != nil {
+					return
+				}
+				if err := writeSSE(w, "events", fragment.Bytes()); err != nil || rc.Flush() != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := io.WriteString(w, ": ping\n\n"); err != nil || rc.Flush() != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// HttpViewAdminTrace handles GET /ui/admin/trace?correlation_id=....
+// It lists the recent events with the outcome and latency of their handlers. With a
+// correlation ID, it shows the trail of one request, e.g. a booking, oldest event first.
+func HttpViewAdminTrace(e *templating.Engine, adminService *admin.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		loc := localizer(r)
+		correlationID := strings.TrimSpace(r.URL.Query().Get("correlation_id"))
+		records := adminService.RecentEvents()
+		if correlationID != "" {
+			records = adminService.EventTrail(correlationID)
+		}
+
+		HttpView(e, "admin_trace", HttpViewAdminResponse{
+			AppName:       appName,
+			Title:         appName + " - " + loc.T("admin.trace"),
+			SessionID:     SessionFromContext(r.Context()).SessionID,
+			I18n:          loc,
+			Theme:         theme(r),
+			RecentEvents:  buildAdminEventItems(records, loc),
+			CorrelationID: correlationID,
+		})(w, r)
+	}
+}
+
+// errUnknownAdminPanel is returned by loadAdminPanel for panels that do not exist.
+var errUnknownAdminPanel = errors.New("unknown admin panel")
+
+// loadAdminPanel queries the data of a panel into the view data.
+func loadAdminPanel(ctx context.Context, adminService *admin.Service, panel string, loc *i18n.Localizer, now time.Time, data *HttpViewAdminResponse) error {
+	switch panel {
+	case AdminPanelMovements:
+		movements, err := adminService.Movements(ctx, now)
+		if err != nil {
+			return err
+		}
+		data.Today = loc.Date(now)
+		data.Arrivals = buildAdminReservationItems(movements.Arrivals, loc)
+		data.Departures = buildAdminReservationItems(movements.Departures, loc)
+	case AdminPanelPayments:
+		payments, err := adminService.PendingPayments(ctx)
+		if err != nil {
+			return err
+		}
+		data.PendingPayments = make([]AdminPaymentItem, 0, len(payments))
+		for _, p := range payments {
+			data.PendingPayments = append(data.PendingPayments, AdminPaymentItem{
+				ID:            string(p.ID),
+				ReservationID: string(p.ReservationID),
+				Amount:        loc.Money(p.Amount),
+				Status:        loc.T("payment_status." + string(p.Status)),
+				StatusClass:   paymentStatusClass(p.Status),
+				CreatedAt:     loc.DateTime(p.CreatedAt),
+			})
+		}
+	case AdminPanelCompensations:
+		compensations, err := adminService.FailedCompensations(ctx)
+		if err != nil {
+			return err
+		}
+		data.FailedCompensations = make([]AdminCompensationItem, 0, len(compensations))
+		for _, c := range compensations {
+			data.FailedCompensations = append(data.FailedCompensations, AdminCompensationItem{
+				ReservationID: string(c.ReservationID),
+				Action:        loc.T("compensation." + string(c.Action)),
+				Reason:        c.Reason,
+				LastError:     c.LastError,
+				Attempts:      c.Attempts,
+				UpdatedAt:     loc.DateTime(c.UpdatedAt),
+			})
+		}
+	case AdminPanelEvents:
+		data.RecentEvents = buildAdminEventItems(adminService.RecentEvents(), loc)
+	case AdminPanelIndexes:
+		report, err := adminService.IndexHealth(ctx, now)
+		if err != nil {
+			return err
+		}
+		data.Indexes = make([]AdminIndexItem, 0, len(report))
+		for _, index := range report {
+			item := AdminIndexItem{Name: index.Name, Entries: index.Entries, Healthy: index.Healthy(), Issues: index.Issues}
+			item.Status = loc.T("admin.healthy")
+			if !item.Healthy {
+				item.Status = loc.Plural("admin.issues", len(index.Issues))
+			}
+			data.Indexes = append(data.Indexes, item)
+		}
+	default:
+		return errUnknownAdminPanel
+	}
+	return nil
+}
+
+// buildAdminReservationItems converts reservations to localized view items.
+func buildAdminReservationItems(reservations []*reservation.Reservation, loc *i18n.Localizer) []AdminReservationItem {
+	items := make([]AdminReservationItem, 0, len(reservations))
+	for _, res := range reservations {
+		item := AdminReservationItem{
+			ID:          string(res.ID),
+			RoomID:      string(res.RoomID),
+			Guest:       string(res.GuestID),
+			Dates:       loc.DateRange(res.DateRange),
+			Status:      loc.T("status." + string(res.Status)),
+			StatusClass: reservationStatusClass(res.Status),
+			CanCheckIn:  res.Status == reservation.StatusConfirmed,
+		}
+		if len(res.Guests) > 0 && res.Guests[0].Name != "" {
+			item.Guest = res.Guests[0].Name
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// buildAdminEventItems converts event records to localized view items.
+func buildAdminEventItems(records []admin.EventRecord, loc *i18n.Localizer) []AdminEventItem {
+	items := make([]AdminEventItem, 0, len(records))
+	for _, record := range records {
+		item := AdminEventItem{
+			Topic:         record.Topic,
+			ReservationID: string(record.ReservationID),
+			CorrelationID: record.CorrelationID,
+			ReceivedAt:    loc.DateTime(record.ReceivedAt),
+			Latency:       formatLatency(record.Latency()),
+			Failed:        record.Failed(),
+			Handlers:      make([]AdminHandlerItem, 0, len(record.Handlers)),
+		}
+		for _, outcome := range record.Handlers {
+			item.Handlers = append(item.Handlers, AdminHandlerItem{
+				Name:     outcome.Handler,
+				Failed:   outcome.Failed,
+				Error:    outcome.Error,
+				Duration: formatLatency(outcome.Duration),
+			})
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// formatLatency rounds a handler duration for display, e.g. "12.3ms".
+func formatLatency(d time.Duration) string {
+	return d.Round(100 * time.Microsecond).String()
+}
+
+// paymentStatusClass returns the CSS class for a payment status.
+func paymentStatusClass(status payment.PaymentStatus) string {
+	switch status {
+	case payment.StatusPending:
+		return "warning"
+	case payment.StatusAuthorized:
+		return "info"
+	case payment.StatusCaptured:
+		return "success"
+	case payment.StatusFailed:
+		return "danger"
+	default:
+		return "secondary"
+	}
+}
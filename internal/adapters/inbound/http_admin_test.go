@@ -0,0 +1,256 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/admin"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type adminTestServices struct {
+	engine       *templating.Engine
+	reservations *mockReservationRepository
+	dispatcher   messaging.Dispatcher
+	eventLog     *admin.EventLog
+	adminService *admin.Service
+}
+
+func createAdminTestServices(t *testing.T) *adminTestServices {
+	t.Helper()
+	t.Setenv("APP_NAME", "TestApp")
+
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	dispatcher := messaging.NewInternalDispatcher()
+	publisher := outbound.NewEventPublisher(dispatcher)
+	reservationRepo := newMockReservationRepository()
+	reservationService := reservation.NewService(reservationRepo, outbound.NewRepositoryAvailabilityChecker(reservationRepo), publisher)
+	paymentRepo := outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]())
+	paymentService := payment.NewService(paymentRepo, outbound.NewMockPaymentGateway(), publisher)
+	bookingService := orchestration.NewBookingService(reservationService, paymentService, nil)
+
+	eventLog := admin.NewEventLog(10)
+	if err := eventLog.RegisterHandlers(context.Background(), dispatcher); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	return &adminTestServices{
+		engine:       e,
+		reservations: reservationRepo,
+		dispatcher:   dispatcher,
+		eventLog:     eventLog,
+		adminService: admin.NewService(reservationService, paymentService, bookingService, eventLog),
+	}
+}
+
+// ============================================================================
+// WithAdmin Tests
+// ============================================================================
+
+func Test_WithAdmin_Without_Session_Should_Redirect_To_Login(t *testing.T) {
+	// Arrange
+	handler := inbound.WithAdmin([]string{"staff@example.com"}, func(w http.ResponseWriter, r *http.Request) {})
+	req := httptest.NewRequest(http.MethodGet, "/ui/admin", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303 (redirect)", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be the login page", rec.Header().Get("Location"), "/ui/login")
+}
+
+func Test_WithAdmin_With_Guest_Should_Return_403(t *testing.T) {
+	// Arrange
+	handler := inbound.WithAdmin([]string{"staff@example.com"}, func(w http.ResponseWriter, r *http.Request) {})
+	req := httptest.NewRequest(http.MethodGet, "/ui/admin", nil)
+	req = addAuthContext(req, "test-session-123", "guest@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_WithAdmin_With_Staff_Email_Should_Call_Next_Ignoring_Case(t *testing.T) {
+	// Arrange
+	called := false
+	handler := inbound.WithAdmin([]string{" Staff@Example.com "}, func(w http.ResponseWriter, r *http.Request) { called = true })
+	req := httptest.NewRequest(http.MethodGet, "/ui/admin", nil)
+	req = addAuthContext(req, "test-session-123", "staff@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "next handler must be called", called, true)
+}
+
+// ============================================================================
+// HttpViewAdminDashboard Tests
+// ============================================================================
+
+func Test_HttpViewAdminDashboard_Should_Render_All_Panels(t *testing.T) {
+	// Arrange
+	svc := createAdminTestServices(t)
+	today := time.Now()
+	checkIn := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	res := createTestReservation("res-arrival", "guest@example.com", "room-101", checkIn, checkIn.AddDate(0, 0, 2))
+	res.Status = reservation.StatusConfirmed
+	svc.reservations.reservations[res.ID] = *res
+	_ = svc.dispatcher.Publish(context.Background(), messaging.NewMessage(reservation.EventTopicConfirmed, []byte(`{"reservation_id":"res-arrival"}`)))
+
+	handler := inbound.HttpViewAdminDashboard(svc.engine, svc.adminService)
+	req := httptest.NewRequest(http.MethodGet, "/ui/admin", nil)
+	req = addAuthContext(req, "test-session-123", "staff@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must list the arrival", strings.Contains(body, "<li>res-arrival room-101"), true)
+	assert.That(t, "body must list the recent event", strings.Contains(body, "reservation.confirmed res-arrival"), true)
+	assert.That(t, "body must report the payment index", strings.Contains(body, admin.IndexPaymentsByReservation), true)
+	assert.That(t, "body must subscribe to the event stream", strings.Contains(body, `data-sse-src="/ui/admin/events/stream"`), true)
+}
+
+// ============================================================================
+// HttpViewAdminTrace Tests
+// ============================================================================
+
+func Test_HttpViewAdminTrace_With_Correlation_ID_Should_Render_Trail_With_Outcomes(t *testing.T) {
+	// Arrange
+	svc := createAdminTestServices(t)
+	ctx := context.Background()
+	created := messaging.NewMessage(reservation.EventTopicCreated, []byte(`{"reservation_id":"res-1","correlation_id":"req-1"}`))
+	_ = svc.dispatcher.Publish(ctx, created)
+	_ = svc.dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCreated, []byte(`{"reservation_id":"res-2","correlation_id":"req-2"}`)))
+	_ = svc.dispatcher.Publish(ctx, messaging.NewMessage(payment.EventTopicFailed, []byte(`{"reservation_id":"res-1","correlation_id":"req-1"}`)))
+	svc.eventLog.ObserveHandler(created, admin.HandlerOutcome{Handler: "orchestration", Failed: true, Error: "payment declined"})
+
+	handler := inbound.HttpViewAdminTrace(svc.engine, svc.adminService)
+	req := httptest.NewRequest(http.MethodGet, "/ui/admin/trace?correlation_id=req-1", nil)
+	req = addAuthContext(req, "test-session-123", "staff@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must list the trail oldest first", strings.Index(body, "reservation.created res-1") < strings.Index(body, "payment.failed res-1"), true)
+	assert.That(t, "body must show the failed handler", strings.Contains(body, "[orchestration failed: payment declined]"), true)
+	assert.That(t, "body must not list other trails", strings.Contains(body, "res-2"), false)
+}
+
+func Test_HttpViewAdminTrace_Without_Correlation_ID_Should_List_Recent_Events(t *testing.T) {
+	// Arrange
+	svc := createAdminTestServices(t)
+	_ = svc.dispatcher.Publish(context.Background(), messaging.NewMessage(reservation.EventTopicCreated, []byte(`{"reservation_id":"res-1","correlation_id":"req-1"}`)))
+
+	handler := inbound.HttpViewAdminTrace(svc.engine, svc.adminService)
+	req := httptest.NewRequest(http.MethodGet, "/ui/admin/trace", nil)
+	req = addAuthContext(req, "test-session-123", "staff@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must list the event", strings.Contains(rec.Body.String(), "reservation.created res-1 req-1"), true)
+}
+
+// ============================================================================
+// HttpViewAdminPanel Tests
+// ============================================================================
+
+func Test_HttpViewAdminPanel_Should_Render_Fragment(t *testing.T) {
+	// Arrange
+	svc := createAdminTestServices(t)
+	handler := inbound.HttpViewAdminPanel(svc.engine, svc.adminService)
+	req := httptest.NewRequest(http.MethodGet, "/ui/admin/panels/indexes", nil)
+	req.SetPathValue("panel", "indexes")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain the index list", strings.Contains(body, `<ul class="indexes">`), true)
+	assert.That(t, "body must not contain the page", strings.Contains(body, "<html>"), false)
+}
+
+func Test_HttpViewAdminPanel_With_Unknown_Panel_Should_Return_404(t *testing.T) {
+	// Arrange
+	svc := createAdminTestServices(t)
+	handler := inbound.HttpViewAdminPanel(svc.engine, svc.adminService)
+	req := httptest.NewRequest(http.MethodGet, "/ui/admin/panels/unknown", nil)
+	req.SetPathValue("panel", "unknown")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpStreamAdminEvents Tests
+// ============================================================================
+
+func Test_HttpStreamAdminEvents_Should_Send_Events_Panel_After_New_Event(t *testing.T) {
+	// Arrange
+	svc := createAdminTestServices(t)
+	handler := inbound.HttpStreamAdminEvents(svc.engine, svc.adminService)
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/ui/admin/events/stream", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		handler(rec, req)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	_ = svc.dispatcher.Publish(ctx, messaging.NewMessage(payment.EventTopicFailed, []byte(`{"reservation_id":"res-1"}`)))
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "content type must be text/event-stream", rec.Header().Get("Content-Type"), "text/event-stream")
+	assert.That(t, "body must contain an events event", strings.Contains(body, "event: events\n"), true)
+	assert.That(t, "body must contain the new event", strings.Contains(body, "payment.failed res-1"), true)
+}
@@ -1,13 +1,14 @@
 package inbound
 
 import (
+	"errors"
 	"net/http"
 	"os"
 
 	"github.com/andygeiss/cloud-native-utils/templating"
-	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
-	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
 )
 
 // GuestInfoView represents guest information for the view.
@@ -38,16 +39,18 @@ type HttpViewReservationDetailResponse struct {
 	AppName     string
 	Title       string
 	SessionID   string
+	I18n        *i18n.Localizer
+	Theme       reservation.Theme
 	Reservation ReservationDetailView
 }
 
-func buildReservationDetailView(res *reservation.Reservation) ReservationDetailView {
+func buildReservationDetailView(res *reservation.Reservation, loc *i18n.Localizer) ReservationDetailView {
 	guests := make([]GuestInfoView, 0, len(res.Guests))
 	for _, g := range res.Guests {
 		guests = append(guests, GuestInfoView{
 			Name:        g.Name,
-			Email:       g.Email,
-			PhoneNumber: g.PhoneNumber,
+			Email:       string(g.Email),
+			PhoneNumber: string(g.PhoneNumber),
 		})
 	}
 
@@ -55,12 +58,12 @@ func buildReservationDetailView(res *reservation.Reservation) ReservationDetailV
 		Guests:             guests,
 		ID:                 string(res.ID),
 		RoomID:             string(res.RoomID),
-		CheckIn:            res.DateRange.CheckIn.Format("2006-01-02"),
-		CheckOut:           res.DateRange.CheckOut.Format("2006-01-02"),
-		Status:             string(res.Status),
+		CheckIn:            loc.Date(res.DateRange.CheckIn),
+		CheckOut:           loc.Date(res.DateRange.CheckOut),
+		Status:             loc.T("status." + string(res.Status)),
 		StatusClass:        reservationStatusClass(res.Status),
-		TotalAmount:        res.TotalAmount.FormatAmount(),
-		CreatedAt:          res.CreatedAt.Format("2006-01-02 15:04"),
+		TotalAmount:        loc.Money(res.TotalAmount),
+		CreatedAt:          loc.DateTime(res.CreatedAt),
 		CancellationReason: res.CancellationReason,
 		Nights:             res.Nights(),
 		CanCancel:          res.CanBeCancelled(),
@@ -74,35 +77,37 @@ func HttpViewReservationDetail(e *templating.Engine, reservationService *reserva
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
-		email, _ := ctx.Value(web.ContextEmail).(string)
-		if sessionID == "" || email == "" {
+		session := SessionFromContext(ctx)
+		if !session.Authenticated() {
 			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
 			return
 		}
 
 		reservationID := r.PathValue("id")
 		if reservationID == "" {
-			http.Error(w, "Reservation ID required", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, "Reservation ID required")
 			return
 		}
 
-		res, err := reservationService.GetReservation(ctx, shared.ReservationID(reservationID))
+		res, err := reservationService.GetReservation(ctx, reservation.ReservationID(reservationID))
 		if err != nil {
-			http.Error(w, "Reservation not found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, "Reservation not found")
 			return
 		}
 
-		if string(res.GuestID) != email {
-			http.Error(w, "Access denied", http.StatusForbidden)
+		if string(res.GuestID) != session.Email {
+			writeProblem(w, r, http.StatusForbidden, "Access denied")
 			return
 		}
 
+		loc := localizer(r)
 		data := HttpViewReservationDetailResponse{
 			AppName:     appName,
 			Title:       appName + " - Reservation " + reservationID,
-			SessionID:   sessionID,
-			Reservation: buildReservationDetailView(res),
+			SessionID:   session.SessionID,
+			I18n:        loc,
+			Theme:       theme(r),
+			Reservation: buildReservationDetailView(res, loc),
 		}
 
 		HttpView(e, "reservation_detail", data)(w, r)
@@ -110,41 +115,42 @@ func HttpViewReservationDetail(e *templating.Engine, reservationService *reserva
 }
 
 // HttpCancelReservation handles the POST request to cancel a reservation.
-func HttpCancelReservation(reservationService *reservation.Service) http.HandlerFunc {
+// The booking is cancelled through the booking service, so its payments are refunded.
+func HttpCancelReservation(reservationService *reservation.Service, bookingService *orchestration.BookingService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
 		// Check authentication
-		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
-		email, _ := ctx.Value(web.ContextEmail).(string)
-		if sessionID == "" || email == "" {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		session := SessionFromContext(ctx)
+		if !session.Authenticated() {
+			writeProblem(w, r, http.StatusUnauthorized, "Unauthorized")
 			return
 		}
 
 		// Get reservation ID from path
 		reservationID := r.PathValue("id")
 		if reservationID == "" {
-			http.Error(w, "Reservation ID required", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, "Reservation ID required")
 			return
 		}
 
 		// Verify the reservation belongs to the current user
-		res, err := reservationService.GetReservation(ctx, shared.ReservationID(reservationID))
+		res, err := reservationService.GetReservation(ctx, reservation.ReservationID(reservationID))
 		if err != nil {
-			http.Error(w, "Reservation not found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, "Reservation not found")
 			return
 		}
 
-		if string(res.GuestID) != email {
-			http.Error(w, "Access denied", http.StatusForbidden)
+		if string(res.GuestID) != session.Email {
+			writeProblem(w, r, http.StatusForbidden, "Access denied")
 			return
 		}
 
-		// Cancel the reservation
-		err = reservationService.CancelReservation(ctx, shared.ReservationID(reservationID), "Cancelled by guest")
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		// Cancel the reservation and refund its payments.
+		// A failed refund is queued for retry, the reservation is cancelled anyway.
+		err = bookingService.CancelBookingWithRefund(ctx, res.ID.Shared(), "Cancelled by guest")
+		if err != nil && !errors.Is(err, orchestration.ErrRefundFailed) {
+			writeDomainError(w, r, err, "Failed to cancel reservation")
 			return
 		}
 
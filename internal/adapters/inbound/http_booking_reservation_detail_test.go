@@ -1,8 +1,10 @@
 package inbound_test
 
 import (
+	"context"
 	"embed"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,11 +12,13 @@ import (
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/cloud-native-utils/templating"
 	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
-	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
 // ============================================================================
@@ -34,6 +38,17 @@ func createDetailTestService(repo *mockReservationRepository) *reservation.Servi
 	return reservation.NewService(repo, availabilityChecker, eventPublisher)
 }
 
+func createDetailTestPaymentService() *payment.Service {
+	paymentRepo := outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]())
+	eventPublisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	return payment.NewService(paymentRepo, outbound.NewMockPaymentGateway(), eventPublisher)
+}
+
+func createDetailTestBookingService(service *reservation.Service, paymentService *payment.Service) *orchestration.BookingService {
+	notifier := outbound.NewMockNotificationService(slog.New(slog.DiscardHandler))
+	return orchestration.NewBookingService(service, paymentService, notifier)
+}
+
 // ============================================================================
 // HttpViewReservationDetail Tests
 // ============================================================================
@@ -125,7 +140,7 @@ func Test_HttpViewReservationDetail_With_Other_User_Reservation_Should_Return_40
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
 	res := createTestReservation("res-001", "other@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	repo.reservations[reservation.ReservationID("res-001")] = *res
 
 	handler := inbound.HttpViewReservationDetail(e, service)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil)
@@ -155,7 +170,7 @@ func Test_HttpViewReservationDetail_With_Valid_Session_Should_Return_200(t *test
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
 	res := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	repo.reservations[reservation.ReservationID("res-001")] = *res
 
 	handler := inbound.HttpViewReservationDetail(e, service)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil)
@@ -185,7 +200,7 @@ func Test_HttpViewReservationDetail_Should_Render_Reservation_Data(t *testing.T)
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
 	res := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	repo.reservations[reservation.ReservationID("res-001")] = *res
 
 	handler := inbound.HttpViewReservationDetail(e, service)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil)
@@ -215,7 +230,7 @@ func Test_HttpCancelReservation_Without_Session_Should_Return_401(t *testing.T)
 	repo := newMockReservationRepository()
 	service := createDetailTestService(repo)
 
-	handler := inbound.HttpCancelReservation(service)
+	handler := inbound.HttpCancelReservation(service, createDetailTestBookingService(service, createDetailTestPaymentService()))
 	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
 	req.SetPathValue("id", "res-001")
 	rec := httptest.NewRecorder()
@@ -235,7 +250,7 @@ func Test_HttpCancelReservation_Without_Reservation_ID_Should_Return_400(t *test
 	repo := newMockReservationRepository()
 	service := createDetailTestService(repo)
 
-	handler := inbound.HttpCancelReservation(service)
+	handler := inbound.HttpCancelReservation(service, createDetailTestBookingService(service, createDetailTestPaymentService()))
 	req := httptest.NewRequest(http.MethodPost, "/ui/reservations//cancel", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -255,7 +270,7 @@ func Test_HttpCancelReservation_With_NonExistent_Reservation_Should_Return_404(t
 	repo := newMockReservationRepository()
 	service := createDetailTestService(repo)
 
-	handler := inbound.HttpCancelReservation(service)
+	handler := inbound.HttpCancelReservation(service, createDetailTestBookingService(service, createDetailTestPaymentService()))
 	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/nonexistent/cancel", nil)
 	req.SetPathValue("id", "nonexistent")
 	req = addAuthContext(req, "test-session-123", "test@example.com")
@@ -280,9 +295,9 @@ func Test_HttpCancelReservation_With_Other_User_Reservation_Should_Return_403(t
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
 	res := createTestReservation("res-001", "other@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	repo.reservations[reservation.ReservationID("res-001")] = *res
 
-	handler := inbound.HttpCancelReservation(service)
+	handler := inbound.HttpCancelReservation(service, createDetailTestBookingService(service, createDetailTestPaymentService()))
 	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
 	req.SetPathValue("id", "res-001")
 	req = addAuthContext(req, "test-session-123", "test@example.com")
@@ -307,9 +322,9 @@ func Test_HttpCancelReservation_With_Valid_Reservation_Should_Redirect(t *testin
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
 	res := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	repo.reservations[reservation.ReservationID("res-001")] = *res
 
-	handler := inbound.HttpCancelReservation(service)
+	handler := inbound.HttpCancelReservation(service, createDetailTestBookingService(service, createDetailTestPaymentService()))
 	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
 	req.SetPathValue("id", "res-001")
 	req = addAuthContext(req, "test-session-123", "test@example.com")
@@ -336,9 +351,9 @@ func Test_HttpCancelReservation_Should_Update_Reservation_Status(t *testing.T) {
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
 	res := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	repo.reservations[reservation.ReservationID("res-001")] = *res
 
-	handler := inbound.HttpCancelReservation(service)
+	handler := inbound.HttpCancelReservation(service, createDetailTestBookingService(service, createDetailTestPaymentService()))
 	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
 	req.SetPathValue("id", "res-001")
 	req = addAuthContext(req, "test-session-123", "test@example.com")
@@ -348,10 +363,48 @@ func Test_HttpCancelReservation_Should_Update_Reservation_Status(t *testing.T) {
 	handler(rec, req)
 
 	// Assert
-	updatedRes := repo.reservations[shared.ReservationID("res-001")]
+	updatedRes := repo.reservations[reservation.ReservationID("res-001")]
 	assert.That(t, "reservation status must be cancelled", updatedRes.Status, reservation.StatusCancelled)
 }
 
+func Test_HttpCancelReservation_With_Captured_Payment_Should_Refund_Payment(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	repo := newMockReservationRepository()
+	service := createDetailTestService(repo)
+	paymentService := createDetailTestPaymentService()
+
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	checkOut := checkIn.AddDate(0, 0, 3)
+	res := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
+	repo.reservations[reservation.ReservationID("res-001")] = *res
+
+	ctx := context.Background()
+	if _, err := paymentService.AuthorizePayment(ctx, "pay-001", "res-001", payment.NewMoney(29700, "USD"), "credit_card"); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	if err := paymentService.CapturePayment(ctx, "pay-001"); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	handler := inbound.HttpCancelReservation(service, createDetailTestBookingService(service, paymentService))
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
+	req.SetPathValue("id", "res-001")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303 (redirect)", rec.Code, http.StatusSeeOther)
+	pay, err := paymentService.GetPayment(ctx, "pay-001")
+	assert.That(t, "payment must be found", err, nil)
+	assert.That(t, "payment must be refunded", pay.Status, payment.StatusRefunded)
+}
+
 // ============================================================================
 // Unit Tests for View Logic
 // ============================================================================
@@ -377,8 +430,8 @@ func Test_GuestInfoView_Should_Have_Expected_Fields(t *testing.T) {
 func Test_BuildReservationDetailView_Logic_Should_Convert_Guests(t *testing.T) {
 	// Arrange
 	domainGuests := []reservation.GuestInfo{
-		reservation.NewGuestInfo("John Doe", "john@example.com", "+1234567890"),
-		reservation.NewGuestInfo("Jane Doe", "jane@example.com", "+0987654321"),
+		{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+1234567890"},
+		{Name: "Jane Doe", Email: "jane@example.com", PhoneNumber: "+4915112345678"},
 	}
 
 	viewGuests := make([]struct {
@@ -395,8 +448,8 @@ func Test_BuildReservationDetailView_Logic_Should_Convert_Guests(t *testing.T) {
 			PhoneNumber string
 		}{
 			Name:        g.Name,
-			Email:       g.Email,
-			PhoneNumber: g.PhoneNumber,
+			Email:       string(g.Email),
+			PhoneNumber: string(g.PhoneNumber),
 		})
 	}
 
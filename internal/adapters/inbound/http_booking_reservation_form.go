@@ -1,15 +1,17 @@
 package inbound
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
-	"github.com/andygeiss/cloud-native-utils/security"
 	"github.com/andygeiss/cloud-native-utils/templating"
-	"github.com/andygeiss/cloud-native-utils/web"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
 )
 
 // RoomOption represents a room option for the form dropdown.
@@ -20,24 +22,26 @@ type RoomOption struct {
 }
 
 // HttpViewReservationFormResponse specifies the view data for the reservation form.
+// Fields holds the inputs by name with their submitted values and validation errors.
 type HttpViewReservationFormResponse struct {
-	AppName    string
-	Title      string
-	SessionID  string
-	MinDate    string
-	GuestName  string
-	GuestEmail string
-	Error      string
-	Rooms      []RoomOption
+	AppName   string
+	Title     string
+	SessionID string
+	I18n      *i18n.Localizer
+	Theme     reservation.Theme
+	Error     string
+	Fields    FormFields
 }
 
-func getDefaultRooms() []RoomOption {
+func getDefaultRooms(loc *i18n.Localizer) []RoomOption {
+	prices := getRoomPrices()
+	price := func(id string) string { return loc.Money(shared.NewMoney(prices[id], "USD")) }
 	return []RoomOption{
-		{ID: "room-101", Name: "Standard Room 101", Price: "$99.00"},
-		{ID: "room-102", Name: "Standard Room 102", Price: "$99.00"},
-		{ID: "room-201", Name: "Deluxe Room 201", Price: "$149.00"},
-		{ID: "room-202", Name: "Deluxe Room 202", Price: "$149.00"},
-		{ID: "room-301", Name: "Suite 301", Price: "$249.00"},
+		{ID: "room-101", Name: "Standard Room 101", Price: price("room-101")},
+		{ID: "room-102", Name: "Standard Room 102", Price: price("room-102")},
+		{ID: "room-201", Name: "Deluxe Room 201", Price: price("room-201")},
+		{ID: "room-202", Name: "Deluxe Room 202", Price: price("room-202")},
+		{ID: "room-301", Name: "Suite 301", Price: price("room-301")},
 	}
 }
 
@@ -51,6 +55,16 @@ func getRoomPrices() map[string]int64 {
 	}
 }
 
+// RoomRates returns the nightly rates the reservation form books at, so other
+// entry points, e.g. the booking assistant, price stays the same way.
+func RoomRates() *reservation.RoomRates {
+	rates := reservation.NewRoomRates()
+	for id, price := range getRoomPrices() {
+		rates.WithRate(reservation.RoomID(id), shared.NewMoney(price, "USD"))
+	}
+	return rates
+}
+
 // HttpViewReservationForm defines an HTTP handler function for rendering the new reservation form.
 func HttpViewReservationForm(e *templating.Engine) http.HandlerFunc {
 	appName := os.Getenv("APP_NAME")
@@ -59,23 +73,22 @@ func HttpViewReservationForm(e *templating.Engine) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
-		email, _ := ctx.Value(web.ContextEmail).(string)
-		if sessionID == "" || email == "" {
+		session := SessionFromContext(ctx)
+		if !session.Authenticated() {
 			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
 			return
 		}
 
-		name, _ := ctx.Value(web.ContextName).(string)
+		name := session.Name
 
+		loc := localizer(r)
 		data := HttpViewReservationFormResponse{
-			Rooms:      getDefaultRooms(),
-			AppName:    appName,
-			Title:      title,
-			SessionID:  sessionID,
-			MinDate:    time.Now().Format("2006-01-02"),
-			GuestName:  name,
-			GuestEmail: email,
+			AppName:   appName,
+			Title:     title,
+			SessionID: session.SessionID,
+			I18n:      loc,
+			Theme:     theme(r),
+			Fields:    reservationFormFields(loc, url.Values{"guest_name": {name}, "guest_email": {session.Email}}),
 		}
 
 		HttpView(e, "reservation_form", data)(w, r)
@@ -91,74 +104,91 @@ type reservationFormInput struct {
 	guestPhone string
 }
 
-func parseReservationForm(r *http.Request) (*reservationFormInput, string) {
-	if err := r.ParseForm(); err != nil {
-		return nil, "Invalid form data"
+// parseReservationForm parses the form. Missing and malformed inputs are
+// returned as messages per input name; the input is only valid without them.
+func parseReservationForm(r *http.Request, loc *i18n.Localizer) (*reservationFormInput, map[string]string) {
+	input := &reservationFormInput{
+		roomID:     r.FormValue("room_id"),
+		guestName:  strings.TrimSpace(r.FormValue("guest_name")),
+		guestEmail: r.FormValue("guest_email"),
+		guestPhone: r.FormValue("guest_phone"),
 	}
+	fieldErrors := make(map[string]string)
 
-	roomID := r.FormValue("room_id")
-	checkInStr := r.FormValue("check_in")
-	checkOutStr := r.FormValue("check_out")
-	guestName := r.FormValue("guest_name")
-	guestEmail := r.FormValue("guest_email")
-	guestPhone := r.FormValue("guest_phone")
-
-	if roomID == "" || checkInStr == "" || checkOutStr == "" || guestName == "" || guestEmail == "" {
-		return nil, "Please fill in all required fields"
+	for _, name := range []string{"room_id", "check_in", "check_out", "guest_name", "guest_email"} {
+		if strings.TrimSpace(r.FormValue(name)) == "" {
+			fieldErrors[name] = loc.T("form.required")
+		}
 	}
 
-	checkIn, err := time.Parse("2006-01-02", checkInStr)
-	if err != nil {
-		return nil, "Invalid check-in date format"
+	if _, ok := fieldErrors["room_id"]; !ok {
+		if _, known := getRoomPrices()[input.roomID]; !known {
+			fieldErrors["room_id"] = loc.T("form.invalid_room")
+		}
 	}
 
-	checkOut, err := time.Parse("2006-01-02", checkOutStr)
-	if err != nil {
-		return nil, "Invalid check-out date format"
+	var err error
+	if _, ok := fieldErrors["check_in"]; !ok {
+		if input.checkIn, err = time.Parse("2006-01-02", r.FormValue("check_in")); err != nil {
+			fieldErrors["check_in"] = loc.T("form.invalid_check_in")
+		}
 	}
-
-	if _, ok := getRoomPrices()[roomID]; !ok {
-		return nil, "Invalid room selected"
+	if _, ok := fieldErrors["check_out"]; !ok {
+		if input.checkOut, err = time.Parse("2006-01-02", r.FormValue("check_out")); err != nil {
+			fieldErrors["check_out"] = loc.T("form.invalid_check_out")
+		}
 	}
 
-	return &reservationFormInput{
-		checkIn:    checkIn,
-		checkOut:   checkOut,
-		roomID:     roomID,
-		guestName:  guestName,
-		guestEmail: guestEmail,
-		guestPhone: guestPhone,
-	}, ""
+	return input, fieldErrors
 }
 
 // HttpCreateReservation handles the POST request to create a new reservation.
-func HttpCreateReservation(e *templating.Engine, reservationService *reservation.Service) http.HandlerFunc {
+func HttpCreateReservation(e *templating.Engine, reservationService *reservation.Service, ids shared.IDGenerator) http.HandlerFunc {
 	appName := os.Getenv("APP_NAME")
 	title := appName + " - New Reservation"
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
-		email, _ := ctx.Value(web.ContextEmail).(string)
-		if sessionID == "" || email == "" {
+		session := SessionFromContext(ctx)
+		if !session.Authenticated() {
 			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
 			return
 		}
 
-		input, errMsg := parseReservationForm(r)
-		if errMsg != "" {
-			renderReservationFormWithError(e, w, r, appName, title, sessionID, errMsg, r.FormValue("guest_name"), r.FormValue("guest_email"))
+		if err := r.ParseForm(); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+
+		// Report the errors of all inputs at once, the missing ones first.
+		input, fieldErrors := parseReservationForm(r, localizer(r))
+		guest, err := reservation.NewGuestInfo(input.guestName, input.guestEmail, input.guestPhone)
+		for name, msg := range guestFieldErrors(err) {
+			if _, ok := fieldErrors[name]; !ok {
+				fieldErrors[name] = msg
+			}
+		}
+		if len(fieldErrors) > 0 {
+			renderReservationFormWithError(e, w, r, appName, title, session.SessionID, "", fieldErrors)
 			return
 		}
 
 		nights := int(input.checkOut.Sub(input.checkIn).Hours() / 24)
 		totalAmount := shared.NewMoney(getRoomPrices()[input.roomID]*int64(nights), "USD")
-		guests := []reservation.GuestInfo{reservation.NewGuestInfo(input.guestName, input.guestEmail, input.guestPhone)}
+		guests := []reservation.GuestInfo{guest}
 
-		_, err := reservationService.CreateReservation(ctx, shared.ReservationID(security.GenerateID()), reservation.GuestID(email), reservation.RoomID(input.roomID), reservation.NewDateRange(input.checkIn, input.checkOut), totalAmount, guests)
+		_, err = reservationService.CreateReservation(ctx, reservation.ToReservationID(shared.NewReservationID(ids)), reservation.GuestID(session.Email), reservation.RoomID(input.roomID), reservation.NewDateRange(input.checkIn, input.checkOut), totalAmount, guests)
+		if errors.Is(err, reservation.ErrPolicyViolation) {
+			renderReservationFormWithError(e, w, r, appName, title, session.SessionID, err.Error(), formFieldErrors(err, policyFormFields))
+			return
+		}
+		if name, ok := reservationErrorField(err); ok {
+			renderReservationFormWithError(e, w, r, appName, title, session.SessionID, "", map[string]string{name: err.Error()})
+			return
+		}
 		if err != nil {
-			renderReservationFormWithError(e, w, r, appName, title, sessionID, err.Error(), input.guestName, input.guestEmail)
+			renderReservationFormWithError(e, w, r, appName, title, session.SessionID, err.Error(), nil)
 			return
 		}
 
@@ -166,16 +196,91 @@ func HttpCreateReservation(e *templating.Engine, reservationService *reservation
 	}
 }
 
-func renderReservationFormWithError(e *templating.Engine, w http.ResponseWriter, r *http.Request, appName, title, sessionID, errMsg, guestName, guestEmail string) {
+// guestFormFields maps GuestInfo fields to their form input names.
+var guestFormFields = map[string]string{
+	"email":        "guest_email",
+	"phone_number": "guest_phone",
+}
+
+// policyFormFields maps the fields of booking policy violations to their form input names.
+var policyFormFields = map[string]string{
+	"check_in":  "check_in",
+	"check_out": "check_out",
+}
+
+// reservationFormErrors maps the reservation errors caused by a single input to its name.
+var reservationFormErrors = map[error]string{
+	reservation.ErrRoomUnavailable:  "room_id",
+	reservation.ErrCheckInPast:      "check_in",
+	reservation.ErrInvalidDateRange: "check_out",
+	reservation.ErrMinimumStay:      "check_out",
+}
+
+// reservationErrorField returns the input name of a reservation error caused by a single input.
+func reservationErrorField(err error) (string, bool) {
+	for target, name := range reservationFormErrors {
+		if errors.Is(err, target) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// guestFieldErrors converts domain validation errors into messages per form input.
+func guestFieldErrors(err error) map[string]string {
+	return formFieldErrors(err, guestFormFields)
+}
+
+// formFieldErrors converts domain validation errors into messages per form input,
+// using fields to map domain field names to input names.
+func formFieldErrors(err error, fields map[string]string) map[string]string {
+	var verrs reservation.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+	fieldErrors := make(map[string]string, len(verrs))
+	for _, ferr := range verrs {
+		fieldErrors[fields[ferr.Field]] = ferr.Err.Error()
+	}
+	return fieldErrors
+}
+
+// reservationFormFields returns the inputs of the reservation form with the given values.
+func reservationFormFields(loc *i18n.Localizer, values url.Values) FormFields {
+	minDate := time.Now().Format("2006-01-02")
+	rooms := []FormOption{{Value: "", Label: loc.T("form.select_room")}}
+	for _, room := range getDefaultRooms(loc) {
+		rooms = append(rooms, FormOption{
+			Value:    room.ID,
+			Label:    room.Name + " - " + room.Price + "/" + loc.T("form.per_night"),
+			Selected: room.ID == values.Get("room_id"),
+		})
+	}
+	return FormFields{
+		"room_id":     {ID: "room_id", Label: loc.T("form.room"), Type: "select", Required: true, Options: rooms},
+		"check_in":    {ID: "check_in", Label: loc.T("form.check_in"), Type: "date", Value: values.Get("check_in"), Required: true, Min: minDate},
+		"check_out":   {ID: "check_out", Label: loc.T("form.check_out"), Type: "date", Value: values.Get("check_out"), Required: true, Min: minDate},
+		"guest_name":  {ID: "guest_name", Label: loc.T("form.guest_name"), Type: "text", Value: values.Get("guest_name"), Required: true, Autocomplete: "name"},
+		"guest_email": {ID: "guest_email", Label: loc.T("form.guest_email"), Type: "email", Value: values.Get("guest_email"), Required: true, Autocomplete: "email"},
+		"guest_phone": {ID: "guest_phone", Label: loc.T("form.guest_phone"), Type: "tel", Value: values.Get("guest_phone"), Autocomplete: "tel", Placeholder: "+15551234567", Hint: loc.T("form.guest_phone_hint")},
+	}
+}
+
+// renderReservationFormWithError re-renders the form with the submitted values and
+// the messages per input. Without a general message, it asks to correct the fields.
+func renderReservationFormWithError(e *templating.Engine, w http.ResponseWriter, r *http.Request, appName, title, sessionID, errMsg string, fieldErrors map[string]string) {
+	loc := localizer(r)
+	if errMsg == "" && len(fieldErrors) > 0 {
+		errMsg = loc.T("form.correct_fields")
+	}
 	data := HttpViewReservationFormResponse{
-		Rooms:      getDefaultRooms(),
-		AppName:    appName,
-		Title:      title,
-		SessionID:  sessionID,
-		MinDate:    time.Now().Format("2006-01-02"),
-		GuestName:  guestName,
-		GuestEmail: guestEmail,
-		Error:      errMsg,
+		AppName:   appName,
+		Title:     title,
+		SessionID: sessionID,
+		I18n:      loc,
+		Theme:     theme(r),
+		Error:     errMsg,
+		Fields:    reservationFormFields(loc, r.Form).withErrors(fieldErrors),
 	}
 	HttpView(e, "reservation_form", data)(w, r)
 }
@@ -1,6 +1,7 @@
 package inbound_test
 
 import (
+	"context"
 	"embed"
 	"io"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
 // ============================================================================
@@ -161,7 +163,7 @@ func Test_HttpCreateReservation_Without_Session_Should_Redirect_To_Login(t *test
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, service, shared.NewUUIDv7Generator())
 	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", nil)
 	rec := httptest.NewRecorder()
 
@@ -185,7 +187,7 @@ func Test_HttpCreateReservation_With_Missing_Fields_Should_Show_Error(t *testing
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, service, shared.NewUUIDv7Generator())
 
 	// Create request with empty form
 	form := url.Values{}
@@ -215,7 +217,7 @@ func Test_HttpCreateReservation_With_Invalid_Room_Should_Show_Error(t *testing.T
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, service, shared.NewUUIDv7Generator())
 
 	// Create request with invalid room
 	checkIn := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
@@ -242,6 +244,86 @@ func Test_HttpCreateReservation_With_Invalid_Room_Should_Show_Error(t *testing.T
 	assert.That(t, "body must contain error message", containsString(bodyStr, "Invalid room"), true)
 }
 
+func Test_HttpCreateReservation_With_Invalid_Phone_Should_Show_Field_Error(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	repo := newMockReservationRepository()
+	service := createFormTestService(repo)
+
+	handler := inbound.HttpCreateReservation(e, service, shared.NewUUIDv7Generator())
+
+	checkIn := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	checkOut := time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+	form := url.Values{
+		"room_id":     {"room-101"},
+		"check_in":    {checkIn},
+		"check_out":   {checkOut},
+		"guest_name":  {"Test Guest"},
+		"guest_email": {"test@example.com"},
+		"guest_phone": {"555-1234"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200 (form re-rendered with error)", rec.Code, http.StatusOK)
+	body, _ := io.ReadAll(rec.Body)
+	bodyStr := string(body)
+	assert.That(t, "body must contain phone field error", containsString(bodyStr, "guest_phone: invalid phone number"), true)
+	assert.That(t, "body must keep submitted phone", containsString(bodyStr, "Guest Phone: 555-1234"), true)
+	assert.That(t, "no reservation must be stored", len(repo.reservations), 0)
+}
+
+func Test_HttpCreateReservation_With_Policy_Violation_Should_Show_Field_Error(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	repo := newMockReservationRepository()
+	service := createFormTestService(repo).WithPolicies(reservation.StaticBookingPolicies{
+		Default: reservation.BookingPolicy{MinNights: 5},
+	})
+
+	handler := inbound.HttpCreateReservation(e, service, shared.NewUUIDv7Generator())
+
+	checkIn := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	checkOut := time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+	form := url.Values{
+		"room_id":     {"room-101"},
+		"check_in":    {checkIn},
+		"check_out":   {checkOut},
+		"guest_name":  {"Test Guest"},
+		"guest_email": {"test@example.com"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200 (form re-rendered with error)", rec.Code, http.StatusOK)
+	body, _ := io.ReadAll(rec.Body)
+	bodyStr := string(body)
+	assert.That(t, "body must contain check-out field error", containsString(bodyStr, "check_out: stay is shorter than the minimum stay: 5 nights required"), true)
+	assert.That(t, "no reservation must be stored", len(repo.reservations), 0)
+}
+
 func Test_HttpCreateReservation_With_Valid_Data_Should_Redirect_To_Reservations(t *testing.T) {
 	// Arrange
 	t.Setenv("APP_NAME", "TestApp")
@@ -253,7 +335,7 @@ func Test_HttpCreateReservation_With_Valid_Data_Should_Redirect_To_Reservations(
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, service, shared.NewUUIDv7Generator())
 
 	// Create request with valid data
 	checkIn := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
@@ -291,7 +373,7 @@ func Test_HttpCreateReservation_Should_Create_Reservation_In_Repository(t *testi
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, service, shared.NewUUIDv7Generator())
 
 	// Create request with valid data
 	checkIn := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
@@ -316,6 +398,48 @@ func Test_HttpCreateReservation_Should_Create_Reservation_In_Repository(t *testi
 	assert.That(t, "repository must have 1 reservation", len(repo.reservations), 1)
 }
 
+type fixedIDGenerator struct {
+	id string
+}
+
+func (g fixedIDGenerator) NewID() string { return g.id }
+
+func Test_HttpCreateReservation_Should_Use_Injected_IDGenerator(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	repo := newMockReservationRepository()
+	service := createFormTestService(repo)
+
+	handler := inbound.HttpCreateReservation(e, service, fixedIDGenerator{id: "res-fixed"})
+
+	checkIn := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	checkOut := time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+	form := url.Values{
+		"room_id":     {"room-101"},
+		"check_in":    {checkIn},
+		"check_out":   {checkOut},
+		"guest_name":  {"Test Guest"},
+		"guest_email": {"test@example.com"},
+		"guest_phone": {"+1234567890"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	_, exists := repo.reservations["res-fixed"]
+	assert.That(t, "reservation must use the generated ID", exists, true)
+}
+
 func Test_HttpCreateReservation_With_Invalid_CheckIn_Date_Format_Should_Show_Error(t *testing.T) {
 	// Arrange
 	t.Setenv("APP_NAME", "TestApp")
@@ -327,7 +451,7 @@ func Test_HttpCreateReservation_With_Invalid_CheckIn_Date_Format_Should_Show_Err
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, service, shared.NewUUIDv7Generator())
 
 	// Create request with invalid date format
 	form := url.Values{
@@ -352,6 +476,70 @@ func Test_HttpCreateReservation_With_Invalid_CheckIn_Date_Format_Should_Show_Err
 	assert.That(t, "body must contain error message", containsString(bodyStr, "Invalid check-in date"), true)
 }
 
+func Test_HttpCreateReservation_With_Missing_And_Invalid_Fields_Should_Show_All_Field_Errors(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpCreateReservation(e, createFormTestService(newMockReservationRepository()), shared.NewUUIDv7Generator())
+	form := url.Values{
+		"room_id":     {"room-201"},
+		"check_in":    {"invalid-date"},
+		"guest_email": {"test@example.com"},
+		"guest_phone": {"555-1234"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "body must contain the summary", containsString(body, "Please correct the highlighted fields"), true)
+	assert.That(t, "check-in must be invalid", containsString(body, "check_in: Invalid check-in date format"), true)
+	assert.That(t, "check-out must be required", containsString(body, "check_out: This field is required"), true)
+	assert.That(t, "guest name must be required", containsString(body, "guest_name: This field is required"), true)
+	assert.That(t, "phone must be invalid", containsString(body, "guest_phone: invalid phone number"), true)
+	assert.That(t, "phone error must describe the input", containsString(body, `aria-describedby="guest_phone-hint guest_phone-error" aria-invalid="true"`), true)
+	assert.That(t, "room must stay selected", containsString(body, `<option value="room-201" selected>`), true)
+}
+
+func Test_HttpCreateReservation_With_Unavailable_Room_Should_Show_Room_Field_Error(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	service := createFormTestService(newMockReservationRepository())
+	checkIn := time.Now().AddDate(0, 0, 7)
+	checkOut := checkIn.AddDate(0, 0, 3)
+	guest, _ := reservation.NewGuestInfo("Other Guest", "other@example.com", "")
+	_, err := service.CreateReservation(context.Background(), "res-001", "other@example.com", "room-101",
+		reservation.NewDateRange(checkIn, checkOut), shared.NewMoney(29700, "USD"), []reservation.GuestInfo{guest})
+	assert.That(t, "reservation must be created", err == nil, true)
+	handler := inbound.HttpCreateReservation(e, service, shared.NewUUIDv7Generator())
+	form := url.Values{
+		"room_id":     {"room-101"},
+		"check_in":    {checkIn.Format("2006-01-02")},
+		"check_out":   {checkOut.Format("2006-01-02")},
+		"guest_name":  {"Test Guest"},
+		"guest_email": {"test@example.com"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200 (form re-rendered with error)", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain room field error", containsString(rec.Body.String(), "room_id: room is not available"), true)
+}
+
 // ============================================================================
 // Unit Tests for Room Configuration
 // ============================================================================
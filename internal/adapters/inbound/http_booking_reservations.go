@@ -5,8 +5,8 @@ import (
 	"os"
 
 	"github.com/andygeiss/cloud-native-utils/templating"
-	"github.com/andygeiss/cloud-native-utils/web"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
 )
 
 // ReservationListItem represents a reservation item for the list view.
@@ -26,6 +26,8 @@ type HttpViewReservationsResponse struct {
 	AppName      string
 	Title        string
 	SessionID    string
+	I18n         *i18n.Localizer
+	Theme        reservation.Theme
 	Reservations []ReservationListItem
 }
 
@@ -38,47 +40,52 @@ func HttpViewReservations(e *templating.Engine, reservationService *reservation.
 		ctx := r.Context()
 
 		// Check authentication
-		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
-		email, _ := ctx.Value(web.ContextEmail).(string)
-		if sessionID == "" || email == "" {
+		session := SessionFromContext(ctx)
+		if !session.Authenticated() {
 			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
 			return
 		}
 
 		// Get reservations for the current user (using email as guest ID)
-		guestID := reservation.GuestID(email)
+		guestID := reservation.GuestID(session.Email)
 		reservations, err := reservationService.ListReservationsByGuest(ctx, guestID)
 		if err != nil {
 			// If repository doesn't exist yet, treat as empty list
 			reservations = []*reservation.Reservation{}
 		}
 
-		// Convert domain reservations to view items
-		items := make([]ReservationListItem, 0, len(reservations))
-		for _, res := range reservations {
-			items = append(items, ReservationListItem{
-				ID:          string(res.ID),
-				RoomID:      string(res.RoomID),
-				CheckIn:     res.DateRange.CheckIn.Format("2006-01-02"),
-				CheckOut:    res.DateRange.CheckOut.Format("2006-01-02"),
-				Status:      string(res.Status),
-				StatusClass: reservationStatusClass(res.Status),
-				TotalAmount: res.TotalAmount.FormatAmount(),
-				CanCancel:   res.CanBeCancelled(),
-			})
-		}
-
+		loc := localizer(r)
 		data := HttpViewReservationsResponse{
 			AppName:      appName,
 			Title:        title,
-			SessionID:    sessionID,
-			Reservations: items,
+			SessionID:    session.SessionID,
+			I18n:         loc,
+			Theme:        theme(r),
+			Reservations: buildReservationListItems(reservations, loc),
 		}
 
 		HttpView(e, "reservations", data)(w, r)
 	}
 }
 
+// buildReservationListItems converts domain reservations to localized view items.
+func buildReservationListItems(reservations []*reservation.Reservation, loc *i18n.Localizer) []ReservationListItem {
+	items := make([]ReservationListItem, 0, len(reservations))
+	for _, res := range reservations {
+		items = append(items, ReservationListItem{
+			ID:          string(res.ID),
+			RoomID:      string(res.RoomID),
+			CheckIn:     loc.Date(res.DateRange.CheckIn),
+			CheckOut:    loc.Date(res.DateRange.CheckOut),
+			Status:      loc.T("status." + string(res.Status)),
+			StatusClass: reservationStatusClass(res.Status),
+			TotalAmount: loc.Money(res.TotalAmount),
+			CanCancel:   res.CanBeCancelled(),
+		})
+	}
+	return items
+}
+
 // reservationStatusClass returns the CSS class for a reservation status.
 func reservationStatusClass(status reservation.ReservationStatus) string {
 	switch status {
@@ -90,7 +97,7 @@ func reservationStatusClass(status reservation.ReservationStatus) string {
 		return "primary"
 	case reservation.StatusCompleted:
 		return "success"
-	case reservation.StatusCancelled:
+	case reservation.StatusCancelled, reservation.StatusNoShow:
 		return "danger"
 	default:
 		return "secondary"
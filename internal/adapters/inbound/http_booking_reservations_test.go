@@ -1,7 +1,6 @@
 package inbound_test
 
 import (
-	"context"
 	"embed"
 	"io"
 	"net/http"
@@ -12,7 +11,6 @@ import (
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/messaging"
 	"github.com/andygeiss/cloud-native-utils/templating"
-	"github.com/andygeiss/cloud-native-utils/web"
 	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
@@ -40,9 +38,9 @@ func createTestReservation(id, guestEmail, roomID string, checkIn, checkOut time
 	dateRange := reservation.NewDateRange(checkIn, checkOut)
 	nights := int(checkOut.Sub(checkIn).Hours() / 24)
 	amount := shared.NewMoney(int64(nights)*9900, "USD")
-	guests := []reservation.GuestInfo{reservation.NewGuestInfo("Test Guest", guestEmail, "+1234567890")}
+	guests := []reservation.GuestInfo{{Name: "Test Guest", Email: reservation.Email(guestEmail), PhoneNumber: "+1234567890"}}
 	r, _ := reservation.NewReservation(
-		shared.ReservationID(id),
+		reservation.ReservationID(id),
 		reservation.GuestID(guestEmail),
 		reservation.RoomID(roomID),
 		dateRange,
@@ -53,14 +51,15 @@ func createTestReservation(id, guestEmail, roomID string, checkIn, checkOut time
 }
 
 func addAuthContext(req *http.Request, sessionID, email string) *http.Request {
-	ctx := req.Context()
-	ctx = context.WithValue(ctx, web.ContextSessionID, sessionID)
-	ctx = context.WithValue(ctx, web.ContextEmail, email)
-	ctx = context.WithValue(ctx, web.ContextIssuer, "https://issuer.example.com")
-	ctx = context.WithValue(ctx, web.ContextName, "Test User")
-	ctx = context.WithValue(ctx, web.ContextSubject, "user-subject-456")
-	ctx = context.WithValue(ctx, web.ContextVerified, true)
-	return req.WithContext(ctx)
+	session := inbound.SessionContext{
+		SessionID: sessionID,
+		Email:     email,
+		Issuer:    "https://issuer.example.com",
+		Name:      "Test User",
+		Subject:   "user-subject-456",
+		Verified:  true,
+	}
+	return req.WithContext(session.IntoContext(req.Context()))
 }
 
 // ============================================================================
@@ -202,7 +201,7 @@ func Test_HttpViewReservations_With_Reservations_Should_Render_Reservation_List(
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
 	res := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	repo.reservations[reservation.ReservationID("res-001")] = *res
 
 	handler := inbound.HttpViewReservations(e, service)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
@@ -235,8 +234,8 @@ func Test_HttpViewReservations_Should_Only_Show_Current_User_Reservations(t *tes
 	checkOut := checkIn.AddDate(0, 0, 3)
 	res1 := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
 	res2 := createTestReservation("res-002", "other@example.com", "room-102", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res1
-	repo.reservations[shared.ReservationID("res-002")] = *res2
+	repo.reservations[reservation.ReservationID("res-001")] = *res1
+	repo.reservations[reservation.ReservationID("res-002")] = *res2
 
 	handler := inbound.HttpViewReservations(e, service)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
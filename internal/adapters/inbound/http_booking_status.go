@@ -0,0 +1,233 @@
+package inbound
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
+)
+
+// sseHeartbeat is the interval of keep-alive comments on idle event streams,
+// so proxies do not close them.
+const sseHeartbeat = 15 * time.Second
+
+// SagaStepView represents a saga step for the status view.
+type SagaStepView struct {
+	Name        string
+	Status      string
+	StatusClass string
+	Error       string
+	UpdatedAt   string
+}
+
+// BookingStatusView represents the saga progress of a reservation for the status view.
+type BookingStatusView struct {
+	ReservationID      string
+	StreamURL          string // Empty when the saga is done, so the page stops listening
+	Steps              []SagaStepView
+	CancellationReason string
+	Failed             bool
+}
+
+// HttpViewBookingStatusResponse specifies the view data for the booking status page.
+type HttpViewBookingStatusResponse struct {
+	AppName   string
+	Title     string
+	SessionID string
+	I18n      *i18n.Localizer
+	Theme     reservation.Theme
+	Status    BookingStatusView
+}
+
+func buildBookingStatusView(state *orchestration.SagaState, loc *i18n.Localizer) BookingStatusView {
+	steps := make([]SagaStepView, 0, len(state.Steps))
+	for _, step := range state.Steps {
+		view := SagaStepView{
+			Name:        loc.T("saga.step." + string(step.Step)),
+			Status:      loc.T("saga.status." + string(step.Status)),
+			StatusClass: sagaStepStatusClass(step.Status),
+			Error:       step.Error,
+		}
+		if !step.UpdatedAt.IsZero() {
+			view.UpdatedAt = loc.DateTime(step.UpdatedAt)
+		}
+		steps = append(steps, view)
+	}
+
+	view := BookingStatusView{
+		ReservationID:      string(state.ReservationID),
+		Steps:              steps,
+		CancellationReason: state.CancellationReason,
+		Failed:             state.Failed(),
+	}
+	if !state.Done() {
+		view.StreamURL = "/ui/bookings/" + string(state.ReservationID) + "/status/stream"
+	}
+	return view
+}
+
+func sagaStepStatusClass(status orchestration.SagaStepStatus) string {
+	switch status {
+	case orchestration.SagaStepRunning:
+		return "info"
+	case orchestration.SagaStepCompleted:
+		return "success"
+	case orchestration.SagaStepFailed:
+		return "danger"
+	case orchestration.SagaStepCompensated:
+		return "warning"
+	default:
+		return "secondary"
+	}
+}
+
+// HttpViewBookingStatus handles GET /ui/bookings/{id}/status.
+// It renders each step of the booking saga; the page subscribes to the event stream until the saga is done.
+func HttpViewBookingStatus(e *templating.Engine, reservationService *reservation.Service, tracker *orchestration.SagaTracker) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID, res, ok := ownReservation(w, r, reservationService)
+		if !ok {
+			return
+		}
+
+		state, err := tracker.GetSagaState(r.Context(), res.ID.Shared())
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to load booking status")
+			return
+		}
+
+		loc := localizer(r)
+		data := HttpViewBookingStatusResponse{
+			AppName:   appName,
+			Title:     appName + " - " + loc.T("saga.title"),
+			SessionID: sessionID,
+			I18n:      loc,
+			Theme:     theme(r),
+			Status:    buildBookingStatusView(state, loc),
+		}
+
+		HttpView(e, "booking_status", data)(w, r)
+	}
+}
+
+// HttpStreamBookingStatus handles GET /ui/bookings/{id}/status/stream.
+// It sends the rendered saga steps as server-sent "saga" events after every change
+// and a "done" event once the saga is done.
+func HttpStreamBookingStatus(e *templating.Engine, reservationService *reservation.Service, tracker *orchestration.SagaTracker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, res, ok := ownReservation(w, r, reservationService)
+		if !ok {
+			return
+		}
+		ctx := r.Context()
+		loc := localizer(r)
+
+		// Watch before reading the current state, so no change is missed.
+		updates := tracker.Watch(ctx, res.ID.Shared())
+		state, err := tracker.GetSagaState(ctx, res.ID.Shared())
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to load booking status")
+			return
+		}
+
+		// The stream outlives the server's write timeout.
+		rc := http.NewResponseController(w)
+		_ = rc.SetWriteDeadline(time.Time{})
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		send := func(state *orchestration.SagaState) bool {
+			var fragment bytes.Buffer
+			data := HttpViewBookingStatusResponse{I18n: loc, Status: buildBookingStatusView(state, loc)}
+			if err := e.Render(&fragment, "booking_status_steps", data); err != nil {
+				return false
+			}
+			if err := writeSSE(w, "saga", fragment.Bytes()); err != nil {
+				return false
+			}
+			if state.Done() {
+				_ = writeSSE(w, "done", []byte(string(state.ReservationID)))
+				_ = rc.Flush()
+				return false
+			}
+			return rc.Flush() == nil
+		}
+
+		if !send(state) {
+			return
+		}
+
+		heartbeat := time.NewTicker(sseHeartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok := <-updates:
+				if !ok || !send(&update) {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := io.WriteString(w, ": ping\n\n"); err != nil || rc.Flush() != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ownReservation loads the reservation of the request path and checks that it
+// belongs to the signed-in guest. It writes the error response otherwise.
+func ownReservation(w http.ResponseWriter, r *http.Request, reservationService *reservation.Service) (string, *reservation.Reservation, bool) {
+	ctx := r.Context()
+
+	session := SessionFromContext(ctx)
+	if !session.Authenticated() {
+		http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+		return "", nil, false
+	}
+
+	reservationID := r.PathValue("id")
+	if reservationID == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Reservation ID required")
+		return "", nil, false
+	}
+
+	res, err := reservationService.GetReservation(ctx, reservation.ReservationID(reservationID))
+	if err != nil {
+		writeProblem(w, r, http.StatusNotFound, "Reservation not found")
+		return "", nil, false
+	}
+
+	if string(res.GuestID) != session.Email {
+		writeProblem(w, r, http.StatusForbidden, "Access denied")
+		return "", nil, false
+	}
+
+	return session.SessionID, res, true
+}
+
+// writeSSE writes a server-sent event. Every line of data becomes a data field.
+func writeSSE(w io.Writer, event string, data []byte) error {
+	var b bytes.Buffer
+	b.WriteString("event: " + event + "\n")
+	for _, line := range bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n")) {
+		b.WriteString("data: ")
+		b.Write(bytes.TrimRight(line, "\r"))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,182 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createBookingStatusTestSetup(t *testing.T, guestEmail string) (*templating.Engine, *reservation.Service, *orchestration.SagaTracker, resource.Access[shared.ReservationID, orchestration.SagaState]) {
+	t.Helper()
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	repo := newMockReservationRepository()
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	res := createTestReservation("res-001", guestEmail, "room-101", checkIn, checkIn.AddDate(0, 0, 3))
+	repo.reservations[reservation.ReservationID("res-001")] = *res
+
+	states := resource.NewInMemoryAccess[shared.ReservationID, orchestration.SagaState]()
+	return e, createDetailTestService(repo), orchestration.NewSagaTracker(states), states
+}
+
+// ============================================================================
+// HttpViewBookingStatus Tests
+// ============================================================================
+
+func Test_HttpViewBookingStatus_Without_Session_Should_Redirect_To_Login(t *testing.T) {
+	// Arrange
+	e, service, tracker, _ := createBookingStatusTestSetup(t, "test@example.com")
+	handler := inbound.HttpViewBookingStatus(e, service, tracker)
+	req := httptest.NewRequest(http.MethodGet, "/ui/bookings/res-001/status", nil)
+	req.SetPathValue("id", "res-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303 (redirect)", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must contain login", rec.Header().Get("Location"), "/ui/login")
+}
+
+func Test_HttpViewBookingStatus_With_Other_User_Reservation_Should_Return_403(t *testing.T) {
+	// Arrange
+	e, service, tracker, _ := createBookingStatusTestSetup(t, "other@example.com")
+	handler := inbound.HttpViewBookingStatus(e, service, tracker)
+	req := httptest.NewRequest(http.MethodGet, "/ui/bookings/res-001/status", nil)
+	req.SetPathValue("id", "res-001")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_HttpViewBookingStatus_With_Pending_Saga_Should_Render_Steps_And_Stream_URL(t *testing.T) {
+	// Arrange
+	e, service, tracker, _ := createBookingStatusTestSetup(t, "test@example.com")
+	handler := inbound.HttpViewBookingStatus(e, service, tracker)
+	req := httptest.NewRequest(http.MethodGet, "/ui/bookings/res-001/status", nil)
+	req.SetPathValue("id", "res-001")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain the stream URL", strings.Contains(body, `data-sse-src="/ui/bookings/res-001/status/stream"`), true)
+	assert.That(t, "body must contain four steps", strings.Count(body, "<li "), 4)
+}
+
+func Test_HttpViewBookingStatus_With_Done_Saga_Should_Not_Render_Stream_URL(t *testing.T) {
+	// Arrange
+	e, service, tracker, states := createBookingStatusTestSetup(t, "test@example.com")
+	state := orchestration.NewSagaState("res-001")
+	for i := range state.Steps {
+		state.Steps[i].Status = orchestration.SagaStepCompleted
+	}
+	_ = states.Create(context.Background(), "res-001", *state)
+
+	handler := inbound.HttpViewBookingStatus(e, service, tracker)
+	req := httptest.NewRequest(http.MethodGet, "/ui/bookings/res-001/status", nil)
+	req.SetPathValue("id", "res-001")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must not contain a stream URL", strings.Contains(rec.Body.String(), "data-sse-src"), false)
+}
+
+// ============================================================================
+// HttpStreamBookingStatus Tests
+// ============================================================================
+
+func Test_HttpStreamBookingStatus_With_Done_Saga_Should_Send_Saga_And_Done_Events(t *testing.T) {
+	// Arrange
+	e, service, tracker, states := createBookingStatusTestSetup(t, "test@example.com")
+	state := orchestration.NewSagaState("res-001")
+	state.Steps[0].Status = orchestration.SagaStepCompensated
+	state.Steps[1].Status = orchestration.SagaStepFailed
+	state.Steps[1].Error = "card declined"
+	_ = states.Create(context.Background(), "res-001", *state)
+
+	handler := inbound.HttpStreamBookingStatus(e, service, tracker)
+	req := httptest.NewRequest(http.MethodGet, "/ui/bookings/res-001/status/stream", nil)
+	req.SetPathValue("id", "res-001")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be text/event-stream", rec.Header().Get("Content-Type"), "text/event-stream")
+	assert.That(t, "body must contain a saga event", strings.Contains(body, "event: saga\ndata: "), true)
+	assert.That(t, "body must contain the step error", strings.Contains(body, "card declined"), true)
+	assert.That(t, "body must end with a done event", strings.HasSuffix(body, "event: done\ndata: res-001\n\n"), true)
+}
+
+func Test_HttpStreamBookingStatus_With_Pending_Saga_Should_Send_Update_After_Change(t *testing.T) {
+	// Arrange
+	e, service, tracker, _ := createBookingStatusTestSetup(t, "test@example.com")
+	dispatcher := messaging.NewInternalDispatcher()
+	_ = tracker.RegisterHandlers(context.Background(), dispatcher)
+	publisher := outbound.NewEventPublisher(dispatcher)
+
+	handler := inbound.HttpStreamBookingStatus(e, service, tracker)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/ui/bookings/res-001/status/stream", nil)
+	req.SetPathValue("id", "res-001")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		handler(rec, req)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	_ = publisher.Publish(ctx, reservation.NewEventCancelled().WithReservationID("res-001").WithReason("guest request"))
+	<-done
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "body must contain two saga events", strings.Count(body, "event: saga\n"), 2)
+	assert.That(t, "body must contain the cancellation reason", strings.Contains(body, "guest request"), true)
+	assert.That(t, "body must end with a done event", strings.HasSuffix(body, "event: done\ndata: res-001\n\n"), true)
+}
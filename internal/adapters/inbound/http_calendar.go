@@ -0,0 +1,67 @@
+package inbound
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/calendar"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/ical"
+)
+
+// HttpExportRoomCalendar handles GET /api/rooms/{id}/calendar.ics.
+// It returns the confirmed reservations of the room as an iCal feed,
+// so external platforms can block the dates.
+func HttpExportRoomCalendar(calendarService *calendar.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roomID := r.PathValue("id")
+		if roomID == "" {
+			writeProblem(w, r, http.StatusBadRequest, "Room ID required")
+			return
+		}
+
+		events, err := calendarService.RoomEvents(r.Context(), reservation.RoomID(roomID))
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to load room calendar")
+			return
+		}
+
+		feed := make([]ical.Event, 0, len(events))
+		for _, evt := range events {
+			feed = append(feed, ical.Event{
+				UID:     evt.UID,
+				Start:   evt.Start,
+				End:     evt.End,
+				AllDay:  true,
+				Summary: evt.Summary,
+			})
+		}
+
+		var buf bytes.Buffer
+		if err := ical.Encode(&buf, "Room "+roomID, feed); err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to encode room calendar")
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="room-`+roomID+`.ics"`)
+		w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+// WithFeedToken requires the secret token as "token" query parameter.
+// Calendar platforms such as Airbnb subscribe to a plain URL and cannot send
+// bearer tokens, so the secret is part of the subscribed link instead.
+func WithFeedToken(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := r.URL.Query().Get("token")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			writeProblem(w, r, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,106 @@
+package inbound_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/calendar"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createCalendarTestServices(t *testing.T) (*reservation.Service, *calendar.Service) {
+	t.Helper()
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	reservationRepo := newMockReservationRepository()
+	reservationService := reservation.NewService(reservationRepo, outbound.NewRepositoryAvailabilityChecker(reservationRepo), publisher)
+	checkIn := time.Now().AddDate(0, 0, 7)
+	_, err := reservationService.PlaceExternalHold(context.Background(), "hold-001", "room-101",
+		reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2)), "airbnb", "evt-1@airbnb.com")
+	assert.That(t, "hold must be placed", err, nil)
+	return reservationService, calendar.NewService(reservationService, outbound.NewICalFeedFetcher(http.DefaultClient))
+}
+
+// ============================================================================
+// HttpExportRoomCalendar Tests
+// ============================================================================
+
+func Test_HttpExportRoomCalendar_Should_Return_ICal_Feed(t *testing.T) {
+	// Arrange
+	_, calendarService := createCalendarTestServices(t)
+	handler := inbound.HttpExportRoomCalendar(calendarService)
+	req := httptest.NewRequest(http.MethodGet, "/api/rooms/room-101/calendar.ics", nil)
+	req.SetPathValue("id", "room-101")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be text/calendar", rec.Header().Get("Content-Type"), "text/calendar; charset=utf-8")
+	assert.That(t, "body must be a calendar", strings.HasPrefix(body, "BEGIN:VCALENDAR\r\n"), true)
+	assert.That(t, "body must contain the hold", strings.Contains(body, "UID:hold-001\r\n"), true)
+	assert.That(t, "dates must be all-day", strings.Contains(body, "DTSTART;VALUE=DATE:"), true)
+}
+
+// ============================================================================
+// Route Tests
+// ============================================================================
+
+func Test_Route_Room_Calendar_With_Feed_Token_Should_Require_Token(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	reservationService, calendarService := createCalendarTestServices(t)
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: reservationService,
+		CalendarService:    calendarService,
+		CalendarFeedToken:  "feed-secret",
+	})
+	withoutToken := httptest.NewRecorder()
+	withToken := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(withoutToken, httptest.NewRequest(http.MethodGet, "/api/rooms/room-101/calendar.ics?token=wrong", nil))
+	mux.ServeHTTP(withToken, httptest.NewRequest(http.MethodGet, "/api/rooms/room-101/calendar.ics?token=feed-secret", nil))
+
+	// Assert
+	assert.That(t, "wrong token must be rejected", withoutToken.Code, http.StatusUnauthorized)
+	assert.That(t, "valid token must be accepted", withToken.Code, http.StatusOK)
+}
+
+func Test_Route_Room_Calendar_Without_Verifier_Or_Token_Should_Return_404(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	reservationService, calendarService := createCalendarTestServices(t)
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: reservationService,
+		CalendarService:    calendarService,
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/rooms/room-101/calendar.ics", nil))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
@@ -0,0 +1,154 @@
+package inbound
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/channel"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// channelSignatureHeader carries the HMAC-SHA256 of the webhook body as "sha256=<hex>".
+const channelSignatureHeader = "X-Channel-Signature"
+
+// maxChannelBookingBytes limits the size of a webhook body.
+const maxChannelBookingBytes = 1 << 20
+
+// ChannelBookingRequest is the webhook payload of a booking made on an OTA.
+type ChannelBookingRequest struct {
+	Channel    string `json:"channel"`
+	ExternalID string `json:"external_id"`
+	RoomID     string `json:"room_id"`
+	CheckIn    string `json:"check_in"`  // 2006-01-02
+	CheckOut   string `json:"check_out"` // 2006-01-02
+	Guest      struct {
+		Name        string `json:"name"`
+		Email       string `json:"email"`
+		PhoneNumber string `json:"phone_number"`
+	} `json:"guest"`
+	Total struct {
+		Amount   string `json:"amount"` // Decimal in major units, e.g. "300.00"
+		Currency string `json:"currency"`
+	} `json:"total"`
+}
+
+// ChannelBookingResponse is returned for an imported booking.
+type ChannelBookingResponse struct {
+	ReservationID string `json:"reservation_id"`
+	Status        string `json:"status"`
+}
+
+// HttpImportChannelBooking handles POST /webhooks/channel/bookings.
+// The channel manager signs each body with the shared secret. Valid bookings are
+// imported as confirmed reservations; repeated deliveries return the same reservation.
+func HttpImportChannelBooking(channelService *channel.Service, secret []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxChannelBookingBytes))
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Failed to read request body")
+			return
+		}
+
+		if !validSignature(secret, body, r.Header.Get(channelSignatureHeader)) {
+			writeProblem(w, r, http.StatusUnauthorized, "Invalid signature")
+			return
+		}
+
+		var req ChannelBookingRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		booking, err := req.toBooking()
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := channelService.ImportBooking(r.Context(), booking)
+		switch {
+		case errors.Is(err, reservation.ErrRoomUnavailable):
+			writeProblem(w, r, http.StatusConflict, err.Error())
+			return
+		case isRejectedBooking(err):
+			writeProblem(w, r, http.StatusUnprocessableEntity, err.Error())
+			return
+		case err != nil:
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to import booking")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, ChannelBookingResponse{
+			ReservationID: string(res.ID),
+			Status:        string(res.Status),
+		})
+	}
+}
+
+// toBooking parses the payload into a channel booking.
+func (req ChannelBookingRequest) toBooking() (channel.Booking, error) {
+	checkIn, err := time.Parse(time.DateOnly, req.CheckIn)
+	if err != nil {
+		return channel.Booking{}, errors.New("invalid check_in, expected YYYY-MM-DD")
+	}
+	checkOut, err := time.Parse(time.DateOnly, req.CheckOut)
+	if err != nil {
+		return channel.Booking{}, errors.New("invalid check_out, expected YYYY-MM-DD")
+	}
+
+	guest, err := reservation.NewGuestInfo(req.Guest.Name, req.Guest.Email, req.Guest.PhoneNumber)
+	if err != nil {
+		return channel.Booking{}, err
+	}
+
+	total, err := shared.ParseAmount(req.Total.Amount, req.Total.Currency)
+	if err != nil {
+		return channel.Booking{}, err
+	}
+
+	return channel.Booking{
+		Channel:     req.Channel,
+		ExternalID:  req.ExternalID,
+		RoomID:      reservation.RoomID(req.RoomID),
+		DateRange:   reservation.NewDateRange(checkIn, checkOut),
+		Guest:       guest,
+		TotalAmount: total,
+	}, nil
+}
+
+// validSignature compares the "sha256=<hex>" signature with the HMAC of the body.
+func validSignature(secret, body []byte, signature string) bool {
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// isRejectedBooking reports whether the booking was rejected by a business rule.
+func isRejectedBooking(err error) bool {
+	for _, target := range []error{
+		channel.ErrInvalidBooking,
+		reservation.ErrInvalidDateRange,
+		reservation.ErrMinimumStay,
+		reservation.ErrCheckInPast,
+		reservation.ErrNoGuests,
+		shared.ErrUnknownCurrency,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package inbound_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/channel"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+var channelTestSecret = []byte("webhook-secret")
+
+type nopChannelSync struct{}
+
+func (nopChannelSync) PushAvailability(ctx context.Context, update channel.AvailabilityUpdate) error {
+	return nil
+}
+
+func createChannelTestServices() (*reservation.Service, *channel.Service) {
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	reservationRepo := newMockReservationRepository()
+	reservationService := reservation.NewService(reservationRepo, outbound.NewRepositoryAvailabilityChecker(reservationRepo), publisher)
+	return reservationService, channel.NewService(reservationService, nopChannelSync{})
+}
+
+func channelBookingBody(checkIn time.Time) string {
+	return `{"channel":"booking.com","external_id":"4711","room_id":"room-101",` +
+		`"check_in":"` + checkIn.Format(time.DateOnly) + `","check_out":"` + checkIn.AddDate(0, 0, 3).Format(time.DateOnly) + `",` +
+		`"guest":{"name":"Jane Doe","email":"jane@example.com"},` +
+		`"total":{"amount":"300.00","currency":"EUR"}}`
+}
+
+func newChannelBookingRequest(body string, secret []byte) *http.Request {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(body))
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/channel/bookings", strings.NewReader(body))
+	req.Header.Set("X-Channel-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+// ============================================================================
+// HttpImportChannelBooking Tests
+// ============================================================================
+
+func Test_HttpImportChannelBooking_With_Valid_Booking_Should_Import_Reservation(t *testing.T) {
+	// Arrange
+	reservationService, channelService := createChannelTestServices()
+	handler := inbound.HttpImportChannelBooking(channelService, channelTestSecret)
+	rec := httptest.NewRecorder()
+	body := channelBookingBody(time.Now().AddDate(0, 0, 7))
+
+	// Act
+	handler(rec, newChannelBookingRequest(body, channelTestSecret))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var resp inbound.ChannelBookingResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.That(t, "reservation ID must be derived from the booking", resp.ReservationID, "booking.com-4711")
+	assert.That(t, "status must be confirmed", resp.Status, "confirmed")
+	res, err := reservationService.GetReservation(context.Background(), "booking.com-4711")
+	assert.That(t, "reservation must be stored", err == nil, true)
+	assert.That(t, "amount must be parsed in minor units", res.TotalAmount.Amount, int64(30000))
+}
+
+func Test_HttpImportChannelBooking_With_Invalid_Signature_Should_Return_401(t *testing.T) {
+	// Arrange
+	_, channelService := createChannelTestServices()
+	handler := inbound.HttpImportChannelBooking(channelService, channelTestSecret)
+	rec := httptest.NewRecorder()
+	body := channelBookingBody(time.Now().AddDate(0, 0, 7))
+
+	// Act
+	handler(rec, newChannelBookingRequest(body, []byte("wrong-secret")))
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
+
+func Test_HttpImportChannelBooking_With_Invalid_Dates_Should_Return_400(t *testing.T) {
+	// Arrange
+	_, channelService := createChannelTestServices()
+	handler := inbound.HttpImportChannelBooking(channelService, channelTestSecret)
+	rec := httptest.NewRecorder()
+	body := `{"channel":"booking.com","external_id":"4711","room_id":"room-101","check_in":"tomorrow"}`
+
+	// Act
+	handler(rec, newChannelBookingRequest(body, channelTestSecret))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpImportChannelBooking_With_Past_Check_In_Should_Return_422(t *testing.T) {
+	// Arrange
+	_, channelService := createChannelTestServices()
+	handler := inbound.HttpImportChannelBooking(channelService, channelTestSecret)
+	rec := httptest.NewRecorder()
+	body := channelBookingBody(time.Now().AddDate(0, 0, -7))
+
+	// Act
+	handler(rec, newChannelBookingRequest(body, channelTestSecret))
+
+	// Assert
+	assert.That(t, "status code must be 422", rec.Code, http.StatusUnprocessableEntity)
+}
+
+func Test_HttpImportChannelBooking_With_Booked_Room_Should_Return_409(t *testing.T) {
+	// Arrange
+	reservationService, channelService := createChannelTestServices()
+	handler := inbound.HttpImportChannelBooking(channelService, channelTestSecret)
+	rec := httptest.NewRecorder()
+	checkIn := time.Now().AddDate(0, 0, 7)
+	_, _ = reservationService.CreateReservation(context.Background(), "res-001", "guest@example.com", "room-101",
+		reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2)), reservation.Money{Amount: 10000, Currency: "EUR"},
+		[]reservation.GuestInfo{{Name: "Guest", Email: "guest@example.com"}})
+
+	// Act
+	handler(rec, newChannelBookingRequest(channelBookingBody(checkIn), channelTestSecret))
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+}
+
+// ============================================================================
+// Route Tests
+// ============================================================================
+
+func Test_Route_Channel_Webhook_Without_Secret_Should_Return_404(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	reservationService, channelService := createChannelTestServices()
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: reservationService,
+		ChannelService:     channelService,
+	})
+	rec := httptest.NewRecorder()
+	body := channelBookingBody(time.Now().AddDate(0, 0, 7))
+
+	// Act
+	mux.ServeHTTP(rec, newChannelBookingRequest(body, nil))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
@@ -0,0 +1,146 @@
+package inbound
+
+import (
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
+)
+
+// DocumentTypeOption represents a document type for the check-in form dropdown.
+type DocumentTypeOption struct {
+	Value string
+	Label string
+}
+
+// RegistrationView represents the registration card of a checked-in reservation.
+type RegistrationView struct {
+	DocumentType string
+	DocumentRef  string
+	CheckedInBy  string
+	CheckedInAt  string
+}
+
+// HttpViewCheckInResponse specifies the view data for the staff check-in page.
+type HttpViewCheckInResponse struct {
+	AppName       string
+	Title         string
+	SessionID     string
+	I18n          *i18n.Localizer
+	Theme         reservation.Theme
+	Reservation   ReservationDetailView
+	CanCheckIn    bool
+	Registration  *RegistrationView
+	DocumentTypes []DocumentTypeOption
+	DocumentType  string
+	DocumentRef   string
+	Error         string
+	FieldErrors   map[string]string
+}
+
+// checkInFormFields maps the fields of registration errors to their form input names.
+var checkInFormFields = map[string]string{
+	"document_type": "document_type",
+	"document_ref":  "document_ref",
+	"signed":        "signed",
+}
+
+// HttpViewCheckIn handles GET /ui/admin/checkin/{id}.
+// It shows the reservation for verification and the registration card form,
+// or the saved card once the guest is checked in.
+func HttpViewCheckIn(e *templating.Engine, reservationService *reservation.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := reservationService.GetReservation(r.Context(), reservation.ReservationID(r.PathValue("id")))
+		if err != nil {
+			writeProblem(w, r, http.StatusNotFound, "Reservation not found")
+			return
+		}
+
+		data := newCheckInResponse(r, appName, res)
+		if res.Status == reservation.StatusActive {
+			if reg, err := reservationService.GetRegistration(r.Context(), res.ID); err == nil {
+				data.Registration = buildRegistrationView(reg, data.I18n)
+			}
+		}
+
+		HttpView(e, "checkin", data)(w, r)
+	}
+}
+
+// HttpCheckIn handles POST /ui/admin/checkin/{id}.
+// It saves the registration card and activates the reservation; invalid details re-render the form.
+func HttpCheckIn(e *templating.Engine, reservationService *reservation.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		res, err := reservationService.GetReservation(ctx, reservation.ReservationID(r.PathValue("id")))
+		if err != nil {
+			writeProblem(w, r, http.StatusNotFound, "Reservation not found")
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+
+		staff := SessionFromContext(ctx).Email
+		details := reservation.RegistrationDetails{
+			DocumentType: reservation.DocumentType(r.FormValue("document_type")),
+			DocumentRef:  r.FormValue("document_ref"),
+			Signed:       r.FormValue("signed") == "on",
+		}
+
+		_, err = reservationService.CheckInReservation(ctx, res.ID, details, staff)
+		if err == nil {
+			http.Redirect(w, r, "/ui/admin/checkin/"+string(res.ID), http.StatusSeeOther)
+			return
+		}
+
+		data := newCheckInResponse(r, appName, res)
+		data.DocumentType = string(details.DocumentType)
+		data.DocumentRef = details.DocumentRef
+		data.Error = err.Error()
+		var verrs reservation.ValidationErrors
+		if errors.As(err, &verrs) {
+			data.Error = "Please correct the highlighted fields"
+			data.FieldErrors = formFieldErrors(err, checkInFormFields)
+		}
+		HttpView(e, "checkin", data)(w, r)
+	}
+}
+
+// newCheckInResponse creates the view data of the check-in page for a reservation.
+func newCheckInResponse(r *http.Request, appName string, res *reservation.Reservation) HttpViewCheckInResponse {
+	loc := localizer(r)
+	return HttpViewCheckInResponse{
+		AppName:     appName,
+		Title:       appName + " - " + loc.T("checkin.title"),
+		SessionID:   SessionFromContext(r.Context()).SessionID,
+		I18n:        loc,
+		Theme:       theme(r),
+		Reservation: buildReservationDetailView(res, loc),
+		CanCheckIn:  res.Status == reservation.StatusConfirmed,
+		DocumentTypes: []DocumentTypeOption{
+			{Value: string(reservation.DocumentPassport), Label: loc.T("checkin.document.passport")},
+			{Value: string(reservation.DocumentIDCard), Label: loc.T("checkin.document.id_card")},
+			{Value: string(reservation.DocumentDrivingLicence), Label: loc.T("checkin.document.driving_licence")},
+		},
+	}
+}
+
+// buildRegistrationView converts a registration card to a localized view.
+func buildRegistrationView(reg *reservation.Registration, loc *i18n.Localizer) *RegistrationView {
+	return &RegistrationView{
+		DocumentType: loc.T("checkin.document." + string(reg.DocumentType)),
+		DocumentRef:  reg.DocumentRef,
+		CheckedInBy:  reg.CheckedInBy,
+		CheckedInAt:  loc.DateTime(reg.CheckedInAt),
+	}
+}
@@ -0,0 +1,142 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createCheckInTestServices(t *testing.T, status reservation.ReservationStatus) (*templating.Engine, *mockReservationRepository, *reservation.Service) {
+	t.Helper()
+	t.Setenv("APP_NAME", "TestApp")
+
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	repo := newMockReservationRepository()
+	checkIn := time.Now().Truncate(24 * time.Hour)
+	res := createTestReservation("res-arrival", "guest@example.com", "room-101", checkIn, checkIn.AddDate(0, 0, 2))
+	res.Status = status
+	repo.reservations[res.ID] = *res
+
+	registrations := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Registration]()
+	return e, repo, createDetailTestService(repo).WithRegistrations(registrations)
+}
+
+func newCheckInRequest(form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/ui/admin/checkin/res-arrival", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetPathValue("id", "res-arrival")
+	return addAuthContext(req, "test-session-123", "staff@example.com")
+}
+
+// ============================================================================
+// HttpViewCheckIn Tests
+// ============================================================================
+
+func Test_HttpViewCheckIn_With_Confirmed_Reservation_Should_Render_Form(t *testing.T) {
+	// Arrange
+	e, _, service := createCheckInTestServices(t, reservation.StatusConfirmed)
+	handler := inbound.HttpViewCheckIn(e, service)
+	req := httptest.NewRequest(http.MethodGet, "/ui/admin/checkin/res-arrival", nil)
+	req.SetPathValue("id", "res-arrival")
+	req = addAuthContext(req, "test-session-123", "staff@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must show the reservation", strings.Contains(body, "Reservation: res-arrival room-101"), true)
+	assert.That(t, "body must contain the form", strings.Contains(body, `action="/ui/admin/checkin/res-arrival"`), true)
+}
+
+func Test_HttpViewCheckIn_With_Pending_Reservation_Should_Not_Render_Form(t *testing.T) {
+	// Arrange
+	e, _, service := createCheckInTestServices(t, reservation.StatusPending)
+	handler := inbound.HttpViewCheckIn(e, service)
+	req := httptest.NewRequest(http.MethodGet, "/ui/admin/checkin/res-arrival", nil)
+	req.SetPathValue("id", "res-arrival")
+	req = addAuthContext(req, "test-session-123", "staff@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "body must not contain the form", strings.Contains(body, "<form"), false)
+	assert.That(t, "body must explain why", strings.Contains(body, "Not ready for check-in"), true)
+}
+
+func Test_HttpViewCheckIn_With_Unknown_Reservation_Should_Return_404(t *testing.T) {
+	// Arrange
+	e, _, service := createCheckInTestServices(t, reservation.StatusConfirmed)
+	handler := inbound.HttpViewCheckIn(e, service)
+	req := httptest.NewRequest(http.MethodGet, "/ui/admin/checkin/res-unknown", nil)
+	req.SetPathValue("id", "res-unknown")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpCheckIn Tests
+// ============================================================================
+
+func Test_HttpCheckIn_With_Valid_Card_Should_Activate_And_Redirect(t *testing.T) {
+	// Arrange
+	e, repo, service := createCheckInTestServices(t, reservation.StatusConfirmed)
+	handler := inbound.HttpCheckIn(e, service)
+	form := url.Values{"document_type": {"passport"}, "document_ref": {"C01X00T47"}, "signed": {"on"}}
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, newCheckInRequest(form))
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be the check-in page", rec.Header().Get("Location"), "/ui/admin/checkin/res-arrival")
+	assert.That(t, "reservation must be active", repo.reservations["res-arrival"].Status, reservation.StatusActive)
+	card, err := service.GetRegistration(context.Background(), "res-arrival")
+	assert.That(t, "card must be saved", err == nil, true)
+	assert.That(t, "card must name the staff member", card.CheckedInBy, "staff@example.com")
+}
+
+func Test_HttpCheckIn_Without_Signature_Should_Render_Field_Error(t *testing.T) {
+	// Arrange
+	e, repo, service := createCheckInTestServices(t, reservation.StatusConfirmed)
+	handler := inbound.HttpCheckIn(e, service)
+	form := url.Values{"document_type": {"passport"}, "document_ref": {"C01X00T47"}}
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, newCheckInRequest(form))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must report the signature", strings.Contains(body, "signed: "+reservation.ErrSignatureRequired.Error()), true)
+	assert.That(t, "body must keep the document reference", strings.Contains(body, "Document Ref: C01X00T47"), true)
+	assert.That(t, "reservation must stay confirmed", repo.reservations["res-arrival"].Status, reservation.StatusConfirmed)
+}
@@ -0,0 +1,47 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// IncidentalsRequest is the payload of an incidentals charge.
+type IncidentalsRequest struct {
+	Amount int64 `json:"amount"` // In the smallest unit of the deposit's currency
+}
+
+// HttpGetDeposit handles GET /api/reservations/{id}/deposit.
+func HttpGetDeposit(depositService *orchestration.DepositService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deposit, err := depositService.GetDeposit(r.Context(), reservation.ReservationID(r.PathValue("id")))
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to process deposit")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, deposit)
+	}
+}
+
+// HttpChargeIncidentals handles POST /api/reservations/{id}/deposit/incidentals.
+// The charges are captured from the deposit when it is released after check-out.
+func HttpChargeIncidentals(depositService *orchestration.DepositService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req IncidentalsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		deposit, err := depositService.ChargeIncidentals(r.Context(), reservation.ReservationID(r.PathValue("id")), req.Amount)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to process deposit")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, deposit)
+	}
+}
@@ -0,0 +1,118 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// createDepositTestService returns a deposit service with a held deposit of 200.00 USD for res-001.
+func createDepositTestService(t *testing.T) *orchestration.DepositService {
+	t.Helper()
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	paymentRepo := outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]())
+	paymentService := payment.NewService(paymentRepo, outbound.NewMockPaymentGateway(), publisher)
+	_, err := paymentService.HoldDeposit(context.Background(), "dep-res-001", "res-001", payment.NewMoney(20000, "USD"), "credit_card")
+	assert.That(t, "deposit must be held", err == nil, true)
+	return orchestration.NewDepositService(nil, paymentService, 20000)
+}
+
+func newIncidentalsRequest(id, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/reservations/"+id+"/deposit/incidentals", strings.NewReader(body))
+	req.SetPathValue("id", id)
+	return req
+}
+
+// ============================================================================
+// HttpGetDeposit Tests
+// ============================================================================
+
+func Test_HttpGetDeposit_Should_Return_Deposit(t *testing.T) {
+	// Arrange
+	service := createDepositTestService(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/reservations/res-001/deposit", nil)
+	req.SetPathValue("id", "res-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpGetDeposit(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var deposit payment.Payment
+	_ = json.Unmarshal(rec.Body.Bytes(), &deposit)
+	assert.That(t, "deposit ID must match", deposit.ID, payment.PaymentID("dep-res-001"))
+	assert.That(t, "deposit must be authorized", deposit.Status, payment.StatusAuthorized)
+}
+
+func Test_HttpGetDeposit_Without_Deposit_Should_Return_404(t *testing.T) {
+	// Arrange
+	service := createDepositTestService(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/reservations/res-999/deposit", nil)
+	req.SetPathValue("id", "res-999")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpGetDeposit(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpChargeIncidentals Tests
+// ============================================================================
+
+func Test_HttpChargeIncidentals_Should_Add_Incidentals(t *testing.T) {
+	// Arrange
+	service := createDepositTestService(t)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpChargeIncidentals(service)(rec, newIncidentalsRequest("res-001", `{"amount":4200}`))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var deposit payment.Payment
+	_ = json.Unmarshal(rec.Body.Bytes(), &deposit)
+	assert.That(t, "incidentals must be charged", deposit.Incidentals, payment.NewMoney(4200, "USD"))
+}
+
+func Test_HttpChargeIncidentals_Exceeding_Deposit_Should_Return_400(t *testing.T) {
+	// Arrange
+	service := createDepositTestService(t)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpChargeIncidentals(service)(rec, newIncidentalsRequest("res-001", `{"amount":20001}`))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpChargeIncidentals_With_Invalid_JSON_Should_Return_400(t *testing.T) {
+	// Arrange
+	service := createDepositTestService(t)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpChargeIncidentals(service)(rec, newIncidentalsRequest("res-001", `{`))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
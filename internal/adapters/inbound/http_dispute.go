@@ -0,0 +1,172 @@
+package inbound
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// paymentSignatureHeader carries the HMAC-SHA256 of the webhook body as "sha256=<hex>".
+const paymentSignatureHeader = "X-Payment-Signature"
+
+// maxDisputeNotificationBytes limits the size of a webhook body.
+const maxDisputeNotificationBytes = 1 << 16
+
+// Dispute notification types sent by the payment gateway.
+const (
+	disputeCreated = "dispute.created"
+	disputeWon     = "dispute.won"
+	disputeLost    = "dispute.lost"
+)
+
+// DisputeNotification is the webhook payload of a dispute reported by the payment gateway.
+type DisputeNotification struct {
+	Type          string `json:"type"` // dispute.created, dispute.won or dispute.lost
+	DisputeID     string `json:"dispute_id"`
+	TransactionID string `json:"transaction_id"`
+	Reason        string `json:"reason"`
+	Amount        struct {
+		Amount   string `json:"amount"` // Decimal in major units, e.g. "300.00"
+		Currency string `json:"currency"`
+	} `json:"amount"`
+	EvidenceDueBy string `json:"evidence_due_by"` // 2006-01-02, optional
+}
+
+// DisputeNotificationResponse is returned for a handled dispute notification.
+type DisputeNotificationResponse struct {
+	PaymentID string `json:"payment_id"`
+	DisputeID string `json:"dispute_id"`
+	Status    string `json:"status"`
+}
+
+// DisputeEvidenceRequest is the payload of the evidence contesting a dispute.
+type DisputeEvidenceRequest struct {
+	Evidence string `json:"evidence"`
+}
+
+// DisputeResolutionRequest is the payload of a dispute decided by the card issuer.
+type DisputeResolutionRequest struct {
+	Outcome string `json:"outcome"` // won or lost
+}
+
+// HttpHandleDisputeNotification handles POST /webhooks/payments/disputes.
+// The payment gateway signs each body with the shared secret. A new dispute
+// freezes the refunds of the payment until the gateway reports the outcome;
+// repeated deliveries leave the dispute unchanged.
+func HttpHandleDisputeNotification(paymentService *payment.Service, secret []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDisputeNotificationBytes))
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Failed to read request body")
+			return
+		}
+
+		if !validSignature(secret, body, r.Header.Get(paymentSignatureHeader)) {
+			writeProblem(w, r, http.StatusUnauthorized, "Invalid signature")
+			return
+		}
+
+		var req DisputeNotification
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		pay, err := paymentService.GetPaymentByTransaction(r.Context(), req.TransactionID)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to process dispute")
+			return
+		}
+
+		switch req.Type {
+		case disputeCreated:
+			amount, err := shared.ParseAmount(req.Amount.Amount, req.Amount.Currency)
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, err.Error())
+				return
+			}
+			var dueBy time.Time
+			if req.EvidenceDueBy != "" {
+				if dueBy, err = time.Parse(time.DateOnly, req.EvidenceDueBy); err != nil {
+					writeProblem(w, r, http.StatusBadRequest, "invalid evidence_due_by, expected YYYY-MM-DD")
+					return
+				}
+			}
+			pay, err = paymentService.OpenDispute(r.Context(), pay.ID, payment.DisputeID(req.DisputeID), req.Reason, amount, dueBy)
+		case disputeWon:
+			pay, err = paymentService.ResolveDispute(r.Context(), pay.ID, payment.DisputeWon)
+		case disputeLost:
+			pay, err = paymentService.ResolveDispute(r.Context(), pay.ID, payment.DisputeLost)
+		default:
+			writeProblem(w, r, http.StatusBadRequest, "Unknown notification type")
+			return
+		}
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to process dispute")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, DisputeNotificationResponse{
+			PaymentID: string(pay.ID),
+			DisputeID: string(pay.Dispute.ID),
+			Status:    string(pay.Dispute.Status),
+		})
+	}
+}
+
+// HttpListDisputes handles GET /api/disputes.
+// It returns the payments with an open dispute.
+func HttpListDisputes(paymentService *payment.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		disputes, err := paymentService.ListDisputes(r.Context())
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to list disputes")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, disputes)
+	}
+}
+
+// HttpSubmitDisputeEvidence handles POST /api/payments/{id}/dispute/evidence.
+func HttpSubmitDisputeEvidence(paymentService *payment.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DisputeEvidenceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		pay, err := paymentService.SubmitDisputeEvidence(r.Context(), payment.PaymentID(r.PathValue("id")), req.Evidence)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to process dispute")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, pay)
+	}
+}
+
+// HttpResolveDispute handles POST /api/payments/{id}/dispute/resolve.
+// Staff record the issuer's decision, e.g. when the hotel accepts a chargeback.
+func HttpResolveDispute(paymentService *payment.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DisputeResolutionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		pay, err := paymentService.ResolveDispute(r.Context(), payment.PaymentID(r.PathValue("id")), payment.DisputeStatus(req.Outcome))
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to process dispute")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, pay)
+	}
+}
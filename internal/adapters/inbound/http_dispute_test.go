@@ -0,0 +1,188 @@
+package inbound_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+var paymentTestSecret = []byte("payment-secret")
+
+// createDisputeTestService returns a payment service with a captured payment of 100.00 USD,
+// transaction "txn_pay-001_10000".
+func createDisputeTestService(t *testing.T) *payment.Service {
+	t.Helper()
+	ctx := context.Background()
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	paymentRepo := outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]())
+	paymentService := payment.NewService(paymentRepo, outbound.NewMockPaymentGateway(), publisher)
+	_, err := paymentService.AuthorizePayment(ctx, "pay-001", "res-001", payment.NewMoney(10000, "USD"), "credit_card")
+	assert.That(t, "payment must be authorized", err == nil, true)
+	assert.That(t, "payment must be captured", paymentService.CapturePayment(ctx, "pay-001") == nil, true)
+	return paymentService
+}
+
+func newDisputeNotification(body string, secret []byte) *http.Request {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(body))
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/payments/disputes", strings.NewReader(body))
+	req.Header.Set("X-Payment-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+const disputeCreatedBody = `{"type":"dispute.created","dispute_id":"dp-001","transaction_id":"txn_pay-001_10000",` +
+	`"reason":"fraudulent","amount":{"amount":"100.00","currency":"USD"},"evidence_due_by":"2030-01-31"}`
+
+func newDisputeRequest(action, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/payments/pay-001/dispute/"+action, strings.NewReader(body))
+	req.SetPathValue("id", "pay-001")
+	return req
+}
+
+// ============================================================================
+// HttpHandleDisputeNotification Tests
+// ============================================================================
+
+func Test_HttpHandleDisputeNotification_With_Created_Dispute_Should_Freeze_Refunds(t *testing.T) {
+	// Arrange
+	paymentService := createDisputeTestService(t)
+	handler := inbound.HttpHandleDisputeNotification(paymentService, paymentTestSecret)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, newDisputeNotification(disputeCreatedBody, paymentTestSecret))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var resp inbound.DisputeNotificationResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.That(t, "dispute must be open", resp.Status, "open")
+	pay, _ := paymentService.GetPayment(context.Background(), "pay-001")
+	assert.That(t, "refunds must be frozen", pay.RefundsFrozen(), true)
+	assert.That(t, "amount must be parsed in minor units", pay.Dispute.Amount.Amount, int64(10000))
+}
+
+func Test_HttpHandleDisputeNotification_With_Lost_Dispute_Should_Resolve_Dispute(t *testing.T) {
+	// Arrange
+	paymentService := createDisputeTestService(t)
+	handler := inbound.HttpHandleDisputeNotification(paymentService, paymentTestSecret)
+	handler(httptest.NewRecorder(), newDisputeNotification(disputeCreatedBody, paymentTestSecret))
+	rec := httptest.NewRecorder()
+	body := `{"type":"dispute.lost","dispute_id":"dp-001","transaction_id":"txn_pay-001_10000"}`
+
+	// Act
+	handler(rec, newDisputeNotification(body, paymentTestSecret))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	pay, _ := paymentService.GetPayment(context.Background(), "pay-001")
+	assert.That(t, "payment must be charged back", pay.ChargedBack(), true)
+}
+
+func Test_HttpHandleDisputeNotification_With_Invalid_Signature_Should_Return_401(t *testing.T) {
+	// Arrange
+	paymentService := createDisputeTestService(t)
+	handler := inbound.HttpHandleDisputeNotification(paymentService, paymentTestSecret)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, newDisputeNotification(disputeCreatedBody, []byte("wrong-secret")))
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
+
+func Test_HttpHandleDisputeNotification_With_Unknown_Transaction_Should_Return_404(t *testing.T) {
+	// Arrange
+	paymentService := createDisputeTestService(t)
+	handler := inbound.HttpHandleDisputeNotification(paymentService, paymentTestSecret)
+	rec := httptest.NewRecorder()
+	body := `{"type":"dispute.won","dispute_id":"dp-001","transaction_id":"txn_unknown"}`
+
+	// Act
+	handler(rec, newDisputeNotification(body, paymentTestSecret))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// Dispute API Tests
+// ============================================================================
+
+func Test_HttpSubmitDisputeEvidence_Should_Record_Evidence(t *testing.T) {
+	// Arrange
+	paymentService := createDisputeTestService(t)
+	inbound.HttpHandleDisputeNotification(paymentService, paymentTestSecret)(httptest.NewRecorder(), newDisputeNotification(disputeCreatedBody, paymentTestSecret))
+	handler := inbound.HttpSubmitDisputeEvidence(paymentService)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, newDisputeRequest("evidence", `{"evidence":"signed registration card"}`))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	pay, _ := paymentService.GetPayment(context.Background(), "pay-001")
+	assert.That(t, "dispute must have evidence submitted", pay.Dispute.Status, payment.DisputeEvidenceSubmitted)
+}
+
+func Test_HttpSubmitDisputeEvidence_Without_Dispute_Should_Return_409(t *testing.T) {
+	// Arrange
+	paymentService := createDisputeTestService(t)
+	handler := inbound.HttpSubmitDisputeEvidence(paymentService)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, newDisputeRequest("evidence", `{"evidence":"signed registration card"}`))
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+}
+
+func Test_HttpResolveDispute_With_Invalid_Outcome_Should_Return_400(t *testing.T) {
+	// Arrange
+	paymentService := createDisputeTestService(t)
+	inbound.HttpHandleDisputeNotification(paymentService, paymentTestSecret)(httptest.NewRecorder(), newDisputeNotification(disputeCreatedBody, paymentTestSecret))
+	handler := inbound.HttpResolveDispute(paymentService)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, newDisputeRequest("resolve", `{"outcome":"settled"}`))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpListDisputes_Should_Return_Open_Disputes(t *testing.T) {
+	// Arrange
+	paymentService := createDisputeTestService(t)
+	inbound.HttpHandleDisputeNotification(paymentService, paymentTestSecret)(httptest.NewRecorder(), newDisputeNotification(disputeCreatedBody, paymentTestSecret))
+	handler := inbound.HttpListDisputes(paymentService)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/api/disputes", nil))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var disputes []payment.Payment
+	_ = json.Unmarshal(rec.Body.Bytes(), &disputes)
+	assert.That(t, "one dispute must be listed", len(disputes), 1)
+}
@@ -5,15 +5,19 @@ import (
 	"os"
 
 	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 )
 
 // HttpViewErrorResponse specifies the view data for error pages.
 type HttpViewErrorResponse struct {
-	AppName      string
-	Title        string
-	ErrorTitle   string
-	ErrorMessage string
-	ErrorDetails string
+	AppName       string
+	Title         string
+	ErrorTitle    string
+	ErrorMessage  string
+	ErrorDetails  string
+	Status        int    // Status code of an error response, 0 on the /ui/error page
+	CorrelationID string // Reference of the request for support, if any
+	Theme         reservation.Theme
 }
 
 // HttpViewError defines an HTTP handler function for rendering the error template.
@@ -38,11 +42,13 @@ func HttpViewError(e *templating.Engine) http.HandlerFunc {
 		}
 
 		data := HttpViewErrorResponse{
-			AppName:      appName,
-			Title:        pageTitle,
-			ErrorTitle:   errorTitle,
-			ErrorMessage: errorMessage,
-			ErrorDetails: errorDetails,
+			AppName:       appName,
+			Title:         pageTitle,
+			ErrorTitle:    errorTitle,
+			ErrorMessage:  errorMessage,
+			ErrorDetails:  errorDetails,
+			CorrelationID: CorrelationID(r.Context()),
+			Theme:         theme(r),
 		}
 
 		HttpView(e, "error", data)(w, r)
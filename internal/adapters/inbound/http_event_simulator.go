@@ -0,0 +1,51 @@
+package inbound
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/domain/admin"
+)
+
+// maxSimulatedEventSize limits the payload of a simulated event.
+const maxSimulatedEventSize = 1 << 20
+
+// SimulatedEventResponse is the response of the event simulator.
+type SimulatedEventResponse struct {
+	Topic string `json:"topic"`
+	Size  int    `json:"size"`
+}
+
+// HttpSimulateEvent handles POST /api/admin/events/{topic}, the event simulator for local development.
+// It publishes the JSON body as the payload of an event of a domain topic (admin.EventTopics)
+// onto the dispatcher, so event handlers and projections can be exercised without the saga.
+// The payload is not validated beyond being JSON: handlers see exactly what was sent.
+func HttpSimulateEvent(dispatcher messaging.Dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		topic := r.PathValue("topic")
+		if !slices.Contains(admin.EventTopics, topic) {
+			writeProblem(w, r, http.StatusBadRequest, "Unknown topic "+topic)
+			return
+		}
+
+		payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSimulatedEventSize))
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Failed to read request body")
+			return
+		}
+		if !json.Valid(payload) {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		if err := dispatcher.Publish(r.Context(), messaging.NewMessage(topic, payload)); err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to publish event")
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, SimulatedEventResponse{Topic: topic, Size: len(payload)})
+	}
+}
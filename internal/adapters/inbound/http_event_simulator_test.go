@@ -0,0 +1,67 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+)
+
+// ============================================================================
+// HttpSimulateEvent Tests
+// ============================================================================
+
+func serveSimulateEvent(dispatcher messaging.Dispatcher, topic, body string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/admin/events/{topic}", inbound.HttpSimulateEvent(dispatcher))
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/events/"+topic, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func Test_HttpSimulateEvent_Should_Publish_Payload(t *testing.T) {
+	// Arrange
+	dispatcher := messaging.NewInternalDispatcher()
+	var received messaging.Message
+	_ = dispatcher.Subscribe(context.Background(), payment.EventTopicCaptured, func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		received = msg
+		return messaging.MessageStateCompleted, nil
+	})
+
+	// Act
+	rec := serveSimulateEvent(dispatcher, payment.EventTopicCaptured, `{"reservation_id":"res-001"}`)
+
+	// Assert
+	assert.That(t, "status code must be 202", rec.Code, http.StatusAccepted)
+	assert.That(t, "topic must match", received.Topic, payment.EventTopicCaptured)
+	assert.That(t, "payload must match", string(received.Data), `{"reservation_id":"res-001"}`)
+}
+
+func Test_HttpSimulateEvent_With_Unknown_Topic_Should_Return_400(t *testing.T) {
+	// Arrange
+	dispatcher := messaging.NewInternalDispatcher()
+
+	// Act
+	rec := serveSimulateEvent(dispatcher, "payment.unknown", `{}`)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpSimulateEvent_With_Invalid_JSON_Should_Return_400(t *testing.T) {
+	// Arrange
+	dispatcher := messaging.NewInternalDispatcher()
+
+	// Act
+	rec := serveSimulateEvent(dispatcher, payment.EventTopicCaptured, `{"reservation_id":`)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
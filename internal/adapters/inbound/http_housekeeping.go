@@ -0,0 +1,65 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/housekeeping"
+)
+
+// AssignTaskRequest is the payload of a task assignment.
+type AssignTaskRequest struct {
+	Assignee string `json:"assignee"`
+}
+
+// HttpListHousekeepingTasks handles GET /api/housekeeping/tasks.
+// The optional status query parameter (open, assigned, done) filters the tasks.
+func HttpListHousekeepingTasks(housekeepingService *housekeeping.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, err := housekeeping.ParseTaskStatus(r.URL.Query().Get("status"))
+		if err != nil {
+			writeDomainError(w, r, err, "Invalid task status")
+			return
+		}
+
+		tasks, err := housekeepingService.ListTasks(r.Context(), status)
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to list housekeeping tasks")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, tasks)
+	}
+}
+
+// HttpAssignHousekeepingTask handles POST /api/housekeeping/tasks/{id}/assign.
+func HttpAssignHousekeepingTask(housekeepingService *housekeeping.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req AssignTaskRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		task, err := housekeepingService.AssignTask(r.Context(), housekeeping.TaskID(r.PathValue("id")), req.Assignee)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to update housekeeping task")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, task)
+	}
+}
+
+// HttpCompleteHousekeepingTask handles POST /api/housekeeping/tasks/{id}/complete.
+func HttpCompleteHousekeepingTask(housekeepingService *housekeeping.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		task, err := housekeepingService.CompleteTask(r.Context(), housekeeping.TaskID(r.PathValue("id")))
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to update housekeeping task")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, task)
+	}
+}
@@ -0,0 +1,167 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/housekeeping"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createHousekeepingTestService(tasks ...housekeeping.Task) *housekeeping.Service {
+	repo := resource.NewInMemoryAccess[housekeeping.TaskID, housekeeping.Task]()
+	for _, task := range tasks {
+		_ = repo.Create(context.Background(), task.ID, task)
+	}
+	return housekeeping.NewService(nil, repo)
+}
+
+func housekeepingTask(id string, status housekeeping.TaskStatus) housekeeping.Task {
+	return housekeeping.Task{
+		ID:            housekeeping.TaskID(id),
+		ReservationID: "res-001",
+		RoomID:        "room-101",
+		Type:          housekeeping.TaskDepartureClean,
+		DueAt:         time.Now().Add(3 * time.Hour),
+		Status:        status,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// ============================================================================
+// HttpListHousekeepingTasks Tests
+// ============================================================================
+
+func Test_HttpListHousekeepingTasks_With_Status_Should_Return_Matching_Tasks(t *testing.T) {
+	// Arrange
+	service := createHousekeepingTestService(
+		housekeepingTask("task-001", housekeeping.TaskOpen),
+		housekeepingTask("task-002", housekeeping.TaskDone),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/api/housekeeping/tasks?status=open", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpListHousekeepingTasks(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var tasks []housekeeping.Task
+	_ = json.Unmarshal(rec.Body.Bytes(), &tasks)
+	assert.That(t, "only the open task must be returned", len(tasks), 1)
+	assert.That(t, "task ID must match", tasks[0].ID, housekeeping.TaskID("task-001"))
+}
+
+func Test_HttpListHousekeepingTasks_With_Unknown_Status_Should_Return_400(t *testing.T) {
+	// Arrange
+	service := createHousekeepingTestService()
+	req := httptest.NewRequest(http.MethodGet, "/api/housekeeping/tasks?status=dirty", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpListHousekeepingTasks(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// HttpAssignHousekeepingTask Tests
+// ============================================================================
+
+func Test_HttpAssignHousekeepingTask_With_Assignee_Should_Return_200(t *testing.T) {
+	// Arrange
+	service := createHousekeepingTestService(housekeepingTask("task-001", housekeeping.TaskOpen))
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/housekeeping/tasks/{id}/assign", inbound.HttpAssignHousekeepingTask(service))
+	req := httptest.NewRequest(http.MethodPost, "/api/housekeeping/tasks/task-001/assign", strings.NewReader(`{"assignee":"Maria"}`))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var task housekeeping.Task
+	_ = json.Unmarshal(rec.Body.Bytes(), &task)
+	assert.That(t, "task must be assigned", task.Status, housekeeping.TaskAssigned)
+	assert.That(t, "assignee must be stored", task.Assignee, "Maria")
+}
+
+func Test_HttpAssignHousekeepingTask_Without_Assignee_Should_Return_400(t *testing.T) {
+	// Arrange
+	service := createHousekeepingTestService(housekeepingTask("task-001", housekeeping.TaskOpen))
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/housekeeping/tasks/{id}/assign", inbound.HttpAssignHousekeepingTask(service))
+	req := httptest.NewRequest(http.MethodPost, "/api/housekeeping/tasks/task-001/assign", strings.NewReader(`{"assignee":" "}`))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpAssignHousekeepingTask_With_Unknown_Task_Should_Return_404(t *testing.T) {
+	// Arrange
+	service := createHousekeepingTestService()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/housekeeping/tasks/{id}/assign", inbound.HttpAssignHousekeepingTask(service))
+	req := httptest.NewRequest(http.MethodPost, "/api/housekeeping/tasks/task-404/assign", strings.NewReader(`{"assignee":"Maria"}`))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpCompleteHousekeepingTask Tests
+// ============================================================================
+
+func Test_HttpCompleteHousekeepingTask_With_Open_Task_Should_Return_200(t *testing.T) {
+	// Arrange
+	service := createHousekeepingTestService(housekeepingTask("task-001", housekeeping.TaskOpen))
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/housekeeping/tasks/{id}/complete", inbound.HttpCompleteHousekeepingTask(service))
+	req := httptest.NewRequest(http.MethodPost, "/api/housekeeping/tasks/task-001/complete", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var task housekeeping.Task
+	_ = json.Unmarshal(rec.Body.Bytes(), &task)
+	assert.That(t, "task must be done", task.Status, housekeeping.TaskDone)
+}
+
+func Test_HttpCompleteHousekeepingTask_With_Done_Task_Should_Return_409(t *testing.T) {
+	// Arrange
+	service := createHousekeepingTestService(housekeepingTask("task-001", housekeeping.TaskDone))
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/housekeeping/tasks/{id}/complete", inbound.HttpCompleteHousekeepingTask(service))
+	req := httptest.NewRequest(http.MethodPost, "/api/housekeeping/tasks/task-001/complete", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+}
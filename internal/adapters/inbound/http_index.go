@@ -5,17 +5,20 @@ import (
 	"os"
 
 	"github.com/andygeiss/cloud-native-utils/templating"
-	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
 )
 
 // HttpViewIndexResponse specifies the view data.
 type HttpViewIndexResponse struct {
 	AppName   string
 	Email     string
+	I18n      *i18n.Localizer
 	Issuer    string
 	Name      string
 	SessionID string
 	Subject   string
+	Theme     reservation.Theme
 	Title     string
 	Verified  bool
 }
@@ -28,16 +31,9 @@ func HttpViewIndex(e *templating.Engine) http.HandlerFunc {
 	title := appName + " - " + os.Getenv("APP_DESCRIPTION")
 
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Make a shortcut for the current context.
-		ctx := r.Context()
-
 		// Check if the user is authenticated.
-		// We check both sessionID and email because:
-		// - sessionID might exist (from cookie) even after logout
-		// - email being empty indicates the session was deleted server-side
-		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
-		email, _ := ctx.Value(web.ContextEmail).(string)
-		if sessionID == "" || email == "" {
+		session := SessionFromContext(r.Context())
+		if !session.Authenticated() {
 			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
 			return
 		}
@@ -45,13 +41,15 @@ func HttpViewIndex(e *templating.Engine) http.HandlerFunc {
 		// Add session-specific data.
 		data := HttpViewIndexResponse{
 			AppName:   appName,
-			Email:     email,
-			Issuer:    ctx.Value(web.ContextIssuer).(string),
-			Name:      ctx.Value(web.ContextName).(string),
-			SessionID: sessionID,
-			Subject:   ctx.Value(web.ContextSubject).(string),
+			Email:     session.Email,
+			I18n:      localizer(r),
+			Issuer:    session.Issuer,
+			Name:      session.Name,
+			SessionID: session.SessionID,
+			Subject:   session.Subject,
+			Theme:     theme(r),
 			Title:     title,
-			Verified:  ctx.Value(web.ContextVerified).(bool),
+			Verified:  session.Verified,
 		}
 
 		// Render the template using the provided engine and data.
@@ -1,7 +1,6 @@
 package inbound_test
 
 import (
-	"context"
 	"embed"
 	"io"
 	"net/http"
@@ -10,7 +9,6 @@ import (
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/templating"
-	"github.com/andygeiss/cloud-native-utils/web"
 	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
 )
 
@@ -57,9 +55,7 @@ func Test_HttpViewIndex_With_Empty_SessionID_Should_Redirect_To_Login(t *testing
 	handler := inbound.HttpViewIndex(e)
 	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
 	// Add empty session ID to context
-	ctx := context.WithValue(req.Context(), web.ContextSessionID, "")
-	ctx = context.WithValue(ctx, web.ContextEmail, "")
-	req = req.WithContext(ctx)
+	req = addAuthContext(req, "", "")
 	rec := httptest.NewRecorder()
 
 	// Act
@@ -80,13 +76,8 @@ func Test_HttpViewIndex_With_SessionID_But_Empty_Email_Should_Redirect_To_Login(
 	handler := inbound.HttpViewIndex(e)
 	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
 	// Session ID exists (from stale cookie) but email is empty (session deleted server-side)
-	ctx := context.WithValue(req.Context(), web.ContextSessionID, "stale-session-id")
-	ctx = context.WithValue(ctx, web.ContextEmail, "")
-	ctx = context.WithValue(ctx, web.ContextIssuer, "")
-	ctx = context.WithValue(ctx, web.ContextName, "")
-	ctx = context.WithValue(ctx, web.ContextSubject, "")
-	ctx = context.WithValue(ctx, web.ContextVerified, false)
-	req = req.WithContext(ctx)
+	session := inbound.SessionContext{SessionID: "stale-session-id"}
+	req = req.WithContext(session.IntoContext(req.Context()))
 	rec := httptest.NewRecorder()
 
 	// Act
@@ -110,14 +101,7 @@ func Test_HttpViewIndex_With_Valid_Session_Should_Return_200(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
 
 	// Add session context values
-	ctx := req.Context()
-	ctx = context.WithValue(ctx, web.ContextSessionID, "test-session-123")
-	ctx = context.WithValue(ctx, web.ContextEmail, "test@example.com")
-	ctx = context.WithValue(ctx, web.ContextIssuer, "https://issuer.example.com")
-	ctx = context.WithValue(ctx, web.ContextName, "Test User")
-	ctx = context.WithValue(ctx, web.ContextSubject, "user-subject-456")
-	ctx = context.WithValue(ctx, web.ContextVerified, true)
-	req = req.WithContext(ctx)
+	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
 
 	// Act
@@ -139,14 +123,7 @@ func Test_HttpViewIndex_With_Valid_Session_Should_Render_User_Data(t *testing.T)
 	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
 
 	// Add session context values
-	ctx := req.Context()
-	ctx = context.WithValue(ctx, web.ContextSessionID, "test-session-123")
-	ctx = context.WithValue(ctx, web.ContextEmail, "test@example.com")
-	ctx = context.WithValue(ctx, web.ContextIssuer, "https://issuer.example.com")
-	ctx = context.WithValue(ctx, web.ContextName, "Test User")
-	ctx = context.WithValue(ctx, web.ContextSubject, "user-subject-456")
-	ctx = context.WithValue(ctx, web.ContextVerified, true)
-	req = req.WithContext(ctx)
+	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
 
 	// Act
@@ -0,0 +1,38 @@
+package inbound
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/invoicing"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// HttpDownloadInvoice handles GET /api/reservations/{id}/invoice.pdf.
+// It returns the stored invoice and generates it on first access.
+// Reservations without a captured payment are rejected with 409 Conflict.
+func HttpDownloadInvoice(reservationService *reservation.Service, invoiceService *invoicing.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reservationID := reservation.ReservationID(r.PathValue("id"))
+		if reservationID == "" {
+			writeProblem(w, r, http.StatusBadRequest, "Reservation ID required")
+			return
+		}
+
+		if _, err := reservationService.GetReservation(r.Context(), reservationID); err != nil {
+			writeProblem(w, r, http.StatusNotFound, "Reservation not found")
+			return
+		}
+
+		data, err := invoiceService.GetInvoice(r.Context(), reservationID.Shared())
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to create invoice")
+			return
+		}
+
+		w.Header().Set("Content-Type", invoiceService.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="invoice-`+string(reservationID)+`.pdf"`)
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		_, _ = w.Write(data)
+	}
+}
@@ -0,0 +1,140 @@
+package inbound_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoicing"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type invoiceTestServices struct {
+	reservationService *reservation.Service
+	paymentService     *payment.Service
+	invoiceService     *invoicing.Service
+}
+
+func createInvoiceTestServices(t *testing.T) *invoiceTestServices {
+	t.Helper()
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	reservationRepo := newMockReservationRepository()
+	reservationService := reservation.NewService(reservationRepo, outbound.NewRepositoryAvailabilityChecker(reservationRepo), publisher)
+	paymentRepo := outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]())
+	paymentService := payment.NewService(paymentRepo, outbound.NewMockPaymentGateway(), publisher)
+	invoiceService := invoicing.NewService(reservationService, paymentService,
+		outbound.NewPDFInvoiceRenderer(), outbound.NewFileDocumentRepository(t.TempDir()))
+	return &invoiceTestServices{
+		reservationService: reservationService,
+		paymentService:     paymentService,
+		invoiceService:     invoiceService,
+	}
+}
+
+func createInvoiceTestReservation(t *testing.T, svc *invoiceTestServices, captured bool) {
+	t.Helper()
+	ctx := context.Background()
+	checkIn := time.Now().AddDate(0, 0, 7)
+	amount := payment.NewMoney(19800, "USD")
+	guests := []reservation.GuestInfo{{Name: "Test Guest", Email: "guest@example.com"}}
+	_, err := svc.reservationService.CreateReservation(ctx, "res-001", "guest@example.com", "room-101",
+		reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2)), amount, guests)
+	assert.That(t, "reservation must be created", err == nil, true)
+	if captured {
+		_, _ = svc.paymentService.AuthorizePayment(ctx, "pay-001", "res-001", amount, "credit_card")
+		assert.That(t, "payment must be captured", svc.paymentService.CapturePayment(ctx, "pay-001"), nil)
+	}
+}
+
+func newInvoiceRequest(id string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/reservations/"+id+"/invoice.pdf", nil)
+	req.SetPathValue("id", id)
+	return req
+}
+
+// ============================================================================
+// HttpDownloadInvoice Tests
+// ============================================================================
+
+func Test_HttpDownloadInvoice_With_Captured_Payment_Should_Return_PDF(t *testing.T) {
+	// Arrange
+	svc := createInvoiceTestServices(t)
+	createInvoiceTestReservation(t, svc, true)
+	handler := inbound.HttpDownloadInvoice(svc.reservationService, svc.invoiceService)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, newInvoiceRequest("res-001"))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be PDF", rec.Header().Get("Content-Type"), "application/pdf")
+	assert.That(t, "content disposition must name the file", rec.Header().Get("Content-Disposition"), `attachment; filename="invoice-res-001.pdf"`)
+	assert.That(t, "body must be a PDF document", bytes.HasPrefix(rec.Body.Bytes(), []byte("%PDF-")), true)
+}
+
+func Test_HttpDownloadInvoice_Without_Captured_Payment_Should_Return_409(t *testing.T) {
+	// Arrange
+	svc := createInvoiceTestServices(t)
+	createInvoiceTestReservation(t, svc, false)
+	handler := inbound.HttpDownloadInvoice(svc.reservationService, svc.invoiceService)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, newInvoiceRequest("res-001"))
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+}
+
+func Test_HttpDownloadInvoice_With_Unknown_Reservation_Should_Return_404(t *testing.T) {
+	// Arrange
+	svc := createInvoiceTestServices(t)
+	handler := inbound.HttpDownloadInvoice(svc.reservationService, svc.invoiceService)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, newInvoiceRequest("res-unknown"))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// Route Tests
+// ============================================================================
+
+func Test_Route_Invoice_API_Without_Verifier_Should_Return_404(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	svc := createInvoiceTestServices(t)
+	createInvoiceTestReservation(t, svc, true)
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: svc.reservationService,
+		InvoiceService:     svc.invoiceService,
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/reservations/res-001/invoice.pdf", nil))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
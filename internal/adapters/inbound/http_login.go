@@ -5,16 +5,30 @@ import (
 	"os"
 
 	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
 )
 
 // HttpViewLoginResponse specifies the view data.
 type HttpViewLoginResponse struct {
-	AppName string
-	Title   string
+	AppName   string
+	Title     string
+	I18n      *i18n.Localizer
+	Theme     reservation.Theme
+	MagicLink bool   // Shows the passwordless sign-in form
+	Message   string // Confirmation after a sign-in link was requested
+	Error     string
+	Fields    FormFields // Inputs of the sign-in form
+	// ConfirmToken is the token of an opened sign-in link; the page then only
+	// shows the confirmation that exchanges it for a session.
+	ConfirmToken string
+	CSRFToken    string // Bound to the cookie set with the confirmation
 }
 
 // HttpViewLogin defines an HTTP handler function for rendering the login template.
-func HttpViewLogin(e *templating.Engine) http.HandlerFunc {
+// If magicLink is set, the page also offers the passwordless sign-in form.
+// The page only depends on the locale and theme, so it is rendered once per combination.
+func HttpViewLogin(e *templating.Engine, magicLink bool) http.HandlerFunc {
 	// Retrieve application details from environment variables at startup.
 	// We can reuse these values instead of reading them from the environment on each request.
 	appName := os.Getenv("APP_NAME")
@@ -22,11 +36,24 @@ func HttpViewLogin(e *templating.Engine) http.HandlerFunc {
 
 	// Create the Data Object (DTO) once at startup.
 	data := HttpViewLoginResponse{
-		AppName: appName,
-		Title:   title,
+		AppName:   appName,
+		Title:     title,
+		MagicLink: magicLink,
 	}
+	cache := NewRenderCache()
 
 	return func(w http.ResponseWriter, r *http.Request) {
-		HttpView(e, "login", data)(w, r)
+		view := data
+		view.I18n = localizer(r)
+		view.Theme = theme(r)
+		view.Fields = loginFormFields(view.I18n, "")
+		HttpCachedView(e, cache, "login", view.I18n.Lang()+"/"+string(view.Theme), view)(w, r)
+	}
+}
+
+// loginFormFields returns the inputs of the passwordless sign-in form.
+func loginFormFields(loc *i18n.Localizer, email string) FormFields {
+	return FormFields{
+		"email": {ID: "email", Label: loc.T("login.email"), Type: "email", Value: email, Required: true, Autocomplete: "email"},
 	}
 }
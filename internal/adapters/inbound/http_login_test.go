@@ -31,7 +31,7 @@ func Test_HttpViewLogin_With_Request_Should_Return_200(t *testing.T) {
 	e := templating.NewEngine(loginTestAssets)
 	e.Parse("testdata/assets/templates/*.tmpl")
 
-	handler := inbound.HttpViewLogin(e)
+	handler := inbound.HttpViewLogin(e, false)
 	req := httptest.NewRequest(http.MethodGet, "/ui/login", nil)
 	rec := httptest.NewRecorder()
 
@@ -50,7 +50,7 @@ func Test_HttpViewLogin_With_Request_Should_Render_Template(t *testing.T) {
 	e := templating.NewEngine(loginTestAssets)
 	e.Parse("testdata/assets/templates/*.tmpl")
 
-	handler := inbound.HttpViewLogin(e)
+	handler := inbound.HttpViewLogin(e, false)
 	req := httptest.NewRequest(http.MethodGet, "/ui/login", nil)
 	rec := httptest.NewRecorder()
 
@@ -71,7 +71,7 @@ func Test_HttpViewLogin_With_Request_Should_Return_HTML_Content_Type(t *testing.
 	e := templating.NewEngine(loginTestAssets)
 	e.Parse("testdata/assets/templates/*.tmpl")
 
-	handler := inbound.HttpViewLogin(e)
+	handler := inbound.HttpViewLogin(e, false)
 	req := httptest.NewRequest(http.MethodGet, "/ui/login", nil)
 	rec := httptest.NewRecorder()
 
@@ -0,0 +1,28 @@
+package inbound
+
+import (
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// HttpListNotifications handles GET /api/reservations/{id}/notifications.
+// It returns the delivery status of the reservation's guest notifications, oldest first.
+func HttpListNotifications(reservationService *reservation.Service, tracker *orchestration.NotificationTracker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reservationID := reservation.ReservationID(r.PathValue("id"))
+		if _, err := reservationService.GetReservation(r.Context(), reservationID); err != nil {
+			writeProblem(w, r, http.StatusNotFound, "Reservation not found")
+			return
+		}
+
+		jobs, err := tracker.ListNotifications(r.Context(), reservationID.Shared())
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to list notifications")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, jobs)
+	}
+}
@@ -0,0 +1,97 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type failingGuestNotifier struct{}
+
+func (failingGuestNotifier) SendReservationConfirmation(ctx context.Context, r *reservation.Reservation) error {
+	return errors.New("smtp down")
+}
+
+func (failingGuestNotifier) SendCancellationNotice(ctx context.Context, r *reservation.Reservation, reason string) error {
+	return errors.New("smtp down")
+}
+
+func (failingGuestNotifier) SendPaymentReceipt(ctx context.Context, p *payment.Payment, attachments ...orchestration.Attachment) error {
+	return errors.New("smtp down")
+}
+
+func (failingGuestNotifier) SendNoShowNotice(ctx context.Context, r *reservation.Reservation, fee shared.Money) error {
+	return errors.New("smtp down")
+}
+
+func (failingGuestNotifier) SendBalanceReminder(ctx context.Context, r *reservation.Reservation) error {
+	return errors.New("smtp down")
+}
+
+func (failingGuestNotifier) SendPreArrivalReminder(ctx context.Context, r *reservation.Reservation, instructions, upsellURL string) error {
+	return errors.New("smtp down")
+}
+
+func (failingGuestNotifier) SendReviewRequest(ctx context.Context, r *reservation.Reservation, link string) error {
+	return errors.New("smtp down")
+}
+
+// ============================================================================
+// HttpListNotifications Tests
+// ============================================================================
+
+func Test_HttpListNotifications_Should_Return_Delivery_Status(t *testing.T) {
+	// Arrange
+	reservationService, _ := createPreviewTestServices(t)
+	tracker := orchestration.NewNotificationTracker(reservationService, nil, failingGuestNotifier{},
+		resource.NewInMemoryAccess[orchestration.NotificationJobID, orchestration.NotificationJob]())
+	res, _ := reservationService.GetReservation(context.Background(), "res-001")
+	_ = tracker.SendReservationConfirmation(context.Background(), res)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/reservations/{id}/notifications", inbound.HttpListNotifications(reservationService, tracker))
+	req := httptest.NewRequest(http.MethodGet, "/api/reservations/res-001/notifications", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var jobs []orchestration.NotificationJob
+	_ = json.Unmarshal(rec.Body.Bytes(), &jobs)
+	assert.That(t, "one job must be listed", len(jobs), 1)
+	assert.That(t, "job must be failed", jobs[0].Status, orchestration.NotificationFailed)
+	assert.That(t, "error must be recorded", jobs[0].LastError, "smtp down")
+}
+
+func Test_HttpListNotifications_With_Unknown_Reservation_Should_Return_404(t *testing.T) {
+	// Arrange
+	reservationService, _ := createPreviewTestServices(t)
+	tracker := orchestration.NewNotificationTracker(reservationService, nil, failingGuestNotifier{},
+		resource.NewInMemoryAccess[orchestration.NotificationJobID, orchestration.NotificationJob]())
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/reservations/{id}/notifications", inbound.HttpListNotifications(reservationService, tracker))
+	req := httptest.NewRequest(http.MethodGet, "/api/reservations/res-404/notifications", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
@@ -0,0 +1,92 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// NotificationPreferencesRequest is the payload of the channels a guest is notified on,
+// in order of preference. ByType overrides the order per message type, and OptOut lists
+// the message types the guest does not want.
+type NotificationPreferencesRequest struct {
+	Channels []reservation.NotificationChannel            `json:"channels"`
+	ByType   map[string][]reservation.NotificationChannel `json:"by_type,omitempty"`
+	OptOut   []string                                     `json:"opt_out,omitempty"`
+}
+
+// NotificationPreferencesResponse describes the notification preferences of a guest.
+type NotificationPreferencesResponse struct {
+	GuestID  string                                       `json:"guest_id"`
+	Channels []reservation.NotificationChannel            `json:"channels"`
+	ByType   map[string][]reservation.NotificationChannel `json:"by_type,omitempty"`
+	OptOut   []string                                     `json:"opt_out,omitempty"`
+}
+
+// newNotificationPreferencesResponse converts the preferences of a profile into their API representation.
+// Guests without preferences are shown the email default.
+func newNotificationPreferencesResponse(profile *reservation.GuestProfile) NotificationPreferencesResponse {
+	return NotificationPreferencesResponse{
+		GuestID:  string(profile.GuestID),
+		Channels: profile.Notifications.ChannelsFor(""),
+		ByType:   profile.Notifications.ByType,
+		OptOut:   profile.Notifications.OptOut,
+	}
+}
+
+// HttpGetNotificationPreferences handles GET /api/guests/{id}/notification-preferences.
+func HttpGetNotificationPreferences(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		profile, err := reservationService.GetGuestProfile(r.Context(), reservation.GuestID(r.PathValue("id")))
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to read notification preferences")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newNotificationPreferencesResponse(profile))
+	}
+}
+
+// HttpUpdateNotificationPreferences handles PUT /api/guests/{id}/notification-preferences.
+// The keys of by_type and the entries of opt_out must be notification types, e.g. "balance_reminder".
+func HttpUpdateNotificationPreferences(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req NotificationPreferencesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+		for messageType := range req.ByType {
+			if _, err := orchestration.ParseNotificationType(messageType); err != nil {
+				writeDomainError(w, r, err, "Invalid notification type")
+				return
+			}
+		}
+
+		for _, messageType := range req.OptOut {
+			if _, err := orchestration.ParseNotificationType(messageType); err != nil {
+				writeDomainError(w, r, err, "Invalid notification type")
+				return
+			}
+		}
+
+		prefs, err := reservation.NewNotificationPreferences(req.Channels, req.ByType)
+		if err == nil {
+			prefs, err = prefs.WithOptOut(req.OptOut)
+		}
+		if err != nil {
+			writeDomainError(w, r, err, "Invalid notification preferences")
+			return
+		}
+
+		profile, err := reservationService.UpdateNotificationPreferences(r.Context(), reservation.GuestID(r.PathValue("id")), prefs)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to save notification preferences")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newNotificationPreferencesResponse(profile))
+	}
+}
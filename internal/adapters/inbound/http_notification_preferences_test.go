@@ -0,0 +1,112 @@
+package inbound_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createNotificationPreferencesMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+	service := createTestReservationService(t).
+		WithGuestProfiles(outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]()))
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/guests/{id}/notification-preferences", inbound.HttpGetNotificationPreferences(service))
+	mux.HandleFunc("PUT /api/guests/{id}/notification-preferences", inbound.HttpUpdateNotificationPreferences(service))
+	return mux
+}
+
+// ============================================================================
+// Notification Preferences Tests
+// ============================================================================
+
+func Test_HttpGetNotificationPreferences_Without_Preferences_Should_Return_Email(t *testing.T) {
+	// Arrange
+	mux := createNotificationPreferencesMux(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/guests/guest-001/notification-preferences", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var resp inbound.NotificationPreferencesResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.That(t, "channels must default to email", resp.Channels, []reservation.NotificationChannel{reservation.ChannelEmail})
+}
+
+func Test_HttpUpdateNotificationPreferences_Should_Save_Preferences(t *testing.T) {
+	// Arrange
+	mux := createNotificationPreferencesMux(t)
+	body := `{"channels":["push","email"],"by_type":{"balance_reminder":["sms"]}}`
+	req := httptest.NewRequest(http.MethodPut, "/api/guests/guest-001/notification-preferences", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	get := httptest.NewRecorder()
+	mux.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/api/guests/guest-001/notification-preferences", nil))
+	var resp inbound.NotificationPreferencesResponse
+	_ = json.Unmarshal(get.Body.Bytes(), &resp)
+	assert.That(t, "channels must be saved", resp.Channels, []reservation.NotificationChannel{reservation.ChannelPush, reservation.ChannelEmail})
+	assert.That(t, "override must be saved", resp.ByType["balance_reminder"], []reservation.NotificationChannel{reservation.ChannelSMS})
+}
+
+func Test_HttpUpdateNotificationPreferences_With_Opt_Out_Should_Save_Opt_Out(t *testing.T) {
+	// Arrange
+	mux := createNotificationPreferencesMux(t)
+	body := `{"channels":["email"],"opt_out":["pre_arrival"]}`
+	req := httptest.NewRequest(http.MethodPut, "/api/guests/guest-001/notification-preferences", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var resp inbound.NotificationPreferencesResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.That(t, "opt-out must be saved", resp.OptOut, []string{"pre_arrival"})
+}
+
+func Test_HttpUpdateNotificationPreferences_With_Unknown_Channel_Should_Return_400(t *testing.T) {
+	// Arrange
+	mux := createNotificationPreferencesMux(t)
+	req := httptest.NewRequest(http.MethodPut, "/api/guests/guest-001/notification-preferences", strings.NewReader(`{"channels":["fax"]}`))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpUpdateNotificationPreferences_With_Unknown_Message_Type_Should_Return_400(t *testing.T) {
+	// Arrange
+	mux := createNotificationPreferencesMux(t)
+	req := httptest.NewRequest(http.MethodPut, "/api/guests/guest-001/notification-preferences", strings.NewReader(`{"by_type":{"newsletter":["sms"]}}`))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
@@ -0,0 +1,47 @@
+package inbound
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// HttpPreviewNotification handles GET /api/admin/notifications/preview.
+// It renders the notification of ?type= for the reservation ?reservationId= without
+// sending it, in ?locale= or the guest's preferred locale. Reservations without the
+// data of the template, e.g. a balance reminder without payment schedule, get 409 Conflict.
+func HttpPreviewNotification(reservationService *reservation.Service, previewService *orchestration.NotificationPreviewService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		notificationType, err := orchestration.ParseNotificationType(query.Get("type"))
+		if err != nil {
+			writeDomainError(w, r, err, "Invalid notification type")
+			return
+		}
+		reservationID := reservation.ReservationID(query.Get("reservationId"))
+		if reservationID == "" {
+			writeProblem(w, r, http.StatusBadRequest, "Reservation ID required")
+			return
+		}
+
+		res, err := reservationService.GetReservation(r.Context(), reservationID)
+		if err != nil {
+			writeProblem(w, r, http.StatusNotFound, "Reservation not found")
+			return
+		}
+
+		msg, err := previewService.PreviewNotification(r.Context(), notificationType, res, query.Get("locale"))
+		switch {
+		case errors.Is(err, reservation.ErrNoPaymentSchedule), errors.Is(err, reservation.ErrNoGuests):
+			writeProblem(w, r, http.StatusConflict, err.Error())
+			return
+		case err != nil:
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to render notification")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, msg)
+	}
+}
@@ -0,0 +1,102 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// createPreviewTestServices returns a reservation service with the reservation res-001
+// of 300.00 USD and a preview service that renders with the mock notification service.
+func createPreviewTestServices(t *testing.T) (*reservation.Service, *orchestration.NotificationPreviewService) {
+	t.Helper()
+	reservationService := createTestReservationService(t)
+	checkIn := time.Now().AddDate(0, 0, 7)
+	_, err := reservationService.CreateReservation(context.Background(), "res-001", "guest@example.com", "room-101",
+		reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 3)), shared.NewMoney(30000, "USD"),
+		[]reservation.GuestInfo{{Name: "Test Guest", Email: "guest@example.com"}})
+	assert.That(t, "reservation must be created", err == nil, true)
+
+	renderer := outbound.NewMockNotificationService(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	noShows := orchestration.NewNoShowService(reservationService, nil, renderer)
+	return reservationService, orchestration.NewNotificationPreviewService(noShows, renderer)
+}
+
+// ============================================================================
+// HttpPreviewNotification Tests
+// ============================================================================
+
+func Test_HttpPreviewNotification_Should_Render_Notification_In_Locale(t *testing.T) {
+	// Arrange
+	reservationService, previewService := createPreviewTestServices(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/notifications/preview?type=confirmation&reservationId=res-001&locale=de", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpPreviewNotification(reservationService, previewService)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var msg orchestration.Notification
+	_ = json.Unmarshal(rec.Body.Bytes(), &msg)
+	assert.That(t, "locale must be de", msg.Locale, "de")
+	assert.That(t, "recipient must be the guest", msg.To, "guest@example.com")
+	assert.That(t, "subject must name the reservation", strings.Contains(msg.Subject, "res-001"), true)
+	assert.That(t, "fields must hold the guest name", msg.Fields["guest_name"], "Test Guest")
+}
+
+func Test_HttpPreviewNotification_With_Unknown_Type_Should_Return_400(t *testing.T) {
+	// Arrange
+	reservationService, previewService := createPreviewTestServices(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/notifications/preview?type=newsletter&reservationId=res-001", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpPreviewNotification(reservationService, previewService)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpPreviewNotification_With_Unknown_Reservation_Should_Return_404(t *testing.T) {
+	// Arrange
+	reservationService, previewService := createPreviewTestServices(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/notifications/preview?type=confirmation&reservationId=res-404", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpPreviewNotification(reservationService, previewService)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+func Test_HttpPreviewNotification_Balance_Reminder_Without_Schedule_Should_Return_409(t *testing.T) {
+	// Arrange
+	reservationService, previewService := createPreviewTestServices(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/notifications/preview?type=balance_reminder&reservationId=res-001", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpPreviewNotification(reservationService, previewService)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+}
@@ -0,0 +1,99 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// PaymentMethodRequest is the payload of a card tokenized by the payment gateway.
+// The card number never reaches the hotel, only the gateway's token does.
+type PaymentMethodRequest struct {
+	Token    string `json:"token"`
+	Brand    string `json:"brand"`
+	Last4    string `json:"last4"`
+	ExpMonth int    `json:"exp_month"`
+	ExpYear  int    `json:"exp_year"`
+}
+
+// PaymentMethodResponse describes a stored payment method without its token.
+type PaymentMethodResponse struct {
+	ID       string `json:"id"`
+	GuestID  string `json:"guest_id"`
+	Brand    string `json:"brand"`
+	Last4    string `json:"last4"`
+	ExpMonth int    `json:"exp_month"`
+	ExpYear  int    `json:"exp_year"`
+}
+
+// newPaymentMethodResponse converts a payment method into its API representation.
+func newPaymentMethodResponse(method *payment.PaymentMethod) PaymentMethodResponse {
+	return PaymentMethodResponse{
+		ID:       string(method.ID),
+		GuestID:  string(method.GuestID),
+		Brand:    method.Brand,
+		Last4:    method.Last4,
+		ExpMonth: method.ExpMonth,
+		ExpYear:  method.ExpYear,
+	}
+}
+
+// HttpListPaymentMethods handles GET /api/guests/{id}/payment-methods.
+// It returns the guest's stored payment methods, newest first.
+func HttpListPaymentMethods(paymentService *payment.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		methods, err := paymentService.ListPaymentMethods(r.Context(), payment.GuestID(r.PathValue("id")))
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to process payment method")
+			return
+		}
+
+		response := make([]PaymentMethodResponse, 0, len(methods))
+		for i := range methods {
+			response = append(response, newPaymentMethodResponse(&methods[i]))
+		}
+		writeJSON(w, http.StatusOK, response)
+	}
+}
+
+// HttpAddPaymentMethod handles POST /api/guests/{id}/payment-methods.
+// The newest method is charged for the guest's next booking and balances.
+func HttpAddPaymentMethod(paymentService *payment.Service, ids shared.IDGenerator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req PaymentMethodRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		method, err := paymentService.AddPaymentMethod(r.Context(),
+			payment.PaymentMethodID(ids.NewID()),
+			payment.GuestID(r.PathValue("id")),
+			req.Token, req.Brand, req.Last4, req.ExpMonth, req.ExpYear,
+		)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to process payment method")
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, newPaymentMethodResponse(method))
+	}
+}
+
+// HttpDeletePaymentMethod handles DELETE /api/guests/{id}/payment-methods/{method}.
+func HttpDeletePaymentMethod(paymentService *payment.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := paymentService.RemovePaymentMethod(r.Context(),
+			payment.GuestID(r.PathValue("id")),
+			payment.PaymentMethodID(r.PathValue("method")),
+		)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to process payment method")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
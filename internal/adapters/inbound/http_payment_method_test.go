@@ -0,0 +1,139 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createPaymentMethodTestService() *payment.Service {
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	paymentRepo := outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]())
+	return payment.NewService(paymentRepo, outbound.NewMockPaymentGateway(), publisher).
+		WithPaymentMethods(resource.NewInMemoryAccess[payment.PaymentMethodID, payment.PaymentMethod]())
+}
+
+func paymentMethodBody(token string) string {
+	return `{"token":"` + token + `","brand":"Visa","last4":"4242","exp_month":12,"exp_year":` + strconv.Itoa(time.Now().Year()+2) + `}`
+}
+
+// ============================================================================
+// HttpAddPaymentMethod Tests
+// ============================================================================
+
+func Test_HttpAddPaymentMethod_With_Token_Should_Return_201_Without_Token(t *testing.T) {
+	// Arrange
+	service := createPaymentMethodTestService()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/guests/{id}/payment-methods", inbound.HttpAddPaymentMethod(service, fixedIDGenerator{id: "pm-001"}))
+	req := httptest.NewRequest(http.MethodPost, "/api/guests/guest-001/payment-methods", strings.NewReader(paymentMethodBody("tok_visa_4242")))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	var resp inbound.PaymentMethodResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.That(t, "ID must be generated", resp.ID, "pm-001")
+	assert.That(t, "guest must be taken from the path", resp.GuestID, "guest-001")
+	assert.That(t, "token must not be returned", strings.Contains(rec.Body.String(), "tok_visa_4242"), false)
+}
+
+func Test_HttpAddPaymentMethod_With_Card_Number_Should_Return_400(t *testing.T) {
+	// Arrange
+	service := createPaymentMethodTestService()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/guests/{id}/payment-methods", inbound.HttpAddPaymentMethod(service, fixedIDGenerator{id: "pm-001"}))
+	req := httptest.NewRequest(http.MethodPost, "/api/guests/guest-001/payment-methods", strings.NewReader(paymentMethodBody("4242424242424242")))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+	methods, _ := service.ListPaymentMethods(context.Background(), "guest-001")
+	assert.That(t, "card must not be stored", len(methods), 0)
+}
+
+// ============================================================================
+// HttpListPaymentMethods Tests
+// ============================================================================
+
+func Test_HttpListPaymentMethods_Should_Return_Methods_Of_Guest(t *testing.T) {
+	// Arrange
+	service := createPaymentMethodTestService()
+	ctx := context.Background()
+	_, _ = service.AddPaymentMethod(ctx, "pm-001", "guest-001", "tok_visa_4242", "visa", "4242", 12, time.Now().Year()+2)
+	_, _ = service.AddPaymentMethod(ctx, "pm-002", "guest-002", "tok_visa_1111", "visa", "1111", 12, time.Now().Year()+2)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/guests/{id}/payment-methods", inbound.HttpListPaymentMethods(service))
+	req := httptest.NewRequest(http.MethodGet, "/api/guests/guest-001/payment-methods", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var resp []inbound.PaymentMethodResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.That(t, "only the guest's method must be listed", len(resp), 1)
+	assert.That(t, "method must match", resp[0].Last4, "4242")
+}
+
+// ============================================================================
+// HttpDeletePaymentMethod Tests
+// ============================================================================
+
+func Test_HttpDeletePaymentMethod_Should_Return_204(t *testing.T) {
+	// Arrange
+	service := createPaymentMethodTestService()
+	_, _ = service.AddPaymentMethod(context.Background(), "pm-001", "guest-001", "tok_visa_4242", "visa", "4242", 12, time.Now().Year()+2)
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /api/guests/{id}/payment-methods/{method}", inbound.HttpDeletePaymentMethod(service))
+	req := httptest.NewRequest(http.MethodDelete, "/api/guests/guest-001/payment-methods/pm-001", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+	methods, _ := service.ListPaymentMethods(context.Background(), "guest-001")
+	assert.That(t, "method must be removed", len(methods), 0)
+}
+
+func Test_HttpDeletePaymentMethod_Of_Other_Guest_Should_Return_404(t *testing.T) {
+	// Arrange
+	service := createPaymentMethodTestService()
+	_, _ = service.AddPaymentMethod(context.Background(), "pm-001", "guest-001", "tok_visa_4242", "visa", "4242", 12, time.Now().Year()+2)
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /api/guests/{id}/payment-methods/{method}", inbound.HttpDeletePaymentMethod(service))
+	req := httptest.NewRequest(http.MethodDelete, "/api/guests/guest-002/payment-methods/pm-001", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
@@ -0,0 +1,58 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/privacy"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// HttpExportGuestData handles GET /api/privacy/guests/{id}/export.
+// It returns all data stored about the guest as a JSON download.
+func HttpExportGuestData(privacyService *privacy.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		guestID := r.PathValue("id")
+		if guestID == "" {
+			writeProblem(w, r, http.StatusBadRequest, "Guest ID required")
+			return
+		}
+
+		export, err := privacyService.ExportGuestData(r.Context(), reservation.GuestID(guestID))
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to export guest data")
+			return
+		}
+
+		w.Header().Set("Content-Disposition", `attachment; filename="guest-data.json"`)
+		writeJSON(w, http.StatusOK, export)
+	}
+}
+
+// HttpEraseGuestData handles POST /api/privacy/guests/{id}/erase.
+// It anonymizes the guest's personal data and returns the erasure report.
+// Guests with open reservations are rejected with 409 Conflict.
+func HttpEraseGuestData(privacyService *privacy.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		guestID := r.PathValue("id")
+		if guestID == "" {
+			writeProblem(w, r, http.StatusBadRequest, "Guest ID required")
+			return
+		}
+
+		report, err := privacyService.EraseGuestData(r.Context(), reservation.GuestID(guestID))
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to erase guest data")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, report)
+	}
+}
+
+// writeJSON encodes v as the JSON response body.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
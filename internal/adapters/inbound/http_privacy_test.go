@@ -0,0 +1,157 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/privacy"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createPrivacyTestServices(t *testing.T) (*reservation.Service, *privacy.Service) {
+	t.Helper()
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	reservationRepo := newMockReservationRepository()
+	reservationService := reservation.NewService(reservationRepo, outbound.NewRepositoryAvailabilityChecker(reservationRepo), publisher)
+	paymentRepo := outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]())
+	paymentService := payment.NewService(paymentRepo, outbound.NewMockPaymentGateway(), publisher)
+	return reservationService, privacy.NewService(reservationService, paymentService)
+}
+
+func createPrivacyTestReservation(t *testing.T, service *reservation.Service, guestID string) {
+	t.Helper()
+	checkIn := time.Now().AddDate(0, 0, 7)
+	guests := []reservation.GuestInfo{{Name: "Test Guest", Email: reservation.Email(guestID)}}
+	_, err := service.CreateReservation(context.Background(), "res-001", reservation.GuestID(guestID), "room-101",
+		reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2)), payment.NewMoney(19800, "USD"), guests)
+	assert.That(t, "reservation must be created", err == nil, true)
+}
+
+// ============================================================================
+// HttpExportGuestData Tests
+// ============================================================================
+
+func Test_HttpExportGuestData_Should_Return_Guest_Data_As_JSON(t *testing.T) {
+	// Arrange
+	reservationService, privacyService := createPrivacyTestServices(t)
+	createPrivacyTestReservation(t, reservationService, "guest@example.com")
+	handler := inbound.HttpExportGuestData(privacyService)
+	req := httptest.NewRequest(http.MethodGet, "/api/privacy/guests/guest@example.com/export", nil)
+	req.SetPathValue("id", "guest@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be JSON", rec.Header().Get("Content-Type"), "application/json")
+	var export privacy.GuestDataExport
+	_ = json.NewDecoder(rec.Body).Decode(&export)
+	assert.That(t, "guest ID must match", export.GuestID, reservation.GuestID("guest@example.com"))
+	assert.That(t, "must export one reservation", len(export.Reservations), 1)
+}
+
+// ============================================================================
+// HttpEraseGuestData Tests
+// ============================================================================
+
+func Test_HttpEraseGuestData_With_Cancelled_Reservation_Should_Return_Report(t *testing.T) {
+	// Arrange
+	reservationService, privacyService := createPrivacyTestServices(t)
+	createPrivacyTestReservation(t, reservationService, "guest@example.com")
+	_ = reservationService.CancelReservation(context.Background(), "res-001", "guest request")
+	handler := inbound.HttpEraseGuestData(privacyService)
+	req := httptest.NewRequest(http.MethodPost, "/api/privacy/guests/guest@example.com/erase", nil)
+	req.SetPathValue("id", "guest@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var report privacy.ErasureReport
+	_ = json.NewDecoder(rec.Body).Decode(&report)
+	assert.That(t, "one reservation must be anonymized", len(report.AnonymizedReservations), 1)
+}
+
+func Test_HttpEraseGuestData_With_Open_Reservation_Should_Return_409(t *testing.T) {
+	// Arrange
+	reservationService, privacyService := createPrivacyTestServices(t)
+	createPrivacyTestReservation(t, reservationService, "guest@example.com")
+	handler := inbound.HttpEraseGuestData(privacyService)
+	req := httptest.NewRequest(http.MethodPost, "/api/privacy/guests/guest@example.com/erase", nil)
+	req.SetPathValue("id", "guest@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+}
+
+// ============================================================================
+// Route Tests
+// ============================================================================
+
+func Test_Route_Privacy_API_Without_Verifier_Should_Return_404(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	reservationService, privacyService := createPrivacyTestServices(t)
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: reservationService,
+		PrivacyService:     privacyService,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/privacy/guests/guest@example.com/export", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+func Test_Route_Privacy_API_Without_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	reservationService, privacyService := createPrivacyTestServices(t)
+	verifier := oidc.NewVerifier("http://localhost:8180/realms/local", &oidc.StaticKeySet{}, &oidc.Config{ClientID: "hotel-booking-mcp"})
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: reservationService,
+		PrivacyService:     privacyService,
+		Verifier:           verifier,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/privacy/guests/guest@example.com/erase", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
@@ -0,0 +1,240 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/calendar"
+	"github.com/andygeiss/hotel-booking/internal/domain/channel"
+	"github.com/andygeiss/hotel-booking/internal/domain/housekeeping"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoicing"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/review"
+	"github.com/andygeiss/hotel-booking/internal/domain/search"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ProblemContentType is the media type of the error responses of the API (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase is the prefix of the type URIs of the problem catalog.
+// Errors outside the catalog use "about:blank", whose title is the status text.
+const problemTypeBase = "urn:hotel-booking:problem:"
+
+// Problem is the body of an error response (RFC 7807 problem details).
+// CorrelationID identifies the request in the logs of support requests.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	CorrelationID string         `json:"correlationId,omitempty"`
+	Errors        []ProblemField `json:"errors,omitempty"` // Invalid fields of a validation error
+}
+
+// ProblemField is an invalid field of a problem.
+type ProblemField struct {
+	Field  string `json:"field"`
+	Detail string `json:"detail"`
+}
+
+// problemType is an entry of the problem catalog: the status and type of a domain error.
+type problemType struct {
+	err    error
+	status int
+	code   string // Last segment of the type URI
+	title  string
+}
+
+// problemCatalog maps the domain errors to problem types. The first match wins,
+// so errors that wrap others (e.g. ErrPolicyViolation) come first.
+var problemCatalog = []problemType{
+	{reservation.ErrPolicyViolation, http.StatusUnprocessableEntity, "policy-violation", "Booking policy violated"},
+	{reservation.ErrRoomUnavailable, http.StatusConflict, "room-unavailable", "Room unavailable"},
+	{reservation.ErrRoomBlockNotFound, http.StatusNotFound, "room-block-not-found", "Room block not found"},
+	{reservation.ErrAddOnNotFound, http.StatusNotFound, "add-on-not-found", "Add-on not found"},
+	{reservation.ErrReservationOpen, http.StatusConflict, "reservation-open", "Reservation still open"},
+	{reservation.ErrInvalidStateTransition, http.StatusConflict, "reservation-state-conflict", "Reservation state conflict"},
+	{reservation.ErrCannotCancelNearCheckIn, http.StatusConflict, "reservation-state-conflict", "Reservation state conflict"},
+	{reservation.ErrCannotCancelActive, http.StatusConflict, "reservation-state-conflict", "Reservation state conflict"},
+	{reservation.ErrCannotCancelCompleted, http.StatusConflict, "reservation-state-conflict", "Reservation state conflict"},
+	{reservation.ErrAlreadyCancelled, http.StatusConflict, "reservation-state-conflict", "Reservation state conflict"},
+	{reservation.ErrCannotCancelNoShow, http.StatusConflict, "reservation-state-conflict", "Reservation state conflict"},
+	{reservation.ErrAlreadyNoShow, http.StatusConflict, "reservation-state-conflict", "Reservation state conflict"},
+	{reservation.ErrNoPaymentSchedule, http.StatusConflict, "no-payment-schedule", "No payment schedule"},
+	{reservation.ErrAddOnAlreadyBooked, http.StatusConflict, "add-on-conflict", "Add-on conflict"},
+	{reservation.ErrAddOnsClosed, http.StatusConflict, "add-on-conflict", "Add-on conflict"},
+	{reservation.ErrAddOnPaidWithBalance, http.StatusConflict, "add-on-conflict", "Add-on conflict"},
+	{reservation.ErrProfilesUnavailable, http.StatusNotImplemented, "not-configured", "Feature not configured"},
+	{reservation.ErrRoomBlocksUnavailable, http.StatusNotImplemented, "not-configured", "Feature not configured"},
+	{reservation.ErrRegistrationsUnavailable, http.StatusNotImplemented, "not-configured", "Feature not configured"},
+	{reservation.ErrAddOnsUnavailable, http.StatusNotImplemented, "not-configured", "Feature not configured"},
+	{reservation.ErrInvalidDateRange, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrCheckInPast, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrMinimumStay, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrNoGuests, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrInvalidEmail, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrInvalidPhoneNumber, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrNameRequired, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrInvalidLocale, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrUnknownTheme, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrUnknownNotificationChannel, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrDuplicateNotificationChannel, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrMessageTypeRequired, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrInvalidDocumentType, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrDocumentRefRequired, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrSignatureRequired, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrInvalidBlockReason, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrRoomRequired, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrInvalidAddOnKind, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{reservation.ErrAddOnNotPriced, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{payment.ErrPaymentNotFound, http.StatusNotFound, "payment-not-found", "Payment not found"},
+	{payment.ErrPaymentMethodNotFound, http.StatusNotFound, "payment-method-not-found", "Payment method not found"},
+	{payment.ErrPaymentMethodsUnavailable, http.StatusNotImplemented, "not-configured", "Feature not configured"},
+	{payment.ErrNoDispute, http.StatusConflict, "dispute-conflict", "Dispute conflict"},
+	{payment.ErrAlreadyDisputed, http.StatusConflict, "dispute-conflict", "Dispute conflict"},
+	{payment.ErrDisputeResolved, http.StatusConflict, "dispute-conflict", "Dispute conflict"},
+	{payment.ErrCannotDispute, http.StatusConflict, "dispute-conflict", "Dispute conflict"},
+	{payment.ErrNotAuthorized, http.StatusConflict, "payment-state-conflict", "Payment state conflict"},
+	{payment.ErrRefundsFrozen, http.StatusConflict, "payment-state-conflict", "Payment state conflict"},
+	{payment.ErrPaymentChargedBack, http.StatusConflict, "payment-state-conflict", "Payment state conflict"},
+	{payment.ErrGuestRequired, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{payment.ErrTokenRequired, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{payment.ErrCardNumberNotAllowed, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{payment.ErrInvalidLast4, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{payment.ErrInvalidExpiry, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{payment.ErrCardExpired, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{payment.ErrMissingEvidence, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{payment.ErrInvalidDisputeOutcome, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{payment.ErrInvalidDisputeAmount, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{payment.ErrIncidentalsExceedDeposit, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{housekeeping.ErrTaskNotFound, http.StatusNotFound, "task-not-found", "Housekeeping task not found"},
+	{housekeeping.ErrInvalidTaskTransition, http.StatusConflict, "task-state-conflict", "Housekeeping task state conflict"},
+	{housekeeping.ErrAssigneeRequired, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{housekeeping.ErrInvalidTaskStatus, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{review.ErrReviewNotFound, http.StatusNotFound, "review-not-found", "Review not found"},
+	{review.ErrInvalidReviewToken, http.StatusUnauthorized, "invalid-review-link", "Invalid review link"},
+	{review.ErrInvalidReviewTransition, http.StatusConflict, "review-state-conflict", "Review state conflict"},
+	{review.ErrInvalidRating, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{review.ErrCommentTooLong, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{review.ErrInvalidReviewStatus, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{review.ErrInvalidModeration, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{invoicing.ErrNotInvoiceable, http.StatusConflict, "not-invoiceable", "Reservation not invoiceable"},
+	{orchestration.ErrUnknownNotificationType, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{channel.ErrInvalidBooking, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{search.ErrInvalidQuery, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{calendar.ErrInvalidFeed, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{shared.ErrUnknownCurrency, http.StatusBadRequest, "invalid-input", "Invalid input"},
+	{shared.ErrInvalidAmount, http.StatusBadRequest, "invalid-input", "Invalid input"},
+}
+
+// errorPagesContextKey is the context key of the error pages of UI routes.
+type errorPagesContextKey struct{}
+
+// errorPages renders problems as the error page of the UI.
+type errorPages struct {
+	appName string
+	e       *templating.Engine
+}
+
+// WithErrorPages renders the error responses of UI routes as error pages instead of
+// problem+json bodies, which remain the default of API routes.
+func WithErrorPages(e *templating.Engine, next http.HandlerFunc) http.HandlerFunc {
+	pages := &errorPages{appName: os.Getenv("APP_NAME"), e: e}
+	return func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), errorPagesContextKey{}, pages)))
+	}
+}
+
+// writeProblem writes an error response with the status and a detail message.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	renderProblem(w, r, Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	})
+}
+
+// writeDomainError writes an error response for a domain error, using the status and
+// type of the problem catalog. Errors outside the catalog are internal server errors,
+// which show the fallback message instead of the error.
+func writeDomainError(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+	pt, ok := lookupProblemType(err)
+	if !ok {
+		writeProblem(w, r, http.StatusInternalServerError, fallback)
+		return
+	}
+
+	problem := Problem{
+		Type:   problemTypeBase + pt.code,
+		Title:  pt.title,
+		Status: pt.status,
+		Detail: err.Error(),
+	}
+	var verrs reservation.ValidationErrors
+	if errors.As(err, &verrs) {
+		for _, ferr := range verrs {
+			problem.Errors = append(problem.Errors, ProblemField{Field: ferr.Field, Detail: ferr.Err.Error()})
+		}
+	}
+	renderProblem(w, r, problem)
+}
+
+// lookupProblemType returns the first entry of the problem catalog that matches err.
+func lookupProblemType(err error) (problemType, bool) {
+	for _, pt := range problemCatalog {
+		if errors.Is(err, pt.err) {
+			return pt, true
+		}
+	}
+	return problemType{}, false
+}
+
+// renderProblem completes the problem with the request and writes it as an error page
+// on UI routes, or as a problem+json body otherwise.
+func renderProblem(w http.ResponseWriter, r *http.Request, problem Problem) {
+	problem.Instance = r.URL.Path
+	problem.CorrelationID = CorrelationID(r.Context())
+
+	if pages, ok := r.Context().Value(errorPagesContextKey{}).(*errorPages); ok {
+		if page, err := pages.render(r, problem); err == nil {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			w.WriteHeader(problem.Status)
+			_, _ = w.Write(page)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// render renders the error page of a problem.
+func (p *errorPages) render(r *http.Request, problem Problem) ([]byte, error) {
+	data := HttpViewErrorResponse{
+		AppName:       p.appName,
+		Title:         p.appName + " - " + problem.Title,
+		ErrorTitle:    problem.Title,
+		ErrorMessage:  problem.Detail,
+		Status:        problem.Status,
+		CorrelationID: problem.CorrelationID,
+		Theme:         theme(r),
+	}
+	var buf bytes.Buffer
+	if err := p.e.Render(&buf, "error", data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,106 @@
+package inbound_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/housekeeping"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Problem Details Tests
+// ============================================================================
+
+func Test_Problem_With_Catalog_Error_Should_Return_Problem_Type(t *testing.T) {
+	// Arrange
+	service := createHousekeepingTestService(housekeepingTask("task-001", housekeeping.TaskDone))
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/housekeeping/tasks/{id}/complete", inbound.HttpCompleteHousekeepingTask(service))
+	handler := inbound.WithCorrelationID(shared.NewUUIDv7Generator(), mux)
+	req := httptest.NewRequest(http.MethodPost, "/api/housekeeping/tasks/task-001/complete", nil)
+	req.Header.Set(inbound.CorrelationIDHeader, "req-123")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	var problem inbound.Problem
+	err := json.Unmarshal(rec.Body.Bytes(), &problem)
+	assert.That(t, "body must be valid JSON", err, nil)
+	assert.That(t, "content type must be problem+json", rec.Header().Get("Content-Type"), inbound.ProblemContentType)
+	assert.That(t, "status must be 409", problem.Status, http.StatusConflict)
+	assert.That(t, "type must name the catalog entry", problem.Type, "urn:hotel-booking:problem:task-state-conflict")
+	assert.That(t, "detail must be the domain error", strings.HasPrefix(problem.Detail, housekeeping.ErrInvalidTaskTransition.Error()), true)
+	assert.That(t, "instance must be the request path", problem.Instance, "/api/housekeeping/tasks/task-001/complete")
+	assert.That(t, "correlation ID must be included", problem.CorrelationID, "req-123")
+}
+
+func Test_Problem_With_Status_Only_Should_Return_About_Blank(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest(http.MethodGet, "/api/reservations//invoice.pdf", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpDownloadInvoice(nil, nil)(rec, req)
+
+	// Assert
+	var problem inbound.Problem
+	_ = json.Unmarshal(rec.Body.Bytes(), &problem)
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+	assert.That(t, "type must be about:blank", problem.Type, "about:blank")
+	assert.That(t, "title must be the status text", problem.Title, "Bad Request")
+	assert.That(t, "detail must be the message", problem.Detail, "Reservation ID required")
+}
+
+func Test_Problem_With_Invalid_Input_Should_Return_400(t *testing.T) {
+	// Arrange
+	service := createHousekeepingTestService()
+	req := httptest.NewRequest(http.MethodGet, "/api/housekeeping/tasks?status=unknown", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpListHousekeepingTasks(service)(rec, req)
+
+	// Assert
+	var problem inbound.Problem
+	_ = json.Unmarshal(rec.Body.Bytes(), &problem)
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+	assert.That(t, "type must be invalid input", problem.Type, "urn:hotel-booking:problem:invalid-input")
+}
+
+// ============================================================================
+// WithErrorPages Tests
+// ============================================================================
+
+func Test_WithErrorPages_Should_Render_Error_Page_With_Correlation_ID(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(errorTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	service := createDetailTestService(newMockReservationRepository())
+	handler := inbound.WithCorrelationID(shared.NewUUIDv7Generator(), inbound.WithErrorPages(e, inbound.HttpViewReservationDetail(e, service)))
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/nonexistent", nil)
+	req.SetPathValue("id", "nonexistent")
+	req.Header.Set(inbound.CorrelationIDHeader, "req-123")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+	assert.That(t, "content type must be HTML", strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html"), true)
+	assert.That(t, "body must contain the title", strings.Contains(body, "Not Found"), true)
+	assert.That(t, "body must contain the message", strings.Contains(body, "Reservation not found"), true)
+	assert.That(t, "body must contain the correlation ID", strings.Contains(body, "Reference: req-123"), true)
+}
@@ -0,0 +1,150 @@
+package inbound
+
+import (
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
+)
+
+// HttpViewProfileResponse specifies the view data for the guest's account page.
+type HttpViewProfileResponse struct {
+	AppName      string
+	Title        string
+	SessionID    string
+	I18n         *i18n.Localizer
+	Theme        reservation.Theme
+	Email        string
+	Name         string
+	PhoneNumber  string
+	Locale       string
+	Locales      []i18n.Option
+	Saved        bool
+	Error        string
+	FieldErrors  map[string]string
+	Reservations []ReservationListItem
+}
+
+// profileFormFields maps GuestProfile fields to their form input names.
+var profileFormFields = map[string]string{
+	"name":         "profile_name",
+	"phone_number": "profile_phone",
+	"locale":       "profile_locale",
+}
+
+// HttpViewProfile defines an HTTP handler function for rendering the guest's account page.
+// It shows the editable profile and the guest's own reservations.
+func HttpViewProfile(e *templating.Engine, reservationService *reservation.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - My Account"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		session := SessionFromContext(ctx)
+		if !session.Authenticated() {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		guestID := reservation.GuestID(session.Email)
+		profile, err := reservationService.GetGuestProfile(ctx, guestID)
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to load profile")
+			return
+		}
+
+		// Fall back to the name of the identity provider until the guest saved a profile.
+		name := profile.Name
+		if name == "" {
+			name = session.Name
+		}
+
+		loc := localizer(r)
+		data := HttpViewProfileResponse{
+			AppName:      appName,
+			Title:        title,
+			SessionID:    session.SessionID,
+			I18n:         loc,
+			Theme:        theme(r),
+			Email:        session.Email,
+			Name:         name,
+			PhoneNumber:  string(profile.PhoneNumber),
+			Locale:       profile.Locale,
+			Locales:      i18n.Supported(),
+			Saved:        r.URL.Query().Get("saved") == "1",
+			Reservations: listGuestReservations(r, reservationService, guestID, loc),
+		}
+
+		HttpView(e, "profile", data)(w, r)
+	}
+}
+
+// HttpUpdateProfile handles the POST request to update the guest's profile.
+// The guest is always the logged-in user, so a guest can only edit their own profile.
+func HttpUpdateProfile(e *templating.Engine, reservationService *reservation.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - My Account"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		session := SessionFromContext(ctx)
+		if !session.Authenticated() {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+
+		guestID := reservation.GuestID(session.Email)
+		_, err := reservationService.UpdateGuestProfile(ctx, guestID, r.FormValue("profile_name"), r.FormValue("profile_phone"), r.FormValue("profile_locale"))
+		if err != nil {
+			loc := localizer(r)
+			data := HttpViewProfileResponse{
+				AppName:      appName,
+				Title:        title,
+				SessionID:    session.SessionID,
+				I18n:         loc,
+				Theme:        theme(r),
+				Email:        session.Email,
+				Name:         r.FormValue("profile_name"),
+				PhoneNumber:  r.FormValue("profile_phone"),
+				Locale:       r.FormValue("profile_locale"),
+				Locales:      i18n.Supported(),
+				Reservations: listGuestReservations(r, reservationService, guestID, loc),
+			}
+			fieldErrors := formFieldErrors(err, profileFormFields)
+			switch {
+			case errors.Is(err, reservation.ErrProfilesUnavailable):
+				data.Error = "Profiles are not available"
+			case fieldErrors != nil:
+				data.Error = "Please correct the highlighted fields"
+				data.FieldErrors = fieldErrors
+			default:
+				writeProblem(w, r, http.StatusInternalServerError, "Failed to save profile")
+				return
+			}
+			HttpView(e, "profile", data)(w, r)
+			return
+		}
+
+		http.Redirect(w, r, "/ui/profile?saved=1", http.StatusSeeOther)
+	}
+}
+
+// listGuestReservations returns the view items of the guest's reservations.
+// A failing lookup is treated as an empty list, like on the reservations page.
+func listGuestReservations(r *http.Request, reservationService *reservation.Service, guestID reservation.GuestID, loc *i18n.Localizer) []ReservationListItem {
+	reservations, err := reservationService.ListReservationsByGuest(r.Context(), guestID)
+	if err != nil {
+		return []ReservationListItem{}
+	}
+	return buildReservationListItems(reservations, loc)
+}
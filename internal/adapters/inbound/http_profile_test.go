@@ -0,0 +1,224 @@
+package inbound_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createProfileTestService(repo *mockReservationRepository) (*reservation.Service, *outbound.GuestProfileRepository) {
+	profiles := outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]())
+	return createReservationsTestService(repo).WithGuestProfiles(profiles), profiles
+}
+
+func createProfileTestEngine(t *testing.T) *templating.Engine {
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+	e := templating.NewEngine(reservationsTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	return e
+}
+
+func newProfileFormRequest(name, phone string) *http.Request {
+	form := url.Values{}
+	form.Set("profile_name", name)
+	form.Set("profile_phone", phone)
+	req := httptest.NewRequest(http.MethodPost, "/ui/profile", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// ============================================================================
+// HttpViewProfile Tests
+// ============================================================================
+
+func Test_HttpViewProfile_Without_Session_Should_Redirect_To_Login(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, _ := createProfileTestService(newMockReservationRepository())
+	handler := inbound.HttpViewProfile(e, service)
+	req := httptest.NewRequest(http.MethodGet, "/ui/profile", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303 (redirect)", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be login", rec.Header().Get("Location"), "/ui/login")
+}
+
+func Test_HttpViewProfile_Without_Saved_Profile_Should_Use_Identity_Name(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, _ := createProfileTestService(newMockReservationRepository())
+	handler := inbound.HttpViewProfile(e, service)
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/profile", nil), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain email", strings.Contains(string(body), "Email: test@example.com"), true)
+	assert.That(t, "body must contain identity name", strings.Contains(string(body), "Name: Test User"), true)
+}
+
+func Test_HttpViewProfile_With_Saved_Profile_Should_Render_Profile(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, profiles := createProfileTestService(newMockReservationRepository())
+	_ = profiles.SaveProfile(context.Background(), reservation.GuestProfile{GuestID: "test@example.com", Name: "Jane Doe", PhoneNumber: "+1 555 0100"})
+	handler := inbound.HttpViewProfile(e, service)
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/profile?saved=1", nil), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "body must contain saved name", strings.Contains(string(body), "Name: Jane Doe"), true)
+	assert.That(t, "body must contain saved phone", strings.Contains(string(body), "Phone: +1 555 0100"), true)
+	assert.That(t, "body must contain saved message", strings.Contains(string(body), "Profile saved"), true)
+}
+
+func Test_HttpViewProfile_Should_List_Only_Own_Reservations(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	repo := newMockReservationRepository()
+	checkIn := time.Now().AddDate(0, 0, 30)
+	checkOut := checkIn.AddDate(0, 0, 2)
+	_ = repo.Create(context.Background(), "res-own", *createTestReservation("res-own", "test@example.com", "room-101", checkIn, checkOut))
+	_ = repo.Create(context.Background(), "res-other", *createTestReservation("res-other", "other@example.com", "room-102", checkIn, checkOut))
+	service, _ := createProfileTestService(repo)
+	handler := inbound.HttpViewProfile(e, service)
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/profile", nil), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "body must contain own reservation", strings.Contains(string(body), "res-own"), true)
+	assert.That(t, "body must not contain other reservation", strings.Contains(string(body), "res-other"), false)
+	assert.That(t, "own reservation must be cancellable", strings.Contains(string(body), "cancellable"), true)
+}
+
+// ============================================================================
+// HttpUpdateProfile Tests
+// ============================================================================
+
+func Test_HttpUpdateProfile_Without_Session_Should_Redirect_To_Login(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, _ := createProfileTestService(newMockReservationRepository())
+	handler := inbound.HttpUpdateProfile(e, service)
+	req := newProfileFormRequest("Jane Doe", "")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303 (redirect)", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be login", rec.Header().Get("Location"), "/ui/login")
+}
+
+func Test_HttpUpdateProfile_With_Valid_Input_Should_Save_And_Redirect(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, profiles := createProfileTestService(newMockReservationRepository())
+	handler := inbound.HttpUpdateProfile(e, service)
+	req := addAuthContext(newProfileFormRequest("Jane Doe", "+1 555 0100"), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	profile, _ := profiles.FindProfile(context.Background(), "test@example.com")
+	assert.That(t, "status code must be 303 (redirect)", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be profile page", rec.Header().Get("Location"), "/ui/profile?saved=1")
+	assert.That(t, "profile name must be saved", profile.Name, "Jane Doe")
+	assert.That(t, "profile phone must be saved", profile.PhoneNumber, reservation.PhoneNumber("+15550100"))
+}
+
+func Test_HttpUpdateProfile_With_Invalid_Input_Should_Render_Field_Errors(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, profiles := createProfileTestService(newMockReservationRepository())
+	handler := inbound.HttpUpdateProfile(e, service)
+	req := addAuthContext(newProfileFormRequest("  ", "not a phone"), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	profile, _ := profiles.FindProfile(context.Background(), "test@example.com")
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain name error", strings.Contains(string(body), "profile_name:"), true)
+	assert.That(t, "body must contain phone error", strings.Contains(string(body), "profile_phone:"), true)
+	assert.That(t, "body must keep submitted phone", strings.Contains(string(body), "Phone: not a phone"), true)
+	assert.That(t, "profile must not be saved", profile == nil, true)
+}
+
+func Test_HttpUpdateProfile_Without_Profile_Repository_Should_Render_Error(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service := createReservationsTestService(newMockReservationRepository())
+	handler := inbound.HttpUpdateProfile(e, service)
+	req := addAuthContext(newProfileFormRequest("Jane Doe", ""), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain error", strings.Contains(string(body), "Profiles are not available"), true)
+}
+
+func Test_HttpUpdateProfile_With_Locale_Should_Save_Locale(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, profiles := createProfileTestService(newMockReservationRepository())
+	handler := inbound.HttpUpdateProfile(e, service)
+	form := url.Values{}
+	form.Set("profile_name", "Jane Doe")
+	form.Set("profile_locale", "de")
+	req := httptest.NewRequest(http.MethodPost, "/ui/profile", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	profile, _ := profiles.FindProfile(context.Background(), "test@example.com")
+	assert.That(t, "status code must be 303 (redirect)", rec.Code, http.StatusSeeOther)
+	assert.That(t, "profile locale must be saved", profile.Locale, "de")
+}
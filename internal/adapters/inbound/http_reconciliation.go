@@ -0,0 +1,21 @@
+package inbound
+
+import (
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reconciliation"
+)
+
+// HttpReconciliationReport handles GET /api/reconciliation/report.
+// It returns the last reconciliation run and all flagged discrepancies.
+func HttpReconciliationReport(reconciliationService *reconciliation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := reconciliationService.Report(r.Context())
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to create reconciliation report")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, report)
+	}
+}
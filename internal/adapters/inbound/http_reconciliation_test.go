@@ -0,0 +1,85 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reconciliation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createReconciliationTestService(t *testing.T) (*payment.Service, *reconciliation.Service) {
+	t.Helper()
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	gateway := outbound.NewMockPaymentGateway()
+	paymentRepo := outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]())
+	paymentService := payment.NewService(paymentRepo, gateway, publisher)
+	discrepancies := resource.NewInMemoryAccess[reconciliation.DiscrepancyID, reconciliation.Discrepancy]()
+	return paymentService, reconciliation.NewService(paymentService, gateway, discrepancies)
+}
+
+// ============================================================================
+// HttpReconciliationReport Tests
+// ============================================================================
+
+func Test_HttpReconciliationReport_After_Run_Should_Return_Report(t *testing.T) {
+	// Arrange
+	paymentService, reconciliationService := createReconciliationTestService(t)
+	ctx := context.Background()
+	_, _ = paymentService.AuthorizePayment(ctx, "pay-001", "res-001", payment.NewMoney(19800, "USD"), "credit_card")
+	assert.That(t, "payment must be captured", paymentService.CapturePayment(ctx, "pay-001"), nil)
+	_, err := reconciliationService.Reconcile(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	assert.That(t, "reconciliation must succeed", err, nil)
+	handler := inbound.HttpReconciliationReport(reconciliationService)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/api/reconciliation/report", nil))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be JSON", rec.Header().Get("Content-Type"), "application/json")
+	var report reconciliation.Report
+	assert.That(t, "body must be a report", json.NewDecoder(rec.Body).Decode(&report), nil)
+	assert.That(t, "last run must be set", report.LastRun != nil, true)
+	assert.That(t, "capture must be matched", report.LastRun.Matched, 1)
+	assert.That(t, "no discrepancies must be flagged", len(report.Discrepancies), 0)
+}
+
+// ============================================================================
+// Route Tests
+// ============================================================================
+
+func Test_Route_Reconciliation_API_Without_Verifier_Should_Return_404(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	_, reconciliationService := createReconciliationTestService(t)
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                   context.Background(),
+		EFS:                   getRouterTestFS(t),
+		Logger:                slog.Default(),
+		ReservationService:    createTestReservationService(t),
+		ReconciliationService: reconciliationService,
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/reconciliation/report", nil))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
@@ -0,0 +1,127 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/review"
+)
+
+// SubmitReviewRequest is the payload of a review written by a guest.
+type SubmitReviewRequest struct {
+	Rating  int    `json:"rating"` // 1 to 5 stars
+	Comment string `json:"comment"`
+}
+
+// ModerateReviewRequest is the payload of a moderation.
+type ModerateReviewRequest struct {
+	Status string `json:"status"` // approved or rejected
+}
+
+// HttpGetReviewRequest handles GET /api/reviews/{id}?token=.
+// The token of the emailed link authorizes the guest instead of a bearer token.
+func HttpGetReviewRequest(reviewService *review.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rev, err := reviewService.GetReviewRequest(r.Context(), review.ReviewID(r.PathValue("id")), r.URL.Query().Get("token"), time.Now())
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to get review")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, rev)
+	}
+}
+
+// HttpSubmitReview handles POST /api/reviews/{id}?token=.
+// The review waits for moderation before it counts towards the ratings.
+func HttpSubmitReview(reviewService *review.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SubmitReviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		rev, err := reviewService.SubmitReview(r.Context(), review.ReviewID(r.PathValue("id")), r.URL.Query().Get("token"), req.Rating, req.Comment, time.Now())
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to submit review")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, rev)
+	}
+}
+
+// HttpListReviews handles GET /api/reviews.
+// The optional status query parameter (requested, pending, approved, rejected) filters the reviews.
+func HttpListReviews(reviewService *review.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, err := review.ParseStatus(r.URL.Query().Get("status"))
+		if err != nil {
+			writeDomainError(w, r, err, "Invalid review status")
+			return
+		}
+
+		reviews, err := reviewService.ListReviews(r.Context(), status)
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to list reviews")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, reviews)
+	}
+}
+
+// HttpModerateReview handles POST /api/reviews/{id}/moderate.
+func HttpModerateReview(reviewService *review.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ModerateReviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+		status, err := review.ParseModeration(req.Status)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to moderate review")
+			return
+		}
+
+		rev, err := reviewService.ModerateReview(r.Context(), review.ReviewID(r.PathValue("id")), status, time.Now())
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to moderate review")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, rev)
+	}
+}
+
+// HttpGetRoomRating handles GET /api/rooms/{id}/rating.
+// It responds with the average and count of the approved reviews of the room.
+func HttpGetRoomRating(reviewService *review.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rating, err := reviewService.RoomRating(r.Context(), reservation.RoomID(r.PathValue("id")))
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to get rating")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, rating)
+	}
+}
+
+// HttpGetPropertyRating handles GET /api/rating.
+// It responds with the average and count of the approved reviews of all rooms.
+func HttpGetPropertyRating(reviewService *review.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rating, err := reviewService.PropertyRating(r.Context())
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to get rating")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, rating)
+	}
+}
@@ -0,0 +1,154 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/review"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// createReviewTestService returns a review service whose links are the bare tokens.
+func createReviewTestService(reviews ...review.Review) *review.Service {
+	repo := resource.NewInMemoryAccess[review.ReviewID, review.Review]()
+	for _, rev := range reviews {
+		_ = repo.Create(context.Background(), rev.ID, rev)
+	}
+	return review.NewService(nil, repo, nil, []byte("secret")).WithLinkURL("{token}")
+}
+
+func storedReview(id string, status review.Status, rating int) review.Review {
+	return review.Review{
+		ID:            review.ReviewID(id),
+		ReservationID: "res-001",
+		RoomID:        "room-101",
+		GuestID:       "guest-001",
+		Rating:        rating,
+		Status:        status,
+		RequestedAt:   time.Now().Add(-time.Hour).Truncate(time.Second),
+	}
+}
+
+// ============================================================================
+// HttpSubmitReview Tests
+// ============================================================================
+
+func Test_HttpSubmitReview_With_Token_Should_Return_Pending_Review(t *testing.T) {
+	// Arrange
+	rev := storedReview("review-001", review.StatusRequested, 0)
+	service := createReviewTestService(rev)
+	req := httptest.NewRequest(http.MethodPost, "/api/reviews/review-001?token="+service.Link(&rev), strings.NewReader(`{"rating":5,"comment":"Great stay"}`))
+	req.SetPathValue("id", "review-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpSubmitReview(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var submitted review.Review
+	_ = json.Unmarshal(rec.Body.Bytes(), &submitted)
+	assert.That(t, "review must wait for moderation", submitted.Status, review.StatusPending)
+	assert.That(t, "rating must match", submitted.Rating, 5)
+}
+
+func Test_HttpSubmitReview_With_Invalid_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	service := createReviewTestService(storedReview("review-001", review.StatusRequested, 0))
+	req := httptest.NewRequest(http.MethodPost, "/api/reviews/review-001?token=forged", strings.NewReader(`{"rating":5}`))
+	req.SetPathValue("id", "review-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpSubmitReview(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
+
+func Test_HttpSubmitReview_With_Invalid_Rating_Should_Return_400(t *testing.T) {
+	// Arrange
+	rev := storedReview("review-001", review.StatusRequested, 0)
+	service := createReviewTestService(rev)
+	req := httptest.NewRequest(http.MethodPost, "/api/reviews/review-001?token="+service.Link(&rev), strings.NewReader(`{"rating":0}`))
+	req.SetPathValue("id", "review-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpSubmitReview(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// HttpModerateReview Tests
+// ============================================================================
+
+func Test_HttpModerateReview_With_Pending_Review_Should_Approve(t *testing.T) {
+	// Arrange
+	service := createReviewTestService(storedReview("review-001", review.StatusPending, 4))
+	req := httptest.NewRequest(http.MethodPost, "/api/reviews/review-001/moderate", strings.NewReader(`{"status":"approved"}`))
+	req.SetPathValue("id", "review-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpModerateReview(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var moderated review.Review
+	_ = json.Unmarshal(rec.Body.Bytes(), &moderated)
+	assert.That(t, "review must be approved", moderated.Status, review.StatusApproved)
+}
+
+func Test_HttpModerateReview_With_Unknown_Review_Should_Return_404(t *testing.T) {
+	// Arrange
+	service := createReviewTestService()
+	req := httptest.NewRequest(http.MethodPost, "/api/reviews/review-404/moderate", strings.NewReader(`{"status":"rejected"}`))
+	req.SetPathValue("id", "review-404")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpModerateReview(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpGetRoomRating Tests
+// ============================================================================
+
+func Test_HttpGetRoomRating_Should_Return_Approved_Rating(t *testing.T) {
+	// Arrange
+	service := createReviewTestService(
+		storedReview("review-001", review.StatusApproved, 5),
+		storedReview("review-002", review.StatusApproved, 3),
+		storedReview("review-003", review.StatusRejected, 1),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/api/rooms/room-101/rating", nil)
+	req.SetPathValue("id", "room-101")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpGetRoomRating(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var rating review.Rating
+	_ = json.Unmarshal(rec.Body.Bytes(), &rating)
+	assert.That(t, "rating must count the approved reviews", rating.Count, 2)
+	assert.That(t, "rating must average the approved reviews", rating.Average, 4.0)
+}
@@ -0,0 +1,113 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// RoomBlockRequest is the payload of a new room block.
+type RoomBlockRequest struct {
+	CheckIn  string `json:"check_in"`  // 2006-01-02, first blocked night
+	CheckOut string `json:"check_out"` // 2006-01-02, first night the room can be booked again
+	Reason   string `json:"reason"`    // maintenance or renovation
+	Note     string `json:"note"`
+}
+
+// RoomBlockResponse describes a room block.
+type RoomBlockResponse struct {
+	ID       string `json:"id"`
+	RoomID   string `json:"room_id"`
+	CheckIn  string `json:"check_in"`
+	CheckOut string `json:"check_out"`
+	Reason   string `json:"reason"`
+	Note     string `json:"note,omitempty"`
+}
+
+// newRoomBlockResponse converts a room block into its API representation.
+func newRoomBlockResponse(block *reservation.RoomBlock) RoomBlockResponse {
+	return RoomBlockResponse{
+		ID:       string(block.ID),
+		RoomID:   string(block.RoomID),
+		CheckIn:  block.DateRange.CheckIn.Format(time.DateOnly),
+		CheckOut: block.DateRange.CheckOut.Format(time.DateOnly),
+		Reason:   string(block.Reason),
+		Note:     block.Note,
+	}
+}
+
+// HttpListRoomBlocks handles GET /api/rooms/{id}/blocks.
+// It returns the blocks of the room ordered by start date.
+func HttpListRoomBlocks(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		blocks, err := reservationService.ListRoomBlocks(r.Context(), reservation.RoomID(r.PathValue("id")))
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to list room blocks")
+			return
+		}
+
+		response := make([]RoomBlockResponse, 0, len(blocks))
+		for i := range blocks {
+			response = append(response, newRoomBlockResponse(&blocks[i]))
+		}
+		writeJSON(w, http.StatusOK, response)
+	}
+}
+
+// HttpCreateRoomBlock handles POST /api/rooms/{id}/blocks.
+// The room is blocked even if it is booked; the booked reservations are cancelled
+// and refunded by the saga, so the response does not wait for them.
+func HttpCreateRoomBlock(reservationService *reservation.Service, ids shared.IDGenerator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RoomBlockRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+
+		checkIn, err := time.Parse(time.DateOnly, req.CheckIn)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid check_in date, expected YYYY-MM-DD")
+			return
+		}
+		checkOut, err := time.Parse(time.DateOnly, req.CheckOut)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid check_out date, expected YYYY-MM-DD")
+			return
+		}
+
+		block, err := reservationService.BlockRoom(r.Context(),
+			reservation.RoomBlockID(ids.NewID()),
+			reservation.RoomID(r.PathValue("id")),
+			reservation.NewDateRange(checkIn, checkOut),
+			reservation.BlockReason(req.Reason),
+			req.Note,
+		)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to block room")
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, newRoomBlockResponse(block))
+	}
+}
+
+// HttpDeleteRoomBlock handles DELETE /api/rooms/{id}/blocks/{block}.
+// Reservations cancelled for the block are not restored.
+func HttpDeleteRoomBlock(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := reservationService.RemoveRoomBlock(r.Context(),
+			reservation.RoomID(r.PathValue("id")),
+			reservation.RoomBlockID(r.PathValue("block")),
+		)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to remove room block")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
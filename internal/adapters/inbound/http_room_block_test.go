@@ -0,0 +1,115 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createRoomBlockTestService() *reservation.Service {
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	reservationRepo := newMockReservationRepository()
+	blocks := resource.NewInMemoryAccess[reservation.RoomBlockID, reservation.RoomBlock]()
+	return reservation.NewService(reservationRepo, outbound.NewRepositoryAvailabilityChecker(reservationRepo), publisher).
+		WithRoomBlocks(blocks)
+}
+
+func roomBlockBody(checkIn time.Time, reason string) string {
+	return `{"check_in":"` + checkIn.Format(time.DateOnly) + `","check_out":"` + checkIn.AddDate(0, 0, 3).Format(time.DateOnly) + `",` +
+		`"reason":"` + reason + `","note":"Water damage"}`
+}
+
+// ============================================================================
+// HttpCreateRoomBlock Tests
+// ============================================================================
+
+func Test_HttpCreateRoomBlock_With_Valid_Block_Should_Return_201(t *testing.T) {
+	// Arrange
+	service := createRoomBlockTestService()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/rooms/{id}/blocks", inbound.HttpCreateRoomBlock(service, fixedIDGenerator{id: "block-001"}))
+	body := roomBlockBody(time.Now().AddDate(0, 0, 7), "maintenance")
+	req := httptest.NewRequest(http.MethodPost, "/api/rooms/room-101/blocks", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	var resp inbound.RoomBlockResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.That(t, "ID must be generated", resp.ID, "block-001")
+	assert.That(t, "room must be taken from the path", resp.RoomID, "room-101")
+	blocks, _ := service.ListRoomBlocks(context.Background(), "room-101")
+	assert.That(t, "block must be stored", len(blocks), 1)
+}
+
+func Test_HttpCreateRoomBlock_With_Unknown_Reason_Should_Return_400(t *testing.T) {
+	// Arrange
+	service := createRoomBlockTestService()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/rooms/{id}/blocks", inbound.HttpCreateRoomBlock(service, fixedIDGenerator{id: "block-001"}))
+	body := roomBlockBody(time.Now().AddDate(0, 0, 7), "vacation")
+	req := httptest.NewRequest(http.MethodPost, "/api/rooms/room-101/blocks", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// HttpDeleteRoomBlock Tests
+// ============================================================================
+
+func Test_HttpDeleteRoomBlock_Should_Return_204(t *testing.T) {
+	// Arrange
+	service := createRoomBlockTestService()
+	dateRange := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 10))
+	_, _ = service.BlockRoom(context.Background(), "block-001", "room-101", dateRange, reservation.BlockRenovation, "")
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /api/rooms/{id}/blocks/{block}", inbound.HttpDeleteRoomBlock(service))
+	req := httptest.NewRequest(http.MethodDelete, "/api/rooms/room-101/blocks/block-001", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+	blocks, _ := service.ListRoomBlocks(context.Background(), "room-101")
+	assert.That(t, "block must be removed", len(blocks), 0)
+}
+
+func Test_HttpDeleteRoomBlock_With_Unknown_Block_Should_Return_404(t *testing.T) {
+	// Arrange
+	service := createRoomBlockTestService()
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /api/rooms/{id}/blocks/{block}", inbound.HttpDeleteRoomBlock(service))
+	req := httptest.NewRequest(http.MethodDelete, "/api/rooms/room-101/blocks/block-404", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
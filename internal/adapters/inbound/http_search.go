@@ -0,0 +1,81 @@
+package inbound
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/search"
+)
+
+// ReservationSearchHit describes a reservation matching a search.
+type ReservationSearchHit struct {
+	ReservationID string              `json:"reservation_id"`
+	GuestID       string              `json:"guest_id"`
+	GuestNames    []string            `json:"guest_names"`
+	GuestEmails   []string            `json:"guest_emails"`
+	RoomID        string              `json:"room_id"`
+	CheckIn       string              `json:"check_in"`
+	CheckOut      string              `json:"check_out"`
+	Status        string              `json:"status"`
+	Channel       string              `json:"channel,omitempty"`
+	Highlights    map[string][]string `json:"highlights,omitempty"` // Matching fragments per field, matches wrapped in <em>
+}
+
+// ReservationSearchResponse is a page of a reservation search.
+type ReservationSearchResponse struct {
+	Total int                    `json:"total"`
+	Page  int                    `json:"page"`
+	Size  int                    `json:"size"`
+	Hits  []ReservationSearchHit `json:"hits"`
+}
+
+// HttpSearchReservations handles GET /api/reservations/search?q=...&page=1&size=20.
+// It searches reservations by guest name, email or ID; an empty q lists all reservations.
+func HttpSearchReservations(searchService *search.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := search.Query{Text: r.URL.Query().Get("q")}
+		var err error
+		if page := r.URL.Query().Get("page"); page != "" {
+			if query.Page, err = strconv.Atoi(page); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, "Invalid page")
+				return
+			}
+		}
+		if size := r.URL.Query().Get("size"); size != "" {
+			if query.Size, err = strconv.Atoi(size); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, "Invalid size")
+				return
+			}
+		}
+
+		result, err := searchService.SearchReservations(r.Context(), query)
+		if err != nil {
+			writeDomainError(w, r, err, "Failed to search reservations")
+			return
+		}
+
+		response := ReservationSearchResponse{
+			Total: result.Total,
+			Page:  result.Page,
+			Size:  result.Size,
+			Hits:  make([]ReservationSearchHit, 0, len(result.Hits)),
+		}
+		for _, hit := range result.Hits {
+			summary := hit.Summary
+			response.Hits = append(response.Hits, ReservationSearchHit{
+				ReservationID: string(summary.ReservationID),
+				GuestID:       string(summary.GuestID),
+				GuestNames:    summary.GuestNames,
+				GuestEmails:   summary.GuestEmails,
+				RoomID:        string(summary.RoomID),
+				CheckIn:       summary.CheckIn.Format(time.DateOnly),
+				CheckOut:      summary.CheckOut.Format(time.DateOnly),
+				Status:        string(summary.Status),
+				Channel:       summary.Channel,
+				Highlights:    hit.Highlights,
+			})
+		}
+		writeJSON(w, http.StatusOK, response)
+	}
+}
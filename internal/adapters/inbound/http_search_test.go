@@ -0,0 +1,95 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/search"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type stubSearchIndex struct {
+	result *search.Result
+	err    error
+	query  search.Query
+}
+
+func (s *stubSearchIndex) Index(ctx context.Context, summary search.ReservationSummary) error {
+	return nil
+}
+
+func (s *stubSearchIndex) Search(ctx context.Context, query search.Query) (*search.Result, error) {
+	s.query = query
+	return s.result, s.err
+}
+
+func createSearchTestService(index *stubSearchIndex) *search.Service {
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	reservationRepo := newMockReservationRepository()
+	reservationService := reservation.NewService(reservationRepo, outbound.NewRepositoryAvailabilityChecker(reservationRepo), publisher)
+	return search.NewService(reservationService, index)
+}
+
+// ============================================================================
+// HttpSearchReservations Tests
+// ============================================================================
+
+func Test_HttpSearchReservations_Should_Return_Hits_With_Highlights(t *testing.T) {
+	// Arrange
+	index := &stubSearchIndex{result: &search.Result{Total: 1, Page: 2, Size: 10, Hits: []search.Hit{{
+		Summary:    search.ReservationSummary{ReservationID: "res-001", GuestNames: []string{"Jane Doe"}},
+		Highlights: map[string][]string{"guest_names": {"<em>Jane</em> Doe"}},
+	}}}}
+	handler := inbound.HttpSearchReservations(createSearchTestService(index))
+	req := httptest.NewRequest(http.MethodGet, "/api/reservations/search?q=jane&page=2&size=10", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "query must be passed on", index.query, search.Query{Text: "jane", Page: 2, Size: 10})
+	var resp inbound.ReservationSearchResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.That(t, "one hit must be returned", len(resp.Hits), 1)
+	assert.That(t, "highlight must be returned", resp.Hits[0].Highlights["guest_names"], []string{"<em>Jane</em> Doe"})
+}
+
+func Test_HttpSearchReservations_With_Too_Large_Size_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpSearchReservations(createSearchTestService(&stubSearchIndex{}))
+	req := httptest.NewRequest(http.MethodGet, "/api/reservations/search?q=jane&size=1000", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpSearchReservations_When_Index_Fails_Should_Return_500(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpSearchReservations(createSearchTestService(&stubSearchIndex{err: errors.New("cluster unavailable")}))
+	req := httptest.NewRequest(http.MethodGet, "/api/reservations/search?q=jane", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 500", rec.Code, http.StatusInternalServerError)
+}
@@ -0,0 +1,108 @@
+package inbound
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StartupCheck is a named check that must pass once before the application is started,
+// e.g. that the database migrations are applied or a cache is warmed up.
+type StartupCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// StartupProbe runs the startup checks in the background and reports the result
+// on the /startup endpoint. Kubernetes holds back the liveness and readiness probes
+// until the startup probe succeeds, so slow migrations don't get the pod restarted.
+type StartupProbe struct {
+	checks        []StartupCheck
+	retryInterval time.Duration
+	mutex         sync.RWMutex
+	pending       map[string]error
+}
+
+// NewStartupProbe creates a new startup probe.
+// Failed checks are retried every second until WithRetryInterval is set.
+func NewStartupProbe() *StartupProbe {
+	return &StartupProbe{
+		retryInterval: time.Second,
+		pending:       make(map[string]error),
+	}
+}
+
+// WithCheck adds a check that must pass before the application is started.
+func (p *StartupProbe) WithCheck(name string, check func(ctx context.Context) error) *StartupProbe {
+	p.checks = append(p.checks, StartupCheck{Name: name, Check: check})
+	p.pending[name] = nil
+	return p
+}
+
+// WithRetryInterval sets the delay before a failed check is run again.
+func (p *StartupProbe) WithRetryInterval(d time.Duration) *StartupProbe {
+	p.retryInterval = d
+	return p
+}
+
+// Run runs the checks in order, retrying each until it passes or the context is done.
+// It returns the error of the context if the application is stopped during startup.
+func (p *StartupProbe) Run(ctx context.Context) error {
+	for _, check := range p.checks {
+		for {
+			err := check.Check(ctx)
+			p.mutex.Lock()
+			if err == nil {
+				delete(p.pending, check.Name)
+			} else {
+				p.pending[check.Name] = err
+			}
+			p.mutex.Unlock()
+			if err == nil {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(p.retryInterval):
+			}
+		}
+	}
+	return nil
+}
+
+// Started reports whether all checks have passed.
+func (p *StartupProbe) Started() bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return len(p.pending) == 0
+}
+
+// Pending returns the names of the checks that have not passed yet, in the order of the checks.
+func (p *StartupProbe) Pending() []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	names := []string{}
+	for _, check := range p.checks {
+		if _, ok := p.pending[check.Name]; ok {
+			names = append(names, check.Name)
+		}
+	}
+	return names
+}
+
+// HttpStartup handles GET /startup.
+// It returns 200 once all startup checks have passed, and 503 with the pending checks before.
+func HttpStartup(probe *StartupProbe) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !probe.Started() {
+			http.Error(w, "Starting: "+strings.Join(probe.Pending(), ", "), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	}
+}
@@ -0,0 +1,96 @@
+package inbound_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// StartupProbe Tests
+// ============================================================================
+
+func Test_StartupProbe_Run_Should_Retry_Failed_Checks_Until_They_Pass(t *testing.T) {
+	// Arrange
+	calls := 0
+	probe := inbound.NewStartupProbe().
+		WithRetryInterval(time.Millisecond).
+		WithCheck("migrations", func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return errors.New("relation does not exist")
+			}
+			return nil
+		})
+
+	// Act
+	err := probe.Run(context.Background())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "check must be called three times", calls, 3)
+	assert.That(t, "probe must be started", probe.Started(), true)
+}
+
+func Test_StartupProbe_Run_When_Context_Done_Should_Return_Error(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	probe := inbound.NewStartupProbe().
+		WithRetryInterval(time.Millisecond).
+		WithCheck("migrations", func(ctx context.Context) error { return nil }).
+		WithCheck("warmup", func(ctx context.Context) error {
+			cancel()
+			return errors.New("cache not ready")
+		})
+
+	// Act
+	err := probe.Run(ctx)
+
+	// Assert
+	assert.That(t, "err must be the context error", errors.Is(err, context.Canceled), true)
+	assert.That(t, "probe must not be started", probe.Started(), false)
+	assert.That(t, "warmup must be pending", probe.Pending(), []string{"warmup"})
+}
+
+// ============================================================================
+// HttpStartup Tests
+// ============================================================================
+
+func Test_HttpStartup_Before_Checks_Pass_Should_Return_503(t *testing.T) {
+	// Arrange
+	probe := inbound.NewStartupProbe().
+		WithCheck("migrations", func(ctx context.Context) error { return nil })
+	handler := inbound.HttpStartup(probe)
+	req := httptest.NewRequest(http.MethodGet, "/startup", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 503", rec.Code, http.StatusServiceUnavailable)
+	assert.That(t, "body must list the pending check", strings.Contains(rec.Body.String(), "migrations"), true)
+}
+
+func Test_HttpStartup_After_Checks_Pass_Should_Return_200(t *testing.T) {
+	// Arrange
+	probe := inbound.NewStartupProbe().
+		WithCheck("migrations", func(ctx context.Context) error { return nil })
+	_ = probe.Run(context.Background())
+	handler := inbound.HttpStartup(probe)
+	req := httptest.NewRequest(http.MethodGet, "/startup", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+}
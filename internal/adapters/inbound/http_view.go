@@ -1,7 +1,10 @@
 package inbound
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/andygeiss/cloud-native-utils/templating"
 )
@@ -12,3 +15,51 @@ import (
 func HttpView(e *templating.Engine, name string, data any) http.HandlerFunc {
 	return e.View(name, data)
 }
+
+// RenderCache keeps the rendered pages whose data does not change between requests,
+// e.g. the login page of each locale. Templates are parsed once at startup by the
+// engine; the cache also saves executing them on every request.
+type RenderCache struct {
+	mutex sync.RWMutex
+	pages map[string][]byte
+}
+
+// NewRenderCache creates a new, empty render cache.
+func NewRenderCache() *RenderCache {
+	return &RenderCache{pages: make(map[string][]byte)}
+}
+
+// HttpCachedView renders the template with data once per key and serves the cached
+// page afterwards. The key must identify the data, e.g. the locale of the page.
+func HttpCachedView(e *templating.Engine, cache *RenderCache, name, key string, data any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, err := cache.render(e, name, key, data)
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, fmt.Sprintf("templating: render %q: %v", name, err))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(page)
+	}
+}
+
+// render returns the cached page of the template and key, rendering it on the first call.
+func (c *RenderCache) render(e *templating.Engine, name, key string, data any) ([]byte, error) {
+	id := name + "/" + key
+	c.mutex.RLock()
+	page, ok := c.pages[id]
+	c.mutex.RUnlock()
+	if ok {
+		return page, nil
+	}
+
+	var buf bytes.Buffer
+	if err := e.Render(&buf, name, data); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.pages[id] = buf.Bytes()
+	c.mutex.Unlock()
+	return buf.Bytes(), nil
+}
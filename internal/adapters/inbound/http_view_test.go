@@ -72,3 +72,85 @@ func Test_HttpView_With_Data_Should_Render_Data_In_Template(t *testing.T) {
 	bodyStr := string(body)
 	assert.That(t, "body must contain custom app name", containsString(bodyStr, "MyCustomApp"), true)
 }
+
+// ============================================================================
+// HttpCachedView Tests
+// ============================================================================
+
+func Test_HttpCachedView_Should_Render_Once_Per_Key(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(viewTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	cache := inbound.NewRenderCache()
+	inbound.HttpCachedView(e, cache, "login", "en", inbound.HttpViewLoginResponse{AppName: "FirstApp"})(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpCachedView(e, cache, "login", "en", inbound.HttpViewLoginResponse{AppName: "SecondApp"})(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be html", rec.Header().Get("Content-Type"), "text/html; charset=utf-8")
+	assert.That(t, "body must be the cached page", containsString(string(body), "FirstApp"), true)
+}
+
+func Test_HttpCachedView_With_Other_Key_Should_Render_Again(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(viewTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	cache := inbound.NewRenderCache()
+	inbound.HttpCachedView(e, cache, "login", "en", inbound.HttpViewLoginResponse{AppName: "FirstApp"})(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpCachedView(e, cache, "login", "de", inbound.HttpViewLoginResponse{AppName: "SecondApp"})(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "body must be rendered for the key", containsString(string(body), "SecondApp"), true)
+}
+
+func Test_HttpCachedView_With_Unknown_Template_Should_Return_500(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(viewTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpCachedView(e, inbound.NewRenderCache(), "missing", "en", nil)(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	// Assert
+	assert.That(t, "status code must be 500", rec.Code, http.StatusInternalServerError)
+}
+
+// ============================================================================
+// HttpView Benchmarks
+// ============================================================================
+
+func Benchmark_HttpView_Login_Should_Render_Fast(b *testing.B) {
+	e := templating.NewEngine(viewTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	data := inbound.HttpViewLoginResponse{AppName: "TestApp", Title: "Test Title", MagicLink: true}
+	req := httptest.NewRequest(http.MethodGet, "/ui/login", nil)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		inbound.HttpView(e, "login", data)(httptest.NewRecorder(), req)
+	}
+}
+
+func Benchmark_HttpCachedView_Login_Should_Render_Faster(b *testing.B) {
+	e := templating.NewEngine(viewTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	cache := inbound.NewRenderCache()
+	data := inbound.HttpViewLoginResponse{AppName: "TestApp", Title: "Test Title", MagicLink: true}
+	req := httptest.NewRequest(http.MethodGet, "/ui/login", nil)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		inbound.HttpCachedView(e, cache, "login", "en", data)(httptest.NewRecorder(), req)
+	}
+}
@@ -0,0 +1,135 @@
+package inbound
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+)
+
+// ErrDispatcherClosed is returned for messages that arrive after the keyed dispatcher was closed.
+var ErrDispatcherClosed = errors.New("keyed dispatcher is closed")
+
+// keyedJob is a message waiting for its worker.
+type keyedJob struct {
+	ctx    context.Context
+	msg    messaging.Message
+	fn     service.Function[messaging.Message, messaging.MessageState]
+	result chan keyedResult
+}
+
+// keyedResult is the outcome of a handler call.
+type keyedResult struct {
+	state messaging.MessageState
+	err   error
+}
+
+// KeyedDispatcher decorates a messaging.Dispatcher so that the handlers of all its
+// subscriptions process the messages of one aggregate sequentially. Each key (e.g. the
+// reservation ID) is assigned to one worker of a fixed pool, so a payment.captured is
+// never handled while the reservation.created of the same reservation is still running,
+// while messages of different aggregates are handled in parallel by the other workers.
+//
+// The decorated dispatcher must deliver messages asynchronously (e.g. Kafka): a handler
+// that publishes a message of its own key synchronously would wait for its own worker.
+type KeyedDispatcher struct {
+	dispatcher messaging.Dispatcher
+	keyFunc    func(msg messaging.Message) []byte
+	workers    []chan keyedJob
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewKeyedDispatcher creates a new keyed dispatcher with the given number of workers.
+// Messages without a key are handled at once, without a worker.
+func NewKeyedDispatcher(dispatcher messaging.Dispatcher, keyFunc func(msg messaging.Message) []byte, workers int) *KeyedDispatcher {
+	d := &KeyedDispatcher{
+		dispatcher: dispatcher,
+		keyFunc:    keyFunc,
+		workers:    make([]chan keyedJob, max(workers, 1)),
+		closed:     make(chan struct{}),
+	}
+	for i := range d.workers {
+		d.workers[i] = make(chan keyedJob)
+		go d.work(d.workers[i])
+	}
+	return d
+}
+
+// Publish publishes a message with the decorated dispatcher.
+func (d *KeyedDispatcher) Publish(ctx context.Context, message messaging.Message) error {
+	return d.dispatcher.Publish(ctx, message)
+}
+
+// Subscribe subscribes the handler with the decorated dispatcher, serialized by key.
+func (d *KeyedDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return d.dispatcher.Subscribe(ctx, topic, d.serialize(fn))
+}
+
+// SubscribeGroup subscribes the handler in a consumer group, serialized by key.
+// Without consumer groups in the decorated dispatcher, it subscribes as usual.
+func (d *KeyedDispatcher) SubscribeGroup(ctx context.Context, group, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	if subscriber, ok := d.dispatcher.(GroupSubscriber); ok {
+		return subscriber.SubscribeGroup(ctx, group, topic, d.serialize(fn))
+	}
+	return d.dispatcher.Subscribe(ctx, topic, d.serialize(fn))
+}
+
+// Close rejects new messages and stops the workers once their current messages
+// are handled. It does not wait for them; see DrainingDispatcher.Drain.
+func (d *KeyedDispatcher) Close() {
+	d.closeOnce.Do(func() { close(d.closed) })
+}
+
+// serialize hands the calls of the handler to the worker of the message key
+// and waits for the result, so the caller (e.g. a Kafka consumer) commits the
+// message only after it was handled.
+func (d *KeyedDispatcher) serialize(fn service.Function[messaging.Message, messaging.MessageState]) service.Function[messaging.Message, messaging.MessageState] {
+	return func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		key := d.keyFunc(msg)
+		if key == nil {
+			return fn(ctx, msg)
+		}
+
+		select {
+		case <-d.closed:
+			return messaging.MessageStateFailed, ErrDispatcherClosed
+		default:
+		}
+
+		job := keyedJob{ctx: ctx, msg: msg, fn: fn, result: make(chan keyedResult, 1)}
+		select {
+		case d.workers[d.worker(key)] <- job:
+		case <-d.closed:
+			return messaging.MessageStateFailed, ErrDispatcherClosed
+		case <-ctx.Done():
+			return messaging.MessageStateFailed, ctx.Err()
+		}
+
+		res := <-job.result
+		return res.state, res.err
+	}
+}
+
+// worker returns the index of the worker of a key.
+func (d *KeyedDispatcher) worker(key []byte) int {
+	h := fnv.New32a()
+	_, _ = h.Write(key)
+	return int(h.Sum32() % uint32(len(d.workers)))
+}
+
+// work handles the jobs of one worker in the order they arrive.
+func (d *KeyedDispatcher) work(jobs <-chan keyedJob) {
+	for {
+		select {
+		case <-d.closed:
+			return
+		case job := <-jobs:
+			state, err := job.fn(job.ctx, job.msg)
+			job.result <- keyedResult{state: state, err: err}
+		}
+	}
+}
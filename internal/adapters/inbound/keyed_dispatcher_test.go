@@ -0,0 +1,134 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func testReservationKey(msg messaging.Message) []byte {
+	var payload struct {
+		ReservationID string `json:"reservation_id"`
+	}
+	_ = json.Unmarshal(msg.Data, &payload)
+	if payload.ReservationID == "" {
+		return nil
+	}
+	return []byte(payload.ReservationID)
+}
+
+func reservationMessage(topic, reservationID string) messaging.Message {
+	return messaging.NewMessage(topic, []byte(`{"reservation_id":"`+reservationID+`"}`))
+}
+
+// blockingHandler records the messages it handles and blocks on the first one
+// until released.
+type blockingHandler struct {
+	mutex   sync.Mutex
+	handled []string
+	started chan struct{}
+	release chan struct{}
+	blocked bool
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{started: make(chan struct{}), release: make(chan struct{})}
+}
+
+func (h *blockingHandler) handle(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	h.mutex.Lock()
+	first := !h.blocked
+	h.blocked = true
+	h.mutex.Unlock()
+	if first {
+		close(h.started)
+		<-h.release
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.handled = append(h.handled, msg.Topic+" "+string(testReservationKey(msg)))
+	return messaging.MessageStateCompleted, nil
+}
+
+func (h *blockingHandler) messages() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return append([]string{}, h.handled...)
+}
+
+// ============================================================================
+// KeyedDispatcher Tests
+// ============================================================================
+
+func Test_KeyedDispatcher_Should_Handle_Messages_Of_One_Aggregate_Sequentially(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := inbound.NewKeyedDispatcher(messaging.NewInternalDispatcher(), testReservationKey, 4)
+	defer dispatcher.Close()
+	handler := newBlockingHandler()
+	_ = dispatcher.Subscribe(ctx, "reservation.created", handler.handle)
+	_ = dispatcher.Subscribe(ctx, "payment.captured", handler.handle)
+
+	// Act
+	go func() { _ = dispatcher.Publish(ctx, reservationMessage("reservation.created", "res-1")) }()
+	<-handler.started
+	done := make(chan struct{})
+	go func() {
+		_ = dispatcher.Publish(ctx, reservationMessage("payment.captured", "res-1"))
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	handledWhileBlocked := len(handler.messages())
+	close(handler.release)
+	<-done
+
+	// Assert
+	assert.That(t, "second message must wait for the first", handledWhileBlocked, 0)
+	assert.That(t, "messages must be handled in order", handler.messages(), []string{"reservation.created res-1", "payment.captured res-1"})
+}
+
+func Test_KeyedDispatcher_Should_Handle_Different_Aggregates_In_Parallel(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := inbound.NewKeyedDispatcher(messaging.NewInternalDispatcher(), testReservationKey, 4)
+	defer dispatcher.Close()
+	handler := newBlockingHandler()
+	_ = dispatcher.Subscribe(ctx, "reservation.created", handler.handle)
+
+	// Act
+	go func() { _ = dispatcher.Publish(ctx, reservationMessage("reservation.created", "res-1")) }()
+	<-handler.started
+	err := dispatcher.Publish(ctx, reservationMessage("reservation.created", "res-2"))
+	handledWhileBlocked := handler.messages()
+	close(handler.release)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "other aggregate must not wait", handledWhileBlocked, []string{"reservation.created res-2"})
+}
+
+func Test_KeyedDispatcher_After_Close_Should_Reject_Keyed_Messages(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := inbound.NewKeyedDispatcher(messaging.NewInternalDispatcher(), testReservationKey, 2)
+	_ = dispatcher.Subscribe(ctx, "reservation.created", completed)
+	dispatcher.Close()
+
+	// Act
+	err := dispatcher.Publish(ctx, reservationMessage("reservation.created", "res-1"))
+
+	// Assert
+	assert.That(t, "err must be ErrDispatcherClosed", errors.Is(err, inbound.ErrDispatcherClosed), true)
+}
@@ -0,0 +1,40 @@
+package inbound
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
+)
+
+// localeContextKey is the context key of the negotiated Localizer.
+type localeContextKey struct{}
+
+// WithLocale negotiates the locale of a request and adds the Localizer to the context.
+// The locale saved in the guest's profile wins over the browser's Accept-Language header.
+// It must run after WithSessionContext, which provides the guest's email.
+func WithLocale(reservationService *reservation.Service, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var preferred string
+		if email := SessionFromContext(ctx).Email; email != "" && reservationService != nil {
+			if profile, err := reservationService.GetGuestProfile(ctx, reservation.GuestID(email)); err == nil {
+				preferred = profile.Locale
+			}
+		}
+
+		loc := i18n.Negotiate(preferred, r.Header.Get("Accept-Language"))
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, localeContextKey{}, loc)))
+	}
+}
+
+// localizer returns the Localizer negotiated by WithLocale.
+// Without it, e.g. on public pages, the Accept-Language header decides.
+func localizer(r *http.Request) *i18n.Localizer {
+	if loc, ok := r.Context().Value(localeContextKey{}).(*i18n.Localizer); ok {
+		return loc
+	}
+	return i18n.Negotiate(r.Header.Get("Accept-Language"))
+}
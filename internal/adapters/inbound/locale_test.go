@@ -0,0 +1,70 @@
+package inbound_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// WithLocale Tests
+// ============================================================================
+
+func Test_WithLocale_Without_Profile_Should_Use_Accept_Language(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, _ := createProfileTestService(newMockReservationRepository())
+	handler := inbound.WithLocale(service, inbound.HttpViewReservations(e, service))
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/reservations", nil), "test-session-123", "test@example.com")
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9,en;q=0.8")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "body must be rendered in german", strings.Contains(string(body), "Lang: de"), true)
+}
+
+func Test_WithLocale_With_Profile_Locale_Should_Override_Accept_Language(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, profiles := createProfileTestService(newMockReservationRepository())
+	_ = profiles.SaveProfile(context.Background(), reservation.GuestProfile{GuestID: "test@example.com", Name: "Jane Doe", Locale: "de"})
+	handler := inbound.WithLocale(service, inbound.HttpViewReservations(e, service))
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/reservations", nil), "test-session-123", "test@example.com")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "body must be rendered in the profile locale", strings.Contains(string(body), "Lang: de"), true)
+}
+
+func Test_WithLocale_With_Unsupported_Language_Should_Use_Default(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, _ := createProfileTestService(newMockReservationRepository())
+	handler := inbound.WithLocale(service, inbound.HttpViewReservations(e, service))
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/reservations", nil), "test-session-123", "test@example.com")
+	req.Header.Set("Accept-Language", "ja-JP")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "body must be rendered in english", strings.Contains(string(body), "Lang: en"), true)
+}
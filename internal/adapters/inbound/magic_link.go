@@ -0,0 +1,438 @@
+package inbound
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// This file contains the passwordless login flow for deployments without an
+// OIDC provider. A guest requests a sign-in link for their email address,
+// receives a signed, short-lived token by email and exchanges it for a session.
+// Opening the link shows a confirmation, which exchanges the token when submitted,
+// so link scanners of mail providers that open the link do not use it up.
+// Signing in also proves that the guest owns the email address.
+
+// MagicLinkSender delivers sign-in links to guests.
+// Implementations live in the outbound adapters (notification service).
+type MagicLinkSender interface {
+	SendMagicLink(ctx context.Context, email, link string) error
+}
+
+// magicLinkIssuer is the issuer of sessions created by a magic link.
+const magicLinkIssuer = "magic-link"
+
+// magicLinkVerifyPath is the endpoint the emailed links point to.
+const magicLinkVerifyPath = "/auth/magic-link/verify"
+
+// magicLinkCSRFCookie holds the nonce the CSRF token of the confirmation is derived from.
+const magicLinkCSRFCookie = "magic_link_csrf"
+
+var (
+	ErrMagicLinkInvalid = errors.New("invalid magic link")
+	ErrMagicLinkExpired = errors.New("magic link expired")
+	ErrMagicLinkUsed    = errors.New("magic link already used")
+)
+
+// MagicLinkAuth issues and verifies HMAC-signed sign-in tokens.
+// Tokens are single-use; used tokens and sign-in attempts are kept in the
+// MagicLinkStore until they expire, in memory unless a shared store is set.
+type MagicLinkAuth struct {
+	secret  []byte
+	sender  MagicLinkSender
+	baseURL string
+	ttl     time.Duration
+	limit   int
+	window  time.Duration
+	store   MagicLinkStore
+	logger  *slog.Logger
+	header  string
+}
+
+// NewMagicLinkAuth creates a new magic link flow.
+// Links point to baseURL (e.g. https://hotel.example.com) and expire after 15 minutes.
+// By default, five links per email address and client may be requested per hour.
+func NewMagicLinkAuth(secret []byte, sender MagicLinkSender, baseURL string) *MagicLinkAuth {
+	return &MagicLinkAuth{
+		secret:  secret,
+		sender:  sender,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		ttl:     15 * time.Minute,
+		limit:   5,
+		window:  time.Hour,
+		store:   newMemoryMagicLinkStore(),
+		logger:  slog.New(slog.DiscardHandler),
+	}
+}
+
+// WithTTL sets how long a link stays valid.
+func (a *MagicLinkAuth) WithTTL(ttl time.Duration) *MagicLinkAuth {
+	a.ttl = ttl
+	return a
+}
+
+// WithRateLimit sets how many links may be requested per client and sent per email address within a window.
+func (a *MagicLinkAuth) WithRateLimit(limit int, window time.Duration) *MagicLinkAuth {
+	a.limit = limit
+	a.window = window
+	return a
+}
+
+// WithStore keeps the used tokens and the sign-in attempts in the store,
+// e.g. the SessionStore shared by all replicas.
+func (a *MagicLinkAuth) WithStore(store MagicLinkStore) *MagicLinkAuth {
+	a.store = store
+	return a
+}
+
+// WithClientIPHeader takes the client IP of the rate limit from the header set by a
+// trusted reverse proxy, e.g. X-Forwarded-For, instead of the remote address.
+// Of a list, the last address is used, which is the one the proxy appended.
+func (a *MagicLinkAuth) WithClientIPHeader(header string) *MagicLinkAuth {
+	a.header = header
+	return a
+}
+
+// WithLogger sets the logger for delivery failures.
+func (a *MagicLinkAuth) WithLogger(logger *slog.Logger) *MagicLinkAuth {
+	a.logger = logger
+	return a
+}
+
+// IssueToken creates a signed token for the email address.
+func (a *MagicLinkAuth) IssueToken(email string) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(a.ttl).Unix()
+	payload := email + "|" + strconv.FormatInt(expires, 10) + "|" + hex.EncodeToString(nonce)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + a.sign(payload), nil
+}
+
+// VerifyToken checks the signature and expiry of a token, marks it as used
+// and returns the email address it was issued for.
+func (a *MagicLinkAuth) VerifyToken(ctx context.Context, token string) (string, error) {
+	email, nonce, expires, err := a.parseToken(token)
+	if err != nil {
+		return "", err
+	}
+	unused, err := a.store.UseNonce(ctx, nonce, time.Until(expires))
+	if err != nil {
+		return "", fmt.Errorf("failed to use magic link: %w", err)
+	}
+	if !unused {
+		return "", ErrMagicLinkUsed
+	}
+	return email, nil
+}
+
+// parseToken checks the signature and expiry of a token without using it up
+// and returns its email address, nonce and expiry.
+func (a *MagicLinkAuth) parseToken(token string) (email, nonce string, expires time.Time, err error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", "", time.Time{}, ErrMagicLinkInvalid
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", time.Time{}, ErrMagicLinkInvalid
+	}
+	payload := string(raw)
+	if !hmac.Equal([]byte(signature), []byte(a.sign(payload))) {
+		return "", "", time.Time{}, ErrMagicLinkInvalid
+	}
+
+	// The email is split off last because its local part may contain the separator.
+	rest, nonce, ok := cutLast(payload, "|")
+	if !ok {
+		return "", "", time.Time{}, ErrMagicLinkInvalid
+	}
+	email, exp, ok := cutLast(rest, "|")
+	if !ok {
+		return "", "", time.Time{}, ErrMagicLinkInvalid
+	}
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return "", "", time.Time{}, ErrMagicLinkInvalid
+	}
+	expires = time.Unix(unix, 0)
+	if !time.Now().Before(expires) {
+		return "", "", time.Time{}, ErrMagicLinkExpired
+	}
+	return email, nonce, expires, nil
+}
+
+// HttpRequestLink handles the sign-in form. It always shows the same confirmation,
+// so the response does not reveal whether a link was sent.
+func (a *MagicLinkAuth) HttpRequestLink(e *templating.Engine) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - " + os.Getenv("APP_DESCRIPTION")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		loc := localizer(r)
+		data := HttpViewLoginResponse{
+			AppName:   appName,
+			Title:     title,
+			I18n:      loc,
+			Theme:     theme(r),
+			MagicLink: true,
+			Fields:    loginFormFields(loc, ""),
+		}
+
+		email, err := reservation.ParseEmail(r.FormValue("email"))
+		if err != nil {
+			data.Fields = loginFormFields(loc, r.FormValue("email")).withErrors(map[string]string{"email": loc.T("login.invalid_email")})
+			HttpView(e, "login", data)(w, r)
+			return
+		}
+
+		// The client is limited first, so its rejected requests do not count towards
+		// the email address of someone else.
+		allowed, err := a.allow(r.Context(), "client:"+a.clientIP(r))
+		if err != nil {
+			a.logger.Error("failed to count sign-in attempt", "error", err)
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to create sign-in link")
+			return
+		}
+		if !allowed {
+			data.Error = loc.T("login.rate_limited")
+			w.WriteHeader(http.StatusTooManyRequests)
+			HttpView(e, "login", data)(w, r)
+			return
+		}
+
+		// Over the limit of the email address, no further link is sent, but the response
+		// stays the same and the links sent before can still be used to sign in.
+		allowed, err = a.allow(r.Context(), "email:"+string(email))
+		if err != nil {
+			a.logger.Error("failed to count sign-in attempt", "error", err)
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to create sign-in link")
+			return
+		}
+		if allowed {
+			token, err := a.IssueToken(string(email))
+			if err != nil {
+				writeProblem(w, r, http.StatusInternalServerError, "Failed to create sign-in link")
+				return
+			}
+			link := a.baseURL + magicLinkVerifyPath + "?token=" + url.QueryEscape(token)
+			if err := a.sender.SendMagicLink(r.Context(), string(email), link); err != nil {
+				a.logger.Error("failed to send magic link", "error", err)
+			}
+		}
+
+		data.Message = loc.T("login.link_sent", a.ttl.String())
+		HttpView(e, "login", data)(w, r)
+	}
+}
+
+// HttpConfirmLink shows the confirmation of an opened sign-in link. The token is
+// only checked here; submitting the confirmation exchanges it via HttpVerifyLink.
+// The form carries a CSRF token bound to a cookie, so other sites cannot submit
+// a link of their own and sign the guest in to another account.
+func (a *MagicLinkAuth) HttpConfirmLink(e *templating.Engine) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - " + os.Getenv("APP_DESCRIPTION")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		_, _, expires, err := a.parseToken(token)
+		if err != nil {
+			redirectSignInFailed(w, r)
+			return
+		}
+
+		nonce := security.GenerateID()
+		http.SetCookie(w, &http.Cookie{
+			Name:     magicLinkCSRFCookie,
+			Value:    nonce,
+			Path:     magicLinkVerifyPath,
+			Expires:  expires,
+			HttpOnly: true,
+			Secure:   strings.HasPrefix(a.baseURL, "https://"),
+			SameSite: http.SameSiteStrictMode,
+		})
+
+		HttpView(e, "login", HttpViewLoginResponse{
+			AppName:      appName,
+			Title:        title,
+			I18n:         localizer(r),
+			Theme:        theme(r),
+			ConfirmToken: token,
+			CSRFToken:    a.csrfToken(nonce, token),
+		})(w, r)
+	}
+}
+
+// HttpVerifyLink exchanges a valid token for a session, like the OIDC callback does.
+// It handles the submitted confirmation of HttpConfirmLink.
+func (a *MagicLinkAuth) HttpVerifyLink(sessions *web.ServerSessions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// The CSRF token is checked first, so a forged confirmation does not use up the link.
+		token := r.FormValue("token")
+		nonce, err := r.Cookie(magicLinkCSRFCookie)
+		if err != nil || !hmac.Equal([]byte(r.FormValue("csrf_token")), []byte(a.csrfToken(nonce.Value, token))) {
+			redirectSignInFailed(w, r)
+			return
+		}
+
+		email, err := a.VerifyToken(r.Context(), token)
+		if err != nil {
+			if !errors.Is(err, ErrMagicLinkInvalid) && !errors.Is(err, ErrMagicLinkExpired) && !errors.Is(err, ErrMagicLinkUsed) {
+				a.logger.Error("failed to verify magic link", "error", err)
+			}
+			redirectSignInFailed(w, r)
+			return
+		}
+
+		sessionID := security.GenerateID()[:32]
+		sessions.Create(sessionID, web.IdentityTokenClaims{
+			Email:    email,
+			Issuer:   magicLinkIssuer,
+			Subject:  email,
+			Verified: true,
+		})
+
+		redirectURL := os.Getenv("REDIRECT_URL")
+		http.SetCookie(w, &http.Cookie{
+			Name:     sessionCookie,
+			Value:    sessionID,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   strings.HasPrefix(redirectURL, "https://"),
+			SameSite: http.SameSiteLaxMode,
+		})
+		if redirectURL == "" {
+			redirectURL = "/ui/"
+		}
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+	}
+}
+
+// allow counts a sign-in attempt for the key and reports whether it is within the limit.
+func (a *MagicLinkAuth) allow(ctx context.Context, key string) (bool, error) {
+	count, err := a.store.CountAttempt(ctx, key, a.window)
+	if err != nil {
+		return false, err
+	}
+	return count <= a.limit, nil
+}
+
+// redirectSignInFailed redirects to the error page for invalid, expired or used links.
+func redirectSignInFailed(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/ui/error?title="+url.QueryEscape("Sign-in failed")+"&message="+url.QueryEscape("This sign-in link is invalid or has expired."), http.StatusSeeOther)
+}
+
+// sign returns the base64url-encoded HMAC-SHA256 of the payload.
+func (a *MagicLinkAuth) sign(payload string) string {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// csrfToken returns the CSRF token of the confirmation of a link, derived from
+// the nonce in the cookie and the token of the link.
+func (a *MagicLinkAuth) csrfToken(nonce, token string) string {
+	return a.sign("csrf|" + nonce + "|" + token)
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// clientIP returns the last address in the client IP header, if one is configured
+// and set, or else the host part of the request's remote address.
+func (a *MagicLinkAuth) clientIP(r *http.Request) string {
+	if a.header != "" {
+		if value := r.Header.Get(a.header); value != "" {
+			addresses := strings.Split(value, ",")
+			return strings.TrimSpace(addresses[len(addresses)-1])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// memoryMagicLinkStore keeps the used tokens and sign-in attempts in memory.
+// It is the default for a single replica without a SessionStore.
+type memoryMagicLinkStore struct {
+	mu       sync.Mutex
+	used     map[string]time.Time
+	attempts map[string]attemptWindow
+}
+
+// attemptWindow counts the attempts of a key since the start of its window.
+type attemptWindow struct {
+	start time.Time
+	count int
+}
+
+func newMemoryMagicLinkStore() *memoryMagicLinkStore {
+	return &memoryMagicLinkStore{
+		used:     make(map[string]time.Time),
+		attempts: make(map[string]attemptWindow),
+	}
+}
+
+// UseNonce marks the nonce as used until ttl passed and reports whether it was unused.
+func (s *memoryMagicLinkStore) UseNonce(_ context.Context, nonce string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for n, expires := range s.used {
+		if !now.Before(expires) {
+			delete(s.used, n)
+		}
+	}
+	if _, ok := s.used[nonce]; ok {
+		return false, nil
+	}
+	s.used[nonce] = now.Add(ttl)
+	return true, nil
+}
+
+// CountAttempt counts an attempt for the key in its current window and returns the attempts of the window.
+func (s *memoryMagicLinkStore) CountAttempt(_ context.Context, key string, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for k, w := range s.attempts {
+		if now.Sub(w.start) >= window {
+			delete(s.attempts, k)
+		}
+	}
+	w, ok := s.attempts[key]
+	if !ok {
+		w = attemptWindow{start: now}
+	}
+	w.count++
+	s.attempts[key] = w
+	return w.count, nil
+}
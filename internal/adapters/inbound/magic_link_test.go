@@ -0,0 +1,400 @@
+package inbound_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type mockMagicLinkSender struct {
+	email string
+	link  string
+	calls int
+}
+
+func (s *mockMagicLinkSender) SendMagicLink(_ context.Context, email, link string) error {
+	s.email = email
+	s.link = link
+	s.calls++
+	return nil
+}
+
+func newMagicLinkRequest(email string) *http.Request {
+	form := url.Values{}
+	form.Set("email", email)
+	req := httptest.NewRequest(http.MethodPost, "/auth/magic-link", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func newMagicLinkVerifyRequest(token string) *http.Request {
+	form := url.Values{}
+	form.Set("token", token)
+	req := httptest.NewRequest(http.MethodPost, "/auth/magic-link/verify", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+var csrfTokenPattern = regexp.MustCompile(`name="csrf_token" value="([^"]*)"`)
+
+// newConfirmedMagicLinkVerifyRequest opens the link and submits its confirmation
+// with the CSRF token and cookie, like the browser does.
+func newConfirmedMagicLinkVerifyRequest(t *testing.T, auth *inbound.MagicLinkAuth, token string) *http.Request {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	auth.HttpConfirmLink(createProfileTestEngine(t))(rec, httptest.NewRequest(http.MethodGet, "/auth/magic-link/verify?token="+url.QueryEscape(token), nil))
+	match := csrfTokenPattern.FindStringSubmatch(rec.Body.String())
+	if match == nil {
+		t.Fatal("confirmation must contain a CSRF token")
+	}
+
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("csrf_token", match[1])
+	req := httptest.NewRequest(http.MethodPost, "/auth/magic-link/verify", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, cookie := range rec.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
+	return req
+}
+
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, cookie := range cookies {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Token Tests
+// ============================================================================
+
+func Test_MagicLinkAuth_VerifyToken_With_Issued_Token_Should_Return_Email(t *testing.T) {
+	// Arrange
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080")
+	token, _ := auth.IssueToken("guest@example.com")
+
+	// Act
+	email, err := auth.VerifyToken(context.Background(), token)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "email must match", email, "guest@example.com")
+}
+
+func Test_MagicLinkAuth_VerifyToken_Twice_Should_Return_ErrMagicLinkUsed(t *testing.T) {
+	// Arrange
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080")
+	token, _ := auth.IssueToken("guest@example.com")
+	_, _ = auth.VerifyToken(context.Background(), token)
+
+	// Act
+	_, err := auth.VerifyToken(context.Background(), token)
+
+	// Assert
+	assert.That(t, "err must be ErrMagicLinkUsed", errors.Is(err, inbound.ErrMagicLinkUsed), true)
+}
+
+func Test_MagicLinkAuth_VerifyToken_On_Other_Replica_With_Shared_Store_Should_Return_ErrMagicLinkUsed(t *testing.T) {
+	// Arrange
+	store := newMockSessionStore()
+	first := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080").WithStore(store)
+	second := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080").WithStore(store)
+	token, _ := first.IssueToken("guest@example.com")
+	_, _ = first.VerifyToken(context.Background(), token)
+
+	// Act
+	_, err := second.VerifyToken(context.Background(), token)
+
+	// Assert
+	assert.That(t, "err must be ErrMagicLinkUsed", errors.Is(err, inbound.ErrMagicLinkUsed), true)
+}
+
+func Test_MagicLinkAuth_VerifyToken_With_Expired_Token_Should_Return_ErrMagicLinkExpired(t *testing.T) {
+	// Arrange
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080").WithTTL(-time.Minute)
+	token, _ := auth.IssueToken("guest@example.com")
+
+	// Act
+	_, err := auth.VerifyToken(context.Background(), token)
+
+	// Assert
+	assert.That(t, "err must be ErrMagicLinkExpired", errors.Is(err, inbound.ErrMagicLinkExpired), true)
+}
+
+func Test_MagicLinkAuth_VerifyToken_With_Other_Secret_Should_Return_ErrMagicLinkInvalid(t *testing.T) {
+	// Arrange
+	issuer := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080")
+	verifier := inbound.NewMagicLinkAuth([]byte("other"), &mockMagicLinkSender{}, "http://localhost:8080")
+	token, _ := issuer.IssueToken("guest@example.com")
+
+	// Act
+	_, err := verifier.VerifyToken(context.Background(), token)
+
+	// Assert
+	assert.That(t, "err must be ErrMagicLinkInvalid", errors.Is(err, inbound.ErrMagicLinkInvalid), true)
+}
+
+func Test_MagicLinkAuth_VerifyToken_With_Garbage_Should_Return_ErrMagicLinkInvalid(t *testing.T) {
+	// Arrange
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080")
+
+	// Act
+	_, err := auth.VerifyToken(context.Background(), "not-a-token")
+
+	// Assert
+	assert.That(t, "err must be ErrMagicLinkInvalid", errors.Is(err, inbound.ErrMagicLinkInvalid), true)
+}
+
+// ============================================================================
+// HttpRequestLink Tests
+// ============================================================================
+
+func Test_MagicLinkAuth_HttpRequestLink_With_Valid_Email_Should_Send_Link(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	sender := &mockMagicLinkSender{}
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), sender, "https://hotel.example.com/")
+	rec := httptest.NewRecorder()
+
+	// Act
+	auth.HttpRequestLink(e)(rec, newMagicLinkRequest("guest@example.com"))
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "link must be sent to the guest", sender.email, "guest@example.com")
+	assert.That(t, "link must point to the verify endpoint", strings.HasPrefix(sender.link, "https://hotel.example.com/auth/magic-link/verify?token="), true)
+	assert.That(t, "body must contain confirmation", strings.Contains(string(body), "sign-in link is on its way"), true)
+}
+
+func Test_MagicLinkAuth_HttpRequestLink_With_Invalid_Email_Should_Not_Send_Link(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	sender := &mockMagicLinkSender{}
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), sender, "http://localhost:8080")
+	rec := httptest.NewRecorder()
+
+	// Act
+	auth.HttpRequestLink(e)(rec, newMagicLinkRequest("not-an-email"))
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "sender must not be called", sender.calls, 0)
+	assert.That(t, "body must contain error", strings.Contains(string(body), "valid email address"), true)
+	assert.That(t, "body must keep the submitted email", strings.Contains(string(body), `value="not-an-email"`), true)
+	assert.That(t, "error must describe the input", strings.Contains(string(body), `aria-describedby="email-error"`), true)
+}
+
+func Test_MagicLinkAuth_HttpRequestLink_Over_Rate_Limit_Should_Return_429(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	sender := &mockMagicLinkSender{}
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), sender, "http://localhost:8080").WithRateLimit(2, time.Hour)
+	for range 2 {
+		auth.HttpRequestLink(e)(httptest.NewRecorder(), newMagicLinkRequest("guest@example.com"))
+	}
+	rec := httptest.NewRecorder()
+
+	// Act
+	auth.HttpRequestLink(e)(rec, newMagicLinkRequest("guest@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 429", rec.Code, http.StatusTooManyRequests)
+	assert.That(t, "sender must be called only within the limit", sender.calls, 2)
+}
+
+func Test_MagicLinkAuth_HttpRequestLink_Over_Rate_Limit_On_Other_Replica_With_Shared_Store_Should_Return_429(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	store := newMockSessionStore()
+	first := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080").WithRateLimit(2, time.Hour).WithStore(store)
+	second := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080").WithRateLimit(2, time.Hour).WithStore(store)
+	for range 2 {
+		first.HttpRequestLink(e)(httptest.NewRecorder(), newMagicLinkRequest("guest@example.com"))
+	}
+	rec := httptest.NewRecorder()
+
+	// Act
+	second.HttpRequestLink(e)(rec, newMagicLinkRequest("guest@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 429", rec.Code, http.StatusTooManyRequests)
+}
+
+func Test_MagicLinkAuth_HttpRequestLink_Over_Email_Limit_From_Other_Clients_Should_Not_Send_Link(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	sender := &mockMagicLinkSender{}
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), sender, "http://localhost:8080").WithRateLimit(2, time.Hour)
+	for _, addr := range []string{"198.51.100.1:1234", "198.51.100.2:1234"} {
+		req := newMagicLinkRequest("guest@example.com")
+		req.RemoteAddr = addr
+		auth.HttpRequestLink(e)(httptest.NewRecorder(), req)
+	}
+	issued := strings.TrimPrefix(sender.link, "http://localhost:8080/auth/magic-link/verify?token=")
+	token, _ := url.QueryUnescape(issued)
+	req := newMagicLinkRequest("guest@example.com")
+	req.RemoteAddr = "198.51.100.3:1234"
+	rec := httptest.NewRecorder()
+
+	// Act
+	auth.HttpRequestLink(e)(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain confirmation", strings.Contains(string(body), "sign-in link is on its way"), true)
+	assert.That(t, "sender must be called only within the limit", sender.calls, 2)
+	_, err := auth.VerifyToken(context.Background(), token)
+	assert.That(t, "link sent before must still be usable", err, nil)
+}
+
+func Test_MagicLinkAuth_HttpRequestLink_With_Client_IP_Header_Should_Limit_Forwarded_Client(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080").
+		WithRateLimit(1, time.Hour).
+		WithClientIPHeader("X-Forwarded-For")
+	first := newMagicLinkRequest("first@example.com")
+	first.Header.Set("X-Forwarded-For", "192.0.2.99, 203.0.113.1")
+	auth.HttpRequestLink(e)(httptest.NewRecorder(), first)
+	other := newMagicLinkRequest("other@example.com")
+	other.Header.Set("X-Forwarded-For", "203.0.113.2")
+	same := newMagicLinkRequest("same@example.com")
+	same.Header.Set("X-Forwarded-For", "192.0.2.100, 203.0.113.1")
+	otherRec := httptest.NewRecorder()
+	sameRec := httptest.NewRecorder()
+
+	// Act
+	auth.HttpRequestLink(e)(otherRec, other)
+	auth.HttpRequestLink(e)(sameRec, same)
+
+	// Assert
+	assert.That(t, "other client behind the proxy must be allowed", otherRec.Code, http.StatusOK)
+	assert.That(t, "same client behind the proxy must be limited", sameRec.Code, http.StatusTooManyRequests)
+}
+
+// ============================================================================
+// HttpConfirmLink Tests
+// ============================================================================
+
+func Test_MagicLinkAuth_HttpConfirmLink_With_Valid_Token_Should_Show_Confirmation_Without_Using_Token(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080")
+	token, _ := auth.IssueToken("guest@example.com")
+	req := httptest.NewRequest(http.MethodGet, "/auth/magic-link/verify?token="+url.QueryEscape(token), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	auth.HttpConfirmLink(e)(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "form must post to the verify endpoint", strings.Contains(string(body), `action="/auth/magic-link/verify"`), true)
+	assert.That(t, "form must carry the token", strings.Contains(string(body), `value="`+token+`"`), true)
+	assert.That(t, "form must carry a CSRF token", csrfTokenPattern.MatchString(string(body)), true)
+	assert.That(t, "CSRF cookie must be set", findCookie(rec.Result().Cookies(), "magic_link_csrf") != nil, true)
+	assert.That(t, "no session cookie must be set", findCookie(rec.Result().Cookies(), "sid") == nil, true)
+	_, err := auth.VerifyToken(context.Background(), token)
+	assert.That(t, "token must still be usable", err, nil)
+}
+
+func Test_MagicLinkAuth_HttpConfirmLink_With_Invalid_Token_Should_Redirect_To_Error(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080")
+	req := httptest.NewRequest(http.MethodGet, "/auth/magic-link/verify?token=invalid", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	auth.HttpConfirmLink(e)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be error page", strings.HasPrefix(rec.Header().Get("Location"), "/ui/error"), true)
+}
+
+// ============================================================================
+// HttpVerifyLink Tests
+// ============================================================================
+
+func Test_MagicLinkAuth_HttpVerifyLink_With_Valid_Token_Should_Create_Session(t *testing.T) {
+	// Arrange
+	t.Setenv("REDIRECT_URL", "/ui/")
+	sessions := web.NewServerSessions()
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080")
+	token, _ := auth.IssueToken("guest@example.com")
+	req := newConfirmedMagicLinkVerifyRequest(t, auth, token)
+	rec := httptest.NewRecorder()
+
+	// Act
+	auth.HttpVerifyLink(sessions)(rec, req)
+
+	// Assert
+	cookie := findCookie(rec.Result().Cookies(), "sid")
+	assert.That(t, "status code must be 302", rec.Code, http.StatusFound)
+	assert.That(t, "location must be redirect URL", rec.Header().Get("Location"), "/ui/")
+	assert.That(t, "session cookie must be set", cookie != nil, true)
+	session, ok := sessions.Read(cookie.Value)
+	assert.That(t, "session must exist", ok, true)
+	claims, _ := session.Data.(web.IdentityTokenClaims)
+	assert.That(t, "session email must match", claims.Email, "guest@example.com")
+	assert.That(t, "session email must be verified", claims.Verified, true)
+}
+
+func Test_MagicLinkAuth_HttpVerifyLink_With_Invalid_Token_Should_Redirect_To_Error(t *testing.T) {
+	// Arrange
+	sessions := web.NewServerSessions()
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080")
+	req := newMagicLinkVerifyRequest("invalid")
+	rec := httptest.NewRecorder()
+
+	// Act
+	auth.HttpVerifyLink(sessions)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be error page", strings.HasPrefix(rec.Header().Get("Location"), "/ui/error"), true)
+	assert.That(t, "no session cookie must be set", len(rec.Result().Cookies()), 0)
+}
+
+func Test_MagicLinkAuth_HttpVerifyLink_Without_CSRF_Token_Should_Redirect_To_Error_Without_Using_Token(t *testing.T) {
+	// Arrange
+	sessions := web.NewServerSessions()
+	auth := inbound.NewMagicLinkAuth([]byte("secret"), &mockMagicLinkSender{}, "http://localhost:8080")
+	token, _ := auth.IssueToken("attacker@example.com")
+	req := newMagicLinkVerifyRequest(token)
+	req.AddCookie(&http.Cookie{Name: "magic_link_csrf", Value: "forged"})
+	rec := httptest.NewRecorder()
+
+	// Act
+	auth.HttpVerifyLink(sessions)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be error page", strings.HasPrefix(rec.Header().Get("Location"), "/ui/error"), true)
+	assert.That(t, "no session cookie must be set", len(rec.Result().Cookies()), 0)
+	_, err := auth.VerifyToken(context.Background(), token)
+	assert.That(t, "token must still be usable", err, nil)
+}
@@ -0,0 +1,190 @@
+package inbound
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+)
+
+// maxRecordedMessageSize limits the size of a line of a recording.
+const maxRecordedMessageSize = 4 << 20
+
+// ErrRecordingNotErasable is returned by Erase if the recording cannot be rewritten.
+var ErrRecordingNotErasable = errors.New("recording cannot be rewritten")
+
+// erasableRecording is a recording that can be rewritten in place,
+// e.g. a file opened for reading and appending.
+type erasableRecording interface {
+	io.ReadWriteSeeker
+	Truncate(size int64) error
+}
+
+// RecordedMessage is a line of a recording: a message with the time it was published.
+// The payload is kept as JSON (the encoding of every codec), so recordings can be read
+// and edited by hand.
+type RecordedMessage struct {
+	Time  time.Time       `json:"time"`
+	Topic string          `json:"topic"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// RecordingDispatcher decorates a messaging.Dispatcher so that every message it
+// publishes is appended to a recording, one JSON line per message, in the order the
+// decorated dispatcher accepted them. Replay republishes a recording, which is useful
+// to reproduce an incident locally with the events of production.
+type RecordingDispatcher struct {
+	dispatcher messaging.Dispatcher
+	mutex      sync.Mutex
+	writer     io.Writer
+	now        func() time.Time
+}
+
+// NewRecordingDispatcher creates a new recording dispatcher that writes to the writer,
+// e.g. a file opened for appending.
+func NewRecordingDispatcher(dispatcher messaging.Dispatcher, writer io.Writer) *RecordingDispatcher {
+	return &RecordingDispatcher{
+		dispatcher: dispatcher,
+		writer:     writer,
+		now:        time.Now,
+	}
+}
+
+// Publish publishes a message with the decorated dispatcher and records it.
+// Messages the decorated dispatcher rejects are not recorded, as they were never published.
+func (d *RecordingDispatcher) Publish(ctx context.Context, message messaging.Message) error {
+	if err := d.dispatcher.Publish(ctx, message); err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	line, err := json.Marshal(RecordedMessage{Time: d.now().UTC(), Topic: message.Topic, Data: message.Data})
+	if err != nil {
+		return fmt.Errorf("failed to record message: %w", err)
+	}
+	if _, err := d.writer.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to record message: %w", err)
+	}
+	return nil
+}
+
+// Erase removes the recorded messages of the guest or the reservations, e.g. on an
+// erasure request, by rewriting the recording in place. It returns the number of
+// removed messages. The writer must be a file opened for reading and appending.
+func (d *RecordingDispatcher) Erase(guestID string, reservationIDs []string) (int, error) {
+	file, ok := d.writer.(erasableRecording)
+	if !ok {
+		return 0, ErrRecordingNotErasable
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to read recording: %w", err)
+	}
+	messages, err := ReadRecording(file)
+	if err != nil {
+		return 0, err
+	}
+
+	var kept []byte
+	erased := 0
+	for _, message := range messages {
+		var refs struct {
+			ReservationID string `json:"reservation_id"`
+			GuestID       string `json:"guest_id"`
+		}
+		_ = json.Unmarshal(message.Data, &refs)
+		if slices.Contains(reservationIDs, refs.ReservationID) || (guestID != "" && refs.GuestID == guestID) {
+			erased++
+			continue
+		}
+		line, err := json.Marshal(message)
+		if err != nil {
+			return 0, fmt.Errorf("failed to rewrite recording: %w", err)
+		}
+		kept = append(append(kept, line...), '\n')
+	}
+	if erased == 0 {
+		return 0, nil
+	}
+
+	// Appending writes go to the end, which is the start after the truncation.
+	if err := file.Truncate(0); err != nil {
+		return 0, fmt.Errorf("failed to rewrite recording: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to rewrite recording: %w", err)
+	}
+	if _, err := file.Write(kept); err != nil {
+		return 0, fmt.Errorf("failed to rewrite recording: %w", err)
+	}
+	return erased, nil
+}
+
+// Subscribe subscribes the handler with the decorated dispatcher.
+func (d *RecordingDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return d.dispatcher.Subscribe(ctx, topic, fn)
+}
+
+// SubscribeGroup subscribes the handler in a consumer group.
+// Without consumer groups in the decorated dispatcher, it subscribes as usual.
+func (d *RecordingDispatcher) SubscribeGroup(ctx context.Context, group, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	if subscriber, ok := d.dispatcher.(GroupSubscriber); ok {
+		return subscriber.SubscribeGroup(ctx, group, topic, fn)
+	}
+	return d.dispatcher.Subscribe(ctx, topic, fn)
+}
+
+// ReadRecording reads the messages of a recording in their recorded order.
+func ReadRecording(r io.Reader) ([]RecordedMessage, error) {
+	var messages []RecordedMessage
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordedMessageSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var message RecordedMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			return nil, fmt.Errorf("invalid recording at line %d: %w", line, err)
+		}
+		messages = append(messages, message)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	return messages, nil
+}
+
+// Replay republishes the messages with the dispatcher in their recorded order.
+// The gaps between the messages are kept, divided by speed: 2 replays twice as fast,
+// and 0 (or less) replays without waiting. Replay stops when the context is done.
+func Replay(ctx context.Context, dispatcher messaging.Dispatcher, messages []RecordedMessage, speed float64) error {
+	for i, message := range messages {
+		if i > 0 && speed > 0 {
+			if gap := message.Time.Sub(messages[i-1].Time); gap > 0 {
+				timer := time.NewTimer(time.Duration(float64(gap) / speed))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return ctx.Err()
+				case <-timer.C:
+				}
+			}
+		}
+		if err := dispatcher.Publish(ctx, messaging.NewMessage(message.Topic, message.Data)); err != nil {
+			return fmt.Errorf("failed to replay message %d (%s): %w", i+1, message.Topic, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package inbound_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// RecordingDispatcher Tests
+// ============================================================================
+
+func Test_RecordingDispatcher_Publish_Should_Record_Messages_In_Order(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	next := &mockDispatcher{}
+	var recording bytes.Buffer
+	dispatcher := inbound.NewRecordingDispatcher(next, &recording)
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("reservation.created", []byte(`{"reservation_id":"res-001"}`)))
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("payment.captured", []byte(`{"reservation_id":"res-001"}`)))
+	messages, err := inbound.ReadRecording(&recording)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "messages must be published", len(next.publishedMessages), 2)
+	assert.That(t, "messages must be recorded", len(messages), 2)
+	assert.That(t, "first topic must match", messages[0].Topic, "reservation.created")
+	assert.That(t, "second topic must match", messages[1].Topic, "payment.captured")
+	assert.That(t, "payload must be kept as JSON", string(messages[0].Data), `{"reservation_id":"res-001"}`)
+	assert.That(t, "time must be set", messages[0].Time.IsZero(), false)
+}
+
+func Test_ReadRecording_With_Invalid_Line_Should_Return_Error(t *testing.T) {
+	// Arrange
+	line := `{"time":"` + time.Now().UTC().Format(time.RFC3339) + `","topic":"payment.captured","data":{}}`
+	recording := strings.NewReader(line + "\nnot json\n")
+
+	// Act
+	_, err := inbound.ReadRecording(recording)
+
+	// Assert
+	assert.That(t, "err must name the line", err != nil && strings.Contains(err.Error(), "line 2"), true)
+}
+
+func Test_RecordingDispatcher_Erase_Should_Remove_Messages_Of_Guest_And_Reservations(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	file, err := os.OpenFile(filepath.Join(t.TempDir(), "events.jsonl"), os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o600)
+	assert.That(t, "file must be opened", err, nil)
+	t.Cleanup(func() { _ = file.Close() })
+	dispatcher := inbound.NewRecordingDispatcher(&mockDispatcher{}, file)
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("reservation.created", []byte(`{"reservation_id":"res-001"}`)))
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("payment.method_added", []byte(`{"guest_id":"john@example.com"}`)))
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("reservation.created", []byte(`{"reservation_id":"res-002"}`)))
+
+	// Act
+	erased, err := dispatcher.Erase("john@example.com", []string{"res-001"})
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("payment.captured", []byte(`{"reservation_id":"res-002"}`)))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "two messages must be erased", erased, 2)
+	_, _ = file.Seek(0, io.SeekStart)
+	messages, _ := inbound.ReadRecording(file)
+	assert.That(t, "recording must keep two messages", len(messages), 2)
+	assert.That(t, "other reservation must be kept", string(messages[0].Data), `{"reservation_id":"res-002"}`)
+	assert.That(t, "recording must continue after the erasure", messages[1].Topic, "payment.captured")
+}
+
+func Test_RecordingDispatcher_Erase_Without_File_Should_Return_Error(t *testing.T) {
+	// Arrange
+	var recording bytes.Buffer
+	dispatcher := inbound.NewRecordingDispatcher(&mockDispatcher{}, &recording)
+
+	// Act
+	_, err := dispatcher.Erase("john@example.com", nil)
+
+	// Assert
+	assert.That(t, "err must be ErrRecordingNotErasable", errors.Is(err, inbound.ErrRecordingNotErasable), true)
+}
+
+// ============================================================================
+// Replay Tests
+// ============================================================================
+
+func Test_Replay_Should_Republish_Messages_In_Recorded_Order(t *testing.T) {
+	// Arrange
+	start := time.Now().Add(-24 * time.Hour)
+	messages := []inbound.RecordedMessage{
+		{Time: start, Topic: "reservation.created", Data: []byte(`{"reservation_id":"res-001"}`)},
+		{Time: start.Add(time.Hour), Topic: "payment.captured", Data: []byte(`{"reservation_id":"res-001"}`)},
+	}
+	next := &mockDispatcher{}
+
+	// Act
+	err := inbound.Replay(context.Background(), next, messages, 0)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "messages must be republished", len(next.publishedMessages), 2)
+	assert.That(t, "first topic must match", next.publishedMessages[0].Topic, "reservation.created")
+	assert.That(t, "second topic must match", next.publishedMessages[1].Topic, "payment.captured")
+	assert.That(t, "payload must match", string(next.publishedMessages[1].Data), `{"reservation_id":"res-001"}`)
+}
+
+func Test_Replay_Should_Keep_Gaps_Divided_By_Speed(t *testing.T) {
+	// Arrange
+	start := time.Now().Add(-24 * time.Hour)
+	messages := []inbound.RecordedMessage{
+		{Time: start, Topic: "reservation.created", Data: []byte(`{}`)},
+		{Time: start.Add(time.Second), Topic: "payment.captured", Data: []byte(`{}`)},
+	}
+	next := &mockDispatcher{}
+
+	// Act
+	begin := time.Now()
+	err := inbound.Replay(context.Background(), next, messages, 20)
+	elapsed := time.Since(begin)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "replay must wait for the gap", elapsed >= 50*time.Millisecond, true)
+}
+
+func Test_Replay_With_Cancelled_Context_Should_Stop(t *testing.T) {
+	// Arrange
+	start := time.Now().Add(-24 * time.Hour)
+	messages := []inbound.RecordedMessage{
+		{Time: start, Topic: "reservation.created", Data: []byte(`{}`)},
+		{Time: start.Add(time.Hour), Topic: "payment.captured", Data: []byte(`{}`)},
+	}
+	next := &mockDispatcher{}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := inbound.Replay(ctx, next, messages, 1)
+
+	// Assert
+	assert.That(t, "err must be the context error", err, context.DeadlineExceeded)
+	assert.That(t, "only the first message must be republished", len(next.publishedMessages), 1)
+}
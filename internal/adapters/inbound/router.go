@@ -5,54 +5,143 @@ import (
 	"io/fs"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/andygeiss/cloud-native-utils/logging"
 	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/cloud-native-utils/messaging"
 	"github.com/andygeiss/cloud-native-utils/templating"
 	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/admin"
+	"github.com/andygeiss/hotel-booking/internal/domain/calendar"
+	"github.com/andygeiss/hotel-booking/internal/domain/channel"
+	"github.com/andygeiss/hotel-booking/internal/domain/housekeeping"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoicing"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/privacy"
+	"github.com/andygeiss/hotel-booking/internal/domain/reconciliation"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/review"
+	"github.com/andygeiss/hotel-booking/internal/domain/search"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 	"github.com/coreos/go-oidc/v3/oidc"
 )
 
 // RouterConfig holds all dependencies for HTTP routing.
 type RouterConfig struct {
-	Ctx                context.Context
-	EFS                fs.FS
-	Logger             *slog.Logger
-	MCPServer          *mcp.Server // Optional: nil disables MCP endpoint
-	ReservationService *reservation.Service
-	Verifier           *oidc.IDTokenVerifier // Required if MCPServer is set
+	AddOnService            *orchestration.AddOnService   // Optional: nil disables the add-on API, requires Verifier
+	AdminEmails             []string                      // Required if AdminService is set, staff allowed to open the admin dashboard
+	AdminService            *admin.Service                // Optional: nil disables the admin dashboard
+	BookingService          *orchestration.BookingService // Required: cancels guest reservations together with their refunds
+	CalendarFeedToken       string                        // Optional: serves room calendars with a ?token= secret instead of bearer tokens
+	CalendarService         *calendar.Service             // Optional: nil disables room calendar export, requires Verifier or CalendarFeedToken
+	ChannelService          *channel.Service              // Optional: nil disables the channel manager webhook
+	ChannelWebhookSecret    []byte                        // Required if ChannelService is set, verifies webhook signatures
+	Compression             *Compression                  // Optional: nil disables response compression
+	Ctx                     context.Context
+	DepositService          *orchestration.DepositService // Optional: nil disables the deposit API, requires Verifier
+	EFS                     fs.FS
+	EventSimulator          messaging.Dispatcher  // Optional: nil disables the event simulator API for local development, requires Verifier
+	HousekeepingService     *housekeeping.Service // Optional: nil disables the housekeeping task API, requires Verifier
+	IDGenerator             shared.IDGenerator    // Optional: nil defaults to UUIDv7
+	InvoiceService          *invoicing.Service    // Optional: nil disables invoice API, requires Verifier
+	Logger                  *slog.Logger
+	MagicLink               *MagicLinkAuth                            // Optional: nil disables passwordless sign-in
+	MCPServer               *mcp.Server                               // Optional: nil disables MCP endpoint
+	Metrics                 http.Handler                              // Optional: nil disables the Prometheus metrics endpoint (/metrics)
+	NotificationDelivery    *orchestration.NotificationTracker        // Optional: nil disables the notification delivery status API, requires Verifier
+	NotificationPreferences bool                                      // Optional: serves the notification preference API, requires Verifier and guest profiles in ReservationService
+	NotificationPreview     *orchestration.NotificationPreviewService // Optional: nil disables the notification preview API, requires Verifier
+	PaymentMethods          bool                                      // Optional: serves the stored payment method API, requires Verifier and payment methods in PaymentService
+	PaymentService          *payment.Service                          // Optional: nil disables the dispute API (requires Verifier) and webhook (requires PaymentWebhookSecret)
+	PaymentWebhookSecret    []byte                                    // Optional: verifies the signatures of the payment gateway's dispute webhook
+	PrivacyService          *privacy.Service                          // Optional: nil disables privacy API, requires Verifier
+	ReconciliationService   *reconciliation.Service                   // Optional: nil disables reconciliation report, requires Verifier
+	RequireClientCert       bool                                      // Optional: requires verified TLS client certificates on API routes
+	ReservationService      *reservation.Service
+	ReviewService           *review.Service            // Optional: nil disables reviews and ratings, moderation requires Verifier
+	RoomBlocks              bool                       // Optional: serves the room block API, requires Verifier and room blocks in ReservationService
+	SagaTracker             *orchestration.SagaTracker // Optional: nil disables the booking status page
+	SearchService           *search.Service            // Optional: nil disables reservation search, requires Verifier
+	SessionStore            SessionStore               // Optional: nil keeps sessions in memory only
+	SessionTTL              time.Duration              // Optional: idle timeout of stored sessions, defaults to 24h
+	StartupProbe            *StartupProbe              // Optional: nil disables the startup probe (/startup)
+	StaticMaxAge            time.Duration              // Optional: max-age of unversioned static assets, 0 revalidates them with their ETag
+	Verifier                *oidc.IDTokenVerifier      // Required if MCPServer is set
 }
 
-// Route creates a new mux with the liveness and readiness probe (/liveness, /readiness),
-// the static assets endpoint (/) and the ui endpoints (/ui).
+// Route creates a new mux with the liveness, readiness and startup probe (/liveness, /readiness, /startup),
+// the metrics endpoint (/metrics), the static assets endpoint (/) and the ui endpoints (/ui).
 // The EFS field in config accepts any fs.FS implementation (embed.FS, fs.Sub result, etc.).
 func Route(config RouterConfig) *http.ServeMux {
 	// Create a new mux with liveness and readyness endpoint.
 	// Embed the assets into the mux.
 	mux, serverSessions := web.NewServeMux(config.Ctx, config.EFS)
 
+	// Add the startup probe, which succeeds once the startup checks have passed.
+	// Kubernetes waits for it before the liveness and readiness probes are started.
+	if config.StartupProbe != nil {
+		mux.HandleFunc("GET /startup", HttpStartup(config.StartupProbe))
+	}
+
+	// Add the metrics endpoint, which Prometheus scrapes like the probes.
+	if config.Metrics != nil {
+		mux.Handle("GET /metrics", config.Metrics)
+	}
+
+	// Hash the static assets and load their fingerprinted names from the asset manifest.
+	assets := NewStaticAssets(config.EFS, config.StaticMaxAge)
+
 	// Create a new templating engine.
 	// We use the fs.FS to load the templates from the file system.
+	// The assetPath helpers of the templates are resolved to the fingerprinted names.
 	// We use the templating.Engine from cloud-native-utils and reuse it for all views.
-	e := templating.NewEngine(config.EFS)
+	e := templating.NewEngine(assets.TemplateFS(config.EFS))
 
 	// Parse the templates under the assets/templates directory.
 	// Every template must have a .tmpl extension.
 	e.Parse("assets/templates/*.tmpl")
 
+	// Use UUIDv7 for new aggregate IDs unless a generator is injected.
+	ids := config.IDGenerator
+	if ids == nil {
+		ids = shared.NewUUIDv7Generator()
+	}
+
+	// UI pages render their errors as error pages; API routes answer with problem+json.
+	page := func(next http.HandlerFunc) http.HandlerFunc {
+		return WithErrorPages(e, next)
+	}
+
+	// Authenticated UI pages read the session once (SessionContext) and are rendered in the
+	// guest's locale (profile or Accept-Language) and theme (theme cookie or profile).
+	ui := func(next http.HandlerFunc) http.HandlerFunc {
+		return page(web.WithAuth(serverSessions, WithSessionContext(WithLocale(config.ReservationService, WithTheme(config.ReservationService, next)))))
+	}
+
 	// The static assets are served from the embed.FS under the /static path directly.
 	// This is defined in the web.NewServeMux function from cloud-native-utils.
+	// GET and HEAD requests are served with ETag and Cache-Control headers instead.
+	mux.Handle("GET /static/", assets)
 
 	// Add the index endpoint for the UI.
 	// The HttpViewIndex is handling unauthenticated and authenticated requests.
 	// The unauthenticated requests are redirected to the login page /ui/login.
 	// The authenticated requests are rendered with the index template.
-	mux.HandleFunc("GET /ui/", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpViewIndex(e))))
+	mux.HandleFunc("GET /ui/", logging.WithLogging(config.Logger, ui(HttpViewIndex(e))))
 
 	// Add the login endpoint for the UI.
 	// This endpoint is used to forward the user to the login page of the OIDC provider.
-	mux.HandleFunc("GET /ui/login", logging.WithLogging(config.Logger, HttpViewLogin(e)))
+	mux.HandleFunc("GET /ui/login", logging.WithLogging(config.Logger, page(HttpViewLogin(e, config.MagicLink != nil))))
+
+	// Add the passwordless sign-in endpoints as an alternative to the OIDC provider.
+	// The sign-in link is emailed to the guest and creates a session like the OIDC callback.
+	if config.MagicLink != nil {
+		mux.HandleFunc("POST /auth/magic-link", logging.WithLogging(config.Logger, page(config.MagicLink.HttpRequestLink(e))))
+		mux.HandleFunc("GET "+magicLinkVerifyPath, logging.WithLogging(config.Logger, page(config.MagicLink.HttpConfirmLink(e))))
+		mux.HandleFunc("POST "+magicLinkVerifyPath, logging.WithLogging(config.Logger, page(config.MagicLink.HttpVerifyLink(serverSessions))))
+	}
 
 	// Add the error endpoint for displaying user-friendly error pages.
 	// This endpoint accepts query parameters: title, message, and details.
@@ -67,29 +156,213 @@ func Route(config RouterConfig) *http.ServeMux {
 	mux.HandleFunc("GET /sw.js", logging.WithLogging(config.Logger, HttpViewServiceWorker(e)))
 
 	// Add the reservations list endpoint.
-	mux.HandleFunc("GET /ui/reservations", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpViewReservations(e, config.ReservationService))))
+	mux.HandleFunc("GET /ui/reservations", logging.WithLogging(config.Logger, ui(HttpViewReservations(e, config.ReservationService))))
 
 	// Add the new reservation form endpoint.
-	mux.HandleFunc("GET /ui/reservations/new", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpViewReservationForm(e))))
+	mux.HandleFunc("GET /ui/reservations/new", logging.WithLogging(config.Logger, ui(HttpViewReservationForm(e))))
 
 	// Add the create reservation endpoint.
-	mux.HandleFunc("POST /ui/reservations", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpCreateReservation(e, config.ReservationService))))
+	mux.HandleFunc("POST /ui/reservations", logging.WithLogging(config.Logger, ui(HttpCreateReservation(e, config.ReservationService, ids))))
 
 	// Add the reservation detail endpoint.
-	mux.HandleFunc("GET /ui/reservations/{id}", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpViewReservationDetail(e, config.ReservationService))))
+	mux.HandleFunc("GET /ui/reservations/{id}", logging.WithLogging(config.Logger, ui(HttpViewReservationDetail(e, config.ReservationService))))
 
 	// Add the cancel reservation endpoint.
-	mux.HandleFunc("POST /ui/reservations/{id}/cancel", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpCancelReservation(config.ReservationService))))
+	mux.HandleFunc("POST /ui/reservations/{id}/cancel", logging.WithLogging(config.Logger, ui(HttpCancelReservation(config.ReservationService, config.BookingService))))
+
+	// Add the booking status page, which shows the progress of the booking saga.
+	// The page receives live updates from the event stream until the saga is done.
+	if config.SagaTracker != nil {
+		mux.HandleFunc("GET /ui/bookings/{id}/status", logging.WithLogging(config.Logger, ui(HttpViewBookingStatus(e, config.ReservationService, config.SagaTracker))))
+		mux.HandleFunc("GET /ui/bookings/{id}/status/stream", logging.WithLogging(config.Logger, ui(HttpStreamBookingStatus(e, config.ReservationService, config.SagaTracker))))
+	}
+
+	// Add the admin dashboard for the hotel staff, restricted to the admin email addresses.
+	// Panels refresh by htmx polling; recent events are pushed over an event stream.
+	// Arriving guests are checked in with a digital registration card.
+	// The message trace shows the handler outcomes of recent events, per correlation ID.
+	if config.AdminService != nil && len(config.AdminEmails) > 0 {
+		staff := func(next http.HandlerFunc) http.HandlerFunc {
+			return ui(WithAdmin(config.AdminEmails, next))
+		}
+		mux.HandleFunc("GET /ui/admin", logging.WithLogging(config.Logger, staff(HttpViewAdminDashboard(e, config.AdminService))))
+		mux.HandleFunc("GET /ui/admin/panels/{panel}", logging.WithLogging(config.Logger, staff(HttpViewAdminPanel(e, config.AdminService))))
+		mux.HandleFunc("GET /ui/admin/events/stream", logging.WithLogging(config.Logger, staff(HttpStreamAdminEvents(e, config.AdminService))))
+		mux.HandleFunc("GET /ui/admin/trace", logging.WithLogging(config.Logger, staff(HttpViewAdminTrace(e, config.AdminService))))
+		mux.HandleFunc("GET /ui/admin/checkin/{id}", logging.WithLogging(config.Logger, staff(HttpViewCheckIn(e, config.ReservationService))))
+		mux.HandleFunc("POST /ui/admin/checkin/{id}", logging.WithLogging(config.Logger, staff(HttpCheckIn(e, config.ReservationService))))
+	}
+
+	// Define a protected endpoint for the guest's account page (profile and own reservations).
+	mux.HandleFunc("GET /ui/profile", logging.WithLogging(config.Logger, ui(HttpViewProfile(e, config.ReservationService))))
+
+	// Define a protected endpoint for updating the guest's profile.
+	mux.HandleFunc("POST /ui/profile", logging.WithLogging(config.Logger, ui(HttpUpdateProfile(e, config.ReservationService))))
+
+	// Define the theme toggle of the UI. It also works before sign-in, e.g. on the login page,
+	// and saves the theme in the profile of signed-in guests.
+	mux.HandleFunc("POST /ui/theme", logging.WithLogging(config.Logger, page(web.WithAuth(serverSessions, WithSessionContext(HttpSetTheme(config.ReservationService))))))
+
+	// Machine-facing API routes (MCP, privacy) additionally require a verified
+	// TLS client certificate when mTLS is enabled.
+	api := func(next http.HandlerFunc) http.HandlerFunc {
+		if config.RequireClientCert {
+			return WithClientCert(next)
+		}
+		return next
+	}
 
 	// Add MCP endpoint if configured.
 	if config.MCPServer != nil {
 		mcpHandler := web.NewMCPHandler(config.MCPServer)
 		if config.Verifier != nil {
-			mux.Handle("POST /mcp", logging.WithLogging(config.Logger, web.WithBearerAuth(config.Verifier, mcpHandler.Handler())))
+			mux.Handle("POST /mcp", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, WithToolGuest(mcpHandler.Handler())))))
 		} else {
-			mux.Handle("POST /mcp", logging.WithLogging(config.Logger, mcpHandler.Handler()))
+			mux.Handle("POST /mcp", logging.WithLogging(config.Logger, api(mcpHandler.Handler())))
 		}
 	}
 
-	return mux
+	// Add the privacy API for data subject requests (GDPR export and erasure).
+	// It is only served with a token verifier, because it exposes every guest's data.
+	if config.PrivacyService != nil && config.Verifier != nil {
+		mux.HandleFunc("GET /api/privacy/guests/{id}/export", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpExportGuestData(config.PrivacyService)))))
+		mux.HandleFunc("POST /api/privacy/guests/{id}/erase", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpEraseGuestData(config.PrivacyService)))))
+	}
+
+	// Add the invoice API for downloading the PDF receipt of a paid reservation.
+	if config.InvoiceService != nil && config.Verifier != nil {
+		mux.HandleFunc("GET /api/reservations/{id}/invoice.pdf", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpDownloadInvoice(config.ReservationService, config.InvoiceService)))))
+	}
+
+	// Add the reservation search for staff, answered by the search index.
+	if config.SearchService != nil && config.Verifier != nil {
+		mux.HandleFunc("GET /api/reservations/search", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpSearchReservations(config.SearchService)))))
+	}
+
+	// Add the report of the payment reconciliation with the gateway's settlements.
+	if config.ReconciliationService != nil && config.Verifier != nil {
+		mux.HandleFunc("GET /api/reconciliation/report", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpReconciliationReport(config.ReconciliationService)))))
+	}
+
+	// Add the dispute API for contesting chargebacks and recording their outcome.
+	if config.PaymentService != nil && config.Verifier != nil {
+		mux.HandleFunc("GET /api/disputes", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpListDisputes(config.PaymentService)))))
+		mux.HandleFunc("POST /api/payments/{id}/dispute/evidence", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpSubmitDisputeEvidence(config.PaymentService)))))
+		mux.HandleFunc("POST /api/payments/{id}/dispute/resolve", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpResolveDispute(config.PaymentService)))))
+	}
+
+	// Add the API for the cards guests stored to book with one click.
+	if config.PaymentMethods && config.PaymentService != nil && config.Verifier != nil {
+		mux.HandleFunc("GET /api/guests/{id}/payment-methods", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpListPaymentMethods(config.PaymentService)))))
+		mux.HandleFunc("POST /api/guests/{id}/payment-methods", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpAddPaymentMethod(config.PaymentService, ids)))))
+		mux.HandleFunc("DELETE /api/guests/{id}/payment-methods/{method}", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpDeletePaymentMethod(config.PaymentService)))))
+	}
+
+	// Add the API for the channels guests are notified on (email, SMS, push).
+	if config.NotificationPreferences && config.Verifier != nil {
+		mux.HandleFunc("GET /api/guests/{id}/notification-preferences", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpGetNotificationPreferences(config.ReservationService)))))
+		mux.HandleFunc("PUT /api/guests/{id}/notification-preferences", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpUpdateNotificationPreferences(config.ReservationService)))))
+	}
+
+	// Add the iCal export of room calendars for external platforms (e.g. Airbnb).
+	// Platforms that cannot send bearer tokens subscribe to a link with the feed token.
+	if config.CalendarService != nil {
+		switch {
+		case config.CalendarFeedToken != "":
+			mux.HandleFunc("GET /api/rooms/{id}/calendar.ics", logging.WithLogging(config.Logger, WithFeedToken(config.CalendarFeedToken, HttpExportRoomCalendar(config.CalendarService))))
+		case config.Verifier != nil:
+			mux.HandleFunc("GET /api/rooms/{id}/calendar.ics", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpExportRoomCalendar(config.CalendarService)))))
+		}
+	}
+
+	// Add the admin API for maintenance and renovation blocks of rooms.
+	if config.RoomBlocks && config.Verifier != nil {
+		mux.HandleFunc("GET /api/rooms/{id}/blocks", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpListRoomBlocks(config.ReservationService)))))
+		mux.HandleFunc("POST /api/rooms/{id}/blocks", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpCreateRoomBlock(config.ReservationService, ids)))))
+		mux.HandleFunc("DELETE /api/rooms/{id}/blocks/{block}", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpDeleteRoomBlock(config.ReservationService)))))
+	}
+
+	// Add the deposit API for charging incidentals against the deposit of a stay.
+	if config.DepositService != nil && config.Verifier != nil {
+		mux.HandleFunc("GET /api/reservations/{id}/deposit", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpGetDeposit(config.DepositService)))))
+		mux.HandleFunc("POST /api/reservations/{id}/deposit/incidentals", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpChargeIncidentals(config.DepositService)))))
+	}
+
+	// Add the add-on API for booking extras of a stay before check-in.
+	if config.AddOnService != nil && config.Verifier != nil {
+		mux.HandleFunc("POST /api/reservations/{id}/add-ons", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpAddAddOn(config.AddOnService)))))
+		mux.HandleFunc("DELETE /api/reservations/{id}/add-ons/{addOn}", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpRemoveAddOn(config.AddOnService)))))
+	}
+
+	// Add the housekeeping API for assigning and completing the cleaning tasks.
+	if config.HousekeepingService != nil && config.Verifier != nil {
+		mux.HandleFunc("GET /api/housekeeping/tasks", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpListHousekeepingTasks(config.HousekeepingService)))))
+		mux.HandleFunc("POST /api/housekeeping/tasks/{id}/assign", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpAssignHousekeepingTask(config.HousekeepingService)))))
+		mux.HandleFunc("POST /api/housekeeping/tasks/{id}/complete", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpCompleteHousekeepingTask(config.HousekeepingService)))))
+	}
+
+	// Add the review API. Guests write their review via the emailed link, whose token
+	// authorizes them instead of a bearer token; the ratings are public for the UI.
+	if config.ReviewService != nil {
+		mux.HandleFunc("GET /api/reviews/{id}", logging.WithLogging(config.Logger, HttpGetReviewRequest(config.ReviewService)))
+		mux.HandleFunc("POST /api/reviews/{id}", logging.WithLogging(config.Logger, HttpSubmitReview(config.ReviewService)))
+		mux.HandleFunc("GET /api/rooms/{id}/rating", logging.WithLogging(config.Logger, HttpGetRoomRating(config.ReviewService)))
+		mux.HandleFunc("GET /api/rating", logging.WithLogging(config.Logger, HttpGetPropertyRating(config.ReviewService)))
+		if config.Verifier != nil {
+			mux.HandleFunc("GET /api/reviews", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpListReviews(config.ReviewService)))))
+			mux.HandleFunc("POST /api/reviews/{id}/moderate", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpModerateReview(config.ReviewService)))))
+		}
+	}
+
+	// Add the notification preview, which renders guest emails with real data without sending them.
+	if config.NotificationPreview != nil && config.Verifier != nil {
+		mux.HandleFunc("GET /api/admin/notifications/preview", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpPreviewNotification(config.ReservationService, config.NotificationPreview)))))
+	}
+
+	// Add the delivery status of a reservation's guest notifications.
+	if config.NotificationDelivery != nil && config.Verifier != nil {
+		mux.HandleFunc("GET /api/reservations/{id}/notifications", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpListNotifications(config.ReservationService, config.NotificationDelivery)))))
+	}
+
+	// Add the event simulator, which publishes crafted events for local development.
+	if config.EventSimulator != nil && config.Verifier != nil {
+		mux.HandleFunc("POST /api/admin/events/{topic}", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpSimulateEvent(config.EventSimulator)))))
+	}
+
+	// Add the webhook for bookings made on OTAs, delivered by the channel manager.
+	// It is authenticated by an HMAC signature instead of a bearer token or client certificate.
+	if config.ChannelService != nil && len(config.ChannelWebhookSecret) > 0 {
+		mux.HandleFunc("POST /webhooks/channel/bookings", logging.WithLogging(config.Logger, HttpImportChannelBooking(config.ChannelService, config.ChannelWebhookSecret)))
+	}
+
+	// Add the webhook for disputes reported by the payment gateway, authenticated by an HMAC signature.
+	if config.PaymentService != nil && len(config.PaymentWebhookSecret) > 0 {
+		mux.HandleFunc("POST /webhooks/payments/disputes", logging.WithLogging(config.Logger, HttpHandleDisputeNotification(config.PaymentService, config.PaymentWebhookSecret)))
+	}
+
+	// Persist sessions in an external store so they survive restarts and are shared
+	// between replicas. The admin API logs a guest out of all devices.
+	var handler http.Handler = mux
+	if config.SessionStore != nil {
+		if config.Verifier != nil {
+			mux.HandleFunc("DELETE /api/guests/{id}/sessions", logging.WithLogging(config.Logger, api(web.WithBearerAuth(config.Verifier, HttpRevokeGuestSessions(config.SessionStore)))))
+		}
+		ttl := config.SessionTTL
+		if ttl == 0 {
+			ttl = 24 * time.Hour
+		}
+		handler = WithSessionStore(config.SessionStore, serverSessions, ttl, config.Logger, handler)
+	}
+
+	// Compress HTML, JSON and event stream responses for clients that accept it.
+	if config.Compression != nil {
+		handler = WithCompression(config.Compression, handler)
+	}
+
+	// Give every request a correlation ID, which error responses include.
+	handler = WithCorrelationID(ids, handler)
+
+	outer := http.NewServeMux()
+	outer.Handle("/", handler)
+	return outer
 }
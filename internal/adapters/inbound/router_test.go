@@ -83,6 +83,16 @@ func (m *mockReservationRepository) ReadAll(ctx context.Context) ([]reservation.
 	return result, nil
 }
 
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	var result []reservation.Reservation
+	for _, res := range m.reservations {
+		if res.GuestID == guestID {
+			result = append(result, res)
+		}
+	}
+	return result, nil
+}
+
 func createTestReservationService(t *testing.T) *reservation.Service {
 	t.Helper()
 	reservationRepo := newMockReservationRepository()
@@ -166,6 +176,34 @@ func Test_Route_Readiness_Endpoint_Should_Return_200(t *testing.T) {
 	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
 }
 
+func Test_Route_Startup_Endpoint_Before_Checks_Pass_Should_Return_503(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	ctx := context.Background()
+	logger := slog.Default()
+	reservationService := createTestReservationService(t)
+	probe := inbound.NewStartupProbe().
+		WithCheck("migrations", func(ctx context.Context) error { return errors.New("relation does not exist") })
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                ctx,
+		EFS:                getRouterTestFS(t),
+		Logger:             logger,
+		ReservationService: reservationService,
+		StartupProbe:       probe,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/startup", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 503", rec.Code, http.StatusServiceUnavailable)
+}
+
 func Test_Route_UI_Endpoint_Without_Session_Should_Redirect_To_Login(t *testing.T) {
 	// Arrange
 	t.Setenv("APP_NAME", "TestApp")
@@ -324,6 +362,28 @@ func Test_Route_Unknown_Endpoint_Should_Return_404(t *testing.T) {
 	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
 }
 
+func Test_Route_Should_Return_Correlation_ID_Header(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ui/login", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "correlation ID header must be set", rec.Header().Get(inbound.CorrelationIDHeader) != "", true)
+}
+
 // ============================================================================
 // MCP Endpoint Tests
 // ============================================================================
@@ -384,3 +444,53 @@ func Test_Route_MCP_Endpoint_With_MCPServer_Should_Return_200(t *testing.T) {
 	// Assert
 	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
 }
+
+func Test_Route_Static_Asset_Should_Return_ETag(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/static/css/test.css", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "etag must be set", rec.Header().Get("ETag") != "", true)
+}
+
+func Test_Route_Metrics_Endpoint_Should_Serve_Metrics_Handler(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("go_sql_in_use_connections 0\n"))
+	})
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		Metrics:            metrics,
+		ReservationService: createTestReservationService(t),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain the metrics", rec.Body.String(), "go_sql_in_use_connections 0\n")
+}
@@ -0,0 +1,75 @@
+package inbound
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// SessionContext holds the session and identity claims of a request.
+// WithSessionContext reads them once from the context values of web.WithAuth,
+// so handlers and tests deal with one typed value instead of six raw keys.
+type SessionContext struct {
+	SessionID string
+	Email     string
+	Issuer    string
+	Name      string
+	Subject   string
+	Verified  bool
+}
+
+// sessionContextKey is the context key of the SessionContext.
+type sessionContextKey struct{}
+
+// Authenticated reports whether the request belongs to a signed-in user.
+// Both values are checked, because the session ID might still exist (from the
+// cookie) after logout, while the email is gone with the server-side session.
+func (s SessionContext) Authenticated() bool {
+	return s.SessionID != "" && s.Email != ""
+}
+
+// IntoContext returns a copy of ctx that carries the session.
+func (s SessionContext) IntoContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, s)
+}
+
+// SessionFromContext returns the session added by WithSessionContext,
+// or an empty, unauthenticated session.
+func SessionFromContext(ctx context.Context) SessionContext {
+	session, _ := ctx.Value(sessionContextKey{}).(SessionContext)
+	return session
+}
+
+// WithSessionContext adds the SessionContext of a request to the context.
+// It must run after web.WithAuth, which provides the session and claims.
+func WithSessionContext(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var session SessionContext
+		session.SessionID, _ = ctx.Value(web.ContextSessionID).(string)
+		session.Email, _ = ctx.Value(web.ContextEmail).(string)
+		session.Issuer, _ = ctx.Value(web.ContextIssuer).(string)
+		session.Name, _ = ctx.Value(web.ContextName).(string)
+		session.Subject, _ = ctx.Value(web.ContextSubject).(string)
+		session.Verified, _ = ctx.Value(web.ContextVerified).(bool)
+
+		next.ServeHTTP(w, r.WithContext(session.IntoContext(ctx)))
+	}
+}
+
+// WithToolGuest makes the email of the verified bearer token the guest of the
+// booking assistant's tool calls, like the UI books for the session's email.
+// It must run after web.WithBearerAuth, which provides the claims.
+func WithToolGuest(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if email, _ := ctx.Value(web.ContextEmail).(string); email != "" {
+			ctx = orchestration.WithGuestID(ctx, reservation.GuestID(email))
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+}
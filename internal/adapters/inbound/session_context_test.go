@@ -0,0 +1,135 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// SessionContext Tests
+// ============================================================================
+
+func Test_SessionFromContext_Without_Session_Should_Be_Unauthenticated(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	session := inbound.SessionFromContext(ctx)
+
+	// Assert
+	assert.That(t, "session must be empty", session, inbound.SessionContext{})
+	assert.That(t, "session must not be authenticated", session.Authenticated(), false)
+}
+
+func Test_SessionContext_IntoContext_Should_Be_Read_By_SessionFromContext(t *testing.T) {
+	// Arrange
+	session := inbound.SessionContext{SessionID: "test-session-123", Email: "test@example.com", Name: "Test User", Verified: true}
+
+	// Act
+	got := inbound.SessionFromContext(session.IntoContext(context.Background()))
+
+	// Assert
+	assert.That(t, "session must match", got, session)
+	assert.That(t, "session must be authenticated", got.Authenticated(), true)
+}
+
+func Test_SessionContext_With_SessionID_But_Empty_Email_Should_Be_Unauthenticated(t *testing.T) {
+	// Arrange - simulates the case after logout where session is deleted but cookie remains
+	session := inbound.SessionContext{SessionID: "stale-session-id"}
+
+	// Act
+	authenticated := session.Authenticated()
+
+	// Assert
+	assert.That(t, "session must not be authenticated", authenticated, false)
+}
+
+// ============================================================================
+// WithSessionContext Tests
+// ============================================================================
+
+func Test_WithSessionContext_Should_Read_Auth_Context_Values(t *testing.T) {
+	// Arrange
+	var got inbound.SessionContext
+	handler := inbound.WithSessionContext(func(w http.ResponseWriter, r *http.Request) {
+		got = inbound.SessionFromContext(r.Context())
+	})
+	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, web.ContextSessionID, "test-session-123")
+	ctx = context.WithValue(ctx, web.ContextEmail, "test@example.com")
+	ctx = context.WithValue(ctx, web.ContextIssuer, "https://issuer.example.com")
+	ctx = context.WithValue(ctx, web.ContextName, "Test User")
+	ctx = context.WithValue(ctx, web.ContextSubject, "user-subject-456")
+	ctx = context.WithValue(ctx, web.ContextVerified, true)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req.WithContext(ctx))
+
+	// Assert
+	assert.That(t, "session must hold the auth context values", got, inbound.SessionContext{
+		SessionID: "test-session-123",
+		Email:     "test@example.com",
+		Issuer:    "https://issuer.example.com",
+		Name:      "Test User",
+		Subject:   "user-subject-456",
+		Verified:  true,
+	})
+}
+
+func Test_WithSessionContext_Without_Auth_Context_Values_Should_Be_Unauthenticated(t *testing.T) {
+	// Arrange
+	var got inbound.SessionContext
+	handler := inbound.WithSessionContext(func(w http.ResponseWriter, r *http.Request) {
+		got = inbound.SessionFromContext(r.Context())
+	})
+	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "session must not be authenticated", got.Authenticated(), false)
+}
+
+func Test_WithToolGuest_Should_Make_Token_Email_The_Guest(t *testing.T) {
+	// Arrange
+	var got reservation.GuestID
+	handler := inbound.WithToolGuest(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = orchestration.GuestIDFromContext(r.Context())
+	})
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	ctx := context.WithValue(req.Context(), web.ContextEmail, "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req.WithContext(ctx))
+
+	// Assert
+	assert.That(t, "guest must be the token email", got, reservation.GuestID("test@example.com"))
+}
+
+func Test_WithToolGuest_Without_Token_Email_Should_Not_Set_Guest(t *testing.T) {
+	// Arrange
+	var ok bool
+	handler := inbound.WithToolGuest(func(w http.ResponseWriter, r *http.Request) {
+		_, ok = orchestration.GuestIDFromContext(r.Context())
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+
+	// Assert
+	assert.That(t, "guest must not be set", ok, false)
+}
@@ -0,0 +1,135 @@
+package inbound
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/web"
+)
+
+// SessionStore persists login sessions outside the process, so sessions
+// survive restarts and are shared between replicas.
+// Implementations live in the outbound adapters (Redis, Postgres).
+type SessionStore interface {
+	// Save stores the claims of a new session for the given time to live.
+	Save(ctx context.Context, id string, claims web.IdentityTokenClaims, ttl time.Duration) error
+	// Read returns the claims of a session, or nil if it is unknown, expired or revoked.
+	Read(ctx context.Context, id string) (*web.IdentityTokenClaims, error)
+	// Touch extends the session's time to live (sliding expiration).
+	Touch(ctx context.Context, id string, ttl time.Duration) error
+	// Delete removes a session (logout).
+	Delete(ctx context.Context, id string) error
+	// DeleteByEmail removes all sessions of a guest (remote logout) and returns their count.
+	DeleteByEmail(ctx context.Context, email string) (int, error)
+	MagicLinkStore
+}
+
+// MagicLinkStore keeps the used tokens and the rate limits of the magic link flow.
+// It is part of the SessionStore, so the flow holds across replicas like the sessions.
+type MagicLinkStore interface {
+	// UseNonce marks the nonce of a single-use token as used until ttl passed and reports whether it was unused.
+	UseNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+	// CountAttempt counts an attempt for the key in its current window and returns the attempts of the window.
+	CountAttempt(ctx context.Context, key string, window time.Duration) (int, error)
+}
+
+// sessionCookie is the session ID cookie set by the cloud-native-utils identity provider.
+const sessionCookie = "sid"
+
+// WithSessionStore keeps the in-memory sessions of cloud-native-utils in sync with a SessionStore.
+// The store is the source of truth: new sessions are saved after the login callback
+// (OIDC or magic link),
+// known sessions are restored into memory and their expiration is extended on each request,
+// and sessions that expired or were revoked in the store are dropped from memory.
+func WithSessionStore(store SessionStore, sessions *web.ServerSessions, ttl time.Duration, logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if strings.HasPrefix(r.URL.Path, "/static/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var sessionID string
+		if c, err := r.Cookie(sessionCookie); err == nil {
+			sessionID = c.Value
+		}
+
+		switch {
+		case r.URL.Path == "/auth/callback" || r.URL.Path == magicLinkVerifyPath:
+			next.ServeHTTP(w, r)
+			saveNewSession(ctx, store, sessions, ttl, logger, w.Header())
+			return
+		case strings.HasPrefix(r.URL.Path, "/auth/logout/") && sessionID != "":
+			if err := store.Delete(ctx, sessionID); err != nil {
+				logger.Error("failed to delete session", "error", err)
+			}
+		case sessionID != "":
+			restoreSession(ctx, store, sessions, ttl, logger, sessionID)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// saveNewSession stores the session created by the login callback.
+// The session ID is taken from the Set-Cookie header of the callback response.
+func saveNewSession(ctx context.Context, store SessionStore, sessions *web.ServerSessions, ttl time.Duration, logger *slog.Logger, header http.Header) {
+	for _, c := range (&http.Response{Header: header}).Cookies() {
+		if c.Name != sessionCookie || c.Value == "" {
+			continue
+		}
+		session, ok := sessions.Read(c.Value)
+		if !ok {
+			continue
+		}
+		claims, ok := session.Data.(web.IdentityTokenClaims)
+		if !ok {
+			continue
+		}
+		if err := store.Save(ctx, c.Value, claims, ttl); err != nil {
+			logger.Error("failed to save session", "error", err)
+		}
+	}
+}
+
+// restoreSession loads a session from the store into memory and extends its expiration.
+// If the store fails, the session is treated as logged out for this request.
+func restoreSession(ctx context.Context, store SessionStore, sessions *web.ServerSessions, ttl time.Duration, logger *slog.Logger, sessionID string) {
+	claims, err := store.Read(ctx, sessionID)
+	if err != nil {
+		logger.Error("failed to read session", "error", err)
+		sessions.Delete(sessionID)
+		return
+	}
+	if claims == nil {
+		sessions.Delete(sessionID)
+		return
+	}
+	sessions.Create(sessionID, *claims)
+	if err := store.Touch(ctx, sessionID, ttl); err != nil {
+		logger.Error("failed to extend session", "error", err)
+	}
+}
+
+// HttpRevokeGuestSessions logs a guest out of all devices (admin remote logout).
+func HttpRevokeGuestSessions(store SessionStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		email := r.PathValue("id")
+		if email == "" {
+			writeProblem(w, r, http.StatusBadRequest, "Guest ID required")
+			return
+		}
+
+		revoked, err := store.DeleteByEmail(r.Context(), email)
+		if err != nil {
+			writeProblem(w, r, http.StatusInternalServerError, "Failed to revoke sessions")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]int{"revoked": revoked})
+	}
+}
@@ -0,0 +1,234 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// mockSessionStore is an in-memory SessionStore that records calls.
+type mockSessionStore struct {
+	sessions map[string]web.IdentityTokenClaims
+	touched  map[string]time.Duration
+	nonces   map[string]bool
+	attempts map[string]int
+	readErr  error
+}
+
+func newMockSessionStore() *mockSessionStore {
+	return &mockSessionStore{
+		sessions: make(map[string]web.IdentityTokenClaims),
+		touched:  make(map[string]time.Duration),
+	}
+}
+
+func (m *mockSessionStore) Save(_ context.Context, id string, claims web.IdentityTokenClaims, _ time.Duration) error {
+	m.sessions[id] = claims
+	return nil
+}
+
+func (m *mockSessionStore) Read(_ context.Context, id string) (*web.IdentityTokenClaims, error) {
+	if m.readErr != nil {
+		return nil, m.readErr
+	}
+	claims, ok := m.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	return &claims, nil
+}
+
+func (m *mockSessionStore) Touch(_ context.Context, id string, ttl time.Duration) error {
+	m.touched[id] = ttl
+	return nil
+}
+
+func (m *mockSessionStore) UseNonce(_ context.Context, nonce string, _ time.Duration) (bool, error) {
+	if m.nonces == nil {
+		m.nonces = make(map[string]bool)
+	}
+	if m.nonces[nonce] {
+		return false, nil
+	}
+	m.nonces[nonce] = true
+	return true, nil
+}
+
+func (m *mockSessionStore) CountAttempt(_ context.Context, key string, _ time.Duration) (int, error) {
+	if m.attempts == nil {
+		m.attempts = make(map[string]int)
+	}
+	m.attempts[key]++
+	return m.attempts[key], nil
+}
+
+func (m *mockSessionStore) Delete(_ context.Context, id string) error {
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *mockSessionStore) DeleteByEmail(_ context.Context, email string) (int, error) {
+	count := 0
+	for id, claims := range m.sessions {
+		if claims.Email == email {
+			delete(m.sessions, id)
+			count++
+		}
+	}
+	return count, nil
+}
+
+func newSessionRequest(path, sessionID string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.AddCookie(&http.Cookie{Name: "sid", Value: sessionID})
+	return req
+}
+
+var testClaims = web.IdentityTokenClaims{Email: "guest@example.com", Name: "Guest", Subject: "sub-001"}
+
+// ============================================================================
+// WithSessionStore Tests
+// ============================================================================
+
+func Test_WithSessionStore_With_Stored_Session_Should_Restore_And_Extend_It(t *testing.T) {
+	// Arrange
+	store := newMockSessionStore()
+	store.sessions["sid-001"] = testClaims
+	sessions := web.NewServerSessions()
+	var restored bool
+	handler := inbound.WithSessionStore(store, sessions, time.Hour, slog.Default(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, restored = sessions.Read("sid-001")
+	}))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), newSessionRequest("/ui/reservations", "sid-001"))
+
+	// Assert
+	assert.That(t, "session must be restored into memory", restored, true)
+	assert.That(t, "session must be extended", store.touched["sid-001"], time.Hour)
+}
+
+func Test_WithSessionStore_With_Revoked_Session_Should_Drop_It_From_Memory(t *testing.T) {
+	// Arrange
+	store := newMockSessionStore()
+	sessions := web.NewServerSessions()
+	sessions.Create("sid-001", testClaims)
+	handler := inbound.WithSessionStore(store, sessions, time.Hour, slog.Default(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), newSessionRequest("/ui/reservations", "sid-001"))
+
+	// Assert
+	_, ok := sessions.Read("sid-001")
+	assert.That(t, "session must be dropped", ok, false)
+}
+
+func Test_WithSessionStore_When_Store_Fails_Should_Drop_Session_For_Request(t *testing.T) {
+	// Arrange
+	store := newMockSessionStore()
+	store.readErr = errors.New("store unavailable")
+	sessions := web.NewServerSessions()
+	sessions.Create("sid-001", testClaims)
+	handler := inbound.WithSessionStore(store, sessions, time.Hour, slog.Default(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), newSessionRequest("/ui/reservations", "sid-001"))
+
+	// Assert
+	_, ok := sessions.Read("sid-001")
+	assert.That(t, "session must be dropped", ok, false)
+}
+
+func Test_WithSessionStore_After_Login_Callback_Should_Save_New_Session(t *testing.T) {
+	// Arrange
+	store := newMockSessionStore()
+	sessions := web.NewServerSessions()
+	handler := inbound.WithSessionStore(store, sessions, time.Hour, slog.Default(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessions.Create("sid-new", testClaims)
+		http.SetCookie(w, &http.Cookie{Name: "sid", Value: "sid-new", Path: "/"})
+		w.WriteHeader(http.StatusFound)
+	}))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/auth/callback?code=x&state=y", nil))
+
+	// Assert
+	assert.That(t, "session must be saved", store.sessions["sid-new"], testClaims)
+}
+
+func Test_WithSessionStore_On_Logout_Should_Delete_Session(t *testing.T) {
+	// Arrange
+	store := newMockSessionStore()
+	store.sessions["sid-001"] = testClaims
+	handler := inbound.WithSessionStore(store, web.NewServerSessions(), time.Hour, slog.Default(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), newSessionRequest("/auth/logout/sid-001", "sid-001"))
+
+	// Assert
+	_, ok := store.sessions["sid-001"]
+	assert.That(t, "session must be deleted", ok, false)
+}
+
+// ============================================================================
+// HttpRevokeGuestSessions Tests
+// ============================================================================
+
+func Test_HttpRevokeGuestSessions_Should_Return_Revoked_Count(t *testing.T) {
+	// Arrange
+	store := newMockSessionStore()
+	store.sessions["sid-001"] = testClaims
+	store.sessions["sid-002"] = testClaims
+	store.sessions["sid-003"] = web.IdentityTokenClaims{Email: "other@example.com"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /api/guests/{id}/sessions", inbound.HttpRevokeGuestSessions(store))
+	req := httptest.NewRequest(http.MethodDelete, "/api/guests/guest@example.com/sessions", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	var body map[string]int
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "must revoke two sessions", body["revoked"], 2)
+	assert.That(t, "other guest's session must remain", len(store.sessions), 1)
+}
+
+func Test_Route_Revoke_Sessions_Without_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	reservationService, _ := createPrivacyTestServices(t)
+	verifier := oidc.NewVerifier("http://localhost:8180/realms/local", &oidc.StaticKeySet{}, &oidc.Config{ClientID: "hotel-booking-mcp"})
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: reservationService,
+		SessionStore:       newMockSessionStore(),
+		Verifier:           verifier,
+	})
+	req := httptest.NewRequest(http.MethodDelete, "/api/guests/guest@example.com/sessions", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
@@ -0,0 +1,224 @@
+package inbound
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/efficiency"
+)
+
+// immutableMaxAge is the max-age of versioned asset URLs, whose content never changes.
+const immutableMaxAge = 365 * 24 * time.Hour
+
+// AssetManifestFile is the file below assets that maps the CSS and JS files to their
+// fingerprinted names. It is written by go generate ./cmd/server (cmd/fingerprint).
+const AssetManifestFile = "asset-manifest.json"
+
+// assetPathPattern matches the assetPath helper of the templates, e.g. {{ assetPath "css/styles.css" }}.
+var assetPathPattern = regexp.MustCompile(`{{-?\s*assetPath\s+"([^"]+)"\s*-?}}`)
+
+// StaticAssets serves the static assets under /static with cache headers. The embedded
+// files have no modification time, so it hashes their content once at startup and sends
+// the hash as ETag. Browsers revalidate with If-None-Match and receive 304 Not Modified
+// instead of the file as long as the content has not changed.
+//
+// URLs versioned with the content hash (see URL) and the fingerprinted names of the
+// asset manifest (see Path) are cached as immutable; other URLs are cached for maxAge,
+// or revalidated on every use if maxAge is zero.
+type StaticAssets struct {
+	hashes       map[string]string
+	fingerprints map[string]string // Fingerprinted URL path to the path of the file
+	paths        map[string]string // Asset name to its fingerprinted URL path
+	maxAge       time.Duration
+	next         http.Handler
+}
+
+// NewStaticAssets hashes the files below assets/static of the filesystem and loads
+// the asset manifest. Manifest entries whose hash does not match the file, because
+// go generate was not run after a change, are ignored.
+func NewStaticAssets(efs fs.FS, maxAge time.Duration) *StaticAssets {
+	assets := &StaticAssets{
+		hashes:       make(map[string]string),
+		fingerprints: make(map[string]string),
+		paths:        make(map[string]string),
+		maxAge:       maxAge,
+	}
+
+	staticFS, err := fs.Sub(efs, "assets")
+	if err != nil {
+		staticFS = efs
+	}
+	assets.next = efficiency.WithCompression(http.FileServerFS(staticFS))
+
+	_ = fs.WalkDir(staticFS, "static", func(name string, d fs.DirEntry, err error) error {
+		// Unreadable files are served without cache headers.
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		content, err := fs.ReadFile(staticFS, name)
+		if err != nil {
+			return nil
+		}
+		assets.hashes["/"+name] = contentHash(content)
+		return nil
+	})
+
+	var manifest map[string]string
+	if data, err := fs.ReadFile(staticFS, AssetManifestFile); err == nil {
+		_ = json.Unmarshal(data, &manifest)
+	}
+	for name, fingerprinted := range manifest {
+		if fingerprinted != fingerprint(name, assets.hashes["/static/"+name]) {
+			continue
+		}
+		assets.fingerprints["/static/"+fingerprinted] = "/static/" + name
+		assets.paths[name] = "/static/" + fingerprinted
+	}
+	return assets
+}
+
+// BuildAssetManifest maps the CSS and JS files below static of the filesystem
+// to their fingerprinted names, e.g. css/styles.css to css/styles.1a2b3c4d5e6f7a8b.css.
+func BuildAssetManifest(assets fs.FS) (map[string]string, error) {
+	manifest := make(map[string]string)
+	err := fs.WalkDir(assets, "static", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || (path.Ext(name) != ".css" && path.Ext(name) != ".js") {
+			return nil
+		}
+		content, err := fs.ReadFile(assets, name)
+		if err != nil {
+			return err
+		}
+		relative := strings.TrimPrefix(name, "static/")
+		manifest[relative] = fingerprint(relative, contentHash(content))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build asset manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// contentHash returns the hash of an asset's content used in ETags and URLs.
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:8])
+}
+
+// fingerprint inserts the hash before the extension of the asset name.
+func fingerprint(name, hash string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// URL returns the URL of an asset versioned with its content hash,
+// e.g. /static/css/base.css?v=1a2b3c4d5e6f7a8b. Unknown assets are returned as they are.
+func (s *StaticAssets) URL(name string) string {
+	hash, ok := s.hashes[path.Clean(name)]
+	if !ok {
+		return name
+	}
+	return name + "?v=" + hash
+}
+
+// Path returns the URL path of an asset below /static, e.g. css/styles.css, with
+// its fingerprinted name from the asset manifest. Assets missing from the manifest
+// are versioned like URL does.
+func (s *StaticAssets) Path(name string) string {
+	if fingerprinted, ok := s.paths[name]; ok {
+		return fingerprinted
+	}
+	return s.URL("/static/" + name)
+}
+
+// TemplateFS returns the filesystem with the assetPath helpers of its templates
+// resolved by Path. The templating engine has a fixed function map, so the helper
+// is replaced when the templates are read instead of when they are rendered.
+func (s *StaticAssets) TemplateFS(efs fs.FS) fs.FS {
+	return &assetTemplateFS{FS: efs, assets: s}
+}
+
+// ServeHTTP sets the ETag and Cache-Control headers and serves the asset.
+// Fingerprinted names are served from the file of the asset.
+// The file server answers If-None-Match with 304 Not Modified.
+func (s *StaticAssets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if name, ok := s.fingerprints[r.URL.Path]; ok {
+		w.Header().Set("ETag", `W/"`+s.hashes[name]+`"`)
+		w.Header().Set("Cache-Control", s.cacheControl(true))
+		r = r.Clone(r.Context())
+		r.URL.Path = name
+		s.next.ServeHTTP(w, r)
+		return
+	}
+	if hash, ok := s.hashes[r.URL.Path]; ok {
+		// The ETag is weak, because the body may be compressed or not.
+		w.Header().Set("ETag", `W/"`+hash+`"`)
+		w.Header().Set("Cache-Control", s.cacheControl(r.URL.Query().Get("v") == hash))
+	}
+	s.next.ServeHTTP(w, r)
+}
+
+// cacheControl returns the Cache-Control header of an asset.
+func (s *StaticAssets) cacheControl(versioned bool) string {
+	switch {
+	case versioned:
+		return fmt.Sprintf("public, max-age=%d, immutable", int(immutableMaxAge.Seconds()))
+	case s.maxAge > 0:
+		return fmt.Sprintf("public, max-age=%d", int(s.maxAge.Seconds()))
+	default:
+		return "no-cache"
+	}
+}
+
+// assetTemplateFS resolves the assetPath helpers of the .tmpl files it opens.
+type assetTemplateFS struct {
+	fs.FS
+	assets *StaticAssets
+}
+
+// Open opens the file and replaces the assetPath helpers of templates.
+func (f *assetTemplateFS) Open(name string) (fs.File, error) {
+	file, err := f.FS.Open(name)
+	if err != nil || path.Ext(name) != ".tmpl" {
+		return file, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var content bytes.Buffer
+	if _, err := content.ReadFrom(file); err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	resolved := assetPathPattern.ReplaceAllFunc(content.Bytes(), func(match []byte) []byte {
+		name := assetPathPattern.FindSubmatch(match)[1]
+		return []byte(f.assets.Path(string(name)))
+	})
+	return &assetTemplateFile{Reader: bytes.NewReader(resolved), info: info}, nil
+}
+
+// assetTemplateFile is a template with resolved assetPath helpers.
+type assetTemplateFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+// Stat returns the info of the original template.
+func (f *assetTemplateFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+// Close does nothing, because the content is in memory.
+func (f *assetTemplateFile) Close() error { return nil }
@@ -0,0 +1,172 @@
+package inbound_test
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// StaticAssets Tests
+// ============================================================================
+
+func createStaticAssetsTestFS() fstest.MapFS {
+	return fstest.MapFS{
+		"assets/static/css/base.css": &fstest.MapFile{Data: []byte("body { margin: 0; }")},
+	}
+}
+
+// createFingerprintedTestFS returns the test assets with the asset manifest and a template using assetPath.
+func createFingerprintedTestFS(t *testing.T) fstest.MapFS {
+	t.Helper()
+	assetsFS := createStaticAssetsTestFS()
+	sub, err := fs.Sub(assetsFS, "assets")
+	assert.That(t, "sub must succeed", err == nil, true)
+	manifest, err := inbound.BuildAssetManifest(sub)
+	assert.That(t, "manifest must be built", err == nil, true)
+	data, _ := json.Marshal(manifest)
+	assetsFS["assets/"+inbound.AssetManifestFile] = &fstest.MapFile{Data: data}
+	assetsFS["assets/templates/index.tmpl"] = &fstest.MapFile{Data: []byte(`<link href="{{ assetPath "css/base.css" }}" />`)}
+	return assetsFS
+}
+
+func Test_StaticAssets_Should_Set_ETag_And_Revalidate(t *testing.T) {
+	// Arrange
+	assets := inbound.NewStaticAssets(createStaticAssetsTestFS(), 0)
+	req := httptest.NewRequest(http.MethodGet, "/static/css/base.css", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	assets.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "etag must be weak", strings.HasPrefix(rec.Header().Get("ETag"), `W/"`), true)
+	assert.That(t, "cache control must revalidate", rec.Header().Get("Cache-Control"), "no-cache")
+	assert.That(t, "body must be the file", rec.Body.String(), "body { margin: 0; }")
+}
+
+func Test_StaticAssets_With_Matching_If_None_Match_Should_Return_304(t *testing.T) {
+	// Arrange
+	assets := inbound.NewStaticAssets(createStaticAssetsTestFS(), 0)
+	first := httptest.NewRecorder()
+	assets.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/static/css/base.css", nil))
+	req := httptest.NewRequest(http.MethodGet, "/static/css/base.css", nil)
+	req.Header.Set("If-None-Match", first.Header().Get("ETag"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	assets.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 304", rec.Code, http.StatusNotModified)
+	assert.That(t, "body must be empty", rec.Body.Len(), 0)
+}
+
+func Test_StaticAssets_With_Versioned_URL_Should_Be_Immutable(t *testing.T) {
+	// Arrange
+	assets := inbound.NewStaticAssets(createStaticAssetsTestFS(), time.Hour)
+	req := httptest.NewRequest(http.MethodGet, assets.URL("/static/css/base.css"), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	assets.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "url must be versioned", strings.Contains(req.URL.RawQuery, "v="), true)
+	assert.That(t, "cache control must be immutable", rec.Header().Get("Cache-Control"), "public, max-age=31536000, immutable")
+}
+
+func Test_StaticAssets_With_Max_Age_Should_Cache_Unversioned_URL(t *testing.T) {
+	// Arrange
+	assets := inbound.NewStaticAssets(createStaticAssetsTestFS(), time.Hour)
+	req := httptest.NewRequest(http.MethodGet, "/static/css/base.css?v=outdated", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	assets.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "cache control must use max age", rec.Header().Get("Cache-Control"), "public, max-age=3600")
+}
+
+func Test_StaticAssets_URL_With_Unknown_Asset_Should_Return_Path(t *testing.T) {
+	// Arrange
+	assets := inbound.NewStaticAssets(createStaticAssetsTestFS(), 0)
+
+	// Act
+	url := assets.URL("/static/css/missing.css")
+
+	// Assert
+	assert.That(t, "url must be unchanged", url, "/static/css/missing.css")
+}
+
+func Test_BuildAssetManifest_Should_Fingerprint_CSS_And_JS(t *testing.T) {
+	// Arrange
+	assetsFS := fstest.MapFS{
+		"static/css/base.css": &fstest.MapFile{Data: []byte("body { margin: 0; }")},
+		"static/js/app.js":    &fstest.MapFile{Data: []byte("console.log(1);")},
+		"static/img/icon.png": &fstest.MapFile{Data: []byte("png")},
+	}
+
+	// Act
+	manifest, err := inbound.BuildAssetManifest(assetsFS)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "manifest must hold css and js only", len(manifest), 2)
+	assert.That(t, "css name must hold the hash", strings.HasPrefix(manifest["css/base.css"], "css/base."), true)
+	assert.That(t, "css name must keep the extension", strings.HasSuffix(manifest["css/base.css"], ".css"), true)
+	assert.That(t, "css name must differ", manifest["css/base.css"] != "css/base.css", true)
+}
+
+func Test_StaticAssets_With_Fingerprinted_Path_Should_Serve_Immutable_File(t *testing.T) {
+	// Arrange
+	assets := inbound.NewStaticAssets(createFingerprintedTestFS(t), 0)
+	path := assets.Path("css/base.css")
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	assets.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "path must be fingerprinted", path != "/static/css/base.css" && !strings.Contains(path, "?"), true)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "cache control must be immutable", rec.Header().Get("Cache-Control"), "public, max-age=31536000, immutable")
+	assert.That(t, "body must be the file", rec.Body.String(), "body { margin: 0; }")
+}
+
+func Test_StaticAssets_Path_With_Stale_Manifest_Should_Return_Versioned_URL(t *testing.T) {
+	// Arrange
+	assetsFS := createFingerprintedTestFS(t)
+	assetsFS["assets/static/css/base.css"] = &fstest.MapFile{Data: []byte("body { margin: 1px; }")}
+	assets := inbound.NewStaticAssets(assetsFS, 0)
+
+	// Act
+	path := assets.Path("css/base.css")
+
+	// Assert
+	assert.That(t, "path must be the versioned url", path, assets.URL("/static/css/base.css"))
+}
+
+func Test_StaticAssets_TemplateFS_Should_Resolve_AssetPath(t *testing.T) {
+	// Arrange
+	assetsFS := createFingerprintedTestFS(t)
+	assets := inbound.NewStaticAssets(assetsFS, 0)
+
+	// Act
+	content, err := fs.ReadFile(assets.TemplateFS(assetsFS), "assets/templates/index.tmpl")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "helper must be resolved", string(content), `<link href="`+assets.Path("css/base.css")+`" />`)
+}
@@ -0,0 +1,103 @@
+package inbound
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// themeCookie is the cookie that keeps the theme of the browser, also before sign-in.
+const themeCookie = "theme"
+
+// themeCookieMaxAge is the lifetime of the theme cookie.
+const themeCookieMaxAge = 365 * 24 * time.Hour
+
+// themeContextKey is the context key of the active theme.
+type themeContextKey struct{}
+
+// WithTheme adds the active theme of a request to the context.
+// The theme cookie of the browser wins over the theme saved in the guest's profile,
+// so the profile is only read on devices that did not toggle the theme yet.
+// It must run after WithSessionContext, which provides the guest's email.
+func WithTheme(reservationService *reservation.Service, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		active, ok := themeFromCookie(r)
+		if !ok {
+			if email := SessionFromContext(ctx).Email; email != "" && reservationService != nil {
+				if profile, err := reservationService.GetGuestProfile(ctx, reservation.GuestID(email)); err == nil && profile.Theme != "" {
+					active = profile.Theme
+				}
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, themeContextKey{}, active)))
+	}
+}
+
+// HttpSetTheme handles POST /ui/theme, the theme toggle of the UI.
+// It keeps the theme of the form in the theme cookie and, for signed-in guests,
+// in their profile, then redirects back to the local path of the redirect field.
+// It must run after WithSessionContext, which provides the guest's email.
+func HttpSetTheme(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+		theme, err := reservation.ParseTheme(r.FormValue("theme"))
+		if err != nil {
+			writeDomainError(w, r, err, "Invalid theme")
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     themeCookie,
+			Value:    string(theme),
+			Path:     "/",
+			MaxAge:   int(themeCookieMaxAge.Seconds()),
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		// Saving the theme is best effort; the cookie already applies it.
+		if email := SessionFromContext(r.Context()).Email; email != "" && reservationService != nil {
+			_, _ = reservationService.UpdateTheme(r.Context(), reservation.GuestID(email), theme)
+		}
+
+		redirect := r.FormValue("redirect")
+		if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.HasPrefix(redirect, "/\\") {
+			redirect = "/ui/"
+		}
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+	}
+}
+
+// theme returns the theme added by WithTheme.
+// Without it, e.g. on public pages, the theme cookie decides.
+func theme(r *http.Request) reservation.Theme {
+	if active, ok := r.Context().Value(themeContextKey{}).(reservation.Theme); ok {
+		return active
+	}
+	active, _ := themeFromCookie(r)
+	return active
+}
+
+// themeFromCookie returns the theme of the theme cookie, or the system theme
+// if the cookie is missing or invalid.
+func themeFromCookie(r *http.Request) (reservation.Theme, bool) {
+	c, err := r.Cookie(themeCookie)
+	if err != nil {
+		return reservation.ThemeSystem, false
+	}
+	active, err := reservation.ParseTheme(c.Value)
+	if err != nil {
+		return reservation.ThemeSystem, false
+	}
+	return active, true
+}
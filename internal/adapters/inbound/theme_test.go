@@ -0,0 +1,154 @@
+package inbound_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func newThemeFormRequest(theme, redirect string) *http.Request {
+	form := url.Values{}
+	form.Set("theme", theme)
+	form.Set("redirect", redirect)
+	req := httptest.NewRequest(http.MethodPost, "/ui/theme", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// ============================================================================
+// WithTheme Tests
+// ============================================================================
+
+func Test_WithTheme_Without_Cookie_Or_Profile_Should_Use_System(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, _ := createProfileTestService(newMockReservationRepository())
+	handler := inbound.WithTheme(service, inbound.HttpViewReservations(e, service))
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/reservations", nil), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "theme must be system", strings.Contains(string(body), "Theme: system"), true)
+}
+
+func Test_WithTheme_With_Profile_Theme_Should_Use_Profile(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, profiles := createProfileTestService(newMockReservationRepository())
+	_ = profiles.SaveProfile(context.Background(), reservation.GuestProfile{GuestID: "test@example.com", Name: "Jane Doe", Theme: reservation.ThemeDark})
+	handler := inbound.WithTheme(service, inbound.HttpViewReservations(e, service))
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/reservations", nil), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "theme must be the profile theme", strings.Contains(string(body), "Theme: dark"), true)
+}
+
+func Test_WithTheme_With_Cookie_Should_Override_Profile(t *testing.T) {
+	// Arrange
+	e := createProfileTestEngine(t)
+	service, profiles := createProfileTestService(newMockReservationRepository())
+	_ = profiles.SaveProfile(context.Background(), reservation.GuestProfile{GuestID: "test@example.com", Name: "Jane Doe", Theme: reservation.ThemeDark})
+	handler := inbound.WithTheme(service, inbound.HttpViewReservations(e, service))
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/reservations", nil), "test-session-123", "test@example.com")
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "light"})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "theme must be the cookie theme", strings.Contains(string(body), "Theme: light"), true)
+}
+
+func Test_HttpViewLogin_With_Theme_Cookie_Should_Render_Theme(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(loginTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpViewLogin(e, false)
+	first := httptest.NewRecorder()
+	handler(first, httptest.NewRequest(http.MethodGet, "/ui/login", nil))
+	req := httptest.NewRequest(http.MethodGet, "/ui/login", nil)
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "first render must use the system theme", strings.Contains(first.Body.String(), "Theme: system"), true)
+	assert.That(t, "cached render must not be reused for another theme", strings.Contains(rec.Body.String(), "Theme: dark"), true)
+}
+
+// ============================================================================
+// HttpSetTheme Tests
+// ============================================================================
+
+func Test_HttpSetTheme_Should_Set_Cookie_Save_Profile_And_Redirect(t *testing.T) {
+	// Arrange
+	service, _ := createProfileTestService(newMockReservationRepository())
+	req := addAuthContext(newThemeFormRequest("dark", "/ui/reservations"), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpSetTheme(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be the redirect", rec.Header().Get("Location"), "/ui/reservations")
+	cookies := rec.Result().Cookies()
+	assert.That(t, "theme cookie must be set", len(cookies) == 1 && cookies[0].Name == "theme" && cookies[0].Value == "dark", true)
+	profile, _ := service.GetGuestProfile(context.Background(), "test@example.com")
+	assert.That(t, "profile theme must be saved", profile.Theme, reservation.ThemeDark)
+}
+
+func Test_HttpSetTheme_With_External_Redirect_Should_Redirect_To_Index(t *testing.T) {
+	// Arrange
+	service, _ := createProfileTestService(newMockReservationRepository())
+	req := newThemeFormRequest("light", "//evil.example.com/")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpSetTheme(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be the index", rec.Header().Get("Location"), "/ui/")
+}
+
+func Test_HttpSetTheme_With_Unknown_Theme_Should_Return_400(t *testing.T) {
+	// Arrange
+	service, _ := createProfileTestService(newMockReservationRepository())
+	req := newThemeFormRequest("sepia", "/ui/")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpSetTheme(service)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+	assert.That(t, "cookie must not be set", len(rec.Result().Cookies()), 0)
+}
@@ -0,0 +1,62 @@
+package inbound
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// CertificateReloader serves a TLS certificate loaded from PEM files and
+// allows replacing it at runtime, e.g. after a certificate renewal on SIGHUP.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+	mutex    sync.RWMutex
+	cert     *tls.Certificate
+}
+
+// NewCertificateReloader creates a new reloader and loads the initial certificate.
+func NewCertificateReloader(certFile, keyFile string) (*CertificateReloader, error) {
+	r := &CertificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload loads the certificate files again.
+// On failure the previous certificate is kept, so a broken renewal never takes the server down.
+func (r *CertificateReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.cert = &cert
+	return nil
+}
+
+// GetCertificate returns the current certificate.
+// It is meant to be used as tls.Config.GetCertificate.
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.cert, nil
+}
+
+// WithClientCert rejects requests that did not present a verified TLS client certificate.
+// The server must request client certificates (tls.VerifyClientCertIfGiven) for this to pass.
+func WithClientCert(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+			writeProblem(w, r, http.StatusUnauthorized, "Client certificate required")
+			return
+		}
+		next(w, r)
+	}
+}
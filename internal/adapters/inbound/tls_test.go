@@ -0,0 +1,173 @@
+package inbound_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// writeTestCertificate writes a self-signed certificate for the common name
+// into dir and returns the certificate and key paths.
+func writeTestCertificate(t *testing.T, dir, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	_ = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	_ = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	return certFile, keyFile
+}
+
+func certificateCommonName(t *testing.T, cert *tls.Certificate) string {
+	t.Helper()
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return parsed.Subject.CommonName
+}
+
+// ============================================================================
+// CertificateReloader Tests
+// ============================================================================
+
+func Test_CertificateReloader_Reload_Should_Serve_New_Certificate(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir, "old.example.com")
+	reloader, err := inbound.NewCertificateReloader(certFile, keyFile)
+	assert.That(t, "error must be nil", err == nil, true)
+	writeTestCertificate(t, dir, "new.example.com")
+
+	// Act
+	reloadErr := reloader.Reload()
+
+	// Assert
+	assert.That(t, "reload error must be nil", reloadErr == nil, true)
+	cert, _ := reloader.GetCertificate(nil)
+	assert.That(t, "certificate must be replaced", certificateCommonName(t, cert), "new.example.com")
+}
+
+func Test_CertificateReloader_Reload_With_Broken_Files_Should_Keep_Previous_Certificate(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir, "old.example.com")
+	reloader, _ := inbound.NewCertificateReloader(certFile, keyFile)
+	_ = os.WriteFile(certFile, []byte("broken"), 0o600)
+
+	// Act
+	err := reloader.Reload()
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	cert, _ := reloader.GetCertificate(nil)
+	assert.That(t, "certificate must be kept", certificateCommonName(t, cert), "old.example.com")
+}
+
+func Test_NewCertificateReloader_With_Missing_Files_Should_Return_Error(t *testing.T) {
+	// Act
+	_, err := inbound.NewCertificateReloader("missing.crt", "missing.key")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+// ============================================================================
+// WithClientCert Tests
+// ============================================================================
+
+func Test_WithClientCert_Without_Certificate_Should_Return_401(t *testing.T) {
+	// Arrange
+	handler := inbound.WithClientCert(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/privacy/guests/guest@example.com/export", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
+
+func Test_WithClientCert_With_Verified_Certificate_Should_Call_Next(t *testing.T) {
+	// Arrange
+	handler := inbound.WithClientCert(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/privacy/guests/guest@example.com/export", nil)
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{&x509.Certificate{}}}}
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+}
+
+func Test_Route_Privacy_API_With_RequireClientCert_Without_Certificate_Should_Return_401(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	reservationService, privacyService := createPrivacyTestServices(t)
+	verifier := oidc.NewVerifier("http://localhost:8180/realms/local", &oidc.StaticKeySet{}, &oidc.Config{ClientID: "hotel-booking-mcp"})
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: reservationService,
+		PrivacyService:     privacyService,
+		RequireClientCert:  true,
+		Verifier:           verifier,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/privacy/guests/guest@example.com/export", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+	assert.That(t, "body must mention client certificate", strings.Contains(rec.Body.String(), "Client certificate required"), true)
+}
@@ -0,0 +1,91 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/admin"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// HandlerObserver is notified of the outcome of every handler call, e.g. the admin.EventLog.
+type HandlerObserver interface {
+	ObserveHandler(msg messaging.Message, outcome admin.HandlerOutcome)
+}
+
+// TracingDispatcher decorates a messaging.Dispatcher so that the events of one request
+// can be followed end to end. Handlers run with the correlation ID of their event in the
+// context, so the events they publish carry it on, and the outcome and duration of each
+// handler call are reported to the observer.
+//
+// Handlers subscribed in a consumer group are reported under the name of the group,
+// the others under the name of the dispatcher (see Named).
+type TracingDispatcher struct {
+	dispatcher messaging.Dispatcher
+	observer   HandlerObserver
+	name       string
+}
+
+// NewTracingDispatcher creates a new tracing dispatcher.
+func NewTracingDispatcher(dispatcher messaging.Dispatcher, observer HandlerObserver) *TracingDispatcher {
+	return &TracingDispatcher{
+		dispatcher: dispatcher,
+		observer:   observer,
+		name:       "subscriber",
+	}
+}
+
+// Named returns a tracing dispatcher that reports the handlers subscribed without a
+// consumer group under the name, e.g. "saga-tracker" for a local read model.
+func (d *TracingDispatcher) Named(name string) *TracingDispatcher {
+	named := *d
+	named.name = name
+	return &named
+}
+
+// Publish publishes a message with the decorated dispatcher.
+func (d *TracingDispatcher) Publish(ctx context.Context, message messaging.Message) error {
+	return d.dispatcher.Publish(ctx, message)
+}
+
+// Subscribe subscribes the handler with the decorated dispatcher and traces its calls.
+func (d *TracingDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return d.dispatcher.Subscribe(ctx, topic, d.trace(d.name, fn))
+}
+
+// SubscribeGroup subscribes the handler in a consumer group and traces its calls.
+// Without consumer groups in the decorated dispatcher, it subscribes as usual.
+func (d *TracingDispatcher) SubscribeGroup(ctx context.Context, group, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	if subscriber, ok := d.dispatcher.(GroupSubscriber); ok {
+		return subscriber.SubscribeGroup(ctx, group, topic, d.trace(group, fn))
+	}
+	return d.dispatcher.Subscribe(ctx, topic, d.trace(group, fn))
+}
+
+// trace adds the correlation ID of the message to the context of the handler
+// and reports the outcome of the call.
+func (d *TracingDispatcher) trace(name string, fn service.Function[messaging.Message, messaging.MessageState]) service.Function[messaging.Message, messaging.MessageState] {
+	return func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		var payload struct {
+			CorrelationID string `json:"correlation_id"`
+		}
+		if err := json.Unmarshal(msg.Data, &payload); err == nil && payload.CorrelationID != "" {
+			ctx = shared.WithCorrelationID(ctx, payload.CorrelationID)
+		}
+
+		start := time.Now()
+		state, err := fn(ctx, msg)
+		outcome := admin.HandlerOutcome{Handler: name, Duration: time.Since(start)}
+		if err != nil || state == messaging.MessageStateFailed {
+			outcome.Failed = true
+		}
+		if err != nil {
+			outcome.Error = err.Error()
+		}
+		d.observer.ObserveHandler(msg, outcome)
+		return state, err
+	}
+}
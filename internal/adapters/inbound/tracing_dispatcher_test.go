@@ -0,0 +1,86 @@
+package inbound_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/admin"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Observer collecting the outcomes of handler calls.
+type outcomeRecorder struct {
+	mu       sync.Mutex
+	outcomes []admin.HandlerOutcome
+}
+
+func (o *outcomeRecorder) ObserveHandler(msg messaging.Message, outcome admin.HandlerOutcome) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.outcomes = append(o.outcomes, outcome)
+}
+
+// ============================================================================
+// TracingDispatcher Tests
+// ============================================================================
+
+func Test_TracingDispatcher_Should_Pass_Correlation_ID_To_Handler(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	observer := &outcomeRecorder{}
+	dispatcher := inbound.NewTracingDispatcher(messaging.NewInternalDispatcher(), observer)
+	var correlationID string
+	_ = dispatcher.Subscribe(ctx, "test.topic", func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		correlationID = shared.CorrelationID(ctx)
+		return messaging.MessageStateCompleted, nil
+	})
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("test.topic", []byte(`{"correlation_id":"req-123"}`)))
+
+	// Assert
+	assert.That(t, "handler must receive the correlation ID", correlationID, "req-123")
+	assert.That(t, "outcome must be reported", len(observer.outcomes), 1)
+	assert.That(t, "outcome must not be failed", observer.outcomes[0].Failed, false)
+}
+
+func Test_TracingDispatcher_Should_Report_Failed_Handler_With_Group_Name(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	observer := &outcomeRecorder{}
+	dispatcher := inbound.NewTracingDispatcher(messaging.NewInternalDispatcher(), observer)
+	_ = inbound.NewConsumerGroup(dispatcher, "orchestration").Subscribe(ctx, "test.topic", func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		return messaging.MessageStateFailed, errors.New("payment declined")
+	})
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("test.topic", []byte(`{}`)))
+
+	// Assert
+	assert.That(t, "outcome must be reported", len(observer.outcomes), 1)
+	assert.That(t, "handler must be named after the group", observer.outcomes[0].Handler, "orchestration")
+	assert.That(t, "outcome must be failed", observer.outcomes[0].Failed, true)
+	assert.That(t, "error must be reported", observer.outcomes[0].Error, "payment declined")
+}
+
+func Test_TracingDispatcher_Named_Should_Report_Handler_Under_Name(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	observer := &outcomeRecorder{}
+	dispatcher := inbound.NewTracingDispatcher(messaging.NewInternalDispatcher(), observer)
+	_ = dispatcher.Named("saga-tracker").Subscribe(ctx, "test.topic", func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		return messaging.MessageStateCompleted, nil
+	})
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("test.topic", []byte(`{}`)))
+
+	// Assert
+	assert.That(t, "outcome must be reported", len(observer.outcomes), 1)
+	assert.That(t, "handler must be named", observer.outcomes[0].Handler, "saga-tracker")
+}
@@ -0,0 +1,250 @@
+package outbound
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// This file contains the field-level encryption used by repository adapters
+// to protect PII at rest. Ciphertexts carry the ID of the key that produced
+// them, so keys can be rotated without rewriting all data at once.
+
+// Encryptor encrypts and decrypts single field values.
+// Implementations may use local keys (AESGCMEncryptor) or delegate to a KMS.
+// EncryptLookup encrypts lookup keys such as the guest ID deterministically:
+// equal values give equal ciphertexts, so a store can still find them, and
+// LookupValues returns every form a value may be stored in.
+type Encryptor interface {
+	Encrypt(ctx context.Context, plaintext string) (string, error)
+	EncryptLookup(ctx context.Context, plaintext string) (string, error)
+	LookupValues(ctx context.Context, plaintext string) ([]string, error)
+	Decrypt(ctx context.Context, ciphertext string) (string, error)
+	NeedsReEncryption(value string) bool
+}
+
+// ciphertextPrefix marks encrypted values. Values without it are treated as
+// legacy plaintext, which keeps existing data readable until it is re-encrypted.
+const ciphertextPrefix = "enc:v1:"
+
+// lookupPrefix marks deterministically encrypted lookup keys.
+const lookupPrefix = "enc:l1:"
+
+// lookupTagSize is the size of the HMAC that is the IV and tag of a lookup key.
+const lookupTagSize = aes.BlockSize
+
+var (
+	ErrInvalidEncryptionKey = errors.New("invalid encryption key")
+	ErrUnknownEncryptionKey = errors.New("unknown encryption key")
+	ErrInvalidCiphertext    = errors.New("invalid ciphertext")
+)
+
+// AESGCMEncryptor encrypts values with AES-256-GCM.
+// New values are always encrypted with the active key; any known key decrypts.
+// Lookup keys use the SIV construction instead: the HMAC-SHA256 of the value
+// is the IV of AES-CTR and authenticates the value, with keys derived from
+// the same key.
+type AESGCMEncryptor struct {
+	ciphers     map[string]cipher.AEAD
+	lookupKeys  map[string]lookupKey
+	activeKeyID string
+}
+
+// lookupKey holds the keys of the lookup encryption derived from one key.
+type lookupKey struct {
+	mac   []byte
+	block cipher.Block
+}
+
+// NewAESGCMEncryptor creates a new encryptor from 32-byte keys indexed by key ID.
+func NewAESGCMEncryptor(keys map[string][]byte, activeKeyID string) (*AESGCMEncryptor, error) {
+	if _, ok := keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("%w: active key %q", ErrUnknownEncryptionKey, activeKeyID)
+	}
+	ciphers := make(map[string]cipher.AEAD, len(keys))
+	lookupKeys := make(map[string]lookupKey, len(keys))
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("%w: key ID %q", ErrInvalidEncryptionKey, id)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("%w: key %q must be 32 bytes", ErrInvalidEncryptionKey, id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher: %w", err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GCM: %w", err)
+		}
+		ciphers[id] = aead
+		lookupBlock, err := aes.NewCipher(deriveKey(key, "lookup-enc"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher: %w", err)
+		}
+		lookupKeys[id] = lookupKey{mac: deriveKey(key, "lookup-mac"), block: lookupBlock}
+	}
+	return &AESGCMEncryptor{
+		ciphers:     ciphers,
+		lookupKeys:  lookupKeys,
+		activeKeyID: activeKeyID,
+	}, nil
+}
+
+// NewAESGCMEncryptorFromSpec creates a new encryptor from a key specification
+// of the form "id1:base64key1,id2:base64key2". The first key is the active one.
+func NewAESGCMEncryptorFromSpec(spec string) (*AESGCMEncryptor, error) {
+	keys := make(map[string][]byte)
+	var activeKeyID string
+	for _, entry := range strings.Split(spec, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: expected id:base64key", ErrInvalidEncryptionKey)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q is not valid base64", ErrInvalidEncryptionKey, id)
+		}
+		if activeKeyID == "" {
+			activeKeyID = id
+		}
+		keys[id] = key
+	}
+	return NewAESGCMEncryptor(keys, activeKeyID)
+}
+
+// Encrypt encrypts a value with the active key.
+// Empty values stay empty so optional fields remain distinguishable.
+func (e *AESGCMEncryptor) Encrypt(_ context.Context, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	aead := e.ciphers[e.activeKeyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(e.activeKeyID))
+	return ciphertextPrefix + e.activeKeyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// EncryptLookup encrypts a lookup key deterministically with the active key.
+// Empty values stay empty.
+func (e *AESGCMEncryptor) EncryptLookup(_ context.Context, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	return e.sealLookup(e.activeKeyID, plaintext), nil
+}
+
+// LookupValues returns the forms a lookup key may be stored in: encrypted with
+// the active key, with each rotated key, and as legacy plaintext.
+func (e *AESGCMEncryptor) LookupValues(_ context.Context, plaintext string) ([]string, error) {
+	values := []string{e.sealLookup(e.activeKeyID, plaintext)}
+	for id := range e.lookupKeys {
+		if id != e.activeKeyID {
+			values = append(values, e.sealLookup(id, plaintext))
+		}
+	}
+	return append(values, plaintext), nil
+}
+
+// sealLookup encrypts a lookup key with the key of the ID.
+func (e *AESGCMEncryptor) sealLookup(keyID, plaintext string) string {
+	key := e.lookupKeys[keyID]
+	iv := lookupTag(key, []byte(plaintext))
+	sealed := make([]byte, lookupTagSize+len(plaintext))
+	copy(sealed, iv)
+	cipher.NewCTR(key.block, iv).XORKeyStream(sealed[lookupTagSize:], []byte(plaintext))
+	return lookupPrefix + keyID + ":" + base64.RawURLEncoding.EncodeToString(sealed)
+}
+
+// openLookup decrypts a lookup key and verifies its HMAC.
+func (e *AESGCMEncryptor) openLookup(keyID, payload string) (string, error) {
+	key, ok := e.lookupKeys[keyID]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownEncryptionKey, keyID)
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < lookupTagSize {
+		return "", ErrInvalidCiphertext
+	}
+	iv, data := sealed[:lookupTagSize], sealed[lookupTagSize:]
+	plaintext := make([]byte, len(data))
+	cipher.NewCTR(key.block, iv).XORKeyStream(plaintext, data)
+	if !hmac.Equal(iv, lookupTag(key, plaintext)) {
+		return "", ErrInvalidCiphertext
+	}
+	return string(plaintext), nil
+}
+
+// lookupTag returns the truncated HMAC of a lookup key.
+func lookupTag(key lookupKey, plaintext []byte) []byte {
+	mac := hmac.New(sha256.New, key.mac)
+	mac.Write(plaintext)
+	return mac.Sum(nil)[:lookupTagSize]
+}
+
+// deriveKey derives a key for one purpose from a key.
+func deriveKey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// Decrypt decrypts a value with the key it was encrypted with.
+// Values without the ciphertext prefix are returned unchanged.
+func (e *AESGCMEncryptor) Decrypt(_ context.Context, ciphertext string) (string, error) {
+	if rest, ok := strings.CutPrefix(ciphertext, lookupPrefix); ok {
+		keyID, payload, _ := strings.Cut(rest, ":")
+		return e.openLookup(keyID, payload)
+	}
+	keyID, payload, ok := splitCiphertext(ciphertext)
+	if !ok {
+		return ciphertext, nil
+	}
+	aead, ok := e.ciphers[keyID]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownEncryptionKey, keyID)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+	nonce, data := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, data, []byte(keyID))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReEncryption reports whether a stored value is plaintext or was
+// encrypted with a key other than the active one.
+func (e *AESGCMEncryptor) NeedsReEncryption(value string) bool {
+	if value == "" {
+		return false
+	}
+	keyID, _, ok := splitCiphertext(value)
+	return !ok || keyID != e.activeKeyID
+}
+
+// splitCiphertext splits an encrypted value or lookup key into its key ID and payload.
+func splitCiphertext(value string) (keyID, payload string, ok bool) {
+	rest, found := strings.CutPrefix(value, ciphertextPrefix)
+	if !found {
+		rest, found = strings.CutPrefix(value, lookupPrefix)
+	}
+	if !found {
+		return "", "", false
+	}
+	return strings.Cut(rest, ":")
+}
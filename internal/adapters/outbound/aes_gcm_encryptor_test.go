@@ -0,0 +1,198 @@
+package outbound_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func newTestEncryptor(t *testing.T, keys map[string][]byte, activeKeyID string) *outbound.AESGCMEncryptor {
+	t.Helper()
+	enc, err := outbound.NewAESGCMEncryptor(keys, activeKeyID)
+	if err != nil {
+		t.Fatalf("failed to create encryptor: %v", err)
+	}
+	return enc
+}
+
+// ============================================================================
+// AESGCMEncryptor Tests
+// ============================================================================
+
+func Test_AESGCMEncryptor_Encrypt_Decrypt_Should_Roundtrip(t *testing.T) {
+	// Arrange
+	enc := newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1")
+	ctx := context.Background()
+
+	// Act
+	ciphertext, err := enc.Encrypt(ctx, "john@example.com")
+	plaintext, decErr := enc.Decrypt(ctx, ciphertext)
+
+	// Assert
+	assert.That(t, "encrypt error must be nil", err == nil, true)
+	assert.That(t, "decrypt error must be nil", decErr == nil, true)
+	assert.That(t, "ciphertext must not contain plaintext", strings.Contains(ciphertext, "john"), false)
+	assert.That(t, "ciphertext must carry key ID", strings.HasPrefix(ciphertext, "enc:v1:k1:"), true)
+	assert.That(t, "plaintext must match", plaintext, "john@example.com")
+}
+
+func Test_AESGCMEncryptor_Encrypt_Empty_Should_Stay_Empty(t *testing.T) {
+	// Arrange
+	enc := newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1")
+
+	// Act
+	ciphertext, err := enc.Encrypt(context.Background(), "")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "ciphertext must be empty", ciphertext, "")
+}
+
+func Test_AESGCMEncryptor_Decrypt_Plaintext_Should_Pass_Through(t *testing.T) {
+	// Arrange
+	enc := newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1")
+
+	// Act
+	plaintext, err := enc.Decrypt(context.Background(), "legacy@example.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "plaintext must be unchanged", plaintext, "legacy@example.com")
+}
+
+func Test_AESGCMEncryptor_Decrypt_With_Rotated_Key_Should_Succeed(t *testing.T) {
+	// Arrange
+	old := newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1")
+	rotated := newTestEncryptor(t, map[string][]byte{"k1": testKey(1), "k2": testKey(2)}, "k2")
+	ctx := context.Background()
+	ciphertext, _ := old.Encrypt(ctx, "John Doe")
+
+	// Act
+	plaintext, err := rotated.Decrypt(ctx, ciphertext)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "plaintext must match", plaintext, "John Doe")
+	assert.That(t, "old ciphertext must need re-encryption", rotated.NeedsReEncryption(ciphertext), true)
+}
+
+func Test_AESGCMEncryptor_Decrypt_With_Unknown_Key_Should_Fail(t *testing.T) {
+	// Arrange
+	old := newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1")
+	other := newTestEncryptor(t, map[string][]byte{"k2": testKey(2)}, "k2")
+	ctx := context.Background()
+	ciphertext, _ := old.Encrypt(ctx, "John Doe")
+
+	// Act
+	_, err := other.Decrypt(ctx, ciphertext)
+
+	// Assert
+	assert.That(t, "error must be ErrUnknownEncryptionKey", errors.Is(err, outbound.ErrUnknownEncryptionKey), true)
+}
+
+func Test_AESGCMEncryptor_Decrypt_Tampered_Should_Fail(t *testing.T) {
+	// Arrange
+	enc := newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1")
+	ctx := context.Background()
+	ciphertext, _ := enc.Encrypt(ctx, "John Doe")
+	tampered := ciphertext[:len(ciphertext)-4] + "AAAA"
+
+	// Act
+	_, err := enc.Decrypt(ctx, tampered)
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidCiphertext", errors.Is(err, outbound.ErrInvalidCiphertext), true)
+}
+
+func Test_AESGCMEncryptor_NeedsReEncryption_Should_Detect_Plaintext(t *testing.T) {
+	// Arrange
+	enc := newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1")
+	current, _ := enc.Encrypt(context.Background(), "John Doe")
+
+	// Act & Assert
+	assert.That(t, "plaintext must need re-encryption", enc.NeedsReEncryption("John Doe"), true)
+	assert.That(t, "current ciphertext must not need re-encryption", enc.NeedsReEncryption(current), false)
+	assert.That(t, "empty value must not need re-encryption", enc.NeedsReEncryption(""), false)
+}
+
+func Test_AESGCMEncryptor_EncryptLookup_Should_Be_Deterministic_And_Roundtrip(t *testing.T) {
+	// Arrange
+	enc := newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1")
+	ctx := context.Background()
+
+	// Act
+	first, err := enc.EncryptLookup(ctx, "john@example.com")
+	second, _ := enc.EncryptLookup(ctx, "john@example.com")
+	other, _ := enc.EncryptLookup(ctx, "jane@example.com")
+	plaintext, decErr := enc.Decrypt(ctx, first)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "equal values must give equal lookup keys", first, second)
+	assert.That(t, "other values must give other lookup keys", first != other, true)
+	assert.That(t, "lookup key must not contain the value", strings.Contains(first, "john"), false)
+	assert.That(t, "decrypt error must be nil", decErr == nil, true)
+	assert.That(t, "lookup key must decrypt", plaintext, "john@example.com")
+}
+
+func Test_AESGCMEncryptor_Decrypt_Tampered_Lookup_Key_Should_Fail(t *testing.T) {
+	// Arrange
+	enc := newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1")
+	ctx := context.Background()
+	key, _ := enc.EncryptLookup(ctx, "john@example.com")
+	i := len(key) - 10
+	tampered := key[:i] + "A" + key[i+1:]
+	if key[i] == 'A' {
+		tampered = key[:i] + "B" + key[i+1:]
+	}
+
+	// Act
+	_, err := enc.Decrypt(ctx, tampered)
+
+	// Assert
+	assert.That(t, "tampered lookup key must fail", errors.Is(err, outbound.ErrInvalidCiphertext), true)
+}
+
+func Test_NewAESGCMEncryptor_With_Short_Key_Should_Fail(t *testing.T) {
+	// Act
+	_, err := outbound.NewAESGCMEncryptor(map[string][]byte{"k1": []byte("short")}, "k1")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidEncryptionKey", errors.Is(err, outbound.ErrInvalidEncryptionKey), true)
+}
+
+func Test_NewAESGCMEncryptorFromSpec_Should_Use_First_Key_As_Active(t *testing.T) {
+	// Arrange
+	spec := "k2:" + base64.StdEncoding.EncodeToString(testKey(2)) +
+		", k1:" + base64.StdEncoding.EncodeToString(testKey(1))
+
+	// Act
+	enc, err := outbound.NewAESGCMEncryptorFromSpec(spec)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	ciphertext, _ := enc.Encrypt(context.Background(), "John Doe")
+	assert.That(t, "active key must be k2", strings.HasPrefix(ciphertext, "enc:v1:k2:"), true)
+}
+
+func Test_NewAESGCMEncryptorFromSpec_With_Invalid_Spec_Should_Fail(t *testing.T) {
+	// Act
+	_, err := outbound.NewAESGCMEncryptorFromSpec("not-a-key")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidEncryptionKey", errors.Is(err, outbound.ErrInvalidEncryptionKey), true)
+}
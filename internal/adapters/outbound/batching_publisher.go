@@ -0,0 +1,187 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ErrPublisherClosed is returned for messages published after the batching publisher was closed.
+var ErrPublisherClosed = errors.New("batching publisher is closed")
+
+// BatchPublisher publishes several messages in one round trip, e.g. KafkaDispatcher.
+type BatchPublisher interface {
+	PublishBatch(ctx context.Context, messages []messaging.Message) error
+}
+
+// groupSubscriber is implemented by dispatchers that support consumer groups.
+type groupSubscriber interface {
+	SubscribeGroup(ctx context.Context, group, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error
+}
+
+// BatchingPublisher decorates a messaging.Dispatcher with write-behind publishing.
+// Publish queues the message and returns at once, so the broker round trip is not part
+// of the request latency. The queue is flushed in order when a batch is full or the
+// interval has passed, in one round trip if the dispatcher is a BatchPublisher.
+//
+// Failed batches are retried with the retry policy and then passed to the error
+// handler, since their publishers have already returned. Close flushes the queue.
+type BatchingPublisher struct {
+	dispatcher messaging.Dispatcher
+	size       int
+	interval   time.Duration
+	retry      RetryPolicy
+	onError    func(messages []messaging.Message, err error)
+
+	mutex  sync.RWMutex
+	closed bool
+	queue  chan messaging.Message
+	done   chan struct{}
+}
+
+// NewBatchingPublisher creates a new batching publisher that flushes batches of up to
+// size messages at least every interval. Failed batches are not retried until
+// WithRetryPolicy is set, and they are dropped until WithErrorHandler is set.
+func NewBatchingPublisher(dispatcher messaging.Dispatcher, size int, interval time.Duration) *BatchingPublisher {
+	size = max(size, 1)
+	p := &BatchingPublisher{
+		dispatcher: dispatcher,
+		size:       size,
+		interval:   max(interval, time.Millisecond),
+		retry:      NewRetryPolicy().WithMaxAttempts(1),
+		onError:    func([]messaging.Message, error) {},
+		queue:      make(chan messaging.Message, size*4),
+		done:       make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// WithRetryPolicy retries failed batches.
+func (p *BatchingPublisher) WithRetryPolicy(policy RetryPolicy) *BatchingPublisher {
+	p.retry = policy
+	return p
+}
+
+// WithErrorHandler sets the handler of the messages that could not be published,
+// e.g. to log them for a replay.
+func (p *BatchingPublisher) WithErrorHandler(fn func(messages []messaging.Message, err error)) *BatchingPublisher {
+	p.onError = fn
+	return p
+}
+
+// Publish queues the message. It only blocks while the queue is full.
+// Messages with priority (see shared.WithPriority) skip the queue and are published at once.
+func (p *BatchingPublisher) Publish(ctx context.Context, message messaging.Message) error {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if p.closed {
+		return ErrPublisherClosed
+	}
+	if shared.Priority(ctx) {
+		return Retry(ctx, p.retry, func(ctx context.Context) error {
+			return p.dispatcher.Publish(ctx, message)
+		})
+	}
+
+	select {
+	case p.queue <- message:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Subscribe subscribes the handler with the decorated dispatcher.
+func (p *BatchingPublisher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return p.dispatcher.Subscribe(ctx, topic, fn)
+}
+
+// SubscribeGroup subscribes the handler in a consumer group.
+// Without consumer groups in the decorated dispatcher, it subscribes as usual.
+func (p *BatchingPublisher) SubscribeGroup(ctx context.Context, group, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	if subscriber, ok := p.dispatcher.(groupSubscriber); ok {
+		return subscriber.SubscribeGroup(ctx, group, topic, fn)
+	}
+	return p.dispatcher.Subscribe(ctx, topic, fn)
+}
+
+// Close rejects new messages and waits until the queued messages were flushed
+// or the context is done.
+func (p *BatchingPublisher) Close(ctx context.Context) error {
+	p.mutex.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mutex.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run collects the queued messages into batches until the queue is closed.
+func (p *BatchingPublisher) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	batch := make([]messaging.Message, 0, p.size)
+	for {
+		select {
+		case message, ok := <-p.queue:
+			if !ok {
+				p.flush(batch)
+				return
+			}
+			batch = append(batch, message)
+			if len(batch) >= p.size {
+				p.flush(batch)
+				batch = make([]messaging.Message, 0, p.size)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				p.flush(batch)
+				batch = make([]messaging.Message, 0, p.size)
+			}
+		}
+	}
+}
+
+// flush publishes the batch in order and passes what could not be published to the error handler.
+func (p *BatchingPublisher) flush(batch []messaging.Message) {
+	if len(batch) == 0 {
+		return
+	}
+
+	// The publishers of the batch have returned, so their contexts are not used.
+	ctx := context.Background()
+	if publisher, ok := p.dispatcher.(BatchPublisher); ok {
+		if err := Retry(ctx, p.retry, func(ctx context.Context) error {
+			return publisher.PublishBatch(ctx, batch)
+		}); err != nil {
+			p.onError(batch, err)
+		}
+		return
+	}
+
+	for i, message := range batch {
+		if err := Retry(ctx, p.retry, func(ctx context.Context) error {
+			return p.dispatcher.Publish(ctx, message)
+		}); err != nil {
+			// Later messages may depend on this one, so they are not published either.
+			p.onError(batch[i:], err)
+			return
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Dispatcher recording the batches it publishes.
+type batchRecorder struct {
+	mockDispatcher
+	mu      sync.Mutex
+	batches [][]messaging.Message
+	err     error
+}
+
+func (b *batchRecorder) PublishBatch(ctx context.Context, messages []messaging.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	b.batches = append(b.batches, messages)
+	return nil
+}
+
+func (b *batchRecorder) published() [][]messaging.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.batches
+}
+
+// ============================================================================
+// BatchingPublisher Tests
+// ============================================================================
+
+func Test_BatchingPublisher_Should_Flush_Full_Batch_In_Order(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := &batchRecorder{}
+	publisher := outbound.NewBatchingPublisher(dispatcher, 2, time.Hour)
+
+	// Act
+	_ = publisher.Publish(ctx, messaging.NewMessage("reservation.created", nil))
+	_ = publisher.Publish(ctx, messaging.NewMessage("payment.authorized", nil))
+	err := publisher.Close(ctx)
+
+	// Assert
+	batches := dispatcher.published()
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "messages must be published in one batch", len(batches), 1)
+	assert.That(t, "first message must come first", batches[0][0].Topic, "reservation.created")
+	assert.That(t, "second message must come second", batches[0][1].Topic, "payment.authorized")
+}
+
+func Test_BatchingPublisher_Should_Flush_Partial_Batch_After_Interval(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := &batchRecorder{}
+	publisher := outbound.NewBatchingPublisher(dispatcher, 100, 5*time.Millisecond)
+	defer func() { _ = publisher.Close(ctx) }()
+
+	// Act
+	_ = publisher.Publish(ctx, messaging.NewMessage("reservation.created", nil))
+	deadline := time.Now().Add(time.Second)
+	for len(dispatcher.published()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Assert
+	assert.That(t, "partial batch must be flushed", len(dispatcher.published()), 1)
+}
+
+func Test_BatchingPublisher_With_Failing_Batch_Should_Call_Error_Handler(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := &batchRecorder{err: errors.New("broker unavailable")}
+	var failed []messaging.Message
+	var failure error
+	publisher := outbound.NewBatchingPublisher(dispatcher, 10, time.Hour).
+		WithErrorHandler(func(messages []messaging.Message, err error) {
+			failed, failure = messages, err
+		})
+
+	// Act
+	_ = publisher.Publish(ctx, messaging.NewMessage("reservation.created", nil))
+	_ = publisher.Close(ctx)
+
+	// Assert
+	assert.That(t, "failed messages must be passed on", len(failed), 1)
+	assert.That(t, "error must be passed on", failure != nil, true)
+}
+
+func Test_BatchingPublisher_Without_Batch_Support_Should_Publish_Each_Message(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := &mockDispatcher{}
+	publisher := outbound.NewBatchingPublisher(dispatcher, 10, time.Hour)
+
+	// Act
+	_ = publisher.Publish(ctx, messaging.NewMessage("reservation.created", nil))
+	_ = publisher.Publish(ctx, messaging.NewMessage("payment.authorized", nil))
+	_ = publisher.Close(ctx)
+
+	// Assert
+	assert.That(t, "messages must be published", len(dispatcher.publishedMessages), 2)
+	assert.That(t, "order must be kept", dispatcher.publishedMessages[1].Topic, "payment.authorized")
+}
+
+func Test_BatchingPublisher_After_Close_Should_Reject_Messages(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	publisher := outbound.NewBatchingPublisher(&batchRecorder{}, 10, time.Hour)
+	_ = publisher.Close(ctx)
+
+	// Act
+	err := publisher.Publish(ctx, messaging.NewMessage("reservation.created", nil))
+
+	// Assert
+	assert.That(t, "err must be ErrPublisherClosed", errors.Is(err, outbound.ErrPublisherClosed), true)
+}
+
+func Test_BatchingPublisher_With_Priority_Should_Publish_At_Once(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := &mockDispatcher{}
+	publisher := outbound.NewBatchingPublisher(dispatcher, 10, time.Hour)
+	defer func() { _ = publisher.Close(ctx) }()
+
+	// Act
+	err := publisher.Publish(shared.WithPriority(ctx), messaging.NewMessage("payment.failed", nil))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "message must be published without waiting for the batch", len(dispatcher.publishedMessages), 1)
+}
@@ -0,0 +1,186 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+)
+
+// maxAvailabilityEntries limits the number of cached date ranges.
+// Expired entries are dropped once it is reached, and all entries if none expired.
+const maxAvailabilityEntries = 10000
+
+// availabilityTopics are the events that change the occupancy of a room.
+var availabilityTopics = []string{
+	reservation.EventTopicCreated,
+	reservation.EventTopicCancelled,
+	reservation.EventTopicNoShow,
+	reservation.EventTopicRoomBlocked,
+	reservation.EventTopicRoomUnblocked,
+}
+
+// availabilityKey identifies a cached availability check.
+type availabilityKey struct {
+	roomID   reservation.RoomID
+	checkIn  time.Time
+	checkOut time.Time
+}
+
+// availabilityEntry is a cached availability with its expiry.
+type availabilityEntry struct {
+	available bool
+	expiresAt time.Time
+}
+
+// CachedAvailabilityChecker decorates an AvailabilityChecker with a short-lived cache
+// of IsRoomAvailable for read-heavy callers such as availability queries.
+// Concurrent misses of the same room and dates share one query of the next checker,
+// so an expired entry does not send a burst of queries to the repository. Entries
+// of a room are invalidated by the reservation events once RegisterHandlers is called.
+// Overlapping reservations are always read from the next checker.
+// It implements the reservation.AvailabilityChecker port and prometheus.Collector.
+type CachedAvailabilityChecker struct {
+	next     reservation.AvailabilityChecker
+	ttl      time.Duration
+	group    singleflight.Group
+	mu       sync.Mutex
+	entries  map[availabilityKey]availabilityEntry
+	versions map[reservation.RoomID]uint64 // Incremented on invalidation, so in-flight queries are not cached
+	version  uint64                        // Incremented when all rooms are invalidated
+	requests *prometheus.CounterVec
+}
+
+// NewCachedAvailabilityChecker creates a new caching availability checker that keeps entries for ttl.
+func NewCachedAvailabilityChecker(next reservation.AvailabilityChecker, ttl time.Duration) *CachedAvailabilityChecker {
+	return &CachedAvailabilityChecker{
+		next:     next,
+		ttl:      ttl,
+		entries:  make(map[availabilityKey]availabilityEntry),
+		versions: make(map[reservation.RoomID]uint64),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "availability_cache_requests_total",
+			Help: "The availability checks answered by the cache (hit) or the repository (miss).",
+		}, []string{"result"}),
+	}
+}
+
+// IsRoomAvailable returns the cached availability of the room or checks it with the next checker.
+func (c *CachedAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	key := availabilityKey{roomID: roomID, checkIn: dateRange.CheckIn, checkOut: dateRange.CheckOut}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	version := c.version + c.versions[roomID]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		c.requests.WithLabelValues("hit").Inc()
+		return entry.available, nil
+	}
+	c.requests.WithLabelValues("miss").Inc()
+
+	// Callers after an invalidation must not join a query started before it.
+	flight := fmt.Sprintf("%s/%d/%d/%d", roomID, dateRange.CheckIn.UnixNano(), dateRange.CheckOut.UnixNano(), version)
+	result, err, _ := c.group.Do(flight, func() (any, error) {
+		available, err := c.next.IsRoomAvailable(ctx, roomID, dateRange)
+		if err != nil {
+			return false, err
+		}
+		c.store(key, version, available)
+		return available, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.(bool), nil
+}
+
+// GetOverlappingReservations returns the overlapping reservations of the next checker.
+func (c *CachedAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	return c.next.GetOverlappingReservations(ctx, roomID, dateRange)
+}
+
+// Invalidate drops the cached availability of the room.
+func (c *CachedAvailabilityChecker) Invalidate(roomID reservation.RoomID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.versions[roomID]++
+	for key := range c.entries {
+		if key.roomID == roomID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// InvalidateAll drops the cached availability of all rooms.
+func (c *CachedAvailabilityChecker) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	clear(c.entries)
+}
+
+// RegisterHandlers subscribes the cache to the events that change the occupancy of a room.
+func (c *CachedAvailabilityChecker) RegisterHandlers(ctx context.Context, dispatcher messaging.Dispatcher) error {
+	for _, topic := range availabilityTopics {
+		if err := dispatcher.Subscribe(ctx, topic, service.Wrap(c.handleEvent)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// Describe implements prometheus.Collector.
+func (c *CachedAvailabilityChecker) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *CachedAvailabilityChecker) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+}
+
+// handleEvent invalidates the room of the event. Events without a room,
+// e.g. reservation.cancelled, invalidate all rooms.
+func (c *CachedAvailabilityChecker) handleEvent(msg messaging.Message) (messaging.MessageState, error) {
+	var evt struct {
+		RoomID reservation.RoomID `json:"room_id"`
+	}
+	if err := json.Unmarshal(msg.Data, &evt); err != nil || evt.RoomID == "" {
+		c.InvalidateAll()
+		return messaging.MessageStateCompleted, nil
+	}
+	c.Invalidate(evt.RoomID)
+	return messaging.MessageStateCompleted, nil
+}
+
+// store caches the availability unless the room was invalidated while it was checked.
+func (c *CachedAvailabilityChecker) store(key availabilityKey, version uint64, available bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.version+c.versions[key.roomID] != version {
+		return
+	}
+	now := time.Now()
+	if len(c.entries) >= maxAvailabilityEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxAvailabilityEntries {
+			clear(c.entries)
+		}
+	}
+	c.entries[key] = availabilityEntry{available: available, expiresAt: now.Add(c.ttl)}
+}
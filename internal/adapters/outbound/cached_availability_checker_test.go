@@ -0,0 +1,155 @@
+package outbound_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ============================================================================
+// CachedAvailabilityChecker Tests
+// ============================================================================
+
+// countingAvailabilityChecker counts the availability checks and blocks them until release is closed.
+type countingAvailabilityChecker struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (c *countingAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	c.calls.Add(1)
+	if c.release != nil {
+		<-c.release
+	}
+	return true, nil
+}
+
+func (c *countingAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	return nil, nil
+}
+
+func cacheTestDateRange() reservation.DateRange {
+	checkIn := time.Date(2030, time.March, 1, 0, 0, 0, 0, time.UTC)
+	return reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2))
+}
+
+// gatherCacheRequests returns availability_cache_requests_total by result.
+func gatherCacheRequests(t *testing.T, cache *outbound.CachedAvailabilityChecker) map[string]float64 {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(cache)
+	families, err := registry.Gather()
+	assert.That(t, "gather error must be nil", err == nil, true)
+	counts := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			counts[metric.GetLabel()[0].GetValue()] = metric.GetCounter().GetValue()
+		}
+	}
+	return counts
+}
+
+func Test_CachedAvailabilityChecker_Should_Answer_Repeated_Checks_From_Cache(t *testing.T) {
+	// Arrange
+	next := &countingAvailabilityChecker{}
+	cache := outbound.NewCachedAvailabilityChecker(next, time.Minute)
+	ctx := context.Background()
+
+	// Act
+	_, _ = cache.IsRoomAvailable(ctx, "room-101", cacheTestDateRange())
+	available, err := cache.IsRoomAvailable(ctx, "room-101", cacheTestDateRange())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "room must be available", available, true)
+	assert.That(t, "next checker must be called once", next.calls.Load(), int32(1))
+	counts := gatherCacheRequests(t, cache)
+	assert.That(t, "one hit must be counted", counts["hit"], float64(1))
+	assert.That(t, "one miss must be counted", counts["miss"], float64(1))
+}
+
+func Test_CachedAvailabilityChecker_With_Concurrent_Misses_Should_Check_Once(t *testing.T) {
+	// Arrange
+	next := &countingAvailabilityChecker{release: make(chan struct{})}
+	cache := outbound.NewCachedAvailabilityChecker(next, time.Minute)
+	var wg sync.WaitGroup
+
+	// Act
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = cache.IsRoomAvailable(context.Background(), "room-101", cacheTestDateRange())
+		}()
+	}
+	for next.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+
+	// Assert
+	assert.That(t, "next checker must be called once", next.calls.Load(), int32(1))
+}
+
+func Test_CachedAvailabilityChecker_With_Reservation_Event_Should_Invalidate_Room(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	next := &countingAvailabilityChecker{}
+	cache := outbound.NewCachedAvailabilityChecker(next, time.Minute)
+	dispatcher := messaging.NewInternalDispatcher()
+	_ = cache.RegisterHandlers(ctx, dispatcher)
+	_, _ = cache.IsRoomAvailable(ctx, "room-101", cacheTestDateRange())
+	_, _ = cache.IsRoomAvailable(ctx, "room-102", cacheTestDateRange())
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCreated, []byte(`{"reservation_id":"res-1","room_id":"room-101"}`)))
+	_, _ = cache.IsRoomAvailable(ctx, "room-101", cacheTestDateRange())
+	_, _ = cache.IsRoomAvailable(ctx, "room-102", cacheTestDateRange())
+
+	// Assert
+	assert.That(t, "only the booked room must be checked again", next.calls.Load(), int32(3))
+}
+
+func Test_CachedAvailabilityChecker_With_Cancelled_Event_Should_Invalidate_All_Rooms(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	next := &countingAvailabilityChecker{}
+	cache := outbound.NewCachedAvailabilityChecker(next, time.Minute)
+	dispatcher := messaging.NewInternalDispatcher()
+	_ = cache.RegisterHandlers(ctx, dispatcher)
+	_, _ = cache.IsRoomAvailable(ctx, "room-101", cacheTestDateRange())
+	_, _ = cache.IsRoomAvailable(ctx, "room-102", cacheTestDateRange())
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCancelled, []byte(`{"reservation_id":"res-1"}`)))
+	_, _ = cache.IsRoomAvailable(ctx, "room-101", cacheTestDateRange())
+	_, _ = cache.IsRoomAvailable(ctx, "room-102", cacheTestDateRange())
+
+	// Assert
+	assert.That(t, "both rooms must be checked again", next.calls.Load(), int32(4))
+}
+
+func Test_CachedAvailabilityChecker_After_TTL_Should_Check_Again(t *testing.T) {
+	// Arrange
+	next := &countingAvailabilityChecker{}
+	cache := outbound.NewCachedAvailabilityChecker(next, time.Millisecond)
+	ctx := context.Background()
+	_, _ = cache.IsRoomAvailable(ctx, "room-101", cacheTestDateRange())
+	time.Sleep(5 * time.Millisecond)
+
+	// Act
+	_, _ = cache.IsRoomAvailable(ctx, "room-101", cacheTestDateRange())
+
+	// Assert
+	assert.That(t, "next checker must be called again", next.calls.Load(), int32(2))
+}
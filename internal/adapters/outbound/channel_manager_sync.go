@@ -0,0 +1,73 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/channel"
+)
+
+// ChannelManagerSync pushes availability changes to a channel manager's REST API,
+// which forwards them to the connected OTAs (e.g. Booking.com, Expedia).
+// Requests are authenticated with the API key as a Bearer token.
+// It implements the channel.ChannelSync port.
+type ChannelManagerSync struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewChannelManagerSync creates a new channel manager client for the API at baseURL.
+func NewChannelManagerSync(baseURL, apiKey string, client *http.Client) *ChannelManagerSync {
+	return &ChannelManagerSync{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  client,
+	}
+}
+
+// availabilityRequest is the body of POST /availability.
+// Dates are sent as calendar days; the check-out day itself stays bookable.
+type availabilityRequest struct {
+	RoomID        string `json:"room_id"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	Available     bool   `json:"available"`
+	ReservationID string `json:"reservation_id"`
+}
+
+// PushAvailability opens or closes the room for the date range with a POST request.
+func (c *ChannelManagerSync) PushAvailability(ctx context.Context, update channel.AvailabilityUpdate) error {
+	body, err := json.Marshal(availabilityRequest{
+		RoomID:        string(update.RoomID),
+		From:          update.CheckIn.Format("2006-01-02"),
+		To:            update.CheckOut.Format("2006-01-02"),
+		Available:     update.Available,
+		ReservationID: string(update.ReservationID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode availability: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/availability", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push availability: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to push availability: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
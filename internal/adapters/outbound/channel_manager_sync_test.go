@@ -0,0 +1,68 @@
+package outbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/channel"
+)
+
+// ============================================================================
+// ChannelManagerSync Tests
+// ============================================================================
+
+func Test_ChannelManagerSync_PushAvailability_Should_Post_Update(t *testing.T) {
+	// Arrange
+	var auth string
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/availability" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+	sync := outbound.NewChannelManagerSync(srv.URL+"/v1/", "api-key", srv.Client())
+	checkIn := time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	err := sync.PushAvailability(context.Background(), channel.AvailabilityUpdate{
+		RoomID:        "room-101",
+		CheckIn:       checkIn,
+		CheckOut:      checkIn.AddDate(0, 0, 3),
+		Available:     false,
+		ReservationID: "res-001",
+	})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "request must carry the API key", auth, "Bearer api-key")
+	assert.That(t, "room ID must be sent", body["room_id"], any("room-101"))
+	assert.That(t, "from must be the check-in day", body["from"], any("2030-05-01"))
+	assert.That(t, "to must be the check-out day", body["to"], any("2030-05-04"))
+	assert.That(t, "room must be closed", body["available"], any(false))
+}
+
+func Test_ChannelManagerSync_PushAvailability_With_Error_Status_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+	sync := outbound.NewChannelManagerSync(srv.URL, "api-key", srv.Client())
+
+	// Act
+	err := sync.PushAvailability(context.Background(), channel.AvailabilityUpdate{RoomID: "room-101"})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
@@ -0,0 +1,41 @@
+package outbound
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Codec encodes and decodes values, e.g. events and the records of file repositories.
+// The standard library is used by default; faster implementations are compiled in
+// with build tags and selected by name (see NewCodec).
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec is the Codec of the standard library encoding/json package.
+type JSONCodec struct{}
+
+// Marshal encodes the value to JSON.
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes JSON into the value.
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// codecs are the codecs compiled into the binary, by name.
+var codecs = map[string]Codec{
+	"json": JSONCodec{},
+}
+
+// NewCodec returns the codec with the given name: "json" (default) or "go-json",
+// which requires the gojson build tag.
+func NewCodec(name string) (Codec, error) {
+	if codec, ok := codecs[name]; ok {
+		return codec, nil
+	}
+	return nil, fmt.Errorf("unknown codec %q (go-json requires the gojson build tag)", name)
+}
@@ -0,0 +1,24 @@
+//go:build gojson
+
+package outbound
+
+import gojson "github.com/goccy/go-json"
+
+// GoJSONCodec is a Codec backed by github.com/goccy/go-json, a drop-in replacement
+// of encoding/json that encodes and decodes several times faster.
+// It is compiled in with the gojson build tag and selected with CODEC=go-json.
+type GoJSONCodec struct{}
+
+func init() {
+	codecs["go-json"] = GoJSONCodec{}
+}
+
+// Marshal encodes the value to JSON.
+func (GoJSONCodec) Marshal(v any) ([]byte, error) {
+	return gojson.Marshal(v)
+}
+
+// Unmarshal decodes JSON into the value.
+func (GoJSONCodec) Unmarshal(data []byte, v any) error {
+	return gojson.Unmarshal(data, v)
+}
@@ -0,0 +1,23 @@
+//go:build gojson
+
+package outbound_test
+
+import (
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+func Test_NewCodec_With_GoJson_Should_Return_GoJSONCodec(t *testing.T) {
+	// Arrange & Act
+	codec, err := outbound.NewCodec("go-json")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "codec must be go-json", codec, outbound.Codec(outbound.GoJSONCodec{}))
+}
+
+func Benchmark_GoJSONCodec_Round_Trip_Should_Be_Fast(b *testing.B) {
+	benchmarkCodec(b, outbound.GoJSONCodec{})
+}
@@ -0,0 +1,66 @@
+package outbound_test
+
+import (
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Codec Tests
+// ============================================================================
+
+func Test_NewCodec_With_Json_Should_Return_JSONCodec(t *testing.T) {
+	// Arrange & Act
+	codec, err := outbound.NewCodec("json")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "codec must be the standard library", codec, outbound.Codec(outbound.JSONCodec{}))
+}
+
+func Test_NewCodec_With_Unknown_Name_Should_Return_Error(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewCodec("xml")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_JSONCodec_Marshal_Unmarshal_Should_Round_Trip(t *testing.T) {
+	// Arrange
+	codec := outbound.JSONCodec{}
+	event := testEvent{EventTopic: "test.topic", Data: "test data"}
+
+	// Act
+	encoded, err := codec.Marshal(event)
+	var decoded testEvent
+	decodeErr := codec.Unmarshal(encoded, &decoded)
+
+	// Assert
+	assert.That(t, "marshal error must be nil", err == nil, true)
+	assert.That(t, "unmarshal error must be nil", decodeErr == nil, true)
+	assert.That(t, "event must match", decoded, event)
+}
+
+// ============================================================================
+// Codec Benchmarks
+// ============================================================================
+
+func benchmarkCodec(b *testing.B, codec outbound.Codec) {
+	events := make([]testEvent, 1000)
+	for i := range events {
+		events[i] = testEvent{EventTopic: "reservation.created", Data: "guest-001 room-101 2026-10-16 2026-10-20"}
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		encoded, _ := codec.Marshal(events)
+		var decoded []testEvent
+		_ = codec.Unmarshal(encoded, &decoded)
+	}
+}
+
+func Benchmark_JSONCodec_Round_Trip_Should_Be_Fast(b *testing.B) {
+	benchmarkCodec(b, outbound.JSONCodec{})
+}
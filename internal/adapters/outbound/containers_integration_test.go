@@ -0,0 +1,294 @@
+//go:build integration
+
+package outbound_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/modules/redpanda"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// ============================================================================
+// Test Containers
+// ============================================================================
+// The integration tests start their infrastructure with testcontainers and remove
+// it when the test ends. They need a Docker-compatible runtime and are skipped
+// without one:
+//   go test -tags=integration ./internal/adapters/outbound/
+
+const (
+	postgresImage = "postgres:16-alpine"
+	redpandaImage = "docker.redpanda.com/redpandadata/redpanda:v24.3.7"
+	redisImage    = "redis:7-alpine"
+)
+
+// skipWithoutDocker skips the test if no container runtime is available. Like
+// testcontainers.SkipIfProviderIsNotHealthy, but usable in benchmarks, too.
+func skipWithoutDocker(tb testing.TB) {
+	tb.Helper()
+	// The provider panics if it finds no Docker host at all.
+	defer func() {
+		if r := recover(); r != nil {
+			tb.Skipf("docker is not available: %v", r)
+		}
+	}()
+	provider, err := testcontainers.NewDockerProvider()
+	if err != nil {
+		tb.Skipf("docker is not available: %v", err)
+	}
+	defer func() { _ = provider.Close() }()
+	if err := provider.Health(context.Background()); err != nil {
+		tb.Skipf("docker is not available: %v", err)
+	}
+}
+
+// startPostgres starts a Postgres container with the schema of migrations/<context>/init.sql,
+// e.g. "reservation", and returns the opened database.
+func startPostgres(tb testing.TB, boundedContext string, config outbound.PostgresPoolConfig) *outbound.PostgresDB {
+	tb.Helper()
+	skipWithoutDocker(tb)
+	ctx := context.Background()
+
+	ctr, err := postgres.Run(ctx, postgresImage,
+		postgres.WithDatabase(boundedContext+"_db"),
+		postgres.WithUsername(boundedContext),
+		postgres.WithPassword(boundedContext+"_secret"),
+		postgres.WithInitScripts(filepath.Join("..", "..", "..", "migrations", boundedContext, "init.sql")),
+		postgres.BasicWaitStrategies(),
+	)
+	testcontainers.CleanupContainer(tb, ctr)
+	if err != nil {
+		tb.Fatalf("failed to start postgres: %v", err)
+	}
+	dsn, err := ctr.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		tb.Fatalf("failed to get postgres DSN: %v", err)
+	}
+
+	db, err := outbound.OpenPostgres(ctx, boundedContext, dsn, config)
+	if err != nil {
+		tb.Fatalf("failed to open postgres: %v", err)
+	}
+	tb.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// startRedis starts a Redis container and returns a client connected to it.
+func startRedis(tb testing.TB) *redis.Client {
+	tb.Helper()
+	skipWithoutDocker(tb)
+	ctx := context.Background()
+
+	ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        redisImage,
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForListeningPort("6379/tcp"),
+		},
+		Started: true,
+	})
+	testcontainers.CleanupContainer(tb, ctr)
+	if err != nil {
+		tb.Fatalf("failed to start redis: %v", err)
+	}
+	addr, err := ctr.PortEndpoint(ctx, "6379/tcp", "")
+	if err != nil {
+		tb.Fatalf("failed to get redis address: %v", err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	tb.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// startKafka starts a Redpanda container, which speaks the Kafka protocol, creates
+// the topics and returns the seed broker.
+func startKafka(tb testing.TB, topics ...string) string {
+	tb.Helper()
+	skipWithoutDocker(tb)
+	ctx := context.Background()
+
+	ctr, err := redpanda.Run(ctx, redpandaImage, redpanda.WithAutoCreateTopics())
+	testcontainers.CleanupContainer(tb, ctr)
+	if err != nil {
+		tb.Fatalf("failed to start redpanda: %v", err)
+	}
+	broker, err := ctr.KafkaSeedBroker(ctx)
+	if err != nil {
+		tb.Fatalf("failed to get kafka broker: %v", err)
+	}
+
+	// Consumer groups only join existing topics, so the topics are created up front.
+	conn, err := kafka.DialContext(ctx, "tcp", broker)
+	if err != nil {
+		tb.Fatalf("failed to connect to kafka: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+	controller, err := conn.Controller()
+	if err != nil {
+		tb.Fatalf("failed to find kafka controller: %v", err)
+	}
+	controllerConn, err := kafka.DialContext(ctx, "tcp", net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port)))
+	if err != nil {
+		tb.Fatalf("failed to connect to kafka controller: %v", err)
+	}
+	defer func() { _ = controllerConn.Close() }()
+	configs := make([]kafka.TopicConfig, 0, len(topics))
+	for _, topic := range topics {
+		configs = append(configs, kafka.TopicConfig{Topic: topic, NumPartitions: 3, ReplicationFactor: 1})
+	}
+	if err := controllerConn.CreateTopics(configs...); err != nil {
+		tb.Fatalf("failed to create topics: %v", err)
+	}
+	return broker
+}
+
+// ============================================================================
+// Session Store Tests
+// ============================================================================
+// The session stores hold the sessions and the magic link state across replicas,
+// so both backends are checked against the same behavior.
+
+// sessionStoreBackends returns the session store backends, each started in its own container.
+func sessionStoreBackends() map[string]func(t *testing.T) inbound.SessionStore {
+	return map[string]func(t *testing.T) inbound.SessionStore{
+		"postgres": func(t *testing.T) inbound.SessionStore {
+			return outbound.NewPostgresSessionStore(startPostgres(t, "reservation", outbound.PostgresPoolConfig{}).DB)
+		},
+		"redis": func(t *testing.T) inbound.SessionStore {
+			return outbound.NewRedisSessionStore(startRedis(t))
+		},
+	}
+}
+
+func Test_SessionStore_DeleteByEmail_Should_Revoke_Sessions_Of_Guest_Only(t *testing.T) {
+	for name, start := range sessionStoreBackends() {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			store := start(t)
+			ctx := context.Background()
+			john := web.IdentityTokenClaims{Email: "john@example.com", Name: "John Doe"}
+			jane := web.IdentityTokenClaims{Email: "jane@example.com", Name: "Jane Doe"}
+			_ = store.Save(ctx, "session-001", john, time.Hour)
+			_ = store.Save(ctx, "session-002", john, time.Hour)
+			_ = store.Save(ctx, "session-003", jane, time.Hour)
+
+			// Act
+			count, err := store.DeleteByEmail(ctx, "john@example.com")
+
+			// Assert
+			assert.That(t, "err must be nil", err, nil)
+			assert.That(t, "must delete the sessions of the guest", count, 2)
+			first, _ := store.Read(ctx, "session-001")
+			second, _ := store.Read(ctx, "session-002")
+			other, _ := store.Read(ctx, "session-003")
+			assert.That(t, "first session must be revoked", first == nil, true)
+			assert.That(t, "second session must be revoked", second == nil, true)
+			assert.That(t, "session of other guest must remain", other != nil, true)
+		})
+	}
+}
+
+func Test_SessionStore_DeleteByEmail_Without_Sessions_Should_Return_Zero(t *testing.T) {
+	for name, start := range sessionStoreBackends() {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			store := start(t)
+
+			// Act
+			count, err := store.DeleteByEmail(context.Background(), "john@example.com")
+
+			// Assert
+			assert.That(t, "err must be nil", err, nil)
+			assert.That(t, "no session must be deleted", count, 0)
+		})
+	}
+}
+
+func Test_SessionStore_UseNonce_Should_Accept_Unused_Nonce(t *testing.T) {
+	for name, start := range sessionStoreBackends() {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			store := start(t)
+
+			// Act
+			unused, err := store.UseNonce(context.Background(), "nonce-001", time.Hour)
+
+			// Assert
+			assert.That(t, "err must be nil", err, nil)
+			assert.That(t, "nonce must be unused", unused, true)
+		})
+	}
+}
+
+func Test_SessionStore_UseNonce_Twice_Should_Reject_Reuse(t *testing.T) {
+	for name, start := range sessionStoreBackends() {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			store := start(t)
+			ctx := context.Background()
+			_, _ = store.UseNonce(ctx, "nonce-001", time.Hour)
+
+			// Act
+			unused, err := store.UseNonce(ctx, "nonce-001", time.Hour)
+
+			// Assert
+			assert.That(t, "err must be nil", err, nil)
+			assert.That(t, "used nonce must be rejected", unused, false)
+		})
+	}
+}
+
+func Test_SessionStore_CountAttempt_Should_Count_Attempts_Per_Key(t *testing.T) {
+	for name, start := range sessionStoreBackends() {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			store := start(t)
+			ctx := context.Background()
+			_, _ = store.CountAttempt(ctx, "client:192.0.2.1", time.Hour)
+			_, _ = store.CountAttempt(ctx, "client:192.0.2.1", time.Hour)
+			_, _ = store.CountAttempt(ctx, "client:192.0.2.2", time.Hour)
+
+			// Act
+			count, err := store.CountAttempt(ctx, "client:192.0.2.1", time.Hour)
+
+			// Assert
+			assert.That(t, "err must be nil", err, nil)
+			assert.That(t, "attempts of the key must be counted", count, 3)
+		})
+	}
+}
+
+func Test_SessionStore_CountAttempt_After_Window_Should_Start_New_Window(t *testing.T) {
+	for name, start := range sessionStoreBackends() {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			store := start(t)
+			ctx := context.Background()
+			_, _ = store.CountAttempt(ctx, "client:192.0.2.1", time.Second)
+			_, _ = store.CountAttempt(ctx, "client:192.0.2.1", time.Second)
+			time.Sleep(1500 * time.Millisecond)
+
+			// Act
+			count, err := store.CountAttempt(ctx, "client:192.0.2.1", time.Second)
+
+			// Assert
+			assert.That(t, "err must be nil", err, nil)
+			assert.That(t, "new window must start with one attempt", count, 1)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package outbound
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// DelayedMessage is a message held back until its delivery time. The message is kept
+// whole, and the publish options and correlation ID of its context are restored when it
+// is released, so the decorators below the queue see the message as it was published.
+type DelayedMessage struct {
+	ID            string            `json:"id"`
+	Message       messaging.Message `json:"message"`
+	DeliverAt     time.Time         `json:"deliver_at"`
+	Priority      bool              `json:"priority,omitempty"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
+}
+
+// DelayQueue decorates a messaging.Dispatcher with delayed delivery, since Kafka has none.
+// Messages published with a future delivery time (see shared.WithDeliverAt) are stored
+// instead, and Release publishes them once they are due. Release is called by a scheduled
+// job, so a message is delivered within one interval of the job after its time.
+// The stored messages survive restarts. In production they are stored in Postgres,
+// so the job of the leader also releases the messages delayed on the other replicas.
+type DelayQueue struct {
+	dispatcher messaging.Dispatcher
+	messages   resource.Access[string, DelayedMessage]
+	ids        shared.IDGenerator
+	now        func() time.Time
+}
+
+// NewDelayQueue creates a new delay queue that stores the held back messages in messages.
+func NewDelayQueue(dispatcher messaging.Dispatcher, messages resource.Access[string, DelayedMessage]) *DelayQueue {
+	return &DelayQueue{
+		dispatcher: dispatcher,
+		messages:   messages,
+		ids:        shared.NewUUIDv7Generator(),
+		now:        time.Now,
+	}
+}
+
+// WithClock sets the clock that decides whether a delivery time is in the future.
+func (q *DelayQueue) WithClock(now func() time.Time) *DelayQueue {
+	q.now = now
+	return q
+}
+
+// Publish publishes a message with the decorated dispatcher,
+// or stores it if its delivery time is in the future.
+func (q *DelayQueue) Publish(ctx context.Context, message messaging.Message) error {
+	at := shared.DeliverAt(ctx)
+	if !at.After(q.now()) {
+		return q.dispatcher.Publish(ctx, message)
+	}
+
+	delayed := DelayedMessage{
+		ID:            q.ids.NewID(),
+		Message:       message,
+		DeliverAt:     at,
+		Priority:      shared.Priority(ctx),
+		CorrelationID: shared.CorrelationID(ctx),
+	}
+	if err := q.messages.Create(ctx, delayed.ID, delayed); err != nil {
+		return fmt.Errorf("failed to store delayed message: %w", err)
+	}
+	return nil
+}
+
+// Subscribe subscribes the handler with the decorated dispatcher.
+func (q *DelayQueue) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return q.dispatcher.Subscribe(ctx, topic, fn)
+}
+
+// SubscribeGroup subscribes the handler in a consumer group.
+// Without consumer groups in the decorated dispatcher, it subscribes as usual.
+func (q *DelayQueue) SubscribeGroup(ctx context.Context, group, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	if subscriber, ok := q.dispatcher.(groupSubscriber); ok {
+		return subscriber.SubscribeGroup(ctx, group, topic, fn)
+	}
+	return q.dispatcher.Subscribe(ctx, topic, fn)
+}
+
+// Release publishes the messages that are due at now in the order of their delivery time
+// and returns how many were published. It stops at the first failure, so the remaining
+// messages are published by the next call.
+func (q *DelayQueue) Release(ctx context.Context, now time.Time) (int, error) {
+	stored, err := q.messages.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read delayed messages: %w", err)
+	}
+	due := slices.DeleteFunc(stored, func(message DelayedMessage) bool {
+		return message.DeliverAt.After(now)
+	})
+	slices.SortFunc(due, func(a, b DelayedMessage) int {
+		return cmp.Or(a.DeliverAt.Compare(b.DeliverAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	released := 0
+	for _, delayed := range due {
+		publishCtx := ctx
+		if delayed.Priority {
+			publishCtx = shared.WithPriority(publishCtx)
+		}
+		if delayed.CorrelationID != "" {
+			publishCtx = shared.WithCorrelationID(publishCtx, delayed.CorrelationID)
+		}
+		if err := q.dispatcher.Publish(publishCtx, delayed.Message); err != nil {
+			return released, fmt.Errorf("failed to publish delayed message: %w", err)
+		}
+		if err := q.messages.Delete(ctx, delayed.ID); err != nil {
+			return released, fmt.Errorf("failed to delete delayed message: %w", err)
+		}
+		released++
+	}
+	return released, nil
+}
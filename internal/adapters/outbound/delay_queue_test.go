@@ -0,0 +1,92 @@
+package outbound_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+func newTestDelayQueue(t *testing.T, inner *mockDispatcher, now time.Time) *outbound.DelayQueue {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "delayed_events.json")
+	messages := outbound.NewFileAccess[string, outbound.DelayedMessage](path, outbound.JSONCodec{})
+	return outbound.NewDelayQueue(inner, messages).WithClock(func() time.Time { return now })
+}
+
+// ============================================================================
+// DelayQueue Tests
+// ============================================================================
+
+func Test_DelayQueue_Without_Delivery_Time_Should_Publish_At_Once(t *testing.T) {
+	// Arrange
+	inner := &mockDispatcher{}
+	queue := newTestDelayQueue(t, inner, time.Now())
+
+	// Act
+	err := queue.Publish(context.Background(), messaging.NewMessage("reservation.created", nil))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "message must be published", len(inner.publishedMessages), 1)
+}
+
+func Test_DelayQueue_Should_Hold_Back_Message_Until_Due(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC().Truncate(time.Second)
+	inner := &mockDispatcher{}
+	queue := newTestDelayQueue(t, inner, now)
+	ctx := shared.WithDeliverAt(context.Background(), now.Add(time.Hour))
+	_ = queue.Publish(ctx, messaging.NewMessage("reservation.reminder", []byte(`{}`)))
+
+	// Act
+	early, _ := queue.Release(context.Background(), now.Add(time.Minute))
+	due, err := queue.Release(context.Background(), now.Add(time.Hour))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "message must not be released early", early, 0)
+	assert.That(t, "message must be released when due", due, 1)
+	assert.That(t, "message must be published", inner.publishedMessages[0].Topic, "reservation.reminder")
+}
+
+func Test_DelayQueue_Should_Release_Messages_In_Order_Of_Delivery_Time(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC().Truncate(time.Second)
+	inner := &mockDispatcher{}
+	queue := newTestDelayQueue(t, inner, now)
+	_ = queue.Publish(shared.WithDeliverAt(context.Background(), now.Add(2*time.Hour)), messaging.NewMessage("second", nil))
+	_ = queue.Publish(shared.WithDeliverAt(context.Background(), now.Add(time.Hour)), messaging.NewMessage("first", nil))
+
+	// Act
+	released, _ := queue.Release(context.Background(), now.Add(3*time.Hour))
+
+	// Assert
+	assert.That(t, "both messages must be released", released, 2)
+	assert.That(t, "earlier message must come first", inner.publishedMessages[0].Topic, "first")
+	assert.That(t, "later message must come second", inner.publishedMessages[1].Topic, "second")
+}
+
+func Test_DelayQueue_Should_Release_Message_With_Its_Context(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC().Truncate(time.Second)
+	inner := &mockDispatcher{}
+	queue := newTestDelayQueue(t, inner, now)
+	ctx := shared.WithCorrelationID(shared.WithPriority(shared.WithDeliverAt(context.Background(), now.Add(time.Hour))), "corr-001")
+	message := messaging.NewMessage("reservation.reminder", []byte(`{"reservation_id":"res-001"}`))
+	_ = queue.Publish(ctx, message)
+
+	// Act
+	_, err := queue.Release(context.Background(), now.Add(time.Hour))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "message must be kept whole", inner.publishedMessages[0], message)
+	assert.That(t, "priority must be restored", shared.Priority(inner.publishedContexts[0]), true)
+	assert.That(t, "correlation ID must be restored", shared.CorrelationID(inner.publishedContexts[0]), "corr-001")
+}
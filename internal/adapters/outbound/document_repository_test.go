@@ -0,0 +1,133 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoicing"
+)
+
+// ============================================================================
+// FileDocumentRepository Tests
+// ============================================================================
+
+func Test_FileDocumentRepository_Save_And_Load_Should_Round_Trip(t *testing.T) {
+	// Arrange
+	repo := outbound.NewFileDocumentRepository(t.TempDir())
+	ctx := context.Background()
+
+	// Act
+	err := repo.Save(ctx, "invoices/res-001", []byte("%PDF-1.4"))
+	data, loadErr := repo.Load(ctx, "invoices/res-001")
+
+	// Assert
+	assert.That(t, "save error must be nil", err, nil)
+	assert.That(t, "load error must be nil", loadErr, nil)
+	assert.That(t, "document must match", string(data), "%PDF-1.4")
+}
+
+func Test_FileDocumentRepository_Load_Unknown_Key_Should_Return_ErrDocumentNotFound(t *testing.T) {
+	// Arrange
+	repo := outbound.NewFileDocumentRepository(t.TempDir())
+
+	// Act
+	_, err := repo.Load(context.Background(), "invoices/missing")
+
+	// Assert
+	assert.That(t, "error must be ErrDocumentNotFound", errors.Is(err, invoicing.ErrDocumentNotFound), true)
+}
+
+func Test_FileDocumentRepository_Save_With_Path_Traversal_Should_Return_Error(t *testing.T) {
+	// Arrange
+	repo := outbound.NewFileDocumentRepository(t.TempDir())
+
+	// Act
+	err := repo.Save(context.Background(), "../escape", []byte("data"))
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+// ============================================================================
+// S3DocumentRepository Tests
+// ============================================================================
+
+// newS3Server emulates the PUT and GET object operations of a bucket.
+func newS3Server(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access-key/") ||
+			!strings.Contains(auth, "/eu-central-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=") ||
+			r.Header.Get("X-Amz-Date") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = data
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func Test_S3DocumentRepository_Save_And_Load_Should_Round_Trip(t *testing.T) {
+	// Arrange
+	srv := newS3Server(t)
+	repo := outbound.NewS3DocumentRepository(srv.URL, "eu-central-1", "documents", "access-key", "secret-key", srv.Client())
+	ctx := context.Background()
+
+	// Act
+	err := repo.Save(ctx, "invoices/res-001", []byte("%PDF-1.4"))
+	data, loadErr := repo.Load(ctx, "invoices/res-001")
+
+	// Assert
+	assert.That(t, "save error must be nil", err, nil)
+	assert.That(t, "load error must be nil", loadErr, nil)
+	assert.That(t, "document must match", string(data), "%PDF-1.4")
+}
+
+func Test_S3DocumentRepository_Load_Unknown_Key_Should_Return_ErrDocumentNotFound(t *testing.T) {
+	// Arrange
+	srv := newS3Server(t)
+	repo := outbound.NewS3DocumentRepository(srv.URL, "eu-central-1", "documents", "access-key", "secret-key", srv.Client())
+
+	// Act
+	_, err := repo.Load(context.Background(), "invoices/missing")
+
+	// Assert
+	assert.That(t, "error must be ErrDocumentNotFound", errors.Is(err, invoicing.ErrDocumentNotFound), true)
+}
+
+func Test_S3DocumentRepository_Save_When_Rejected_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv := newS3Server(t)
+	repo := outbound.NewS3DocumentRepository(srv.URL, "us-east-1", "documents", "access-key", "secret-key", srv.Client())
+
+	// Act
+	err := repo.Save(context.Background(), "invoices/res-001", []byte("%PDF-1.4"))
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
@@ -0,0 +1,63 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// EncryptedGuestProfileRepository decorates a GuestProfileRepository with
+// field-level encryption of the guest's name and phone number.
+// It implements the reservation.GuestProfileRepository port.
+type EncryptedGuestProfileRepository struct {
+	next      reservation.GuestProfileRepository
+	encryptor Encryptor
+}
+
+// NewEncryptedGuestProfileRepository creates a new encrypting guest profile repository.
+func NewEncryptedGuestProfileRepository(next reservation.GuestProfileRepository, encryptor Encryptor) *EncryptedGuestProfileRepository {
+	return &EncryptedGuestProfileRepository{
+		next:      next,
+		encryptor: encryptor,
+	}
+}
+
+// FindProfile loads a profile and decrypts its PII.
+func (r *EncryptedGuestProfileRepository) FindProfile(ctx context.Context, guestID reservation.GuestID) (*reservation.GuestProfile, error) {
+	profile, err := r.next.FindProfile(ctx, guestID)
+	if err != nil || profile == nil {
+		return profile, err
+	}
+	name, err := r.encryptor.Decrypt(ctx, profile.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt profile: %w", err)
+	}
+	phone, err := r.encryptor.Decrypt(ctx, string(profile.PhoneNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt profile: %w", err)
+	}
+	profile.Name = name
+	profile.PhoneNumber = reservation.PhoneNumber(phone)
+	return profile, nil
+}
+
+// SaveProfile encrypts the profile's PII and stores it.
+func (r *EncryptedGuestProfileRepository) SaveProfile(ctx context.Context, profile reservation.GuestProfile) error {
+	name, err := r.encryptor.Encrypt(ctx, profile.Name)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt profile: %w", err)
+	}
+	phone, err := r.encryptor.Encrypt(ctx, string(profile.PhoneNumber))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt profile: %w", err)
+	}
+	profile.Name = name
+	profile.PhoneNumber = reservation.PhoneNumber(phone)
+	return r.next.SaveProfile(ctx, profile)
+}
+
+// DeleteProfile removes the profile of a guest.
+func (r *EncryptedGuestProfileRepository) DeleteProfile(ctx context.Context, guestID reservation.GuestID) error {
+	return r.next.DeleteProfile(ctx, guestID)
+}
@@ -0,0 +1,96 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// EncryptedRegistrationRepository decorates a RegistrationRepository with
+// field-level encryption of the guest's identity document reference.
+// It implements the reservation.RegistrationRepository port.
+type EncryptedRegistrationRepository struct {
+	next      reservation.RegistrationRepository
+	encryptor Encryptor
+}
+
+// NewEncryptedRegistrationRepository creates a new encrypting registration repository.
+func NewEncryptedRegistrationRepository(next reservation.RegistrationRepository, encryptor Encryptor) *EncryptedRegistrationRepository {
+	return &EncryptedRegistrationRepository{
+		next:      next,
+		encryptor: encryptor,
+	}
+}
+
+// Create encrypts the document reference and stores the registration.
+func (r *EncryptedRegistrationRepository) Create(ctx context.Context, id reservation.ReservationID, reg reservation.Registration) error {
+	encrypted, err := r.encrypt(ctx, reg)
+	if err != nil {
+		return err
+	}
+	return r.next.Create(ctx, id, encrypted)
+}
+
+// Read loads a registration and decrypts the document reference.
+func (r *EncryptedRegistrationRepository) Read(ctx context.Context, id reservation.ReservationID) (*reservation.Registration, error) {
+	reg, err := r.next.Read(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := r.decrypt(ctx, *reg)
+	if err != nil {
+		return nil, err
+	}
+	return &decrypted, nil
+}
+
+// ReadAll loads all registrations and decrypts the document references.
+func (r *EncryptedRegistrationRepository) ReadAll(ctx context.Context) ([]reservation.Registration, error) {
+	registrations, err := r.next.ReadAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range registrations {
+		decrypted, err := r.decrypt(ctx, registrations[i])
+		if err != nil {
+			return nil, err
+		}
+		registrations[i] = decrypted
+	}
+	return registrations, nil
+}
+
+// Update encrypts the document reference and updates the registration.
+func (r *EncryptedRegistrationRepository) Update(ctx context.Context, id reservation.ReservationID, reg reservation.Registration) error {
+	encrypted, err := r.encrypt(ctx, reg)
+	if err != nil {
+		return err
+	}
+	return r.next.Update(ctx, id, encrypted)
+}
+
+// Delete removes a registration.
+func (r *EncryptedRegistrationRepository) Delete(ctx context.Context, id reservation.ReservationID) error {
+	return r.next.Delete(ctx, id)
+}
+
+// encrypt returns a copy of the registration with an encrypted document reference.
+func (r *EncryptedRegistrationRepository) encrypt(ctx context.Context, reg reservation.Registration) (reservation.Registration, error) {
+	ref, err := r.encryptor.Encrypt(ctx, reg.DocumentRef)
+	if err != nil {
+		return reg, fmt.Errorf("failed to encrypt registration: %w", err)
+	}
+	reg.DocumentRef = ref
+	return reg, nil
+}
+
+// decrypt returns a copy of the registration with a decrypted document reference.
+func (r *EncryptedRegistrationRepository) decrypt(ctx context.Context, reg reservation.Registration) (reservation.Registration, error) {
+	ref, err := r.encryptor.Decrypt(ctx, reg.DocumentRef)
+	if err != nil {
+		return reg, fmt.Errorf("failed to decrypt registration: %w", err)
+	}
+	reg.DocumentRef = ref
+	return reg, nil
+}
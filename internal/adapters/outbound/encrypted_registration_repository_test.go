@@ -0,0 +1,54 @@
+package outbound_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// EncryptedRegistrationRepository Tests
+// ============================================================================
+
+func Test_EncryptedRegistrationRepository_Create_Should_Store_Encrypted_Document_Ref(t *testing.T) {
+	// Arrange
+	inner := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Registration]()
+	repo := outbound.NewEncryptedRegistrationRepository(inner, newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1"))
+	ctx := context.Background()
+	reg := reservation.Registration{ReservationID: "res-001", DocumentType: reservation.DocumentPassport, DocumentRef: "C01X00T47"}
+
+	// Act
+	err := repo.Create(ctx, "res-001", reg)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	stored, _ := inner.Read(ctx, "res-001")
+	assert.That(t, "document reference must be encrypted", strings.HasPrefix(stored.DocumentRef, "enc:v1:k1:"), true)
+	assert.That(t, "caller value must not be modified", reg.DocumentRef, "C01X00T47")
+}
+
+func Test_EncryptedRegistrationRepository_Read_Should_Return_Decrypted_Document_Ref(t *testing.T) {
+	// Arrange
+	inner := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Registration]()
+	repo := outbound.NewEncryptedRegistrationRepository(inner, newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1"))
+	ctx := context.Background()
+	_ = repo.Create(ctx, "res-001", reservation.Registration{ReservationID: "res-001", DocumentRef: "C01X00T47"})
+	_ = inner.Create(ctx, "res-002", reservation.Registration{ReservationID: "res-002", DocumentRef: "L01X00T47"})
+
+	// Act
+	reg, err := repo.Read(ctx, "res-001")
+	all, allErr := repo.ReadAll(ctx)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "read all error must be nil", allErr == nil, true)
+	assert.That(t, "document reference must be decrypted", reg.DocumentRef, "C01X00T47")
+	assert.That(t, "both registrations must be returned", len(all), 2)
+	refs := all[0].DocumentRef + "," + all[1].DocumentRef
+	assert.That(t, "plaintext reference must stay readable", refs == "C01X00T47,L01X00T47" || refs == "L01X00T47,C01X00T47", true)
+}
@@ -0,0 +1,204 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// EncryptedReservationRepository decorates a ReservationRepository with
+// field-level encryption of guest PII (name, email, phone number).
+// The GuestID, which is the guest's email, is encrypted as a lookup key, so
+// FindByGuestID still finds the reservations of a guest by equality.
+// It implements the reservation.ReservationRepository port.
+type EncryptedReservationRepository struct {
+	next      reservation.ReservationRepository
+	encryptor Encryptor
+}
+
+// NewEncryptedReservationRepository creates a new encrypting reservation repository.
+func NewEncryptedReservationRepository(next reservation.ReservationRepository, encryptor Encryptor) *EncryptedReservationRepository {
+	return &EncryptedReservationRepository{
+		next:      next,
+		encryptor: encryptor,
+	}
+}
+
+// Create encrypts the guest PII and stores the reservation.
+func (r *EncryptedReservationRepository) Create(ctx context.Context, id reservation.ReservationID, res reservation.Reservation) error {
+	encrypted, err := r.encrypt(ctx, res)
+	if err != nil {
+		return err
+	}
+	return r.next.Create(ctx, id, encrypted)
+}
+
+// Read loads a reservation and decrypts the guest PII.
+func (r *EncryptedReservationRepository) Read(ctx context.Context, id reservation.ReservationID) (*reservation.Reservation, error) {
+	res, err := r.next.Read(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := r.decrypt(ctx, *res)
+	if err != nil {
+		return nil, err
+	}
+	return &decrypted, nil
+}
+
+// ReadAll loads all reservations and decrypts the guest PII.
+func (r *EncryptedReservationRepository) ReadAll(ctx context.Context) ([]reservation.Reservation, error) {
+	reservations, err := r.next.ReadAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.decryptAll(ctx, reservations)
+}
+
+// Update encrypts the guest PII and updates the reservation.
+func (r *EncryptedReservationRepository) Update(ctx context.Context, id reservation.ReservationID, res reservation.Reservation) error {
+	encrypted, err := r.encrypt(ctx, res)
+	if err != nil {
+		return err
+	}
+	return r.next.Update(ctx, id, encrypted)
+}
+
+// Delete removes a reservation.
+func (r *EncryptedReservationRepository) Delete(ctx context.Context, id reservation.ReservationID) error {
+	return r.next.Delete(ctx, id)
+}
+
+// FindByGuestID returns all reservations of the given guest with decrypted PII.
+// Reservations not re-encrypted yet are found under a rotated key or in plaintext.
+func (r *EncryptedReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	values, err := r.encryptor.LookupValues(ctx, string(guestID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt guest ID: %w", err)
+	}
+
+	var reservations []reservation.Reservation
+	for _, value := range values {
+		found, err := r.next.FindByGuestID(ctx, reservation.GuestID(value))
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, found...)
+	}
+	return r.decryptAll(ctx, reservations)
+}
+
+// ReEncrypt rewrites every reservation whose PII is stored in plaintext or
+// under a rotated key, using the active key. It returns the number of
+// reservations rewritten.
+func (r *EncryptedReservationRepository) ReEncrypt(ctx context.Context) (int, error) {
+	stored, err := r.next.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	count := 0
+	for _, res := range stored {
+		if !r.needsReEncryption(res) {
+			continue
+		}
+		decrypted, err := r.decrypt(ctx, res)
+		if err != nil {
+			return count, err
+		}
+		if err := r.Update(ctx, res.ID, decrypted); err != nil {
+			return count, fmt.Errorf("failed to update reservation %s: %w", res.ID, err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// encrypt returns a copy of the reservation with encrypted guest PII.
+func (r *EncryptedReservationRepository) encrypt(ctx context.Context, res reservation.Reservation) (reservation.Reservation, error) {
+	guestID, err := r.encryptor.EncryptLookup(ctx, string(res.GuestID))
+	if err != nil {
+		return res, fmt.Errorf("failed to encrypt guest ID: %w", err)
+	}
+	res.GuestID = reservation.GuestID(guestID)
+	return r.mapGuests(res, func(value string) (string, error) {
+		encrypted, err := r.encryptor.Encrypt(ctx, value)
+		if err != nil {
+			return "", fmt.Errorf("failed to encrypt guest data: %w", err)
+		}
+		return encrypted, nil
+	})
+}
+
+// decrypt returns a copy of the reservation with decrypted guest PII.
+func (r *EncryptedReservationRepository) decrypt(ctx context.Context, res reservation.Reservation) (reservation.Reservation, error) {
+	guestID, err := r.encryptor.Decrypt(ctx, string(res.GuestID))
+	if err != nil {
+		return res, fmt.Errorf("failed to decrypt guest ID: %w", err)
+	}
+	res.GuestID = reservation.GuestID(guestID)
+	return r.mapGuests(res, func(value string) (string, error) {
+		decrypted, err := r.encryptor.Decrypt(ctx, value)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt guest data: %w", err)
+		}
+		return decrypted, nil
+	})
+}
+
+// decryptAll decrypts the guest PII of a list of reservations.
+func (r *EncryptedReservationRepository) decryptAll(ctx context.Context, reservations []reservation.Reservation) ([]reservation.Reservation, error) {
+	for i := range reservations {
+		decrypted, err := r.decrypt(ctx, reservations[i])
+		if err != nil {
+			return nil, err
+		}
+		reservations[i] = decrypted
+	}
+	return reservations, nil
+}
+
+// needsReEncryption reports whether the guest ID or any guest field of the
+// stored reservation is plaintext or encrypted with a rotated key.
+func (r *EncryptedReservationRepository) needsReEncryption(res reservation.Reservation) bool {
+	if r.encryptor.NeedsReEncryption(string(res.GuestID)) {
+		return true
+	}
+	for _, guest := range res.Guests {
+		if r.encryptor.NeedsReEncryption(guest.Name) ||
+			r.encryptor.NeedsReEncryption(string(guest.Email)) ||
+			r.encryptor.NeedsReEncryption(string(guest.PhoneNumber)) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapGuests applies fn to every PII field of every guest.
+// The guests slice is copied so the caller's reservation is never modified.
+func (r *EncryptedReservationRepository) mapGuests(res reservation.Reservation, fn func(string) (string, error)) (reservation.Reservation, error) {
+	guests := make([]reservation.GuestInfo, len(res.Guests))
+	for i, guest := range res.Guests {
+		name, err := fn(guest.Name)
+		if err != nil {
+			return res, err
+		}
+		email, err := fn(string(guest.Email))
+		if err != nil {
+			return res, err
+		}
+		phone, err := fn(string(guest.PhoneNumber))
+		if err != nil {
+			return res, err
+		}
+		guests[i] = reservation.GuestInfo{
+			Name:        name,
+			Email:       reservation.Email(email),
+			PhoneNumber: reservation.PhoneNumber(phone),
+		}
+	}
+	res.Guests = guests
+	return res, nil
+}
@@ -0,0 +1,125 @@
+package outbound_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func testGuestReservation(id reservation.ReservationID) reservation.Reservation {
+	return reservation.Reservation{
+		ID:      id,
+		GuestID: "guest-001",
+		Guests: []reservation.GuestInfo{
+			{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+4915112345678"},
+		},
+	}
+}
+
+// ============================================================================
+// EncryptedReservationRepository Tests
+// ============================================================================
+
+func Test_EncryptedReservationRepository_Create_Should_Store_Encrypted_PII(t *testing.T) {
+	// Arrange
+	inner := outbound.NewReservationRepository(resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]())
+	repo := outbound.NewEncryptedReservationRepository(inner, newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1"))
+	ctx := context.Background()
+
+	// Act
+	err := repo.Create(ctx, "res-001", testGuestReservation("res-001"))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	stored, _ := inner.Read(ctx, "res-001")
+	assert.That(t, "name must be encrypted", strings.HasPrefix(stored.Guests[0].Name, "enc:v1:k1:"), true)
+	assert.That(t, "email must be encrypted", strings.HasPrefix(string(stored.Guests[0].Email), "enc:v1:k1:"), true)
+	assert.That(t, "phone must be encrypted", strings.HasPrefix(string(stored.Guests[0].PhoneNumber), "enc:v1:k1:"), true)
+	assert.That(t, "guest ID must be encrypted as lookup key", strings.HasPrefix(string(stored.GuestID), "enc:l1:k1:"), true)
+}
+
+func Test_EncryptedReservationRepository_Read_Should_Return_Decrypted_PII(t *testing.T) {
+	// Arrange
+	inner := outbound.NewReservationRepository(resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]())
+	repo := outbound.NewEncryptedReservationRepository(inner, newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1"))
+	ctx := context.Background()
+	_ = repo.Create(ctx, "res-001", testGuestReservation("res-001"))
+
+	// Act
+	res, err := repo.Read(ctx, "res-001")
+	byGuest, findErr := repo.FindByGuestID(ctx, "guest-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "find error must be nil", findErr == nil, true)
+	assert.That(t, "guest must be decrypted", res.Guests[0], testGuestReservation("res-001").Guests[0])
+	assert.That(t, "must find one reservation", len(byGuest), 1)
+	assert.That(t, "found guest must be decrypted", byGuest[0].Guests[0], testGuestReservation("res-001").Guests[0])
+	assert.That(t, "guest ID must be decrypted", res.GuestID, reservation.GuestID("guest-001"))
+}
+
+func Test_EncryptedReservationRepository_FindByGuestID_Should_Find_Plaintext_And_Rotated_Records(t *testing.T) {
+	// Arrange
+	inner := outbound.NewReservationRepository(resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]())
+	ctx := context.Background()
+	_ = inner.Create(ctx, "res-001", testGuestReservation("res-001"))
+	oldRepo := outbound.NewEncryptedReservationRepository(inner, newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1"))
+	_ = oldRepo.Create(ctx, "res-002", testGuestReservation("res-002"))
+	repo := outbound.NewEncryptedReservationRepository(inner, newTestEncryptor(t, map[string][]byte{"k1": testKey(1), "k2": testKey(2)}, "k2"))
+	_ = repo.Create(ctx, "res-003", testGuestReservation("res-003"))
+
+	// Act
+	byGuest, err := repo.FindByGuestID(ctx, "guest-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must find all three reservations", len(byGuest), 3)
+}
+
+func Test_EncryptedReservationRepository_Create_Should_Not_Modify_Caller_Value(t *testing.T) {
+	// Arrange
+	inner := outbound.NewReservationRepository(resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]())
+	repo := outbound.NewEncryptedReservationRepository(inner, newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1"))
+	res := testGuestReservation("res-001")
+
+	// Act
+	_ = repo.Create(context.Background(), "res-001", res)
+
+	// Assert
+	assert.That(t, "caller guest must stay plaintext", res.Guests[0].Name, "John Doe")
+}
+
+func Test_EncryptedReservationRepository_ReEncrypt_Should_Rewrite_Plaintext_And_Rotated_Records(t *testing.T) {
+	// Arrange
+	inner := outbound.NewReservationRepository(resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]())
+	ctx := context.Background()
+	_ = inner.Create(ctx, "res-001", testGuestReservation("res-001"))
+	oldRepo := outbound.NewEncryptedReservationRepository(inner, newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1"))
+	_ = oldRepo.Create(ctx, "res-002", testGuestReservation("res-002"))
+	rotated := newTestEncryptor(t, map[string][]byte{"k1": testKey(1), "k2": testKey(2)}, "k2")
+	repo := outbound.NewEncryptedReservationRepository(inner, rotated)
+	_ = repo.Create(ctx, "res-003", testGuestReservation("res-003"))
+
+	// Act
+	count, err := repo.ReEncrypt(ctx)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must rewrite two reservations", count, 2)
+	stored, _ := inner.ReadAll(ctx)
+	for _, res := range stored {
+		assert.That(t, "name must use active key", strings.HasPrefix(res.Guests[0].Name, "enc:v1:k2:"), true)
+		assert.That(t, "guest ID must use active key", strings.HasPrefix(string(res.GuestID), "enc:l1:k2:"), true)
+	}
+	decrypted, _ := repo.Read(ctx, "res-001")
+	assert.That(t, "guest must be decrypted", decrypted.Guests[0], testGuestReservation("res-001").Guests[0])
+}
@@ -0,0 +1,98 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// EnvBookingPolicies reads booking policies from environment variables.
+// BOOKING_MIN_NIGHTS, BOOKING_MAX_GUESTS, BOOKING_MAX_ADVANCE_DAYS and
+// BOOKING_BLACKOUT_DATES set the rules of all rooms. A room overrides a rule
+// with the room ID inserted, e.g. BOOKING_ROOM_101_MIN_NIGHTS for "room-101".
+// Blackout dates are comma-separated ranges like "2026-12-24/2026-12-27",
+// whose end date is the first bookable night.
+// It implements the reservation.BookingPolicies port.
+type EnvBookingPolicies struct {
+	prefix string
+}
+
+// NewEnvBookingPolicies creates a new environment based booking policy provider.
+func NewEnvBookingPolicies() *EnvBookingPolicies {
+	return &EnvBookingPolicies{prefix: "BOOKING_"}
+}
+
+// PolicyFor returns the policy of the room. Unset rules are disabled;
+// unparsable values return an error, so misconfigured rules are not silently ignored.
+func (p *EnvBookingPolicies) PolicyFor(_ context.Context, roomID reservation.RoomID) (reservation.BookingPolicy, error) {
+	var policy reservation.BookingPolicy
+	var err error
+
+	if policy.MinNights, err = p.lookupInt(roomID, "MIN_NIGHTS"); err != nil {
+		return reservation.BookingPolicy{}, err
+	}
+	if policy.MaxGuests, err = p.lookupInt(roomID, "MAX_GUESTS"); err != nil {
+		return reservation.BookingPolicy{}, err
+	}
+	if policy.MaxAdvanceDays, err = p.lookupInt(roomID, "MAX_ADVANCE_DAYS"); err != nil {
+		return reservation.BookingPolicy{}, err
+	}
+	if policy.BlackoutDates, err = p.lookupDateRanges(roomID, "BLACKOUT_DATES"); err != nil {
+		return reservation.BookingPolicy{}, err
+	}
+	return policy, nil
+}
+
+// lookup returns the room's value of a rule, falling back to the value for all rooms.
+func (p *EnvBookingPolicies) lookup(roomID reservation.RoomID, rule string) (name, value string, ok bool) {
+	room := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(string(roomID)))
+	for _, name := range []string{p.prefix + room + "_" + rule, p.prefix + rule} {
+		if value, ok := os.LookupEnv(name); ok {
+			return name, strings.TrimSpace(value), true
+		}
+	}
+	return "", "", false
+}
+
+// lookupInt returns the rule as a non-negative integer, or 0 if it is unset.
+func (p *EnvBookingPolicies) lookupInt(roomID reservation.RoomID, rule string) (int, error) {
+	name, value, ok := p.lookup(roomID, rule)
+	if !ok || value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q: expected a non-negative integer", name, value)
+	}
+	return n, nil
+}
+
+// lookupDateRanges returns the rule as a list of date ranges, or nil if it is unset.
+func (p *EnvBookingPolicies) lookupDateRanges(roomID reservation.RoomID, rule string) ([]reservation.DateRange, error) {
+	name, value, ok := p.lookup(roomID, rule)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var ranges []reservation.DateRange
+	for _, part := range strings.Split(value, ",") {
+		start, end, found := strings.Cut(strings.TrimSpace(part), "/")
+		if !found {
+			return nil, fmt.Errorf("invalid %s %q: expected ranges like 2026-12-24/2026-12-27", name, value)
+		}
+		from, err := time.Parse(time.DateOnly, start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", name, value, err)
+		}
+		to, err := time.Parse(time.DateOnly, end)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", name, value, err)
+		}
+		ranges = append(ranges, reservation.NewDateRange(from, to))
+	}
+	return ranges, nil
+}
@@ -0,0 +1,76 @@
+package outbound_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// EnvBookingPolicies Tests
+// ============================================================================
+
+func Test_EnvBookingPolicies_PolicyFor_With_Variables_Unset_Should_Disable_Rules(t *testing.T) {
+	// Arrange
+	policies := outbound.NewEnvBookingPolicies()
+
+	// Act
+	policy, err := policies.PolicyFor(context.Background(), "room-101")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "policy must be empty", policy.MinNights == 0 && policy.MaxGuests == 0 && policy.BlackoutDates == nil, true)
+}
+
+func Test_EnvBookingPolicies_PolicyFor_With_Room_Override_Should_Prefer_Room_Value(t *testing.T) {
+	// Arrange
+	t.Setenv("BOOKING_MIN_NIGHTS", "2")
+	t.Setenv("BOOKING_ROOM_101_MIN_NIGHTS", "4")
+	t.Setenv("BOOKING_MAX_GUESTS", "3")
+	policies := outbound.NewEnvBookingPolicies()
+
+	// Act
+	room101, err101 := policies.PolicyFor(context.Background(), "room-101")
+	room102, err102 := policies.PolicyFor(context.Background(), "room-102")
+
+	// Assert
+	assert.That(t, "errors must be nil", err101 == nil && err102 == nil, true)
+	assert.That(t, "room override must be used", room101.MinNights, 4)
+	assert.That(t, "global value must be used", room102.MinNights, 2)
+	assert.That(t, "global rule must apply to overridden room", room101.MaxGuests, 3)
+}
+
+func Test_EnvBookingPolicies_PolicyFor_With_Blackout_Dates_Should_Parse_Ranges(t *testing.T) {
+	// Arrange
+	start := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 60)
+	end := start.AddDate(0, 0, 3)
+	later := end.AddDate(0, 0, 5)
+	t.Setenv("BOOKING_BLACKOUT_DATES", fmt.Sprintf("%s/%s, %s/%s",
+		start.Format(time.DateOnly), end.Format(time.DateOnly), later.Format(time.DateOnly), later.AddDate(0, 0, 1).Format(time.DateOnly)))
+	policies := outbound.NewEnvBookingPolicies()
+
+	// Act
+	policy, err := policies.PolicyFor(context.Background(), "room-101")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "two ranges must be parsed", len(policy.BlackoutDates), 2)
+	assert.That(t, "first range must match", policy.BlackoutDates[0], reservation.NewDateRange(start, end))
+}
+
+func Test_EnvBookingPolicies_PolicyFor_With_Invalid_Value_Should_Return_Error(t *testing.T) {
+	// Arrange
+	t.Setenv("BOOKING_MAX_ADVANCE_DAYS", "a year")
+	policies := outbound.NewEnvBookingPolicies()
+
+	// Act
+	_, err := policies.PolicyFor(context.Background(), "room-101")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
@@ -0,0 +1,42 @@
+package outbound
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// EnvFeatureFlags evaluates feature flags from environment variables.
+// The flag "synchronous-saga" is read from FEATURE_SYNCHRONOUS_SAGA.
+// It implements the shared.FeatureFlags port.
+type EnvFeatureFlags struct {
+	prefix string
+}
+
+// NewEnvFeatureFlags creates a new environment based feature flag provider.
+func NewEnvFeatureFlags() *EnvFeatureFlags {
+	return &EnvFeatureFlags{prefix: "FEATURE_"}
+}
+
+// IsEnabled returns the boolean value of the flag's environment variable.
+// Unset or unparsable values return defaultValue.
+func (f *EnvFeatureFlags) IsEnabled(_ context.Context, flag shared.FeatureFlag, defaultValue bool) bool {
+	value, ok := os.LookupEnv(f.variable(flag))
+	if !ok {
+		return defaultValue
+	}
+	enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return defaultValue
+	}
+	return enabled
+}
+
+// variable returns the environment variable name of a flag.
+func (f *EnvFeatureFlags) variable(flag shared.FeatureFlag) string {
+	name := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(string(flag)))
+	return f.prefix + name
+}
@@ -0,0 +1,49 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// EnvFeatureFlags Tests
+// ============================================================================
+
+func Test_EnvFeatureFlags_IsEnabled_With_Variable_Set_Should_Return_Value(t *testing.T) {
+	// Arrange
+	t.Setenv("FEATURE_SYNCHRONOUS_SAGA", "true")
+	flags := outbound.NewEnvFeatureFlags()
+
+	// Act
+	enabled := flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, false)
+
+	// Assert
+	assert.That(t, "flag must be enabled", enabled, true)
+}
+
+func Test_EnvFeatureFlags_IsEnabled_With_Variable_Unset_Should_Return_Default(t *testing.T) {
+	// Arrange
+	flags := outbound.NewEnvFeatureFlags()
+
+	// Act
+	enabled := flags.IsEnabled(context.Background(), shared.FeatureFlag("unknown-feature"), true)
+
+	// Assert
+	assert.That(t, "default must be returned", enabled, true)
+}
+
+func Test_EnvFeatureFlags_IsEnabled_With_Invalid_Value_Should_Return_Default(t *testing.T) {
+	// Arrange
+	t.Setenv("FEATURE_SYNCHRONOUS_SAGA", "maybe")
+	flags := outbound.NewEnvFeatureFlags()
+
+	// Act
+	enabled := flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, false)
+
+	// Assert
+	assert.That(t, "default must be returned", enabled, false)
+}
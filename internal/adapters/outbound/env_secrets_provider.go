@@ -0,0 +1,25 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// EnvSecretsProvider reads secrets from environment variables of the same name.
+// It implements the SecretsProvider port.
+type EnvSecretsProvider struct{}
+
+// NewEnvSecretsProvider creates a new environment based secrets provider.
+func NewEnvSecretsProvider() *EnvSecretsProvider {
+	return &EnvSecretsProvider{}
+}
+
+// GetSecret returns the value of the environment variable with the given name.
+func (p *EnvSecretsProvider) GetSecret(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return value, nil
+}
@@ -1,11 +1,13 @@
 package outbound
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 
 	"github.com/andygeiss/cloud-native-utils/event"
 	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
 // This file contains the implementation of the EventPublisher.
@@ -14,24 +16,39 @@ import (
 
 // EventPublisher represents an event publisher.
 type EventPublisher struct {
+	codec      Codec
 	dispatcher messaging.Dispatcher
 }
 
 // NewEventPublisher creates a new event publisher.
+// Events are encoded with JSONCodec until WithCodec is set.
 func NewEventPublisher(dispatcher messaging.Dispatcher) *EventPublisher {
 	return &EventPublisher{
+		codec:      JSONCodec{},
 		dispatcher: dispatcher,
 	}
 }
 
+// WithCodec sets the codec events are encoded with.
+func (ep *EventPublisher) WithCodec(codec Codec) *EventPublisher {
+	ep.codec = codec
+	return ep
+}
+
 // Publish publishes an event.
+// The correlation ID of the context is added to the payload as "correlation_id".
 func (ep *EventPublisher) Publish(ctx context.Context, e event.Event) error {
 	// Encode the event to JSON.
-	encoded, err := json.Marshal(e)
+	encoded, err := ep.codec.Marshal(e)
 	if err != nil {
 		return err
 	}
 
+	// Tie the event to the request or event that caused it.
+	if id := shared.CorrelationID(ctx); id != "" {
+		encoded = withCorrelationID(encoded, id)
+	}
+
 	// Create a new message with the encoded event.
 	msg := messaging.NewMessage(e.Topic(), encoded)
 
@@ -41,3 +58,22 @@ func (ep *EventPublisher) Publish(ctx context.Context, e event.Event) error {
 	}
 	return nil
 }
+
+// withCorrelationID adds the correlation ID as the first field of an encoded event.
+// Events that are not encoded as JSON objects are left unchanged.
+func withCorrelationID(encoded []byte, id string) []byte {
+	rest := bytes.TrimSpace(encoded)
+	if len(rest) < 2 || rest[0] != '{' {
+		return encoded
+	}
+	rest = bytes.TrimSpace(rest[1:])
+	value, _ := json.Marshal(id)
+
+	out := make([]byte, 0, len(encoded)+len(value)+20)
+	out = append(out, `{"correlation_id":`...)
+	out = append(out, value...)
+	if rest[0] != '}' {
+		out = append(out, ',')
+	}
+	return append(out, rest...)
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/messaging"
 	"github.com/andygeiss/cloud-native-utils/service"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
 // ============================================================================
@@ -20,6 +22,7 @@ type mockDispatcher struct {
 	publishErr        error
 	subscribeErr      error
 	publishedMessages []messaging.Message
+	publishedContexts []context.Context
 }
 
 func (m *mockDispatcher) Publish(ctx context.Context, msg messaging.Message) error {
@@ -27,6 +30,7 @@ func (m *mockDispatcher) Publish(ctx context.Context, msg messaging.Message) err
 		return m.publishErr
 	}
 	m.publishedMessages = append(m.publishedMessages, msg)
+	m.publishedContexts = append(m.publishedContexts, ctx)
 	return nil
 }
 
@@ -68,6 +72,27 @@ func Test_EventPublisher_Publish_Should_Succeed(t *testing.T) {
 	assert.That(t, "data must match", decoded.Data, "test data")
 }
 
+func Test_EventPublisher_Publish_With_Correlation_ID_Should_Add_It_To_Payload(t *testing.T) {
+	// Arrange
+	dispatcher := &mockDispatcher{}
+	publisher := outbound.NewEventPublisher(dispatcher)
+	ctx := shared.WithCorrelationID(context.Background(), "req-123")
+
+	// Act
+	err := publisher.Publish(ctx, &testEvent{EventTopic: "test.topic", Data: "test data"})
+
+	// Assert
+	var decoded struct {
+		CorrelationID string `json:"correlation_id"`
+		Data          string `json:"data"`
+	}
+	unmarshalErr := json.Unmarshal(dispatcher.publishedMessages[0].Data, &decoded)
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "unmarshal must succeed", unmarshalErr == nil, true)
+	assert.That(t, "correlation ID must be added", decoded.CorrelationID, "req-123")
+	assert.That(t, "data must be kept", decoded.Data, "test data")
+}
+
 func Test_EventPublisher_Publish_Dispatcher_Error_Should_Return_Error(t *testing.T) {
 	// Arrange
 	dispatcher := &mockDispatcher{
@@ -124,3 +149,28 @@ func Test_EventPublisher_Publish_Different_Topics_Should_Use_Correct_Topic(t *te
 	assert.That(t, "first message topic must match", dispatcher.publishedMessages[0].Topic, "reservation.created")
 	assert.That(t, "second message topic must match", dispatcher.publishedMessages[1].Topic, "payment.authorized")
 }
+
+func Test_EventPublisher_WithCodec_Should_Encode_With_Codec(t *testing.T) {
+	// Arrange
+	dispatcher := &mockDispatcher{}
+	publisher := outbound.NewEventPublisher(dispatcher).WithCodec(upperCodec{})
+	ctx := context.Background()
+
+	// Act
+	err := publisher.Publish(ctx, &testEvent{EventTopic: "test.topic", Data: "test data"})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "data must be encoded by the codec", string(dispatcher.publishedMessages[0].Data), "TEST.TOPIC")
+}
+
+// upperCodec encodes the topic of test events in upper case.
+type upperCodec struct{}
+
+func (upperCodec) Marshal(v any) ([]byte, error) {
+	return []byte(strings.ToUpper(v.(*testEvent).EventTopic)), nil
+}
+
+func (upperCodec) Unmarshal(data []byte, v any) error {
+	return nil
+}
@@ -0,0 +1,157 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// FileAccess stores resources as a map in a single file, like JsonFileAccess from
+// cloud-native-utils, but encodes them with a Codec. It is used for the small
+// file repositories, e.g. the compensation queue and the saga states.
+// It implements the resource.Access interface.
+type FileAccess[K comparable, V any] struct {
+	path  string
+	codec Codec
+	mutex sync.RWMutex
+}
+
+// NewFileAccess creates a new file access for the file at path.
+func NewFileAccess[K comparable, V any](path string, codec Codec) *FileAccess[K, V] {
+	return &FileAccess[K, V]{path: path, codec: codec}
+}
+
+// Create creates a new resource.
+func (a *FileAccess[K, V]) Create(ctx context.Context, key K, value V) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	data, err := a.load()
+	if err != nil {
+		return err
+	}
+	if _, exists := data[key]; exists {
+		return errors.New(resource.ErrorResourceAlreadyExists)
+	}
+	data[key] = value
+	return a.save(data)
+}
+
+// Read reads a resource.
+func (a *FileAccess[K, V]) Read(ctx context.Context, key K) (*V, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	data, err := a.load()
+	if err != nil {
+		return nil, err
+	}
+	value, exists := data[key]
+	if !exists {
+		return nil, errors.New(resource.ErrorResourceNotFound)
+	}
+	return &value, nil
+}
+
+// ReadAll reads all resources.
+func (a *FileAccess[K, V]) ReadAll(ctx context.Context) ([]V, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	data, err := a.load()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]V, 0, len(data))
+	for _, value := range data {
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Update updates a resource.
+func (a *FileAccess[K, V]) Update(ctx context.Context, key K, value V) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	data, err := a.load()
+	if err != nil {
+		return err
+	}
+	if _, exists := data[key]; !exists {
+		return errors.New(resource.ErrorResourceNotFound)
+	}
+	data[key] = value
+	return a.save(data)
+}
+
+// Delete deletes a resource.
+func (a *FileAccess[K, V]) Delete(ctx context.Context, key K) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	data, err := a.load()
+	if err != nil {
+		return err
+	}
+	if _, exists := data[key]; !exists {
+		return errors.New(resource.ErrorResourceNotFound)
+	}
+	delete(data, key)
+	return a.save(data)
+}
+
+// load reads the resources from the file. A missing file holds no resources.
+func (a *FileAccess[K, V]) load() (map[K]V, error) {
+	values := make(map[K]V)
+	encoded, err := os.ReadFile(a.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", a.path, err)
+	}
+	if err := a.codec.Unmarshal(encoded, &values); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", a.path, err)
+	}
+	if values == nil {
+		values = make(map[K]V)
+	}
+	return values, nil
+}
+
+// save writes the resources to the file.
+func (a *FileAccess[K, V]) save(values map[K]V) error {
+	encoded, err := a.codec.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", a.path, err)
+	}
+	if err := os.WriteFile(a.path, encoded, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", a.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package outbound_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// FileAccess Tests
+// ============================================================================
+
+func Test_FileAccess_Create_Read_Should_Return_Value(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "access.json")
+	access := outbound.NewFileAccess[string, testEvent](path, outbound.JSONCodec{})
+	ctx := context.Background()
+	event := testEvent{EventTopic: "test.topic", Data: "test data"}
+
+	// Act
+	err := access.Create(ctx, "evt-001", event)
+	got, readErr := access.Read(ctx, "evt-001")
+
+	// Assert
+	assert.That(t, "create error must be nil", err == nil, true)
+	assert.That(t, "read error must be nil", readErr == nil, true)
+	assert.That(t, "value must match", *got, event)
+}
+
+func Test_FileAccess_Create_Twice_Should_Return_Already_Exists(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "access.json")
+	access := outbound.NewFileAccess[string, testEvent](path, outbound.JSONCodec{})
+	ctx := context.Background()
+	_ = access.Create(ctx, "evt-001", testEvent{})
+
+	// Act
+	err := access.Create(ctx, "evt-001", testEvent{})
+
+	// Assert
+	assert.That(t, "error must be already exists", err.Error(), resource.ErrorResourceAlreadyExists)
+}
+
+func Test_FileAccess_ReadAll_Without_File_Should_Return_Empty(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "missing.json")
+	access := outbound.NewFileAccess[string, testEvent](path, outbound.JSONCodec{})
+
+	// Act
+	values, err := access.ReadAll(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "values must be empty", len(values), 0)
+}
+
+func Test_FileAccess_Update_Delete_Should_Change_File(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "access.json")
+	access := outbound.NewFileAccess[string, testEvent](path, outbound.JSONCodec{})
+	ctx := context.Background()
+	_ = access.Create(ctx, "evt-001", testEvent{Data: "old"})
+	_ = access.Create(ctx, "evt-002", testEvent{Data: "other"})
+
+	// Act
+	updateErr := access.Update(ctx, "evt-001", testEvent{Data: "new"})
+	deleteErr := access.Delete(ctx, "evt-002")
+
+	// Assert
+	assert.That(t, "update error must be nil", updateErr == nil, true)
+	assert.That(t, "delete error must be nil", deleteErr == nil, true)
+	reopened := outbound.NewFileAccess[string, testEvent](path, outbound.JSONCodec{})
+	values, _ := reopened.ReadAll(ctx)
+	assert.That(t, "one value must remain", len(values), 1)
+	assert.That(t, "value must be updated", values[0].Data, "new")
+	_, statErr := os.Stat(path)
+	assert.That(t, "file must exist", statErr == nil, true)
+}
@@ -0,0 +1,77 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/invoicing"
+)
+
+// FileDocumentRepository stores documents as files below a directory.
+// Keys are relative paths, e.g. "invoices/res-001".
+// It implements the invoicing.DocumentRepository port.
+type FileDocumentRepository struct {
+	dir string
+}
+
+// NewFileDocumentRepository creates a new file based document repository.
+func NewFileDocumentRepository(dir string) *FileDocumentRepository {
+	return &FileDocumentRepository{dir: dir}
+}
+
+// Save writes the document to a temporary file and renames it,
+// so readers never see a partially written document.
+func (r *FileDocumentRepository) Save(_ context.Context, key string, data []byte) error {
+	path, err := r.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create document directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create document file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write document: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write document: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store document: %w", err)
+	}
+	return nil
+}
+
+// Load reads the document stored under the key.
+func (r *FileDocumentRepository) Load(_ context.Context, key string) ([]byte, error) {
+	path, err := r.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", invoicing.ErrDocumentNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document: %w", err)
+	}
+	return data, nil
+}
+
+// path maps a key to a file below the directory and rejects keys that would escape it.
+func (r *FileDocumentRepository) path(key string) (string, error) {
+	if !filepath.IsLocal(key) {
+		return "", fmt.Errorf("invalid document key: %q", key)
+	}
+	return filepath.Join(r.dir, key), nil
+}
@@ -0,0 +1,40 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileSecretsProvider reads secrets from one file per secret in a directory,
+// as mounted by Docker secrets (/run/secrets) or Kubernetes secret volumes.
+// It implements the SecretsProvider port.
+type FileSecretsProvider struct {
+	dir string
+}
+
+// NewFileSecretsProvider creates a new file based secrets provider.
+func NewFileSecretsProvider(dir string) *FileSecretsProvider {
+	return &FileSecretsProvider{dir: dir}
+}
+
+// GetSecret returns the content of the file with the given name.
+// A single trailing newline is removed because most tooling writes one.
+func (p *FileSecretsProvider) GetSecret(_ context.Context, name string) (string, error) {
+	if name == "" || name != filepath.Base(name) {
+		return "", fmt.Errorf("%w: %q", ErrSecretNotFound, name)
+	}
+	data, err := os.ReadFile(filepath.Join(p.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	value := strings.TrimSuffix(string(data), "\n")
+	return strings.TrimSuffix(value, "\r"), nil
+}
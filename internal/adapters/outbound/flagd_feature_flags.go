@@ -0,0 +1,116 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// FlagdFeatureFlags evaluates feature flags remotely using the OpenFeature
+// Remote Evaluation Protocol (OFREP), which is served by flagd on port 8016.
+// Evaluations are cached, so a flag consulted on every event does not cost a request each time.
+// It implements the shared.FeatureFlags port.
+type FlagdFeatureFlags struct {
+	baseURL string
+	client  *http.Client
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[shared.FeatureFlag]flagEntry
+}
+
+// flagEntry is a cached evaluation with its expiry. Unknown flags are cached as
+// well, so an unreachable provider is not asked again until the entry expires.
+type flagEntry struct {
+	enabled   bool
+	known     bool
+	expiresAt time.Time
+}
+
+// NewFlagdFeatureFlags creates a new flagd feature flag provider.
+// Evaluations are cached for 10 seconds by default.
+func NewFlagdFeatureFlags(baseURL string, client *http.Client) *FlagdFeatureFlags {
+	return &FlagdFeatureFlags{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+		ttl:     10 * time.Second,
+		entries: make(map[shared.FeatureFlag]flagEntry),
+	}
+}
+
+// WithCacheTTL sets how long an evaluation is cached (default 10s). Zero disables the cache.
+func (f *FlagdFeatureFlags) WithCacheTTL(ttl time.Duration) *FlagdFeatureFlags {
+	f.ttl = ttl
+	return f
+}
+
+// ofrepRequest is the body of an OFREP single flag evaluation.
+type ofrepRequest struct {
+	Context map[string]any `json:"context"`
+}
+
+// ofrepResponse is the successful result of an OFREP single flag evaluation.
+type ofrepResponse struct {
+	Value any `json:"value"`
+}
+
+// IsEnabled returns the cached evaluation of the flag or evaluates it with flagd.
+// Unreachable providers, unknown flags and non-boolean values return defaultValue.
+func (f *FlagdFeatureFlags) IsEnabled(ctx context.Context, flag shared.FeatureFlag, defaultValue bool) bool {
+	now := time.Now()
+	f.mu.Lock()
+	entry, ok := f.entries[flag]
+	f.mu.Unlock()
+	if !ok || !now.Before(entry.expiresAt) {
+		entry.enabled, entry.known = f.evaluate(ctx, flag)
+		entry.expiresAt = now.Add(f.ttl)
+		if f.ttl > 0 && ctx.Err() == nil {
+			f.mu.Lock()
+			f.entries[flag] = entry
+			f.mu.Unlock()
+		}
+	}
+	if !entry.known {
+		return defaultValue
+	}
+	return entry.enabled
+}
+
+// evaluate asks flagd for the value of the flag. It reports false for unknown
+// flags, non-boolean values and unreachable providers.
+func (f *FlagdFeatureFlags) evaluate(ctx context.Context, flag shared.FeatureFlag) (enabled, known bool) {
+	body, err := json.Marshal(ofrepRequest{Context: map[string]any{}})
+	if err != nil {
+		return false, false
+	}
+
+	endpoint := f.baseURL + "/ofrep/v1/evaluate/flags/" + url.PathEscape(string(flag))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, false
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return false, false
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, false
+	}
+
+	var result ofrepResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, false
+	}
+	enabled, known = result.Value.(bool)
+	return enabled, known
+}
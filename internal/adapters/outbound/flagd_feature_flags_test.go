@@ -0,0 +1,119 @@
+package outbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// FlagdFeatureFlags Tests
+// ============================================================================
+
+func newOfrepServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/ofrep/v1/evaluate/flags/synchronous-saga" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func Test_FlagdFeatureFlags_IsEnabled_With_Boolean_Flag_Should_Return_Value(t *testing.T) {
+	// Arrange
+	srv := newOfrepServer(t, http.StatusOK, `{"key":"synchronous-saga","value":true,"reason":"STATIC","variant":"on"}`)
+	flags := outbound.NewFlagdFeatureFlags(srv.URL, srv.Client())
+
+	// Act
+	enabled := flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, false)
+
+	// Assert
+	assert.That(t, "flag must be enabled", enabled, true)
+}
+
+func Test_FlagdFeatureFlags_IsEnabled_With_Unknown_Flag_Should_Return_Default(t *testing.T) {
+	// Arrange
+	srv := newOfrepServer(t, http.StatusNotFound, `{"key":"synchronous-saga","errorCode":"FLAG_NOT_FOUND"}`)
+	flags := outbound.NewFlagdFeatureFlags(srv.URL, srv.Client())
+
+	// Act
+	enabled := flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, true)
+
+	// Assert
+	assert.That(t, "default must be returned", enabled, true)
+}
+
+func Test_FlagdFeatureFlags_IsEnabled_With_Non_Boolean_Value_Should_Return_Default(t *testing.T) {
+	// Arrange
+	srv := newOfrepServer(t, http.StatusOK, `{"key":"synchronous-saga","value":"on"}`)
+	flags := outbound.NewFlagdFeatureFlags(srv.URL, srv.Client())
+
+	// Act
+	enabled := flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, false)
+
+	// Assert
+	assert.That(t, "default must be returned", enabled, false)
+}
+
+func Test_FlagdFeatureFlags_IsEnabled_With_Unreachable_Provider_Should_Return_Default(t *testing.T) {
+	// Arrange
+	srv := newOfrepServer(t, http.StatusOK, `{"value":true}`)
+	srv.Close()
+	flags := outbound.NewFlagdFeatureFlags(srv.URL, http.DefaultClient)
+
+	// Act
+	enabled := flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, false)
+
+	// Assert
+	assert.That(t, "default must be returned", enabled, false)
+}
+
+func Test_FlagdFeatureFlags_IsEnabled_Twice_Should_Evaluate_Once(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"key":"synchronous-saga","value":true}`))
+	}))
+	t.Cleanup(srv.Close)
+	flags := outbound.NewFlagdFeatureFlags(srv.URL, srv.Client())
+
+	// Act
+	first := flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, false)
+	second := flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, false)
+
+	// Assert
+	assert.That(t, "first evaluation must be true", first, true)
+	assert.That(t, "cached evaluation must be true", second, true)
+	assert.That(t, "flagd must be asked once", calls.Load(), int32(1))
+}
+
+func Test_FlagdFeatureFlags_IsEnabled_Without_Cache_Should_Evaluate_Every_Time(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"key":"synchronous-saga","value":true}`))
+	}))
+	t.Cleanup(srv.Close)
+	flags := outbound.NewFlagdFeatureFlags(srv.URL, srv.Client()).WithCacheTTL(0)
+
+	// Act
+	_ = flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, false)
+	_ = flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, false)
+
+	// Assert
+	assert.That(t, "flagd must be asked every time", calls.Load(), int32(2))
+}
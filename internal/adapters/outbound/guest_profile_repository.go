@@ -0,0 +1,53 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// GuestProfileRepository stores guest profiles in any key/value store,
+// such as InMemoryAccess or JsonFileAccess from cloud-native-utils.
+// It implements the reservation.GuestProfileRepository port.
+type GuestProfileRepository struct {
+	access resource.Access[reservation.GuestID, reservation.GuestProfile]
+}
+
+// NewGuestProfileRepository creates a new guest profile repository backed by the given access.
+func NewGuestProfileRepository(access resource.Access[reservation.GuestID, reservation.GuestProfile]) *GuestProfileRepository {
+	return &GuestProfileRepository{access: access}
+}
+
+// FindProfile returns the profile of the given guest, or nil if none was saved.
+func (r *GuestProfileRepository) FindProfile(ctx context.Context, guestID reservation.GuestID) (*reservation.GuestProfile, error) {
+	profile, err := r.access.Read(ctx, guestID)
+	if err != nil {
+		if err.Error() == resource.ErrorResourceNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+	return profile, nil
+}
+
+// SaveProfile creates or replaces the profile of a guest.
+func (r *GuestProfileRepository) SaveProfile(ctx context.Context, profile reservation.GuestProfile) error {
+	existing, err := r.FindProfile(ctx, profile.GuestID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return r.access.Create(ctx, profile.GuestID, profile)
+	}
+	return r.access.Update(ctx, profile.GuestID, profile)
+}
+
+// DeleteProfile removes the profile of a guest if it exists.
+func (r *GuestProfileRepository) DeleteProfile(ctx context.Context, guestID reservation.GuestID) error {
+	if err := r.access.Delete(ctx, guestID); err != nil && err.Error() != resource.ErrorResourceNotFound {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package outbound_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// GuestProfileRepository Tests
+// ============================================================================
+
+func Test_GuestProfileRepository_FindProfile_When_Missing_Should_Return_Nil(t *testing.T) {
+	// Arrange
+	repo := outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]())
+
+	// Act
+	profile, err := repo.FindProfile(context.Background(), "john@example.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "profile must be nil", profile == nil, true)
+}
+
+func Test_GuestProfileRepository_SaveProfile_Should_Create_And_Replace(t *testing.T) {
+	// Arrange
+	repo := outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]())
+	ctx := context.Background()
+	_ = repo.SaveProfile(ctx, reservation.GuestProfile{GuestID: "john@example.com", Name: "John"})
+
+	// Act
+	err := repo.SaveProfile(ctx, reservation.GuestProfile{GuestID: "john@example.com", Name: "John Doe"})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	profile, _ := repo.FindProfile(ctx, "john@example.com")
+	assert.That(t, "name must be replaced", profile.Name, "John Doe")
+}
+
+func Test_GuestProfileRepository_DeleteProfile_When_Missing_Should_Succeed(t *testing.T) {
+	// Arrange
+	repo := outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]())
+
+	// Act
+	err := repo.DeleteProfile(context.Background(), "john@example.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+}
+
+// ============================================================================
+// EncryptedGuestProfileRepository Tests
+// ============================================================================
+
+func Test_EncryptedGuestProfileRepository_Should_Encrypt_At_Rest_And_Decrypt_On_Read(t *testing.T) {
+	// Arrange
+	inner := outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]())
+	repo := outbound.NewEncryptedGuestProfileRepository(inner, newTestEncryptor(t, map[string][]byte{"k1": testKey(1)}, "k1"))
+	ctx := context.Background()
+	profile := reservation.GuestProfile{GuestID: "john@example.com", Name: "John Doe", PhoneNumber: "+15551234567"}
+
+	// Act
+	err := repo.SaveProfile(ctx, profile)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	stored, _ := inner.FindProfile(ctx, "john@example.com")
+	assert.That(t, "name must be encrypted", strings.HasPrefix(stored.Name, "enc:v1:k1:"), true)
+	assert.That(t, "phone must be encrypted", strings.HasPrefix(string(stored.PhoneNumber), "enc:v1:k1:"), true)
+	decrypted, _ := repo.FindProfile(ctx, "john@example.com")
+	assert.That(t, "profile must be decrypted", *decrypted, profile)
+}
@@ -0,0 +1,61 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/calendar"
+	"github.com/andygeiss/hotel-booking/internal/ical"
+)
+
+// maxFeedBytes limits the size of a downloaded calendar feed.
+const maxFeedBytes = 5 << 20
+
+// ICalFeedFetcher downloads iCal feeds over HTTP, e.g. the export links of Airbnb listings.
+// It implements the calendar.FeedFetcher port.
+type ICalFeedFetcher struct {
+	client *http.Client
+}
+
+// NewICalFeedFetcher creates a new iCal feed fetcher.
+func NewICalFeedFetcher(client *http.Client) *ICalFeedFetcher {
+	return &ICalFeedFetcher{client: client}
+}
+
+// Fetch downloads the feed with a GET request and parses its events.
+func (f *ICalFeedFetcher) Fetch(ctx context.Context, url string) ([]calendar.Event, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download feed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download feed: unexpected status %d", resp.StatusCode)
+	}
+
+	events, err := ical.Decode(io.LimitReader(resp.Body, maxFeedBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse feed: %w", err)
+	}
+
+	result := make([]calendar.Event, 0, len(events))
+	for _, evt := range events {
+		result = append(result, calendar.Event{
+			UID:       evt.UID,
+			Start:     evt.Start,
+			End:       evt.End,
+			Summary:   evt.Summary,
+			Cancelled: evt.Cancelled,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package outbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// ICalFeedFetcher Tests
+// ============================================================================
+
+func Test_ICalFeedFetcher_Fetch_Should_Return_Events(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/calendar")
+		_, _ = w.Write([]byte("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:evt-1@airbnb.com\r\n" +
+			"DTSTART;VALUE=DATE:20300507\r\nDTEND;VALUE=DATE:20300510\r\nSUMMARY:Reserved\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"))
+	}))
+	t.Cleanup(srv.Close)
+	fetcher := outbound.NewICalFeedFetcher(srv.Client())
+
+	// Act
+	events, err := fetcher.Fetch(context.Background(), srv.URL+"/listing.ics")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one event must be returned", len(events), 1)
+	assert.That(t, "UID must match", events[0].UID, "evt-1@airbnb.com")
+	assert.That(t, "start must match", events[0].Start, time.Date(2030, 5, 7, 0, 0, 0, 0, time.UTC))
+}
+
+func Test_ICalFeedFetcher_Fetch_With_Error_Status_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+	fetcher := outbound.NewICalFeedFetcher(srv.Client())
+
+	// Act
+	_, err := fetcher.Fetch(context.Background(), srv.URL+"/listing.ics")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
@@ -0,0 +1,202 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/segmentio/kafka-go"
+)
+
+// This file contains a Kafka dispatcher for horizontally scaled deployments.
+// Unlike the external dispatcher of cloud-native-utils, which reads partition 0
+// on every replica, it publishes keyed messages and consumes with consumer groups.
+
+// KeyFunc selects the partition key of a message.
+// Messages with the same key go to the same partition and are consumed in order.
+type KeyFunc func(msg messaging.Message) []byte
+
+// ReservationKey is the default KeyFunc. It keys events by their reservation ID,
+// the aggregate every event of the booking flow refers to.
+func ReservationKey(msg messaging.Message) []byte {
+	var payload struct {
+		ReservationID string `json:"reservation_id"`
+	}
+	if err := json.Unmarshal(msg.Data, &payload); err != nil || payload.ReservationID == "" {
+		return nil
+	}
+	return []byte(payload.ReservationID)
+}
+
+// KafkaDispatcher publishes and consumes messages with Kafka consumer groups.
+//
+// SubscribeGroup joins the group of a bounded context: the partitions of a topic are
+// balanced between the replicas, so each event is handled by one replica (competing
+// consumers). Subscribe joins a group of its own per replica, so every replica receives
+// every event (broadcast), which suits read models kept in memory or in local files.
+//
+// Offsets are committed after the handler returns. When partitions are rebalanced,
+// the new owner resumes after the last committed message, so a message is redelivered
+// rather than lost if a replica leaves mid-flight. Handlers must be idempotent.
+// It implements the messaging.Dispatcher interface.
+type KafkaDispatcher struct {
+	brokers    []string
+	groupID    string
+	instanceID string
+	keyFunc    KeyFunc
+	logger     *slog.Logger
+	retry      RetryPolicy
+	writer     *kafka.Writer
+}
+
+// NewKafkaDispatcher creates a new Kafka dispatcher for the brokers.
+// Consumer groups are named after groupID, e.g. "hotel-booking.orchestration",
+// Messages are keyed by ReservationKey until WithKeyFunc is set, and failed
+// handlers are not retried until WithRetryPolicy is set.
+func NewKafkaDispatcher(brokers []string, groupID, instanceID string) *KafkaDispatcher {
+	return &KafkaDispatcher{
+		brokers:    brokers,
+		groupID:    groupID,
+		instanceID: instanceID,
+		keyFunc:    ReservationKey,
+		retry:      NewRetryPolicy().WithMaxAttempts(1),
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			AllowAutoTopicCreation: true,
+			Balancer:               &kafka.Hash{},
+		},
+	}
+}
+
+// WithKeyFunc sets how the partition key of a message is selected.
+func (d *KafkaDispatcher) WithKeyFunc(fn KeyFunc) *KafkaDispatcher {
+	d.keyFunc = fn
+	return d
+}
+
+// WithRetryPolicy retries failed handlers before the message is committed.
+func (d *KafkaDispatcher) WithRetryPolicy(policy RetryPolicy) *KafkaDispatcher {
+	d.retry = policy
+	return d
+}
+
+// WithLogger logs handler and commit failures.
+func (d *KafkaDispatcher) WithLogger(logger *slog.Logger) *KafkaDispatcher {
+	d.logger = logger
+	return d
+}
+
+// GroupID returns the consumer group ID of a bounded context.
+func (d *KafkaDispatcher) GroupID(group string) string {
+	return d.groupID + "." + group
+}
+
+// InstanceGroupID returns the consumer group ID of this replica.
+func (d *KafkaDispatcher) InstanceGroupID() string {
+	return d.groupID + ".instance." + d.instanceID
+}
+
+// Publish writes the message to its topic, keyed by the KeyFunc.
+func (d *KafkaDispatcher) Publish(ctx context.Context, message messaging.Message) error {
+	if err := d.writer.WriteMessages(ctx, kafka.Message{
+		Topic: message.Topic,
+		Key:   d.keyFunc(message),
+		Value: message.Data,
+	}); err != nil {
+		return fmt.Errorf("failed to write message to %s: %w", message.Topic, err)
+	}
+	return nil
+}
+
+// PublishBatch writes the messages in one round trip, in their order.
+// It implements the BatchPublisher of the BatchingPublisher.
+func (d *KafkaDispatcher) PublishBatch(ctx context.Context, messages []messaging.Message) error {
+	batch := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		batch = append(batch, kafka.Message{
+			Topic: message.Topic,
+			Key:   d.keyFunc(message),
+			Value: message.Data,
+		})
+	}
+	if err := d.writer.WriteMessages(ctx, batch...); err != nil {
+		return fmt.Errorf("failed to write %d messages: %w", len(messages), err)
+	}
+	return nil
+}
+
+// Subscribe consumes the topic in the consumer group of this replica, starting
+// with the messages published after the first start of the replica.
+func (d *KafkaDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return d.subscribe(ctx, d.InstanceGroupID(), kafka.LastOffset, topic, fn)
+}
+
+// SubscribeGroup consumes the topic in the consumer group of a bounded context,
+// starting with the oldest message the first time the group is used.
+func (d *KafkaDispatcher) SubscribeGroup(ctx context.Context, group, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return d.subscribe(ctx, d.GroupID(group), kafka.FirstOffset, topic, fn)
+}
+
+// Close flushes pending messages and closes the writer.
+func (d *KafkaDispatcher) Close() error {
+	return d.writer.Close()
+}
+
+// subscribe starts a consumer that runs until the context is done. It then leaves
+// the group, so the partitions are rebalanced to the remaining replicas at once.
+func (d *KafkaDispatcher) subscribe(ctx context.Context, groupID string, startOffset int64, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     d.brokers,
+		GroupID:     groupID,
+		Topic:       topic,
+		StartOffset: startOffset,
+		MaxBytes:    10e6, // 10MB
+	})
+
+	go func() {
+		defer func() { _ = reader.Close() }()
+		for {
+			// FetchMessage blocks until a message arrives in an assigned partition
+			// and returns an error once the context is done.
+			m, err := reader.FetchMessage(ctx)
+			if err != nil {
+				return
+			}
+
+			msg := messaging.Message{Data: m.Value, State: messaging.MessageStateCreated, Topic: m.Topic}
+			err = Retry(ctx, d.retry, func(ctx context.Context) error {
+				_, err := fn(ctx, msg)
+				return err
+			})
+			if err != nil {
+				// A message rejected during the shutdown stays uncommitted,
+				// so the replica that takes over the partition handles it.
+				if ctx.Err() != nil {
+					return
+				}
+				d.log("failed to handle message", "topic", m.Topic, "group", groupID, "offset", m.Offset, "error", err)
+			}
+
+			// Commit even after the retries failed, so a poison message does not block
+			// its partition. The commit must not be cancelled by the shutdown.
+			if err := reader.CommitMessages(context.WithoutCancel(ctx), m); err != nil {
+				d.log("failed to commit message", "topic", m.Topic, "group", groupID, "offset", m.Offset, "error", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// log writes a warning if a logger is set.
+func (d *KafkaDispatcher) log(msg string, args ...any) {
+	if d.logger != nil {
+		d.logger.Warn(msg, args...)
+	}
+}
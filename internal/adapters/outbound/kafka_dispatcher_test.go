@@ -0,0 +1,69 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// ReservationKey Tests
+// ============================================================================
+
+func Test_ReservationKey_Should_Return_Reservation_ID_Of_Event(t *testing.T) {
+	// Arrange
+	msg := messaging.NewMessage("payment.captured", []byte(`{"payment_id":"pay-1","reservation_id":"res-1"}`))
+
+	// Act
+	key := outbound.ReservationKey(msg)
+
+	// Assert
+	assert.That(t, "key must be the reservation ID", string(key), "res-1")
+}
+
+func Test_ReservationKey_Without_Reservation_ID_Should_Return_Nil(t *testing.T) {
+	// Arrange
+	msg := messaging.NewMessage("test.topic", []byte(`not json`))
+
+	// Act
+	key := outbound.ReservationKey(msg)
+
+	// Assert
+	assert.That(t, "key must be nil", key == nil, true)
+}
+
+// ============================================================================
+// KafkaDispatcher Tests
+// ============================================================================
+
+func Test_KafkaDispatcher_GroupIDs_Should_Separate_Contexts_And_Replicas(t *testing.T) {
+	// Arrange
+	dispatcher := outbound.NewKafkaDispatcher([]string{"localhost:9092"}, "hotel-booking", "pod-a")
+
+	// Act
+	groupID := dispatcher.GroupID("orchestration")
+	instanceGroupID := dispatcher.InstanceGroupID()
+
+	// Assert
+	assert.That(t, "context group must be prefixed", groupID, "hotel-booking.orchestration")
+	assert.That(t, "replica group must contain the instance", instanceGroupID, "hotel-booking.instance.pod-a")
+}
+
+func Test_KafkaDispatcher_SubscribeGroup_With_Done_Context_Should_Return_Error(t *testing.T) {
+	// Arrange
+	dispatcher := outbound.NewKafkaDispatcher([]string{"localhost:9092"}, "hotel-booking", "pod-a")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := dispatcher.SubscribeGroup(ctx, "orchestration", "reservation.created", func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		return messaging.MessageStateCompleted, nil
+	})
+
+	// Assert
+	assert.That(t, "err must be the context error", errors.Is(err, context.Canceled), true)
+}
@@ -0,0 +1,112 @@
+//go:build integration
+
+package outbound_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Kafka Integration Tests
+// ============================================================================
+
+// sagaTopics are the topics the booking saga publishes to.
+var sagaTopics = []string{
+	reservation.EventTopicCreated,
+	reservation.EventTopicConfirmed,
+	reservation.EventTopicCancelled,
+	payment.EventTopicAuthorized,
+	payment.EventTopicCaptured,
+	payment.EventTopicFailed,
+	payment.EventTopicRefunded,
+}
+
+// eventually polls the condition until it holds or the timeout is reached.
+func eventually(timeout time.Duration, condition func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return true
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	return condition()
+}
+
+func Test_KafkaDispatcher_Should_Deliver_Published_Message_To_Group(t *testing.T) {
+	// Arrange
+	broker := startKafka(t, "test.roundtrip")
+	dispatcher := outbound.NewKafkaDispatcher([]string{broker}, "hotel-booking", "pod-a")
+	t.Cleanup(func() { _ = dispatcher.Close() })
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	received := make(chan string, 1)
+	_ = dispatcher.SubscribeGroup(ctx, "test", "test.roundtrip", func(_ context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		received <- string(msg.Data)
+		return messaging.MessageStateCompleted, nil
+	})
+
+	// Act
+	err := dispatcher.Publish(ctx, messaging.NewMessage("test.roundtrip", []byte(`{"reservation_id":"res-001"}`)))
+
+	// Assert
+	assert.That(t, "publish error must be nil", err == nil, true)
+	select {
+	case data := <-received:
+		assert.That(t, "data must match", data, `{"reservation_id":"res-001"}`)
+	case <-time.After(30 * time.Second):
+		t.Fatal("message must be delivered")
+	}
+}
+
+func Test_BookingSaga_With_Postgres_And_Kafka_Should_Confirm_Reservation(t *testing.T) {
+	// Arrange
+	broker := startKafka(t, sagaTopics...)
+	reservationDB := startPostgres(t, "reservation", outbound.PostgresPoolConfig{})
+	paymentDB := startPostgres(t, "payment", outbound.PostgresPoolConfig{})
+	dispatcher := outbound.NewKafkaDispatcher([]string{broker}, "hotel-booking", "pod-a")
+	t.Cleanup(func() { _ = dispatcher.Close() })
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	reservationRepo := outbound.NewPostgresReservationRepository(reservationDB.DB)
+	reservationService := reservation.NewService(reservationRepo,
+		outbound.NewRepositoryAvailabilityChecker(reservationRepo), outbound.NewEventPublisher(dispatcher))
+	paymentService := payment.NewService(outbound.NewPostgresPaymentRepository(paymentDB.DB),
+		outbound.NewMockPaymentGateway(), outbound.NewEventPublisher(dispatcher))
+	bookingService := orchestration.NewBookingService(reservationService, paymentService,
+		outbound.NewMockNotificationService(slog.New(slog.DiscardHandler)))
+	handlers := orchestration.NewEventHandlers(bookingService, reservationService, paymentService)
+	err := handlers.RegisterHandlers(ctx, inbound.NewConsumerGroup(dispatcher, "orchestration"))
+	assert.That(t, "register error must be nil", err == nil, true)
+
+	checkIn := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
+	dateRange := reservation.NewDateRange(checkIn, checkIn.Add(72*time.Hour))
+	guests := []reservation.GuestInfo{{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+15551234567"}}
+
+	// Act
+	_, err = bookingService.InitiateBooking(ctx, "saga-res-001", "guest-001", "room-101", dateRange, shared.NewMoney(30000, "EUR"), guests)
+
+	// Assert
+	assert.That(t, "initiate error must be nil", err == nil, true)
+	confirmed := eventually(60*time.Second, func() bool {
+		res, err := reservationService.GetReservation(ctx, "saga-res-001")
+		return err == nil && res.Status == reservation.StatusConfirmed
+	})
+	assert.That(t, "reservation must be confirmed", confirmed, true)
+	p, err := paymentService.GetPaymentByReservation(ctx, "saga-res-001")
+	assert.That(t, "payment error must be nil", err == nil, true)
+	assert.That(t, "payment must be captured", p.Status, payment.StatusCaptured)
+}
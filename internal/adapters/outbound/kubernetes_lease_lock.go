@@ -0,0 +1,242 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// serviceAccountDir is where Kubernetes mounts the service account of a pod.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// kubernetesMicroTime is the format of MicroTime fields in the Kubernetes API.
+const kubernetesMicroTime = "2006-01-02T15:04:05.000000Z07:00"
+
+// KubernetesLeaseLock is a lease lock backed by a coordination.k8s.io/v1 Lease object.
+// Updates use the resource version of the Lease, so two replicas never win the same term.
+// It implements the LeaseLock interface.
+type KubernetesLeaseLock struct {
+	apiURL    string
+	namespace string
+	name      string
+	tokenFile string
+	client    *http.Client
+}
+
+// NewKubernetesLeaseLock creates a new lease lock for the Lease namespace/name.
+// The bearer token is read from tokenFile on every request, because projected
+// service account tokens are rotated by the kubelet.
+func NewKubernetesLeaseLock(apiURL, namespace, name, tokenFile string, client *http.Client) *KubernetesLeaseLock {
+	return &KubernetesLeaseLock{
+		apiURL:    strings.TrimSuffix(apiURL, "/"),
+		namespace: namespace,
+		name:      name,
+		tokenFile: tokenFile,
+		client:    client,
+	}
+}
+
+// NewInClusterLeaseLock creates a new lease lock with the service account of the pod.
+// The Lease lives in the namespace of the pod; the service account needs the
+// permission to get, create and update leases there.
+func NewInClusterLeaseLock(name string, timeout time.Duration) (*KubernetesLeaseLock, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("failed to detect cluster: KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be set")
+	}
+	namespace, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read namespace: %w", err)
+	}
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("failed to parse cluster CA")
+	}
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+	}
+	apiURL := "https://" + host + ":" + port
+	if strings.Contains(host, ":") {
+		apiURL = "https://[" + host + "]:" + port
+	}
+	return NewKubernetesLeaseLock(apiURL, strings.TrimSpace(string(namespace)), name, filepath.Join(serviceAccountDir, "token"), client), nil
+}
+
+// kubernetesLease is the subset of a Lease object used for leader election.
+type kubernetesLease struct {
+	APIVersion string                  `json:"apiVersion"`
+	Kind       string                  `json:"kind"`
+	Metadata   kubernetesLeaseMetadata `json:"metadata"`
+	Spec       kubernetesLeaseSpec     `json:"spec"`
+}
+
+type kubernetesLeaseMetadata struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type kubernetesLeaseSpec struct {
+	HolderIdentity       string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int    `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int    `json:"leaseTransitions,omitempty"`
+}
+
+// expired reports whether the lease has not been renewed within its duration.
+func (s kubernetesLeaseSpec) expired(now time.Time) bool {
+	if s.HolderIdentity == "" {
+		return true
+	}
+	renewed, err := time.Parse(kubernetesMicroTime, s.RenewTime)
+	if err != nil {
+		return true
+	}
+	return now.After(renewed.Add(time.Duration(s.LeaseDurationSeconds) * time.Second))
+}
+
+// TryAcquireOrRenew acquires the Lease if it is missing, released or expired, and
+// renews it if identity holds it already.
+func (l *KubernetesLeaseLock) TryAcquireOrRenew(ctx context.Context, identity string, duration time.Duration) (bool, error) {
+	now := time.Now().UTC().Format(kubernetesMicroTime)
+	seconds := max(int(duration/time.Second), 1)
+
+	lease, err := l.get(ctx)
+	if err != nil {
+		return false, err
+	}
+	if lease == nil {
+		lease = &kubernetesLease{
+			APIVersion: "coordination.k8s.io/v1",
+			Kind:       "Lease",
+			Metadata:   kubernetesLeaseMetadata{Name: l.name, Namespace: l.namespace},
+			Spec: kubernetesLeaseSpec{
+				HolderIdentity:       identity,
+				LeaseDurationSeconds: seconds,
+				AcquireTime:          now,
+				RenewTime:            now,
+			},
+		}
+		return l.write(ctx, http.MethodPost, l.collectionURL(), lease)
+	}
+
+	switch {
+	case lease.Spec.HolderIdentity == identity:
+		lease.Spec.LeaseDurationSeconds = seconds
+		lease.Spec.RenewTime = now
+	case lease.Spec.expired(time.Now()):
+		lease.Spec.HolderIdentity = identity
+		lease.Spec.LeaseDurationSeconds = seconds
+		lease.Spec.AcquireTime = now
+		lease.Spec.RenewTime = now
+		lease.Spec.LeaseTransitions++
+	default:
+		return false, nil
+	}
+	return l.write(ctx, http.MethodPut, l.leaseURL(), lease)
+}
+
+// Release clears the holder of the Lease if identity holds it.
+func (l *KubernetesLeaseLock) Release(ctx context.Context, identity string) error {
+	lease, err := l.get(ctx)
+	if err != nil {
+		return err
+	}
+	if lease == nil || lease.Spec.HolderIdentity != identity {
+		return nil
+	}
+	lease.Spec.HolderIdentity = ""
+	lease.Spec.LeaseDurationSeconds = 1
+	if _, err := l.write(ctx, http.MethodPut, l.leaseURL(), lease); err != nil {
+		return err
+	}
+	return nil
+}
+
+// get reads the Lease and returns nil if it does not exist.
+func (l *KubernetesLeaseLock) get(ctx context.Context) (*kubernetesLease, error) {
+	resp, err := l.do(ctx, http.MethodGet, l.leaseURL(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lease: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to read lease: unexpected status %d", resp.StatusCode)
+	}
+
+	var lease kubernetesLease
+	if err := json.NewDecoder(resp.Body).Decode(&lease); err != nil {
+		return nil, fmt.Errorf("failed to decode lease: %w", err)
+	}
+	return &lease, nil
+}
+
+// write creates or updates the Lease. A conflict means another replica wrote
+// the Lease first, so the lock is not acquired.
+func (l *KubernetesLeaseLock) write(ctx context.Context, method, endpoint string, lease *kubernetesLease) (bool, error) {
+	body, err := json.Marshal(lease)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode lease: %w", err)
+	}
+	resp, err := l.do(ctx, method, endpoint, body)
+	if err != nil {
+		return false, fmt.Errorf("failed to write lease: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return true, nil
+	case http.StatusConflict:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to write lease: unexpected status %d", resp.StatusCode)
+	}
+}
+
+// do sends an authenticated request to the Kubernetes API.
+func (l *KubernetesLeaseLock) do(ctx context.Context, method, endpoint string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	token, err := os.ReadFile(l.tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return l.client.Do(req)
+}
+
+// collectionURL returns the URL of the leases in the namespace.
+func (l *KubernetesLeaseLock) collectionURL() string {
+	return l.apiURL + "/apis/coordination.k8s.io/v1/namespaces/" + l.namespace + "/leases"
+}
+
+// leaseURL returns the URL of the Lease.
+func (l *KubernetesLeaseLock) leaseURL() string {
+	return l.collectionURL() + "/" + l.name
+}
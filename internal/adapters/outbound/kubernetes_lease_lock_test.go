@@ -0,0 +1,178 @@
+package outbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+const testLeasePath = "/apis/coordination.k8s.io/v1/namespaces/hotel/leases/hotel-booking"
+
+// fakeLeaseAPI stores one Lease like the Kubernetes API server, including
+// optimistic concurrency by resource version.
+type fakeLeaseAPI struct {
+	mutex   sync.Mutex
+	lease   map[string]any
+	version int
+	token   string
+}
+
+func (f *fakeLeaseAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+f.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == testLeasePath:
+		if f.lease == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(f.lease)
+	case r.Method == http.MethodPost && r.URL.Path == filepath.Dir(testLeasePath):
+		if f.lease != nil {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.store(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && r.URL.Path == testLeasePath:
+		var lease map[string]any
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &lease)
+		metadata, _ := lease["metadata"].(map[string]any)
+		if metadata["resourceVersion"] != strconv.Itoa(f.version) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.lease = lease
+		f.bump()
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeLeaseAPI) store(body io.Reader) {
+	_ = json.NewDecoder(body).Decode(&f.lease)
+	f.bump()
+}
+
+func (f *fakeLeaseAPI) bump() {
+	f.version++
+	f.lease["metadata"].(map[string]any)["resourceVersion"] = strconv.Itoa(f.version)
+}
+
+func (f *fakeLeaseAPI) spec() map[string]any {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.lease["spec"].(map[string]any)
+}
+
+func createTestLeaseLock(t *testing.T) (*outbound.KubernetesLeaseLock, *fakeLeaseAPI) {
+	t.Helper()
+	api := &fakeLeaseAPI{token: "sa-token"}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("sa-token\n"), 0o600); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	return outbound.NewKubernetesLeaseLock(server.URL, "hotel", "hotel-booking", tokenFile, server.Client()), api
+}
+
+// ============================================================================
+// KubernetesLeaseLock Tests
+// ============================================================================
+
+func Test_KubernetesLeaseLock_TryAcquireOrRenew_Without_Lease_Should_Create_It(t *testing.T) {
+	// Arrange
+	lock, api := createTestLeaseLock(t)
+
+	// Act
+	acquired, err := lock.TryAcquireOrRenew(context.Background(), "pod-a", 15*time.Second)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "lock must be acquired", acquired, true)
+	assert.That(t, "holder must be pod-a", api.spec()["holderIdentity"], any("pod-a"))
+	assert.That(t, "duration must be in seconds", api.spec()["leaseDurationSeconds"], any(float64(15)))
+}
+
+func Test_KubernetesLeaseLock_TryAcquireOrRenew_With_Held_Lease_Should_Not_Acquire(t *testing.T) {
+	// Arrange
+	lock, api := createTestLeaseLock(t)
+	_, _ = lock.TryAcquireOrRenew(context.Background(), "pod-a", 15*time.Second)
+
+	// Act
+	acquired, err := lock.TryAcquireOrRenew(context.Background(), "pod-b", 15*time.Second)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "lock must not be acquired", acquired, false)
+	assert.That(t, "holder must still be pod-a", api.spec()["holderIdentity"], any("pod-a"))
+}
+
+func Test_KubernetesLeaseLock_TryAcquireOrRenew_By_Holder_Should_Renew(t *testing.T) {
+	// Arrange
+	lock, api := createTestLeaseLock(t)
+	_, _ = lock.TryAcquireOrRenew(context.Background(), "pod-a", 15*time.Second)
+
+	// Act
+	acquired, err := lock.TryAcquireOrRenew(context.Background(), "pod-a", 15*time.Second)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "lock must be renewed", acquired, true)
+	assert.That(t, "lease must be written twice", api.version, 2)
+}
+
+func Test_KubernetesLeaseLock_TryAcquireOrRenew_With_Expired_Lease_Should_Take_Over(t *testing.T) {
+	// Arrange
+	lock, api := createTestLeaseLock(t)
+	_, _ = lock.TryAcquireOrRenew(context.Background(), "pod-a", 15*time.Second)
+	api.spec()["renewTime"] = time.Now().Add(-time.Minute).UTC().Format("2006-01-02T15:04:05.000000Z07:00")
+
+	// Act
+	acquired, err := lock.TryAcquireOrRenew(context.Background(), "pod-b", 15*time.Second)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "lock must be acquired", acquired, true)
+	assert.That(t, "holder must be pod-b", api.spec()["holderIdentity"], any("pod-b"))
+	assert.That(t, "transition must be counted", api.spec()["leaseTransitions"], any(float64(1)))
+}
+
+func Test_KubernetesLeaseLock_Release_Should_Let_Another_Identity_Acquire(t *testing.T) {
+	// Arrange
+	lock, api := createTestLeaseLock(t)
+	_, _ = lock.TryAcquireOrRenew(context.Background(), "pod-a", 15*time.Second)
+
+	// Act
+	err := lock.Release(context.Background(), "pod-a")
+	acquired, _ := lock.TryAcquireOrRenew(context.Background(), "pod-b", 15*time.Second)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "lock must be acquired after release", acquired, true)
+	assert.That(t, "holder must be pod-b", api.spec()["holderIdentity"], any("pod-b"))
+}
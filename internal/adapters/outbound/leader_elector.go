@@ -0,0 +1,114 @@
+package outbound
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// This file contains the leader election used by the composition root to run
+// singleton jobs (compensation retries, calendar sync, reconciliation) on exactly
+// one replica of a scaled-out deployment.
+
+// LeaseLock is a lock that is held by one identity for a limited duration.
+type LeaseLock interface {
+	// TryAcquireOrRenew acquires the lock for identity, or renews it if identity holds it already.
+	// It returns false without error if another identity holds a lease that has not expired.
+	TryAcquireOrRenew(ctx context.Context, identity string, duration time.Duration) (bool, error)
+	// Release gives up the lock if identity holds it, so another replica can take over at once.
+	Release(ctx context.Context, identity string) error
+}
+
+// LeaderElector campaigns for a lease lock and reports whether this replica is the leader.
+// A nil LeaderElector is always the leader, which suits single-replica deployments.
+type LeaderElector struct {
+	lock          LeaseLock
+	identity      string
+	leaseDuration time.Duration
+	retryPeriod   time.Duration
+	logger        *slog.Logger
+	leader        atomic.Bool
+}
+
+// NewLeaderElector creates a new leader elector for identity, e.g. the pod name.
+// The lease lasts 15 seconds and is renewed every 5 seconds until
+// WithLeaseDuration and WithRetryPeriod are set.
+func NewLeaderElector(lock LeaseLock, identity string) *LeaderElector {
+	return &LeaderElector{
+		lock:          lock,
+		identity:      identity,
+		leaseDuration: 15 * time.Second,
+		retryPeriod:   5 * time.Second,
+	}
+}
+
+// WithLeaseDuration sets how long a lease is valid without renewal.
+// Another replica takes over at the latest after this duration if the leader dies.
+func (e *LeaderElector) WithLeaseDuration(d time.Duration) *LeaderElector {
+	e.leaseDuration = d
+	return e
+}
+
+// WithRetryPeriod sets how often the lease is acquired or renewed.
+// It must be shorter than the lease duration.
+func (e *LeaderElector) WithRetryPeriod(d time.Duration) *LeaderElector {
+	e.retryPeriod = d
+	return e
+}
+
+// WithLogger logs changes of the leadership.
+func (e *LeaderElector) WithLogger(logger *slog.Logger) *LeaderElector {
+	e.logger = logger
+	return e
+}
+
+// IsLeader reports whether this replica holds the lease.
+func (e *LeaderElector) IsLeader() bool {
+	if e == nil {
+		return true
+	}
+	return e.leader.Load()
+}
+
+// Run campaigns for the lease until the context is done and releases it afterwards.
+// Leadership is given up as soon as a renewal fails, because the lease may expire
+// before the next attempt succeeds.
+func (e *LeaderElector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.retryPeriod)
+	defer ticker.Stop()
+	for {
+		e.campaign(ctx)
+		select {
+		case <-ctx.Done():
+			if e.leader.Swap(false) {
+				releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), e.retryPeriod)
+				defer cancel()
+				if err := e.lock.Release(releaseCtx, e.identity); err != nil {
+					e.log(slog.LevelWarn, "failed to release leader lease", "error", err)
+				}
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign acquires or renews the lease once and updates the leadership.
+func (e *LeaderElector) campaign(ctx context.Context) {
+	acquired, err := e.lock.TryAcquireOrRenew(ctx, e.identity, e.leaseDuration)
+	if err != nil {
+		e.log(slog.LevelWarn, "failed to acquire leader lease", "error", err)
+		acquired = false
+	}
+	if was := e.leader.Swap(acquired); was != acquired {
+		e.log(slog.LevelInfo, "leadership changed", "identity", e.identity, "leader", acquired)
+	}
+}
+
+// log writes a message if a logger is set.
+func (e *LeaderElector) log(level slog.Level, msg string, args ...any) {
+	if e.logger != nil {
+		e.logger.Log(context.Background(), level, msg, args...)
+	}
+}
@@ -0,0 +1,132 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// LeaderElector Tests
+// ============================================================================
+
+// inMemoryLeaseLock is a lease lock shared by the electors of one test.
+type inMemoryLeaseLock struct {
+	mutex    sync.Mutex
+	holder   string
+	err      error
+	released bool
+}
+
+func (l *inMemoryLeaseLock) TryAcquireOrRenew(ctx context.Context, identity string, duration time.Duration) (bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.err != nil {
+		return false, l.err
+	}
+	if l.holder == "" || l.holder == identity {
+		l.holder = identity
+		return true, nil
+	}
+	return false, nil
+}
+
+func (l *inMemoryLeaseLock) Release(ctx context.Context, identity string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.holder == identity {
+		l.holder = ""
+		l.released = true
+	}
+	return nil
+}
+
+func (l *inMemoryLeaseLock) fail(err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.err = err
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func Test_LeaderElector_Nil_Should_Always_Be_Leader(t *testing.T) {
+	// Arrange
+	var elector *outbound.LeaderElector
+
+	// Act
+	leader := elector.IsLeader()
+
+	// Assert
+	assert.That(t, "nil elector must be leader", leader, true)
+}
+
+func Test_LeaderElector_Run_Should_Elect_Exactly_One_Leader(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lock := &inMemoryLeaseLock{}
+	a := outbound.NewLeaderElector(lock, "pod-a").WithRetryPeriod(10 * time.Millisecond)
+	b := outbound.NewLeaderElector(lock, "pod-b").WithRetryPeriod(10 * time.Millisecond)
+
+	// Act
+	go a.Run(ctx)
+	waitFor(t, a.IsLeader)
+	go b.Run(ctx)
+	time.Sleep(30 * time.Millisecond)
+
+	// Assert
+	assert.That(t, "first elector must be leader", a.IsLeader(), true)
+	assert.That(t, "second elector must not be leader", b.IsLeader(), false)
+}
+
+func Test_LeaderElector_Run_When_Renewal_Fails_Should_Give_Up_Leadership(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lock := &inMemoryLeaseLock{}
+	elector := outbound.NewLeaderElector(lock, "pod-a").WithRetryPeriod(10 * time.Millisecond)
+	go elector.Run(ctx)
+	waitFor(t, elector.IsLeader)
+
+	// Act
+	lock.fail(errors.New("api server unavailable"))
+	waitFor(t, func() bool { return !elector.IsLeader() })
+
+	// Assert
+	assert.That(t, "elector must not be leader", elector.IsLeader(), false)
+}
+
+func Test_LeaderElector_Run_When_Context_Done_Should_Release_Lease(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	lock := &inMemoryLeaseLock{}
+	elector := outbound.NewLeaderElector(lock, "pod-a").WithRetryPeriod(10 * time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		elector.Run(ctx)
+		close(done)
+	}()
+	waitFor(t, elector.IsLeader)
+
+	// Act
+	cancel()
+	<-done
+
+	// Assert
+	assert.That(t, "lease must be released", lock.released, true)
+	assert.That(t, "elector must not be leader", elector.IsLeader(), false)
+}
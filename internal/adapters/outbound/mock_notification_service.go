@@ -2,16 +2,58 @@ package outbound
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
 )
 
+// notificationTemplate lists the fields that fill the placeholders of the
+// subject and body messages of a notification, in order.
+type notificationTemplate struct {
+	subject []string
+	body    []string
+}
+
+// notificationTemplates maps the notification types to the fields of their
+// catalog messages "notification.<type>.subject" and "notification.<type>.body".
+var notificationTemplates = map[orchestration.NotificationType]notificationTemplate{
+	orchestration.NotificationConfirmation: {
+		subject: []string{"reservation_id"},
+		body:    []string{"guest_name", "room_id", "stay", "total"},
+	},
+	orchestration.NotificationCancellation: {
+		subject: []string{"reservation_id"},
+		body:    []string{"guest_name", "reason"},
+	},
+	orchestration.NotificationNoShow: {
+		subject: []string{"reservation_id"},
+		body:    []string{"guest_name", "check_in", "fee"},
+	},
+	orchestration.NotificationBalanceReminder: {
+		subject: []string{"reservation_id"},
+		body:    []string{"guest_name", "balance", "check_in", "due_at"},
+	},
+	orchestration.NotificationPreArrival: {
+		subject: []string{"reservation_id"},
+		body:    []string{"guest_name", "check_in", "room_id", "instructions", "upsell_url"},
+	},
+	orchestration.NotificationReviewRequest: {
+		subject: []string{"reservation_id"},
+		body:    []string{"guest_name", "room_id", "review_url"},
+	},
+}
+
 // MockNotificationService implements NotificationService by logging to console.
+// Messages are localized in the guest's profile locale, if profiles are configured.
+// It implements the orchestration.NotificationRenderer port for previews and is
+// the email sender of the NotificationDispatcher.
 type MockNotificationService struct {
-	logger *slog.Logger
+	logger   *slog.Logger
+	profiles reservation.GuestProfileRepository
 }
 
 // NewMockNotificationService creates a new mock notification service.
@@ -21,21 +63,123 @@ func NewMockNotificationService(logger *slog.Logger) *MockNotificationService {
 	}
 }
 
+// WithGuestProfiles sets the repository used to look up the guest's preferred locale.
+func (s *MockNotificationService) WithGuestProfiles(profiles reservation.GuestProfileRepository) *MockNotificationService {
+	s.profiles = profiles
+	return s
+}
+
+// localizer returns the Localizer for the guest's profile locale.
+// Without a profile, the default locale is used.
+func (s *MockNotificationService) localizer(ctx context.Context, guestID reservation.GuestID) *i18n.Localizer {
+	if s.profiles == nil {
+		return i18n.Negotiate()
+	}
+	profile, err := s.profiles.FindProfile(ctx, guestID)
+	if err != nil || profile == nil {
+		return i18n.Negotiate()
+	}
+	return i18n.Negotiate(profile.Locale)
+}
+
+// RenderNotification renders the subject and body of a reservation notification
+// from the message catalog of the locale, or of the guest's preferred locale if it is empty.
+func (s *MockNotificationService) RenderNotification(
+	ctx context.Context,
+	notificationType orchestration.NotificationType,
+	data orchestration.NotificationData,
+	locale string,
+) (*orchestration.Notification, error) {
+	tmpl, ok := notificationTemplates[notificationType]
+	if !ok {
+		return nil, orchestration.ErrUnknownNotificationType
+	}
+	res := data.Reservation
+	if len(res.Guests) == 0 {
+		return nil, reservation.ErrNoGuests
+	}
+
+	loc := s.localizer(ctx, res.GuestID)
+	if locale != "" {
+		loc = i18n.Negotiate(locale)
+	}
+
+	primaryGuest := res.Guests[0]
+	values := map[string]string{
+		"reservation_id": string(res.ID),
+		"guest_name":     primaryGuest.Name,
+		"room_id":        string(res.RoomID),
+		"stay":           loc.DateRange(res.DateRange),
+		"check_in":       loc.Date(res.DateRange.CheckIn),
+		"total":          loc.Money(res.TotalAmount),
+		"reason":         data.Reason,
+		"fee":            loc.Money(data.Fee),
+		"instructions":   data.Instructions,
+		"upsell_url":     data.UpsellURL,
+		"review_url":     data.ReviewURL,
+	}
+	if notificationType == orchestration.NotificationBalanceReminder {
+		if res.Schedule == nil {
+			return nil, reservation.ErrNoPaymentSchedule
+		}
+		values["balance"] = loc.Money(res.Schedule.Balance)
+		values["due_at"] = loc.Date(res.Schedule.BalanceDueAt)
+	}
+
+	fields := make(map[string]string, len(tmpl.subject)+len(tmpl.body))
+	args := func(names []string) []any {
+		result := make([]any, 0, len(names))
+		for _, name := range names {
+			fields[name] = values[name]
+			result = append(result, values[name])
+		}
+		return result
+	}
+	prefix := "notification." + string(notificationType)
+	return &orchestration.Notification{
+		Type:    notificationType,
+		Locale:  loc.Lang(),
+		To:      string(primaryGuest.Email),
+		Subject: loc.T(prefix+".subject", args(tmpl.subject)...),
+		Body:    loc.T(prefix+".body", args(tmpl.body)...),
+		Fields:  fields,
+	}, nil
+}
+
+// Send logs a rendered notification as email.
+func (s *MockNotificationService) Send(ctx context.Context, to NotificationRecipient, msg *orchestration.Notification) error {
+	if to.Email == "" {
+		return ErrNoRecipientAddress
+	}
+
+	s.logger.Info("sending notification email",
+		"type", msg.Type,
+		"guest_email", to.Email,
+		"locale", msg.Locale,
+		"subject", msg.Subject,
+		"body", msg.Body,
+	)
+
+	return nil
+}
+
 // SendReservationConfirmation logs a confirmation message.
 func (s *MockNotificationService) SendReservationConfirmation(
 	ctx context.Context,
 	res *reservation.Reservation,
 ) error {
-	if len(res.Guests) == 0 {
-		return errors.New("no guests found in reservation")
+	msg, err := s.RenderNotification(ctx, orchestration.NotificationConfirmation, orchestration.NotificationData{Reservation: res}, "")
+	if err != nil {
+		return err
 	}
 
-	primaryGuest := res.Guests[0]
-
 	s.logger.Info("sending reservation confirmation email",
 		"reservation_id", res.ID,
-		"guest_email", primaryGuest.Email,
-		"guest_name", primaryGuest.Name,
+		"guest_email", msg.To,
+		"locale", msg.Locale,
+		"subject", msg.Subject,
+		"body", msg.Body,
+		"guest_name", res.Guests[0].Name,
 		"room_id", res.RoomID,
 		"check_in", res.DateRange.CheckIn.Format("2006-01-02"),
 		"check_out", res.DateRange.CheckOut.Format("2006-01-02"),
@@ -51,34 +195,169 @@ func (s *MockNotificationService) SendCancellationNotice(
 	res *reservation.Reservation,
 	reason string,
 ) error {
-	if len(res.Guests) == 0 {
-		return errors.New("no guests found in reservation")
+	msg, err := s.RenderNotification(ctx, orchestration.NotificationCancellation, orchestration.NotificationData{Reservation: res, Reason: reason}, "")
+	if err != nil {
+		return err
 	}
 
-	primaryGuest := res.Guests[0]
-
 	s.logger.Info("sending cancellation notice email",
 		"reservation_id", res.ID,
-		"guest_email", primaryGuest.Email,
-		"guest_name", primaryGuest.Name,
+		"guest_email", msg.To,
+		"locale", msg.Locale,
+		"subject", msg.Subject,
+		"body", msg.Body,
+		"guest_name", res.Guests[0].Name,
 		"reason", reason,
 	)
 
 	return nil
 }
 
-// SendPaymentReceipt logs a payment receipt message.
+// SendNoShowNotice logs a no-show notice with the retained fee.
+func (s *MockNotificationService) SendNoShowNotice(
+	ctx context.Context,
+	res *reservation.Reservation,
+	fee shared.Money,
+) error {
+	msg, err := s.RenderNotification(ctx, orchestration.NotificationNoShow, orchestration.NotificationData{Reservation: res, Fee: fee}, "")
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("sending no-show notice email",
+		"reservation_id", res.ID,
+		"guest_email", msg.To,
+		"locale", msg.Locale,
+		"subject", msg.Subject,
+		"body", msg.Body,
+		"guest_name", res.Guests[0].Name,
+		"fee", fee.FormatAmount(),
+	)
+
+	return nil
+}
+
+// SendBalanceReminder logs a reminder of the open balance of a payment schedule.
+func (s *MockNotificationService) SendBalanceReminder(
+	ctx context.Context,
+	res *reservation.Reservation,
+) error {
+	msg, err := s.RenderNotification(ctx, orchestration.NotificationBalanceReminder, orchestration.NotificationData{Reservation: res}, "")
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("sending balance reminder email",
+		"reservation_id", res.ID,
+		"guest_email", msg.To,
+		"locale", msg.Locale,
+		"subject", msg.Subject,
+		"body", msg.Body,
+		"guest_name", res.Guests[0].Name,
+		"balance", res.Schedule.Balance.FormatAmount(),
+		"due_at", res.Schedule.BalanceDueAt.Format("2006-01-02"),
+	)
+
+	return nil
+}
+
+// SendPreArrivalReminder logs a reminder with the check-in instructions before arrival.
+func (s *MockNotificationService) SendPreArrivalReminder(
+	ctx context.Context,
+	res *reservation.Reservation,
+	instructions string,
+	upsellURL string,
+) error {
+	data := orchestration.NotificationData{Reservation: res, Instructions: instructions, UpsellURL: upsellURL}
+	msg, err := s.RenderNotification(ctx, orchestration.NotificationPreArrival, data, "")
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("sending pre-arrival reminder email",
+		"reservation_id", res.ID,
+		"guest_email", msg.To,
+		"locale", msg.Locale,
+		"subject", msg.Subject,
+		"body", msg.Body,
+		"guest_name", res.Guests[0].Name,
+		"check_in", res.DateRange.CheckIn.Format("2006-01-02"),
+		"upsell_url", upsellURL,
+	)
+
+	return nil
+}
+
+// SendReviewRequest logs the link to the review form after check-out.
+func (s *MockNotificationService) SendReviewRequest(
+	ctx context.Context,
+	res *reservation.Reservation,
+	link string,
+) error {
+	data := orchestration.NotificationData{Reservation: res, ReviewURL: link}
+	msg, err := s.RenderNotification(ctx, orchestration.NotificationReviewRequest, data, "")
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("sending review request email",
+		"reservation_id", res.ID,
+		"guest_email", msg.To,
+		"locale", msg.Locale,
+		"subject", msg.Subject,
+		"body", msg.Body,
+		"guest_name", res.Guests[0].Name,
+		"review_url", link,
+	)
+
+	return nil
+}
+
+// SendMagicLink logs a passwordless sign-in link.
+func (s *MockNotificationService) SendMagicLink(
+	ctx context.Context,
+	email string,
+	link string,
+) error {
+	loc := s.localizer(ctx, reservation.GuestID(email))
+
+	s.logger.Info("sending sign-in link email",
+		"guest_email", email,
+		"locale", loc.Lang(),
+		"subject", loc.T("notification.magic_link.subject"),
+		"body", loc.T("notification.magic_link.body", link),
+		"link", link,
+	)
+
+	return nil
+}
+
+// SendPaymentReceipt logs a payment receipt message and its attachments.
 func (s *MockNotificationService) SendPaymentReceipt(
 	ctx context.Context,
 	pay *payment.Payment,
+	attachments ...orchestration.Attachment,
 ) error {
+	// Payments carry no guest ID, so receipts use the default locale.
+	loc := i18n.Negotiate()
+
 	s.logger.Info("sending payment receipt email",
 		"payment_id", pay.ID,
+		"subject", loc.T("notification.receipt.subject", pay.ReservationID),
+		"body", loc.T("notification.receipt.body", loc.Money(pay.Amount), pay.TransactionID),
 		"reservation_id", pay.ReservationID,
 		"amount", pay.Amount.FormatAmount(),
 		"payment_method", pay.PaymentMethod,
 		"transaction_id", pay.TransactionID,
 	)
+	for _, a := range attachments {
+		s.logger.Info("attaching file to payment receipt email",
+			"payment_id", pay.ID,
+			"filename", a.Filename,
+			"content_type", a.ContentType,
+			"size", len(a.Data),
+		)
+	}
 
 	return nil
 }
@@ -1,14 +1,18 @@
 package outbound_test
 
 import (
+	"bytes"
 	"context"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
@@ -30,7 +34,7 @@ func createTestReservation() *reservation.Reservation {
 		Status:    reservation.StatusPending,
 		TotalAmount: shared.NewMoney(30000, "USD"),
 		Guests: []reservation.GuestInfo{
-			reservation.NewGuestInfo("John Doe", "john@example.com", "+1234567890"),
+			{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+1234567890"},
 		},
 	}
 }
@@ -102,6 +106,60 @@ func Test_MockNotificationService_SendCancellationNotice_No_Guests_Should_Return
 	assert.That(t, "error must not be nil for no guests", err != nil, true)
 }
 
+func Test_MockNotificationService_SendNoShowNotice_Should_Log_Fee(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	svc := outbound.NewMockNotificationService(logger)
+	ctx := context.Background()
+	res := createTestReservation()
+
+	// Act
+	err := svc.SendNoShowNotice(ctx, res, shared.NewMoney(10000, "USD"))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "log must contain the fee", strings.Contains(buf.String(), "fee=\"100.00 USD\""), true)
+}
+
+func Test_MockNotificationService_SendBalanceReminder_Should_Log_Balance(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	svc := outbound.NewMockNotificationService(logger)
+	ctx := context.Background()
+	res := createTestReservation()
+	res.Schedule = &reservation.PaymentSchedule{
+		Deposit:      shared.NewMoney(3000, "USD"),
+		Balance:      shared.NewMoney(7000, "USD"),
+		BalanceDueAt: res.DateRange.CheckIn.AddDate(0, 0, -1),
+	}
+
+	// Act
+	err := svc.SendBalanceReminder(ctx, res)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "log must contain the balance", strings.Contains(buf.String(), "balance=\"70.00 USD\""), true)
+}
+
+func Test_MockNotificationService_SendPreArrivalReminder_Should_Log_Instructions_And_Upsell_Link(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	svc := outbound.NewMockNotificationService(logger)
+	ctx := context.Background()
+	res := createTestReservation()
+
+	// Act
+	err := svc.SendPreArrivalReminder(ctx, res, "Check-in is from 3 pm.", "https://hotel.example/extras")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "log must contain the instructions", strings.Contains(buf.String(), "Check-in is from 3 pm."), true)
+	assert.That(t, "log must contain the upsell link", strings.Contains(buf.String(), "upsell_url=https://hotel.example/extras"), true)
+}
+
 func Test_MockNotificationService_SendPaymentReceipt_Should_Succeed(t *testing.T) {
 	// Arrange
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
@@ -115,3 +173,85 @@ func Test_MockNotificationService_SendPaymentReceipt_Should_Succeed(t *testing.T
 	// Assert
 	assert.That(t, "error must be nil", err == nil, true)
 }
+
+func Test_MockNotificationService_SendMagicLink_Should_Succeed(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	svc := outbound.NewMockNotificationService(logger)
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendMagicLink(ctx, "guest@example.com", "http://localhost:8080/auth/magic-link/verify?token=abc")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+}
+
+func Test_MockNotificationService_SendReservationConfirmation_With_Profile_Locale_Should_Localize_Message(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	profiles := outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]())
+	_ = profiles.SaveProfile(context.Background(), reservation.GuestProfile{GuestID: "guest-001", Name: "John Doe", Locale: "de"})
+	svc := outbound.NewMockNotificationService(logger).WithGuestProfiles(profiles)
+	ctx := context.Background()
+	res := createTestReservation()
+
+	// Act
+	err := svc.SendReservationConfirmation(ctx, res)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "locale must be de", strings.Contains(buf.String(), "locale=de"), true)
+	assert.That(t, "amount must be localized", strings.Contains(buf.String(), "300,00 USD"), true)
+}
+
+func Test_MockNotificationService_SendReservationConfirmation_Without_Profile_Should_Use_Default_Locale(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	svc := outbound.NewMockNotificationService(logger)
+	ctx := context.Background()
+	res := createTestReservation()
+
+	// Act
+	err := svc.SendReservationConfirmation(ctx, res)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "locale must be en", strings.Contains(buf.String(), "locale=en"), true)
+	assert.That(t, "amount must be localized", strings.Contains(buf.String(), "USD 300.00"), true)
+}
+
+func Test_MockNotificationService_RenderNotification_With_Locale_Should_Override_Profile_Locale(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	profiles := outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]())
+	_ = profiles.SaveProfile(context.Background(), reservation.GuestProfile{GuestID: "guest-001", Name: "John Doe", Locale: "en"})
+	svc := outbound.NewMockNotificationService(logger).WithGuestProfiles(profiles)
+	data := orchestration.NotificationData{Reservation: createTestReservation()}
+
+	// Act
+	msg, err := svc.RenderNotification(context.Background(), orchestration.NotificationConfirmation, data, "de")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "locale must be de", msg.Locale, "de")
+	assert.That(t, "recipient must be the primary guest", msg.To, "john@example.com")
+	assert.That(t, "body must contain the localized total", strings.Contains(msg.Body, "300,00 USD"), true)
+	assert.That(t, "fields must hold the total", msg.Fields["total"], "300,00 USD")
+	assert.That(t, "fields must only hold the template's values", len(msg.Fields), 5)
+}
+
+func Test_MockNotificationService_RenderNotification_Balance_Reminder_Without_Schedule_Should_Return_ErrNoPaymentSchedule(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	svc := outbound.NewMockNotificationService(logger)
+	data := orchestration.NotificationData{Reservation: createTestReservation()}
+
+	// Act
+	_, err := svc.RenderNotification(context.Background(), orchestration.NotificationBalanceReminder, data, "")
+
+	// Assert
+	assert.That(t, "error must be ErrNoPaymentSchedule", err, reservation.ErrNoPaymentSchedule)
+}
@@ -6,14 +6,20 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reconciliation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
 // MockPaymentGateway simulates a payment gateway for testing and demonstration.
+// Captures and refunds are settled immediately and reported by FetchSettlements.
 type MockPaymentGateway struct {
+	mu           sync.Mutex
 	transactions map[string]shared.Money
+	settlements  []reconciliation.Settlement
 	FailureRate  float64 // 0.0 to 1.0, probability of random failures
 	ShouldFail   bool
 }
@@ -44,45 +50,108 @@ func (g *MockPaymentGateway) Authorize(ctx context.Context, pay *payment.Payment
 	}
 
 	transactionID := fmt.Sprintf("txn_%s_%d", pay.ID, pay.Amount.Amount)
+	g.mu.Lock()
 	g.transactions[transactionID] = pay.Amount
+	g.mu.Unlock()
 
 	return transactionID, nil
 }
 
 // Capture simulates capturing an authorized payment.
+// Capturing less than the authorized amount releases the rest.
 func (g *MockPaymentGateway) Capture(ctx context.Context, transactionID string, amount shared.Money) error {
 	if g.ShouldFail || (g.FailureRate > 0 && cryptoRandFloat64() < g.FailureRate) {
-		return errors.New("payment capture failed: gateway timeout")
+		return fmt.Errorf("%w: payment capture failed: gateway timeout", ErrTransient)
 	}
 
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	authorizedAmount, exists := g.transactions[transactionID]
 	if !exists {
 		return fmt.Errorf("transaction %s not found", transactionID)
 	}
 
-	if authorizedAmount.Amount != amount.Amount || authorizedAmount.Currency != amount.Currency {
-		return fmt.Errorf("capture amount mismatch: authorized %v, requested %v", authorizedAmount, amount)
+	if amount.Amount > authorizedAmount.Amount || authorizedAmount.Currency != amount.Currency {
+		return fmt.Errorf("capture amount exceeds authorization: authorized %v, requested %v", authorizedAmount, amount)
 	}
 
+	g.settle(transactionID, reconciliation.SettlementCapture, amount)
 	return nil
 }
 
 // Refund simulates refunding a captured payment.
 func (g *MockPaymentGateway) Refund(ctx context.Context, transactionID string, amount shared.Money) error {
 	if g.ShouldFail || (g.FailureRate > 0 && cryptoRandFloat64() < g.FailureRate) {
-		return errors.New("payment refund failed: gateway error")
+		return fmt.Errorf("%w: payment refund failed: gateway error", ErrTransient)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	_, exists := g.transactions[transactionID]
+	if !exists {
+		return fmt.Errorf("transaction %s not found", transactionID)
+	}
+
+	delete(g.transactions, transactionID)
+	g.settle(transactionID, reconciliation.SettlementRefund, amount)
+
+	return nil
+}
+
+// Void simulates releasing an authorization. Nothing is settled.
+func (g *MockPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	if g.ShouldFail || (g.FailureRate > 0 && cryptoRandFloat64() < g.FailureRate) {
+		return fmt.Errorf("%w: payment void failed: gateway error", ErrTransient)
 	}
 
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	_, exists := g.transactions[transactionID]
 	if !exists {
 		return fmt.Errorf("transaction %s not found", transactionID)
 	}
 
 	delete(g.transactions, transactionID)
+	return nil
+}
 
+// SubmitDisputeEvidence simulates sending dispute evidence to the card issuer.
+// It implements the payment.DisputeGateway port.
+func (g *MockPaymentGateway) SubmitDisputeEvidence(ctx context.Context, disputeID payment.DisputeID, evidence string) error {
+	if g.ShouldFail {
+		return fmt.Errorf("dispute %s: evidence submission failed: gateway error", disputeID)
+	}
 	return nil
 }
 
+// FetchSettlements returns the captures and refunds settled in [from, to).
+// It implements the reconciliation.SettlementProvider port.
+func (g *MockPaymentGateway) FetchSettlements(ctx context.Context, from, to time.Time) ([]reconciliation.Settlement, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var result []reconciliation.Settlement
+	for _, st := range g.settlements {
+		if !st.SettledAt.Before(from) && st.SettledAt.Before(to) {
+			result = append(result, st)
+		}
+	}
+	return result, nil
+}
+
+// settle records a settlement. The caller must hold the lock.
+func (g *MockPaymentGateway) settle(transactionID string, settlementType reconciliation.SettlementType, amount shared.Money) {
+	g.settlements = append(g.settlements, reconciliation.Settlement{
+		TransactionID: transactionID,
+		Type:          settlementType,
+		Amount:        amount,
+		SettledAt:     time.Now(),
+	})
+}
+
 // SetShouldFail configures the mock to always fail (for testing error paths).
 func (g *MockPaymentGateway) SetShouldFail(shouldFail bool) {
 	g.ShouldFail = shouldFail
@@ -95,7 +164,10 @@ func (g *MockPaymentGateway) SetFailureRate(rate float64) {
 
 // Reset clears all transaction state.
 func (g *MockPaymentGateway) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 	g.transactions = make(map[string]shared.Money)
+	g.settlements = nil
 	g.ShouldFail = false
 	g.FailureRate = 0.0
 }
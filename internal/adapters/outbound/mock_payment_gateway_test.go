@@ -3,10 +3,12 @@ package outbound_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reconciliation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
@@ -72,7 +74,7 @@ func Test_MockPaymentGateway_Capture_Unknown_Transaction_Should_Return_Error(t *
 	assert.That(t, "error must not be nil for unknown transaction", err != nil, true)
 }
 
-func Test_MockPaymentGateway_Capture_Amount_Mismatch_Should_Return_Error(t *testing.T) {
+func Test_MockPaymentGateway_Capture_Exceeding_Authorization_Should_Return_Error(t *testing.T) {
 	// Arrange
 	gateway := outbound.NewMockPaymentGateway()
 	ctx := context.Background()
@@ -84,10 +86,32 @@ func Test_MockPaymentGateway_Capture_Amount_Mismatch_Should_Return_Error(t *test
 	}
 
 	// Act
-	err := gateway.Capture(ctx, txnID, shared.NewMoney(5000, "USD"))
+	err := gateway.Capture(ctx, txnID, shared.NewMoney(15000, "USD"))
 
 	// Assert
-	assert.That(t, "error must not be nil for amount mismatch", err != nil, true)
+	assert.That(t, "error must not be nil for amount exceeding authorization", err != nil, true)
+}
+
+func Test_MockPaymentGateway_Capture_Partial_Amount_Should_Settle_Captured_Amount(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	ctx := context.Background()
+	pay := payment.NewPayment("pay-001", "res-001", shared.NewMoney(10000, "USD"), "credit_card")
+	from := time.Now().Add(-time.Minute)
+
+	txnID, authErr := gateway.Authorize(ctx, pay)
+	if authErr != nil {
+		t.Fatalf("setup failed: %v", authErr)
+	}
+
+	// Act
+	err := gateway.Capture(ctx, txnID, shared.NewMoney(2500, "USD"))
+	settlements, _ := gateway.FetchSettlements(ctx, from, time.Now().Add(time.Minute))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "settlements count must be 1", len(settlements), 1)
+	assert.That(t, "settled amount must be the captured amount", settlements[0].Amount.Amount, int64(2500))
 }
 
 func Test_MockPaymentGateway_Capture_With_ShouldFail_Should_Return_Error(t *testing.T) {
@@ -160,6 +184,53 @@ func Test_MockPaymentGateway_Refund_With_ShouldFail_Should_Return_Error(t *testi
 	assert.That(t, "error must not be nil", err != nil, true)
 }
 
+func Test_MockPaymentGateway_Void_Should_Release_Authorization_Without_Settlement(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	ctx := context.Background()
+	pay := payment.NewPayment("pay-001", "res-001", shared.NewMoney(10000, "USD"), "credit_card")
+	from := time.Now().Add(-time.Minute)
+
+	txnID, authErr := gateway.Authorize(ctx, pay)
+	if authErr != nil {
+		t.Fatalf("setup failed: %v", authErr)
+	}
+
+	// Act
+	err := gateway.Void(ctx, txnID)
+	captureErr := gateway.Capture(ctx, txnID, shared.NewMoney(10000, "USD"))
+	settlements, _ := gateway.FetchSettlements(ctx, from, time.Now().Add(time.Minute))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "capture after void must return an error", captureErr != nil, true)
+	assert.That(t, "settlements count must be 0", len(settlements), 0)
+}
+
+func Test_MockPaymentGateway_Void_Unknown_Transaction_Should_Return_Error(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	ctx := context.Background()
+
+	// Act
+	err := gateway.Void(ctx, "unknown-txn")
+
+	// Assert
+	assert.That(t, "error must not be nil for unknown transaction", err != nil, true)
+}
+
+func Test_MockPaymentGateway_SubmitDisputeEvidence_When_Failing_Should_Return_Error(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	gateway.SetShouldFail(true)
+
+	// Act
+	err := gateway.SubmitDisputeEvidence(context.Background(), "dp-001", "signed registration card")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
 func Test_MockPaymentGateway_Reset_Should_Clear_Failure_State(t *testing.T) {
 	// Arrange
 	gateway := outbound.NewMockPaymentGateway()
@@ -191,3 +262,39 @@ func Test_MockPaymentGateway_SetFailureRate_Should_Affect_Behavior(t *testing.T)
 	gateway.SetFailureRate(0.5)
 	assert.That(t, "failure rate must be 0.5", gateway.FailureRate, 0.5)
 }
+
+func Test_MockPaymentGateway_FetchSettlements_Should_Return_Captures_And_Refunds(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	ctx := context.Background()
+	pay := payment.NewPayment("pay-001", "res-001", shared.NewMoney(10000, "USD"), "credit_card")
+	txnID, _ := gateway.Authorize(ctx, pay)
+	_ = gateway.Capture(ctx, txnID, pay.Amount)
+	_ = gateway.Refund(ctx, txnID, pay.Amount)
+
+	// Act
+	settlements, err := gateway.FetchSettlements(ctx, time.Now().Add(-time.Minute), time.Now().Add(time.Minute))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "capture and refund must be settled", len(settlements), 2)
+	assert.That(t, "first settlement must be the capture", settlements[0].Type, reconciliation.SettlementCapture)
+	assert.That(t, "second settlement must be the refund", settlements[1].Type, reconciliation.SettlementRefund)
+	assert.That(t, "transaction ID must match", settlements[0].TransactionID, txnID)
+}
+
+func Test_MockPaymentGateway_FetchSettlements_Outside_Window_Should_Return_None(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	ctx := context.Background()
+	pay := payment.NewPayment("pay-001", "res-001", shared.NewMoney(10000, "USD"), "credit_card")
+	txnID, _ := gateway.Authorize(ctx, pay)
+	_ = gateway.Capture(ctx, txnID, pay.Amount)
+
+	// Act
+	settlements, err := gateway.FetchSettlements(ctx, time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "no settlements must be returned", len(settlements), 0)
+}
@@ -0,0 +1,164 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ErrNoRecipientAddress is returned by senders if the guest has no address for their channel,
+// e.g. no phone number for SMS.
+var ErrNoRecipientAddress = errors.New("guest has no address for the channel")
+
+// NotificationRecipient holds the addresses of the guest a notification is sent to.
+type NotificationRecipient struct {
+	GuestID     reservation.GuestID
+	Email       string
+	PhoneNumber reservation.PhoneNumber
+}
+
+// NotificationSender delivers rendered notifications over one channel.
+type NotificationSender interface {
+	// Send delivers the notification to the recipient
+	Send(ctx context.Context, to NotificationRecipient, msg *orchestration.Notification) error
+}
+
+// NotificationDispatcher delivers reservation notifications over the channels
+// the guest prefers for the message type (email, SMS, push). If a channel is not
+// configured or fails, the next one is tried; email is always the last resort.
+// Sign-in links and payment receipts are sent by email only.
+// It implements the orchestration notification ports and the NotificationRenderer port.
+type NotificationDispatcher struct {
+	email    *MockNotificationService
+	logger   *slog.Logger
+	profiles reservation.GuestProfileRepository
+	senders  map[reservation.NotificationChannel]NotificationSender
+}
+
+// NewNotificationDispatcher creates a new dispatcher that renders and emails with the email service.
+func NewNotificationDispatcher(email *MockNotificationService, logger *slog.Logger) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		email:   email,
+		logger:  logger,
+		senders: map[reservation.NotificationChannel]NotificationSender{reservation.ChannelEmail: email},
+	}
+}
+
+// WithChannel sets the sender of a channel.
+func (d *NotificationDispatcher) WithChannel(channel reservation.NotificationChannel, sender NotificationSender) *NotificationDispatcher {
+	d.senders[channel] = sender
+	return d
+}
+
+// WithGuestProfiles sets the repository used to look up the guest's notification preferences.
+func (d *NotificationDispatcher) WithGuestProfiles(profiles reservation.GuestProfileRepository) *NotificationDispatcher {
+	d.profiles = profiles
+	return d
+}
+
+// RenderNotification renders the notification with the email service.
+func (d *NotificationDispatcher) RenderNotification(
+	ctx context.Context,
+	notificationType orchestration.NotificationType,
+	data orchestration.NotificationData,
+	locale string,
+) (*orchestration.Notification, error) {
+	return d.email.RenderNotification(ctx, notificationType, data, locale)
+}
+
+// SendReservationConfirmation dispatches a confirmation message.
+func (d *NotificationDispatcher) SendReservationConfirmation(ctx context.Context, res *reservation.Reservation) error {
+	return d.dispatch(ctx, orchestration.NotificationConfirmation, orchestration.NotificationData{Reservation: res})
+}
+
+// SendCancellationNotice dispatches a cancellation message.
+func (d *NotificationDispatcher) SendCancellationNotice(ctx context.Context, res *reservation.Reservation, reason string) error {
+	return d.dispatch(ctx, orchestration.NotificationCancellation, orchestration.NotificationData{Reservation: res, Reason: reason})
+}
+
+// SendNoShowNotice dispatches a no-show notice with the retained fee.
+func (d *NotificationDispatcher) SendNoShowNotice(ctx context.Context, res *reservation.Reservation, fee shared.Money) error {
+	return d.dispatch(ctx, orchestration.NotificationNoShow, orchestration.NotificationData{Reservation: res, Fee: fee})
+}
+
+// SendBalanceReminder dispatches a reminder of the open balance of a payment schedule.
+func (d *NotificationDispatcher) SendBalanceReminder(ctx context.Context, res *reservation.Reservation) error {
+	return d.dispatch(ctx, orchestration.NotificationBalanceReminder, orchestration.NotificationData{Reservation: res})
+}
+
+// SendPreArrivalReminder dispatches a reminder with the check-in instructions before arrival.
+func (d *NotificationDispatcher) SendPreArrivalReminder(ctx context.Context, res *reservation.Reservation, instructions, upsellURL string) error {
+	return d.dispatch(ctx, orchestration.NotificationPreArrival, orchestration.NotificationData{Reservation: res, Instructions: instructions, UpsellURL: upsellURL})
+}
+
+// SendReviewRequest dispatches the link to the review form after check-out.
+func (d *NotificationDispatcher) SendReviewRequest(ctx context.Context, res *reservation.Reservation, link string) error {
+	return d.dispatch(ctx, orchestration.NotificationReviewRequest, orchestration.NotificationData{Reservation: res, ReviewURL: link})
+}
+
+// SendMagicLink emails a passwordless sign-in link.
+func (d *NotificationDispatcher) SendMagicLink(ctx context.Context, email string, link string) error {
+	return d.email.SendMagicLink(ctx, email, link)
+}
+
+// SendPaymentReceipt emails a payment receipt with its attachments.
+func (d *NotificationDispatcher) SendPaymentReceipt(ctx context.Context, pay *payment.Payment, attachments ...orchestration.Attachment) error {
+	return d.email.SendPaymentReceipt(ctx, pay, attachments...)
+}
+
+// dispatch renders the notification and sends it over the guest's channels
+// until one succeeds. The errors of all channels are returned if none does.
+func (d *NotificationDispatcher) dispatch(ctx context.Context, notificationType orchestration.NotificationType, data orchestration.NotificationData) error {
+	msg, err := d.email.RenderNotification(ctx, notificationType, data, "")
+	if err != nil {
+		return err
+	}
+
+	res := data.Reservation
+	to := NotificationRecipient{
+		GuestID:     res.GuestID,
+		Email:       msg.To,
+		PhoneNumber: res.Guests[0].PhoneNumber,
+	}
+	var prefs reservation.NotificationPreferences
+	if d.profiles != nil {
+		if profile, err := d.profiles.FindProfile(ctx, res.GuestID); err == nil && profile != nil {
+			prefs = profile.Notifications
+			if profile.PhoneNumber != "" {
+				to.PhoneNumber = profile.PhoneNumber
+			}
+		}
+	}
+
+	channels := prefs.ChannelsFor(string(notificationType))
+	if !slices.Contains(channels, reservation.ChannelEmail) {
+		channels = append(slices.Clone(channels), reservation.ChannelEmail)
+	}
+
+	var errs []error
+	for _, channel := range channels {
+		sender, ok := d.senders[channel]
+		if !ok {
+			continue
+		}
+		err := sender.Send(ctx, to, msg)
+		if err == nil {
+			return nil
+		}
+		d.logger.Warn("notification channel failed, trying next",
+			"reservation_id", res.ID,
+			"type", notificationType,
+			"channel", channel,
+			"error", err,
+		)
+		errs = append(errs, fmt.Errorf("%s: %w", channel, err))
+	}
+	return fmt.Errorf("failed to deliver %s notification: %w", notificationType, errors.Join(errs...))
+}
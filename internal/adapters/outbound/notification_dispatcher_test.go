@@ -0,0 +1,119 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type mockNotificationSender struct {
+	err  error
+	sent []*orchestration.Notification
+	to   outbound.NotificationRecipient
+}
+
+func (m *mockNotificationSender) Send(ctx context.Context, to outbound.NotificationRecipient, msg *orchestration.Notification) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.to = to
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// createTestDispatcher returns a dispatcher with the SMS and push senders
+// and the guest profile of guest-001 with the given preferences.
+func createTestDispatcher(t *testing.T, prefs reservation.NotificationPreferences, sms, push *mockNotificationSender) *outbound.NotificationDispatcher {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	profiles := outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]())
+	_ = profiles.SaveProfile(context.Background(), reservation.GuestProfile{GuestID: "guest-001", Name: "John Doe", PhoneNumber: "+15550001111", Notifications: prefs})
+	return outbound.NewNotificationDispatcher(outbound.NewMockNotificationService(logger), logger).
+		WithGuestProfiles(profiles).
+		WithChannel(reservation.ChannelSMS, sms).
+		WithChannel(reservation.ChannelPush, push)
+}
+
+// ============================================================================
+// NotificationDispatcher Tests
+// ============================================================================
+
+func Test_NotificationDispatcher_Should_Use_Channel_Preferred_For_Type(t *testing.T) {
+	// Arrange
+	sms, push := &mockNotificationSender{}, &mockNotificationSender{}
+	prefs, _ := reservation.NewNotificationPreferences(
+		[]reservation.NotificationChannel{reservation.ChannelPush},
+		map[string][]reservation.NotificationChannel{"balance_reminder": {reservation.ChannelSMS}},
+	)
+	dispatcher := createTestDispatcher(t, prefs, sms, push)
+	res := createTestReservation()
+	res.Schedule = &reservation.PaymentSchedule{Balance: res.TotalAmount, BalanceDueAt: res.DateRange.CheckIn}
+
+	// Act
+	reminderErr := dispatcher.SendBalanceReminder(context.Background(), res)
+	confirmationErr := dispatcher.SendReservationConfirmation(context.Background(), res)
+
+	// Assert
+	assert.That(t, "reminder error must be nil", reminderErr == nil, true)
+	assert.That(t, "confirmation error must be nil", confirmationErr == nil, true)
+	assert.That(t, "reminder must be sent by SMS", len(sms.sent), 1)
+	assert.That(t, "SMS must go to the profile phone", sms.to.PhoneNumber, reservation.PhoneNumber("+15550001111"))
+	assert.That(t, "confirmation must be pushed", len(push.sent), 1)
+	assert.That(t, "pushed type must be confirmation", push.sent[0].Type, orchestration.NotificationConfirmation)
+}
+
+func Test_NotificationDispatcher_With_Failing_Channel_Should_Fall_Back_To_Next(t *testing.T) {
+	// Arrange
+	sms, push := &mockNotificationSender{err: errors.New("provider down")}, &mockNotificationSender{}
+	prefs, _ := reservation.NewNotificationPreferences([]reservation.NotificationChannel{reservation.ChannelSMS, reservation.ChannelPush}, nil)
+	dispatcher := createTestDispatcher(t, prefs, sms, push)
+
+	// Act
+	err := dispatcher.SendCancellationNotice(context.Background(), createTestReservation(), "Cancelled by guest")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "notice must be pushed", len(push.sent), 1)
+}
+
+func Test_NotificationDispatcher_With_Failing_Channels_Should_Fall_Back_To_Email(t *testing.T) {
+	// Arrange
+	sms, push := &mockNotificationSender{err: outbound.ErrNoRecipientAddress}, &mockNotificationSender{err: errors.New("gateway down")}
+	prefs, _ := reservation.NewNotificationPreferences([]reservation.NotificationChannel{reservation.ChannelSMS, reservation.ChannelPush}, nil)
+	dispatcher := createTestDispatcher(t, prefs, sms, push)
+	res := createTestReservation()
+
+	// Act
+	err := dispatcher.SendReservationConfirmation(context.Background(), res)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+}
+
+func Test_NotificationDispatcher_Without_Any_Address_Should_Return_Errors_Of_All_Channels(t *testing.T) {
+	// Arrange
+	sms, push := &mockNotificationSender{err: outbound.ErrNoRecipientAddress}, &mockNotificationSender{}
+	prefs, _ := reservation.NewNotificationPreferences([]reservation.NotificationChannel{reservation.ChannelSMS}, nil)
+	dispatcher := createTestDispatcher(t, prefs, sms, push)
+	res := createTestReservation()
+	res.Guests[0].Email = ""
+
+	// Act
+	err := dispatcher.SendReservationConfirmation(context.Background(), res)
+
+	// Assert
+	assert.That(t, "error must match ErrNoRecipientAddress", errors.Is(err, outbound.ErrNoRecipientAddress), true)
+	assert.That(t, "push must not be tried", len(push.sent), 0)
+}
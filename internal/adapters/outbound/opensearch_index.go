@@ -0,0 +1,246 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/search"
+)
+
+// openSearchMapping maps the guest data to full-text fields and the IDs to exact values.
+const openSearchMapping = `{
+  "mappings": {
+    "properties": {
+      "reservation_id": {"type": "keyword"},
+      "guest_id": {"type": "keyword"},
+      "guest_names": {"type": "text"},
+      "guest_emails": {"type": "text"},
+      "room_id": {"type": "keyword"},
+      "check_in": {"type": "date"},
+      "check_out": {"type": "date"},
+      "status": {"type": "keyword"},
+      "channel": {"type": "keyword"},
+      "updated_at": {"type": "date"}
+    }
+  }
+}`
+
+// OpenSearchIndex stores reservation summaries in an OpenSearch (or Elasticsearch) index
+// through its REST API. Requests are authenticated with basic auth if a username is set.
+// It implements the search.SearchIndex port.
+type OpenSearchIndex struct {
+	baseURL  string
+	index    string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewOpenSearchIndex creates a new client for the index of the cluster at baseURL.
+func NewOpenSearchIndex(baseURL, index, username, password string, client *http.Client) *OpenSearchIndex {
+	return &OpenSearchIndex{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		index:    index,
+		username: username,
+		password: password,
+		client:   client,
+	}
+}
+
+// openSearchDocument is the indexed representation of a reservation summary.
+type openSearchDocument struct {
+	ReservationID string    `json:"reservation_id"`
+	GuestID       string    `json:"guest_id"`
+	GuestNames    []string  `json:"guest_names"`
+	GuestEmails   []string  `json:"guest_emails"`
+	RoomID        string    `json:"room_id"`
+	CheckIn       time.Time `json:"check_in"`
+	CheckOut      time.Time `json:"check_out"`
+	Status        string    `json:"status"`
+	Channel       string    `json:"channel,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// openSearchResponse is the part of a search response that is read.
+type openSearchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			Source    openSearchDocument  `json:"_source"`
+			Highlight map[string][]string `json:"highlight"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// EnsureIndex creates the index with its mapping unless it exists.
+func (o *OpenSearchIndex) EnsureIndex(ctx context.Context) error {
+	resp, err := o.do(ctx, http.MethodHead, "/"+o.index, nil)
+	if err != nil {
+		return fmt.Errorf("failed to check index: %w", err)
+	}
+	_ = resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode != http.StatusNotFound:
+		return fmt.Errorf("failed to check index: unexpected status %d", resp.StatusCode)
+	}
+
+	resp, err = o.do(ctx, http.MethodPut, "/"+o.index, []byte(openSearchMapping))
+	if err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to create index: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Index adds or replaces the document of the reservation.
+func (o *OpenSearchIndex) Index(ctx context.Context, summary search.ReservationSummary) error {
+	body, err := json.Marshal(openSearchDocument{
+		ReservationID: string(summary.ReservationID),
+		GuestID:       string(summary.GuestID),
+		GuestNames:    summary.GuestNames,
+		GuestEmails:   summary.GuestEmails,
+		RoomID:        string(summary.RoomID),
+		CheckIn:       summary.CheckIn,
+		CheckOut:      summary.CheckOut,
+		Status:        string(summary.Status),
+		Channel:       summary.Channel,
+		UpdatedAt:     summary.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode document: %w", err)
+	}
+
+	resp, err := o.do(ctx, http.MethodPut, "/"+o.index+"/_doc/"+url.PathEscape(string(summary.ReservationID)), body)
+	if err != nil {
+		return fmt.Errorf("failed to index document: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to index document: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Search returns the page of documents matching the query. Guest names and emails
+// match fuzzily, IDs exactly; hits are ordered by relevance, then by check-in,
+// and the matches in guest names and emails are highlighted.
+func (o *OpenSearchIndex) Search(ctx context.Context, query search.Query) (*search.Result, error) {
+	body, err := json.Marshal(searchRequest(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	resp, err := o.do(ctx, http.MethodPost, "/"+o.index+"/_search", body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to search: unexpected status %d", resp.StatusCode)
+	}
+
+	var found openSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&found); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	result := &search.Result{
+		Total: found.Hits.Total.Value,
+		Page:  query.Page,
+		Size:  query.Size,
+		Hits:  make([]search.Hit, 0, len(found.Hits.Hits)),
+	}
+	for _, hit := range found.Hits.Hits {
+		doc := hit.Source
+		result.Hits = append(result.Hits, search.Hit{
+			Summary: search.ReservationSummary{
+				ReservationID: reservation.ReservationID(doc.ReservationID),
+				GuestID:       reservation.GuestID(doc.GuestID),
+				GuestNames:    doc.GuestNames,
+				GuestEmails:   doc.GuestEmails,
+				RoomID:        reservation.RoomID(doc.RoomID),
+				CheckIn:       doc.CheckIn,
+				CheckOut:      doc.CheckOut,
+				Status:        reservation.ReservationStatus(doc.Status),
+				Channel:       doc.Channel,
+				UpdatedAt:     doc.UpdatedAt,
+			},
+			Highlights: hit.Highlight,
+		})
+	}
+	return result, nil
+}
+
+// searchRequest builds the body of a search. An empty text matches all documents.
+func searchRequest(query search.Query) map[string]any {
+	match := map[string]any{"match_all": map[string]any{}}
+	if query.Text != "" {
+		match = map[string]any{
+			"bool": map[string]any{
+				"should": []any{
+					map[string]any{"multi_match": map[string]any{
+						"query":     query.Text,
+						"fields":    []string{"guest_names^2", "guest_emails"},
+						"fuzziness": "AUTO",
+					}},
+					map[string]any{"multi_match": map[string]any{
+						"query":  query.Text,
+						"type":   "phrase_prefix",
+						"fields": []string{"guest_names", "guest_emails"},
+					}},
+					map[string]any{"term": map[string]any{"reservation_id": query.Text}},
+					map[string]any{"term": map[string]any{"guest_id": query.Text}},
+					map[string]any{"term": map[string]any{"room_id": query.Text}},
+				},
+				"minimum_should_match": 1,
+			},
+		}
+	}
+	return map[string]any{
+		"query":            match,
+		"from":             query.Offset(),
+		"size":             query.Size,
+		"track_total_hits": true,
+		"sort":             []any{"_score", map[string]any{"check_in": "desc"}},
+		"highlight": map[string]any{
+			"fields": map[string]any{"guest_names": map[string]any{}, "guest_emails": map[string]any{}},
+		},
+	}
+}
+
+// do sends a request with an optional JSON body to the cluster.
+func (o *OpenSearchIndex) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, o.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if o.username != "" {
+		req.SetBasicAuth(o.username, o.password)
+	}
+	return o.client.Do(req)
+}
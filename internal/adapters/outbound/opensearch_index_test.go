@@ -0,0 +1,130 @@
+package outbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/search"
+)
+
+// ============================================================================
+// OpenSearchIndex Tests
+// ============================================================================
+
+func Test_OpenSearchIndex_Index_Should_Put_Document(t *testing.T) {
+	// Arrange
+	var user, path string
+	var doc map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ = r.BasicAuth()
+		path = r.Method + " " + r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&doc)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+	index := outbound.NewOpenSearchIndex(srv.URL, "reservations", "admin", "secret", srv.Client())
+
+	// Act
+	err := index.Index(context.Background(), search.ReservationSummary{
+		ReservationID: "res-001",
+		GuestNames:    []string{"Jane Doe"},
+		GuestEmails:   []string{"jane@example.com"},
+		CheckIn:       time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC),
+		Status:        "confirmed",
+	})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "request must be authenticated", user, "admin")
+	assert.That(t, "document must be put by reservation ID", path, "PUT /reservations/_doc/res-001")
+	assert.That(t, "guest names must be sent", doc["guest_names"], any([]any{"Jane Doe"}))
+	assert.That(t, "status must be sent", doc["status"], any("confirmed"))
+}
+
+func Test_OpenSearchIndex_Search_Should_Return_Page_With_Highlights(t *testing.T) {
+	// Arrange
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/reservations/_search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"hits":{"total":{"value":21},"hits":[{"_source":{"reservation_id":"res-001",` +
+			`"guest_names":["Jane Doe"],"status":"confirmed"},"highlight":{"guest_names":["<em>Jane</em> Doe"]}}]}}`))
+	}))
+	t.Cleanup(srv.Close)
+	index := outbound.NewOpenSearchIndex(srv.URL+"/", "reservations", "", "", srv.Client())
+
+	// Act
+	result, err := index.Search(context.Background(), search.Query{Text: "jane", Page: 2, Size: 20})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "offset must skip the first page", body["from"], any(float64(20)))
+	assert.That(t, "total must be returned", result.Total, 21)
+	assert.That(t, "one hit must be returned", len(result.Hits), 1)
+	assert.That(t, "reservation must be decoded", string(result.Hits[0].Summary.ReservationID), "res-001")
+	assert.That(t, "highlight must be returned", result.Hits[0].Highlights["guest_names"], []string{"<em>Jane</em> Doe"})
+}
+
+func Test_OpenSearchIndex_Search_Without_Text_Should_Match_All(t *testing.T) {
+	// Arrange
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"hits":{"total":{"value":0},"hits":[]}}`))
+	}))
+	t.Cleanup(srv.Close)
+	index := outbound.NewOpenSearchIndex(srv.URL, "reservations", "", "", srv.Client())
+
+	// Act
+	_, err := index.Search(context.Background(), search.Query{Page: 1, Size: 20})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	query := body["query"].(map[string]any)
+	_, matchAll := query["match_all"]
+	assert.That(t, "all documents must match", matchAll, true)
+}
+
+func Test_OpenSearchIndex_EnsureIndex_When_Missing_Should_Create_Index(t *testing.T) {
+	// Arrange
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	index := outbound.NewOpenSearchIndex(srv.URL, "reservations", "", "", srv.Client())
+
+	// Act
+	err := index.EnsureIndex(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "index must be checked and created", requests, []string{"HEAD /reservations", "PUT /reservations"})
+}
+
+func Test_OpenSearchIndex_Search_With_Error_Status_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+	index := outbound.NewOpenSearchIndex(srv.URL, "reservations", "", "", srv.Client())
+
+	// Act
+	_, err := index.Search(context.Background(), search.Query{Page: 1, Size: 20})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
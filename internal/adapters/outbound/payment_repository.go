@@ -0,0 +1,40 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+)
+
+// PaymentRepository adds reservation lookups to any key/value payment store,
+// such as InMemoryAccess or JsonFileAccess from cloud-native-utils.
+// It implements the payment.PaymentRepository port.
+type PaymentRepository struct {
+	resource.Access[payment.PaymentID, payment.Payment]
+}
+
+// NewPaymentRepository creates a new payment repository backed by the given access.
+func NewPaymentRepository(access resource.Access[payment.PaymentID, payment.Payment]) *PaymentRepository {
+	return &PaymentRepository{
+		Access: access,
+	}
+}
+
+// FindByReservationID returns all payments for the given reservation.
+func (r *PaymentRepository) FindByReservationID(ctx context.Context, reservationID payment.ReservationID) ([]payment.Payment, error) {
+	allPayments, err := r.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	var payments []payment.Payment
+	for _, p := range allPayments {
+		if p.ReservationID == reservationID {
+			payments = append(payments, p)
+		}
+	}
+
+	return payments, nil
+}
@@ -0,0 +1,48 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// PaymentRepository Tests
+// ============================================================================
+
+func Test_PaymentRepository_FindByReservationID_Should_Return_Matching_Payments(t *testing.T) {
+	// Arrange
+	repo := outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]())
+	ctx := context.Background()
+	amount := shared.NewMoney(10000, "USD")
+	_ = repo.Create(ctx, "pay-001", *payment.NewPayment("pay-001", "res-001", amount, "credit_card"))
+	_ = repo.Create(ctx, "pay-002", *payment.NewPayment("pay-002", "res-002", amount, "credit_card"))
+	_ = repo.Create(ctx, "pay-003", *payment.NewPayment("pay-003", "res-001", amount, "credit_card"))
+
+	// Act
+	payments, err := repo.FindByReservationID(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must find two payments", len(payments), 2)
+	for _, p := range payments {
+		assert.That(t, "reservation ID must match", p.ReservationID, payment.ReservationID("res-001"))
+	}
+}
+
+func Test_PaymentRepository_FindByReservationID_When_None_Match_Should_Return_Empty(t *testing.T) {
+	// Arrange
+	repo := outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]())
+
+	// Act
+	payments, err := repo.FindByReservationID(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must find no payments", len(payments), 0)
+}
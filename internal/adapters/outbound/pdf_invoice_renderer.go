@@ -0,0 +1,133 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/invoicing"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+//go:embed templates/invoice.tmpl
+var invoiceTemplateFS embed.FS
+
+// PDF page layout in points (A4 portrait, monospaced text).
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 50
+	pdfFontSize     = 10
+	pdfHeadingSize  = 12
+	pdfLineHeight   = 14
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLineHeight
+)
+
+// PDFInvoiceRenderer renders invoices as PDF documents.
+// The text layout comes from the embedded templates/invoice.tmpl; lines starting
+// with "# " are printed as headings. It implements the invoicing.Renderer port.
+type PDFInvoiceRenderer struct {
+	tmpl *template.Template
+}
+
+// NewPDFInvoiceRenderer creates a new PDF renderer with the embedded invoice template.
+func NewPDFInvoiceRenderer() *PDFInvoiceRenderer {
+	funcs := template.FuncMap{
+		"money":    func(m shared.Money) string { return m.FormatAmount() },
+		"date":     func(t time.Time) string { return t.Format("2006-01-02") },
+		"datetime": func(t time.Time) string { return t.Format("2006-01-02 15:04") },
+	}
+	return &PDFInvoiceRenderer{
+		tmpl: template.Must(template.New("invoice.tmpl").Funcs(funcs).ParseFS(invoiceTemplateFS, "templates/invoice.tmpl")),
+	}
+}
+
+// ContentType returns the media type of the rendered documents.
+func (r *PDFInvoiceRenderer) ContentType() string {
+	return "application/pdf"
+}
+
+// Render executes the invoice template and lays the text out on PDF pages.
+func (r *PDFInvoiceRenderer) Render(ctx context.Context, invoice *invoicing.Invoice) ([]byte, error) {
+	var text bytes.Buffer
+	if err := r.tmpl.Execute(&text, invoice); err != nil {
+		return nil, fmt.Errorf("failed to execute invoice template: %w", err)
+	}
+	lines := strings.Split(strings.TrimRight(text.String(), "\n"), "\n")
+	return writePDF(lines), nil
+}
+
+// writePDF writes a minimal PDF 1.4 document with one content stream per page,
+// using the standard Courier fonts so no font data needs to be embedded.
+func writePDF(lines []string) []byte {
+	var pages [][]string
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	// Objects 1-4 are the catalog, the page tree and the fonts;
+	// each page adds a page object and its content stream.
+	objects := []string{"", "", "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>"}
+	var kids []string
+	for _, page := range pages {
+		pageID := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageID))
+		content := pdfPageContent(page)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, pageID+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+	objects[0] = "<< /Type /Catalog /Pages 2 0 R >>"
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// pdfPageContent returns the content stream that prints the lines top to bottom.
+func pdfPageContent(lines []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "BT\n%d TL\n%d %d Td\n", pdfLineHeight, pdfMargin, pdfPageHeight-pdfMargin)
+	for _, line := range lines {
+		if heading, ok := strings.CutPrefix(line, "# "); ok {
+			fmt.Fprintf(&b, "/F2 %d Tf\n(%s) Tj\nT*\n", pdfHeadingSize, pdfEscape(heading))
+			continue
+		}
+		fmt.Fprintf(&b, "/F1 %d Tf\n(%s) Tj\nT*\n", pdfFontSize, pdfEscape(line))
+	}
+	b.WriteString("ET")
+	return b.String()
+}
+
+// pdfEscape encodes a line in the WinAnsi encoding of the standard fonts and
+// escapes it as the content of a PDF literal string. Other characters are replaced.
+func pdfEscape(s string) string {
+	encoded, err := encoding.ReplaceUnsupported(charmap.Windows1252.NewEncoder()).String(s)
+	if err != nil {
+		encoded = s
+	}
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`, "\r", "").Replace(encoded)
+}
@@ -0,0 +1,100 @@
+package outbound_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoicing"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// PDFInvoiceRenderer Tests
+// ============================================================================
+
+func createTestInvoice() *invoicing.Invoice {
+	checkIn := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	return &invoicing.Invoice{
+		Number:        "INV-res-001",
+		IssuedAt:      time.Date(2024, 1, 10, 9, 30, 0, 0, time.UTC),
+		ReservationID: "res-001",
+		GuestName:     "Jöhn (Doe)",
+		GuestEmail:    "john@example.com",
+		RoomID:        "room-101",
+		DateRange:     reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 3)),
+		Lines: []invoicing.InvoiceLine{{
+			Description: "Room room-101, 3 night(s)",
+			Quantity:    3,
+			UnitPrice:   shared.NewMoney(10000, "EUR"),
+			Amount:      shared.NewMoney(30000, "EUR"),
+		}},
+		Net:     shared.NewMoney(25210, "EUR"),
+		Tax:     shared.NewMoney(4790, "EUR"),
+		TaxRate: 1900,
+		Total:   shared.NewMoney(30000, "EUR"),
+		Payment: payment.Payment{
+			ID:            "pay-001",
+			Status:        payment.StatusCaptured,
+			PaymentMethod: "credit_card",
+			TransactionID: "tx-001",
+			Attempts:      []payment.PaymentAttempt{{AttemptedAt: time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC), Status: payment.StatusAuthorized}},
+		},
+	}
+}
+
+func Test_PDFInvoiceRenderer_Render_Should_Return_PDF_Document(t *testing.T) {
+	// Arrange
+	renderer := outbound.NewPDFInvoiceRenderer()
+
+	// Act
+	data, err := renderer.Render(context.Background(), createTestInvoice())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "document must start with PDF header", bytes.HasPrefix(data, []byte("%PDF-1.4\n")), true)
+	assert.That(t, "document must end with EOF marker", bytes.HasSuffix(data, []byte("%%EOF\n")), true)
+	assert.That(t, "content type must be PDF", renderer.ContentType(), "application/pdf")
+}
+
+func Test_PDFInvoiceRenderer_Render_Should_Print_Invoice_Details(t *testing.T) {
+	// Arrange
+	renderer := outbound.NewPDFInvoiceRenderer()
+
+	// Act
+	data, _ := renderer.Render(context.Background(), createTestInvoice())
+
+	// Assert
+	assert.That(t, "number must be printed", bytes.Contains(data, []byte("(Invoice INV-res-001) Tj")), true)
+	assert.That(t, "guest must be encoded and escaped", bytes.Contains(data, []byte("J\xf6hn \\(Doe\\)")), true)
+	assert.That(t, "tax must be printed", bytes.Contains(data, []byte(`Tax \(19%, included\)`)), true)
+	assert.That(t, "total must be printed", bytes.Contains(data, []byte("300.00 EUR")), true)
+	assert.That(t, "transaction must be printed", bytes.Contains(data, []byte("tx-001")), true)
+}
+
+func Test_PDFInvoiceRenderer_Render_Should_Write_Valid_Cross_Reference_Table(t *testing.T) {
+	// Arrange
+	renderer := outbound.NewPDFInvoiceRenderer()
+	data, _ := renderer.Render(context.Background(), createTestInvoice())
+
+	// Act
+	xref := bytes.Index(data, []byte("\nxref\n")) + 1
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+
+	// Assert
+	assert.That(t, "startxref must point to the table", bytes.Contains(data, []byte("startxref\n"+strconv.Itoa(xref)+"\n")), true)
+	assert.That(t, "table must list all objects", len(offsets), 6)
+	for i, match := range offsets {
+		offset, _ := strconv.Atoi(string(match[1]))
+		prefix := fmt.Sprintf("%d 0 obj", i+1)
+		assert.That(t, "offset must point to object "+prefix, string(data[offset:offset+len(prefix)]), prefix)
+	}
+}
@@ -0,0 +1,69 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// PostgresGuestProfileRepository stores guest profiles in the guest_profiles table.
+// Profiles are kept out of kv_store so reservation scans never see them.
+// It implements the reservation.GuestProfileRepository port.
+type PostgresGuestProfileRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresGuestProfileRepository creates a new Postgres guest profile repository.
+// Schema is created by Docker init scripts (migrations/reservation/init.sql).
+func NewPostgresGuestProfileRepository(db *sql.DB) *PostgresGuestProfileRepository {
+	return &PostgresGuestProfileRepository{db: db}
+}
+
+// FindProfile returns the profile of the given guest, or nil if none was saved.
+func (r *PostgresGuestProfileRepository) FindProfile(ctx context.Context, guestID reservation.GuestID) (*reservation.GuestProfile, error) {
+	var value string
+	err := r.db.QueryRowContext(ctx,
+		"SELECT profile FROM guest_profiles WHERE guest_id = $1",
+		string(guestID),
+	).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query profile: %w", err)
+	}
+	var profile reservation.GuestProfile
+	if err := json.Unmarshal([]byte(value), &profile); err != nil {
+		return nil, fmt.Errorf("failed to decode profile: %w", err)
+	}
+	return &profile, nil
+}
+
+// SaveProfile creates or replaces the profile of a guest.
+func (r *PostgresGuestProfileRepository) SaveProfile(ctx context.Context, profile reservation.GuestProfile) error {
+	value, err := json.Marshal(profile)
+	if err != nil {
+		return fmt.Errorf("failed to encode profile: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO guest_profiles (guest_id, profile) VALUES ($1, $2)
+		 ON CONFLICT (guest_id) DO UPDATE SET profile = $2`,
+		string(profile.GuestID), string(value),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+	return nil
+}
+
+// DeleteProfile removes the profile of a guest if it exists.
+func (r *PostgresGuestProfileRepository) DeleteProfile(ctx context.Context, guestID reservation.GuestID) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM guest_profiles WHERE guest_id = $1", string(guestID)); err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+//go:build integration
+
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Postgres Integration Tests
+// ============================================================================
+
+func Test_PostgresReservationRepository_Should_Conform(t *testing.T) {
+	db := startPostgres(t, "reservation", outbound.PostgresPoolConfig{})
+	checkReservationRepositoryConformance(t, outbound.NewPostgresReservationRepository(db.DB))
+}
+
+func Test_PostgresReservationRepository_With_Encryption_Should_Conform(t *testing.T) {
+	db := startPostgres(t, "reservation", outbound.PostgresPoolConfig{})
+	encryptor, err := outbound.NewAESGCMEncryptorFromSpec("k1:MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+	assert.That(t, "encryptor error must be nil", err == nil, true)
+	checkReservationRepositoryConformance(t,
+		outbound.NewEncryptedReservationRepository(outbound.NewPostgresReservationRepository(db.DB), encryptor))
+}
+
+func Test_PostgresPaymentRepository_Should_Conform(t *testing.T) {
+	db := startPostgres(t, "payment", outbound.PostgresPoolConfig{})
+	checkPaymentRepositoryConformance(t, outbound.NewPostgresPaymentRepository(db.DB))
+}
+
+func Test_PostgresPaymentRepository_With_Pgxpool_Should_Conform(t *testing.T) {
+	db := startPostgres(t, "payment", outbound.PostgresPoolConfig{Driver: "pgxpool", MaxOpenConns: 4})
+	checkPaymentRepositoryConformance(t, outbound.NewPostgresPaymentRepository(db.DB))
+}
+
+func Test_PostgresGuestProfileRepository_Should_Conform(t *testing.T) {
+	db := startPostgres(t, "reservation", outbound.PostgresPoolConfig{})
+	checkGuestProfileRepositoryConformance(t, outbound.NewPostgresGuestProfileRepository(db.DB))
+}
+
+func Test_PostgresSessionStore_Should_Save_Read_And_Delete_Sessions(t *testing.T) {
+	// Arrange
+	db := startPostgres(t, "reservation", outbound.PostgresPoolConfig{})
+	store := outbound.NewPostgresSessionStore(db.DB)
+	ctx := context.Background()
+	claims := web.IdentityTokenClaims{Email: "john@example.com", Name: "John Doe"}
+	_ = store.Save(ctx, "session-001", claims, time.Hour)
+	_ = store.Save(ctx, "session-002", claims, time.Hour)
+	_ = store.Save(ctx, "session-expired", claims, -time.Second)
+
+	// Act
+	read, readErr := store.Read(ctx, "session-001")
+	expired, _ := store.Read(ctx, "session-expired")
+	count, deleteErr := store.DeleteByEmail(ctx, "john@example.com")
+
+	// Assert
+	assert.That(t, "read error must be nil", readErr == nil, true)
+	assert.That(t, "email must match", read.Email, "john@example.com")
+	assert.That(t, "expired session must be nil", expired == nil, true)
+	assert.That(t, "delete error must be nil", deleteErr == nil, true)
+	assert.That(t, "must delete the sessions of the guest", count, 2)
+}
+
+func Test_PostgresTableAccess_Should_Create_Read_Update_And_Delete(t *testing.T) {
+	// Arrange
+	db := startPostgres(t, "reservation", outbound.PostgresPoolConfig{})
+	access := outbound.NewPostgresTableAccess[string, outbound.DelayedMessage](db.DB, "delayed_events")
+	ctx := context.Background()
+	message := outbound.DelayedMessage{ID: "msg-001", Message: messaging.NewMessage("reservation.reminder", []byte(`{}`))}
+
+	// Act
+	createErr := access.Create(ctx, message.ID, message)
+	duplicateErr := access.Create(ctx, message.ID, message)
+	message.Priority = true
+	updateErr := access.Update(ctx, message.ID, message)
+	read, readErr := access.Read(ctx, message.ID)
+	deleteErr := access.Delete(ctx, message.ID)
+	_, missingErr := access.Read(ctx, message.ID)
+
+	// Assert
+	assert.That(t, "create error must be nil", createErr == nil, true)
+	assert.That(t, "duplicate must be rejected", duplicateErr != nil && duplicateErr.Error() == resource.ErrorResourceAlreadyExists, true)
+	assert.That(t, "update error must be nil", updateErr == nil, true)
+	assert.That(t, "read error must be nil", readErr == nil, true)
+	assert.That(t, "update must be stored", read.Priority, true)
+	assert.That(t, "topic must be kept", read.Message.Topic, "reservation.reminder")
+	assert.That(t, "delete error must be nil", deleteErr == nil, true)
+	assert.That(t, "deleted message must not be found", missingErr != nil && missingErr.Error() == resource.ErrorResourceNotFound, true)
+}
+
+func Test_DelayQueue_With_Postgres_Should_Release_Messages_Delayed_On_Other_Replica(t *testing.T) {
+	// Arrange
+	db := startPostgres(t, "reservation", outbound.PostgresPoolConfig{})
+	follower := outbound.NewDelayQueue(&mockDispatcher{}, outbound.NewPostgresTableAccess[string, outbound.DelayedMessage](db.DB, "delayed_events"))
+	published := &mockDispatcher{}
+	leader := outbound.NewDelayQueue(published, outbound.NewPostgresTableAccess[string, outbound.DelayedMessage](db.DB, "delayed_events"))
+	at := time.Now().Add(time.Hour)
+	_ = follower.Publish(shared.WithDeliverAt(context.Background(), at), messaging.NewMessage("reservation.reminder", []byte(`{}`)))
+
+	// Act
+	released, err := leader.Release(context.Background(), at)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "message must be released by the leader", released, 1)
+	assert.That(t, "message must be published", published.publishedMessages[0].Topic, "reservation.reminder")
+}
+
+func Test_PostgresTableAccess_With_Processed_Commands_Should_Record_Command_Once(t *testing.T) {
+	// Arrange
+	db := startPostgres(t, "payment", outbound.PostgresPoolConfig{})
+	replica1 := outbound.NewPostgresTableAccess[payment.CommandID, payment.ProcessedCommand](db.DB, "processed_commands")
+	replica2 := outbound.NewPostgresTableAccess[payment.CommandID, payment.ProcessedCommand](db.DB, "processed_commands")
+	ctx := context.Background()
+	id := payment.CommandID("reservation.created/res-001")
+
+	// Act
+	firstErr := replica1.Create(ctx, id, payment.NewProcessedCommand(id, "pay-001"))
+	secondErr := replica2.Create(ctx, id, payment.NewProcessedCommand(id, "pay-002"))
+	cmd, readErr := replica2.Read(ctx, id)
+
+	// Assert
+	assert.That(t, "first error must be nil", firstErr == nil, true)
+	assert.That(t, "second record must be rejected", secondErr != nil && secondErr.Error() == resource.ErrorResourceAlreadyExists, true)
+	assert.That(t, "read error must be nil", readErr == nil, true)
+	assert.That(t, "first record must be kept", cmd.PaymentID, payment.PaymentID("pay-001"))
+}
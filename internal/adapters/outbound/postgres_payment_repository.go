@@ -0,0 +1,55 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+)
+
+// PostgresPaymentRepository stores payments using PostgresAccess from cloud-native-utils
+// and queries the JSON values in kv_store directly for reservation lookups.
+// It implements the payment.PaymentRepository port.
+type PostgresPaymentRepository struct {
+	*resource.PostgresAccess[payment.PaymentID, payment.Payment]
+	db *sql.DB
+}
+
+// NewPostgresPaymentRepository creates a new Postgres payment repository.
+func NewPostgresPaymentRepository(db *sql.DB) *PostgresPaymentRepository {
+	return &PostgresPaymentRepository{
+		PostgresAccess: resource.NewPostgresAccess[payment.PaymentID, payment.Payment](db),
+		db:             db,
+	}
+}
+
+// FindByReservationID returns all payments for the given reservation.
+// The lookup is backed by the idx_kv_store_reservation_id expression index.
+func (r *PostgresPaymentRepository) FindByReservationID(ctx context.Context, reservationID payment.ReservationID) ([]payment.Payment, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT value FROM kv_store WHERE value::jsonb->>'ReservationID' = $1",
+		string(reservationID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var payments []payment.Payment
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to scan payment: %w", err)
+		}
+		var p payment.Payment
+		if err := json.Unmarshal([]byte(value), &p); err != nil {
+			return nil, fmt.Errorf("failed to decode payment: %w", err)
+		}
+		payments = append(payments, p)
+	}
+
+	return payments, rows.Err()
+}
@@ -0,0 +1,173 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// PostgresPoolConfig tunes the connection pool of a Postgres database.
+// Zero values keep the defaults of the pool.
+type PostgresPoolConfig struct {
+	Driver          string        // "stdlib" (database/sql pool, default) or "pgxpool"
+	MaxOpenConns    int           // Maximum open connections
+	MaxIdleConns    int           // Maximum idle connections (stdlib only, pgxpool closes them after ConnMaxIdleTime)
+	ConnMaxLifetime time.Duration // Connections are replaced after this time, e.g. to rebalance after a failover
+	ConnMaxIdleTime time.Duration // Idle connections are closed after this time
+
+	// StatementCacheCapacity is the number of prepared statements cached per connection.
+	// Each distinct SQL is parsed and planned once per connection and then executed by name.
+	// Zero keeps the default of pgx (512); a negative value disables the cache, e.g. for
+	// PgBouncer in transaction mode, and sends every query with the extended protocol.
+	StatementCacheCapacity int
+}
+
+// PostgresDB is a Postgres database for the repositories, which take a *sql.DB.
+// With the pgxpool driver, the *sql.DB acquires its connections from a pgxpool.Pool,
+// which is closed with the database.
+type PostgresDB struct {
+	*sql.DB
+	name    string
+	pool    *pgxpool.Pool
+	queries *QueryTracer
+}
+
+// OpenPostgres opens the Postgres database of the DSN with a tuned connection pool.
+// The name labels its pool and query metrics, e.g. "reservation". Connections are opened lazily.
+func OpenPostgres(ctx context.Context, name, dsn string, config PostgresPoolConfig) (*PostgresDB, error) {
+	queries := NewQueryTracer(name)
+	switch config.Driver {
+	case "", "stdlib":
+		connConfig, err := pgx.ParseConfig(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s database DSN: %w", name, err)
+		}
+		configureConn(connConfig, config.StatementCacheCapacity, queries)
+		db := stdlib.OpenDB(*connConfig)
+		db.SetMaxOpenConns(config.MaxOpenConns)
+		db.SetMaxIdleConns(config.MaxIdleConns)
+		db.SetConnMaxLifetime(config.ConnMaxLifetime)
+		db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+		return &PostgresDB{DB: db, name: name, queries: queries}, nil
+	case "pgxpool":
+		poolConfig, err := pgxpool.ParseConfig(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s database DSN: %w", name, err)
+		}
+		configureConn(poolConfig.ConnConfig, config.StatementCacheCapacity, queries)
+		if config.MaxOpenConns > 0 {
+			poolConfig.MaxConns = int32(config.MaxOpenConns)
+		}
+		if config.ConnMaxLifetime > 0 {
+			poolConfig.MaxConnLifetime = config.ConnMaxLifetime
+		}
+		if config.ConnMaxIdleTime > 0 {
+			poolConfig.MaxConnIdleTime = config.ConnMaxIdleTime
+		}
+		pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s database pool: %w", name, err)
+		}
+		return &PostgresDB{DB: stdlib.OpenDBFromPool(pool), name: name, pool: pool, queries: queries}, nil
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", config.Driver)
+	}
+}
+
+// configureConn sets the statement cache and the query tracer of the connections.
+func configureConn(connConfig *pgx.ConnConfig, statementCacheCapacity int, tracer pgx.QueryTracer) {
+	switch {
+	case statementCacheCapacity > 0:
+		connConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+		connConfig.StatementCacheCapacity = statementCacheCapacity
+	case statementCacheCapacity < 0:
+		connConfig.DefaultQueryExecMode = pgx.QueryExecModeExec
+		connConfig.StatementCacheCapacity = 0
+	}
+	connConfig.Tracer = tracer
+}
+
+// Close closes the database and its pgxpool.Pool.
+func (d *PostgresDB) Close() error {
+	err := d.DB.Close()
+	if d.pool != nil {
+		d.pool.Close()
+	}
+	return err
+}
+
+// Collectors returns the Prometheus collectors of the database, labeled with db_name:
+// the database/sql statistics (go_sql_*, e.g. in-use connections and wait count), the
+// query latencies (db_query_duration_seconds) and, with the pgxpool driver, the pgxpool
+// statistics (pgxpool_*).
+func (d *PostgresDB) Collectors() []prometheus.Collector {
+	cs := []prometheus.Collector{collectors.NewDBStatsCollector(d.DB, d.name), d.queries}
+	if d.pool != nil {
+		cs = append(cs, newPgxPoolCollector(d.pool, d.name))
+	}
+	return cs
+}
+
+// pgxPoolCollector exports the statistics of a pgxpool.Pool.
+type pgxPoolCollector struct {
+	pool                 *pgxpool.Pool
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+}
+
+// newPgxPoolCollector creates a collector for the pool of the named database.
+func newPgxPoolCollector(pool *pgxpool.Pool, name string) *pgxPoolCollector {
+	labels := prometheus.Labels{"db_name": name}
+	desc := func(metric, help string) *prometheus.Desc {
+		return prometheus.NewDesc("pgxpool_"+metric, help, nil, labels)
+	}
+	return &pgxPoolCollector{
+		pool:                 pool,
+		acquiredConns:        desc("acquired_connections", "The number of connections currently in use."),
+		idleConns:            desc("idle_connections", "The number of idle connections."),
+		totalConns:           desc("total_connections", "The number of open connections."),
+		maxConns:             desc("max_connections", "The maximum number of connections."),
+		acquireCount:         desc("acquire_count_total", "The total number of acquired connections."),
+		emptyAcquireCount:    desc("empty_acquire_count_total", "The total number of acquires that waited for a connection."),
+		canceledAcquireCount: desc("canceled_acquire_count_total", "The total number of acquires canceled by their context."),
+		acquireDuration:      desc("acquire_duration_seconds_total", "The total time spent acquiring connections."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *pgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquireCount
+	ch <- c.acquireDuration
+}
+
+// Collect implements prometheus.Collector.
+func (c *pgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
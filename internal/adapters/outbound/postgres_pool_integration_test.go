@@ -0,0 +1,53 @@
+//go:build integration
+
+package outbound_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Postgres Statement Cache Benchmarks
+// ============================================================================
+// Each benchmark starts its own payment database (see startPostgres), e.g.:
+//   go test -tags=integration -run '^$' -bench Postgres ./internal/adapters/outbound/
+
+// benchmarkPaymentLookups reads a payment by ID and by reservation, like the saga does.
+func benchmarkPaymentLookups(b *testing.B, statementCacheCapacity int) {
+	ctx := context.Background()
+	db := startPostgres(b, "payment", outbound.PostgresPoolConfig{
+		MaxOpenConns:           4,
+		MaxIdleConns:           4,
+		StatementCacheCapacity: statementCacheCapacity,
+	})
+	repo := outbound.NewPostgresPaymentRepository(db.DB)
+	id := payment.PaymentID(fmt.Sprintf("bench-pay-%d", statementCacheCapacity))
+	p := payment.NewPayment(id, "bench-res-001", shared.NewMoney(10000, "EUR"), "credit_card")
+	if err := repo.Create(ctx, id, *p); err != nil {
+		b.Fatalf("failed to create payment: %v", err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := repo.Read(ctx, id); err != nil {
+			b.Fatalf("failed to read payment: %v", err)
+		}
+		if _, err := repo.FindByReservationID(ctx, "bench-res-001"); err != nil {
+			b.Fatalf("failed to find payments: %v", err)
+		}
+	}
+}
+
+func Benchmark_Postgres_Payment_Lookups_With_Statement_Cache(b *testing.B) {
+	benchmarkPaymentLookups(b, 512)
+}
+
+func Benchmark_Postgres_Payment_Lookups_Without_Statement_Cache(b *testing.B) {
+	benchmarkPaymentLookups(b, -1)
+}
@@ -0,0 +1,92 @@
+package outbound_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ============================================================================
+// PostgresDB Tests
+// ============================================================================
+
+const testPostgresDSN = "host=localhost port=5432 user=test password=test dbname=test sslmode=disable"
+
+// gatherPostgresMetrics registers the collectors of db and returns the metric names.
+func gatherPostgresMetrics(t *testing.T, db *outbound.PostgresDB) map[string]bool {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(db.Collectors()...)
+	families, err := registry.Gather()
+	assert.That(t, "gather error must be nil", err == nil, true)
+	names := make(map[string]bool)
+	for _, family := range families {
+		names[family.GetName()] = true
+	}
+	return names
+}
+
+func Test_OpenPostgres_With_Stdlib_Should_Apply_Pool_Config(t *testing.T) {
+	// Arrange
+	config := outbound.PostgresPoolConfig{MaxOpenConns: 7, MaxIdleConns: 3, ConnMaxLifetime: time.Hour}
+
+	// Act
+	db, err := outbound.OpenPostgres(context.Background(), "reservation", testPostgresDSN, config)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	defer func() { _ = db.Close() }()
+	assert.That(t, "max open connections must match", db.Stats().MaxOpenConnections, 7)
+}
+
+func Test_OpenPostgres_With_Stdlib_Should_Export_Pool_Metrics(t *testing.T) {
+	// Arrange
+	db, _ := outbound.OpenPostgres(context.Background(), "reservation", testPostgresDSN, outbound.PostgresPoolConfig{})
+	defer func() { _ = db.Close() }()
+
+	// Act
+	names := gatherPostgresMetrics(t, db)
+
+	// Assert
+	assert.That(t, "in-use metric must be exported", names["go_sql_in_use_connections"], true)
+	assert.That(t, "wait count metric must be exported", names["go_sql_wait_count_total"], true)
+	assert.That(t, "pgxpool metrics must not be exported", names["pgxpool_acquired_connections"], false)
+}
+
+func Test_OpenPostgres_With_Pgxpool_Should_Export_Pool_Metrics(t *testing.T) {
+	// Arrange
+	config := outbound.PostgresPoolConfig{Driver: "pgxpool", MaxOpenConns: 5}
+	db, err := outbound.OpenPostgres(context.Background(), "payment", testPostgresDSN, config)
+	assert.That(t, "error must be nil", err == nil, true)
+	defer func() { _ = db.Close() }()
+
+	// Act
+	names := gatherPostgresMetrics(t, db)
+
+	// Assert
+	assert.That(t, "in-use metric must be exported", names["go_sql_in_use_connections"], true)
+	assert.That(t, "acquired metric must be exported", names["pgxpool_acquired_connections"], true)
+	assert.That(t, "empty acquire metric must be exported", names["pgxpool_empty_acquire_count_total"], true)
+}
+
+func Test_OpenPostgres_With_Unknown_Driver_Should_Fail(t *testing.T) {
+	// Act
+	_, err := outbound.OpenPostgres(context.Background(), "reservation", testPostgresDSN, outbound.PostgresPoolConfig{Driver: "mysql"})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "error must name the driver", strings.Contains(err.Error(), "mysql"), true)
+}
+
+func Test_OpenPostgres_With_Invalid_DSN_Should_Fail(t *testing.T) {
+	// Act
+	_, err := outbound.OpenPostgres(context.Background(), "reservation", "port=invalid", outbound.PostgresPoolConfig{})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
@@ -0,0 +1,79 @@
+package outbound
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxQueryLabelLength limits the query label of the latency metric.
+const maxQueryLabelLength = 120
+
+// tracedQueryKey is the context key of the traced query.
+type tracedQueryKey struct{}
+
+// tracedQuery is a query in flight.
+type tracedQuery struct {
+	sql   string
+	start time.Time
+}
+
+// QueryTracer records the latency of every SQL query of a database in the
+// db_query_duration_seconds histogram, labeled with the normalized SQL and its status.
+// The repositories only send static SQL with placeholders, so the labels are bounded.
+// It implements the pgx.QueryTracer and prometheus.Collector interfaces.
+type QueryTracer struct {
+	duration *prometheus.HistogramVec
+}
+
+// NewQueryTracer creates a new query tracer for the named database, e.g. "reservation".
+func NewQueryTracer(name string) *QueryTracer {
+	return &QueryTracer{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "db_query_duration_seconds",
+			Help:        "The latency of SQL queries.",
+			ConstLabels: prometheus.Labels{"db_name": name},
+			Buckets:     []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"query", "status"}),
+	}
+}
+
+// TraceQueryStart stores the query and its start time in the context.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, tracedQueryKey{}, tracedQuery{sql: data.SQL, start: time.Now()})
+}
+
+// TraceQueryEnd observes the latency of the query.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	query, ok := ctx.Value(tracedQueryKey{}).(tracedQuery)
+	if !ok {
+		return
+	}
+	status := "ok"
+	if data.Err != nil {
+		status = "error"
+	}
+	t.duration.WithLabelValues(normalizeQuery(query.sql), status).Observe(time.Since(query.start).Seconds())
+}
+
+// Describe implements prometheus.Collector.
+func (t *QueryTracer) Describe(ch chan<- *prometheus.Desc) {
+	t.duration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (t *QueryTracer) Collect(ch chan<- prometheus.Metric) {
+	t.duration.Collect(ch)
+}
+
+// normalizeQuery collapses the whitespace of the SQL and truncates it to a metric label.
+func normalizeQuery(sql string) string {
+	query := strings.Join(strings.Fields(sql), " ")
+	if len(query) > maxQueryLabelLength {
+		query = query[:maxQueryLabelLength]
+	}
+	return query
+}
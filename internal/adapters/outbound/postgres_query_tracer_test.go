@@ -0,0 +1,89 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// ============================================================================
+// QueryTracer Tests
+// ============================================================================
+
+// gatherQueryDurations returns the histograms of db_query_duration_seconds by query and status.
+func gatherQueryDurations(t *testing.T, tracer *outbound.QueryTracer) map[string]*dto.Histogram {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(tracer)
+	families, err := registry.Gather()
+	assert.That(t, "gather error must be nil", err == nil, true)
+	histograms := make(map[string]*dto.Histogram)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			histograms[labels["db_name"]+"|"+labels["query"]+"|"+labels["status"]] = metric.GetHistogram()
+		}
+	}
+	return histograms
+}
+
+// traceQuery runs a query through the tracer.
+func traceQuery(tracer *outbound.QueryTracer, sql string, err error) {
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: err})
+}
+
+func Test_QueryTracer_Should_Observe_Latency_Per_Query(t *testing.T) {
+	// Arrange
+	tracer := outbound.NewQueryTracer("payment")
+
+	// Act
+	traceQuery(tracer, "SELECT value FROM kv_store WHERE key = $1", nil)
+	traceQuery(tracer, "SELECT value FROM kv_store WHERE key = $1", nil)
+	traceQuery(tracer, "SELECT value FROM kv_store", nil)
+
+	// Assert
+	histograms := gatherQueryDurations(t, tracer)
+	assert.That(t, "read by key must be observed twice", histograms["payment|SELECT value FROM kv_store WHERE key = $1|ok"].GetSampleCount(), uint64(2))
+	assert.That(t, "read all must be observed once", histograms["payment|SELECT value FROM kv_store|ok"].GetSampleCount(), uint64(1))
+}
+
+func Test_QueryTracer_With_Failed_Query_Should_Observe_Error_Status(t *testing.T) {
+	// Arrange
+	tracer := outbound.NewQueryTracer("reservation")
+
+	// Act
+	traceQuery(tracer, "DELETE FROM kv_store WHERE key = $1", errors.New("connection reset"))
+
+	// Assert
+	histograms := gatherQueryDurations(t, tracer)
+	assert.That(t, "error must be observed", histograms["reservation|DELETE FROM kv_store WHERE key = $1|error"].GetSampleCount(), uint64(1))
+}
+
+func Test_QueryTracer_Should_Normalize_Query_Label(t *testing.T) {
+	// Arrange
+	tracer := outbound.NewQueryTracer("payment")
+	long := "SELECT value FROM kv_store\n\t WHERE value::jsonb->>'ReservationID' = $1 " + strings.Repeat("AND true ", 20)
+
+	// Act
+	traceQuery(tracer, long, nil)
+
+	// Assert
+	histograms := gatherQueryDurations(t, tracer)
+	assert.That(t, "must observe one query", len(histograms), 1)
+	for key := range histograms {
+		query := strings.Split(key, "|")[1]
+		assert.That(t, "whitespace must be collapsed", strings.HasPrefix(query, "SELECT value FROM kv_store WHERE value::jsonb"), true)
+		assert.That(t, "label must be truncated", len(query), 120)
+	}
+}
@@ -0,0 +1,55 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// PostgresReservationRepository stores reservations using PostgresAccess from cloud-native-utils
+// and queries the JSON values in kv_store directly for guest lookups.
+// It implements the reservation.ReservationRepository port.
+type PostgresReservationRepository struct {
+	*resource.PostgresAccess[reservation.ReservationID, reservation.Reservation]
+	db *sql.DB
+}
+
+// NewPostgresReservationRepository creates a new Postgres reservation repository.
+func NewPostgresReservationRepository(db *sql.DB) *PostgresReservationRepository {
+	return &PostgresReservationRepository{
+		PostgresAccess: resource.NewPostgresAccess[reservation.ReservationID, reservation.Reservation](db),
+		db:             db,
+	}
+}
+
+// FindByGuestID returns all reservations of the given guest.
+// The lookup is backed by the idx_kv_store_guest_id expression index.
+func (r *PostgresReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT value FROM kv_store WHERE value::jsonb->>'GuestID' = $1",
+		string(guestID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reservations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var reservations []reservation.Reservation
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to scan reservation: %w", err)
+		}
+		var res reservation.Reservation
+		if err := json.Unmarshal([]byte(value), &res); err != nil {
+			return nil, fmt.Errorf("failed to decode reservation: %w", err)
+		}
+		reservations = append(reservations, res)
+	}
+
+	return reservations, rows.Err()
+}
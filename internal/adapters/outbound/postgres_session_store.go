@@ -0,0 +1,140 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/web"
+)
+
+// PostgresSessionStore stores login sessions in the sessions table.
+// Expired rows are ignored on read and removed whenever a new session is saved.
+// The used magic link tokens and the sign-in attempts are kept in the
+// login_nonces and login_attempts tables.
+// It implements the inbound.SessionStore port.
+type PostgresSessionStore struct {
+	db *sql.DB
+}
+
+// NewPostgresSessionStore creates a new Postgres session store.
+// Schema is created by Docker init scripts (migrations/reservation/init.sql).
+func NewPostgresSessionStore(db *sql.DB) *PostgresSessionStore {
+	return &PostgresSessionStore{db: db}
+}
+
+// Save stores the claims of a new session for the given time to live.
+func (s *PostgresSessionStore) Save(ctx context.Context, id string, claims web.IdentityTokenClaims, ttl time.Duration) error {
+	value, err := json.Marshal(claims)
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	now := time.Now()
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM sessions WHERE expires_at <= $1", now); err != nil {
+		return fmt.Errorf("failed to remove expired sessions: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO sessions (id, email, claims, expires_at) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (id) DO UPDATE SET email = $2, claims = $3, expires_at = $4`,
+		id, claims.Email, string(value), now.Add(ttl),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// Read returns the claims of a session, or nil if it is unknown or expired.
+func (s *PostgresSessionStore) Read(ctx context.Context, id string) (*web.IdentityTokenClaims, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx,
+		"SELECT claims FROM sessions WHERE id = $1 AND expires_at > $2",
+		id, time.Now(),
+	).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	var claims web.IdentityTokenClaims
+	if err := json.Unmarshal([]byte(value), &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode session: %w", err)
+	}
+	return &claims, nil
+}
+
+// Touch extends the time to live of a session.
+func (s *PostgresSessionStore) Touch(ctx context.Context, id string, ttl time.Duration) error {
+	now := time.Now()
+	if _, err := s.db.ExecContext(ctx,
+		"UPDATE sessions SET expires_at = $2 WHERE id = $1 AND expires_at > $3",
+		id, now.Add(ttl), now,
+	); err != nil {
+		return fmt.Errorf("failed to extend session: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a session.
+func (s *PostgresSessionStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM sessions WHERE id = $1", id); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// DeleteByEmail removes all sessions of a guest and returns their count.
+func (s *PostgresSessionStore) DeleteByEmail(ctx context.Context, email string) (int, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM sessions WHERE email = $1", email)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sessions: %w", err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted sessions: %w", err)
+	}
+	return int(count), nil
+}
+
+// UseNonce marks the nonce of a single-use token as used until ttl passed and reports whether it was unused.
+func (s *PostgresSessionStore) UseNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM login_nonces WHERE expires_at <= $1", now); err != nil {
+		return false, fmt.Errorf("failed to remove expired nonces: %w", err)
+	}
+	result, err := s.db.ExecContext(ctx,
+		"INSERT INTO login_nonces (nonce, expires_at) VALUES ($1, $2) ON CONFLICT (nonce) DO NOTHING",
+		nonce, now.Add(ttl),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to use nonce: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to use nonce: %w", err)
+	}
+	return inserted == 1, nil
+}
+
+// CountAttempt counts an attempt for the key in its current window and returns the attempts of the window.
+// The window starts with the first attempt after the previous window ended.
+func (s *PostgresSessionStore) CountAttempt(ctx context.Context, key string, window time.Duration) (int, error) {
+	now := time.Now()
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		`INSERT INTO login_attempts (key, window_start, count) VALUES ($1, $2, 1)
+		 ON CONFLICT (key) DO UPDATE SET
+		   window_start = CASE WHEN login_attempts.window_start <= $3 THEN $2 ELSE login_attempts.window_start END,
+		   count = CASE WHEN login_attempts.window_start <= $3 THEN 1 ELSE login_attempts.count + 1 END
+		 RETURNING count`,
+		key, now, now.Add(-window),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count attempt: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,123 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// PostgresTableAccess stores resources as JSON in a table of their own with the
+// columns key and value, like PostgresAccess from cloud-native-utils does in kv_store.
+// It is used for the state that all replicas share, e.g. the compensation queue and
+// the delayed events, so it is kept out of kv_store and its scans.
+// The key is the primary key, so Create fails for a key that exists already.
+// It implements the resource.Access interface.
+type PostgresTableAccess[K ~string, V any] struct {
+	db    *sql.DB
+	table string
+}
+
+// NewPostgresTableAccess creates a new Postgres access to the table.
+// Schema is created by Docker init scripts (migrations/<context>/init.sql).
+func NewPostgresTableAccess[K ~string, V any](db *sql.DB, table string) *PostgresTableAccess[K, V] {
+	return &PostgresTableAccess[K, V]{db: db, table: table}
+}
+
+// Create creates a new resource.
+func (a *PostgresTableAccess[K, V]) Create(ctx context.Context, key K, value V) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", a.table, err)
+	}
+	result, err := a.db.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s (key, value) VALUES ($1, $2) ON CONFLICT (key) DO NOTHING", a.table),
+		string(key), string(encoded),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert into %s: %w", a.table, err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return errors.New(resource.ErrorResourceAlreadyExists)
+	}
+	return nil
+}
+
+// Read reads a resource.
+func (a *PostgresTableAccess[K, V]) Read(ctx context.Context, key K) (*V, error) {
+	var encoded string
+	err := a.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT value FROM %s WHERE key = $1", a.table),
+		string(key),
+	).Scan(&encoded)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New(resource.ErrorResourceNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", a.table, err)
+	}
+	var value V
+	if err := json.Unmarshal([]byte(encoded), &value); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", a.table, err)
+	}
+	return &value, nil
+}
+
+// ReadAll reads all resources in the order of their keys.
+func (a *PostgresTableAccess[K, V]) ReadAll(ctx context.Context) ([]V, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf("SELECT value FROM %s ORDER BY key", a.table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", a.table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	values := []V{}
+	for rows.Next() {
+		var encoded string
+		if err := rows.Scan(&encoded); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", a.table, err)
+		}
+		var value V
+		if err := json.Unmarshal([]byte(encoded), &value); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", a.table, err)
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// Update updates a resource.
+func (a *PostgresTableAccess[K, V]) Update(ctx context.Context, key K, value V) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", a.table, err)
+	}
+	result, err := a.db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET value = $2 WHERE key = $1", a.table),
+		string(key), string(encoded),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", a.table, err)
+	}
+	return a.checkFound(result)
+}
+
+// Delete deletes a resource.
+func (a *PostgresTableAccess[K, V]) Delete(ctx context.Context, key K) error {
+	result, err := a.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE key = $1", a.table), string(key))
+	if err != nil {
+		return fmt.Errorf("failed to delete from %s: %w", a.table, err)
+	}
+	return a.checkFound(result)
+}
+
+// checkFound returns ErrorResourceNotFound if the statement changed no row.
+func (a *PostgresTableAccess[K, V]) checkFound(result sql.Result) error {
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return errors.New(resource.ErrorResourceNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package outbound
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// prioritySuffix is appended to the topic of the messages published with priority.
+const prioritySuffix = ".priority"
+
+// PriorityTopic returns the topic the messages of the topic are published to with priority,
+// e.g. "payment.failed.priority".
+func PriorityTopic(topic string) string {
+	return topic + prioritySuffix
+}
+
+// PriorityDispatcher decorates a messaging.Dispatcher so that urgent messages preempt the
+// others. Messages of the priority topics, or published with shared.WithPriority, are routed
+// to the priority topic of their topic. Every handler is subscribed to both topics, so with
+// Kafka the priority topic has readers of its own and its messages do not wait behind the
+// backlog of the topic. Handlers receive the messages with their original topic.
+type PriorityDispatcher struct {
+	dispatcher messaging.Dispatcher
+	topics     map[string]bool
+}
+
+// NewPriorityDispatcher creates a new priority dispatcher that publishes the messages
+// of the topics with priority, e.g. "payment.failed" for the compensation of a booking.
+func NewPriorityDispatcher(dispatcher messaging.Dispatcher, topics ...string) *PriorityDispatcher {
+	d := &PriorityDispatcher{
+		dispatcher: dispatcher,
+		topics:     make(map[string]bool, len(topics)),
+	}
+	for _, topic := range topics {
+		d.topics[topic] = true
+	}
+	return d
+}
+
+// Publish publishes a message with the decorated dispatcher, to its priority topic
+// if the message has priority.
+func (d *PriorityDispatcher) Publish(ctx context.Context, message messaging.Message) error {
+	if d.topics[message.Topic] || shared.Priority(ctx) {
+		ctx = shared.WithPriority(ctx)
+		message.Topic = PriorityTopic(message.Topic)
+	}
+	return d.dispatcher.Publish(ctx, message)
+}
+
+// Subscribe subscribes the handler to the topic and its priority topic.
+func (d *PriorityDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	if err := d.dispatcher.Subscribe(ctx, PriorityTopic(topic), restoreTopic(fn)); err != nil {
+		return err
+	}
+	return d.dispatcher.Subscribe(ctx, topic, fn)
+}
+
+// SubscribeGroup subscribes the handler to the topic and its priority topic in a consumer group.
+// Without consumer groups in the decorated dispatcher, it subscribes as usual.
+func (d *PriorityDispatcher) SubscribeGroup(ctx context.Context, group, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	subscriber, ok := d.dispatcher.(groupSubscriber)
+	if !ok {
+		return d.Subscribe(ctx, topic, fn)
+	}
+	if err := subscriber.SubscribeGroup(ctx, group, PriorityTopic(topic), restoreTopic(fn)); err != nil {
+		return err
+	}
+	return subscriber.SubscribeGroup(ctx, group, topic, fn)
+}
+
+// restoreTopic passes the messages of a priority topic to the handler with their original topic.
+func restoreTopic(fn service.Function[messaging.Message, messaging.MessageState]) service.Function[messaging.Message, messaging.MessageState] {
+	return func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		msg.Topic = strings.TrimSuffix(msg.Topic, prioritySuffix)
+		return fn(ctx, msg)
+	}
+}
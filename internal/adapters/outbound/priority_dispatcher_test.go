@@ -0,0 +1,61 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// PriorityDispatcher Tests
+// ============================================================================
+
+func Test_PriorityDispatcher_Should_Route_Priority_Topic(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	inner := &mockDispatcher{}
+	dispatcher := outbound.NewPriorityDispatcher(inner, "payment.failed")
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("payment.failed", nil))
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("payment.authorized", nil))
+
+	// Assert
+	assert.That(t, "priority topic must be routed", inner.publishedMessages[0].Topic, "payment.failed.priority")
+	assert.That(t, "other topics must not be routed", inner.publishedMessages[1].Topic, "payment.authorized")
+}
+
+func Test_PriorityDispatcher_With_Priority_Context_Should_Route_Message(t *testing.T) {
+	// Arrange
+	ctx := shared.WithPriority(context.Background())
+	inner := &mockDispatcher{}
+	dispatcher := outbound.NewPriorityDispatcher(inner)
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("reservation.cancelled", nil))
+
+	// Assert
+	assert.That(t, "message must be routed", inner.publishedMessages[0].Topic, "reservation.cancelled.priority")
+}
+
+func Test_PriorityDispatcher_Should_Deliver_Priority_Message_With_Original_Topic(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := outbound.NewPriorityDispatcher(messaging.NewInternalDispatcher(), "payment.failed")
+	var topics []string
+	_ = dispatcher.Subscribe(ctx, "payment.failed", func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		topics = append(topics, msg.Topic)
+		return messaging.MessageStateCompleted, nil
+	})
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage("payment.failed", nil))
+
+	// Assert
+	assert.That(t, "message must be handled once", len(topics), 1)
+	assert.That(t, "handler must receive the original topic", topics[0], "payment.failed")
+}
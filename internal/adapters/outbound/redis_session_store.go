@@ -0,0 +1,156 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisSessionStore stores login sessions in Redis with native key expiration.
+// Each guest has a set of session IDs so all of their sessions can be revoked at once.
+// It implements the inbound.SessionStore port.
+type RedisSessionStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisSessionStore creates a new Redis session store.
+func NewRedisSessionStore(client redis.UniversalClient) *RedisSessionStore {
+	return &RedisSessionStore{client: client}
+}
+
+// Save stores the claims of a new session for the given time to live.
+func (s *RedisSessionStore) Save(ctx context.Context, id string, claims web.IdentityTokenClaims, ttl time.Duration) error {
+	value, err := json.Marshal(claims)
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey(id), value, ttl)
+		pipe.SAdd(ctx, guestSessionsKey(claims.Email), id)
+		pipe.Expire(ctx, guestSessionsKey(claims.Email), ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// Read returns the claims of a session, or nil if it is unknown or expired.
+func (s *RedisSessionStore) Read(ctx context.Context, id string) (*web.IdentityTokenClaims, error) {
+	value, err := s.client.Get(ctx, sessionKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	var claims web.IdentityTokenClaims
+	if err := json.Unmarshal(value, &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode session: %w", err)
+	}
+	return &claims, nil
+}
+
+// Touch extends the time to live of a session and of its guest's session set.
+func (s *RedisSessionStore) Touch(ctx context.Context, id string, ttl time.Duration) error {
+	value, err := s.client.GetEx(ctx, sessionKey(id), ttl).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extend session: %w", err)
+	}
+	var claims web.IdentityTokenClaims
+	if err := json.Unmarshal(value, &claims); err != nil {
+		return fmt.Errorf("failed to decode session: %w", err)
+	}
+	if err := s.client.Expire(ctx, guestSessionsKey(claims.Email), ttl).Err(); err != nil {
+		return fmt.Errorf("failed to extend session: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a session.
+func (s *RedisSessionStore) Delete(ctx context.Context, id string) error {
+	claims, err := s.Read(ctx, id)
+	if err != nil || claims == nil {
+		return err
+	}
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(id))
+		pipe.SRem(ctx, guestSessionsKey(claims.Email), id)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// DeleteByEmail removes all sessions of a guest and returns their count.
+func (s *RedisSessionStore) DeleteByEmail(ctx context.Context, email string) (int, error) {
+	ids, err := s.client.SMembers(ctx, guestSessionsKey(email)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, sessionKey(id))
+	}
+
+	var deleted *redis.IntCmd
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(keys) > 0 {
+			deleted = pipe.Del(ctx, keys...)
+		}
+		pipe.Del(ctx, guestSessionsKey(email))
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sessions: %w", err)
+	}
+	if deleted == nil {
+		return 0, nil
+	}
+	return int(deleted.Val()), nil
+}
+
+// UseNonce marks the nonce of a single-use token as used until ttl passed and reports whether it was unused.
+func (s *RedisSessionStore) UseNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	unused, err := s.client.SetNX(ctx, "magic-link:nonce:"+nonce, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to use nonce: %w", err)
+	}
+	return unused, nil
+}
+
+// CountAttempt counts an attempt for the key in its current window and returns the attempts of the window.
+// The window starts with the first attempt and ends with the expiration of its counter.
+func (s *RedisSessionStore) CountAttempt(ctx context.Context, key string, window time.Duration) (int, error) {
+	var count *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Incr(ctx, "magic-link:attempts:"+key)
+		pipe.ExpireNX(ctx, "magic-link:attempts:"+key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count attempt: %w", err)
+	}
+	return int(count.Val()), nil
+}
+
+// sessionKey returns the Redis key of a session.
+func sessionKey(id string) string {
+	return "session:" + id
+}
+
+// guestSessionsKey returns the Redis key of a guest's session ID set.
+func guestSessionsKey(email string) string {
+	return "session:guest:" + email
+}
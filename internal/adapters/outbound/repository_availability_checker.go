@@ -8,8 +8,10 @@ import (
 )
 
 // RepositoryAvailabilityChecker implements AvailabilityChecker by querying the reservation repository.
+// Room blocks count as occupancy once WithRoomBlocks is set.
 type RepositoryAvailabilityChecker struct {
 	reservationRepo reservation.ReservationRepository
+	blocks          reservation.RoomBlockRepository
 }
 
 // NewRepositoryAvailabilityChecker creates a new availability checker.
@@ -19,12 +21,30 @@ func NewRepositoryAvailabilityChecker(repo reservation.ReservationRepository) *R
 	}
 }
 
+// WithRoomBlocks makes rooms unavailable for the dates of their blocks.
+func (c *RepositoryAvailabilityChecker) WithRoomBlocks(repo reservation.RoomBlockRepository) *RepositoryAvailabilityChecker {
+	c.blocks = repo
+	return c
+}
+
 // IsRoomAvailable checks if a room is available for the given date range.
 func (c *RepositoryAvailabilityChecker) IsRoomAvailable(
 	ctx context.Context,
 	roomID reservation.RoomID,
 	dateRange reservation.DateRange,
 ) (bool, error) {
+	if c.blocks != nil {
+		blocks, err := c.blocks.ReadAll(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to read room blocks: %w", err)
+		}
+		for _, block := range blocks {
+			if block.Overlaps(roomID, dateRange) {
+				return false, nil
+			}
+		}
+	}
+
 	overlapping, err := c.GetOverlappingReservations(ctx, roomID, dateRange)
 	if err != nil {
 		return false, fmt.Errorf("failed to check overlaps: %w", err)
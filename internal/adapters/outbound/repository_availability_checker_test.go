@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
@@ -63,6 +64,16 @@ func (m *mockReservationRepo) ReadAll(ctx context.Context) ([]reservation.Reserv
 	return result, nil
 }
 
+func (m *mockReservationRepo) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	var result []reservation.Reservation
+	for _, res := range m.reservations {
+		if res.GuestID == guestID {
+			result = append(result, res)
+		}
+	}
+	return result, nil
+}
+
 func createTestReservationInRepo(repo *mockReservationRepo, id string, roomID string, checkInDays, checkOutDays int) {
 	checkIn := time.Now().AddDate(0, 0, checkInDays)
 	checkOut := time.Now().AddDate(0, 0, checkOutDays)
@@ -75,7 +86,7 @@ func createTestReservationInRepo(repo *mockReservationRepo, id string, roomID st
 		Status:    reservation.StatusPending,
 		TotalAmount: shared.NewMoney(30000, "USD"),
 		Guests: []reservation.GuestInfo{
-			reservation.NewGuestInfo("John Doe", "john@example.com", "+1234567890"),
+			{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+1234567890"},
 		},
 	}
 	repo.reservations[reservation.ReservationID(id)] = res
@@ -245,3 +256,27 @@ func Test_RepositoryAvailabilityChecker_IsRoomAvailable_Cancelled_Reservation_Sh
 	assert.That(t, "error must be nil", err == nil, true)
 	assert.That(t, "room must be available when existing reservation is cancelled", available, true)
 }
+
+func Test_RepositoryAvailabilityChecker_IsRoomAvailable_With_Room_Block_Should_Return_False(t *testing.T) {
+	// Arrange
+	blocks := resource.NewInMemoryAccess[reservation.RoomBlockID, reservation.RoomBlock]()
+	checker := outbound.NewRepositoryAvailabilityChecker(newMockReservationRepo()).WithRoomBlocks(blocks)
+	ctx := context.Background()
+
+	blockRange := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 10))
+	block, _ := reservation.NewRoomBlock("block-001", "room-101", blockRange, reservation.BlockMaintenance, "")
+	_ = blocks.Create(ctx, block.ID, *block)
+
+	checkIn := time.Now().AddDate(0, 0, 8)
+	checkOut := time.Now().AddDate(0, 0, 12)
+	dateRange := reservation.NewDateRange(checkIn, checkOut)
+
+	// Act
+	blocked, err := checker.IsRoomAvailable(ctx, "room-101", dateRange)
+	other, _ := checker.IsRoomAvailable(ctx, "room-102", dateRange)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "blocked room must be unavailable", blocked, false)
+	assert.That(t, "other room must be available", other, true)
+}
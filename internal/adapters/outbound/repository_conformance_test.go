@@ -0,0 +1,148 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Repository Conformance Suites
+// ============================================================================
+// The suites check the behavior the domain services rely on. They run against
+// the generic repositories here and against Postgres in the integration tests
+// (go test -tags=integration), so every implementation of a port behaves alike.
+
+// conformanceReservation creates a reservation of the guest with a check-in in two days.
+func conformanceReservation(t *testing.T, id reservation.ReservationID, guestID reservation.GuestID) reservation.Reservation {
+	t.Helper()
+	checkIn := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
+	res, err := reservation.NewReservation(id, guestID, "room-101",
+		reservation.NewDateRange(checkIn, checkIn.Add(72*time.Hour)),
+		shared.NewMoney(30000, "EUR"),
+		[]reservation.GuestInfo{{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+15551234567"}},
+	)
+	assert.That(t, "reservation must be valid", err == nil, true)
+	return *res
+}
+
+// checkReservationRepositoryConformance runs the reservation repository suite against an empty repository.
+func checkReservationRepositoryConformance(t *testing.T, repo reservation.ReservationRepository) {
+	t.Helper()
+	ctx := context.Background()
+
+	// Create and Read return the stored reservation with its guests.
+	res := conformanceReservation(t, "conf-res-001", "conf-guest-001")
+	assert.That(t, "create error must be nil", repo.Create(ctx, res.ID, res) == nil, true)
+	read, err := repo.Read(ctx, res.ID)
+	assert.That(t, "read error must be nil", err == nil, true)
+	assert.That(t, "guest ID must match", read.GuestID, res.GuestID)
+	assert.That(t, "amount must match", read.TotalAmount, res.TotalAmount)
+	assert.That(t, "guests must match", read.Guests, res.Guests)
+
+	// Update replaces the stored reservation.
+	_ = read.Confirm()
+	assert.That(t, "update error must be nil", repo.Update(ctx, read.ID, *read) == nil, true)
+	updated, _ := repo.Read(ctx, res.ID)
+	assert.That(t, "status must be updated", updated.Status, reservation.StatusConfirmed)
+
+	// FindByGuestID returns the reservations of the guest only; ReadAll returns all.
+	other := conformanceReservation(t, "conf-res-002", "conf-guest-002")
+	_ = repo.Create(ctx, other.ID, other)
+	second := conformanceReservation(t, "conf-res-003", "conf-guest-001")
+	_ = repo.Create(ctx, second.ID, second)
+	found, err := repo.FindByGuestID(ctx, "conf-guest-001")
+	assert.That(t, "find error must be nil", err == nil, true)
+	assert.That(t, "must find the two reservations of the guest", len(found), 2)
+	all, err := repo.ReadAll(ctx)
+	assert.That(t, "read all error must be nil", err == nil, true)
+	assert.That(t, "must read all reservations", len(all), 3)
+
+	// Delete removes the reservation, so reading it fails.
+	assert.That(t, "delete error must be nil", repo.Delete(ctx, res.ID) == nil, true)
+	_, err = repo.Read(ctx, res.ID)
+	assert.That(t, "read of a deleted reservation must fail", err != nil, true)
+}
+
+// checkPaymentRepositoryConformance runs the payment repository suite against an empty repository.
+func checkPaymentRepositoryConformance(t *testing.T, repo payment.PaymentRepository) {
+	t.Helper()
+	ctx := context.Background()
+
+	// Create and Read return the stored payment with its attempts.
+	p := payment.NewPayment("conf-pay-001", "conf-res-001", shared.NewMoney(30000, "EUR"), "credit_card")
+	_ = p.Authorize("tx-001")
+	assert.That(t, "create error must be nil", repo.Create(ctx, p.ID, *p) == nil, true)
+	read, err := repo.Read(ctx, p.ID)
+	assert.That(t, "read error must be nil", err == nil, true)
+	assert.That(t, "status must match", read.Status, payment.StatusAuthorized)
+	assert.That(t, "transaction ID must match", read.TransactionID, "tx-001")
+	assert.That(t, "attempts must be stored", len(read.Attempts), len(p.Attempts))
+
+	// Update replaces the stored payment.
+	_ = read.Capture()
+	assert.That(t, "update error must be nil", repo.Update(ctx, read.ID, *read) == nil, true)
+	updated, _ := repo.Read(ctx, p.ID)
+	assert.That(t, "status must be updated", updated.Status, payment.StatusCaptured)
+
+	// FindByReservationID returns the payments of the reservation only.
+	other := payment.NewPayment("conf-pay-002", "conf-res-002", shared.NewMoney(10000, "EUR"), "credit_card")
+	_ = repo.Create(ctx, other.ID, *other)
+	found, err := repo.FindByReservationID(ctx, "conf-res-001")
+	assert.That(t, "find error must be nil", err == nil, true)
+	assert.That(t, "must find the payment of the reservation", len(found), 1)
+	assert.That(t, "payment ID must match", found[0].ID, p.ID)
+
+	// Delete removes the payment, so reading it fails.
+	assert.That(t, "delete error must be nil", repo.Delete(ctx, p.ID) == nil, true)
+	_, err = repo.Read(ctx, p.ID)
+	assert.That(t, "read of a deleted payment must fail", err != nil, true)
+}
+
+// checkGuestProfileRepositoryConformance runs the guest profile repository suite against an empty repository.
+func checkGuestProfileRepositoryConformance(t *testing.T, repo reservation.GuestProfileRepository) {
+	t.Helper()
+	ctx := context.Background()
+
+	// FindProfile returns nil without an error for unknown guests.
+	missing, err := repo.FindProfile(ctx, "conf-guest-001")
+	assert.That(t, "find error must be nil", err == nil, true)
+	assert.That(t, "unknown profile must be nil", missing == nil, true)
+
+	// SaveProfile creates and then replaces the profile.
+	profile := reservation.GuestProfile{GuestID: "conf-guest-001", Name: "John", PhoneNumber: "+15551234567", Locale: "de"}
+	assert.That(t, "create error must be nil", repo.SaveProfile(ctx, profile) == nil, true)
+	profile.Name = "John Doe"
+	assert.That(t, "replace error must be nil", repo.SaveProfile(ctx, profile) == nil, true)
+	found, err := repo.FindProfile(ctx, "conf-guest-001")
+	assert.That(t, "find error must be nil", err == nil, true)
+	assert.That(t, "profile must be replaced", *found, profile)
+
+	// DeleteProfile removes the profile and ignores unknown guests.
+	assert.That(t, "delete error must be nil", repo.DeleteProfile(ctx, "conf-guest-001") == nil, true)
+	assert.That(t, "delete of an unknown profile must succeed", repo.DeleteProfile(ctx, "conf-guest-002") == nil, true)
+	deleted, _ := repo.FindProfile(ctx, "conf-guest-001")
+	assert.That(t, "deleted profile must be nil", deleted == nil, true)
+}
+
+func Test_ReservationRepository_With_InMemoryAccess_Should_Conform(t *testing.T) {
+	checkReservationRepositoryConformance(t,
+		outbound.NewReservationRepository(resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()))
+}
+
+func Test_PaymentRepository_With_InMemoryAccess_Should_Conform(t *testing.T) {
+	checkPaymentRepositoryConformance(t,
+		outbound.NewPaymentRepository(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]()))
+}
+
+func Test_GuestProfileRepository_With_InMemoryAccess_Should_Conform(t *testing.T) {
+	checkGuestProfileRepositoryConformance(t,
+		outbound.NewGuestProfileRepository(resource.NewInMemoryAccess[reservation.GuestID, reservation.GuestProfile]()))
+}
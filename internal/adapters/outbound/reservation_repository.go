@@ -0,0 +1,40 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ReservationRepository adds guest lookups to any key/value reservation store,
+// such as InMemoryAccess or JsonFileAccess from cloud-native-utils.
+// It implements the reservation.ReservationRepository port.
+type ReservationRepository struct {
+	resource.Access[reservation.ReservationID, reservation.Reservation]
+}
+
+// NewReservationRepository creates a new reservation repository backed by the given access.
+func NewReservationRepository(access resource.Access[reservation.ReservationID, reservation.Reservation]) *ReservationRepository {
+	return &ReservationRepository{
+		Access: access,
+	}
+}
+
+// FindByGuestID returns all reservations of the given guest.
+func (r *ReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	allReservations, err := r.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	var reservations []reservation.Reservation
+	for _, res := range allReservations {
+		if res.GuestID == guestID {
+			reservations = append(reservations, res)
+		}
+	}
+
+	return reservations, nil
+}
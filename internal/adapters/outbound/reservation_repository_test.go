@@ -0,0 +1,46 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// ReservationRepository Tests
+// ============================================================================
+
+func Test_ReservationRepository_FindByGuestID_Should_Return_Matching_Reservations(t *testing.T) {
+	// Arrange
+	repo := outbound.NewReservationRepository(resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]())
+	ctx := context.Background()
+	_ = repo.Create(ctx, "res-001", reservation.Reservation{ID: "res-001", GuestID: "guest-001"})
+	_ = repo.Create(ctx, "res-002", reservation.Reservation{ID: "res-002", GuestID: "guest-002"})
+	_ = repo.Create(ctx, "res-003", reservation.Reservation{ID: "res-003", GuestID: "guest-001"})
+
+	// Act
+	reservations, err := repo.FindByGuestID(ctx, "guest-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must find two reservations", len(reservations), 2)
+	for _, res := range reservations {
+		assert.That(t, "guest ID must match", res.GuestID, reservation.GuestID("guest-001"))
+	}
+}
+
+func Test_ReservationRepository_FindByGuestID_When_None_Match_Should_Return_Empty(t *testing.T) {
+	// Arrange
+	repo := outbound.NewReservationRepository(resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]())
+
+	// Act
+	reservations, err := repo.FindByGuestID(context.Background(), "guest-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must find no reservations", len(reservations), 0)
+}
@@ -0,0 +1,152 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"time"
+)
+
+// This file contains a generic retry helper for outbound port calls.
+// It is used by the retrying decorators around the EventPublisher and the
+// PaymentGateway so that transient broker or gateway blips don't fail a saga.
+
+// RetryPolicy configures how often and how fast a failed call is retried.
+type RetryPolicy struct {
+	RetryOn      func(err error) bool // Classifies errors as retryable (defaults to IsTransient)
+	MaxAttempts  int                  // Total number of attempts including the first call
+	InitialDelay time.Duration        // Delay before the first retry
+	MaxDelay     time.Duration        // Upper bound for the exponential backoff
+	Multiplier   float64              // Backoff growth factor between attempts
+	Jitter       float64              // Random fraction (0.0 to 1.0) subtracted from each delay
+}
+
+// NewRetryPolicy creates a retry policy with sensible defaults for outbound calls.
+func NewRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		RetryOn:      IsTransient,
+		MaxAttempts:  3,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     2 * time.Second,
+		Multiplier:   2.0,
+		Jitter:       0.2,
+	}
+}
+
+// WithMaxAttempts sets the total number of attempts.
+func (p RetryPolicy) WithMaxAttempts(attempts int) RetryPolicy {
+	p.MaxAttempts = attempts
+	return p
+}
+
+// WithInitialDelay sets the delay before the first retry.
+func (p RetryPolicy) WithInitialDelay(delay time.Duration) RetryPolicy {
+	p.InitialDelay = delay
+	return p
+}
+
+// WithMaxDelay sets the upper bound for the backoff.
+func (p RetryPolicy) WithMaxDelay(delay time.Duration) RetryPolicy {
+	p.MaxDelay = delay
+	return p
+}
+
+// WithJitter sets the random fraction subtracted from each delay.
+func (p RetryPolicy) WithJitter(jitter float64) RetryPolicy {
+	p.Jitter = jitter
+	return p
+}
+
+// WithRetryOn sets the error classification function.
+func (p RetryPolicy) WithRetryOn(fn func(err error) bool) RetryPolicy {
+	p.RetryOn = fn
+	return p
+}
+
+// ErrTransient marks a failure that is worth retrying, e.g. a gateway that
+// responded with a 5xx status. Adapters wrap it into such errors.
+var ErrTransient = errors.New("transient failure")
+
+// IsTransient reports whether an error is worth retrying: network errors, timeouts,
+// errors that report themselves as temporary (e.g. Kafka errors) and errors wrapping
+// ErrTransient. Any other error, such as a declined card, fails the same way again.
+// Cancellation and deadline errors are final because the caller gave up.
+func IsTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrTransient) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return true
+	}
+	var temporary interface{ Temporary() bool }
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
+
+// Retry calls fn until it succeeds, the error is not retryable,
+// the attempts are exhausted or the context is done.
+// The last error returned by fn is returned.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	retryOn := policy.RetryOn
+	if retryOn == nil {
+		retryOn = IsTransient
+	}
+
+	attempts := max(policy.MaxAttempts, 1)
+	delay := policy.InitialDelay
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+
+		// Stop on permanent errors or after the last attempt.
+		if !retryOn(err) || attempt == attempts {
+			return err
+		}
+
+		// Wait for the backoff delay or abort if the caller gives up.
+		timer := time.NewTimer(policy.jittered(delay))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+
+		delay = policy.next(delay)
+	}
+
+	return err
+}
+
+// jittered subtracts a random fraction from the delay to spread retries.
+func (p RetryPolicy) jittered(delay time.Duration) time.Duration {
+	if p.Jitter <= 0 {
+		return delay
+	}
+	return delay - time.Duration(float64(delay)*p.Jitter*cryptoRandFloat64())
+}
+
+// next returns the exponentially increased delay capped at MaxDelay.
+func (p RetryPolicy) next(delay time.Duration) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	next := time.Duration(float64(delay) * multiplier)
+	if p.MaxDelay > 0 && next > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return next
+}
@@ -0,0 +1,29 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/event"
+)
+
+// RetryEventPublisher decorates an EventPublisher with a retry policy.
+// It implements the EventPublisher port of every bounded context.
+type RetryEventPublisher struct {
+	next   event.EventPublisher
+	policy RetryPolicy
+}
+
+// NewRetryEventPublisher creates a new retrying event publisher.
+func NewRetryEventPublisher(next event.EventPublisher, policy RetryPolicy) *RetryEventPublisher {
+	return &RetryEventPublisher{
+		next:   next,
+		policy: policy,
+	}
+}
+
+// Publish publishes an event and retries transient failures.
+func (p *RetryEventPublisher) Publish(ctx context.Context, e event.Event) error {
+	return Retry(ctx, p.policy, func(ctx context.Context) error {
+		return p.next.Publish(ctx, e)
+	})
+}
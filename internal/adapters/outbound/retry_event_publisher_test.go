@@ -0,0 +1,58 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// RetryEventPublisher Tests
+// ============================================================================
+
+type flakyEventPublisher struct {
+	failures  int
+	calls     int
+	published []event.Event
+}
+
+func (p *flakyEventPublisher) Publish(ctx context.Context, e event.Event) error {
+	p.calls++
+	if p.calls <= p.failures {
+		return &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	}
+	p.published = append(p.published, e)
+	return nil
+}
+
+func Test_RetryEventPublisher_With_Transient_Failure_Should_Publish(t *testing.T) {
+	// Arrange
+	next := &flakyEventPublisher{failures: 2}
+	publisher := outbound.NewRetryEventPublisher(next, fastRetryPolicy())
+
+	// Act
+	err := publisher.Publish(context.Background(), &testEvent{EventTopic: "test.topic"})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must be called three times", next.calls, 3)
+	assert.That(t, "event must be published once", len(next.published), 1)
+}
+
+func Test_RetryEventPublisher_With_Persistent_Failure_Should_Return_Error(t *testing.T) {
+	// Arrange
+	next := &flakyEventPublisher{failures: 10}
+	publisher := outbound.NewRetryEventPublisher(next, fastRetryPolicy())
+
+	// Act
+	err := publisher.Publish(context.Background(), &testEvent{EventTopic: "test.topic"})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "must be called MaxAttempts times", next.calls, 3)
+}
@@ -0,0 +1,51 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// RetryPaymentGateway decorates a PaymentGateway with a retry policy.
+// It implements the payment.PaymentGateway port. Authorize is not retried,
+// because an authorization that timed out may still have placed a hold on the
+// card, and a second attempt would place another one.
+type RetryPaymentGateway struct {
+	next   payment.PaymentGateway
+	policy RetryPolicy
+}
+
+// NewRetryPaymentGateway creates a new retrying payment gateway.
+func NewRetryPaymentGateway(next payment.PaymentGateway, policy RetryPolicy) *RetryPaymentGateway {
+	return &RetryPaymentGateway{
+		next:   next,
+		policy: policy,
+	}
+}
+
+// Authorize authorizes a payment without retrying.
+func (g *RetryPaymentGateway) Authorize(ctx context.Context, pay *payment.Payment) (string, error) {
+	return g.next.Authorize(ctx, pay)
+}
+
+// Capture captures an authorized payment and retries transient failures.
+func (g *RetryPaymentGateway) Capture(ctx context.Context, transactionID string, amount shared.Money) error {
+	return Retry(ctx, g.policy, func(ctx context.Context) error {
+		return g.next.Capture(ctx, transactionID, amount)
+	})
+}
+
+// Refund refunds a captured payment and retries transient failures.
+func (g *RetryPaymentGateway) Refund(ctx context.Context, transactionID string, amount shared.Money) error {
+	return Retry(ctx, g.policy, func(ctx context.Context) error {
+		return g.next.Refund(ctx, transactionID, amount)
+	})
+}
+
+// Void releases an authorization and retries transient failures.
+func (g *RetryPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	return Retry(ctx, g.policy, func(ctx context.Context) error {
+		return g.next.Void(ctx, transactionID)
+	})
+}
@@ -0,0 +1,106 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// RetryPaymentGateway Tests
+// ============================================================================
+
+type flakyPaymentGateway struct {
+	failures int
+	err      error // Returned by the failing calls (defaults to a transient gateway timeout)
+	calls    int
+}
+
+func (g *flakyPaymentGateway) fail() error {
+	g.calls++
+	if g.calls <= g.failures {
+		if g.err != nil {
+			return g.err
+		}
+		return fmt.Errorf("%w: gateway timeout", outbound.ErrTransient)
+	}
+	return nil
+}
+
+func (g *flakyPaymentGateway) Authorize(ctx context.Context, pay *payment.Payment) (string, error) {
+	if err := g.fail(); err != nil {
+		return "", err
+	}
+	return "txn-001", nil
+}
+
+func (g *flakyPaymentGateway) Capture(ctx context.Context, transactionID string, amount shared.Money) error {
+	return g.fail()
+}
+
+func (g *flakyPaymentGateway) Refund(ctx context.Context, transactionID string, amount shared.Money) error {
+	return g.fail()
+}
+
+func (g *flakyPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	return g.fail()
+}
+
+func Test_RetryPaymentGateway_Authorize_With_Transient_Failure_Should_Not_Retry(t *testing.T) {
+	// Arrange
+	next := &flakyPaymentGateway{failures: 1}
+	gateway := outbound.NewRetryPaymentGateway(next, fastRetryPolicy())
+	pay := payment.NewPayment("pay-001", "res-001", shared.NewMoney(10000, "USD"), "credit_card")
+
+	// Act
+	_, err := gateway.Authorize(context.Background(), pay)
+
+	// Assert
+	assert.That(t, "error must be transient", errors.Is(err, outbound.ErrTransient), true)
+	assert.That(t, "must be called once", next.calls, 1)
+}
+
+func Test_RetryPaymentGateway_Capture_With_Transient_Failure_Should_Succeed(t *testing.T) {
+	// Arrange
+	next := &flakyPaymentGateway{failures: 2}
+	gateway := outbound.NewRetryPaymentGateway(next, fastRetryPolicy())
+
+	// Act
+	err := gateway.Capture(context.Background(), "txn-001", shared.NewMoney(10000, "USD"))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must be called three times", next.calls, 3)
+}
+
+func Test_RetryPaymentGateway_Refund_With_Persistent_Failure_Should_Return_Error(t *testing.T) {
+	// Arrange
+	next := &flakyPaymentGateway{failures: 10}
+	gateway := outbound.NewRetryPaymentGateway(next, fastRetryPolicy())
+
+	// Act
+	err := gateway.Refund(context.Background(), "txn-001", shared.NewMoney(10000, "USD"))
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "must be called MaxAttempts times", next.calls, 3)
+}
+
+func Test_RetryPaymentGateway_Capture_With_Declined_Card_Should_Not_Retry(t *testing.T) {
+	// Arrange
+	next := &flakyPaymentGateway{failures: 1, err: errors.New("insufficient funds")}
+	gateway := outbound.NewRetryPaymentGateway(next, fastRetryPolicy())
+
+	// Act
+	err := gateway.Capture(context.Background(), "txn-001", shared.NewMoney(10000, "USD"))
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "must be called once", next.calls, 1)
+}
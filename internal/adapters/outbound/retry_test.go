@@ -0,0 +1,137 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func fastRetryPolicy() outbound.RetryPolicy {
+	return outbound.NewRetryPolicy().
+		WithInitialDelay(time.Millisecond).
+		WithMaxDelay(2 * time.Millisecond).
+		WithJitter(0)
+}
+
+// ============================================================================
+// Retry Tests
+// ============================================================================
+
+func Test_Retry_With_Successful_Call_Should_Call_Once(t *testing.T) {
+	// Arrange
+	calls := 0
+
+	// Act
+	err := outbound.Retry(context.Background(), fastRetryPolicy(), func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must be called once", calls, 1)
+}
+
+func Test_Retry_With_Transient_Failure_Should_Succeed_After_Retry(t *testing.T) {
+	// Arrange
+	calls := 0
+
+	// Act
+	err := outbound.Retry(context.Background(), fastRetryPolicy(), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return fmt.Errorf("%w: broker unavailable", outbound.ErrTransient)
+		}
+		return nil
+	})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must be called three times", calls, 3)
+}
+
+func Test_Retry_With_Persistent_Failure_Should_Stop_After_MaxAttempts(t *testing.T) {
+	// Arrange
+	calls := 0
+	policy := fastRetryPolicy().WithMaxAttempts(4)
+
+	// Act
+	err := outbound.Retry(context.Background(), policy, func(ctx context.Context) error {
+		calls++
+		return fmt.Errorf("%w: gateway timeout", outbound.ErrTransient)
+	})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "must be called four times", calls, 4)
+}
+
+func Test_Retry_With_Permanent_Error_Should_Not_Retry(t *testing.T) {
+	// Arrange
+	calls := 0
+	errDeclined := errors.New("card declined")
+	policy := fastRetryPolicy().WithRetryOn(func(err error) bool {
+		return !errors.Is(err, errDeclined)
+	})
+
+	// Act
+	err := outbound.Retry(context.Background(), policy, func(ctx context.Context) error {
+		calls++
+		return errDeclined
+	})
+
+	// Assert
+	assert.That(t, "error must be the permanent error", errors.Is(err, errDeclined), true)
+	assert.That(t, "must be called once", calls, 1)
+}
+
+func Test_Retry_With_Cancelled_Context_Should_Stop(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	policy := fastRetryPolicy().WithInitialDelay(time.Hour).WithMaxDelay(time.Hour)
+
+	// Act
+	err := outbound.Retry(ctx, policy, func(ctx context.Context) error {
+		calls++
+		cancel()
+		return fmt.Errorf("%w: broker unavailable", outbound.ErrTransient)
+	})
+
+	// Assert
+	assert.That(t, "error must wrap context.Canceled", errors.Is(err, context.Canceled), true)
+	assert.That(t, "must be called once", calls, 1)
+}
+
+func Test_IsTransient_Should_Classify_Errors(t *testing.T) {
+	// Arrange
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{"canceled", context.Canceled, false},
+		{"deadline", fmt.Errorf("request: %w", context.DeadlineExceeded), false},
+		{"declined card", errors.New("payment authorization failed: insufficient funds"), false},
+		{"unknown transaction", errors.New("transaction txn-001 not found"), false},
+		{"flagged gateway error", fmt.Errorf("%w: 503 service unavailable", outbound.ErrTransient), true},
+		{"refused connection", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{"timeout", os.ErrDeadlineExceeded, true},
+	}
+
+	// Act & Assert
+	for _, tc := range tests {
+		assert.That(t, tc.name+" must be classified", outbound.IsTransient(tc.err), tc.transient)
+	}
+}
@@ -0,0 +1,161 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/invoicing"
+)
+
+// S3DocumentRepository stores documents as objects in an S3 bucket.
+// It uses path-style URLs, so it also works with S3-compatible stores such as MinIO.
+// Requests are signed with AWS Signature Version 4.
+// It implements the invoicing.DocumentRepository port.
+type S3DocumentRepository struct {
+	endpoint        string
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+	now             func() time.Time
+}
+
+// NewS3DocumentRepository creates a new S3 document repository,
+// e.g. for endpoint "https://s3.eu-central-1.amazonaws.com" and region "eu-central-1".
+func NewS3DocumentRepository(endpoint, region, bucket, accessKeyID, secretAccessKey string, client *http.Client) *S3DocumentRepository {
+	return &S3DocumentRepository{
+		endpoint:        strings.TrimSuffix(endpoint, "/"),
+		region:          region,
+		bucket:          bucket,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		client:          client,
+		now:             time.Now,
+	}
+}
+
+// Save uploads the document with a PUT request.
+func (r *S3DocumentRepository) Save(ctx context.Context, key string, data []byte) error {
+	resp, err := r.do(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return fmt.Errorf("failed to upload document: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to upload document: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Load downloads the document with a GET request.
+func (r *S3DocumentRepository) Load(ctx context.Context, key string) ([]byte, error) {
+	resp, err := r.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download document: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", invoicing.ErrDocumentNotFound, key)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download document: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document: %w", err)
+	}
+	return data, nil
+}
+
+// do sends a signed request for the object with the given key.
+func (r *S3DocumentRepository) do(ctx context.Context, method, key string, body []byte) (*http.Response, error) {
+	endpoint, err := url.Parse(r.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+	objectPath := endpoint.Path + "/" + r.bucket + "/" + strings.TrimPrefix(key, "/")
+	endpoint.Path = objectPath
+	endpoint.RawPath = s3EscapePath(objectPath)
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	r.sign(req, body)
+	return r.client.Do(req)
+}
+
+// sign adds the AWS Signature Version 4 headers to the request.
+func (r *S3DocumentRepository) sign(req *http.Request, body []byte) {
+	now := r.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"",
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + r.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+r.secretAccessKey), date)
+	key = hmacSHA256(key, r.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+r.accessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// s3EscapePath percent-encodes every byte of the path except unreserved characters and slashes.
+func s3EscapePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
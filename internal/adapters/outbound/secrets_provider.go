@@ -0,0 +1,33 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// This file contains the secrets abstraction used by the composition root to
+// resolve credentials (database passwords, OIDC client secrets, encryption keys)
+// without requiring them to be present in the process environment.
+
+// SecretsProvider resolves secrets by name.
+// Names use the environment variable convention, e.g. RESERVATION_DB_PASSWORD.
+type SecretsProvider interface {
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// ErrSecretNotFound is returned when a provider does not know a secret.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// LookupSecret resolves a secret and returns fallback if the provider does not know it.
+// Any other provider error is returned so misconfigured deployments fail fast.
+func LookupSecret(ctx context.Context, provider SecretsProvider, name, fallback string) (string, error) {
+	value, err := provider.GetSecret(ctx, name)
+	if errors.Is(err, ErrSecretNotFound) {
+		return fallback, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+	return value, nil
+}
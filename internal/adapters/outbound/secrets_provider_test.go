@@ -0,0 +1,162 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// LookupSecret Tests
+// ============================================================================
+
+func Test_LookupSecret_When_Not_Found_Should_Return_Fallback(t *testing.T) {
+	// Arrange
+	provider := outbound.NewFileSecretsProvider(t.TempDir())
+
+	// Act
+	value, err := outbound.LookupSecret(context.Background(), provider, "RESERVATION_DB_PASSWORD", "reservation_secret")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "value must be fallback", value, "reservation_secret")
+}
+
+func Test_LookupSecret_When_Provider_Fails_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(srv.Close)
+	provider := outbound.NewVaultSecretsProvider(srv.URL, "token", "secret", "hotel-booking", srv.Client())
+
+	// Act
+	_, err := outbound.LookupSecret(context.Background(), provider, "RESERVATION_DB_PASSWORD", "reservation_secret")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+// ============================================================================
+// EnvSecretsProvider Tests
+// ============================================================================
+
+func Test_EnvSecretsProvider_GetSecret_Should_Return_Variable(t *testing.T) {
+	// Arrange
+	t.Setenv("TEST_SECRET", "s3cret")
+	provider := outbound.NewEnvSecretsProvider()
+
+	// Act
+	value, err := provider.GetSecret(context.Background(), "TEST_SECRET")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "value must match", value, "s3cret")
+}
+
+func Test_EnvSecretsProvider_GetSecret_When_Unset_Should_Return_ErrSecretNotFound(t *testing.T) {
+	// Arrange
+	provider := outbound.NewEnvSecretsProvider()
+
+	// Act
+	_, err := provider.GetSecret(context.Background(), "TEST_SECRET_THAT_IS_NOT_SET")
+
+	// Assert
+	assert.That(t, "error must be ErrSecretNotFound", errors.Is(err, outbound.ErrSecretNotFound), true)
+}
+
+// ============================================================================
+// FileSecretsProvider Tests
+// ============================================================================
+
+func Test_FileSecretsProvider_GetSecret_Should_Return_Trimmed_File_Content(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "PAYMENT_DB_PASSWORD"), []byte("s3cret\n"), 0o600)
+	provider := outbound.NewFileSecretsProvider(dir)
+
+	// Act
+	value, err := provider.GetSecret(context.Background(), "PAYMENT_DB_PASSWORD")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "value must match", value, "s3cret")
+}
+
+func Test_FileSecretsProvider_GetSecret_With_Path_Traversal_Should_Return_ErrSecretNotFound(t *testing.T) {
+	// Arrange
+	provider := outbound.NewFileSecretsProvider(t.TempDir())
+
+	// Act
+	_, err := provider.GetSecret(context.Background(), "../etc/passwd")
+
+	// Assert
+	assert.That(t, "error must be ErrSecretNotFound", errors.Is(err, outbound.ErrSecretNotFound), true)
+}
+
+// ============================================================================
+// VaultSecretsProvider Tests
+// ============================================================================
+
+func newVaultServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/secret/data/hotel-booking" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"data":{"RESERVATION_DB_PASSWORD":"s3cret"},"metadata":{"version":1}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func Test_VaultSecretsProvider_GetSecret_Should_Return_Value(t *testing.T) {
+	// Arrange
+	srv := newVaultServer(t)
+	provider := outbound.NewVaultSecretsProvider(srv.URL, "root", "secret", "hotel-booking", srv.Client())
+
+	// Act
+	value, err := provider.GetSecret(context.Background(), "RESERVATION_DB_PASSWORD")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "value must match", value, "s3cret")
+}
+
+func Test_VaultSecretsProvider_GetSecret_With_Unknown_Key_Should_Return_ErrSecretNotFound(t *testing.T) {
+	// Arrange
+	srv := newVaultServer(t)
+	provider := outbound.NewVaultSecretsProvider(srv.URL, "root", "secret", "hotel-booking", srv.Client())
+
+	// Act
+	_, err := provider.GetSecret(context.Background(), "PAYMENT_DB_PASSWORD")
+
+	// Assert
+	assert.That(t, "error must be ErrSecretNotFound", errors.Is(err, outbound.ErrSecretNotFound), true)
+}
+
+func Test_VaultSecretsProvider_GetSecret_With_Invalid_Token_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv := newVaultServer(t)
+	provider := outbound.NewVaultSecretsProvider(srv.URL, "wrong", "secret", "hotel-booking", srv.Client())
+
+	// Act
+	_, err := provider.GetSecret(context.Background(), "RESERVATION_DB_PASSWORD")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "error must not be ErrSecretNotFound", errors.Is(err, outbound.ErrSecretNotFound), false)
+}
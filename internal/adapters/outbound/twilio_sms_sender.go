@@ -0,0 +1,67 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+)
+
+// TwilioSMSSender sends notifications as text messages via the Twilio Messages API,
+// or any SMS provider that offers the same API. Requests are authenticated with the
+// account SID and auth token as HTTP basic auth.
+// It is the SMS sender of the NotificationDispatcher.
+type TwilioSMSSender struct {
+	baseURL    string
+	accountSID string
+	authToken  string
+	from       string
+	client     *http.Client
+}
+
+// NewTwilioSMSSender creates a new SMS sender for the API at baseURL (e.g. https://api.twilio.com)
+// that sends from the given phone number.
+func NewTwilioSMSSender(baseURL, accountSID, authToken, from string, client *http.Client) *TwilioSMSSender {
+	return &TwilioSMSSender{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		client:     client,
+	}
+}
+
+// Send posts the subject and body of the notification as one text message
+// to the recipient's phone number.
+func (s *TwilioSMSSender) Send(ctx context.Context, to NotificationRecipient, msg *orchestration.Notification) error {
+	if to.PhoneNumber == "" {
+		return ErrNoRecipientAddress
+	}
+
+	form := url.Values{}
+	form.Set("To", string(to.PhoneNumber))
+	form.Set("From", s.from)
+	form.Set("Body", msg.Subject+"\n"+msg.Body)
+
+	endpoint := s.baseURL + "/2010-04-01/Accounts/" + url.PathEscape(s.accountSID) + "/Messages.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(s.accountSID, s.authToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send SMS: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to send SMS: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+)
+
+// ============================================================================
+// TwilioSMSSender Tests
+// ============================================================================
+
+func Test_TwilioSMSSender_Send_Should_Post_Message(t *testing.T) {
+	// Arrange
+	var user, password, to, from, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2010-04-01/Accounts/AC123/Messages.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		user, password, _ = r.BasicAuth()
+		to, from, body = r.FormValue("To"), r.FormValue("From"), r.FormValue("Body")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+	sender := outbound.NewTwilioSMSSender(srv.URL+"/", "AC123", "token", "+15559990000", srv.Client())
+
+	// Act
+	err := sender.Send(context.Background(), outbound.NotificationRecipient{PhoneNumber: "+15550001111"},
+		&orchestration.Notification{Subject: "Confirmed", Body: "See you soon"})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "user must be the account SID", user, "AC123")
+	assert.That(t, "password must be the auth token", password, "token")
+	assert.That(t, "message must go to the guest", to, "+15550001111")
+	assert.That(t, "message must come from the hotel", from, "+15559990000")
+	assert.That(t, "body must hold subject and body", body, "Confirmed\nSee you soon")
+}
+
+func Test_TwilioSMSSender_Send_Without_Phone_Number_Should_Return_ErrNoRecipientAddress(t *testing.T) {
+	// Arrange
+	sender := outbound.NewTwilioSMSSender("http://localhost", "AC123", "token", "+15559990000", http.DefaultClient)
+
+	// Act
+	err := sender.Send(context.Background(), outbound.NotificationRecipient{Email: "john@example.com"}, &orchestration.Notification{})
+
+	// Assert
+	assert.That(t, "error must be ErrNoRecipientAddress", errors.Is(err, outbound.ErrNoRecipientAddress), true)
+}
@@ -0,0 +1,72 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// VaultSecretsProvider reads secrets from a HashiCorp Vault KV version 2 engine.
+// All secrets of the application live in one Vault secret; each name is a key in it.
+// It implements the SecretsProvider port.
+type VaultSecretsProvider struct {
+	addr   string
+	token  string
+	mount  string
+	path   string
+	client *http.Client
+}
+
+// NewVaultSecretsProvider creates a new Vault secrets provider for the secret
+// at mount/path, e.g. mount "secret" and path "hotel-booking".
+func NewVaultSecretsProvider(addr, token, mount, path string, client *http.Client) *VaultSecretsProvider {
+	return &VaultSecretsProvider{
+		addr:   strings.TrimSuffix(addr, "/"),
+		token:  token,
+		mount:  strings.Trim(mount, "/"),
+		path:   strings.Trim(path, "/"),
+		client: client,
+	}
+}
+
+// vaultKVResponse is the response of a KV v2 read.
+type vaultKVResponse struct {
+	Data struct {
+		Data map[string]any `json:"data"`
+	} `json:"data"`
+}
+
+// GetSecret reads the Vault secret and returns the value stored under name.
+func (p *VaultSecretsProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	endpoint := p.addr + "/v1/" + p.mount + "/data/" + p.path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault secret: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to read vault secret: unexpected status %d", resp.StatusCode)
+	}
+
+	var result vaultKVResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode vault secret: %w", err)
+	}
+	value, ok := result.Data.Data[name].(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return value, nil
+}
@@ -0,0 +1,83 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+)
+
+// pushSignatureHeader carries the HMAC-SHA256 of the webhook body as "sha256=<hex>".
+const pushSignatureHeader = "X-Notification-Signature"
+
+// WebhookPushSender posts notifications to a push gateway's webhook, which delivers
+// them to the devices the guest registered in the app. Bodies are signed with the
+// shared secret, so the gateway can verify them.
+// It is the push sender of the NotificationDispatcher.
+type WebhookPushSender struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+// NewWebhookPushSender creates a new push sender for the webhook at url.
+func NewWebhookPushSender(url string, secret []byte, client *http.Client) *WebhookPushSender {
+	return &WebhookPushSender{
+		url:    url,
+		secret: secret,
+		client: client,
+	}
+}
+
+// pushRequest is the body of the push webhook.
+type pushRequest struct {
+	GuestID string `json:"guest_id"`
+	Type    string `json:"type"`
+	Locale  string `json:"locale"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+}
+
+// Send posts the notification for the recipient's devices to the webhook.
+func (s *WebhookPushSender) Send(ctx context.Context, to NotificationRecipient, msg *orchestration.Notification) error {
+	if to.GuestID == "" {
+		return ErrNoRecipientAddress
+	}
+
+	body, err := json.Marshal(pushRequest{
+		GuestID: string(to.GuestID),
+		Type:    string(msg.Type),
+		Locale:  msg.Locale,
+		Title:   msg.Subject,
+		Body:    msg.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode push notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(pushSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send push notification: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to send push notification: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package outbound_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+)
+
+// ============================================================================
+// WebhookPushSender Tests
+// ============================================================================
+
+func Test_WebhookPushSender_Send_Should_Post_Signed_Notification(t *testing.T) {
+	// Arrange
+	var signature string
+	var raw []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get("X-Notification-Signature")
+		raw, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(srv.Close)
+	sender := outbound.NewWebhookPushSender(srv.URL, []byte("secret"), srv.Client())
+
+	// Act
+	err := sender.Send(context.Background(), outbound.NotificationRecipient{GuestID: "guest-001"},
+		&orchestration.Notification{Type: orchestration.NotificationConfirmation, Locale: "en", Subject: "Confirmed", Body: "See you soon"})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(raw)
+	assert.That(t, "body must be signed", signature, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	var body map[string]any
+	_ = json.Unmarshal(raw, &body)
+	assert.That(t, "guest must be sent", body["guest_id"], any("guest-001"))
+	assert.That(t, "type must be sent", body["type"], any("confirmation"))
+	assert.That(t, "title must be the subject", body["title"], any("Confirmed"))
+}
+
+func Test_WebhookPushSender_Send_With_Error_Status_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(srv.Close)
+	sender := outbound.NewWebhookPushSender(srv.URL, []byte("secret"), srv.Client())
+
+	// Act
+	err := sender.Send(context.Background(), outbound.NotificationRecipient{GuestID: "guest-001"}, &orchestration.Notification{})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
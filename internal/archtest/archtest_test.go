@@ -0,0 +1,186 @@
+package archtest_test
+
+import (
+	"go/build"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+)
+
+const module = "github.com/andygeiss/hotel-booking"
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// pkg is a Go package under internal/ with its production and test imports.
+type pkg struct {
+	Path        string
+	Imports     []string
+	TestImports []string
+}
+
+// loadPackages returns every package below the internal directory.
+func loadPackages(t *testing.T) []pkg {
+	t.Helper()
+	root, err := filepath.Abs("..")
+	assert.That(t, "internal directory must resolve", err == nil, true)
+
+	var pkgs []pkg
+	err = filepath.WalkDir(root, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		p, err := build.ImportDir(dir, 0)
+		if _, ok := err.(*build.NoGoError); ok {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, dir)
+		pkgs = append(pkgs, pkg{
+			Path:        "internal/" + filepath.ToSlash(rel),
+			Imports:     p.Imports,
+			TestImports: append(append([]string{}, p.TestImports...), p.XTestImports...),
+		})
+		return nil
+	})
+	assert.That(t, "packages must load", err == nil, true)
+	return pkgs
+}
+
+// layer returns the architectural layer of a package, e.g. "internal/domain"
+// or "internal/adapters/outbound".
+func layer(path string) string {
+	parts := strings.Split(path, "/")
+	switch {
+	case len(parts) >= 2 && parts[1] == "domain":
+		return "internal/domain"
+	case len(parts) >= 3 && parts[1] == "adapters":
+		return strings.Join(parts[:3], "/")
+	}
+	return path
+}
+
+// violations returns "pkg -> import" for every import of pkgs in the given layer
+// that the forbidden func rejects. Test imports are only checked if withTests is set.
+func violations(pkgs []pkg, from string, withTests bool, forbidden func(src, imp string) bool) []string {
+	var found []string
+	for _, p := range pkgs {
+		if layer(p.Path) != from {
+			continue
+		}
+		imports := p.Imports
+		if withTests {
+			imports = append(append([]string{}, imports...), p.TestImports...)
+		}
+		for _, imp := range imports {
+			if forbidden(p.Path, imp) {
+				found = append(found, p.Path+" -> "+imp)
+			}
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+// adapterLayer returns the adapter layer of an import path or an empty string.
+func adapterLayer(imp string) string {
+	rel, ok := strings.CutPrefix(imp, module+"/")
+	if !ok || !strings.HasPrefix(rel, "internal/adapters/") {
+		return ""
+	}
+	return layer(rel)
+}
+
+func assertNoViolations(t *testing.T, found []string) {
+	t.Helper()
+	for _, v := range found {
+		t.Errorf("forbidden import: %s", v)
+	}
+}
+
+// ============================================================================
+// Boundary Tests
+// ============================================================================
+
+func Test_Packages_Should_Be_Loaded(t *testing.T) {
+	// Act
+	pkgs := loadPackages(t)
+
+	// Assert
+	layers := map[string]bool{}
+	for _, p := range pkgs {
+		layers[layer(p.Path)] = true
+	}
+	assert.That(t, "domain layer must be found", layers["internal/domain"], true)
+	assert.That(t, "inbound adapters must be found", layers["internal/adapters/inbound"], true)
+	assert.That(t, "outbound adapters must be found", layers["internal/adapters/outbound"], true)
+}
+
+func Test_Domain_Should_Not_Import_Adapters(t *testing.T) {
+	// Arrange
+	pkgs := loadPackages(t)
+
+	// Act
+	found := violations(pkgs, "internal/domain", true, func(_, imp string) bool {
+		return adapterLayer(imp) != ""
+	})
+
+	// Assert
+	assertNoViolations(t, found)
+}
+
+func Test_Domain_Should_Not_Import_Infrastructure(t *testing.T) {
+	// Arrange
+	pkgs := loadPackages(t)
+	infrastructure := map[string]bool{"database/sql": true, "net/http": true}
+
+	// Act
+	found := violations(pkgs, "internal/domain", true, func(_, imp string) bool {
+		return infrastructure[imp]
+	})
+
+	// Assert
+	assertNoViolations(t, found)
+}
+
+// Adapter tests may wire concrete adapters of other layers, so only production
+// imports are checked.
+func Test_Adapters_Should_Not_Import_Other_Adapters(t *testing.T) {
+	// Arrange
+	pkgs := loadPackages(t)
+	var found []string
+
+	// Act
+	for _, from := range []string{"internal/adapters/inbound", "internal/adapters/outbound"} {
+		found = append(found, violations(pkgs, from, false, func(src, imp string) bool {
+			target := adapterLayer(imp)
+			return target != "" && target != layer(src)
+		})...)
+	}
+
+	// Assert
+	assertNoViolations(t, found)
+}
+
+func Test_Violations_With_Forbidden_Import_Should_Report_It(t *testing.T) {
+	// Arrange
+	pkgs := []pkg{
+		{Path: "internal/domain/reservation", Imports: []string{"context", module + "/internal/adapters/outbound"}},
+		{Path: "internal/adapters/outbound", Imports: []string{"database/sql"}},
+	}
+
+	// Act
+	found := violations(pkgs, "internal/domain", false, func(_, imp string) bool {
+		return adapterLayer(imp) != ""
+	})
+
+	// Assert
+	assert.That(t, "violation must be reported", found, []string{"internal/domain/reservation -> " + module + "/internal/adapters/outbound"})
+}
@@ -0,0 +1,4 @@
+// Package archtest enforces the hexagonal architecture boundaries of this module.
+// It contains no production code; its tests fail the build when a package under
+// internal/ imports across a forbidden boundary.
+package archtest
@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"slices"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Movements are the arrivals and departures of a day.
+type Movements struct {
+	Day        time.Time
+	Arrivals   []*reservation.Reservation
+	Departures []*reservation.Reservation
+}
+
+// EventRecord is a domain event as shown in the activity feed and the message trace,
+// with the outcomes of the handlers that processed it.
+type EventRecord struct {
+	Topic         string
+	ReservationID shared.ReservationID
+	CorrelationID string
+	ReceivedAt    time.Time
+	Handlers      []HandlerOutcome
+
+	key    uint64 // Hash of the topic and payload, to match the outcomes of handlers to the record
+	logged bool   // Whether the event log's own subscription received the event
+}
+
+// Latency returns the duration of the slowest handler of the event.
+func (r EventRecord) Latency() time.Duration {
+	var latency time.Duration
+	for _, outcome := range r.Handlers {
+		latency = max(latency, outcome.Duration)
+	}
+	return latency
+}
+
+// Failed returns true if a handler of the event failed.
+func (r EventRecord) Failed() bool {
+	for _, outcome := range r.Handlers {
+		if outcome.Failed {
+			return true
+		}
+	}
+	return false
+}
+
+// clone returns a copy of the record that does not share the outcomes of its handlers.
+func (r EventRecord) clone() EventRecord {
+	r.Handlers = slices.Clone(r.Handlers)
+	return r
+}
+
+// HandlerOutcome is the result of a handler call for an event.
+// Handler names the consumer group or subscriber, e.g. "orchestration".
+type HandlerOutcome struct {
+	Handler  string
+	Failed   bool
+	Error    string
+	Duration time.Duration
+}
+
+// Index names reported by IndexHealth.
+const (
+	IndexPaymentsByReservation = "payments.reservation_id"
+	IndexSagaStates            = "saga_states"
+)
+
+// IndexHealth reports the consistency of a lookup index or read model
+// with the records it refers to.
+type IndexHealth struct {
+	Name    string
+	Entries int
+	Issues  []string
+}
+
+// Healthy returns true if no inconsistencies were found.
+func (h IndexHealth) Healthy() bool {
+	return len(h.Issues) == 0
+}
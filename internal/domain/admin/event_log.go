@@ -0,0 +1,245 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// EventTopics lists the topics recorded by the EventLog.
+var EventTopics = []string{
+	reservation.EventTopicCreated,
+	reservation.EventTopicConfirmed,
+	reservation.EventTopicActivated,
+	reservation.EventTopicCheckedIn,
+	reservation.EventTopicCompleted,
+	reservation.EventTopicCancelled,
+	reservation.EventTopicNoShow,
+	reservation.EventTopicRoomBlocked,
+	reservation.EventTopicRoomUnblocked,
+	reservation.EventTopicAddOnAdded,
+	reservation.EventTopicAddOnRemoved,
+	reservation.EventTopicAnonymized,
+	payment.EventTopicAuthorized,
+	payment.EventTopicCaptured,
+	payment.EventTopicFailed,
+	payment.EventTopicRefunded,
+	payment.EventTopicVoided,
+	payment.EventTopicDepositHeld,
+	payment.EventTopicDepositCaptured,
+	payment.EventTopicBalanceCaptured,
+	payment.EventTopicBalanceFailed,
+	payment.EventTopicAddOnCaptured,
+	payment.EventTopicAddOnFailed,
+	payment.EventTopicDisputed,
+	payment.EventTopicDisputeResolved,
+	payment.EventTopicMethodAdded,
+	payment.EventTopicMethodRemoved,
+	orchestration.EventTopicRefunded,
+	orchestration.EventTopicCompensationFailed,
+	orchestration.EventTopicPaymentDiscrepancy,
+	orchestration.EventTopicPreArrivalDue,
+}
+
+// EventLog keeps the most recent domain events in memory for the activity feed
+// and notifies watchers of every new event. As the HandlerObserver of the
+// dispatcher, it also records the outcome and duration of every handler call.
+type EventLog struct {
+	capacity int
+
+	mu       sync.Mutex
+	records  []EventRecord
+	watchers map[chan struct{}]struct{}
+}
+
+// NewEventLog creates an event log that keeps the last capacity events.
+func NewEventLog(capacity int) *EventLog {
+	return &EventLog{
+		capacity: max(capacity, 1),
+		watchers: make(map[chan struct{}]struct{}),
+	}
+}
+
+// RegisterHandlers subscribes the event log to all EventTopics.
+func (l *EventLog) RegisterHandlers(ctx context.Context, dispatcher messaging.Dispatcher) error {
+	for _, topic := range EventTopics {
+		if err := dispatcher.Subscribe(ctx, topic, service.Wrap(l.handleEvent)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// Recent returns the recorded events, newest first.
+func (l *EventLog) Recent() []EventRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	records := make([]EventRecord, 0, len(l.records))
+	for i := len(l.records) - 1; i >= 0; i-- {
+		records = append(records, l.records[i].clone())
+	}
+	return records
+}
+
+// Trail returns the recorded events of the correlation ID in the order they were received,
+// e.g. every event of one booking from the request that started it.
+func (l *EventLog) Trail(correlationID string) []EventRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	records := []EventRecord{}
+	for _, record := range l.records {
+		if correlationID != "" && record.CorrelationID == correlationID {
+			records = append(records, record.clone())
+		}
+	}
+	return records
+}
+
+// Forget drops the recorded events of the reservations, e.g. on a guest's erasure
+// request, and returns their number.
+func (l *EventLog) Forget(reservationIDs []shared.ReservationID) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	kept := l.records[:0]
+	for _, record := range l.records {
+		if !slices.Contains(reservationIDs, record.ReservationID) {
+			kept = append(kept, record)
+		}
+	}
+	forgotten := len(l.records) - len(kept)
+	clear(l.records[len(kept):])
+	l.records = kept
+	if forgotten > 0 {
+		l.notify()
+	}
+	return forgotten
+}
+
+// ObserveHandler records the outcome of a handler call for the event of the message.
+// Outcomes that arrive before the event log received the event itself create its record.
+func (l *EventLog) ObserveHandler(msg messaging.Message, outcome HandlerOutcome) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := messageKey(msg)
+	record := l.find(key, func(*EventRecord) bool { return true })
+	if record == nil {
+		evt, _ := parseLoggedEvent(msg)
+		record = l.add(msg.Topic, key, evt)
+	}
+	record.Handlers = append(record.Handlers, outcome)
+	l.notify()
+}
+
+// Watch returns a channel that receives a signal after new events were recorded.
+// The channel is closed when ctx is done.
+func (l *EventLog) Watch(ctx context.Context) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	l.mu.Lock()
+	l.watchers[ch] = struct{}{}
+	l.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.watchers, ch)
+		close(ch)
+	}()
+
+	return ch
+}
+
+// loggedEvent holds the fields every event of the log is shown with.
+type loggedEvent struct {
+	ReservationID shared.ReservationID `json:"reservation_id"`
+	CorrelationID string               `json:"correlation_id"`
+}
+
+// parseLoggedEvent reads the fields of the log from the payload of a message.
+func parseLoggedEvent(msg messaging.Message) (loggedEvent, error) {
+	var evt loggedEvent
+	err := json.Unmarshal(msg.Data, &evt)
+	return evt, err
+}
+
+// messageKey identifies a message by its topic and payload.
+func messageKey(msg messaging.Message) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(msg.Topic))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write(msg.Data)
+	return h.Sum64()
+}
+
+// handleEvent records an event with the reservation it refers to.
+func (l *EventLog) handleEvent(msg messaging.Message) (messaging.MessageState, error) {
+	evt, err := parseLoggedEvent(msg)
+	if err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Handlers may have reported their outcomes before the event arrived here.
+	key := messageKey(msg)
+	record := l.find(key, func(record *EventRecord) bool { return !record.logged })
+	if record == nil {
+		record = l.add(msg.Topic, key, evt)
+	}
+	record.logged = true
+	l.notify()
+	return messaging.MessageStateCompleted, nil
+}
+
+// find returns the newest record of the key that matches, or nil. The caller holds the lock.
+func (l *EventLog) find(key uint64, match func(*EventRecord) bool) *EventRecord {
+	for i := len(l.records) - 1; i >= 0; i-- {
+		if l.records[i].key == key && match(&l.records[i]) {
+			return &l.records[i]
+		}
+	}
+	return nil
+}
+
+// add appends a record and drops the oldest records beyond the capacity. The caller holds the lock.
+func (l *EventLog) add(topic string, key uint64, evt loggedEvent) *EventRecord {
+	l.records = append(l.records, EventRecord{
+		Topic:         topic,
+		ReservationID: evt.ReservationID,
+		CorrelationID: evt.CorrelationID,
+		ReceivedAt:    time.Now(),
+		key:           key,
+	})
+	if len(l.records) > l.capacity {
+		l.records = l.records[len(l.records)-l.capacity:]
+	}
+	return &l.records[len(l.records)-1]
+}
+
+// notify signals the watchers. The caller holds the lock.
+func (l *EventLog) notify() {
+	for ch := range l.watchers {
+		// Watchers that were not notified yet are not signalled twice.
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package admin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/domain/admin"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// EventLog Tests
+// ============================================================================
+
+func Test_EventLog_Should_Record_Events_Newest_First(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := messaging.NewInternalDispatcher()
+	log := admin.NewEventLog(10)
+	_ = log.RegisterHandlers(ctx, dispatcher)
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCreated, []byte(`{"reservation_id":"res-1"}`)))
+	_ = dispatcher.Publish(ctx, messaging.NewMessage(payment.EventTopicAuthorized, []byte(`{"reservation_id":"res-1"}`)))
+
+	// Assert
+	records := log.Recent()
+	assert.That(t, "log must contain two events", len(records), 2)
+	assert.That(t, "newest event must come first", records[0].Topic, payment.EventTopicAuthorized)
+	assert.That(t, "reservation ID must be recorded", string(records[1].ReservationID), "res-1")
+}
+
+func Test_EventLog_Should_Keep_Only_The_Last_Events(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := messaging.NewInternalDispatcher()
+	log := admin.NewEventLog(2)
+	_ = log.RegisterHandlers(ctx, dispatcher)
+
+	// Act
+	for _, id := range []string{"res-1", "res-2", "res-3"} {
+		_ = dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCreated, []byte(`{"reservation_id":"`+id+`"}`)))
+	}
+
+	// Assert
+	records := log.Recent()
+	assert.That(t, "log must contain two events", len(records), 2)
+	assert.That(t, "oldest event must be dropped", string(records[1].ReservationID), "res-2")
+}
+
+func Test_EventLog_Forget_Should_Drop_Events_Of_Reservations(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := messaging.NewInternalDispatcher()
+	log := admin.NewEventLog(10)
+	_ = log.RegisterHandlers(ctx, dispatcher)
+	for _, id := range []string{"res-1", "res-2", "res-1"} {
+		_ = dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCreated, []byte(`{"reservation_id":"`+id+`"}`)))
+	}
+
+	// Act
+	forgotten := log.Forget([]shared.ReservationID{"res-1"})
+
+	// Assert
+	records := log.Recent()
+	assert.That(t, "two events must be forgotten", forgotten, 2)
+	assert.That(t, "log must contain one event", len(records), 1)
+	assert.That(t, "other reservation must be kept", string(records[0].ReservationID), "res-2")
+}
+
+func Test_EventLog_Watch_Should_Signal_New_Events(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	dispatcher := messaging.NewInternalDispatcher()
+	log := admin.NewEventLog(10)
+	_ = log.RegisterHandlers(ctx, dispatcher)
+	updates := log.Watch(ctx)
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCancelled, []byte(`{"reservation_id":"res-1"}`)))
+	_, signalled := <-updates
+	cancel()
+	_, open := <-updates
+
+	// Assert
+	assert.That(t, "watcher must be signalled", signalled, true)
+	assert.That(t, "channel must be closed after cancel", open, false)
+}
+
+func Test_EventLog_ObserveHandler_Should_Add_Outcome_To_Event(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := messaging.NewInternalDispatcher()
+	log := admin.NewEventLog(10)
+	_ = log.RegisterHandlers(ctx, dispatcher)
+	msg := messaging.NewMessage(payment.EventTopicCaptured, []byte(`{"reservation_id":"res-1","correlation_id":"req-1"}`))
+
+	// Act
+	_ = dispatcher.Publish(ctx, msg)
+	log.ObserveHandler(msg, admin.HandlerOutcome{Handler: "orchestration", Duration: 3 * time.Millisecond})
+	log.ObserveHandler(msg, admin.HandlerOutcome{Handler: "search", Failed: true, Error: "index unavailable", Duration: 5 * time.Millisecond})
+
+	// Assert
+	records := log.Recent()
+	assert.That(t, "log must contain one event", len(records), 1)
+	assert.That(t, "correlation ID must be recorded", records[0].CorrelationID, "req-1")
+	assert.That(t, "outcomes must be recorded", len(records[0].Handlers), 2)
+	assert.That(t, "latency must be the slowest handler", records[0].Latency(), 5*time.Millisecond)
+	assert.That(t, "event must be failed", records[0].Failed(), true)
+}
+
+func Test_EventLog_ObserveHandler_Before_Event_Should_Create_Record_Once(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := messaging.NewInternalDispatcher()
+	log := admin.NewEventLog(10)
+	_ = log.RegisterHandlers(ctx, dispatcher)
+	msg := messaging.NewMessage(reservation.EventTopicCreated, []byte(`{"reservation_id":"res-1"}`))
+
+	// Act
+	log.ObserveHandler(msg, admin.HandlerOutcome{Handler: "orchestration"})
+	_ = dispatcher.Publish(ctx, msg)
+
+	// Assert
+	records := log.Recent()
+	assert.That(t, "log must contain one event", len(records), 1)
+	assert.That(t, "outcome must be kept", len(records[0].Handlers), 1)
+}
+
+func Test_EventLog_Trail_Should_Return_Events_Of_Correlation_ID_Oldest_First(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := messaging.NewInternalDispatcher()
+	log := admin.NewEventLog(10)
+	_ = log.RegisterHandlers(ctx, dispatcher)
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCreated, []byte(`{"reservation_id":"res-1","correlation_id":"req-1"}`)))
+	_ = dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCreated, []byte(`{"reservation_id":"res-2","correlation_id":"req-2"}`)))
+	_ = dispatcher.Publish(ctx, messaging.NewMessage(payment.EventTopicAuthorized, []byte(`{"reservation_id":"res-1","correlation_id":"req-1"}`)))
+
+	// Assert
+	trail := log.Trail("req-1")
+	assert.That(t, "trail must contain two events", len(trail), 2)
+	assert.That(t, "oldest event must come first", trail[0].Topic, reservation.EventTopicCreated)
+	assert.That(t, "trail must end with the newest event", trail[1].Topic, payment.EventTopicAuthorized)
+}
@@ -0,0 +1,201 @@
+// Package admin composes the operational views of the hotel staff from the
+// read models of the other contexts: the arrivals and departures of a day,
+// pending payments, failed compensations, recent events and the health of
+// the lookup indexes.
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Service provides the admin dashboard queries.
+type Service struct {
+	reservationService *reservation.Service
+	paymentService     *payment.Service
+	bookingService     *orchestration.BookingService
+	events             *EventLog
+	sagaTracker        *orchestration.SagaTracker
+	staleSagaAfter     time.Duration
+}
+
+// NewService creates a new admin service.
+// Sagas without progress for 15 minutes are reported as stuck until WithStaleSagaAfter is set.
+func NewService(reservationSvc *reservation.Service, paymentSvc *payment.Service, bookingSvc *orchestration.BookingService, events *EventLog) *Service {
+	return &Service{
+		reservationService: reservationSvc,
+		paymentService:     paymentSvc,
+		bookingService:     bookingSvc,
+		events:             events,
+		staleSagaAfter:     15 * time.Minute,
+	}
+}
+
+// WithSagaTracker adds the saga states to the index health report.
+func (s *Service) WithSagaTracker(tracker *orchestration.SagaTracker) *Service {
+	s.sagaTracker = tracker
+	return s
+}
+
+// WithStaleSagaAfter sets how long an unfinished saga may go without progress
+// before it is reported as stuck.
+func (s *Service) WithStaleSagaAfter(d time.Duration) *Service {
+	s.staleSagaAfter = d
+	return s
+}
+
+// Movements returns the reservations arriving and departing on the day, ordered by room.
+// Cancelled and no-show reservations and external holds are not included.
+func (s *Service) Movements(ctx context.Context, day time.Time) (*Movements, error) {
+	reservations, err := s.reservationService.ListReservations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	movements := &Movements{
+		Day:        day,
+		Arrivals:   []*reservation.Reservation{},
+		Departures: []*reservation.Reservation{},
+	}
+	for _, res := range reservations {
+		if res.Status == reservation.StatusCancelled || res.Status == reservation.StatusNoShow || res.GuestID == reservation.ExternalHoldGuestID {
+			continue
+		}
+		if sameDate(res.DateRange.CheckIn, day) {
+			movements.Arrivals = append(movements.Arrivals, res)
+		}
+		if sameDate(res.DateRange.CheckOut, day) {
+			movements.Departures = append(movements.Departures, res)
+		}
+	}
+	sortByRoom(movements.Arrivals)
+	sortByRoom(movements.Departures)
+
+	return movements, nil
+}
+
+// PendingPayments returns the payments that are not captured yet, oldest first.
+func (s *Service) PendingPayments(ctx context.Context) ([]payment.Payment, error) {
+	payments, err := s.paymentService.ListPayments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	pending := []payment.Payment{}
+	for _, p := range payments {
+		if p.Status == payment.StatusPending || p.Status == payment.StatusAuthorized {
+			pending = append(pending, p)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+
+	return pending, nil
+}
+
+// FailedCompensations returns the compensating actions waiting for a retry.
+func (s *Service) FailedCompensations(ctx context.Context) ([]orchestration.FailedCompensation, error) {
+	compensations, err := s.bookingService.ListFailedCompensations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed compensations: %w", err)
+	}
+	return compensations, nil
+}
+
+// RecentEvents returns the recorded domain events, newest first.
+func (s *Service) RecentEvents() []EventRecord {
+	if s.events == nil {
+		return []EventRecord{}
+	}
+	return s.events.Recent()
+}
+
+// EventTrail returns the recorded domain events of the correlation ID, oldest first.
+func (s *Service) EventTrail(correlationID string) []EventRecord {
+	if s.events == nil {
+		return []EventRecord{}
+	}
+	return s.events.Trail(correlationID)
+}
+
+// WatchEvents returns a channel that receives a signal after new events were recorded.
+// Without an event log, the channel never receives.
+func (s *Service) WatchEvents(ctx context.Context) <-chan struct{} {
+	if s.events == nil {
+		return nil
+	}
+	return s.events.Watch(ctx)
+}
+
+// IndexHealth checks the lookup indexes and read models against the reservations
+// they refer to: payments are looked up by reservation, and saga states must belong
+// to a reservation and finish within the stale saga timeout.
+func (s *Service) IndexHealth(ctx context.Context, now time.Time) ([]IndexHealth, error) {
+	reservations, err := s.reservationService.ListReservations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+	exists := make(map[shared.ReservationID]bool, len(reservations))
+	for _, res := range reservations {
+		exists[res.ID.Shared()] = true
+	}
+
+	payments, err := s.paymentService.ListPayments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+	paymentIndex := IndexHealth{Name: IndexPaymentsByReservation, Entries: len(payments), Issues: []string{}}
+	for _, p := range payments {
+		if !exists[p.ReservationID.Shared()] {
+			paymentIndex.Issues = append(paymentIndex.Issues, fmt.Sprintf("payment %s refers to missing reservation %s", p.ID, p.ReservationID))
+		}
+	}
+	sort.Strings(paymentIndex.Issues)
+	report := []IndexHealth{paymentIndex}
+
+	if s.sagaTracker != nil {
+		states, err := s.sagaTracker.ListSagaStates(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list saga states: %w", err)
+		}
+		sagaIndex := IndexHealth{Name: IndexSagaStates, Entries: len(states), Issues: []string{}}
+		for i := range states {
+			state := &states[i]
+			if !exists[state.ReservationID] {
+				sagaIndex.Issues = append(sagaIndex.Issues, fmt.Sprintf("saga %s refers to missing reservation", state.ReservationID))
+				continue
+			}
+			if !state.Done() && now.Sub(state.UpdatedAt) > s.staleSagaAfter {
+				sagaIndex.Issues = append(sagaIndex.Issues, fmt.Sprintf("saga %s without progress since %s", state.ReservationID, state.UpdatedAt.Format(time.RFC3339)))
+			}
+		}
+		sort.Strings(sagaIndex.Issues)
+		report = append(report, sagaIndex)
+	}
+
+	return report, nil
+}
+
+// sameDate reports whether a stay date falls on the day. Stay dates are calendar
+// dates stored at midnight UTC, the day is compared in its own location.
+func sameDate(date, day time.Time) bool {
+	y1, m1, d1 := date.UTC().Date()
+	y2, m2, d2 := day.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// sortByRoom orders reservations by room and ID.
+func sortByRoom(reservations []*reservation.Reservation) {
+	sort.Slice(reservations, func(i, j int) bool {
+		if reservations[i].RoomID != reservations[j].RoomID {
+			return reservations[i].RoomID < reservations[j].RoomID
+		}
+		return reservations[i].ID < reservations[j].ID
+	})
+}
@@ -0,0 +1,262 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/admin"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockReservationRepository struct {
+	resource.Access[reservation.ReservationID, reservation.Reservation]
+}
+
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockAvailabilityChecker struct{}
+
+func (m *mockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	return true, nil
+}
+
+func (m *mockAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockPaymentRepository struct {
+	resource.Access[payment.PaymentID, payment.Payment]
+}
+
+func (m *mockPaymentRepository) FindByReservationID(ctx context.Context, reservationID payment.ReservationID) ([]payment.Payment, error) {
+	return nil, nil
+}
+
+type mockPaymentGateway struct{}
+
+func (m *mockPaymentGateway) Authorize(ctx context.Context, p *payment.Payment) (string, error) {
+	return "tx-" + string(p.ID), nil
+}
+
+func (m *mockPaymentGateway) Capture(ctx context.Context, transactionID string, amount shared.Money) error {
+	return nil
+}
+
+func (m *mockPaymentGateway) Refund(ctx context.Context, transactionID string, amount shared.Money) error {
+	return nil
+}
+
+func (m *mockPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	return nil
+}
+
+type mockEventPublisher struct{}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, e event.Event) error {
+	return nil
+}
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type testServices struct {
+	reservations  resource.Access[reservation.ReservationID, reservation.Reservation]
+	payments      resource.Access[payment.PaymentID, payment.Payment]
+	compensations orchestration.CompensationQueue
+	sagaStates    orchestration.SagaStateRepository
+	adminService  *admin.Service
+}
+
+func createTestServices() *testServices {
+	reservations := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()
+	payments := resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]()
+	compensations := resource.NewInMemoryAccess[orchestration.CompensationID, orchestration.FailedCompensation]()
+	sagaStates := resource.NewInMemoryAccess[shared.ReservationID, orchestration.SagaState]()
+
+	reservationService := reservation.NewService(&mockReservationRepository{reservations}, &mockAvailabilityChecker{}, &mockEventPublisher{})
+	paymentService := payment.NewService(&mockPaymentRepository{payments}, &mockPaymentGateway{}, &mockEventPublisher{})
+	bookingService := orchestration.NewBookingService(reservationService, paymentService, nil).
+		WithCompensationQueue(compensations)
+
+	return &testServices{
+		reservations:  reservations,
+		payments:      payments,
+		compensations: compensations,
+		sagaStates:    sagaStates,
+		adminService: admin.NewService(reservationService, paymentService, bookingService, nil).
+			WithSagaTracker(orchestration.NewSagaTracker(sagaStates)),
+	}
+}
+
+func addReservation(t *testing.T, svc *testServices, id, roomID string, checkIn, checkOut time.Time, status reservation.ReservationStatus) {
+	t.Helper()
+	res := reservation.Reservation{
+		ID:        reservation.ReservationID(id),
+		GuestID:   "guest@example.com",
+		RoomID:    reservation.RoomID(roomID),
+		DateRange: reservation.DateRange{CheckIn: checkIn, CheckOut: checkOut},
+		Status:    status,
+	}
+	if err := svc.reservations.Create(context.Background(), res.ID, res); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+}
+
+func addPayment(t *testing.T, svc *testServices, id, reservationID string, status payment.PaymentStatus, createdAt time.Time) {
+	t.Helper()
+	p := payment.Payment{
+		ID:            payment.PaymentID(id),
+		ReservationID: payment.ReservationID(reservationID),
+		Status:        status,
+		CreatedAt:     createdAt,
+	}
+	if err := svc.payments.Create(context.Background(), p.ID, p); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+}
+
+// testDay is a morning a week ahead, so the reservations of the tests are upcoming whenever they run.
+var testDay = time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 7).Add(9*time.Hour + 30*time.Minute)
+
+func date(days int) time.Time {
+	return testDay.Truncate(24*time.Hour).AddDate(0, 0, days)
+}
+
+// ============================================================================
+// Movements Tests
+// ============================================================================
+
+func Test_Service_Movements_Should_Return_Arrivals_And_Departures_Of_The_Day(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	addReservation(t, svc, "res-arrival-2", "room-202", date(0), date(2), reservation.StatusConfirmed)
+	addReservation(t, svc, "res-arrival-1", "room-101", date(0), date(3), reservation.StatusPending)
+	addReservation(t, svc, "res-departure", "room-303", date(-2), date(0), reservation.StatusActive)
+	addReservation(t, svc, "res-later", "room-404", date(1), date(4), reservation.StatusConfirmed)
+
+	// Act
+	movements, err := svc.adminService.Movements(context.Background(), testDay)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "arrivals must contain two reservations", len(movements.Arrivals), 2)
+	assert.That(t, "arrivals must be ordered by room", movements.Arrivals[0].ID, reservation.ReservationID("res-arrival-1"))
+	assert.That(t, "departures must contain one reservation", len(movements.Departures), 1)
+	assert.That(t, "departure must be the ending stay", movements.Departures[0].ID, reservation.ReservationID("res-departure"))
+}
+
+func Test_Service_Movements_Should_Skip_Cancelled_Reservations_And_External_Holds(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	addReservation(t, svc, "res-cancelled", "room-101", date(0), date(2), reservation.StatusCancelled)
+	hold, _ := reservation.NewExternalHold("hold-1", "room-202", reservation.DateRange{CheckIn: date(0), CheckOut: date(1)}, "airbnb")
+	_ = svc.reservations.Create(context.Background(), hold.ID, *hold)
+
+	// Act
+	movements, err := svc.adminService.Movements(context.Background(), testDay)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "arrivals must be empty", len(movements.Arrivals), 0)
+	assert.That(t, "departures must be empty", len(movements.Departures), 0)
+}
+
+// ============================================================================
+// PendingPayments Tests
+// ============================================================================
+
+func Test_Service_PendingPayments_Should_Return_Uncaptured_Payments_Oldest_First(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	addPayment(t, svc, "pay-authorized", "res-1", payment.StatusAuthorized, testDay)
+	addPayment(t, svc, "pay-pending", "res-2", payment.StatusPending, testDay.Add(-time.Hour))
+	addPayment(t, svc, "pay-captured", "res-3", payment.StatusCaptured, testDay)
+
+	// Act
+	pending, err := svc.adminService.PendingPayments(context.Background())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "pending must contain two payments", len(pending), 2)
+	assert.That(t, "oldest payment must come first", pending[0].ID, payment.PaymentID("pay-pending"))
+}
+
+// ============================================================================
+// FailedCompensations Tests
+// ============================================================================
+
+func Test_Service_FailedCompensations_Should_Return_Queued_Compensations(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	comp := orchestration.NewFailedCompensation(orchestration.CompensationRefundPayment, "res-1", "pay-1", "cancelled", errors.New("gateway down"))
+	_ = svc.compensations.Create(context.Background(), comp.ID, *comp)
+
+	// Act
+	compensations, err := svc.adminService.FailedCompensations(context.Background())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "compensations must contain one entry", len(compensations), 1)
+	assert.That(t, "last error must be kept", compensations[0].LastError, "gateway down")
+}
+
+// ============================================================================
+// IndexHealth Tests
+// ============================================================================
+
+func Test_Service_IndexHealth_With_Consistent_Indexes_Should_Be_Healthy(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	addReservation(t, svc, "res-1", "room-101", date(1), date(3), reservation.StatusConfirmed)
+	addPayment(t, svc, "pay-1", "res-1", payment.StatusCaptured, testDay)
+	state := orchestration.NewSagaState("res-1")
+	for i := range state.Steps {
+		state.Steps[i].Status = orchestration.SagaStepCompleted
+	}
+	_ = svc.sagaStates.Create(context.Background(), "res-1", *state)
+
+	// Act
+	report, err := svc.adminService.IndexHealth(context.Background(), testDay)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "report must contain two indexes", len(report), 2)
+	assert.That(t, "payment index must be healthy", report[0].Healthy(), true)
+	assert.That(t, "payment index must count the entries", report[0].Entries, 1)
+	assert.That(t, "saga index must be healthy", report[1].Healthy(), true)
+}
+
+func Test_Service_IndexHealth_With_Orphans_And_Stuck_Sagas_Should_Report_Issues(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	addReservation(t, svc, "res-1", "room-101", date(1), date(3), reservation.StatusPending)
+	addPayment(t, svc, "pay-orphan", "res-missing", payment.StatusAuthorized, testDay)
+	stuck := orchestration.NewSagaState("res-1")
+	stuck.UpdatedAt = testDay.Add(-time.Hour)
+	_ = svc.sagaStates.Create(context.Background(), "res-1", *stuck)
+	_ = svc.sagaStates.Create(context.Background(), "res-missing", *orchestration.NewSagaState("res-missing"))
+
+	// Act
+	report, err := svc.adminService.IndexHealth(context.Background(), testDay)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "payment index must report the orphan", report[0].Issues, []string{"payment pay-orphan refers to missing reservation res-missing"})
+	assert.That(t, "saga index must report two issues", len(report[1].Issues), 2)
+	assert.That(t, "saga index must not be healthy", report[1].Healthy(), false)
+}
@@ -0,0 +1,44 @@
+package backfill
+
+import (
+	"errors"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// DefaultBatchSize is the number of reservations projected between two checkpoints.
+const DefaultBatchSize = 100
+
+// ErrUnknownProjection is returned for a projection that is not registered.
+var ErrUnknownProjection = errors.New("unknown projection")
+
+// Checkpoint is the progress of a backfill, persisted so an interrupted run
+// resumes after the last projected reservation instead of starting over.
+type Checkpoint struct {
+	Projection string                    `json:"projection"`
+	LastID     reservation.ReservationID `json:"last_id"`   // Reservations are projected in ID order
+	Projected  int                       `json:"projected"` // Reservations projected by all runs
+	Done       bool                      `json:"done"`
+	UpdatedAt  time.Time                 `json:"updated_at"`
+}
+
+// Progress is reported after every batch of a backfill.
+type Progress struct {
+	Projection string
+	Projected  int // Reservations projected, including those of earlier runs
+	Total      int // Stored reservations
+	LastID     reservation.ReservationID
+	Done       bool
+}
+
+// progress returns the progress recorded by the checkpoint.
+func (c *Checkpoint) progress(total int) Progress {
+	return Progress{
+		Projection: c.Projection,
+		Projected:  c.Projected,
+		Total:      total,
+		LastID:     c.LastID,
+		Done:       c.Done,
+	}
+}
@@ -0,0 +1,16 @@
+package backfill
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ProjectFunc updates a read model from the current state of a reservation.
+// It must be idempotent, because a resumed backfill projects the reservations
+// of an unfinished batch again and live events may project them concurrently.
+type ProjectFunc func(ctx context.Context, res *reservation.Reservation) error
+
+// CheckpointRepository persists the checkpoints by projection name.
+type CheckpointRepository resource.Access[string, Checkpoint]
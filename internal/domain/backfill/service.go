@@ -0,0 +1,176 @@
+// Package backfill builds newly added projections from the stored reservations.
+// A projection that is introduced later, e.g. the search index, only sees the
+// events published after it was deployed; a backfill replays the current state
+// of every reservation into it, batch by batch, and resumes after a checkpoint
+// when it is interrupted.
+package backfill
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// Service runs the backfills of the registered projections.
+type Service struct {
+	reservations reservation.ReservationRepository
+	checkpoints  CheckpointRepository
+	projections  map[string]ProjectFunc
+	batchSize    int
+}
+
+// NewService creates a new backfill service.
+func NewService(reservations reservation.ReservationRepository, checkpoints CheckpointRepository) *Service {
+	return &Service{
+		reservations: reservations,
+		checkpoints:  checkpoints,
+		projections:  make(map[string]ProjectFunc),
+		batchSize:    DefaultBatchSize,
+	}
+}
+
+// WithBatchSize sets the number of reservations projected between two checkpoints (default 100).
+func (s *Service) WithBatchSize(size int) *Service {
+	if size > 0 {
+		s.batchSize = size
+	}
+	return s
+}
+
+// WithProjection registers a projection under the name of its checkpoint.
+func (s *Service) WithProjection(name string, project ProjectFunc) *Service {
+	s.projections[name] = project
+	return s
+}
+
+// Projections returns the names of the registered projections in alphabetical order.
+func (s *Service) Projections() []string {
+	names := make([]string, 0, len(s.projections))
+	for name := range s.projections {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Run projects the stored reservations in ID order. A run resumes after the
+// checkpoint of an earlier run, and a finished backfill is not run again until
+// it is reset. The checkpoint is saved and the progress reported after every
+// batch; a failed or cancelled run saves the reservations projected so far.
+// Reservations created while a backfill is paused may sort before its checkpoint,
+// which is fine as long as the projection receives the live events.
+func (s *Service) Run(ctx context.Context, name string, report func(Progress)) (Progress, error) {
+	project, ok := s.projections[name]
+	if !ok {
+		return Progress{Projection: name}, fmt.Errorf("%w: %s", ErrUnknownProjection, name)
+	}
+	if report == nil {
+		report = func(Progress) {}
+	}
+
+	checkpoint, exists, err := s.readCheckpoint(ctx, name)
+	if err != nil {
+		return Progress{Projection: name}, err
+	}
+
+	reservations, err := s.reservations.ReadAll(ctx)
+	if err != nil {
+		return Progress{Projection: name}, fmt.Errorf("failed to read reservations: %w", err)
+	}
+	slices.SortFunc(reservations, func(a, b reservation.Reservation) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	total := len(reservations)
+
+	if checkpoint.Done {
+		progress := checkpoint.progress(total)
+		report(progress)
+		return progress, nil
+	}
+
+	batch := 0
+	for i := range reservations {
+		res := &reservations[i]
+		if checkpoint.LastID != "" && res.ID <= checkpoint.LastID {
+			continue
+		}
+
+		err := ctx.Err()
+		if err == nil {
+			if err = project(ctx, res); err != nil {
+				err = fmt.Errorf("failed to project reservation %s: %w", res.ID, err)
+			}
+		}
+		if err != nil {
+			// Keep the reservations of the unfinished batch for the next run
+			return checkpoint.progress(total), errors.Join(err, s.saveCheckpoint(ctx, checkpoint, &exists))
+		}
+
+		checkpoint.LastID = res.ID
+		checkpoint.Projected++
+		batch++
+		if batch == s.batchSize {
+			if err := s.saveCheckpoint(ctx, checkpoint, &exists); err != nil {
+				return checkpoint.progress(total), err
+			}
+			report(checkpoint.progress(total))
+			batch = 0
+		}
+	}
+
+	checkpoint.Done = true
+	if err := s.saveCheckpoint(ctx, checkpoint, &exists); err != nil {
+		return checkpoint.progress(total), err
+	}
+	progress := checkpoint.progress(total)
+	report(progress)
+	return progress, nil
+}
+
+// Reset deletes the checkpoint of the projection, so the next run starts over.
+func (s *Service) Reset(ctx context.Context, name string) error {
+	if _, ok := s.projections[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownProjection, name)
+	}
+	if err := s.checkpoints.Delete(ctx, name); err != nil && err.Error() != resource.ErrorResourceNotFound {
+		return fmt.Errorf("failed to delete checkpoint: %w", err)
+	}
+	return nil
+}
+
+// readCheckpoint returns the checkpoint of the projection and whether it is stored.
+func (s *Service) readCheckpoint(ctx context.Context, name string) (*Checkpoint, bool, error) {
+	checkpoint, err := s.checkpoints.Read(ctx, name)
+	if err != nil {
+		if err.Error() == resource.ErrorResourceNotFound {
+			return &Checkpoint{Projection: name}, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	return checkpoint, true, nil
+}
+
+// saveCheckpoint creates or updates the checkpoint.
+func (s *Service) saveCheckpoint(ctx context.Context, checkpoint *Checkpoint, exists *bool) error {
+	checkpoint.UpdatedAt = time.Now()
+
+	// The run may have been cancelled, but its progress must still be saved
+	ctx = context.WithoutCancel(ctx)
+	var err error
+	if *exists {
+		err = s.checkpoints.Update(ctx, checkpoint.Projection, *checkpoint)
+	} else {
+		err = s.checkpoints.Create(ctx, checkpoint.Projection, *checkpoint)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	*exists = true
+	return nil
+}
@@ -0,0 +1,182 @@
+package backfill_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/backfill"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockReservationRepository struct {
+	resource.Access[reservation.ReservationID, reservation.Reservation]
+}
+
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	return nil, nil
+}
+
+// recordingProjection records the projected reservations, fails on the reservation
+// in failOn and calls afterProject once a reservation is projected.
+type recordingProjection struct {
+	projected    []reservation.ReservationID
+	failOn       reservation.ReservationID
+	afterProject func()
+}
+
+func (p *recordingProjection) project(ctx context.Context, res *reservation.Reservation) error {
+	if res.ID == p.failOn {
+		return errors.New("index unavailable")
+	}
+	p.projected = append(p.projected, res.ID)
+	if p.afterProject != nil {
+		p.afterProject()
+	}
+	return nil
+}
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type backfillTestServices struct {
+	checkpoints     backfill.CheckpointRepository
+	projection      *recordingProjection
+	backfillService *backfill.Service
+}
+
+// createBackfillTestServices stores the given number of reservations
+// and registers the recording projection as "search".
+func createBackfillTestServices(t *testing.T, count int) *backfillTestServices {
+	t.Helper()
+	repo := &mockReservationRepository{Access: resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()}
+	checkIn := time.Now().Add(48 * time.Hour)
+	for i := range count {
+		id := reservation.ReservationID(fmt.Sprintf("res-%03d", i+1))
+		res, err := reservation.NewReservation(id, "guest-001", "room-101",
+			reservation.NewDateRange(checkIn, checkIn.Add(24*time.Hour)),
+			shared.NewMoney(10000, "USD"),
+			[]reservation.GuestInfo{{Name: "John Doe", Email: "john@example.com"}},
+		)
+		if err != nil {
+			t.Fatalf("setup failed: %v", err)
+		}
+		_ = repo.Create(context.Background(), id, *res)
+	}
+
+	checkpoints := resource.NewInMemoryAccess[string, backfill.Checkpoint]()
+	projection := &recordingProjection{}
+	backfillService := backfill.NewService(repo, checkpoints).
+		WithBatchSize(2).
+		WithProjection("search", projection.project)
+	return &backfillTestServices{
+		checkpoints:     checkpoints,
+		projection:      projection,
+		backfillService: backfillService,
+	}
+}
+
+// ============================================================================
+// Run Tests
+// ============================================================================
+
+func Test_Service_Run_Should_Project_All_Reservations_In_ID_Order(t *testing.T) {
+	// Arrange
+	svc := createBackfillTestServices(t, 5)
+	var reports []backfill.Progress
+
+	// Act
+	progress, err := svc.backfillService.Run(context.Background(), "search", func(p backfill.Progress) {
+		reports = append(reports, p)
+	})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "all reservations must be projected", svc.projection.projected,
+		[]reservation.ReservationID{"res-001", "res-002", "res-003", "res-004", "res-005"})
+	assert.That(t, "progress must be done", progress.Done, true)
+	assert.That(t, "progress must count the reservations", progress.Projected, 5)
+	assert.That(t, "progress must report every batch and the end", len(reports), 3)
+	assert.That(t, "first report must follow the first batch", reports[0].Projected, 2)
+	assert.That(t, "reports must carry the total", reports[0].Total, 5)
+}
+
+func Test_Service_Run_After_Failure_Should_Resume_After_Checkpoint(t *testing.T) {
+	// Arrange
+	svc := createBackfillTestServices(t, 5)
+	svc.projection.failOn = "res-004"
+	ctx := context.Background()
+	_, firstErr := svc.backfillService.Run(ctx, "search", nil)
+	svc.projection.failOn = ""
+	svc.projection.projected = nil
+
+	// Act
+	progress, err := svc.backfillService.Run(ctx, "search", nil)
+
+	// Assert
+	assert.That(t, "first run must fail", firstErr != nil, true)
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "only the remaining reservations must be projected", svc.projection.projected,
+		[]reservation.ReservationID{"res-004", "res-005"})
+	assert.That(t, "progress must count both runs", progress.Projected, 5)
+	assert.That(t, "progress must be done", progress.Done, true)
+}
+
+func Test_Service_Run_When_Cancelled_Should_Save_Checkpoint(t *testing.T) {
+	// Arrange
+	svc := createBackfillTestServices(t, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	svc.projection.afterProject = cancel
+
+	// Act
+	_, err := svc.backfillService.Run(ctx, "search", nil)
+
+	// Assert
+	assert.That(t, "error must be context.Canceled", errors.Is(err, context.Canceled), true)
+	checkpoint, readErr := svc.checkpoints.Read(context.Background(), "search")
+	assert.That(t, "checkpoint must be saved", readErr == nil, true)
+	assert.That(t, "checkpoint must follow the projected reservation", checkpoint.LastID, reservation.ReservationID("res-001"))
+	assert.That(t, "checkpoint must not be done", checkpoint.Done, false)
+}
+
+func Test_Service_Run_When_Done_Should_Not_Project_Again_Until_Reset(t *testing.T) {
+	// Arrange
+	svc := createBackfillTestServices(t, 2)
+	ctx := context.Background()
+	_, _ = svc.backfillService.Run(ctx, "search", nil)
+	svc.projection.projected = nil
+
+	// Act
+	_, againErr := svc.backfillService.Run(ctx, "search", nil)
+	projectedAgain := len(svc.projection.projected)
+	resetErr := svc.backfillService.Reset(ctx, "search")
+	_, restartErr := svc.backfillService.Run(ctx, "search", nil)
+
+	// Assert
+	assert.That(t, "second run error must be nil", againErr == nil, true)
+	assert.That(t, "second run must project nothing", projectedAgain, 0)
+	assert.That(t, "reset error must be nil", resetErr == nil, true)
+	assert.That(t, "restart error must be nil", restartErr == nil, true)
+	assert.That(t, "restart must project all reservations", len(svc.projection.projected), 2)
+}
+
+func Test_Service_Run_With_Unknown_Projection_Should_Return_ErrUnknownProjection(t *testing.T) {
+	// Arrange
+	svc := createBackfillTestServices(t, 1)
+
+	// Act
+	_, err := svc.backfillService.Run(context.Background(), "bitmap", nil)
+
+	// Assert
+	assert.That(t, "error must be ErrUnknownProjection", errors.Is(err, backfill.ErrUnknownProjection), true)
+}
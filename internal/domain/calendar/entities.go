@@ -0,0 +1,62 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ErrInvalidFeed is returned for feed configurations that cannot be parsed.
+var ErrInvalidFeed = errors.New("invalid calendar feed")
+
+// Event is a blocked date range in a room calendar. Start and End are days;
+// End is exclusive, like the check-out date of a reservation.
+type Event struct {
+	UID       string
+	Start     time.Time
+	End       time.Time
+	Summary   string
+	Cancelled bool
+}
+
+// Feed is an external calendar (e.g. an Airbnb listing) whose events block a room.
+type Feed struct {
+	RoomID reservation.RoomID
+	Source string // Name of the platform, stored as the channel of its holds
+	URL    string
+}
+
+// ParseFeeds parses a comma-separated list of "roomID:source:url" entries,
+// e.g. "room-101:airbnb:https://www.airbnb.com/calendar/ical/123.ics".
+func ParseFeeds(spec string) ([]Feed, error) {
+	var feeds []Feed
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("%w: %q, expected roomID:source:url", ErrInvalidFeed, entry)
+		}
+		u, err := url.Parse(parts[2])
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, fmt.Errorf("%w: %q has no http(s) URL", ErrInvalidFeed, entry)
+		}
+		feeds = append(feeds, Feed{RoomID: reservation.RoomID(parts[0]), Source: parts[1], URL: parts[2]})
+	}
+	return feeds, nil
+}
+
+// SyncReport summarizes the import of a feed.
+type SyncReport struct {
+	RoomID    reservation.RoomID
+	Source    string
+	Placed    int      // New holds
+	Released  int      // Holds whose events were removed or moved
+	Conflicts []string // UIDs of events that overlap existing reservations
+}
@@ -0,0 +1,9 @@
+package calendar
+
+import "context"
+
+// FeedFetcher downloads and parses external calendar feeds (e.g. iCal over HTTP).
+type FeedFetcher interface {
+	// Fetch returns the events of the feed at the URL
+	Fetch(ctx context.Context, url string) ([]Event, error)
+}
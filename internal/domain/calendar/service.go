@@ -0,0 +1,170 @@
+// Package calendar keeps room calendars in sync with external platforms.
+// It exports the reservations of a room as calendar events and imports the
+// events of external feeds (e.g. Airbnb) as holds that block the room.
+package calendar
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Service exports room calendars and imports external feeds.
+type Service struct {
+	reservationService *reservation.Service
+	fetcher            FeedFetcher
+	ids                shared.IDGenerator
+}
+
+// NewService creates a new calendar service.
+func NewService(reservationSvc *reservation.Service, fetcher FeedFetcher) *Service {
+	return &Service{
+		reservationService: reservationSvc,
+		fetcher:            fetcher,
+		ids:                shared.NewUUIDv7Generator(),
+	}
+}
+
+// WithIDGenerator sets the generator for new hold IDs (UUIDv7 by default).
+func (s *Service) WithIDGenerator(ids shared.IDGenerator) *Service {
+	s.ids = ids
+	return s
+}
+
+// RoomEvents returns the confirmed and active reservations of a room from
+// 30 days ago up to two years ahead. Events contain no guest data.
+func (s *Service) RoomEvents(ctx context.Context, roomID reservation.RoomID) ([]Event, error) {
+	today := day(time.Now())
+	reservations, err := s.reservationService.ListReservationsByRoom(ctx, roomID,
+		reservation.NewDateRange(today.AddDate(0, 0, -30), today.AddDate(2, 0, 0)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	events := make([]Event, 0, len(reservations))
+	for _, res := range reservations {
+		if res.Status != reservation.StatusConfirmed && res.Status != reservation.StatusActive {
+			continue
+		}
+		summary := "Reserved"
+		if res.IsExternalHold() {
+			summary = "Blocked (" + res.Channel + ")"
+		}
+		events = append(events, Event{
+			UID:     string(res.ID),
+			Start:   day(res.DateRange.CheckIn),
+			End:     day(res.DateRange.CheckOut),
+			Summary: summary,
+		})
+	}
+	return events, nil
+}
+
+// SyncFeed imports the events of a feed as external holds of its room.
+// Holds of events that disappeared or moved are released, new events are held.
+// Past events are ignored; events that overlap reservations are reported as conflicts.
+func (s *Service) SyncFeed(ctx context.Context, feed Feed) (*SyncReport, error) {
+	events, err := s.fetcher.Fetch(ctx, feed.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+
+	// The hold reference covers the dates, so a moved event is released and held again.
+	// Events that already started are held from today, since check-in cannot be in the past.
+	today := day(time.Now())
+	wanted := make(map[string]Event)
+	for _, evt := range events {
+		evt.Start, evt.End = day(evt.Start), ceilDay(evt.End)
+		if evt.Cancelled || !evt.End.After(today) || !evt.End.After(evt.Start) {
+			continue
+		}
+		ref := holdRef(feed, evt)
+		if evt.Start.Before(today) {
+			evt.Start = today
+		}
+		wanted[ref] = evt
+	}
+
+	holds, err := s.reservationService.ListExternalHolds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list holds: %w", err)
+	}
+
+	report := &SyncReport{RoomID: feed.RoomID, Source: feed.Source}
+	for _, hold := range holds {
+		if hold.RoomID != feed.RoomID || hold.Channel != feed.Source || hold.Status == reservation.StatusCancelled {
+			continue
+		}
+		// Holds placed before references were stored carry the reference as their ID
+		ref := hold.ExternalRef
+		if ref == "" {
+			ref = string(hold.ID)
+		}
+		if _, ok := wanted[ref]; ok {
+			delete(wanted, ref)
+			continue
+		}
+		if err := s.reservationService.ReleaseExternalHold(ctx, hold.ID); err != nil {
+			return report, fmt.Errorf("failed to release hold %s: %w", hold.ID, err)
+		}
+		report.Released++
+	}
+
+	for ref, evt := range wanted {
+		id := shared.NewReservationID(s.ids)
+		_, err := s.reservationService.PlaceExternalHold(ctx, reservation.ToReservationID(id), feed.RoomID, reservation.NewDateRange(evt.Start, evt.End), feed.Source, ref)
+		if errors.Is(err, reservation.ErrRoomUnavailable) {
+			report.Conflicts = append(report.Conflicts, evt.UID)
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("failed to place hold for %s: %w", evt.UID, err)
+		}
+		report.Placed++
+	}
+
+	return report, nil
+}
+
+// SyncFeeds imports all feeds. A failing feed does not stop the others.
+func (s *Service) SyncFeeds(ctx context.Context, feeds []Feed) ([]SyncReport, error) {
+	var reports []SyncReport
+	var errs []error
+	for _, feed := range feeds {
+		report, err := s.SyncFeed(ctx, feed)
+		if report != nil {
+			reports = append(reports, *report)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s/%s: %w", feed.RoomID, feed.Source, err))
+		}
+	}
+	return reports, errors.Join(errs...)
+}
+
+// holdRef derives a stable reference from the feed, the event UID and its dates.
+func holdRef(feed Feed, evt Event) string {
+	sum := sha256.Sum256([]byte(string(feed.RoomID) + "|" + feed.Source + "|" + evt.UID + "|" +
+		evt.Start.Format(time.DateOnly) + "|" + evt.End.Format(time.DateOnly)))
+	return "hold-" + hex.EncodeToString(sum[:8])
+}
+
+// day returns the calendar day of t as midnight UTC.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ceilDay returns the day of t, or the next day if t is after midnight.
+func ceilDay(t time.Time) time.Time {
+	d := day(t)
+	if t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 || t.Nanosecond() != 0 {
+		return d.AddDate(0, 0, 1)
+	}
+	return d
+}
@@ -0,0 +1,258 @@
+package calendar_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/calendar"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockReservationRepository struct {
+	resource.Access[reservation.ReservationID, reservation.Reservation]
+}
+
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	all, _ := m.ReadAll(ctx)
+	var result []reservation.Reservation
+	for _, res := range all {
+		if res.GuestID == guestID {
+			result = append(result, res)
+		}
+	}
+	return result, nil
+}
+
+type mockAvailabilityChecker struct {
+	repo *mockReservationRepository
+}
+
+func (m *mockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	overlapping, err := m.GetOverlappingReservations(ctx, roomID, dateRange)
+	return len(overlapping) == 0, err
+}
+
+func (m *mockAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	all, _ := m.repo.ReadAll(ctx)
+	probe := &reservation.Reservation{RoomID: roomID, DateRange: dateRange, Status: reservation.StatusPending}
+	var result []*reservation.Reservation
+	for i := range all {
+		if probe.IsOverlapping(&all[i]) {
+			result = append(result, &all[i])
+		}
+	}
+	return result, nil
+}
+
+type mockEventPublisher struct{}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error {
+	return nil
+}
+
+type mockFeedFetcher struct {
+	events []calendar.Event
+	err    error
+}
+
+func (m *mockFeedFetcher) Fetch(ctx context.Context, url string) ([]calendar.Event, error) {
+	return m.events, m.err
+}
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+var testFeed = calendar.Feed{RoomID: "room-101", Source: "airbnb", URL: "https://example.com/room-101.ics"}
+
+func createCalendarTestServices() (*reservation.Service, *mockFeedFetcher, *calendar.Service) {
+	repo := &mockReservationRepository{Access: resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()}
+	reservationService := reservation.NewService(repo, &mockAvailabilityChecker{repo: repo}, &mockEventPublisher{})
+	fetcher := &mockFeedFetcher{}
+	return reservationService, fetcher, calendar.NewService(reservationService, fetcher)
+}
+
+func daysFromToday(days int) time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, days)
+}
+
+// ============================================================================
+// ParseFeeds Tests
+// ============================================================================
+
+func Test_ParseFeeds_With_Valid_Spec_Should_Return_Feeds(t *testing.T) {
+	// Act
+	feeds, err := calendar.ParseFeeds("room-101:airbnb:https://www.airbnb.com/calendar/ical/1.ics, room-102:vrbo:https://vrbo.example/2.ics")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "two feeds must be parsed", len(feeds), 2)
+	assert.That(t, "first feed must match", feeds[0], calendar.Feed{RoomID: "room-101", Source: "airbnb", URL: "https://www.airbnb.com/calendar/ical/1.ics"})
+}
+
+func Test_ParseFeeds_Without_URL_Should_Return_ErrInvalidFeed(t *testing.T) {
+	// Act
+	_, err := calendar.ParseFeeds("room-101:airbnb")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidFeed", errors.Is(err, calendar.ErrInvalidFeed), true)
+}
+
+// ============================================================================
+// RoomEvents Tests
+// ============================================================================
+
+func Test_Service_RoomEvents_Should_Return_Confirmed_Reservations_Only(t *testing.T) {
+	// Arrange
+	reservationService, _, calendarService := createCalendarTestServices()
+	ctx := context.Background()
+	guests := []reservation.GuestInfo{{Name: "John Doe", Email: "john@example.com"}}
+	amount := shared.NewMoney(10000, "USD")
+	_, _ = reservationService.CreateReservation(ctx, "res-pending", "john@example.com", "room-101",
+		reservation.NewDateRange(daysFromToday(2), daysFromToday(4)), amount, guests)
+	_, _ = reservationService.CreateReservation(ctx, "res-confirmed", "john@example.com", "room-101",
+		reservation.NewDateRange(daysFromToday(10), daysFromToday(12)), amount, guests)
+	_ = reservationService.ConfirmReservation(ctx, "res-confirmed")
+	_, _ = reservationService.PlaceExternalHold(ctx, "hold-001", "room-101",
+		reservation.NewDateRange(daysFromToday(20), daysFromToday(22)), "airbnb", "evt-1@airbnb.com")
+
+	// Act
+	events, err := calendarService.RoomEvents(ctx, "room-101")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "two events must be returned", len(events), 2)
+	summaries := map[string]string{}
+	for _, evt := range events {
+		summaries[evt.UID] = evt.Summary
+	}
+	assert.That(t, "reservation must be reserved", summaries["res-confirmed"], "Reserved")
+	assert.That(t, "hold must name its source", summaries["hold-001"], "Blocked (airbnb)")
+}
+
+// ============================================================================
+// SyncFeed Tests
+// ============================================================================
+
+func Test_Service_SyncFeed_Should_Place_Holds_For_Future_Events(t *testing.T) {
+	// Arrange
+	reservationService, fetcher, calendarService := createCalendarTestServices()
+	ctx := context.Background()
+	fetcher.events = []calendar.Event{
+		{UID: "evt-1@airbnb.com", Start: daysFromToday(5), End: daysFromToday(8)},
+		{UID: "evt-past@airbnb.com", Start: daysFromToday(-10), End: daysFromToday(-7)},
+		{UID: "evt-cancelled@airbnb.com", Start: daysFromToday(30), End: daysFromToday(32), Cancelled: true},
+	}
+
+	// Act
+	report, err := calendarService.SyncFeed(ctx, testFeed)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one hold must be placed", report.Placed, 1)
+	holds, _ := reservationService.ListExternalHolds(ctx)
+	assert.That(t, "one hold must be stored", len(holds), 1)
+	assert.That(t, "hold must block the room", holds[0].RoomID, reservation.RoomID("room-101"))
+	assert.That(t, "hold must start on the event's day", holds[0].DateRange.CheckIn, daysFromToday(5))
+}
+
+type fixedIDGenerator struct{ id string }
+
+func (g fixedIDGenerator) NewID() string { return g.id }
+
+func Test_Service_SyncFeed_Should_Use_Injected_IDGenerator(t *testing.T) {
+	// Arrange
+	reservationService, fetcher, calendarService := createCalendarTestServices()
+	calendarService.WithIDGenerator(fixedIDGenerator{id: "018f3c2a-0000-7000-8000-000000000001"})
+	ctx := context.Background()
+	fetcher.events = []calendar.Event{{UID: "evt-1@airbnb.com", Start: daysFromToday(5), End: daysFromToday(8)}}
+
+	// Act
+	_, err := calendarService.SyncFeed(ctx, testFeed)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	holds, _ := reservationService.ListExternalHolds(ctx)
+	assert.That(t, "one hold must be stored", len(holds), 1)
+	assert.That(t, "hold must use the generated ID", holds[0].ID, reservation.ReservationID("018f3c2a-0000-7000-8000-000000000001"))
+	assert.That(t, "hold must reference the event", holds[0].ExternalRef != "", true)
+}
+
+func Test_Service_SyncFeed_Twice_Should_Not_Duplicate_Holds(t *testing.T) {
+	// Arrange
+	reservationService, fetcher, calendarService := createCalendarTestServices()
+	ctx := context.Background()
+	fetcher.events = []calendar.Event{{UID: "evt-1@airbnb.com", Start: daysFromToday(5), End: daysFromToday(8)}}
+	_, _ = calendarService.SyncFeed(ctx, testFeed)
+
+	// Act
+	report, err := calendarService.SyncFeed(ctx, testFeed)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "no hold must be placed", report.Placed, 0)
+	assert.That(t, "no hold must be released", report.Released, 0)
+	holds, _ := reservationService.ListExternalHolds(ctx)
+	assert.That(t, "one hold must be stored", len(holds), 1)
+}
+
+func Test_Service_SyncFeed_With_Moved_Event_Should_Release_Old_Hold(t *testing.T) {
+	// Arrange
+	reservationService, fetcher, calendarService := createCalendarTestServices()
+	ctx := context.Background()
+	fetcher.events = []calendar.Event{{UID: "evt-1@airbnb.com", Start: daysFromToday(5), End: daysFromToday(8)}}
+	_, _ = calendarService.SyncFeed(ctx, testFeed)
+	fetcher.events = []calendar.Event{{UID: "evt-1@airbnb.com", Start: daysFromToday(6), End: daysFromToday(9)}}
+
+	// Act
+	report, err := calendarService.SyncFeed(ctx, testFeed)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one hold must be released", report.Released, 1)
+	assert.That(t, "one hold must be placed", report.Placed, 1)
+	available, _ := reservationService.ListReservationsByRoom(ctx, "room-101", reservation.NewDateRange(daysFromToday(5), daysFromToday(6)))
+	assert.That(t, "old first night must be free", len(available), 0)
+}
+
+func Test_Service_SyncFeed_With_Overlapping_Reservation_Should_Report_Conflict(t *testing.T) {
+	// Arrange
+	reservationService, fetcher, calendarService := createCalendarTestServices()
+	ctx := context.Background()
+	_, _ = reservationService.CreateReservation(ctx, "res-001", "john@example.com", "room-101",
+		reservation.NewDateRange(daysFromToday(5), daysFromToday(7)), shared.NewMoney(10000, "USD"),
+		[]reservation.GuestInfo{{Name: "John Doe", Email: "john@example.com"}})
+	fetcher.events = []calendar.Event{{UID: "evt-1@airbnb.com", Start: daysFromToday(6), End: daysFromToday(8)}}
+
+	// Act
+	report, err := calendarService.SyncFeed(ctx, testFeed)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "no hold must be placed", report.Placed, 0)
+	assert.That(t, "conflict must be reported", report.Conflicts, []string{"evt-1@airbnb.com"})
+}
+
+func Test_Service_SyncFeeds_When_Fetch_Fails_Should_Return_Error(t *testing.T) {
+	// Arrange
+	_, fetcher, calendarService := createCalendarTestServices()
+	fetcher.err = errors.New("connection refused")
+
+	// Act
+	reports, err := calendarService.SyncFeeds(context.Background(), []calendar.Feed{testFeed})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "no report must be returned", len(reports), 0)
+}
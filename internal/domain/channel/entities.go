@@ -0,0 +1,35 @@
+package channel
+
+import (
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// AvailabilityUpdate tells the channel manager whether a room can be sold
+// for a date range. It is pushed whenever a reservation blocks or frees a room.
+type AvailabilityUpdate struct {
+	RoomID        reservation.RoomID        `json:"room_id"`
+	CheckIn       time.Time                 `json:"check_in"`
+	CheckOut      time.Time                 `json:"check_out"`
+	Available     bool                      `json:"available"`
+	ReservationID reservation.ReservationID `json:"reservation_id"`
+}
+
+// Booking is a reservation sold by an online travel agency (OTA) and
+// delivered by the channel manager.
+type Booking struct {
+	Channel     string // Sales channel, e.g. "booking.com" or "expedia"
+	ExternalID  string // Reservation number at the channel
+	RoomID      reservation.RoomID
+	DateRange   reservation.DateRange
+	Guest       reservation.GuestInfo
+	TotalAmount shared.Money
+}
+
+// ReservationID returns the ID of the imported reservation.
+// It is derived from the channel and the external ID, so a booking is only imported once.
+func (b Booking) ReservationID() reservation.ReservationID {
+	return reservation.ReservationID(b.Channel + "-" + b.ExternalID)
+}
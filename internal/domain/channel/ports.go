@@ -0,0 +1,10 @@
+package channel
+
+import "context"
+
+// ChannelSync pushes inventory changes to a channel manager, which
+// distributes them to the connected OTAs (e.g. Booking.com, Expedia).
+type ChannelSync interface {
+	// PushAvailability opens or closes a room for the date range
+	PushAvailability(ctx context.Context, update AvailabilityUpdate) error
+}
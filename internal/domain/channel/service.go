@@ -0,0 +1,128 @@
+// Package channel connects the hotel to online travel agencies (OTAs) through
+// a channel manager. Availability changes from reservation events are pushed to
+// the channel manager, and bookings made on an OTA are imported as reservations.
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ErrInvalidBooking is returned for bookings without a channel or external ID.
+var ErrInvalidBooking = errors.New("invalid channel booking")
+
+// Service synchronizes availability and reservations with the channel manager.
+type Service struct {
+	reservationService *reservation.Service
+	sync               ChannelSync
+}
+
+// NewService creates a new channel service.
+func NewService(reservationSvc *reservation.Service, sync ChannelSync) *Service {
+	return &Service{
+		reservationService: reservationSvc,
+		sync:               sync,
+	}
+}
+
+// RegisterHandlers subscribes to the reservation events that change availability.
+func (s *Service) RegisterHandlers(ctx context.Context, dispatcher messaging.Dispatcher) error {
+	// A new reservation closes the room for its dates on all channels,
+	// including reservations imported from a channel.
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCreated, service.Wrap(s.handleReservationCreated)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCreated, err)
+	}
+
+	// A cancelled reservation opens the room again.
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCancelled, service.Wrap(s.handleReservationCancelled)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCancelled, err)
+	}
+
+	return nil
+}
+
+// ImportBooking stores an OTA booking as a confirmed reservation.
+// Bookings that were already imported are returned unchanged, so the
+// channel manager can safely deliver a booking more than once.
+func (s *Service) ImportBooking(ctx context.Context, booking Booking) (*reservation.Reservation, error) {
+	if strings.TrimSpace(booking.Channel) == "" || strings.TrimSpace(booking.ExternalID) == "" {
+		return nil, fmt.Errorf("%w: channel and external ID are required", ErrInvalidBooking)
+	}
+
+	id := booking.ReservationID()
+	if existing, err := s.reservationService.GetReservation(ctx, id); err == nil {
+		return existing, nil
+	}
+
+	res, err := s.reservationService.ImportReservation(
+		ctx,
+		id,
+		reservation.GuestID(booking.Guest.Email),
+		booking.RoomID,
+		booking.DateRange,
+		booking.TotalAmount,
+		[]reservation.GuestInfo{booking.Guest},
+		booking.Channel,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import booking: %w", err)
+	}
+	return res, nil
+}
+
+// handleReservationCreated closes the room of a new reservation on all channels.
+func (s *Service) handleReservationCreated(msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventCreated
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	update := AvailabilityUpdate{
+		RoomID:        evt.RoomID,
+		CheckIn:       evt.CheckIn,
+		CheckOut:      evt.CheckOut,
+		Available:     false,
+		ReservationID: evt.ReservationID,
+	}
+	if err := s.sync.PushAvailability(context.Background(), update); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to push availability: %w", err)
+	}
+
+	return messaging.MessageStateCompleted, nil
+}
+
+// handleReservationCancelled opens the room of a cancelled reservation on all channels.
+func (s *Service) handleReservationCancelled(msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventCancelled
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	ctx := context.Background()
+
+	// The cancelled event carries no room or dates, so they are read from the reservation
+	res, err := s.reservationService.GetReservation(ctx, evt.ReservationID)
+	if err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	update := AvailabilityUpdate{
+		RoomID:        res.RoomID,
+		CheckIn:       res.DateRange.CheckIn,
+		CheckOut:      res.DateRange.CheckOut,
+		Available:     true,
+		ReservationID: res.ID,
+	}
+	if err := s.sync.PushAvailability(ctx, update); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to push availability: %w", err)
+	}
+
+	return messaging.MessageStateCompleted, nil
+}
@@ -0,0 +1,257 @@
+package channel_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/channel"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockReservationRepository struct {
+	resource.Access[reservation.ReservationID, reservation.Reservation]
+}
+
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockAvailabilityChecker struct {
+	available bool
+}
+
+func (m *mockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	return m.available, nil
+}
+
+func (m *mockAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockEventPublisher struct {
+	published []event.Event
+}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error {
+	m.published = append(m.published, evt)
+	return nil
+}
+
+type mockChannelSync struct {
+	updates []channel.AvailabilityUpdate
+	err     error
+}
+
+func (m *mockChannelSync) PushAvailability(ctx context.Context, update channel.AvailabilityUpdate) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.updates = append(m.updates, update)
+	return nil
+}
+
+type mockDispatcher struct {
+	subscriptions map[string]service.Function[messaging.Message, messaging.MessageState]
+}
+
+func (m *mockDispatcher) Subscribe(ctx context.Context, topic string, handler service.Function[messaging.Message, messaging.MessageState]) error {
+	m.subscriptions[topic] = handler
+	return nil
+}
+
+func (m *mockDispatcher) Publish(ctx context.Context, msg messaging.Message) error {
+	return nil
+}
+
+func (m *mockDispatcher) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (m *mockDispatcher) trigger(topic string, evt any) (messaging.MessageState, error) {
+	data, _ := json.Marshal(evt)
+	return m.subscriptions[topic](context.Background(), messaging.NewMessage(topic, data))
+}
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type channelTestServices struct {
+	reservationService *reservation.Service
+	publisher          *mockEventPublisher
+	sync               *mockChannelSync
+	dispatcher         *mockDispatcher
+	channelService     *channel.Service
+}
+
+func createChannelTestServices(available bool) *channelTestServices {
+	repo := &mockReservationRepository{Access: resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()}
+	publisher := &mockEventPublisher{}
+	reservationService := reservation.NewService(repo, &mockAvailabilityChecker{available: available}, publisher)
+	sync := &mockChannelSync{}
+	dispatcher := &mockDispatcher{subscriptions: make(map[string]service.Function[messaging.Message, messaging.MessageState])}
+	return &channelTestServices{
+		reservationService: reservationService,
+		publisher:          publisher,
+		sync:               sync,
+		dispatcher:         dispatcher,
+		channelService:     channel.NewService(reservationService, sync),
+	}
+}
+
+func validBooking() channel.Booking {
+	checkIn := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
+	return channel.Booking{
+		Channel:     "booking.com",
+		ExternalID:  "4711",
+		RoomID:      "room-101",
+		DateRange:   reservation.NewDateRange(checkIn, checkIn.Add(72*time.Hour)),
+		Guest:       reservation.GuestInfo{Name: "John Doe", Email: "john@example.com"},
+		TotalAmount: shared.NewMoney(30000, "EUR"),
+	}
+}
+
+// ============================================================================
+// ImportBooking Tests
+// ============================================================================
+
+func Test_Service_ImportBooking_Should_Create_Confirmed_Reservation(t *testing.T) {
+	// Arrange
+	svc := createChannelTestServices(true)
+
+	// Act
+	res, err := svc.channelService.ImportBooking(context.Background(), validBooking())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "reservation ID must be derived from the booking", res.ID, reservation.ReservationID("booking.com-4711"))
+	assert.That(t, "reservation must be confirmed", res.Status, reservation.StatusConfirmed)
+	assert.That(t, "reservation channel must match", res.Channel, "booking.com")
+	assert.That(t, "guest ID must be the guest's email", res.GuestID, reservation.GuestID("john@example.com"))
+}
+
+func Test_Service_ImportBooking_Twice_Should_Return_Existing_Reservation(t *testing.T) {
+	// Arrange
+	svc := createChannelTestServices(true)
+	ctx := context.Background()
+	_, _ = svc.channelService.ImportBooking(ctx, validBooking())
+
+	// Act
+	res, err := svc.channelService.ImportBooking(ctx, validBooking())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "reservation ID must match", res.ID, reservation.ReservationID("booking.com-4711"))
+	assert.That(t, "events must only be published once", len(svc.publisher.published), 2)
+}
+
+func Test_Service_ImportBooking_Without_External_ID_Should_Return_ErrInvalidBooking(t *testing.T) {
+	// Arrange
+	svc := createChannelTestServices(true)
+	booking := validBooking()
+	booking.ExternalID = ""
+
+	// Act
+	_, err := svc.channelService.ImportBooking(context.Background(), booking)
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidBooking", errors.Is(err, channel.ErrInvalidBooking), true)
+}
+
+func Test_Service_ImportBooking_When_Room_Unavailable_Should_Return_ErrRoomUnavailable(t *testing.T) {
+	// Arrange
+	svc := createChannelTestServices(false)
+
+	// Act
+	_, err := svc.channelService.ImportBooking(context.Background(), validBooking())
+
+	// Assert
+	assert.That(t, "error must be ErrRoomUnavailable", errors.Is(err, reservation.ErrRoomUnavailable), true)
+}
+
+// ============================================================================
+// Event Handler Tests
+// ============================================================================
+
+func Test_Service_RegisterHandlers_Should_Subscribe_To_Created_And_Cancelled(t *testing.T) {
+	// Arrange
+	svc := createChannelTestServices(true)
+
+	// Act
+	err := svc.channelService.RegisterHandlers(context.Background(), svc.dispatcher)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "two topics must be subscribed", len(svc.dispatcher.subscriptions), 2)
+}
+
+func Test_Service_On_Reservation_Created_Should_Close_Room(t *testing.T) {
+	// Arrange
+	svc := createChannelTestServices(true)
+	_ = svc.channelService.RegisterHandlers(context.Background(), svc.dispatcher)
+	booking := validBooking()
+	evt := reservation.NewEventCreated().
+		WithReservationID("res-001").
+		WithRoomID(booking.RoomID).
+		WithCheckIn(booking.DateRange.CheckIn).
+		WithCheckOut(booking.DateRange.CheckOut)
+
+	// Act
+	state, err := svc.dispatcher.trigger(reservation.EventTopicCreated, evt)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	assert.That(t, "one update must be pushed", len(svc.sync.updates), 1)
+	assert.That(t, "room must be closed", svc.sync.updates[0].Available, false)
+	assert.That(t, "room ID must match", svc.sync.updates[0].RoomID, booking.RoomID)
+}
+
+func Test_Service_On_Reservation_Cancelled_Should_Open_Room(t *testing.T) {
+	// Arrange
+	svc := createChannelTestServices(true)
+	ctx := context.Background()
+	_ = svc.channelService.RegisterHandlers(ctx, svc.dispatcher)
+	booking := validBooking()
+	_, _ = svc.reservationService.CreateReservation(ctx, "res-001", "john@example.com", booking.RoomID,
+		booking.DateRange, booking.TotalAmount, []reservation.GuestInfo{booking.Guest})
+	evt := reservation.NewEventCancelled().WithReservationID("res-001")
+
+	// Act
+	state, err := svc.dispatcher.trigger(reservation.EventTopicCancelled, evt)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	assert.That(t, "one update must be pushed", len(svc.sync.updates), 1)
+	assert.That(t, "room must be opened", svc.sync.updates[0].Available, true)
+	assert.That(t, "check-in must be read from the reservation", svc.sync.updates[0].CheckIn.Equal(booking.DateRange.CheckIn), true)
+}
+
+func Test_Service_On_Reservation_Created_When_Push_Fails_Should_Return_Failed(t *testing.T) {
+	// Arrange
+	svc := createChannelTestServices(true)
+	svc.sync.err = errors.New("channel manager unavailable")
+	_ = svc.channelService.RegisterHandlers(context.Background(), svc.dispatcher)
+	evt := reservation.NewEventCreated().WithReservationID("res-001").WithRoomID("room-101")
+
+	// Act
+	state, err := svc.dispatcher.trigger(reservation.EventTopicCreated, evt)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "state must be failed", state, messaging.MessageStateFailed)
+}
@@ -0,0 +1,114 @@
+package housekeeping
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Housekeeping errors.
+var (
+	ErrTaskNotFound          = errors.New("housekeeping task not found")
+	ErrAssigneeRequired      = errors.New("assignee is required")
+	ErrInvalidTaskTransition = errors.New("invalid housekeeping task transition")
+	ErrInvalidTaskStatus     = errors.New("invalid task status, expected open, assigned or done")
+)
+
+// TaskID is a strongly-typed identifier for housekeeping tasks.
+type TaskID string
+
+// TaskType is the kind of cleaning a room needs.
+type TaskType string
+
+const (
+	// TaskStayoverClean tidies an occupied room during a stay.
+	TaskStayoverClean TaskType = "stayover_clean"
+	// TaskDepartureClean prepares a room for the next guest after check-out.
+	TaskDepartureClean TaskType = "departure_clean"
+)
+
+// TaskStatus is the progress of a housekeeping task.
+type TaskStatus string
+
+const (
+	TaskOpen     TaskStatus = "open"
+	TaskAssigned TaskStatus = "assigned"
+	TaskDone     TaskStatus = "done"
+)
+
+// ParseTaskStatus parses a task status; the empty string matches every status.
+func ParseTaskStatus(s string) (TaskStatus, error) {
+	switch status := TaskStatus(s); status {
+	case "", TaskOpen, TaskAssigned, TaskDone:
+		return status, nil
+	default:
+		return "", ErrInvalidTaskStatus
+	}
+}
+
+// taskStates holds the status transitions of housekeeping tasks.
+// An assigned task can be handed over to another attendant.
+var taskStates = shared.NewStateMachine[TaskStatus](ErrInvalidTaskTransition).
+	Allow(TaskOpen, TaskAssigned, TaskDone).
+	Allow(TaskAssigned, TaskAssigned, TaskDone)
+
+// Task is a cleaning job for a room, generated from the reservation events.
+type Task struct {
+	ID            TaskID                    `json:"id"`
+	ReservationID reservation.ReservationID `json:"reservation_id"`
+	RoomID        reservation.RoomID        `json:"room_id"`
+	Type          TaskType                  `json:"type"`
+	DueAt         time.Time                 `json:"due_at"`
+	Status        TaskStatus                `json:"status"`
+	Assignee      string                    `json:"assignee,omitempty"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	CompletedAt   time.Time                 `json:"completed_at"`
+}
+
+// NewTaskID returns the ID of a task, which is the same for every delivery of an event,
+// so a redelivered event does not generate the task twice.
+func NewTaskID(reservationID reservation.ReservationID, taskType TaskType, due time.Time) TaskID {
+	return TaskID(string(reservationID) + ":" + string(taskType) + ":" + due.Format(time.DateOnly))
+}
+
+// NewTask creates an open task of a reservation's room.
+func NewTask(res *reservation.Reservation, taskType TaskType, due time.Time) Task {
+	return Task{
+		ID:            NewTaskID(res.ID, taskType, due),
+		ReservationID: res.ID,
+		RoomID:        res.RoomID,
+		Type:          taskType,
+		DueAt:         due,
+		Status:        TaskOpen,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// Assign hands the task to a room attendant.
+func (t *Task) Assign(assignee string) error {
+	assignee = strings.TrimSpace(assignee)
+	if assignee == "" {
+		return ErrAssigneeRequired
+	}
+	if err := taskStates.Transition(t.Status, TaskAssigned); err != nil {
+		return err
+	}
+
+	t.Status = TaskAssigned
+	t.Assignee = assignee
+	return nil
+}
+
+// Complete marks the room as clean.
+func (t *Task) Complete() error {
+	if err := taskStates.Transition(t.Status, TaskDone); err != nil {
+		return err
+	}
+
+	t.Status = TaskDone
+	t.CompletedAt = time.Now()
+	return nil
+}
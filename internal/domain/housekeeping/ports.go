@@ -0,0 +1,6 @@
+package housekeeping
+
+import "github.com/andygeiss/cloud-native-utils/resource"
+
+// TaskRepository persists housekeeping tasks.
+type TaskRepository resource.Access[TaskID, Task]
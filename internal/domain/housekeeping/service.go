@@ -0,0 +1,192 @@
+// Package housekeeping generates cleaning tasks from the reservation events and
+// tracks their assignment and completion. It is a downstream consumer of the
+// event stream: check-ins schedule stayover cleans, check-outs a departure clean.
+package housekeeping
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// stayoverDueHour is the hour of the day by which an occupied room is tidied.
+const stayoverDueHour = 12
+
+// Service generates and manages housekeeping tasks.
+type Service struct {
+	reservationService *reservation.Service
+	tasks              TaskRepository
+	turnaround         time.Duration
+}
+
+// NewService creates a new housekeeping service.
+func NewService(reservationSvc *reservation.Service, tasks TaskRepository) *Service {
+	return &Service{
+		reservationService: reservationSvc,
+		tasks:              tasks,
+		turnaround:         3 * time.Hour,
+	}
+}
+
+// WithTurnaround sets how soon after check-out a room must be clean again (default 3h).
+func (s *Service) WithTurnaround(turnaround time.Duration) *Service {
+	s.turnaround = turnaround
+	return s
+}
+
+// RegisterHandlers subscribes to the reservation events that generate tasks.
+func (s *Service) RegisterHandlers(ctx context.Context, dispatcher messaging.Dispatcher) error {
+	// A check-in schedules a stayover clean for every day the guest wakes up in the room.
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicActivated, service.Wrap(s.handleReservationActivated)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicActivated, err)
+	}
+
+	// A check-out schedules the departure clean for the next guest.
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCompleted, service.Wrap(s.handleReservationCompleted)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCompleted, err)
+	}
+
+	return nil
+}
+
+// ListTasks returns the tasks with the given status ordered by due time.
+// The empty status returns all tasks.
+func (s *Service) ListTasks(ctx context.Context, status TaskStatus) ([]Task, error) {
+	tasks, err := s.tasks.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tasks: %w", err)
+	}
+
+	result := []Task{}
+	for _, task := range tasks {
+		if status == "" || task.Status == status {
+			result = append(result, task)
+		}
+	}
+	slices.SortFunc(result, func(a, b Task) int {
+		if c := a.DueAt.Compare(b.DueAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return result, nil
+}
+
+// AssignTask hands a task to a room attendant.
+func (s *Service) AssignTask(ctx context.Context, id TaskID, assignee string) (*Task, error) {
+	return s.updateTask(ctx, id, func(task *Task) error {
+		return task.Assign(assignee)
+	})
+}
+
+// CompleteTask marks the room of a task as clean.
+func (s *Service) CompleteTask(ctx context.Context, id TaskID) (*Task, error) {
+	return s.updateTask(ctx, id, (*Task).Complete)
+}
+
+// BackfillReservation creates the open tasks of a stored reservation, e.g. when
+// housekeeping is introduced while guests are in house: an active reservation
+// gets its upcoming stayover cleans. Departure cleans of past check-outs are not
+// created, because the rooms have been cleaned since.
+func (s *Service) BackfillReservation(ctx context.Context, res *reservation.Reservation) error {
+	if res.Status != reservation.StatusActive {
+		return nil
+	}
+	return s.scheduleStayovers(ctx, res, time.Now())
+}
+
+// updateTask reads a task, applies the change and persists it.
+func (s *Service) updateTask(ctx context.Context, id TaskID, change func(*Task) error) (*Task, error) {
+	task, err := s.tasks.Read(ctx, id)
+	if err != nil {
+		if err.Error() == resource.ErrorResourceNotFound {
+			return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to read task: %w", err)
+	}
+
+	if err := change(task); err != nil {
+		return nil, err
+	}
+
+	if err := s.tasks.Update(ctx, id, *task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+	return task, nil
+}
+
+// handleReservationActivated schedules the stayover cleans of a checked-in reservation.
+func (s *Service) handleReservationActivated(msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventActivated
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	ctx := context.Background()
+
+	// The activated event carries no room or dates, so they are read from the reservation
+	res, err := s.reservationService.GetReservation(ctx, evt.ReservationID)
+	if err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	if err := s.scheduleStayovers(ctx, res, time.Time{}); err != nil {
+		return messaging.MessageStateFailed, err
+	}
+
+	return messaging.MessageStateCompleted, nil
+}
+
+// handleReservationCompleted schedules the departure clean of a checked-out reservation.
+func (s *Service) handleReservationCompleted(msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventCompleted
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	ctx := context.Background()
+	res, err := s.reservationService.GetReservation(ctx, evt.ReservationID)
+	if err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	if err := s.createTask(ctx, NewTask(res, TaskDepartureClean, time.Now().Add(s.turnaround))); err != nil {
+		return messaging.MessageStateFailed, err
+	}
+
+	return messaging.MessageStateCompleted, nil
+}
+
+// scheduleStayovers creates the stayover cleans of a reservation that are due after the given time.
+func (s *Service) scheduleStayovers(ctx context.Context, res *reservation.Reservation, after time.Time) error {
+	// One clean per night after the first; the last morning is the departure clean
+	var errs []error
+	for night := 1; night < res.Nights(); night++ {
+		day := res.DateRange.CheckIn.AddDate(0, 0, night)
+		due := time.Date(day.Year(), day.Month(), day.Day(), stayoverDueHour, 0, 0, 0, day.Location())
+		if !due.After(after) {
+			continue
+		}
+		errs = append(errs, s.createTask(ctx, NewTask(res, TaskStayoverClean, due)))
+	}
+	return errors.Join(errs...)
+}
+
+// createTask stores a new task. A task that already exists is kept unchanged,
+// so a redelivered event does not reopen a task that is in progress.
+func (s *Service) createTask(ctx context.Context, task Task) error {
+	err := s.tasks.Create(ctx, task.ID, task)
+	if err != nil && err.Error() != resource.ErrorResourceAlreadyExists {
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package housekeeping_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/housekeeping"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockReservationRepository struct {
+	resource.Access[reservation.ReservationID, reservation.Reservation]
+}
+
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockAvailabilityChecker struct{}
+
+func (m *mockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	return true, nil
+}
+
+func (m *mockAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockEventPublisher struct{}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error {
+	return nil
+}
+
+type mockDispatcher struct {
+	subscriptions map[string]service.Function[messaging.Message, messaging.MessageState]
+}
+
+func (m *mockDispatcher) Subscribe(ctx context.Context, topic string, handler service.Function[messaging.Message, messaging.MessageState]) error {
+	m.subscriptions[topic] = handler
+	return nil
+}
+
+func (m *mockDispatcher) Publish(ctx context.Context, msg messaging.Message) error {
+	return nil
+}
+
+func (m *mockDispatcher) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (m *mockDispatcher) trigger(topic string, evt any) (messaging.MessageState, error) {
+	data, _ := json.Marshal(evt)
+	return m.subscriptions[topic](context.Background(), messaging.NewMessage(topic, data))
+}
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type housekeepingTestServices struct {
+	reservations        *mockReservationRepository
+	dispatcher          *mockDispatcher
+	housekeepingService *housekeeping.Service
+}
+
+func createHousekeepingTestServices(t *testing.T) *housekeepingTestServices {
+	t.Helper()
+	repo := &mockReservationRepository{Access: resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()}
+	reservationService := reservation.NewService(repo, &mockAvailabilityChecker{}, &mockEventPublisher{})
+	housekeepingService := housekeeping.NewService(reservationService,
+		resource.NewInMemoryAccess[housekeeping.TaskID, housekeeping.Task]())
+	dispatcher := &mockDispatcher{subscriptions: make(map[string]service.Function[messaging.Message, messaging.MessageState])}
+	if err := housekeepingService.RegisterHandlers(context.Background(), dispatcher); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	return &housekeepingTestServices{
+		reservations:        repo,
+		dispatcher:          dispatcher,
+		housekeepingService: housekeepingService,
+	}
+}
+
+// storeStay stores a reservation of room-101 for the given number of nights.
+func storeStay(t *testing.T, svc *housekeepingTestServices, nights int) *reservation.Reservation {
+	t.Helper()
+	checkIn := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
+	res, err := reservation.NewReservation("res-001", "guest-001", "room-101",
+		reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, nights)),
+		shared.NewMoney(30000, "USD"),
+		[]reservation.GuestInfo{{Name: "John Doe", Email: "john@example.com"}},
+	)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	_ = svc.reservations.Create(context.Background(), res.ID, *res)
+	return res
+}
+
+// ============================================================================
+// Event Handler Tests
+// ============================================================================
+
+func Test_Service_On_Reservation_Activated_Should_Schedule_Stayover_Cleans(t *testing.T) {
+	// Arrange
+	svc := createHousekeepingTestServices(t)
+	res := storeStay(t, svc, 3)
+
+	// Act
+	state, err := svc.dispatcher.trigger(reservation.EventTopicActivated, reservation.NewEventActivated().WithReservationID(res.ID))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	tasks, _ := svc.housekeepingService.ListTasks(context.Background(), housekeeping.TaskOpen)
+	assert.That(t, "two stayover cleans must be scheduled", len(tasks), 2)
+	assert.That(t, "task must be a stayover clean", tasks[0].Type, housekeeping.TaskStayoverClean)
+	assert.That(t, "task must concern the room", tasks[0].RoomID, reservation.RoomID("room-101"))
+	assert.That(t, "first clean must be due on the second day", tasks[0].DueAt.Day(), res.DateRange.CheckIn.AddDate(0, 0, 1).Day())
+	assert.That(t, "first clean must be due by noon", tasks[0].DueAt.Hour(), 12)
+}
+
+func Test_Service_On_Reservation_Completed_Should_Schedule_Departure_Clean_Once(t *testing.T) {
+	// Arrange
+	svc := createHousekeepingTestServices(t)
+	res := storeStay(t, svc, 1)
+	evt := reservation.NewEventCompleted().WithReservationID(res.ID)
+
+	// Act
+	_, _ = svc.dispatcher.trigger(reservation.EventTopicCompleted, evt)
+	state, err := svc.dispatcher.trigger(reservation.EventTopicCompleted, evt)
+
+	// Assert
+	assert.That(t, "redelivery error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	tasks, _ := svc.housekeepingService.ListTasks(context.Background(), "")
+	assert.That(t, "one departure clean must be scheduled", len(tasks), 1)
+	assert.That(t, "task must be a departure clean", tasks[0].Type, housekeeping.TaskDepartureClean)
+	assert.That(t, "task must be due after the turnaround", tasks[0].DueAt.After(time.Now().Add(2*time.Hour)), true)
+}
+
+func Test_Service_On_Reservation_Activated_With_Unknown_Reservation_Should_Return_Failed(t *testing.T) {
+	// Arrange
+	svc := createHousekeepingTestServices(t)
+
+	// Act
+	state, err := svc.dispatcher.trigger(reservation.EventTopicActivated, reservation.NewEventActivated().WithReservationID("res-unknown"))
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "state must be failed", state, messaging.MessageStateFailed)
+}
+
+// ============================================================================
+// Backfill Tests
+// ============================================================================
+
+func Test_Service_BackfillReservation_With_Active_Stay_Should_Schedule_Upcoming_Stayover_Cleans(t *testing.T) {
+	// Arrange
+	svc := createHousekeepingTestServices(t)
+	res := storeStay(t, svc, 5)
+	checkIn := time.Now().Truncate(24*time.Hour).AddDate(0, 0, -2)
+	res.DateRange = reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 5))
+	res.Status = reservation.StatusActive
+	now := time.Now()
+
+	// Act
+	err := svc.housekeepingService.BackfillReservation(context.Background(), res)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	tasks, _ := svc.housekeepingService.ListTasks(context.Background(), housekeeping.TaskOpen)
+	assert.That(t, "the upcoming stayover cleans must be scheduled", len(tasks) >= 2 && len(tasks) < 4, true)
+	for _, task := range tasks {
+		assert.That(t, "past stayover cleans must not be scheduled", task.DueAt.After(now), true)
+	}
+}
+
+func Test_Service_BackfillReservation_With_Confirmed_Stay_Should_Schedule_Nothing(t *testing.T) {
+	// Arrange
+	svc := createHousekeepingTestServices(t)
+	res := storeStay(t, svc, 3)
+
+	// Act
+	err := svc.housekeepingService.BackfillReservation(context.Background(), res)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	tasks, _ := svc.housekeepingService.ListTasks(context.Background(), "")
+	assert.That(t, "no task must be scheduled", len(tasks), 0)
+}
+
+// ============================================================================
+// Assignment and Completion Tests
+// ============================================================================
+
+func Test_Service_AssignTask_And_CompleteTask_Should_Track_Progress(t *testing.T) {
+	// Arrange
+	svc := createHousekeepingTestServices(t)
+	res := storeStay(t, svc, 1)
+	_, _ = svc.dispatcher.trigger(reservation.EventTopicCompleted, reservation.NewEventCompleted().WithReservationID(res.ID))
+	tasks, _ := svc.housekeepingService.ListTasks(context.Background(), housekeeping.TaskOpen)
+	ctx := context.Background()
+
+	// Act
+	assigned, assignErr := svc.housekeepingService.AssignTask(ctx, tasks[0].ID, " maria ")
+	done, completeErr := svc.housekeepingService.CompleteTask(ctx, tasks[0].ID)
+
+	// Assert
+	assert.That(t, "assign error must be nil", assignErr == nil, true)
+	assert.That(t, "assignee must be trimmed", assigned.Assignee, "maria")
+	assert.That(t, "complete error must be nil", completeErr == nil, true)
+	assert.That(t, "task must be done", done.Status, housekeeping.TaskDone)
+	open, _ := svc.housekeepingService.ListTasks(ctx, housekeeping.TaskOpen)
+	assert.That(t, "no task must be open", len(open), 0)
+}
+
+func Test_Service_AssignTask_When_Done_Should_Return_ErrInvalidTaskTransition(t *testing.T) {
+	// Arrange
+	svc := createHousekeepingTestServices(t)
+	res := storeStay(t, svc, 1)
+	_, _ = svc.dispatcher.trigger(reservation.EventTopicCompleted, reservation.NewEventCompleted().WithReservationID(res.ID))
+	tasks, _ := svc.housekeepingService.ListTasks(context.Background(), "")
+	_, _ = svc.housekeepingService.CompleteTask(context.Background(), tasks[0].ID)
+
+	// Act
+	_, err := svc.housekeepingService.AssignTask(context.Background(), tasks[0].ID, "maria")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidTaskTransition", errors.Is(err, housekeeping.ErrInvalidTaskTransition), true)
+}
+
+func Test_Service_AssignTask_Without_Assignee_Should_Return_ErrAssigneeRequired(t *testing.T) {
+	// Arrange
+	svc := createHousekeepingTestServices(t)
+	res := storeStay(t, svc, 1)
+	_, _ = svc.dispatcher.trigger(reservation.EventTopicCompleted, reservation.NewEventCompleted().WithReservationID(res.ID))
+	tasks, _ := svc.housekeepingService.ListTasks(context.Background(), "")
+
+	// Act
+	_, err := svc.housekeepingService.AssignTask(context.Background(), tasks[0].ID, " ")
+
+	// Assert
+	assert.That(t, "error must be ErrAssigneeRequired", errors.Is(err, housekeeping.ErrAssigneeRequired), true)
+}
+
+func Test_Service_CompleteTask_With_Unknown_Task_Should_Return_ErrTaskNotFound(t *testing.T) {
+	// Arrange
+	svc := createHousekeepingTestServices(t)
+
+	// Act
+	_, err := svc.housekeepingService.CompleteTask(context.Background(), "task-unknown")
+
+	// Assert
+	assert.That(t, "error must be ErrTaskNotFound", errors.Is(err, housekeeping.ErrTaskNotFound), true)
+}
@@ -0,0 +1,46 @@
+package invoicing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Invoice is the receipt of a paid reservation. It is a read model built from
+// the reservation and payment contexts and rendered into a document.
+type Invoice struct {
+	Number        string
+	IssuedAt      time.Time
+	ReservationID reservation.ReservationID
+	GuestName     string
+	GuestEmail    reservation.Email
+	RoomID        reservation.RoomID
+	DateRange     reservation.DateRange
+	Lines         []InvoiceLine
+	Net           shared.Money // Total without tax
+	Tax           shared.Money // Tax included in the total
+	TaxRate       int          // Tax rate in basis points (e.g. 1900 for 19%)
+	Total         shared.Money
+	Payment       payment.Payment
+}
+
+// InvoiceLine is one item of the price breakdown.
+type InvoiceLine struct {
+	Description string
+	Quantity    int
+	UnitPrice   shared.Money
+	Amount      shared.Money
+}
+
+// TaxRatePercent returns the tax rate for display, e.g. "19" or "7.5".
+func (i Invoice) TaxRatePercent() string {
+	if i.TaxRate%100 == 0 {
+		return strconv.Itoa(i.TaxRate / 100)
+	}
+	return strings.TrimRight(fmt.Sprintf("%d.%02d", i.TaxRate/100, i.TaxRate%100), "0")
+}
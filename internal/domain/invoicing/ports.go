@@ -0,0 +1,25 @@
+package invoicing
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrDocumentNotFound is returned by a DocumentRepository for unknown keys.
+var ErrDocumentNotFound = errors.New("document not found")
+
+// Renderer turns an invoice into a printable document (e.g. PDF).
+type Renderer interface {
+	// Render returns the document bytes of the invoice
+	Render(ctx context.Context, invoice *Invoice) ([]byte, error)
+	// ContentType returns the media type of rendered documents (e.g. "application/pdf")
+	ContentType() string
+}
+
+// DocumentRepository stores generated documents (e.g. on disk or in S3).
+type DocumentRepository interface {
+	// Save stores the document under the key, replacing an existing one
+	Save(ctx context.Context, key string, data []byte) error
+	// Load returns the document stored under the key, or ErrDocumentNotFound
+	Load(ctx context.Context, key string) ([]byte, error)
+}
@@ -0,0 +1,163 @@
+// Package invoicing creates receipts for paid reservations.
+// It combines the reservation and payment contexts into an Invoice, renders it
+// into a document and keeps the document for later downloads.
+package invoicing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ErrNotInvoiceable is returned for reservations without a captured or refunded payment.
+var ErrNotInvoiceable = errors.New("reservation has no captured payment")
+
+// Service builds, renders and stores invoices.
+type Service struct {
+	reservationService *reservation.Service
+	paymentService     *payment.Service
+	renderer           Renderer
+	documents          DocumentRepository
+	taxRate            int
+}
+
+// NewService creates a new invoicing service. Prices are taxed at 0% until WithTaxRate is set.
+func NewService(reservationSvc *reservation.Service, paymentSvc *payment.Service, renderer Renderer, documents DocumentRepository) *Service {
+	return &Service{
+		reservationService: reservationSvc,
+		paymentService:     paymentSvc,
+		renderer:           renderer,
+		documents:          documents,
+	}
+}
+
+// WithTaxRate sets the tax rate in basis points (e.g. 1900 for 19%).
+// Room prices include tax, so the tax is calculated out of the total.
+func (s *Service) WithTaxRate(basisPoints int) *Service {
+	s.taxRate = basisPoints
+	return s
+}
+
+// ContentType returns the media type of the invoice documents.
+func (s *Service) ContentType() string {
+	return s.renderer.ContentType()
+}
+
+// BuildInvoice assembles the invoice of a reservation from its latest payment.
+func (s *Service) BuildInvoice(ctx context.Context, reservationID shared.ReservationID) (*Invoice, error) {
+	res, err := s.reservationService.GetReservation(ctx, reservation.ToReservationID(reservationID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	pay, err := s.paymentService.GetPaymentByReservation(ctx, payment.ToReservationID(reservationID))
+	if errors.Is(err, payment.ErrPaymentNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrNotInvoiceable, reservationID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	if pay.Status != payment.StatusCaptured && pay.Status != payment.StatusRefunded {
+		return nil, fmt.Errorf("%w: payment is %s", ErrNotInvoiceable, pay.Status)
+	}
+
+	invoice := &Invoice{
+		Number:        "INV-" + string(res.ID),
+		IssuedAt:      time.Now(),
+		ReservationID: res.ID,
+		RoomID:        res.RoomID,
+		DateRange:     res.DateRange,
+		Lines:         roomLines(res),
+		TaxRate:       s.taxRate,
+		Total:         res.TotalAmount,
+		Payment:       *pay,
+	}
+	if len(res.Guests) > 0 {
+		invoice.GuestName = res.Guests[0].Name
+		invoice.GuestEmail = res.Guests[0].Email
+	}
+
+	// The gross amount is split into tax and net at the ratio of the tax rate to 100%,
+	// so the tax is rounded to the nearest minor unit and both add up to the total.
+	parts, err := res.TotalAmount.Allocate(max(s.taxRate, 0), 10000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split tax: %w", err)
+	}
+	invoice.Tax, invoice.Net = parts[0], parts[1]
+
+	return invoice, nil
+}
+
+// GenerateInvoice renders the invoice of a reservation and stores the document,
+// replacing an earlier version.
+func (s *Service) GenerateInvoice(ctx context.Context, reservationID shared.ReservationID) ([]byte, error) {
+	invoice, err := s.BuildInvoice(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.renderer.Render(ctx, invoice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render invoice: %w", err)
+	}
+
+	if err := s.documents.Save(ctx, documentKey(reservationID), data); err != nil {
+		return nil, fmt.Errorf("failed to store invoice: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetInvoice returns the stored invoice document of a reservation.
+// Invoices that were not generated yet are generated on first access.
+func (s *Service) GetInvoice(ctx context.Context, reservationID shared.ReservationID) ([]byte, error) {
+	data, err := s.documents.Load(ctx, documentKey(reservationID))
+	if errors.Is(err, ErrDocumentNotFound) {
+		return s.GenerateInvoice(ctx, reservationID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load invoice: %w", err)
+	}
+	return data, nil
+}
+
+// documentKey returns the storage key of a reservation's invoice.
+func documentKey(reservationID shared.ReservationID) string {
+	return "invoices/" + string(reservationID)
+}
+
+// roomLines returns the price breakdown of the stay, followed by the price lines of
+// its add-ons. The nightly rate is only itemized when the room amount divides evenly,
+// so the lines always add up to the total.
+func roomLines(res *reservation.Reservation) []InvoiceLine {
+	nights := res.Nights()
+	room := res.RoomAmount()
+	line := InvoiceLine{
+		Description: fmt.Sprintf("Room %s, %d night(s)", res.RoomID, nights),
+		Quantity:    1,
+		UnitPrice:   room,
+		Amount:      room,
+	}
+	if nights > 0 && room.Amount%int64(nights) == 0 {
+		line.Quantity = nights
+		line.UnitPrice = shared.NewMoney(room.Amount/int64(nights), room.Currency)
+	}
+
+	lines := []InvoiceLine{line}
+	for _, addOn := range res.AddOns {
+		for _, price := range addOn.Lines {
+			lines = append(lines, InvoiceLine{
+				Description: price.Description,
+				Quantity:    price.Quantity,
+				UnitPrice:   price.UnitPrice,
+				Amount:      price.Amount(),
+			})
+		}
+	}
+	return lines
+}
@@ -0,0 +1,308 @@
+package invoicing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoicing"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockReservationRepository struct {
+	resource.Access[reservation.ReservationID, reservation.Reservation]
+}
+
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockPaymentRepository struct {
+	resource.Access[payment.PaymentID, payment.Payment]
+}
+
+func (m *mockPaymentRepository) FindByReservationID(ctx context.Context, reservationID payment.ReservationID) ([]payment.Payment, error) {
+	all, _ := m.ReadAll(ctx)
+	var result []payment.Payment
+	for _, p := range all {
+		if p.ReservationID == reservationID {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+type mockAvailabilityChecker struct{}
+
+func (m *mockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	return true, nil
+}
+
+func (m *mockAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockPaymentGateway struct{}
+
+func (m *mockPaymentGateway) Authorize(ctx context.Context, p *payment.Payment) (string, error) {
+	return "tx-001", nil
+}
+
+func (m *mockPaymentGateway) Capture(ctx context.Context, transactionID string, amount shared.Money) error {
+	return nil
+}
+
+func (m *mockPaymentGateway) Refund(ctx context.Context, transactionID string, amount shared.Money) error {
+	return nil
+}
+
+func (m *mockPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	return nil
+}
+
+type mockEventPublisher struct{}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, e event.Event) error {
+	return nil
+}
+
+type mockRenderer struct {
+	rendered *invoicing.Invoice
+	calls    int
+	err      error
+}
+
+func (m *mockRenderer) Render(ctx context.Context, invoice *invoicing.Invoice) ([]byte, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.rendered = invoice
+	m.calls++
+	return []byte("invoice " + invoice.Number), nil
+}
+
+func (m *mockRenderer) ContentType() string {
+	return "application/pdf"
+}
+
+type mockDocumentRepository struct {
+	documents map[string][]byte
+}
+
+func (m *mockDocumentRepository) Save(ctx context.Context, key string, data []byte) error {
+	m.documents[key] = data
+	return nil
+}
+
+func (m *mockDocumentRepository) Load(ctx context.Context, key string) ([]byte, error) {
+	data, ok := m.documents[key]
+	if !ok {
+		return nil, invoicing.ErrDocumentNotFound
+	}
+	return data, nil
+}
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type testServices struct {
+	reservationService *reservation.Service
+	paymentService     *payment.Service
+	renderer           *mockRenderer
+	documents          *mockDocumentRepository
+	invoiceService     *invoicing.Service
+}
+
+func createTestServices() *testServices {
+	reservationRepo := &mockReservationRepository{resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()}
+	paymentRepo := &mockPaymentRepository{resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]()}
+	reservationService := reservation.NewService(reservationRepo, &mockAvailabilityChecker{}, &mockEventPublisher{})
+	paymentService := payment.NewService(paymentRepo, &mockPaymentGateway{}, &mockEventPublisher{})
+	renderer := &mockRenderer{}
+	documents := &mockDocumentRepository{documents: make(map[string][]byte)}
+	return &testServices{
+		reservationService: reservationService,
+		paymentService:     paymentService,
+		renderer:           renderer,
+		documents:          documents,
+		invoiceService:     invoicing.NewService(reservationService, paymentService, renderer, documents),
+	}
+}
+
+func createReservation(t *testing.T, svc *testServices, id shared.ReservationID, amount shared.Money) {
+	t.Helper()
+	checkIn := time.Now().Add(72 * time.Hour).Truncate(24 * time.Hour)
+	guests := []reservation.GuestInfo{{Name: "John Doe", Email: "john@example.com"}}
+	_, err := svc.reservationService.CreateReservation(context.Background(), reservation.ToReservationID(id), "john@example.com", "room-101", reservation.NewDateRange(checkIn, checkIn.Add(72*time.Hour)), amount, guests)
+	assert.That(t, "reservation must be created", err == nil, true)
+}
+
+func createCapturedPayment(t *testing.T, svc *testServices, id shared.ReservationID, amount shared.Money) {
+	t.Helper()
+	ctx := context.Background()
+	paymentID := payment.PaymentID("pay-" + id)
+	_, err := svc.paymentService.AuthorizePayment(ctx, paymentID, payment.ToReservationID(id), amount, "credit_card")
+	assert.That(t, "payment must be authorized", err == nil, true)
+	assert.That(t, "payment must be captured", svc.paymentService.CapturePayment(ctx, paymentID), nil)
+}
+
+// ============================================================================
+// BuildInvoice Tests
+// ============================================================================
+
+func Test_Service_BuildInvoice_Should_Itemize_Nights(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	amount := shared.NewMoney(30000, "USD")
+	createReservation(t, svc, "res-001", amount)
+	createCapturedPayment(t, svc, "res-001", amount)
+
+	// Act
+	invoice, err := svc.invoiceService.BuildInvoice(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "number must be derived from reservation", invoice.Number, "INV-res-001")
+	assert.That(t, "guest name must match", invoice.GuestName, "John Doe")
+	assert.That(t, "must have one line", len(invoice.Lines), 1)
+	assert.That(t, "quantity must be the nights", invoice.Lines[0].Quantity, 3)
+	assert.That(t, "unit price must be the nightly rate", invoice.Lines[0].UnitPrice, shared.NewMoney(10000, "USD"))
+	assert.That(t, "total must match", invoice.Total, amount)
+	assert.That(t, "payment must be captured", invoice.Payment.Status, payment.StatusCaptured)
+}
+
+func Test_Service_BuildInvoice_With_Uneven_Total_Should_Not_Itemize_Nights(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	amount := shared.NewMoney(10000, "USD")
+	createReservation(t, svc, "res-001", amount)
+	createCapturedPayment(t, svc, "res-001", amount)
+
+	// Act
+	invoice, _ := svc.invoiceService.BuildInvoice(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "quantity must be 1", invoice.Lines[0].Quantity, 1)
+	assert.That(t, "line must add up to total", invoice.Lines[0].Amount, amount)
+}
+
+func Test_Service_BuildInvoice_With_AddOn_Should_Itemize_Price_Lines(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	amount := shared.NewMoney(30000, "USD")
+	createReservation(t, svc, "res-001", amount)
+	createCapturedPayment(t, svc, "res-001", amount)
+	ctx := context.Background()
+	_ = svc.reservationService.ConfirmReservation(ctx, "res-001")
+	svc.reservationService.WithAddOnPricing(reservation.NewAddOnPricing().
+		WithPrice(reservation.AddOnLateCheckout, shared.NewMoney(2500, "USD")))
+	_, err := svc.reservationService.AddAddOn(ctx, "res-001", "addon-001", reservation.AddOnLateCheckout, time.Now())
+	assert.That(t, "add-on must be booked", err, nil)
+
+	// Act
+	invoice, _ := svc.invoiceService.BuildInvoice(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "must have a room and an add-on line", len(invoice.Lines), 2)
+	assert.That(t, "room must exclude the add-on", invoice.Lines[0].UnitPrice, shared.NewMoney(10000, "USD"))
+	assert.That(t, "add-on must be itemized", invoice.Lines[1].Amount, shared.NewMoney(2500, "USD"))
+	assert.That(t, "total must include the add-on", invoice.Total, shared.NewMoney(32500, "USD"))
+}
+
+func Test_Service_BuildInvoice_With_TaxRate_Should_Calculate_Included_Tax(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.invoiceService.WithTaxRate(1900)
+	amount := shared.NewMoney(11900, "EUR")
+	createReservation(t, svc, "res-001", amount)
+	createCapturedPayment(t, svc, "res-001", amount)
+
+	// Act
+	invoice, _ := svc.invoiceService.BuildInvoice(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "tax must be included in total", invoice.Tax, shared.NewMoney(1900, "EUR"))
+	assert.That(t, "net must be total minus tax", invoice.Net, shared.NewMoney(10000, "EUR"))
+	assert.That(t, "tax rate must be displayed in percent", invoice.TaxRatePercent(), "19")
+}
+
+func Test_Service_BuildInvoice_Without_Captured_Payment_Should_Return_ErrNotInvoiceable(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	amount := shared.NewMoney(30000, "USD")
+	createReservation(t, svc, "res-001", amount)
+	createReservation(t, svc, "res-002", amount)
+	_, _ = svc.paymentService.AuthorizePayment(context.Background(), "pay-res-002", "res-002", amount, "credit_card")
+
+	// Act
+	_, errUnpaid := svc.invoiceService.BuildInvoice(context.Background(), "res-001")
+	_, errAuthorized := svc.invoiceService.BuildInvoice(context.Background(), "res-002")
+
+	// Assert
+	assert.That(t, "unpaid reservation must not be invoiceable", errors.Is(errUnpaid, invoicing.ErrNotInvoiceable), true)
+	assert.That(t, "authorized payment must not be invoiceable", errors.Is(errAuthorized, invoicing.ErrNotInvoiceable), true)
+}
+
+// ============================================================================
+// GenerateInvoice / GetInvoice Tests
+// ============================================================================
+
+func Test_Service_GenerateInvoice_Should_Store_Document(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	amount := shared.NewMoney(30000, "USD")
+	createReservation(t, svc, "res-001", amount)
+	createCapturedPayment(t, svc, "res-001", amount)
+
+	// Act
+	data, err := svc.invoiceService.GenerateInvoice(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "document must be rendered", string(data), "invoice INV-res-001")
+	assert.That(t, "document must be stored", string(svc.documents.documents["invoices/res-001"]), "invoice INV-res-001")
+}
+
+func Test_Service_GenerateInvoice_With_Render_Error_Should_Not_Store_Document(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.renderer.err = errors.New("render failed")
+	amount := shared.NewMoney(30000, "USD")
+	createReservation(t, svc, "res-001", amount)
+	createCapturedPayment(t, svc, "res-001", amount)
+
+	// Act
+	_, err := svc.invoiceService.GenerateInvoice(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "no document must be stored", len(svc.documents.documents), 0)
+}
+
+func Test_Service_GetInvoice_Should_Return_Stored_Document(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	amount := shared.NewMoney(30000, "USD")
+	createReservation(t, svc, "res-001", amount)
+	createCapturedPayment(t, svc, "res-001", amount)
+	_, _ = svc.invoiceService.GetInvoice(context.Background(), "res-001")
+
+	// Act
+	data, err := svc.invoiceService.GetInvoice(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "document must match", string(data), "invoice INV-res-001")
+	assert.That(t, "document must be rendered only once", svc.renderer.calls, 1)
+}
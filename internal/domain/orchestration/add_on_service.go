@@ -0,0 +1,87 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// AddOnService books extras such as breakfast, parking or a late checkout for a stay.
+// The reservation total is recalculated with the add-on pricing. An add-on booked while
+// the balance of a payment schedule is open is paid with the balance. Once the booking
+// is paid, an add-on is charged right away to the guest's payment method, and refunded
+// if the guest removes it before check-in.
+type AddOnService struct {
+	reservationService *reservation.Service
+	paymentService     *payment.Service
+	ids                shared.IDGenerator
+}
+
+// NewAddOnService creates a new add-on service.
+func NewAddOnService(reservationSvc *reservation.Service, paymentSvc *payment.Service) *AddOnService {
+	return &AddOnService{
+		reservationService: reservationSvc,
+		paymentService:     paymentSvc,
+		ids:                shared.NewUUIDv7Generator(),
+	}
+}
+
+// WithIDGenerator sets the generator for new add-on and payment IDs (UUIDv7 by default).
+func (s *AddOnService) WithIDGenerator(ids shared.IDGenerator) *AddOnService {
+	s.ids = ids
+	return s
+}
+
+// AddAddOn books an add-on of the kind for a reservation and charges it if the booking is paid.
+// An add-on whose charge fails is removed again, so it is never booked without payment.
+func (s *AddOnService) AddAddOn(ctx context.Context, reservationID reservation.ReservationID, kind reservation.AddOnKind, now time.Time) (*reservation.Reservation, error) {
+	// 1. Book the add-on and recalculate the total
+	addOnID := reservation.AddOnID(s.ids.NewID())
+	res, err := s.reservationService.AddAddOn(ctx, reservationID, addOnID, kind, now)
+	if err != nil {
+		return nil, err
+	}
+	addOn, err := res.FindAddOn(addOnID)
+	if err != nil {
+		return nil, err
+	}
+	if addOn.InBalance {
+		return res, nil
+	}
+
+	// 2. Charge the add-on on its own
+	paymentID := payment.NewPaymentID(s.ids)
+	method := paymentMethodFor(ctx, s.paymentService, res.GuestID)
+	if _, chargeErr := s.paymentService.ChargeAddOn(ctx, paymentID, payment.ToReservationID(res.ID.Shared()), addOn.Total(), method); chargeErr != nil {
+		// Compensation: remove the add-on that could not be charged.
+		if _, err := s.reservationService.RemoveAddOn(context.WithoutCancel(ctx), res.ID, addOnID, now); err != nil {
+			return nil, fmt.Errorf("failed to charge add-on and compensation failed: %w (original error: %w)", err, chargeErr)
+		}
+		return nil, fmt.Errorf("failed to charge add-on: %w", chargeErr)
+	}
+
+	// 3. Record the payment, so the add-on can be refunded
+	if err := s.reservationService.MarkAddOnCharged(ctx, res.ID, addOnID, string(paymentID)); err != nil {
+		return nil, err
+	}
+	return s.reservationService.GetReservation(ctx, res.ID)
+}
+
+// RemoveAddOn cancels an add-on of a reservation and refunds it if it was charged on its own.
+func (s *AddOnService) RemoveAddOn(ctx context.Context, reservationID reservation.ReservationID, addOnID reservation.AddOnID, now time.Time) (*reservation.Reservation, error) {
+	removed, err := s.reservationService.RemoveAddOn(ctx, reservationID, addOnID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if removed.PaymentID != "" {
+		if err := s.paymentService.RefundPayment(ctx, payment.PaymentID(removed.PaymentID)); err != nil {
+			return nil, fmt.Errorf("add-on removed, but failed to refund payment %s: %w", removed.PaymentID, err)
+		}
+	}
+	return s.reservationService.GetReservation(ctx, reservationID)
+}
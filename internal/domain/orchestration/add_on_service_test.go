@@ -0,0 +1,102 @@
+package orchestration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// createAddOnService returns an add-on service that offers parking at 20.00 USD per night.
+func createAddOnService(svc *testServices) *orchestration.AddOnService {
+	svc.reservationService.WithAddOnPricing(reservation.NewAddOnPricing().
+		WithPrice(reservation.AddOnParking, shared.NewMoney(2000, "USD")))
+	return orchestration.NewAddOnService(svc.reservationService, svc.paymentService)
+}
+
+// ============================================================================
+// AddAddOn Tests
+// ============================================================================
+
+func Test_AddOnService_AddAddOn_After_Payment_Should_Charge_Add_On(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedArrivingReservation(svc, "res-001", 5)
+	addOns := createAddOnService(svc)
+
+	// Act
+	res, err := addOns.AddAddOn(context.Background(), "res-001", reservation.AddOnParking, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "total must include the add-on", res.TotalAmount, shared.NewMoney(24000, "USD"))
+	assert.That(t, "add-on must record its payment", res.AddOns[0].PaymentID != "", true)
+	charged := svc.paymentRepo.payments[payment.PaymentID(res.AddOns[0].PaymentID)]
+	assert.That(t, "add-on must be captured", charged.Status, payment.StatusCaptured)
+	assert.That(t, "add-on payment must be flagged", charged.Kind, payment.KindAddOn)
+	assert.That(t, "charge must match the add-on", charged.Amount, shared.NewMoney(4000, "USD"))
+}
+
+func Test_AddOnService_AddAddOn_With_Open_Balance_Should_Add_To_Balance(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedScheduledReservation(svc, "res-001", 5)
+	addOns := createAddOnService(svc)
+
+	// Act
+	res, err := addOns.AddAddOn(context.Background(), "res-001", reservation.AddOnParking, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "balance must include the add-on", res.Schedule.Balance, shared.NewMoney(74000, "USD"))
+	assert.That(t, "add-on must not be charged on its own", svc.paymentGateway.authorizeCalls, 0)
+}
+
+func Test_AddOnService_AddAddOn_With_Declined_Charge_Should_Remove_Add_On(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedArrivingReservation(svc, "res-001", 5)
+	svc.paymentGateway.authorizeErr = errors.New("card declined")
+	addOns := createAddOnService(svc)
+
+	// Act
+	_, err := addOns.AddAddOn(context.Background(), "res-001", reservation.AddOnParking, time.Now())
+
+	// Assert
+	res := svc.reservationRepo.reservations["res-001"]
+	assert.That(t, "failure must be reported", err != nil, true)
+	assert.That(t, "add-on must be removed", len(res.AddOns), 0)
+	assert.That(t, "total must be restored", res.TotalAmount, shared.NewMoney(20000, "USD"))
+}
+
+// ============================================================================
+// RemoveAddOn Tests
+// ============================================================================
+
+func Test_AddOnService_RemoveAddOn_Should_Refund_Charged_Add_On(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedArrivingReservation(svc, "res-001", 5)
+	addOns := createAddOnService(svc)
+	ctx := context.Background()
+	booked, _ := addOns.AddAddOn(ctx, "res-001", reservation.AddOnParking, time.Now())
+	addOn := booked.AddOns[0]
+
+	// Act
+	res, err := addOns.RemoveAddOn(ctx, "res-001", addOn.ID, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "total must be restored", res.TotalAmount, shared.NewMoney(20000, "USD"))
+	assert.That(t, "add-on must be refunded", svc.paymentRepo.payments[payment.PaymentID(addOn.PaymentID)].Status, payment.StatusRefunded)
+}
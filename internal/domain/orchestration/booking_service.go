@@ -5,8 +5,12 @@ package orchestration
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
@@ -21,12 +25,33 @@ import (
 // - Payment context subscribes and processes payment, publishing payment.authorized/failed
 // - Event handlers capture payment and confirm reservation
 // - Compensation is handled via event subscriptions on failure events
+//
+// In synchronous mode (shared.FlagSynchronousSaga), the reservation.created
+// handler runs the payment steps directly via ProcessPayment.
 type BookingService struct {
 	reservationService  *reservation.Service
 	paymentService      *payment.Service
 	notificationService NotificationService
+	invoices            InvoiceGenerator
+	compensationQueue   CompensationQueue
+	publisher           EventPublisher
+	ids                 shared.IDGenerator
+	flags               shared.FeatureFlags
+	sagas               *SagaTracker
+	sagaBudget          time.Duration
 }
 
+// Saga errors distinguish a step running out of its share of the saga budget
+// from the caller cancelling the whole saga.
+var (
+	ErrStepTimeout   = errors.New("saga step timed out")
+	ErrSagaCancelled = errors.New("saga cancelled")
+	ErrRefundFailed  = errors.New("booking cancelled, but the refund failed")
+)
+
+// completeBookingSteps is the number of budgeted steps in CompleteBooking.
+const completeBookingSteps = 4
+
 // NewBookingService creates a new orchestration service.
 func NewBookingService(
 	reservationSvc *reservation.Service,
@@ -37,9 +62,60 @@ func NewBookingService(
 		reservationService:  reservationSvc,
 		paymentService:      paymentSvc,
 		notificationService: notificationSvc,
+		ids:                 shared.NewUUIDv7Generator(),
+		flags:               shared.StaticFeatureFlags{},
+		sagas:               NewSagaTracker(resource.NewInMemoryAccess[shared.ReservationID, SagaState]()),
 	}
 }
 
+// WithIDGenerator sets the generator for new aggregate IDs (UUIDv7 by default).
+func (s *BookingService) WithIDGenerator(ids shared.IDGenerator) *BookingService {
+	s.ids = ids
+	return s
+}
+
+// WithFeatureFlags sets the provider used to toggle saga behavior per environment.
+func (s *BookingService) WithFeatureFlags(flags shared.FeatureFlags) *BookingService {
+	s.flags = flags
+	return s
+}
+
+// WithSagaTracker sets the tracker whose saga states record the mode of each saga.
+// Without it, the modes are kept in memory and are lost on restart.
+func (s *BookingService) WithSagaTracker(tracker *SagaTracker) *BookingService {
+	s.sagas = tracker
+	return s
+}
+
+// WithSagaBudget sets the total time CompleteBooking may spend on its steps.
+// Each step receives an equal share of the remaining budget, so one slow
+// call cannot consume the time of the steps after it. Zero disables budgeting.
+func (s *BookingService) WithSagaBudget(budget time.Duration) *BookingService {
+	s.sagaBudget = budget
+	return s
+}
+
+// WithCompensationQueue sets the queue where failed compensations are persisted
+// for automatic retry via RetryCompensations.
+func (s *BookingService) WithCompensationQueue(queue CompensationQueue) *BookingService {
+	s.compensationQueue = queue
+	return s
+}
+
+// WithInvoices sets the generator whose invoices are attached to payment receipts.
+// Without it, receipts are sent without an attachment.
+func (s *BookingService) WithInvoices(invoices InvoiceGenerator) *BookingService {
+	s.invoices = invoices
+	return s
+}
+
+// WithEventPublisher sets the publisher for orchestration events such as
+// booking.compensation_failed alerts.
+func (s *BookingService) WithEventPublisher(publisher EventPublisher) *BookingService {
+	s.publisher = publisher
+	return s
+}
+
 // InitiateBooking starts the booking saga by creating a reservation.
 // This publishes a reservation.created event that triggers payment processing.
 func (s *BookingService) InitiateBooking(
@@ -52,7 +128,7 @@ func (s *BookingService) InitiateBooking(
 	guests []reservation.GuestInfo,
 ) (*reservation.Reservation, error) {
 	// Create reservation (publishes reservation.created event)
-	res, err := s.reservationService.CreateReservation(ctx, reservationID, guestID, roomID, dateRange, amount, guests)
+	res, err := s.reservationService.CreateReservation(ctx, reservation.ToReservationID(reservationID), guestID, roomID, dateRange, amount, guests)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create reservation: %w", err)
 	}
@@ -76,61 +152,134 @@ func (s *BookingService) CompleteBooking(
 	guests []reservation.GuestInfo,
 	paymentMethod string,
 ) (*reservation.Reservation, error) {
+	deadline := time.Now().Add(s.sagaBudget)
+
 	// Step 1: Create reservation
-	res, err := s.createReservationStep(ctx, reservationID, guestID, roomID, dateRange, amount, guests)
+	res, err := s.createReservationStep(ctx, deadline, reservationID, guestID, roomID, dateRange, amount, guests)
 	if err != nil {
 		return nil, err
 	}
 
-	// Step 2: Authorize payment
-	pay, err := s.authorizePaymentStep(ctx, paymentID, reservationID, amount, paymentMethod)
-	if err != nil {
+	// Steps 2-4: Authorize, capture and confirm the amount due at booking
+	if err := s.paymentSteps(ctx, deadline, "", paymentID, reservationID, res.AmountDueAtBooking(), paymentMethod); err != nil {
 		return nil, err
 	}
 
-	// Step 3: Capture payment
-	if err := s.capturePaymentStep(ctx, pay.ID, reservationID); err != nil {
+	// Step 5: Send notifications (best effort)
+	_ = s.notificationService.SendReservationConfirmation(ctx, res)
+	s.sendPaymentReceipt(ctx, reservationID)
+
+	return s.reservationService.GetReservation(ctx, reservation.ToReservationID(reservationID))
+}
+
+// ProcessPayment runs the payment steps of the saga synchronously for an existing
+// reservation: authorize, capture and confirm, with the same budget and compensation
+// as CompleteBooking. It is used in synchronous saga mode instead of event chaining.
+// The command ID identifies the triggering event, so a redelivered event neither
+// authorizes nor captures the payment twice.
+func (s *BookingService) ProcessPayment(
+	ctx context.Context,
+	commandID payment.CommandID,
+	paymentID payment.PaymentID,
+	reservationID shared.ReservationID,
+	amount shared.Money,
+	paymentMethod string,
+) (*reservation.Reservation, error) {
+	deadline := time.Now().Add(s.sagaBudget)
+
+	if err := s.paymentSteps(ctx, deadline, commandID, paymentID, reservationID, amount, paymentMethod); err != nil {
 		return nil, err
 	}
 
-	// Step 4: Confirm reservation
-	if err := s.confirmReservationStep(ctx, reservationID, pay.ID); err != nil {
-		return nil, err
+	res, err := s.reservationService.GetReservation(ctx, reservation.ToReservationID(reservationID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
 	}
 
-	// Step 5: Send notification (best effort)
+	// Send notifications (best effort)
 	_ = s.notificationService.SendReservationConfirmation(ctx, res)
+	s.sendPaymentReceipt(ctx, reservationID)
 
-	return s.reservationService.GetReservation(ctx, reservationID)
+	return res, nil
 }
 
 // CancelBookingWithRefund cancels a reservation and refunds the payment if applicable.
+// If the refund fails, the reservation stays cancelled, is flagged as requiring a
+// refund and the refund is queued for retry; the error wraps ErrRefundFailed.
 func (s *BookingService) CancelBookingWithRefund(
 	ctx context.Context,
 	reservationID shared.ReservationID,
 	reason string,
 ) error {
-	res, err := s.reservationService.GetReservation(ctx, reservationID)
+	res, err := s.reservationService.GetReservation(ctx, reservation.ToReservationID(reservationID))
 	if err != nil {
 		return fmt.Errorf("failed to get reservation: %w", err)
 	}
 
-	if err := s.reservationService.CancelReservation(ctx, reservationID, reason); err != nil {
+	if err := s.reservationService.CancelReservation(ctx, reservation.ToReservationID(reservationID), reason); err != nil {
 		return fmt.Errorf("failed to cancel reservation: %w", err)
 	}
 
+	refundErr := s.refundPaymentStep(ctx, reservationID, reason)
+
 	_ = s.notificationService.SendCancellationNotice(ctx, res, reason)
 
+	if refundErr != nil {
+		return fmt.Errorf("%w: %w", ErrRefundFailed, refundErr)
+	}
 	return nil
 }
 
+// OnRoomBlocked handles the reservation.room_blocked event. The hotel cancels the
+// pending and confirmed reservations of the blocked nights regardless of the
+// cancellation deadline, refunds their payments and notifies the guests.
+// Stays in progress and external holds are left to the staff.
+func (s *BookingService) OnRoomBlocked(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange, blockReason reservation.BlockReason) error {
+	reservations, err := s.reservationService.ListReservationsByRoom(ctx, roomID, dateRange)
+	if err != nil {
+		return fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	reason := fmt.Sprintf("room_blocked: %s", blockReason)
+	var errs []error
+	for _, res := range reservations {
+		if res.IsExternalHold() || (res.Status != reservation.StatusPending && res.Status != reservation.StatusConfirmed) {
+			continue
+		}
+		if err := s.displaceBooking(ctx, res, reason); err != nil {
+			errs = append(errs, fmt.Errorf("reservation %s: %w", res.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// displaceBooking cancels a reservation for a room block and refunds its payment.
+// A failed refund is queued for retry like in CancelBookingWithRefund.
+func (s *BookingService) displaceBooking(ctx context.Context, res *reservation.Reservation, reason string) error {
+	if err := s.reservationService.DisplaceReservation(ctx, res.ID, reason); err != nil {
+		return fmt.Errorf("failed to cancel reservation: %w", err)
+	}
+
+	refundErr := s.refundPaymentStep(ctx, res.ID.Shared(), reason)
+
+	_ = s.notificationService.SendCancellationNotice(ctx, res, reason)
+
+	return refundErr
+}
+
 // OnPaymentAuthorized handles the payment.authorized event.
 // It captures the payment and confirms the reservation.
 func (s *BookingService) OnPaymentAuthorized(ctx context.Context, paymentID payment.PaymentID, reservationID shared.ReservationID) error {
-	// Capture the payment
-	if err := s.paymentService.CapturePayment(ctx, paymentID); err != nil {
-		// Compensation: cancel the reservation
-		_ = s.reservationService.CancelReservation(ctx, reservationID, "payment_capture_failed")
+	// Capture the payment (a redelivered event does not capture twice)
+	if err := s.paymentService.CapturePaymentOnAuthorization(ctx, paymentID); err != nil {
+		// Compensation: release the authorization and cancel the reservation,
+		// unless payment.failed has cancelled it already
+		s.voidAuthorization(ctx, paymentID, reservationID)
+		if !s.reservationCancelled(ctx, reservationID) {
+			if cancelErr := s.reservationService.CancelReservation(ctx, reservation.ToReservationID(reservationID), "payment_capture_failed"); cancelErr != nil {
+				s.recordFailedCompensation(ctx, NewFailedCompensation(CompensationCancelReservation, reservationID, paymentID, "payment_capture_failed", cancelErr))
+			}
+		}
 		return fmt.Errorf("failed to capture payment: %w", err)
 	}
 
@@ -140,29 +289,356 @@ func (s *BookingService) OnPaymentAuthorized(ctx context.Context, paymentID paym
 }
 
 // OnPaymentCaptured handles the payment.captured event.
-// It confirms the reservation.
-func (s *BookingService) OnPaymentCaptured(ctx context.Context, reservationID shared.ReservationID) error {
-	if err := s.reservationService.ConfirmReservation(ctx, reservationID); err != nil {
+// It confirms the reservation, unless the captured amount differs from the amount due at booking.
+func (s *BookingService) OnPaymentCaptured(ctx context.Context, paymentID payment.PaymentID, reservationID shared.ReservationID, amount shared.Money) error {
+	if err := s.VerifyPaymentAmount(ctx, SagaStepCapture, paymentID, reservationID, amount); err != nil {
+		return err
+	}
+
+	if err := s.reservationService.ConfirmReservation(ctx, reservation.ToReservationID(reservationID)); err != nil {
 		return fmt.Errorf("failed to confirm reservation: %w", err)
 	}
 
-	res, err := s.reservationService.GetReservation(ctx, reservationID)
+	res, err := s.reservationService.GetReservation(ctx, reservation.ToReservationID(reservationID))
 	if err == nil {
 		_ = s.notificationService.SendReservationConfirmation(ctx, res)
 	}
+	s.sendPaymentReceipt(ctx, reservationID)
 
 	return nil
 }
 
+// VerifyPaymentAmount checks that the amount of a payment step matches the
+// amount due at booking of the reservation. A mismatch publishes a booking.payment_discrepancy alert
+// and returns reservation.ErrPaymentMismatch, so the step is refused.
+func (s *BookingService) VerifyPaymentAmount(
+	ctx context.Context,
+	step SagaStep,
+	paymentID payment.PaymentID,
+	reservationID shared.ReservationID,
+	amount shared.Money,
+) error {
+	res, err := s.reservationService.GetReservation(ctx, reservation.ToReservationID(reservationID))
+	if err != nil {
+		return fmt.Errorf("failed to verify payment amount: %w", err)
+	}
+
+	verifyErr := res.VerifyPayment(amount)
+	if verifyErr == nil {
+		return nil
+	}
+
+	if s.publisher != nil {
+		evt := NewEventPaymentDiscrepancy().
+			WithReservationID(reservationID).
+			WithPaymentID(paymentID).
+			WithStep(step).
+			WithExpected(res.AmountDueAtBooking()).
+			WithActual(amount)
+		_ = s.publisher.Publish(context.WithoutCancel(ctx), evt)
+	}
+	return fmt.Errorf("%s refused: %w", step, verifyErr)
+}
+
+// sendPaymentReceipt sends the receipt of the reservation's payment with the
+// invoice attached (best effort). A failed invoice does not hold back the receipt.
+func (s *BookingService) sendPaymentReceipt(ctx context.Context, reservationID shared.ReservationID) {
+	pay, err := s.paymentService.GetPaymentByReservation(ctx, payment.ToReservationID(reservationID))
+	if err != nil {
+		return
+	}
+
+	var attachments []Attachment
+	if s.invoices != nil {
+		if data, err := s.invoices.GenerateInvoice(ctx, reservationID); err == nil {
+			attachments = append(attachments, Attachment{
+				Filename:    "invoice-" + string(reservationID) + ".pdf",
+				ContentType: s.invoices.ContentType(),
+				Data:        data,
+			})
+		}
+	}
+
+	_ = s.notificationService.SendPaymentReceipt(ctx, pay, attachments...)
+}
+
 // OnPaymentFailed handles the payment.failed event.
 // It cancels the reservation as compensation.
 func (s *BookingService) OnPaymentFailed(ctx context.Context, reservationID shared.ReservationID, reason string) error {
-	return s.reservationService.CancelReservation(ctx, reservationID, reason)
+	return s.reservationService.CancelReservation(ctx, reservation.ToReservationID(reservationID), reason)
+}
+
+// ListFailedCompensations returns the queued failed compensations, oldest first.
+func (s *BookingService) ListFailedCompensations(ctx context.Context) ([]FailedCompensation, error) {
+	if s.compensationQueue == nil {
+		return []FailedCompensation{}, nil
+	}
+
+	pending, err := s.compensationQueue.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compensation queue: %w", err)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].FailedAt.Before(pending[j].FailedAt) })
+
+	return pending, nil
+}
+
+// RetryCompensations retries all queued failed compensations.
+// Resolved entries are removed from the queue; failing entries keep their
+// place with an increased attempt count. It returns the number of resolved entries.
+func (s *BookingService) RetryCompensations(ctx context.Context) (int, error) {
+	if s.compensationQueue == nil {
+		return 0, nil
+	}
+
+	pending, err := s.compensationQueue.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read compensation queue: %w", err)
+	}
+
+	resolved := 0
+	for _, comp := range pending {
+		if err := s.executeCompensation(ctx, comp); err != nil {
+			comp.Attempts++
+			comp.LastError = err.Error()
+			comp.UpdatedAt = time.Now()
+			if err := s.compensationQueue.Update(ctx, comp.ID, comp); err != nil {
+				return resolved, fmt.Errorf("failed to update compensation: %w", err)
+			}
+			continue
+		}
+
+		if err := s.compensationQueue.Delete(ctx, comp.ID); err != nil {
+			return resolved, fmt.Errorf("failed to delete compensation: %w", err)
+		}
+		resolved++
+	}
+
+	return resolved, nil
+}
+
+// executeCompensation runs a queued compensating action.
+// Actions that were already applied count as resolved.
+func (s *BookingService) executeCompensation(ctx context.Context, comp FailedCompensation) error {
+	switch comp.Action {
+	case CompensationCancelReservation:
+		err := s.reservationService.CancelReservation(ctx, reservation.ToReservationID(comp.ReservationID), comp.Reason)
+		if errors.Is(err, reservation.ErrAlreadyCancelled) {
+			return nil
+		}
+		return err
+	case CompensationRefundPayment:
+		pay, err := s.paymentService.GetPayment(ctx, comp.PaymentID)
+		if err != nil {
+			return err
+		}
+		if pay.Status != payment.StatusRefunded && !pay.ChargedBack() {
+			if err := s.paymentService.RefundPayment(ctx, comp.PaymentID); err != nil {
+				return err
+			}
+		}
+		return s.reservationService.ClearRefundRequired(ctx, reservation.ToReservationID(comp.ReservationID))
+	case CompensationVoidPayment:
+		return s.paymentService.VoidPayment(ctx, comp.PaymentID)
+	default:
+		return fmt.Errorf("unknown compensation action: %s", comp.Action)
+	}
+}
+
+// recordFailedCompensation queues a failed compensation for retry and raises an alert.
+// Both are best effort: the compensation error is already returned to the caller.
+func (s *BookingService) recordFailedCompensation(ctx context.Context, comp *FailedCompensation) {
+	ctx = context.WithoutCancel(ctx)
+
+	if s.compensationQueue != nil {
+		existing, err := s.compensationQueue.Read(ctx, comp.ID)
+		if err == nil {
+			existing.Attempts++
+			existing.LastError = comp.LastError
+			existing.UpdatedAt = comp.UpdatedAt
+			_ = s.compensationQueue.Update(ctx, comp.ID, *existing)
+		} else {
+			_ = s.compensationQueue.Create(ctx, comp.ID, *comp)
+		}
+	}
+
+	if s.publisher != nil {
+		evt := NewEventCompensationFailed().
+			WithCompensationID(comp.ID).
+			WithAction(comp.Action).
+			WithReservationID(comp.ReservationID).
+			WithPaymentID(comp.PaymentID).
+			WithErrorMsg(comp.LastError)
+		_ = s.publisher.Publish(ctx, evt)
+	}
+}
+
+// reservationCancelled reports whether the reservation is cancelled already. A reservation
+// that cannot be read is not, so the compensation still tries to cancel it.
+func (s *BookingService) reservationCancelled(ctx context.Context, reservationID shared.ReservationID) bool {
+	res, err := s.reservationService.GetReservation(ctx, reservation.ToReservationID(reservationID))
+	return err == nil && res.Status == reservation.StatusCancelled
+}
+
+// voidAuthorization releases the authorization of a payment whose capture failed,
+// so the funds are not held until it expires. A failed void is queued for retry.
+func (s *BookingService) voidAuthorization(ctx context.Context, paymentID payment.PaymentID, reservationID shared.ReservationID) {
+	if err := s.paymentService.VoidPayment(context.WithoutCancel(ctx), paymentID); err != nil {
+		s.recordFailedCompensation(ctx, NewFailedCompensation(CompensationVoidPayment, reservationID, paymentID, "payment_capture_failed", err))
+	}
+}
+
+// refundPaymentStep refunds the captured payments of a cancelled reservation:
+// the booking payment, the add-ons charged on their own and the balance of a
+// payment schedule, if it was paid. Security deposits are settled when they are
+// released. Reservations without a captured payment have nothing to refund.
+func (s *BookingService) refundPaymentStep(ctx context.Context, reservationID shared.ReservationID, reason string) error {
+	payments, err := s.paymentService.ListPaymentsByReservation(ctx, payment.ToReservationID(reservationID))
+	if err != nil {
+		return fmt.Errorf("failed to find payments: %w", err)
+	}
+
+	for i := range payments {
+		switch payments[i].Kind {
+		case payment.KindDeposit:
+			// Settled by ReleaseDeposit, not refunded.
+		default:
+			if err := s.refundPayment(ctx, reservationID, &payments[i], reason); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// refundPayment refunds a payment of a cancelled reservation, if it was captured.
+// A charged back payment was returned to the guest by the card issuer already.
+// The refund of a disputed payment fails and is queued until the dispute is resolved.
+func (s *BookingService) refundPayment(ctx context.Context, reservationID shared.ReservationID, pay *payment.Payment, reason string) error {
+	if pay.Status != payment.StatusCaptured || pay.ChargedBack() {
+		return nil
+	}
+
+	if err := s.paymentService.RefundPayment(ctx, pay.ID); err != nil {
+		// Compensation: flag the reservation so the missing refund stays visible.
+		s.recordFailedCompensation(ctx, NewFailedCompensation(CompensationRefundPayment, reservationID, pay.ID, reason, err))
+		if flagErr := s.reservationService.MarkRefundRequired(context.WithoutCancel(ctx), reservation.ToReservationID(reservationID)); flagErr != nil {
+			return fmt.Errorf("failed to refund payment and compensation failed: %w (original error: %w)", flagErr, err)
+		}
+		return fmt.Errorf("failed to refund payment: %w", err)
+	}
+
+	if s.publisher != nil {
+		evt := NewEventRefunded().
+			WithReservationID(reservationID).
+			WithPaymentID(pay.ID).
+			WithAmount(pay.Amount).
+			WithReason(reason)
+		if err := s.publisher.Publish(ctx, evt); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// defaultPaymentMethod is charged for guests without a stored payment method.
+const defaultPaymentMethod = "default"
+
+// bookingPaymentID returns the ID of the payment charged at booking. A payment that
+// was created already keeps its ID, so a redelivered event works on the same payment.
+func (s *BookingService) bookingPaymentID(ctx context.Context, reservationID shared.ReservationID) payment.PaymentID {
+	if pay, err := s.paymentService.GetPaymentByReservation(ctx, payment.ToReservationID(reservationID)); err == nil {
+		return pay.ID
+	}
+	return payment.NewPaymentID(s.ids)
+}
+
+// paymentMethodFor returns the stored payment method charged for a guest, so a
+// returning guest books with one click, or the default method if there is none.
+func paymentMethodFor(ctx context.Context, paymentService *payment.Service, guestID reservation.GuestID) string {
+	method, err := paymentService.PreferredPaymentMethod(ctx, payment.GuestID(guestID))
+	if err != nil {
+		return defaultPaymentMethod
+	}
+	return string(method.ID)
+}
+
+// startSaga picks the mode of the reservation's saga from the feature flag and
+// records it on the saga state. It reports whether the payment steps run
+// synchronously instead of being chained through events.
+func (s *BookingService) startSaga(ctx context.Context, reservationID shared.ReservationID) (bool, error) {
+	mode := SagaModeEventDriven
+	if s.flags.IsEnabled(ctx, shared.FlagSynchronousSaga, false) {
+		mode = SagaModeSynchronous
+	}
+	started, err := s.sagas.StartSaga(ctx, reservationID, mode)
+	if err != nil {
+		return false, err
+	}
+	return started == SagaModeSynchronous, nil
+}
+
+// synchronousSaga reports whether the saga of the reservation runs its payment steps
+// synchronously. Sagas that started before their mode was recorded use the feature flag.
+func (s *BookingService) synchronousSaga(ctx context.Context, reservationID shared.ReservationID) bool {
+	if state, err := s.sagas.GetSagaState(ctx, reservationID); err == nil && state.Mode != "" {
+		return state.Mode == SagaModeSynchronous
+	}
+	return s.flags.IsEnabled(ctx, shared.FlagSynchronousSaga, false)
+}
+
+// paymentSteps runs the authorize, capture and confirm steps of the saga.
+func (s *BookingService) paymentSteps(
+	ctx context.Context,
+	deadline time.Time,
+	commandID payment.CommandID,
+	paymentID payment.PaymentID,
+	reservationID shared.ReservationID,
+	amount shared.Money,
+	paymentMethod string,
+) error {
+	pay, err := s.authorizePaymentStep(ctx, deadline, commandID, paymentID, reservationID, amount, paymentMethod)
+	if err != nil {
+		return err
+	}
+	if err := s.capturePaymentStep(ctx, deadline, pay.ID, reservationID); err != nil {
+		return err
+	}
+	return s.confirmReservationStep(ctx, deadline, reservationID, pay.ID, pay.Amount)
+}
+
+// runStep runs a saga step with its share of the remaining saga budget.
+// Step timeouts are reported as ErrStepTimeout and upstream cancellation as ErrSagaCancelled.
+func (s *BookingService) runStep(ctx context.Context, deadline time.Time, remainingSteps int, fn func(ctx context.Context) error) error {
+	stepCtx, cancel := s.stepContext(ctx, deadline, remainingSteps)
+	defer cancel()
+
+	err := fn(stepCtx)
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("%w: %w", ErrSagaCancelled, ctx.Err())
+	}
+	if errors.Is(stepCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrStepTimeout, err)
+	}
+	return err
+}
+
+// stepContext derives the context for a single saga step.
+func (s *BookingService) stepContext(ctx context.Context, deadline time.Time, remainingSteps int) (context.Context, context.CancelFunc) {
+	if s.sagaBudget <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Until(deadline)/time.Duration(remainingSteps))
 }
 
 // createReservationStep is a helper function to encapsulate.
 func (s *BookingService) createReservationStep(
 	ctx context.Context,
+	deadline time.Time,
 	reservationID shared.ReservationID,
 	guestID reservation.GuestID,
 	roomID reservation.RoomID,
@@ -170,7 +646,12 @@ func (s *BookingService) createReservationStep(
 	amount shared.Money,
 	guests []reservation.GuestInfo,
 ) (*reservation.Reservation, error) {
-	res, err := s.reservationService.CreateReservation(ctx, reservationID, guestID, roomID, dateRange, amount, guests)
+	var res *reservation.Reservation
+	err := s.runStep(ctx, deadline, completeBookingSteps, func(ctx context.Context) error {
+		var err error
+		res, err = s.reservationService.CreateReservation(ctx, reservation.ToReservationID(reservationID), guestID, roomID, dateRange, amount, guests)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("step 1 failed (create reservation): %w", err)
 	}
@@ -180,15 +661,25 @@ func (s *BookingService) createReservationStep(
 // authorizePaymentStep is a helper function to encapsulate.
 func (s *BookingService) authorizePaymentStep(
 	ctx context.Context,
+	deadline time.Time,
+	commandID payment.CommandID,
 	paymentID payment.PaymentID,
 	reservationID shared.ReservationID,
 	amount shared.Money,
 	paymentMethod string,
 ) (*payment.Payment, error) {
-	pay, err := s.paymentService.AuthorizePayment(ctx, paymentID, reservationID, amount, paymentMethod)
+	var pay *payment.Payment
+	err := s.runStep(ctx, deadline, completeBookingSteps-1, func(ctx context.Context) error {
+		var err error
+		pay, err = s.paymentService.AuthorizePaymentForReservation(ctx, commandID, paymentID, payment.ToReservationID(reservationID), amount, paymentMethod)
+		return err
+	})
 	if err != nil {
-		cancelErr := s.reservationService.CancelReservation(ctx, reservationID, "payment_authorization_failed")
+		// Compensation must run even if the saga was cancelled or timed out.
+		compensationCtx := context.WithoutCancel(ctx)
+		cancelErr := s.reservationService.CancelReservation(compensationCtx, reservation.ToReservationID(reservationID), "payment_authorization_failed")
 		if cancelErr != nil {
+			s.recordFailedCompensation(ctx, NewFailedCompensation(CompensationCancelReservation, reservationID, paymentID, "payment_authorization_failed", cancelErr))
 			return nil, fmt.Errorf("step 2 failed (authorize payment) and compensation failed: %w (original error: %w)", cancelErr, err)
 		}
 		return nil, fmt.Errorf("step 2 failed (authorize payment): %w", err)
@@ -197,11 +688,16 @@ func (s *BookingService) authorizePaymentStep(
 }
 
 // capturePaymentStep is a helper function to encapsulate.
-func (s *BookingService) capturePaymentStep(ctx context.Context, paymentID payment.PaymentID, reservationID shared.ReservationID) error {
-	captureErr := s.paymentService.CapturePayment(ctx, paymentID)
+func (s *BookingService) capturePaymentStep(ctx context.Context, deadline time.Time, paymentID payment.PaymentID, reservationID shared.ReservationID) error {
+	captureErr := s.runStep(ctx, deadline, completeBookingSteps-2, func(ctx context.Context) error {
+		return s.paymentService.CapturePaymentOnAuthorization(ctx, paymentID)
+	})
 	if captureErr != nil {
-		cancelErr := s.reservationService.CancelReservation(ctx, reservationID, "payment_capture_failed")
+		s.voidAuthorization(ctx, paymentID, reservationID)
+		compensationCtx := context.WithoutCancel(ctx)
+		cancelErr := s.reservationService.CancelReservation(compensationCtx, reservation.ToReservationID(reservationID), "payment_capture_failed")
 		if cancelErr != nil {
+			s.recordFailedCompensation(ctx, NewFailedCompensation(CompensationCancelReservation, reservationID, paymentID, "payment_capture_failed", cancelErr))
 			return fmt.Errorf("step 3 failed (capture payment) and compensation failed: %w (original error: %w)", cancelErr, captureErr)
 		}
 		return fmt.Errorf("step 3 failed (capture payment): %w", captureErr)
@@ -210,11 +706,23 @@ func (s *BookingService) capturePaymentStep(ctx context.Context, paymentID payme
 }
 
 // confirmReservationStep is a helper function to encapsulate.
-func (s *BookingService) confirmReservationStep(ctx context.Context, reservationID shared.ReservationID, paymentID payment.PaymentID) error {
-	confirmErr := s.reservationService.ConfirmReservation(ctx, reservationID)
+func (s *BookingService) confirmReservationStep(ctx context.Context, deadline time.Time, reservationID shared.ReservationID, paymentID payment.PaymentID, amount shared.Money) error {
+	confirmErr := s.runStep(ctx, deadline, completeBookingSteps-3, func(ctx context.Context) error {
+		if err := s.VerifyPaymentAmount(ctx, SagaStepConfirmation, paymentID, reservationID, amount); err != nil {
+			return err
+		}
+		return s.reservationService.ConfirmReservationOnPaymentCaptured(ctx, reservation.ToReservationID(reservationID), amount)
+	})
 	if confirmErr != nil {
-		refundErr := s.paymentService.RefundPayment(ctx, paymentID)
-		cancelErr := s.reservationService.CancelReservation(ctx, reservationID, "confirmation_failed")
+		compensationCtx := context.WithoutCancel(ctx)
+		refundErr := s.paymentService.RefundPayment(compensationCtx, paymentID)
+		cancelErr := s.reservationService.CancelReservation(compensationCtx, reservation.ToReservationID(reservationID), "confirmation_failed")
+		if refundErr != nil {
+			s.recordFailedCompensation(ctx, NewFailedCompensation(CompensationRefundPayment, reservationID, paymentID, "confirmation_failed", refundErr))
+		}
+		if cancelErr != nil {
+			s.recordFailedCompensation(ctx, NewFailedCompensation(CompensationCancelReservation, reservationID, paymentID, "confirmation_failed", cancelErr))
+		}
 		if refundErr != nil || cancelErr != nil {
 			return fmt.Errorf("step 4 failed (confirm reservation) and compensation failed (refund: %w, cancel: %w): %w", refundErr, cancelErr, confirmErr)
 		}
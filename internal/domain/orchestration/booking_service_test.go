@@ -8,6 +8,7 @@ import (
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
@@ -71,9 +72,20 @@ func (m *mockReservationRepository) ReadAll(ctx context.Context) ([]reservation.
 	return result, nil
 }
 
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	var result []reservation.Reservation
+	for _, res := range m.reservations {
+		if res.GuestID == guestID {
+			result = append(result, res)
+		}
+	}
+	return result, nil
+}
+
 type mockAvailabilityChecker struct {
-	available bool
-	err       error
+	available   bool
+	overlapping []*reservation.Reservation
+	err         error
 }
 
 func (m *mockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
@@ -84,7 +96,7 @@ func (m *mockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID re
 }
 
 func (m *mockAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
-	return nil, nil
+	return m.overlapping, nil
 }
 
 // ============================================================================
@@ -144,14 +156,37 @@ func (m *mockPaymentRepository) ReadAll(ctx context.Context) ([]payment.Payment,
 	return result, nil
 }
 
+func (m *mockPaymentRepository) FindByReservationID(ctx context.Context, reservationID payment.ReservationID) ([]payment.Payment, error) {
+	var result []payment.Payment
+	for _, p := range m.payments {
+		if p.ReservationID == reservationID {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
 type mockPaymentGateway struct {
 	authorizeTransactionID string
+	authorizeDelay         time.Duration
 	authorizeErr           error
 	captureErr             error
 	refundErr              error
+	voidErr                error
+	authorizeCalls         int
+	captureCalls           int
+	voidCalls              int
 }
 
 func (m *mockPaymentGateway) Authorize(ctx context.Context, p *payment.Payment) (string, error) {
+	m.authorizeCalls++
+	if m.authorizeDelay > 0 {
+		select {
+		case <-time.After(m.authorizeDelay):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
 	if m.authorizeErr != nil {
 		return "", m.authorizeErr
 	}
@@ -159,6 +194,7 @@ func (m *mockPaymentGateway) Authorize(ctx context.Context, p *payment.Payment)
 }
 
 func (m *mockPaymentGateway) Capture(ctx context.Context, transactionID string, amount shared.Money) error {
+	m.captureCalls++
 	return m.captureErr
 }
 
@@ -166,13 +202,19 @@ func (m *mockPaymentGateway) Refund(ctx context.Context, transactionID string, a
 	return m.refundErr
 }
 
+func (m *mockPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	m.voidCalls++
+	return m.voidErr
+}
+
 // ============================================================================
 // Mock Implementations - Common
 // ============================================================================
 
 type mockEventPublisher struct {
-	published []event.Event
-	err       error
+	published  []event.Event
+	deliverAts []time.Time
+	err        error
 }
 
 func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error {
@@ -180,6 +222,7 @@ func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error
 		return m.err
 	}
 	m.published = append(m.published, evt)
+	m.deliverAts = append(m.deliverAts, shared.DeliverAt(ctx))
 	return nil
 }
 
@@ -187,6 +230,7 @@ type mockNotificationService struct {
 	confirmationsSent int
 	cancellationsSent int
 	receiptsSent      int
+	attachments       []orchestration.Attachment
 	err               error
 }
 
@@ -206,14 +250,30 @@ func (m *mockNotificationService) SendCancellationNotice(ctx context.Context, r
 	return nil
 }
 
-func (m *mockNotificationService) SendPaymentReceipt(ctx context.Context, p *payment.Payment) error {
+func (m *mockNotificationService) SendPaymentReceipt(ctx context.Context, p *payment.Payment, attachments ...orchestration.Attachment) error {
 	if m.err != nil {
 		return m.err
 	}
 	m.receiptsSent++
+	m.attachments = attachments
 	return nil
 }
 
+type mockInvoiceGenerator struct {
+	err error
+}
+
+func (m *mockInvoiceGenerator) GenerateInvoice(ctx context.Context, reservationID shared.ReservationID) ([]byte, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []byte("%PDF-1.4"), nil
+}
+
+func (m *mockInvoiceGenerator) ContentType() string {
+	return "application/pdf"
+}
+
 // ============================================================================
 // Test Helpers
 // ============================================================================
@@ -272,7 +332,7 @@ func validBookingDateRange() reservation.DateRange {
 
 func validBookingGuests() []reservation.GuestInfo {
 	return []reservation.GuestInfo{
-		reservation.NewGuestInfo("John Doe", "john@example.com", "+1234567890"),
+		{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+1234567890"},
 	}
 }
 
@@ -404,6 +464,57 @@ func Test_BookingService_CompleteBooking_Should_Send_Confirmation_Notification(t
 	assert.That(t, "confirmation must be sent", svc.notificationService.confirmationsSent, 1)
 }
 
+func Test_BookingService_CompleteBooking_With_Invoices_Should_Attach_Invoice_To_Receipt(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.bookingService.WithInvoices(&mockInvoiceGenerator{})
+	ctx := context.Background()
+
+	// Act
+	_, err := svc.bookingService.CompleteBooking(
+		ctx,
+		"res-001",
+		"pay-001",
+		"guest-001",
+		"room-101",
+		validBookingDateRange(),
+		validBookingMoney(),
+		validBookingGuests(),
+		"credit_card",
+	)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "receipt must be sent", svc.notificationService.receiptsSent, 1)
+	assert.That(t, "invoice must be attached", len(svc.notificationService.attachments), 1)
+	assert.That(t, "attachment filename must match", svc.notificationService.attachments[0].Filename, "invoice-res-001.pdf")
+}
+
+func Test_BookingService_CompleteBooking_When_Invoice_Fails_Should_Send_Receipt_Without_Attachment(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.bookingService.WithInvoices(&mockInvoiceGenerator{err: errors.New("storage unavailable")})
+	ctx := context.Background()
+
+	// Act
+	_, err := svc.bookingService.CompleteBooking(
+		ctx,
+		"res-001",
+		"pay-001",
+		"guest-001",
+		"room-101",
+		validBookingDateRange(),
+		validBookingMoney(),
+		validBookingGuests(),
+		"credit_card",
+	)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "receipt must be sent", svc.notificationService.receiptsSent, 1)
+	assert.That(t, "no attachment must be sent", len(svc.notificationService.attachments), 0)
+}
+
 func Test_BookingService_CompleteBooking_When_Payment_Authorization_Fails_Should_Cancel_Reservation(t *testing.T) {
 	// Arrange
 	svc := createTestServices()
@@ -458,6 +569,88 @@ func Test_BookingService_CompleteBooking_When_Capture_Fails_Should_Cancel_Reserv
 	// Check compensation occurred
 	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
 	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, "pay-001")
+	assert.That(t, "authorization must be voided", storedPayment.Status, payment.StatusVoided)
+	assert.That(t, "gateway must void once", svc.paymentGateway.voidCalls, 1)
+}
+
+func Test_BookingService_CompleteBooking_With_Saga_Budget_Should_Complete_Full_Saga(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.bookingService.WithSagaBudget(time.Second)
+	ctx := context.Background()
+
+	// Act
+	res, err := svc.bookingService.CompleteBooking(
+		ctx,
+		"res-001",
+		"pay-001",
+		"guest-001",
+		"room-101",
+		validBookingDateRange(),
+		validBookingMoney(),
+		validBookingGuests(),
+		"credit_card",
+	)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "reservation must be confirmed", res.Status, reservation.StatusConfirmed)
+}
+
+func Test_BookingService_CompleteBooking_When_Step_Exceeds_Budget_Should_Return_Step_Timeout(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.bookingService.WithSagaBudget(40 * time.Millisecond)
+	svc.paymentGateway.authorizeDelay = time.Second
+	ctx := context.Background()
+
+	// Act
+	_, err := svc.bookingService.CompleteBooking(
+		ctx,
+		"res-001",
+		"pay-001",
+		"guest-001",
+		"room-101",
+		validBookingDateRange(),
+		validBookingMoney(),
+		validBookingGuests(),
+		"credit_card",
+	)
+
+	// Assert
+	assert.That(t, "error must be ErrStepTimeout", errors.Is(err, orchestration.ErrStepTimeout), true)
+	assert.That(t, "error must not be ErrSagaCancelled", errors.Is(err, orchestration.ErrSagaCancelled), false)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
+}
+
+func Test_BookingService_CompleteBooking_When_Caller_Cancels_Should_Return_Saga_Cancelled(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.bookingService.WithSagaBudget(time.Second)
+	svc.paymentGateway.authorizeDelay = time.Second
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := svc.bookingService.CompleteBooking(
+		ctx,
+		"res-001",
+		"pay-001",
+		"guest-001",
+		"room-101",
+		validBookingDateRange(),
+		validBookingMoney(),
+		validBookingGuests(),
+		"credit_card",
+	)
+
+	// Assert
+	assert.That(t, "error must be ErrSagaCancelled", errors.Is(err, orchestration.ErrSagaCancelled), true)
+	assert.That(t, "error must not be ErrStepTimeout", errors.Is(err, orchestration.ErrStepTimeout), false)
+	storedRes, _ := svc.reservationRepo.Read(context.Background(), "res-001")
+	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
 }
 
 // ============================================================================
@@ -487,7 +680,7 @@ func Test_BookingService_CancelBookingWithRefund_Should_Cancel_Reservation(t *te
 	// Assert
 	assert.That(t, "error must be nil", err == nil, true)
 
-	storedRes, _ := svc.reservationRepo.Read(ctx, reservationID)
+	storedRes, _ := svc.reservationRepo.Read(ctx, reservation.ToReservationID(reservationID))
 	assert.That(t, "status must be cancelled", storedRes.Status, reservation.StatusCancelled)
 }
 
@@ -527,6 +720,219 @@ func Test_BookingService_CancelBookingWithRefund_When_Not_Found_Should_Return_Er
 	assert.That(t, "error must not be nil", err != nil, true)
 }
 
+func completeTestBooking(t *testing.T, svc *testServices) {
+	t.Helper()
+	_, err := svc.bookingService.CompleteBooking(
+		context.Background(),
+		"res-001",
+		"pay-001",
+		"guest-001",
+		"room-101",
+		validBookingDateRange(),
+		validBookingMoney(),
+		validBookingGuests(),
+		"credit_card",
+	)
+	assert.That(t, "booking must complete", err == nil, true)
+}
+
+func Test_BookingService_CancelBookingWithRefund_With_Captured_Payment_Should_Refund_Payment(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	publisher := &mockEventPublisher{}
+	svc.bookingService.WithEventPublisher(publisher)
+	completeTestBooking(t, svc)
+	ctx := context.Background()
+
+	// Act
+	err := svc.bookingService.CancelBookingWithRefund(ctx, "res-001", "guest requested")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	storedPay, _ := svc.paymentRepo.Read(ctx, "pay-001")
+	assert.That(t, "payment must be refunded", storedPay.Status, payment.StatusRefunded)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "topic must be booking.refunded", publisher.published[0].Topic(), orchestration.EventTopicRefunded)
+}
+
+func Test_BookingService_CancelBookingWithRefund_Should_Refund_Balance_But_Not_Deposit(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	completeTestBooking(t, svc)
+	ctx := context.Background()
+	balance := *payment.NewBalancePayment("pay-bal", "res-001", validBookingMoney(), "credit_card")
+	balance.Status = payment.StatusCaptured
+	deposit := *payment.NewDeposit("pay-dep", "res-001", validBookingMoney(), "credit_card")
+	deposit.Status = payment.StatusCaptured
+	_ = svc.paymentRepo.Create(ctx, balance.ID, balance)
+	_ = svc.paymentRepo.Create(ctx, deposit.ID, deposit)
+
+	// Act
+	err := svc.bookingService.CancelBookingWithRefund(ctx, "res-001", "guest requested")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "booking payment must be refunded", svc.paymentRepo.payments["pay-001"].Status, payment.StatusRefunded)
+	assert.That(t, "balance must be refunded", svc.paymentRepo.payments["pay-bal"].Status, payment.StatusRefunded)
+	assert.That(t, "deposit must not be refunded", svc.paymentRepo.payments["pay-dep"].Status, payment.StatusCaptured)
+}
+
+func Test_BookingService_CancelBookingWithRefund_When_Refund_Fails_Should_Flag_Reservation(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	queue := resource.NewInMemoryAccess[orchestration.CompensationID, orchestration.FailedCompensation]()
+	svc.bookingService.WithCompensationQueue(queue)
+	completeTestBooking(t, svc)
+	svc.paymentGateway.refundErr = errors.New("gateway unavailable")
+	ctx := context.Background()
+
+	// Act
+	err := svc.bookingService.CancelBookingWithRefund(ctx, "res-001", "guest requested")
+
+	// Assert
+	assert.That(t, "error must be refund failed", errors.Is(err, orchestration.ErrRefundFailed), true)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
+	assert.That(t, "reservation must require refund", storedRes.RefundRequired, true)
+	pending, _ := queue.ReadAll(ctx)
+	assert.That(t, "refund must be queued", len(pending), 1)
+	assert.That(t, "action must be refund payment", pending[0].Action, orchestration.CompensationRefundPayment)
+}
+
+func Test_BookingService_CancelBookingWithRefund_With_Disputed_Payment_Should_Queue_Refund(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	queue := resource.NewInMemoryAccess[orchestration.CompensationID, orchestration.FailedCompensation]()
+	svc.bookingService.WithCompensationQueue(queue)
+	completeTestBooking(t, svc)
+	ctx := context.Background()
+	_, _ = svc.paymentService.OpenDispute(ctx, "pay-001", "dp-001", "fraudulent", validBookingMoney(), time.Time{})
+
+	// Act
+	err := svc.bookingService.CancelBookingWithRefund(ctx, "res-001", "guest requested")
+
+	// Assert
+	assert.That(t, "error must be ErrRefundsFrozen", errors.Is(err, payment.ErrRefundsFrozen), true)
+	assert.That(t, "payment must stay captured", svc.paymentRepo.payments["pay-001"].Status, payment.StatusCaptured)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must require refund", storedRes.RefundRequired, true)
+	pending, _ := queue.ReadAll(ctx)
+	assert.That(t, "refund must be queued", len(pending), 1)
+}
+
+func Test_BookingService_RetryCompensations_When_Dispute_Lost_Should_Resolve_Refund(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	queue := resource.NewInMemoryAccess[orchestration.CompensationID, orchestration.FailedCompensation]()
+	svc.bookingService.WithCompensationQueue(queue)
+	completeTestBooking(t, svc)
+	ctx := context.Background()
+	_, _ = svc.paymentService.OpenDispute(ctx, "pay-001", "dp-001", "fraudulent", validBookingMoney(), time.Time{})
+	_ = svc.bookingService.CancelBookingWithRefund(ctx, "res-001", "guest requested")
+	_, _ = svc.paymentService.ResolveDispute(ctx, "pay-001", payment.DisputeLost)
+
+	// Act
+	resolved, err := svc.bookingService.RetryCompensations(ctx)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "one compensation must be resolved", resolved, 1)
+	assert.That(t, "charged back payment must not be refunded", svc.paymentRepo.payments["pay-001"].Status, payment.StatusCaptured)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "refund flag must be cleared", storedRes.RefundRequired, false)
+}
+
+func Test_BookingService_RetryCompensations_When_Refund_Succeeds_Should_Clear_Flag(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	queue := resource.NewInMemoryAccess[orchestration.CompensationID, orchestration.FailedCompensation]()
+	svc.bookingService.WithCompensationQueue(queue)
+	completeTestBooking(t, svc)
+	svc.paymentGateway.refundErr = errors.New("gateway unavailable")
+	ctx := context.Background()
+	_ = svc.bookingService.CancelBookingWithRefund(ctx, "res-001", "guest requested")
+	svc.paymentGateway.refundErr = nil
+
+	// Act
+	resolved, err := svc.bookingService.RetryCompensations(ctx)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "one compensation must be resolved", resolved, 1)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "refund flag must be cleared", storedRes.RefundRequired, false)
+	storedPay, _ := svc.paymentRepo.Read(ctx, "pay-001")
+	assert.That(t, "payment must be refunded", storedPay.Status, payment.StatusRefunded)
+}
+
+// ============================================================================
+// OnRoomBlocked Tests
+// ============================================================================
+
+func Test_BookingService_OnRoomBlocked_Should_Cancel_And_Refund_Confirmed_Booking(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	completeTestBooking(t, svc)
+	ctx := context.Background()
+	booked, _ := svc.reservationRepo.Read(ctx, "res-001")
+	svc.availabilityCheck.overlapping = []*reservation.Reservation{booked}
+
+	// Act
+	err := svc.bookingService.OnRoomBlocked(ctx, "room-101", validBookingDateRange(), reservation.BlockMaintenance)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
+	assert.That(t, "reason must name the block", storedRes.CancellationReason, "room_blocked: maintenance")
+	storedPay, _ := svc.paymentRepo.Read(ctx, "pay-001")
+	assert.That(t, "payment must be refunded", storedPay.Status, payment.StatusRefunded)
+	assert.That(t, "guest must be notified", svc.notificationService.cancellationsSent, 1)
+}
+
+func Test_BookingService_OnRoomBlocked_Should_Cancel_Booking_Within_Cancellation_Deadline(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	ctx := context.Background()
+	checkIn := time.Now().Add(2 * time.Hour)
+	res, _ := reservation.NewReservation("res-001", "guest-001", "room-101",
+		reservation.NewDateRange(checkIn, checkIn.Add(48*time.Hour)), validBookingMoney(), validBookingGuests())
+	_ = res.Confirm()
+	_ = svc.reservationRepo.Create(ctx, res.ID, *res)
+	svc.availabilityCheck.overlapping = []*reservation.Reservation{res}
+
+	// Act
+	err := svc.bookingService.OnRoomBlocked(ctx, "room-101", res.DateRange, reservation.BlockRenovation)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
+}
+
+func Test_BookingService_OnRoomBlocked_Should_Leave_Active_Stays_And_External_Holds(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	ctx := context.Background()
+	hold, _ := reservation.NewExternalHold("hold-001", "room-101", validBookingDateRange(), "airbnb")
+	_ = svc.reservationRepo.Create(ctx, hold.ID, *hold)
+	active, _ := reservation.NewReservation("res-002", "guest-001", "room-101", validBookingDateRange(), validBookingMoney(), validBookingGuests())
+	_ = active.Confirm()
+	_ = active.Activate()
+	_ = svc.reservationRepo.Create(ctx, active.ID, *active)
+	svc.availabilityCheck.overlapping = []*reservation.Reservation{hold, active}
+
+	// Act
+	err := svc.bookingService.OnRoomBlocked(ctx, "room-101", validBookingDateRange(), reservation.BlockMaintenance)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	storedHold, _ := svc.reservationRepo.Read(ctx, "hold-001")
+	storedActive, _ := svc.reservationRepo.Read(ctx, "res-002")
+	assert.That(t, "hold must stay confirmed", storedHold.Status, reservation.StatusConfirmed)
+	assert.That(t, "stay must stay active", storedActive.Status, reservation.StatusActive)
+}
+
 // ============================================================================
 // OnPaymentAuthorized Tests
 // ============================================================================
@@ -543,7 +949,7 @@ func Test_BookingService_OnPaymentAuthorized_Should_Capture_Payment(t *testing.T
 		ctx, reservationID, "guest-001", "room-101",
 		validBookingDateRange(), validBookingMoney(), validBookingGuests(),
 	)
-	_, _ = svc.paymentService.AuthorizePayment(ctx, paymentID, reservationID, validBookingMoney(), "credit_card")
+	_, _ = svc.paymentService.AuthorizePayment(ctx, paymentID, payment.ToReservationID(reservationID), validBookingMoney(), "credit_card")
 
 	// Act
 	err := svc.bookingService.OnPaymentAuthorized(ctx, paymentID, reservationID)
@@ -568,7 +974,7 @@ func Test_BookingService_OnPaymentAuthorized_When_Capture_Fails_Should_Cancel_Re
 		ctx, reservationID, "guest-001", "room-101",
 		validBookingDateRange(), validBookingMoney(), validBookingGuests(),
 	)
-	_, _ = svc.paymentService.AuthorizePayment(ctx, paymentID, reservationID, validBookingMoney(), "credit_card")
+	_, _ = svc.paymentService.AuthorizePayment(ctx, paymentID, payment.ToReservationID(reservationID), validBookingMoney(), "credit_card")
 
 	// Act
 	err := svc.bookingService.OnPaymentAuthorized(ctx, paymentID, reservationID)
@@ -576,8 +982,39 @@ func Test_BookingService_OnPaymentAuthorized_When_Capture_Fails_Should_Cancel_Re
 	// Assert
 	assert.That(t, "error must not be nil", err != nil, true)
 
-	storedRes, _ := svc.reservationRepo.Read(ctx, reservationID)
+	storedRes, _ := svc.reservationRepo.Read(ctx, reservation.ToReservationID(reservationID))
 	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, paymentID)
+	assert.That(t, "authorization must be voided", storedPayment.Status, payment.StatusVoided)
+}
+
+func Test_BookingService_OnPaymentAuthorized_When_Capture_Fails_After_Cancellation_Should_Not_Queue_Compensation(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.paymentGateway.captureErr = errors.New("capture failed")
+	queue := resource.NewInMemoryAccess[orchestration.CompensationID, orchestration.FailedCompensation]()
+	svc.bookingService.WithCompensationQueue(queue)
+	ctx := context.Background()
+	reservationID := shared.ReservationID("res-001")
+	paymentID := payment.PaymentID("pay-001")
+
+	// Setup: payment.failed cancelled the reservation before the authorization is handled
+	_, _ = svc.bookingService.InitiateBooking(
+		ctx, reservationID, "guest-001", "room-101",
+		validBookingDateRange(), validBookingMoney(), validBookingGuests(),
+	)
+	_, _ = svc.paymentService.AuthorizePayment(ctx, paymentID, payment.ToReservationID(reservationID), validBookingMoney(), "credit_card")
+	_ = svc.bookingService.OnPaymentFailed(ctx, reservationID, "card_declined")
+
+	// Act
+	err := svc.bookingService.OnPaymentAuthorized(ctx, paymentID, reservationID)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	storedRes, _ := svc.reservationRepo.Read(ctx, reservation.ToReservationID(reservationID))
+	assert.That(t, "reservation must stay cancelled", storedRes.Status, reservation.StatusCancelled)
+	pending, _ := queue.ReadAll(ctx)
+	assert.That(t, "no compensation must be queued", len(pending), 0)
 }
 
 // ============================================================================
@@ -597,12 +1034,12 @@ func Test_BookingService_OnPaymentCaptured_Should_Confirm_Reservation(t *testing
 	)
 
 	// Act
-	err := svc.bookingService.OnPaymentCaptured(ctx, reservationID)
+	err := svc.bookingService.OnPaymentCaptured(ctx, "pay-res-001", reservationID, validBookingMoney())
 
 	// Assert
 	assert.That(t, "error must be nil", err == nil, true)
 
-	storedRes, _ := svc.reservationRepo.Read(ctx, reservationID)
+	storedRes, _ := svc.reservationRepo.Read(ctx, reservation.ToReservationID(reservationID))
 	assert.That(t, "reservation must be confirmed", storedRes.Status, reservation.StatusConfirmed)
 }
 
@@ -618,13 +1055,45 @@ func Test_BookingService_OnPaymentCaptured_Should_Send_Notification(t *testing.T
 	)
 
 	// Act
-	err := svc.bookingService.OnPaymentCaptured(ctx, reservationID)
+	err := svc.bookingService.OnPaymentCaptured(ctx, "pay-res-001", reservationID, validBookingMoney())
 
 	// Assert
 	assert.That(t, "error must be nil", err == nil, true)
 	assert.That(t, "confirmation must be sent", svc.notificationService.confirmationsSent, 1)
 }
 
+func Test_BookingService_OnPaymentCaptured_With_Mismatched_Amount_Should_Refuse_Confirmation(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	publisher := &mockEventPublisher{}
+	svc.bookingService.WithEventPublisher(publisher)
+	ctx := context.Background()
+	reservationID := shared.ReservationID("res-001")
+
+	_, _ = svc.bookingService.InitiateBooking(
+		ctx, reservationID, "guest-001", "room-101",
+		validBookingDateRange(), validBookingMoney(), validBookingGuests(),
+	)
+	captured := shared.NewMoney(validBookingMoney().Amount, "EUR")
+
+	// Act
+	err := svc.bookingService.OnPaymentCaptured(ctx, "pay-res-001", reservationID, captured)
+
+	// Assert
+	assert.That(t, "error must be ErrPaymentMismatch", errors.Is(err, reservation.ErrPaymentMismatch), true)
+	storedRes, _ := svc.reservationRepo.Read(ctx, reservation.ToReservationID(reservationID))
+	assert.That(t, "reservation must remain pending", storedRes.Status, reservation.StatusPending)
+	assert.That(t, "confirmation must not be sent", svc.notificationService.confirmationsSent, 0)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	discrepancy, _ := publisher.published[0].(*orchestration.EventPaymentDiscrepancy)
+	assert.That(t, "discrepancy must be published", discrepancy, orchestration.NewEventPaymentDiscrepancy().
+		WithReservationID(reservationID).
+		WithPaymentID("pay-res-001").
+		WithStep(orchestration.SagaStepCapture).
+		WithExpected(validBookingMoney()).
+		WithActual(captured))
+}
+
 // ============================================================================
 // OnPaymentFailed Tests
 // ============================================================================
@@ -647,7 +1116,7 @@ func Test_BookingService_OnPaymentFailed_Should_Cancel_Reservation(t *testing.T)
 	// Assert
 	assert.That(t, "error must be nil", err == nil, true)
 
-	storedRes, _ := svc.reservationRepo.Read(ctx, reservationID)
+	storedRes, _ := svc.reservationRepo.Read(ctx, reservation.ToReservationID(reservationID))
 	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
 	assert.That(t, "cancellation reason must match", storedRes.CancellationReason, "payment_declined")
 }
@@ -663,3 +1132,136 @@ func Test_BookingService_OnPaymentFailed_When_Reservation_Not_Found_Should_Retur
 	// Assert
 	assert.That(t, "error must not be nil", err != nil, true)
 }
+
+// ============================================================================
+// Compensation Queue Tests
+// ============================================================================
+
+func completeBookingWithFailedCompensation(t *testing.T, svc *testServices) {
+	t.Helper()
+	svc.paymentGateway.captureErr = errors.New("capture failed")
+	svc.reservationRepo.updateErr = errors.New("database unavailable")
+
+	_, err := svc.bookingService.CompleteBooking(
+		context.Background(),
+		"res-001",
+		"pay-001",
+		"guest-001",
+		"room-101",
+		validBookingDateRange(),
+		validBookingMoney(),
+		validBookingGuests(),
+		"credit_card",
+	)
+	assert.That(t, "booking must fail", err != nil, true)
+}
+
+func Test_BookingService_CompleteBooking_When_Compensation_Fails_Should_Queue_Compensation(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	queue := resource.NewInMemoryAccess[orchestration.CompensationID, orchestration.FailedCompensation]()
+	svc.bookingService.WithCompensationQueue(queue)
+
+	// Act
+	completeBookingWithFailedCompensation(t, svc)
+
+	// Assert
+	pending, _ := queue.ReadAll(context.Background())
+	assert.That(t, "queue must contain one compensation", len(pending), 1)
+	assert.That(t, "action must be cancel reservation", pending[0].Action, orchestration.CompensationCancelReservation)
+	assert.That(t, "reservation ID must match", pending[0].ReservationID, shared.ReservationID("res-001"))
+	assert.That(t, "attempts must be 1", pending[0].Attempts, 1)
+}
+
+func Test_BookingService_CompleteBooking_When_Compensation_Fails_Should_Publish_Alert(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	publisher := &mockEventPublisher{}
+	svc.bookingService.WithEventPublisher(publisher)
+
+	// Act
+	completeBookingWithFailedCompensation(t, svc)
+
+	// Assert
+	assert.That(t, "one alert must be published", len(publisher.published), 1)
+	assert.That(t, "topic must match", publisher.published[0].Topic(), orchestration.EventTopicCompensationFailed)
+}
+
+func Test_BookingService_RetryCompensations_When_Compensation_Succeeds_Should_Dequeue(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	queue := resource.NewInMemoryAccess[orchestration.CompensationID, orchestration.FailedCompensation]()
+	svc.bookingService.WithCompensationQueue(queue)
+	completeBookingWithFailedCompensation(t, svc)
+	svc.reservationRepo.updateErr = nil
+	ctx := context.Background()
+
+	// Act
+	resolved, err := svc.bookingService.RetryCompensations(ctx)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "one compensation must be resolved", resolved, 1)
+	pending, _ := queue.ReadAll(ctx)
+	assert.That(t, "queue must be empty", len(pending), 0)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
+}
+
+func Test_BookingService_RetryCompensations_When_Compensation_Still_Fails_Should_Increment_Attempts(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	queue := resource.NewInMemoryAccess[orchestration.CompensationID, orchestration.FailedCompensation]()
+	svc.bookingService.WithCompensationQueue(queue)
+	completeBookingWithFailedCompensation(t, svc)
+	ctx := context.Background()
+
+	// Act
+	resolved, err := svc.bookingService.RetryCompensations(ctx)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "no compensation must be resolved", resolved, 0)
+	pending, _ := queue.ReadAll(ctx)
+	assert.That(t, "queue must still contain the compensation", len(pending), 1)
+	assert.That(t, "attempts must be 2", pending[0].Attempts, 2)
+}
+
+func Test_BookingService_RetryCompensations_When_Void_Failed_Should_Release_Authorization(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	queue := resource.NewInMemoryAccess[orchestration.CompensationID, orchestration.FailedCompensation]()
+	svc.bookingService.WithCompensationQueue(queue)
+	svc.paymentGateway.captureErr = errors.New("capture failed")
+	svc.paymentGateway.voidErr = errors.New("gateway down")
+	ctx := context.Background()
+	_, _ = svc.bookingService.CompleteBooking(
+		ctx, "res-001", "pay-001", "guest-001", "room-101",
+		validBookingDateRange(), validBookingMoney(), validBookingGuests(), "credit_card",
+	)
+	pending, _ := queue.ReadAll(ctx)
+	assert.That(t, "void must be queued", len(pending), 1)
+	assert.That(t, "action must be void payment", pending[0].Action, orchestration.CompensationVoidPayment)
+	svc.paymentGateway.voidErr = nil
+
+	// Act
+	resolved, err := svc.bookingService.RetryCompensations(ctx)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "one compensation must be resolved", resolved, 1)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, "pay-001")
+	assert.That(t, "authorization must be voided", storedPayment.Status, payment.StatusVoided)
+}
+
+func Test_BookingService_RetryCompensations_Without_Queue_Should_Do_Nothing(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+
+	// Act
+	resolved, err := svc.bookingService.RetryCompensations(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "no compensation must be resolved", resolved, 0)
+}
@@ -0,0 +1,159 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// DepositService holds security deposits for incidentals. A deposit is authorized
+// when a booking is confirmed, incidentals are charged against it during the stay,
+// and a scheduled job releases it a few days after check-out: the incidentals are
+// captured, or the authorization is voided if nothing was charged.
+type DepositService struct {
+	reservationService *reservation.Service
+	paymentService     *payment.Service
+	ids                shared.IDGenerator
+	amount             int64
+	releaseAfter       time.Duration
+}
+
+// NewDepositService creates a new deposit service that holds the given amount,
+// in the smallest unit of the reservation's currency.
+// Deposits are released three days after check-out by default.
+func NewDepositService(reservationSvc *reservation.Service, paymentSvc *payment.Service, amount int64) *DepositService {
+	return &DepositService{
+		reservationService: reservationSvc,
+		paymentService:     paymentSvc,
+		ids:                shared.NewUUIDv7Generator(),
+		amount:             amount,
+		releaseAfter:       72 * time.Hour,
+	}
+}
+
+// WithIDGenerator sets the generator for new deposit IDs (UUIDv7 by default).
+func (s *DepositService) WithIDGenerator(ids shared.IDGenerator) *DepositService {
+	s.ids = ids
+	return s
+}
+
+// WithReleaseAfter sets how long after the check-out date a deposit is released (default 72h).
+// Incidentals found after check-out, e.g. damages, can be charged until then.
+func (s *DepositService) WithReleaseAfter(d time.Duration) *DepositService {
+	s.releaseAfter = d
+	return s
+}
+
+// RegisterHandlers subscribes to the confirmation of bookings, which holds their deposit.
+func (s *DepositService) RegisterHandlers(ctx context.Context, dispatcher messaging.Dispatcher) error {
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicConfirmed, s.handleReservationConfirmed); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicConfirmed, err)
+	}
+	return nil
+}
+
+// HoldDeposit authorizes the deposit of a reservation.
+// A deposit that was held already is returned unchanged.
+func (s *DepositService) HoldDeposit(ctx context.Context, reservationID reservation.ReservationID) (*payment.Payment, error) {
+	res, err := s.reservationService.GetReservation(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	paymentID := payment.NewPaymentID(s.ids)
+	if deposit, err := s.GetDeposit(ctx, res.ID); err == nil {
+		paymentID = deposit.ID
+	}
+	amount := shared.NewMoney(s.amount, res.TotalAmount.Currency)
+	return s.paymentService.HoldDeposit(ctx, paymentID, payment.ToReservationID(res.ID.Shared()), amount, "default")
+}
+
+// GetDeposit returns the deposit of a reservation.
+func (s *DepositService) GetDeposit(ctx context.Context, reservationID reservation.ReservationID) (*payment.Payment, error) {
+	return s.paymentService.GetDepositByReservation(ctx, payment.ToReservationID(reservationID.Shared()))
+}
+
+// ChargeIncidentals charges incidentals against the deposit of a reservation.
+// The amount is in the smallest unit of the deposit's currency.
+func (s *DepositService) ChargeIncidentals(ctx context.Context, reservationID reservation.ReservationID, amount int64) (*payment.Payment, error) {
+	deposit, err := s.GetDeposit(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.paymentService.ChargeIncidentals(ctx, deposit.ID, shared.NewMoney(amount, deposit.Amount.Currency))
+}
+
+// ReleaseDeposits releases the held deposits that are due and returns their IDs.
+// A deposit is due once the release period after check-out has passed, or right
+// away if the stay was cancelled or the guest did not arrive. A deposit that fails
+// is reported in the joined error and stays held, while the others are still released.
+func (s *DepositService) ReleaseDeposits(ctx context.Context, now time.Time) ([]payment.PaymentID, error) {
+	deposits, err := s.paymentService.ListHeldDeposits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	released := []payment.PaymentID{}
+	var errs []error
+	for _, deposit := range deposits {
+		res, err := s.reservationService.GetReservation(ctx, reservation.ReservationID(deposit.ReservationID))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("deposit %s: failed to get reservation: %w", deposit.ID, err))
+			continue
+		}
+		if !s.isDue(res, now) {
+			continue
+		}
+
+		if _, err := s.paymentService.ReleaseDeposit(ctx, deposit.ID); err != nil {
+			errs = append(errs, fmt.Errorf("deposit %s: %w", deposit.ID, err))
+			continue
+		}
+		released = append(released, deposit.ID)
+	}
+
+	return released, errors.Join(errs...)
+}
+
+// isDue reports whether the deposit of a reservation can be released.
+func (s *DepositService) isDue(res *reservation.Reservation, now time.Time) bool {
+	switch res.Status {
+	case reservation.StatusCompleted:
+		return !now.Before(res.DateRange.CheckOut.Add(s.releaseAfter))
+	case reservation.StatusCancelled, reservation.StatusNoShow:
+		return true
+	default:
+		return false
+	}
+}
+
+// handleReservationConfirmed holds the deposit of a confirmed booking.
+func (s *DepositService) handleReservationConfirmed(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventConfirmed
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	res, err := s.reservationService.GetReservation(ctx, evt.ReservationID)
+	if err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	// Channel reservations and external holds are settled outside the hotel
+	if res.Channel != "" || res.IsExternalHold() {
+		return messaging.MessageStateCompleted, nil
+	}
+
+	if _, err := s.HoldDeposit(ctx, res.ID); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to hold deposit: %w", err)
+	}
+	return messaging.MessageStateCompleted, nil
+}
@@ -0,0 +1,168 @@
+package orchestration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// seedDepositReservation stores a reservation with the given status whose
+// 2-night stay ended days ago, with a held deposit of 200.00 USD.
+func seedDepositReservation(svc *testServices, id string, status reservation.ReservationStatus, checkOutDaysAgo int) {
+	checkOut := time.Now().AddDate(0, 0, -checkOutDaysAgo).Truncate(24 * time.Hour)
+	svc.reservationRepo.reservations[reservation.ReservationID(id)] = reservation.Reservation{
+		ID:          reservation.ReservationID(id),
+		GuestID:     "guest-001",
+		RoomID:      "room-101",
+		DateRange:   reservation.NewDateRange(checkOut.AddDate(0, 0, -2), checkOut),
+		Status:      status,
+		TotalAmount: shared.NewMoney(20000, "USD"),
+		Guests:      validBookingGuests(),
+	}
+	depositID := payment.PaymentID("dep-" + id)
+	svc.paymentRepo.payments[depositID] = payment.Payment{
+		ID:            depositID,
+		ReservationID: payment.ReservationID(id),
+		Amount:        shared.NewMoney(20000, "USD"),
+		Status:        payment.StatusAuthorized,
+		TransactionID: "tx-dep-" + id,
+		Kind:          payment.KindDeposit,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// ============================================================================
+// HoldDeposit Tests
+// ============================================================================
+
+func Test_DepositService_HoldDeposit_Should_Authorize_Deposit_In_Reservation_Currency(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedNoShowReservation(svc, "res-001", -3)
+	svc.paymentGateway.authorizeTransactionID = "tx-dep"
+	deposits := orchestration.NewDepositService(svc.reservationService, svc.paymentService, 15000)
+
+	// Act
+	deposit, err := deposits.HoldDeposit(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "deposit must be authorized", deposit.Status, payment.StatusAuthorized)
+	assert.That(t, "deposit must be marked as deposit", deposit.Kind, payment.KindDeposit)
+	assert.That(t, "deposit amount must be configured amount", deposit.Amount, shared.NewMoney(15000, "USD"))
+	pay, err := svc.paymentService.GetPaymentByReservation(context.Background(), "res-001")
+	assert.That(t, "payment lookup err must be nil", err == nil, true)
+	assert.That(t, "payment of the reservation must not be the deposit", pay.ID, payment.PaymentID("pay-res-001"))
+}
+
+// ============================================================================
+// ChargeIncidentals Tests
+// ============================================================================
+
+func Test_DepositService_ChargeIncidentals_Exceeding_Deposit_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedDepositReservation(svc, "res-001", reservation.StatusActive, -1)
+	deposits := orchestration.NewDepositService(svc.reservationService, svc.paymentService, 20000)
+
+	// Act
+	_, err := deposits.ChargeIncidentals(context.Background(), "res-001", 25000)
+
+	// Assert
+	assert.That(t, "err must be ErrIncidentalsExceedDeposit", errors.Is(err, payment.ErrIncidentalsExceedDeposit), true)
+}
+
+// ============================================================================
+// ReleaseDeposits Tests
+// ============================================================================
+
+func Test_DepositService_ReleaseDeposits_Without_Incidentals_Should_Void_Deposit(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedDepositReservation(svc, "res-001", reservation.StatusCompleted, 4)
+	deposits := orchestration.NewDepositService(svc.reservationService, svc.paymentService, 20000)
+
+	// Act
+	released, err := deposits.ReleaseDeposits(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "one deposit must be released", released, []payment.PaymentID{"dep-res-001"})
+	assert.That(t, "deposit must be voided", svc.paymentRepo.payments["dep-res-001"].Status, payment.StatusVoided)
+}
+
+func Test_DepositService_ReleaseDeposits_With_Incidentals_Should_Capture_Incidentals(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedDepositReservation(svc, "res-001", reservation.StatusCompleted, 4)
+	deposits := orchestration.NewDepositService(svc.reservationService, svc.paymentService, 20000)
+	if _, err := deposits.ChargeIncidentals(context.Background(), "res-001", 3500); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	// Act
+	_, err := deposits.ReleaseDeposits(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	deposit := svc.paymentRepo.payments["dep-res-001"]
+	assert.That(t, "deposit must be captured", deposit.Status, payment.StatusCaptured)
+	assert.That(t, "captured amount must be the incidentals", deposit.CapturedAmount, shared.NewMoney(3500, "USD"))
+}
+
+func Test_DepositService_ReleaseDeposits_Within_Release_Period_Should_Keep_Deposit(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedDepositReservation(svc, "res-001", reservation.StatusCompleted, 1)
+	deposits := orchestration.NewDepositService(svc.reservationService, svc.paymentService, 20000)
+
+	// Act
+	released, err := deposits.ReleaseDeposits(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "no deposit must be released", len(released), 0)
+	assert.That(t, "deposit must remain authorized", svc.paymentRepo.payments["dep-res-001"].Status, payment.StatusAuthorized)
+}
+
+func Test_DepositService_ReleaseDeposits_With_Cancelled_Reservation_Should_Void_Immediately(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedDepositReservation(svc, "res-001", reservation.StatusCancelled, -5)
+	deposits := orchestration.NewDepositService(svc.reservationService, svc.paymentService, 20000)
+
+	// Act
+	released, err := deposits.ReleaseDeposits(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "one deposit must be released", len(released), 1)
+	assert.That(t, "deposit must be voided", svc.paymentRepo.payments["dep-res-001"].Status, payment.StatusVoided)
+}
+
+func Test_DepositService_ReleaseDeposits_When_Void_Fails_Should_Keep_Deposit_Held(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedDepositReservation(svc, "res-001", reservation.StatusCompleted, 4)
+	svc.paymentGateway.voidErr = errors.New("gateway down")
+	deposits := orchestration.NewDepositService(svc.reservationService, svc.paymentService, 20000)
+
+	// Act
+	released, err := deposits.ReleaseDeposits(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+	assert.That(t, "no deposit must be released", len(released), 0)
+	assert.That(t, "deposit must remain authorized", svc.paymentRepo.payments["dep-res-001"].Status, payment.StatusAuthorized)
+}
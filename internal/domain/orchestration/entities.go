@@ -0,0 +1,300 @@
+package orchestration
+
+import (
+	"errors"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// CompensationID uniquely identifies a failed compensation.
+type CompensationID string
+
+// CompensationAction describes which compensating action failed.
+type CompensationAction string
+
+const (
+	CompensationCancelReservation CompensationAction = "cancel_reservation"
+	CompensationRefundPayment     CompensationAction = "refund_payment"
+	CompensationVoidPayment       CompensationAction = "void_payment"
+)
+
+// FailedCompensation records a compensating action that could not be completed.
+// It is queued for automatic retry so that the saga eventually reaches a consistent state.
+type FailedCompensation struct {
+	ID            CompensationID       `json:"id"`
+	Action        CompensationAction   `json:"action"`
+	ReservationID shared.ReservationID `json:"reservation_id"`
+	PaymentID     payment.PaymentID    `json:"payment_id,omitempty"`
+	Reason        string               `json:"reason"`
+	LastError     string               `json:"last_error"`
+	Attempts      int                  `json:"attempts"`
+	FailedAt      time.Time            `json:"failed_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+// NewFailedCompensation creates a failed compensation record.
+// The ID is derived from the reservation and action, so the same failure is only queued once.
+func NewFailedCompensation(
+	action CompensationAction,
+	reservationID shared.ReservationID,
+	paymentID payment.PaymentID,
+	reason string,
+	err error,
+) *FailedCompensation {
+	now := time.Now()
+	return &FailedCompensation{
+		ID:            CompensationID(string(reservationID) + ":" + string(action)),
+		Action:        action,
+		ReservationID: reservationID,
+		PaymentID:     paymentID,
+		Reason:        reason,
+		LastError:     err.Error(),
+		Attempts:      1,
+		FailedAt:      now,
+		UpdatedAt:     now,
+	}
+}
+
+// SagaStep is a step of the booking saga.
+type SagaStep string
+
+const (
+	SagaStepReservation   SagaStep = "reservation"
+	SagaStepAuthorization SagaStep = "authorization"
+	SagaStepCapture       SagaStep = "capture"
+	SagaStepConfirmation  SagaStep = "confirmation"
+)
+
+// SagaSteps lists the steps of the booking saga in order.
+var SagaSteps = []SagaStep{SagaStepReservation, SagaStepAuthorization, SagaStepCapture, SagaStepConfirmation}
+
+// SagaStepStatus is the progress of a saga step.
+type SagaStepStatus string
+
+const (
+	SagaStepPending     SagaStepStatus = "pending"
+	SagaStepRunning     SagaStepStatus = "running"
+	SagaStepCompleted   SagaStepStatus = "completed"
+	SagaStepSkipped     SagaStepStatus = "skipped"
+	SagaStepFailed      SagaStepStatus = "failed"
+	SagaStepCompensated SagaStepStatus = "compensated"
+)
+
+// SagaMode is how the payment steps of a booking saga run.
+type SagaMode string
+
+const (
+	SagaModeEventDriven SagaMode = "event_driven" // Chained through the payment events
+	SagaModeSynchronous SagaMode = "synchronous"  // Run by the reservation.created handler
+)
+
+// SagaStepState is the status of one saga step.
+type SagaStepState struct {
+	Step      SagaStep       `json:"step"`
+	Status    SagaStepStatus `json:"status"`
+	Error     string         `json:"error,omitempty"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// SagaState is the progress of the booking saga of a reservation.
+// It is a read model built from the domain events of the reservation and payment contexts.
+type SagaState struct {
+	ReservationID      shared.ReservationID `json:"reservation_id"`
+	Mode               SagaMode             `json:"mode,omitempty"` // Chosen when the saga starts, empty before
+	Steps              []SagaStepState      `json:"steps"`
+	CancellationReason string               `json:"cancellation_reason,omitempty"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+}
+
+// NewSagaState creates the state of a saga that has not started yet.
+func NewSagaState(reservationID shared.ReservationID) *SagaState {
+	steps := make([]SagaStepState, 0, len(SagaSteps))
+	for _, step := range SagaSteps {
+		steps = append(steps, SagaStepState{Step: step, Status: SagaStepPending})
+	}
+	return &SagaState{ReservationID: reservationID, Steps: steps}
+}
+
+// Failed returns true if a step failed.
+func (s *SagaState) Failed() bool {
+	for _, step := range s.Steps {
+		if step.Status == SagaStepFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// Done returns true if the saga will not change anymore: every step completed
+// or was skipped, a step failed, or the reservation was cancelled.
+func (s *SagaState) Done() bool {
+	if s.Failed() || s.CancellationReason != "" {
+		return true
+	}
+	for _, step := range s.Steps {
+		if step.Status != SagaStepCompleted && step.Status != SagaStepSkipped {
+			return false
+		}
+	}
+	return true
+}
+
+// complete marks the step and all earlier unfinished steps as completed and starts the next step.
+// Earlier steps are included because events of different topics may arrive out of order.
+func (s *SagaState) complete(step SagaStep, now time.Time) {
+	s.finish(step, SagaStepCompleted, now)
+}
+
+// skip marks a step as skipped, e.g. the payment of a reservation paid through a sales channel.
+func (s *SagaState) skip(step SagaStep, now time.Time) {
+	s.finish(step, SagaStepSkipped, now)
+}
+
+// finish sets the status of the step, completes the earlier unfinished steps and
+// starts the next pending step.
+func (s *SagaState) finish(step SagaStep, status SagaStepStatus, now time.Time) {
+	for i := range s.Steps {
+		current := &s.Steps[i]
+		if current.Step == step {
+			if current.Status != SagaStepFailed {
+				current.Status = status
+				current.UpdatedAt = now
+			}
+			if i+1 < len(s.Steps) && s.Steps[i+1].Status == SagaStepPending {
+				s.Steps[i+1].Status = SagaStepRunning
+				s.Steps[i+1].UpdatedAt = now
+			}
+			break
+		}
+		if current.Status == SagaStepPending || current.Status == SagaStepRunning {
+			current.Status = SagaStepCompleted
+			current.UpdatedAt = now
+		}
+	}
+	s.UpdatedAt = now
+}
+
+// fail marks the step as failed with the error message.
+func (s *SagaState) fail(step SagaStep, errorMsg string, now time.Time) {
+	for i := range s.Steps {
+		if s.Steps[i].Step == step {
+			s.Steps[i].Status = SagaStepFailed
+			s.Steps[i].Error = errorMsg
+			s.Steps[i].UpdatedAt = now
+		}
+	}
+	s.UpdatedAt = now
+}
+
+// cancel records the cancellation of the reservation. After a failed step,
+// the cancellation is the compensation of the reservation step.
+func (s *SagaState) cancel(reason string, now time.Time) {
+	s.CancellationReason = reason
+	if s.Failed() {
+		for i := range s.Steps {
+			if s.Steps[i].Step == SagaStepReservation {
+				s.Steps[i].Status = SagaStepCompensated
+				s.Steps[i].UpdatedAt = now
+			}
+		}
+	}
+	s.UpdatedAt = now
+}
+
+// NotificationType identifies the template of a guest notification.
+type NotificationType string
+
+const (
+	NotificationConfirmation    NotificationType = "confirmation"
+	NotificationCancellation    NotificationType = "cancellation"
+	NotificationNoShow          NotificationType = "no_show"
+	NotificationBalanceReminder NotificationType = "balance_reminder"
+	NotificationPreArrival      NotificationType = "pre_arrival"
+	NotificationReviewRequest   NotificationType = "review_request"
+)
+
+// ErrUnknownNotificationType is returned for a notification type without a template.
+var ErrUnknownNotificationType = errors.New("unknown notification type, expected confirmation, cancellation, no_show, balance_reminder, pre_arrival or review_request")
+
+// ParseNotificationType parses the type of a reservation notification.
+func ParseNotificationType(s string) (NotificationType, error) {
+	switch notificationType := NotificationType(s); notificationType {
+	case NotificationConfirmation, NotificationCancellation, NotificationNoShow, NotificationBalanceReminder, NotificationPreArrival, NotificationReviewRequest:
+		return notificationType, nil
+	default:
+		return "", ErrUnknownNotificationType
+	}
+}
+
+// NotificationData is the input of a notification template.
+type NotificationData struct {
+	Reservation  *reservation.Reservation
+	Reason       string       // Cancellation reason of cancellation notices
+	Fee          shared.Money // Retained fee of no-show notices
+	Instructions string       // Check-in instructions of pre-arrival reminders
+	UpsellURL    string       // Link to the extras of the stay in pre-arrival reminders
+	ReviewURL    string       // Link to the review form in review requests
+}
+
+// Notification is a guest notification rendered from its template. Fields holds
+// the named values the template was rendered with, i.e. the data contract that
+// template authors write against.
+type Notification struct {
+	Type    NotificationType  `json:"type"`
+	Locale  string            `json:"locale"`
+	To      string            `json:"to"`
+	Subject string            `json:"subject"`
+	Body    string            `json:"body"`
+	Fields  map[string]string `json:"fields"`
+}
+
+// NotificationReceipt is the type of payment receipts. Receipts are rendered by the
+// notification service itself, so ParseNotificationType does not accept it.
+const NotificationReceipt NotificationType = "receipt"
+
+// NotificationJobID uniquely identifies the delivery of a notification.
+type NotificationJobID string
+
+// NotificationStatus is the delivery status of a notification.
+type NotificationStatus string
+
+const (
+	NotificationQueued NotificationStatus = "queued"
+	NotificationSent   NotificationStatus = "sent"
+	NotificationFailed NotificationStatus = "failed"
+)
+
+// NotificationJob records the delivery of a guest notification, so failed
+// deliveries are retried instead of being dropped. It holds the input of the
+// notification; the reservation or payment is loaded again for a retry.
+type NotificationJob struct {
+	ID            NotificationJobID    `json:"id"`
+	ReservationID shared.ReservationID `json:"reservation_id"`
+	Type          NotificationType     `json:"type"`
+	PaymentID     payment.PaymentID    `json:"payment_id,omitempty"` // Payment of receipts
+	Reason        string               `json:"reason,omitempty"`     // Cancellation reason of cancellation notices
+	Fee           shared.Money         `json:"fee"`                  // Retained fee of no-show notices
+	Status        NotificationStatus   `json:"status"`
+	Attempts      int                  `json:"attempts"`
+	LastError     string               `json:"last_error,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+// NewNotificationJob creates a queued notification job.
+// The ID is derived from the reservation and type, so sending the same
+// notification again updates its job instead of adding another one.
+func NewNotificationJob(reservationID shared.ReservationID, notificationType NotificationType) *NotificationJob {
+	now := time.Now()
+	return &NotificationJob{
+		ID:            NotificationJobID(string(reservationID) + ":" + string(notificationType)),
+		ReservationID: reservationID,
+		Type:          notificationType,
+		Status:        NotificationQueued,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
@@ -6,10 +6,8 @@ import (
 	"fmt"
 
 	"github.com/andygeiss/cloud-native-utils/messaging"
-	"github.com/andygeiss/cloud-native-utils/service"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
-	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
 // EventHandlers manages cross-context event subscriptions.
@@ -34,54 +32,106 @@ func NewEventHandlers(
 }
 
 // RegisterHandlers registers all cross-context event subscriptions with the dispatcher.
+// The handlers keep the values of the message context, e.g. the correlation ID of the
+// booking, so the events they publish belong to the same trail. They ignore its
+// cancellation, so a shutdown does not abort a saga step halfway.
 func (h *EventHandlers) RegisterHandlers(ctx context.Context, dispatcher messaging.Dispatcher) error {
 	// Payment context subscribes to reservation.created
 	// When a reservation is created, initiate payment authorization
-	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCreated, service.Wrap(h.handleReservationCreated)); err != nil {
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCreated, h.handleReservationCreated); err != nil {
 		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCreated, err)
 	}
 
 	// Orchestration subscribes to payment.authorized
 	// When payment is authorized, capture it
-	if err := dispatcher.Subscribe(ctx, payment.EventTopicAuthorized, service.Wrap(h.handlePaymentAuthorized)); err != nil {
+	if err := dispatcher.Subscribe(ctx, payment.EventTopicAuthorized, h.handlePaymentAuthorized); err != nil {
 		return fmt.Errorf("failed to subscribe to %s: %w", payment.EventTopicAuthorized, err)
 	}
 
 	// Reservation context subscribes to payment.captured
 	// When payment is captured, confirm the reservation
-	if err := dispatcher.Subscribe(ctx, payment.EventTopicCaptured, service.Wrap(h.handlePaymentCaptured)); err != nil {
+	if err := dispatcher.Subscribe(ctx, payment.EventTopicCaptured, h.handlePaymentCaptured); err != nil {
 		return fmt.Errorf("failed to subscribe to %s: %w", payment.EventTopicCaptured, err)
 	}
 
 	// Orchestration subscribes to payment.failed
 	// When payment fails, cancel the reservation as compensation
-	if err := dispatcher.Subscribe(ctx, payment.EventTopicFailed, service.Wrap(h.handlePaymentFailed)); err != nil {
+	if err := dispatcher.Subscribe(ctx, payment.EventTopicFailed, h.handlePaymentFailed); err != nil {
 		return fmt.Errorf("failed to subscribe to %s: %w", payment.EventTopicFailed, err)
 	}
 
+	// Orchestration subscribes to reservation.room_blocked
+	// When a booked room is blocked, cancel and refund the displaced reservations
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicRoomBlocked, h.handleRoomBlocked); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicRoomBlocked, err)
+	}
+
+	// Reservation context subscribes to payment.disputed
+	// When the guest's card issuer disputes a payment, flag the reservation
+	if err := dispatcher.Subscribe(ctx, payment.EventTopicDisputed, h.handlePaymentDisputed); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", payment.EventTopicDisputed, err)
+	}
+
+	// Reservation context subscribes to payment.dispute_resolved
+	// When the issuer decides the dispute, remove the flag
+	if err := dispatcher.Subscribe(ctx, payment.EventTopicDisputeResolved, h.handleDisputeResolved); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", payment.EventTopicDisputeResolved, err)
+	}
+
 	return nil
 }
 
 // handleReservationCreated processes reservation.created events.
 // It triggers payment authorization in the payment context.
-func (h *EventHandlers) handleReservationCreated(msg messaging.Message) (messaging.MessageState, error) {
+func (h *EventHandlers) handleReservationCreated(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
 	var evt reservation.EventCreated
 	if err := json.Unmarshal(msg.Data, &evt); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
 	}
 
-	ctx := context.Background()
+	// Reservations imported from a sales channel were paid through the channel
+	if evt.Channel != "" {
+		return messaging.MessageStateCompleted, nil
+	}
 
-	// Generate a payment ID based on the reservation ID
-	paymentID := payment.PaymentID(fmt.Sprintf("pay-%s", evt.ReservationID))
+	ctx = context.WithoutCancel(ctx)
+
+	// Keep the payment of a redelivered event, and identify the command
+	// by its event, so a redelivered event does not charge the guest twice
+	paymentID := h.bookingService.bookingPaymentID(ctx, evt.ReservationID.Shared())
+	commandID := payment.CommandID(fmt.Sprintf("%s/%s", reservation.EventTopicCreated, evt.ReservationID))
+
+	// Never charge an amount other than the stored amount due at booking,
+	// which is the deposit for reservations with a payment schedule
+	amount := evt.AmountDueAtBooking()
+	if err := h.bookingService.VerifyPaymentAmount(ctx, SagaStepAuthorization, paymentID, evt.ReservationID.Shared(), amount); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to authorize payment: %w", err)
+	}
+
+	// Charge the guest's stored payment method, if there is one
+	method := paymentMethodFor(ctx, h.paymentService, evt.GuestID)
+
+	// Pick the saga mode once, so the later steps run the same way.
+	// In synchronous saga mode, run all payment steps right here
+	synchronous, err := h.bookingService.startSaga(ctx, evt.ReservationID.Shared())
+	if err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to start saga: %w", err)
+	}
+	if synchronous {
+		if _, err := h.bookingService.ProcessPayment(ctx, commandID, paymentID, evt.ReservationID.Shared(), amount, method); err != nil {
+			return messaging.MessageStateFailed, fmt.Errorf("failed to process payment: %w", err)
+		}
+		return messaging.MessageStateCompleted, nil
+	}
 
 	// Authorize payment for the reservation
-	_, err := h.paymentService.AuthorizePaymentForReservation(
+	_, err = h.paymentService.AuthorizePaymentForReservation(
 		ctx,
+		commandID,
 		paymentID,
-		shared.ReservationID(evt.ReservationID),
-		evt.TotalAmount,
-		"default", // Payment method - could be passed in event
+		payment.ToReservationID(evt.ReservationID.Shared()),
+		amount,
+		method,
 	)
 	if err != nil {
 		// The payment service already publishes payment.failed event
@@ -94,16 +144,21 @@ func (h *EventHandlers) handleReservationCreated(msg messaging.Message) (messagi
 
 // handlePaymentAuthorized processes payment.authorized events.
 // It triggers payment capture.
-func (h *EventHandlers) handlePaymentAuthorized(msg messaging.Message) (messaging.MessageState, error) {
+func (h *EventHandlers) handlePaymentAuthorized(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
 	var evt payment.EventAuthorized
 	if err := json.Unmarshal(msg.Data, &evt); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
 	}
 
-	ctx := context.Background()
+	ctx = context.WithoutCancel(ctx)
+
+	// In synchronous saga mode, ProcessPayment already captures the payment
+	if h.bookingService.synchronousSaga(ctx, evt.ReservationID.Shared()) {
+		return messaging.MessageStateCompleted, nil
+	}
 
 	// Capture the authorized payment
-	if err := h.bookingService.OnPaymentAuthorized(ctx, evt.PaymentID, evt.ReservationID); err != nil {
+	if err := h.bookingService.OnPaymentAuthorized(ctx, evt.PaymentID, evt.ReservationID.Shared()); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to handle payment authorized: %w", err)
 	}
 
@@ -112,16 +167,21 @@ func (h *EventHandlers) handlePaymentAuthorized(msg messaging.Message) (messagin
 
 // handlePaymentCaptured processes payment.captured events.
 // It triggers reservation confirmation.
-func (h *EventHandlers) handlePaymentCaptured(msg messaging.Message) (messaging.MessageState, error) {
+func (h *EventHandlers) handlePaymentCaptured(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
 	var evt payment.EventCaptured
 	if err := json.Unmarshal(msg.Data, &evt); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
 	}
 
-	ctx := context.Background()
+	ctx = context.WithoutCancel(ctx)
+
+	// In synchronous saga mode, ProcessPayment already confirms the reservation
+	if h.bookingService.synchronousSaga(ctx, evt.ReservationID.Shared()) {
+		return messaging.MessageStateCompleted, nil
+	}
 
 	// Confirm the reservation
-	if err := h.bookingService.OnPaymentCaptured(ctx, evt.ReservationID); err != nil {
+	if err := h.bookingService.OnPaymentCaptured(ctx, evt.PaymentID, evt.ReservationID.Shared(), evt.Amount); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to confirm reservation: %w", err)
 	}
 
@@ -130,19 +190,71 @@ func (h *EventHandlers) handlePaymentCaptured(msg messaging.Message) (messaging.
 
 // handlePaymentFailed processes payment.failed events.
 // It triggers reservation cancellation as compensation.
-func (h *EventHandlers) handlePaymentFailed(msg messaging.Message) (messaging.MessageState, error) {
+func (h *EventHandlers) handlePaymentFailed(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
 	var evt payment.EventFailed
 	if err := json.Unmarshal(msg.Data, &evt); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
 	}
 
-	ctx := context.Background()
+	ctx = context.WithoutCancel(ctx)
+
+	// In synchronous saga mode, ProcessPayment already compensates failed payments
+	if h.bookingService.synchronousSaga(ctx, evt.ReservationID.Shared()) {
+		return messaging.MessageStateCompleted, nil
+	}
 
 	// Cancel the reservation as compensation
 	reason := fmt.Sprintf("payment_failed: %s - %s", evt.ErrorCode, evt.ErrorMsg)
-	if err := h.bookingService.OnPaymentFailed(ctx, evt.ReservationID, reason); err != nil {
+	if err := h.bookingService.OnPaymentFailed(ctx, evt.ReservationID.Shared(), reason); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to cancel reservation: %w", err)
 	}
 
 	return messaging.MessageStateCompleted, nil
 }
+
+// handleRoomBlocked processes reservation.room_blocked events.
+// It cancels and refunds the reservations booked before the room was blocked.
+// A redelivered event finds them cancelled and does nothing.
+func (h *EventHandlers) handleRoomBlocked(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventRoomBlocked
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	dateRange := reservation.NewDateRange(evt.CheckIn, evt.CheckOut)
+	if err := h.bookingService.OnRoomBlocked(context.WithoutCancel(ctx), evt.RoomID, dateRange, evt.Reason); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to displace reservations: %w", err)
+	}
+
+	return messaging.MessageStateCompleted, nil
+}
+
+// handlePaymentDisputed processes payment.disputed events.
+// It flags the reservation, while the payment service refuses refunds of the payment.
+func (h *EventHandlers) handlePaymentDisputed(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	var evt payment.EventDisputed
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	if err := h.reservationService.MarkDisputed(context.WithoutCancel(ctx), reservation.ToReservationID(evt.ReservationID.Shared())); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to flag disputed reservation: %w", err)
+	}
+
+	return messaging.MessageStateCompleted, nil
+}
+
+// handleDisputeResolved processes payment.dispute_resolved events.
+// It removes the dispute flag from the reservation.
+func (h *EventHandlers) handleDisputeResolved(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	var evt payment.EventDisputeResolved
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	if err := h.reservationService.ClearDisputed(context.WithoutCancel(ctx), reservation.ToReservationID(evt.ReservationID.Shared())); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to clear dispute flag: %w", err)
+	}
+
+	return messaging.MessageStateCompleted, nil
+}
@@ -121,7 +121,7 @@ func eventHandlerValidDateRange() reservation.DateRange {
 
 func eventHandlerValidGuests() []reservation.GuestInfo {
 	return []reservation.GuestInfo{
-		reservation.NewGuestInfo("John Doe", "john@example.com", "+1234567890"),
+		{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+1234567890"},
 	}
 }
 
@@ -147,6 +147,9 @@ func Test_EventHandlers_RegisterHandlers_Should_Subscribe_To_All_Topics(t *testi
 	assert.That(t, "must subscribe to payment.authorized", len(svc.dispatcher.subscriptions[payment.EventTopicAuthorized]), 1)
 	assert.That(t, "must subscribe to payment.captured", len(svc.dispatcher.subscriptions[payment.EventTopicCaptured]), 1)
 	assert.That(t, "must subscribe to payment.failed", len(svc.dispatcher.subscriptions[payment.EventTopicFailed]), 1)
+	assert.That(t, "must subscribe to reservation.room_blocked", len(svc.dispatcher.subscriptions[reservation.EventTopicRoomBlocked]), 1)
+	assert.That(t, "must subscribe to payment.disputed", len(svc.dispatcher.subscriptions[payment.EventTopicDisputed]), 1)
+	assert.That(t, "must subscribe to payment.dispute_resolved", len(svc.dispatcher.subscriptions[payment.EventTopicDisputeResolved]), 1)
 }
 
 // ============================================================================
@@ -158,6 +161,10 @@ func Test_HandleReservationCreated_Should_Parse_Event(t *testing.T) {
 	svc := createEventHandlerTestServices()
 	ctx := context.Background()
 	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
 
 	dateRange := eventHandlerValidDateRange()
 	evt := reservation.EventCreated{
@@ -183,6 +190,10 @@ func Test_HandleReservationCreated_Should_Trigger_Payment_Authorization(t *testi
 	svc := createEventHandlerTestServices()
 	ctx := context.Background()
 	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
 
 	dateRange := eventHandlerValidDateRange()
 	evt := reservation.EventCreated{
@@ -199,12 +210,64 @@ func Test_HandleReservationCreated_Should_Trigger_Payment_Authorization(t *testi
 	_, _ = svc.dispatcher.triggerEvent(reservation.EventTopicCreated, data)
 
 	// Assert
-	paymentID := payment.PaymentID("pay-res-001")
-	storedPayment, err := svc.paymentRepo.Read(ctx, paymentID)
+	storedPayment, err := svc.paymentService.GetPaymentByReservation(ctx, "res-001")
 	assert.That(t, "payment must exist", err == nil, true)
 	assert.That(t, "payment must be authorized", storedPayment.Status, payment.StatusAuthorized)
 }
 
+func Test_HandleReservationCreated_With_Mismatched_Amount_Should_Not_Authorize_Payment(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	publisher := &mockEventPublisher{}
+	svc.bookingService.WithEventPublisher(publisher)
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+	evt := reservation.EventCreated{ReservationID: "res-001", TotalAmount: shared.NewMoney(1, "USD")}
+	data, _ := json.Marshal(evt)
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(reservation.EventTopicCreated, data)
+
+	// Assert
+	assert.That(t, "error must be ErrPaymentMismatch", errors.Is(err, reservation.ErrPaymentMismatch), true)
+	assert.That(t, "state must be failed", state, messaging.MessageStateFailed)
+	assert.That(t, "gateway must not authorize", svc.paymentGateway.authorizeCalls, 0)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "topic must be booking.payment_discrepancy", publisher.published[0].Topic(), orchestration.EventTopicPaymentDiscrepancy)
+}
+
+func Test_HandleReservationCreated_From_Channel_Should_Skip_Payment(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+
+	dateRange := eventHandlerValidDateRange()
+	evt := reservation.EventCreated{
+		ReservationID: "booking.com-4711",
+		GuestID:       "guest-001",
+		RoomID:        "room-101",
+		CheckIn:       dateRange.CheckIn,
+		CheckOut:      dateRange.CheckOut,
+		TotalAmount:   eventHandlerValidMoney(),
+		Channel:       "booking.com",
+	}
+	data, _ := json.Marshal(evt)
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(reservation.EventTopicCreated, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	_, err = svc.paymentRepo.Read(ctx, payment.PaymentID("pay-booking.com-4711"))
+	assert.That(t, "payment must not exist", err != nil, true)
+}
+
 func Test_HandleReservationCreated_With_Invalid_JSON_Should_Return_Failed(t *testing.T) {
 	// Arrange
 	svc := createEventHandlerTestServices()
@@ -221,6 +284,157 @@ func Test_HandleReservationCreated_With_Invalid_JSON_Should_Return_Failed(t *tes
 	assert.That(t, "state must be failed", state, messaging.MessageStateFailed)
 }
 
+func Test_HandleReservationCreated_In_Synchronous_Saga_Mode_Should_Confirm_Reservation(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	svc.bookingService.WithFeatureFlags(shared.StaticFeatureFlags{shared.FlagSynchronousSaga: true})
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+
+	reservationID := shared.ReservationID("res-001")
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, reservation.ToReservationID(reservationID), "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+	evt := reservation.EventCreated{ReservationID: reservation.ToReservationID(reservationID), TotalAmount: eventHandlerValidMoney()}
+	data, _ := json.Marshal(evt)
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(reservation.EventTopicCreated, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	storedPayment, _ := svc.paymentService.GetPaymentByReservation(ctx, "res-001")
+	assert.That(t, "payment must be captured", storedPayment.Status, payment.StatusCaptured)
+	storedRes, _ := svc.reservationRepo.Read(ctx, reservation.ToReservationID(reservationID))
+	assert.That(t, "reservation must be confirmed", storedRes.Status, reservation.StatusConfirmed)
+}
+
+func Test_HandleReservationCreated_Should_Use_Injected_IDGenerator(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	svc.bookingService.WithIDGenerator(fixedIDGenerator{id: "018f3c2a-0000-7000-8000-000000000001"})
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+	evt := reservation.EventCreated{ReservationID: "res-001", TotalAmount: eventHandlerValidMoney()}
+	data, _ := json.Marshal(evt)
+
+	// Act
+	_, _ = svc.dispatcher.triggerEvent(reservation.EventTopicCreated, data)
+
+	// Assert
+	storedPayment, err := svc.paymentRepo.Read(ctx, "018f3c2a-0000-7000-8000-000000000001")
+	assert.That(t, "payment must be stored under the generated ID", err == nil, true)
+	assert.That(t, "payment must belong to the reservation", storedPayment.ReservationID, payment.ReservationID("res-001"))
+}
+
+func Test_HandleReservationCreated_When_Redelivered_Should_Charge_Once(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+
+	evt := reservation.EventCreated{ReservationID: "res-001", TotalAmount: eventHandlerValidMoney()}
+	data, _ := json.Marshal(evt)
+	_, _ = svc.dispatcher.triggerEvent(reservation.EventTopicCreated, data)
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(reservation.EventTopicCreated, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	assert.That(t, "gateway must authorize once", svc.paymentGateway.authorizeCalls, 1)
+}
+
+func Test_HandleReservationCreated_In_Synchronous_Saga_Mode_When_Redelivered_Should_Charge_Once(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	svc.bookingService.WithFeatureFlags(shared.StaticFeatureFlags{shared.FlagSynchronousSaga: true})
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+
+	reservationID := shared.ReservationID("res-001")
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, reservation.ToReservationID(reservationID), "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+	evt := reservation.EventCreated{ReservationID: reservation.ToReservationID(reservationID), TotalAmount: eventHandlerValidMoney()}
+	data, _ := json.Marshal(evt)
+	_, _ = svc.dispatcher.triggerEvent(reservation.EventTopicCreated, data)
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(reservation.EventTopicCreated, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	assert.That(t, "gateway must authorize once", svc.paymentGateway.authorizeCalls, 1)
+	assert.That(t, "gateway must capture once", svc.paymentGateway.captureCalls, 1)
+}
+
+func Test_HandlePaymentAuthorized_In_Synchronous_Saga_Mode_Should_Skip_Capture(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	svc.bookingService.WithFeatureFlags(shared.StaticFeatureFlags{shared.FlagSynchronousSaga: true})
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+
+	reservationID := shared.ReservationID("res-001")
+	paymentID := payment.PaymentID("pay-001")
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, reservation.ToReservationID(reservationID), "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+	_, _ = svc.paymentService.AuthorizePayment(ctx, paymentID, payment.ToReservationID(reservationID), eventHandlerValidMoney(), "credit_card")
+	data, _ := json.Marshal(payment.EventAuthorized{PaymentID: paymentID, ReservationID: payment.ToReservationID(reservationID)})
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(payment.EventTopicAuthorized, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, paymentID)
+	assert.That(t, "payment must stay authorized", storedPayment.Status, payment.StatusAuthorized)
+}
+
+func Test_HandlePaymentAuthorized_When_Flag_Switched_After_Saga_Started_Should_Capture_Payment(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	flags := shared.StaticFeatureFlags{shared.FlagSynchronousSaga: false}
+	svc.bookingService.WithFeatureFlags(flags)
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+	created, _ := json.Marshal(reservation.EventCreated{ReservationID: "res-001", TotalAmount: eventHandlerValidMoney()})
+	_, _ = svc.dispatcher.triggerEvent(reservation.EventTopicCreated, created)
+	pay, _ := svc.paymentService.GetPaymentByReservation(ctx, "res-001")
+	flags[shared.FlagSynchronousSaga] = true
+	data, _ := json.Marshal(payment.EventAuthorized{PaymentID: pay.ID, ReservationID: "res-001"})
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(payment.EventTopicAuthorized, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, pay.ID)
+	assert.That(t, "payment must be captured in the mode the saga started in", storedPayment.Status, payment.StatusCaptured)
+}
+
 // ============================================================================
 // HandlePaymentAuthorized Tests
 // ============================================================================
@@ -236,14 +450,14 @@ func Test_HandlePaymentAuthorized_Should_Parse_Event(t *testing.T) {
 
 	// Setup: create reservation and authorize payment
 	_, _ = svc.reservationService.CreateReservation(
-		ctx, reservationID, "guest-001", "room-101",
+		ctx, reservation.ToReservationID(reservationID), "guest-001", "room-101",
 		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
 	)
-	_, _ = svc.paymentService.AuthorizePayment(ctx, paymentID, reservationID, eventHandlerValidMoney(), "credit_card")
+	_, _ = svc.paymentService.AuthorizePayment(ctx, paymentID, payment.ToReservationID(reservationID), eventHandlerValidMoney(), "credit_card")
 
 	evt := payment.EventAuthorized{
 		PaymentID:     paymentID,
-		ReservationID: reservationID,
+		ReservationID: payment.ToReservationID(reservationID),
 		Amount:        eventHandlerValidMoney(),
 		TransactionID: "tx-12345",
 	}
@@ -268,14 +482,14 @@ func Test_HandlePaymentAuthorized_Should_Capture_Payment(t *testing.T) {
 
 	// Setup
 	_, _ = svc.reservationService.CreateReservation(
-		ctx, reservationID, "guest-001", "room-101",
+		ctx, reservation.ToReservationID(reservationID), "guest-001", "room-101",
 		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
 	)
-	_, _ = svc.paymentService.AuthorizePayment(ctx, paymentID, reservationID, eventHandlerValidMoney(), "credit_card")
+	_, _ = svc.paymentService.AuthorizePayment(ctx, paymentID, payment.ToReservationID(reservationID), eventHandlerValidMoney(), "credit_card")
 
 	evt := payment.EventAuthorized{
 		PaymentID:     paymentID,
-		ReservationID: reservationID,
+		ReservationID: payment.ToReservationID(reservationID),
 		Amount:        eventHandlerValidMoney(),
 		TransactionID: "tx-12345",
 	}
@@ -303,13 +517,13 @@ func Test_HandlePaymentCaptured_Should_Parse_Event(t *testing.T) {
 
 	// Setup: create reservation
 	_, _ = svc.reservationService.CreateReservation(
-		ctx, reservationID, "guest-001", "room-101",
+		ctx, reservation.ToReservationID(reservationID), "guest-001", "room-101",
 		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
 	)
 
 	evt := payment.EventCaptured{
 		PaymentID:     "pay-001",
-		ReservationID: reservationID,
+		ReservationID: payment.ToReservationID(reservationID),
 		Amount:        eventHandlerValidMoney(),
 	}
 	data, _ := json.Marshal(evt)
@@ -332,13 +546,13 @@ func Test_HandlePaymentCaptured_Should_Confirm_Reservation(t *testing.T) {
 
 	// Setup
 	_, _ = svc.reservationService.CreateReservation(
-		ctx, reservationID, "guest-001", "room-101",
+		ctx, reservation.ToReservationID(reservationID), "guest-001", "room-101",
 		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
 	)
 
 	evt := payment.EventCaptured{
 		PaymentID:     "pay-001",
-		ReservationID: reservationID,
+		ReservationID: payment.ToReservationID(reservationID),
 		Amount:        eventHandlerValidMoney(),
 	}
 	data, _ := json.Marshal(evt)
@@ -347,7 +561,7 @@ func Test_HandlePaymentCaptured_Should_Confirm_Reservation(t *testing.T) {
 	_, _ = svc.dispatcher.triggerEvent(payment.EventTopicCaptured, data)
 
 	// Assert
-	storedRes, _ := svc.reservationRepo.Read(ctx, reservationID)
+	storedRes, _ := svc.reservationRepo.Read(ctx, reservation.ToReservationID(reservationID))
 	assert.That(t, "reservation must be confirmed", storedRes.Status, reservation.StatusConfirmed)
 }
 
@@ -365,13 +579,13 @@ func Test_HandlePaymentFailed_Should_Parse_Event(t *testing.T) {
 
 	// Setup: create reservation
 	_, _ = svc.reservationService.CreateReservation(
-		ctx, reservationID, "guest-001", "room-101",
+		ctx, reservation.ToReservationID(reservationID), "guest-001", "room-101",
 		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
 	)
 
 	evt := payment.EventFailed{
 		PaymentID:     "pay-001",
-		ReservationID: reservationID,
+		ReservationID: payment.ToReservationID(reservationID),
 		ErrorCode:     "declined",
 		ErrorMsg:      "Card declined",
 	}
@@ -395,13 +609,13 @@ func Test_HandlePaymentFailed_Should_Cancel_Reservation(t *testing.T) {
 
 	// Setup
 	_, _ = svc.reservationService.CreateReservation(
-		ctx, reservationID, "guest-001", "room-101",
+		ctx, reservation.ToReservationID(reservationID), "guest-001", "room-101",
 		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
 	)
 
 	evt := payment.EventFailed{
 		PaymentID:     "pay-001",
-		ReservationID: reservationID,
+		ReservationID: payment.ToReservationID(reservationID),
 		ErrorCode:     "declined",
 		ErrorMsg:      "Card declined",
 	}
@@ -411,7 +625,7 @@ func Test_HandlePaymentFailed_Should_Cancel_Reservation(t *testing.T) {
 	_, _ = svc.dispatcher.triggerEvent(payment.EventTopicFailed, data)
 
 	// Assert
-	storedRes, _ := svc.reservationRepo.Read(ctx, reservationID)
+	storedRes, _ := svc.reservationRepo.Read(ctx, reservation.ToReservationID(reservationID))
 	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
 }
 
@@ -431,6 +645,181 @@ func Test_HandlePaymentFailed_With_Invalid_JSON_Should_Return_Failed(t *testing.
 	assert.That(t, "state must be failed", state, messaging.MessageStateFailed)
 }
 
+// ============================================================================
+// HandleRoomBlocked Tests
+// ============================================================================
+
+func Test_HandleRoomBlocked_Should_Cancel_Overlapping_Reservation(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+
+	res, _ := svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+	svc.availabilityCheck.overlapping = []*reservation.Reservation{res}
+
+	evt := reservation.NewEventRoomBlocked().
+		WithBlockID("block-001").
+		WithRoomID("room-101").
+		WithCheckIn(res.DateRange.CheckIn).
+		WithCheckOut(res.DateRange.CheckOut).
+		WithReason(reservation.BlockMaintenance)
+	data, _ := json.Marshal(evt)
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(reservation.EventTopicRoomBlocked, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
+}
+
+func Test_HandleRoomBlocked_With_Invalid_JSON_Should_Return_Failed(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+
+	invalidData := []byte("{not valid json}")
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(reservation.EventTopicRoomBlocked, invalidData)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "state must be failed", state, messaging.MessageStateFailed)
+}
+
+// ============================================================================
+// HandlePaymentDisputed Tests
+// ============================================================================
+
+func Test_HandlePaymentDisputed_Should_Flag_Reservation(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+
+	evt := payment.NewEventDisputed().
+		WithPaymentID("pay-001").
+		WithReservationID("res-001").
+		WithDisputeID("dp-001").
+		WithReason("fraudulent").
+		WithAmount(eventHandlerValidMoney())
+	data, _ := json.Marshal(evt)
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(payment.EventTopicDisputed, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must be flagged", storedRes.Disputed, true)
+}
+
+func Test_HandleDisputeResolved_Should_Clear_Flag(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+	_ = svc.reservationService.MarkDisputed(ctx, "res-001")
+
+	evt := payment.NewEventDisputeResolved().
+		WithPaymentID("pay-001").
+		WithReservationID("res-001").
+		WithDisputeID("dp-001").
+		WithOutcome(payment.DisputeWon)
+	data, _ := json.Marshal(evt)
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(payment.EventTopicDisputeResolved, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must not be flagged", storedRes.Disputed, false)
+}
+
+// ============================================================================
+// Fuzz Tests
+// ============================================================================
+// Kafka delivers arbitrary bytes, so every decode path must reject malformed
+// payloads with a failed state instead of panicking the consumer.
+
+// fuzzEventHandler feeds fuzzed payloads of a topic to its registered handler.
+func fuzzEventHandler(f *testing.F, topic string, valid any) {
+	data, _ := json.Marshal(valid)
+	f.Add(data)
+	f.Add([]byte(`{`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`[]`))
+	f.Add([]byte(`{"reservation_id":42,"total_amount":"x"}`))
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		svc := createEventHandlerTestServices()
+		_ = svc.eventHandlers.RegisterHandlers(context.Background(), svc.dispatcher)
+
+		state, err := svc.dispatcher.triggerEvent(topic, data)
+
+		if err != nil {
+			assert.That(t, "state must be failed on error", state, messaging.MessageStateFailed)
+		}
+	})
+}
+
+func Fuzz_HandleReservationCreated(f *testing.F) {
+	dateRange := eventHandlerValidDateRange()
+	fuzzEventHandler(f, reservation.EventTopicCreated, reservation.EventCreated{
+		ReservationID: "res-001",
+		GuestID:       "guest-001",
+		RoomID:        "room-101",
+		CheckIn:       dateRange.CheckIn,
+		CheckOut:      dateRange.CheckOut,
+		TotalAmount:   eventHandlerValidMoney(),
+	})
+}
+
+func Fuzz_HandlePaymentAuthorized(f *testing.F) {
+	fuzzEventHandler(f, payment.EventTopicAuthorized, payment.EventAuthorized{
+		PaymentID:     "pay-001",
+		ReservationID: "res-001",
+		TransactionID: "tx-12345",
+		Amount:        eventHandlerValidMoney(),
+	})
+}
+
+func Fuzz_HandlePaymentCaptured(f *testing.F) {
+	fuzzEventHandler(f, payment.EventTopicCaptured, payment.EventCaptured{
+		PaymentID:     "pay-001",
+		ReservationID: "res-001",
+		Amount:        eventHandlerValidMoney(),
+	})
+}
+
+func Fuzz_HandlePaymentFailed(f *testing.F) {
+	fuzzEventHandler(f, payment.EventTopicFailed, payment.EventFailed{
+		PaymentID:     "pay-001",
+		ReservationID: "res-001",
+		ErrorCode:     "card_declined",
+		ErrorMsg:      "insufficient funds",
+	})
+}
+
 // ============================================================================
 // Helper mock for event.Event interface check
 // ============================================================================
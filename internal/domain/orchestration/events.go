@@ -0,0 +1,147 @@
+package orchestration
+
+import (
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Event topics for Kafka.
+const (
+	EventTopicCompensationFailed = "booking.compensation_failed"
+	EventTopicRefunded           = "booking.refunded"
+	EventTopicPaymentDiscrepancy = "booking.payment_discrepancy"
+	EventTopicPreArrivalDue      = "booking.pre_arrival_due"
+)
+
+// EventCompensationFailed is published when a compensating action fails.
+// Operators are alerted instead of discovering orphaned payments later.
+type EventCompensationFailed struct {
+	CompensationID CompensationID       `json:"compensation_id"`
+	Action         CompensationAction   `json:"action"`
+	ReservationID  shared.ReservationID `json:"reservation_id"`
+	PaymentID      payment.PaymentID    `json:"payment_id,omitempty"`
+	ErrorMsg       string               `json:"error_msg"`
+}
+
+func NewEventCompensationFailed() *EventCompensationFailed {
+	return &EventCompensationFailed{}
+}
+
+func (e *EventCompensationFailed) Topic() string { return EventTopicCompensationFailed }
+
+func (e *EventCompensationFailed) WithCompensationID(id CompensationID) *EventCompensationFailed {
+	e.CompensationID = id
+	return e
+}
+
+func (e *EventCompensationFailed) WithAction(action CompensationAction) *EventCompensationFailed {
+	e.Action = action
+	return e
+}
+
+func (e *EventCompensationFailed) WithReservationID(id shared.ReservationID) *EventCompensationFailed {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventCompensationFailed) WithPaymentID(id payment.PaymentID) *EventCompensationFailed {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventCompensationFailed) WithErrorMsg(msg string) *EventCompensationFailed {
+	e.ErrorMsg = msg
+	return e
+}
+
+// EventRefunded is published when a cancelled booking has been refunded.
+type EventRefunded struct {
+	ReservationID shared.ReservationID `json:"reservation_id"`
+	PaymentID     payment.PaymentID    `json:"payment_id"`
+	Amount        shared.Money         `json:"amount"`
+	Reason        string               `json:"reason"`
+}
+
+func NewEventRefunded() *EventRefunded {
+	return &EventRefunded{}
+}
+
+func (e *EventRefunded) Topic() string { return EventTopicRefunded }
+
+func (e *EventRefunded) WithReservationID(id shared.ReservationID) *EventRefunded {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventRefunded) WithPaymentID(id payment.PaymentID) *EventRefunded {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventRefunded) WithAmount(m shared.Money) *EventRefunded {
+	e.Amount = m
+	return e
+}
+
+func (e *EventRefunded) WithReason(reason string) *EventRefunded {
+	e.Reason = reason
+	return e
+}
+
+// EventPaymentDiscrepancy is published when a payment amount does not match the
+// reservation total. The saga refuses the step and operators are alerted.
+type EventPaymentDiscrepancy struct {
+	ReservationID shared.ReservationID `json:"reservation_id"`
+	PaymentID     payment.PaymentID    `json:"payment_id"`
+	Step          SagaStep             `json:"step"`
+	Expected      shared.Money         `json:"expected"`
+	Actual        shared.Money         `json:"actual"`
+}
+
+func NewEventPaymentDiscrepancy() *EventPaymentDiscrepancy {
+	return &EventPaymentDiscrepancy{}
+}
+
+func (e *EventPaymentDiscrepancy) Topic() string { return EventTopicPaymentDiscrepancy }
+
+func (e *EventPaymentDiscrepancy) WithReservationID(id shared.ReservationID) *EventPaymentDiscrepancy {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventPaymentDiscrepancy) WithPaymentID(id payment.PaymentID) *EventPaymentDiscrepancy {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventPaymentDiscrepancy) WithStep(step SagaStep) *EventPaymentDiscrepancy {
+	e.Step = step
+	return e
+}
+
+func (e *EventPaymentDiscrepancy) WithExpected(m shared.Money) *EventPaymentDiscrepancy {
+	e.Expected = m
+	return e
+}
+
+func (e *EventPaymentDiscrepancy) WithActual(m shared.Money) *EventPaymentDiscrepancy {
+	e.Actual = m
+	return e
+}
+
+// EventPreArrivalDue is published when a booking is confirmed and delivered when
+// the guest is to be reminded of the stay.
+type EventPreArrivalDue struct {
+	ReservationID shared.ReservationID `json:"reservation_id"`
+}
+
+func NewEventPreArrivalDue() *EventPreArrivalDue {
+	return &EventPreArrivalDue{}
+}
+
+func (e *EventPreArrivalDue) Topic() string { return EventTopicPreArrivalDue }
+
+func (e *EventPreArrivalDue) WithReservationID(id shared.ReservationID) *EventPreArrivalDue {
+	e.ReservationID = id
+	return e
+}
@@ -0,0 +1,136 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// NoShowService records guests who did not arrive. It runs as a scheduled job:
+// confirmed reservations whose check-in date has passed without a check-in are
+// marked as no-show, the no-show fee is kept from the payment and the rest is
+// refunded, and the guest is notified.
+type NoShowService struct {
+	reservationService *reservation.Service
+	paymentService     *payment.Service
+	notifier           NoShowNotifier
+	feeNights          int
+	gracePeriod        time.Duration
+}
+
+// NewNoShowService creates a new no-show service.
+// The fee defaults to the first night, charged a day after the check-in date.
+func NewNoShowService(reservationSvc *reservation.Service, paymentSvc *payment.Service, notifier NoShowNotifier) *NoShowService {
+	return &NoShowService{
+		reservationService: reservationSvc,
+		paymentService:     paymentSvc,
+		notifier:           notifier,
+		feeNights:          1,
+		gracePeriod:        24 * time.Hour,
+	}
+}
+
+// WithFeeNights sets the number of nights charged as no-show fee (default 1, 0 waives the fee).
+func (s *NoShowService) WithFeeNights(nights int) *NoShowService {
+	s.feeNights = nights
+	return s
+}
+
+// WithGracePeriod sets how long after the start of the check-in date a guest may still arrive (default 24h).
+func (s *NoShowService) WithGracePeriod(d time.Duration) *NoShowService {
+	s.gracePeriod = d
+	return s
+}
+
+// Fee returns the no-show fee of a reservation: the price of the fee nights,
+// rounded to the nearest minor unit and at most the total amount.
+func (s *NoShowService) Fee(res *reservation.Reservation) shared.Money {
+	nights := res.Nights()
+	if nights <= 0 {
+		return shared.NewMoney(0, res.TotalAmount.Currency)
+	}
+	// The fee nights are capped at the nights, so the allocation cannot fail.
+	feeNights := min(max(s.feeNights, 0), nights)
+	parts, _ := res.TotalAmount.Allocate(feeNights, nights-feeNights)
+	return parts[0]
+}
+
+// ProcessNoShows marks the overdue reservations as no-show and returns their IDs.
+// External holds are skipped. Channel reservations are marked, but the channel
+// collects their fee and notifies the guest. A reservation that fails is reported
+// in the joined error, while the others are still processed.
+func (s *NoShowService) ProcessNoShows(ctx context.Context, now time.Time) ([]reservation.ReservationID, error) {
+	reservations, err := s.reservationService.ListReservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	marked := []reservation.ReservationID{}
+	var errs []error
+	for _, res := range reservations {
+		if res.Status != reservation.StatusConfirmed || res.IsExternalHold() || now.Before(res.DateRange.CheckIn.Add(s.gracePeriod)) {
+			continue
+		}
+
+		// 1. Release the room; a guest who checks in concurrently wins
+		if err := s.reservationService.MarkNoShow(ctx, res.ID); err != nil {
+			errs = append(errs, fmt.Errorf("reservation %s: %w", res.ID, err))
+			continue
+		}
+		marked = append(marked, res.ID)
+		if res.Channel != "" {
+			continue
+		}
+
+		// 2. Keep the fee and refund the remaining nights
+		fee := s.Fee(res)
+		if err := s.retainFee(ctx, res, fee); err != nil {
+			errs = append(errs, fmt.Errorf("reservation %s: %w", res.ID, err))
+			continue
+		}
+
+		// 3. Notify the guest
+		if err := s.notifier.SendNoShowNotice(ctx, res, fee); err != nil {
+			errs = append(errs, fmt.Errorf("reservation %s: failed to send no-show notice: %w", res.ID, err))
+		}
+	}
+
+	return marked, errors.Join(errs...)
+}
+
+// retainFee keeps the fee from the captured payment of the reservation. With a
+// payment schedule, the fee is kept from the deposit first and the rest from the
+// paid balance, and whatever is not needed for the fee is refunded.
+func (s *NoShowService) retainFee(ctx context.Context, res *reservation.Reservation, fee shared.Money) error {
+	reservationID := payment.ToReservationID(res.ID.Shared())
+	pay, err := s.paymentService.GetPaymentByReservation(ctx, reservationID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	balance, err := s.paymentService.GetBalanceByReservation(ctx, reservationID)
+	if err != nil && !errors.Is(err, payment.ErrPaymentNotFound) {
+		return fmt.Errorf("failed to get balance payment: %w", err)
+	}
+	if balance == nil || balance.Status != payment.StatusCaptured {
+		if _, err := s.paymentService.RetainFee(ctx, pay.ID, fee); err != nil {
+			return fmt.Errorf("failed to retain no-show fee: %w", err)
+		}
+		return nil
+	}
+
+	feeFromPayment := shared.NewMoney(min(fee.Amount, pay.Amount.Amount), fee.Currency)
+	if _, err := s.paymentService.RetainFee(ctx, pay.ID, feeFromPayment); err != nil {
+		return fmt.Errorf("failed to retain no-show fee: %w", err)
+	}
+	feeFromBalance := shared.NewMoney(fee.Amount-feeFromPayment.Amount, fee.Currency)
+	if _, err := s.paymentService.RetainFee(ctx, balance.ID, feeFromBalance); err != nil {
+		return fmt.Errorf("failed to retain no-show fee from balance: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package orchestration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type mockNoShowNotifier struct {
+	fees []shared.Money
+	err  error
+}
+
+func (m *mockNoShowNotifier) SendNoShowNotice(ctx context.Context, r *reservation.Reservation, fee shared.Money) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.fees = append(m.fees, fee)
+	return nil
+}
+
+// seedNoShowReservation stores a confirmed 4-night reservation that started
+// days ago, paid with a captured payment of 400.00 USD.
+func seedNoShowReservation(svc *testServices, id string, checkInDaysAgo int) {
+	checkIn := time.Now().AddDate(0, 0, -checkInDaysAgo).Truncate(24 * time.Hour)
+	svc.reservationRepo.reservations[reservation.ReservationID(id)] = reservation.Reservation{
+		ID:          reservation.ReservationID(id),
+		GuestID:     "guest-001",
+		RoomID:      "room-101",
+		DateRange:   reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 4)),
+		Status:      reservation.StatusConfirmed,
+		TotalAmount: shared.NewMoney(40000, "USD"),
+		Guests:      validBookingGuests(),
+	}
+	paymentID := payment.PaymentID("pay-" + id)
+	svc.paymentRepo.payments[paymentID] = payment.Payment{
+		ID:            paymentID,
+		ReservationID: payment.ReservationID(id),
+		Amount:        shared.NewMoney(40000, "USD"),
+		Status:        payment.StatusCaptured,
+		TransactionID: "tx-" + id,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// ============================================================================
+// ProcessNoShows Tests
+// ============================================================================
+
+func Test_NoShowService_ProcessNoShows_With_Missed_CheckIn_Should_Mark_Reservation(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedNoShowReservation(svc, "res-001", 2)
+	notifier := &mockNoShowNotifier{}
+	noShows := orchestration.NewNoShowService(svc.reservationService, svc.paymentService, notifier)
+
+	// Act
+	marked, err := noShows.ProcessNoShows(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "one reservation must be marked", len(marked), 1)
+	res := svc.reservationRepo.reservations["res-001"]
+	assert.That(t, "status must be no-show", res.Status, reservation.StatusNoShow)
+}
+
+func Test_NoShowService_ProcessNoShows_Should_Retain_First_Night_And_Refund_Rest(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedNoShowReservation(svc, "res-001", 2)
+	notifier := &mockNoShowNotifier{}
+	noShows := orchestration.NewNoShowService(svc.reservationService, svc.paymentService, notifier)
+
+	// Act
+	_, err := noShows.ProcessNoShows(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	pay := svc.paymentRepo.payments["pay-res-001"]
+	assert.That(t, "payment must be refunded", pay.Status, payment.StatusRefunded)
+	assert.That(t, "three of four nights must be refunded", pay.RefundedAmount, shared.NewMoney(30000, "USD"))
+	assert.That(t, "guest must be notified with the fee", notifier.fees, []shared.Money{shared.NewMoney(10000, "USD")})
+}
+
+func Test_NoShowService_ProcessNoShows_Within_Grace_Period_Should_Skip_Reservation(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedNoShowReservation(svc, "res-001", 0)
+	notifier := &mockNoShowNotifier{}
+	noShows := orchestration.NewNoShowService(svc.reservationService, svc.paymentService, notifier)
+
+	// Act
+	marked, err := noShows.ProcessNoShows(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "no reservation must be marked", len(marked), 0)
+	res := svc.reservationRepo.reservations["res-001"]
+	assert.That(t, "status must remain confirmed", res.Status, reservation.StatusConfirmed)
+}
+
+func Test_NoShowService_ProcessNoShows_With_Channel_Reservation_Should_Skip_Fee(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedNoShowReservation(svc, "res-001", 2)
+	res := svc.reservationRepo.reservations["res-001"]
+	res.Channel = "booking.com"
+	svc.reservationRepo.reservations["res-001"] = res
+	notifier := &mockNoShowNotifier{}
+	noShows := orchestration.NewNoShowService(svc.reservationService, svc.paymentService, notifier)
+
+	// Act
+	marked, err := noShows.ProcessNoShows(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "reservation must be marked", len(marked), 1)
+	assert.That(t, "payment must remain captured", svc.paymentRepo.payments["pay-res-001"].Status, payment.StatusCaptured)
+	assert.That(t, "guest must not be notified", len(notifier.fees), 0)
+}
+
+func Test_NoShowService_ProcessNoShows_When_Refund_Fails_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedNoShowReservation(svc, "res-001", 2)
+	svc.paymentGateway.refundErr = errors.New("gateway down")
+	notifier := &mockNoShowNotifier{}
+	noShows := orchestration.NewNoShowService(svc.reservationService, svc.paymentService, notifier)
+
+	// Act
+	marked, err := noShows.ProcessNoShows(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+	assert.That(t, "reservation must still be marked", len(marked), 1)
+	assert.That(t, "guest must not be notified", len(notifier.fees), 0)
+}
+
+// ============================================================================
+// Fee Tests
+// ============================================================================
+
+func Test_NoShowService_Fee_With_More_Fee_Nights_Than_Stay_Should_Cap_At_Total(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedNoShowReservation(svc, "res-001", 2)
+	res := svc.reservationRepo.reservations["res-001"]
+	noShows := orchestration.NewNoShowService(svc.reservationService, svc.paymentService, &mockNoShowNotifier{}).
+		WithFeeNights(7)
+
+	// Act
+	fee := noShows.Fee(&res)
+
+	// Assert
+	assert.That(t, "fee must be the total amount", fee, shared.NewMoney(40000, "USD"))
+}
@@ -0,0 +1,61 @@
+package orchestration
+
+import (
+	"context"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// previewCancellationReason stands in for the reason of reservations that are not cancelled.
+const previewCancellationReason = "Cancelled by guest"
+
+// previewReviewURL stands in for the review link, which is signed for each guest.
+const previewReviewURL = "https://hotel.example/reviews/preview"
+
+// NotificationPreviewService renders the guest notifications of a reservation
+// without sending them, so staff can check the wording of each template and
+// locale with real data before a catalog change goes live.
+type NotificationPreviewService struct {
+	noShowService     *NoShowService
+	preArrivalService *PreArrivalService
+	renderer          NotificationRenderer
+}
+
+// NewNotificationPreviewService creates a new notification preview service.
+// No-show notices are previewed with the fee of the no-show service.
+func NewNotificationPreviewService(noShowSvc *NoShowService, renderer NotificationRenderer) *NotificationPreviewService {
+	return &NotificationPreviewService{
+		noShowService: noShowSvc,
+		renderer:      renderer,
+	}
+}
+
+// WithPreArrivalService previews pre-arrival reminders with the check-in instructions
+// and upsell link of the pre-arrival service.
+func (s *NotificationPreviewService) WithPreArrivalService(preArrivalSvc *PreArrivalService) *NotificationPreviewService {
+	s.preArrivalService = preArrivalSvc
+	return s
+}
+
+// PreviewNotification renders the notification of the reservation in the locale,
+// or in the guest's preferred locale if it is empty. Cancellation notices of
+// reservations that are not cancelled use a sample reason.
+func (s *NotificationPreviewService) PreviewNotification(ctx context.Context, notificationType NotificationType, res *reservation.Reservation, locale string) (*Notification, error) {
+	data := NotificationData{Reservation: res, Reason: res.CancellationReason}
+	switch notificationType {
+	case NotificationCancellation:
+		if data.Reason == "" {
+			data.Reason = previewCancellationReason
+		}
+	case NotificationNoShow:
+		data.Fee = s.noShowService.Fee(res)
+	case NotificationPreArrival:
+		if s.preArrivalService != nil {
+			data = s.preArrivalService.NotificationData(res)
+		}
+	case NotificationReviewRequest:
+		data.ReviewURL = previewReviewURL
+	}
+
+	return s.renderer.RenderNotification(ctx, notificationType, data, locale)
+}
@@ -0,0 +1,88 @@
+package orchestration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type mockNotificationRenderer struct {
+	data   orchestration.NotificationData
+	locale string
+}
+
+func (m *mockNotificationRenderer) RenderNotification(ctx context.Context, notificationType orchestration.NotificationType, data orchestration.NotificationData, locale string) (*orchestration.Notification, error) {
+	m.data = data
+	m.locale = locale
+	return &orchestration.Notification{Type: notificationType, Locale: locale}, nil
+}
+
+// createPreviewReservation returns a confirmed 4-night reservation of 400.00 USD.
+func createPreviewReservation() *reservation.Reservation {
+	checkIn := time.Now().AddDate(0, 0, 7)
+	return &reservation.Reservation{
+		ID:          "res-001",
+		GuestID:     "guest-001",
+		RoomID:      "room-101",
+		DateRange:   reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 4)),
+		Status:      reservation.StatusConfirmed,
+		TotalAmount: shared.NewMoney(40000, "USD"),
+		Guests:      validBookingGuests(),
+	}
+}
+
+// ============================================================================
+// PreviewNotification Tests
+// ============================================================================
+
+func Test_NotificationPreviewService_PreviewNotification_No_Show_Should_Use_Fee(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	renderer := &mockNotificationRenderer{}
+	noShows := orchestration.NewNoShowService(svc.reservationService, svc.paymentService, &mockNoShowNotifier{}).WithFeeNights(2)
+	previews := orchestration.NewNotificationPreviewService(noShows, renderer)
+
+	// Act
+	msg, err := previews.PreviewNotification(context.Background(), orchestration.NotificationNoShow, createPreviewReservation(), "de")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "type must be no-show", msg.Type, orchestration.NotificationNoShow)
+	assert.That(t, "locale must be passed on", renderer.locale, "de")
+	assert.That(t, "fee must be two nights", renderer.data.Fee, shared.NewMoney(20000, "USD"))
+}
+
+func Test_NotificationPreviewService_PreviewNotification_Cancellation_Of_Open_Reservation_Should_Use_Sample_Reason(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	renderer := &mockNotificationRenderer{}
+	noShows := orchestration.NewNoShowService(svc.reservationService, svc.paymentService, &mockNoShowNotifier{})
+	previews := orchestration.NewNotificationPreviewService(noShows, renderer)
+
+	// Act
+	_, err := previews.PreviewNotification(context.Background(), orchestration.NotificationCancellation, createPreviewReservation(), "")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "reason must not be empty", renderer.data.Reason != "", true)
+}
+
+func Test_ParseNotificationType_With_Unknown_Type_Should_Return_ErrUnknownNotificationType(t *testing.T) {
+	// Arrange
+	value := "receipt"
+
+	// Act
+	_, err := orchestration.ParseNotificationType(value)
+
+	// Assert
+	assert.That(t, "err must be ErrUnknownNotificationType", err, orchestration.ErrUnknownNotificationType)
+}
@@ -0,0 +1,266 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// DefaultNotificationAttempts is the number of deliveries of a notification until it is given up.
+const DefaultNotificationAttempts = 5
+
+// queuedNotificationTimeout is the time after which a queued job counts as interrupted,
+// e.g. by a restart during its delivery, and is retried.
+const queuedNotificationTimeout = 5 * time.Minute
+
+// NotificationTracker records the delivery of guest notifications as jobs that
+// are queued, sent or failed, and retries failed deliveries via RetryNotifications.
+// It decorates the GuestNotifier of the orchestration services, so their best
+// effort notifications are no longer dropped silently. Errors are still returned
+// to the callers.
+type NotificationTracker struct {
+	reservationService *reservation.Service
+	paymentService     *payment.Service
+	notifier           GuestNotifier
+	jobs               NotificationJobRepository
+	maxAttempts        int
+
+	mu sync.Mutex
+}
+
+// NewNotificationTracker creates a new notification tracker. Retries load the
+// reservation or payment of a job from the given services.
+func NewNotificationTracker(reservationSvc *reservation.Service, paymentSvc *payment.Service, notifier GuestNotifier, jobs NotificationJobRepository) *NotificationTracker {
+	return &NotificationTracker{
+		reservationService: reservationSvc,
+		paymentService:     paymentSvc,
+		notifier:           notifier,
+		jobs:               jobs,
+		maxAttempts:        DefaultNotificationAttempts,
+	}
+}
+
+// WithMaxAttempts sets the number of deliveries of a notification until it is given up.
+func (t *NotificationTracker) WithMaxAttempts(attempts int) *NotificationTracker {
+	t.maxAttempts = attempts
+	return t
+}
+
+// SendReservationConfirmation sends and records a confirmation.
+func (t *NotificationTracker) SendReservationConfirmation(ctx context.Context, res *reservation.Reservation) error {
+	return t.deliver(ctx, NewNotificationJob(res.ID.Shared(), NotificationConfirmation), func(ctx context.Context) error {
+		return t.notifier.SendReservationConfirmation(ctx, res)
+	})
+}
+
+// SendCancellationNotice sends and records a cancellation notice.
+func (t *NotificationTracker) SendCancellationNotice(ctx context.Context, res *reservation.Reservation, reason string) error {
+	job := NewNotificationJob(res.ID.Shared(), NotificationCancellation)
+	job.Reason = reason
+	return t.deliver(ctx, job, func(ctx context.Context) error {
+		return t.notifier.SendCancellationNotice(ctx, res, reason)
+	})
+}
+
+// SendNoShowNotice sends and records a no-show notice.
+func (t *NotificationTracker) SendNoShowNotice(ctx context.Context, res *reservation.Reservation, fee shared.Money) error {
+	job := NewNotificationJob(res.ID.Shared(), NotificationNoShow)
+	job.Fee = fee
+	return t.deliver(ctx, job, func(ctx context.Context) error {
+		return t.notifier.SendNoShowNotice(ctx, res, fee)
+	})
+}
+
+// SendBalanceReminder sends and records a balance reminder. Failed reminders are
+// not retried by the tracker, because the payment schedule sends them again itself.
+func (t *NotificationTracker) SendBalanceReminder(ctx context.Context, res *reservation.Reservation) error {
+	return t.deliver(ctx, NewNotificationJob(res.ID.Shared(), NotificationBalanceReminder), func(ctx context.Context) error {
+		return t.notifier.SendBalanceReminder(ctx, res)
+	})
+}
+
+// SendPreArrivalReminder sends and records a pre-arrival reminder. Failed reminders are
+// not retried by the tracker, because the pre-arrival service sends them again itself.
+func (t *NotificationTracker) SendPreArrivalReminder(ctx context.Context, res *reservation.Reservation, instructions, upsellURL string) error {
+	return t.deliver(ctx, NewNotificationJob(res.ID.Shared(), NotificationPreArrival), func(ctx context.Context) error {
+		return t.notifier.SendPreArrivalReminder(ctx, res, instructions, upsellURL)
+	})
+}
+
+// SendReviewRequest sends and records a review request. Failed requests are not
+// retried by the tracker, because the signed link is not recorded with the job.
+func (t *NotificationTracker) SendReviewRequest(ctx context.Context, res *reservation.Reservation, link string) error {
+	return t.deliver(ctx, NewNotificationJob(res.ID.Shared(), NotificationReviewRequest), func(ctx context.Context) error {
+		return t.notifier.SendReviewRequest(ctx, res, link)
+	})
+}
+
+// SendPaymentReceipt sends and records a payment receipt.
+func (t *NotificationTracker) SendPaymentReceipt(ctx context.Context, pay *payment.Payment, attachments ...Attachment) error {
+	job := NewNotificationJob(pay.ReservationID.Shared(), NotificationReceipt)
+	job.PaymentID = pay.ID
+	return t.deliver(ctx, job, func(ctx context.Context) error {
+		return t.notifier.SendPaymentReceipt(ctx, pay, attachments...)
+	})
+}
+
+// ListNotifications returns the notification jobs of a reservation, oldest first.
+func (t *NotificationTracker) ListNotifications(ctx context.Context, reservationID shared.ReservationID) ([]NotificationJob, error) {
+	all, err := t.jobs.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification jobs: %w", err)
+	}
+
+	jobs := make([]NotificationJob, 0)
+	for _, job := range all {
+		if job.ReservationID == reservationID {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.Before(jobs[j].CreatedAt) })
+
+	return jobs, nil
+}
+
+// DeleteNotifications removes the notification jobs of the reservations, e.g. on a
+// guest's erasure request. It returns the number of deleted jobs.
+func (t *NotificationTracker) DeleteNotifications(ctx context.Context, reservationIDs []shared.ReservationID) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	all, err := t.jobs.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read notification jobs: %w", err)
+	}
+
+	deleted := 0
+	for _, job := range all {
+		if !slices.Contains(reservationIDs, job.ReservationID) {
+			continue
+		}
+		if err := t.jobs.Delete(ctx, job.ID); err != nil {
+			return deleted, fmt.Errorf("failed to delete notification job: %w", err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// RetryNotifications delivers the failed notifications again that have attempts left,
+// and the queued ones whose delivery was interrupted. Confirmations of reservations
+// that were cancelled meanwhile are not retried. It returns the number of sent notifications.
+func (t *NotificationTracker) RetryNotifications(ctx context.Context) (int, error) {
+	jobs, err := t.jobs.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read notification jobs: %w", err)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.Before(jobs[j].CreatedAt) })
+
+	sent := 0
+	for _, job := range jobs {
+		if !t.retryable(job) {
+			continue
+		}
+		if err := t.deliver(ctx, &job, t.resend(job)); err == nil {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// retryable reports whether the job is retried by RetryNotifications.
+func (t *NotificationTracker) retryable(job NotificationJob) bool {
+	if job.Type == NotificationBalanceReminder || job.Type == NotificationPreArrival || job.Type == NotificationReviewRequest || job.Attempts >= t.maxAttempts {
+		return false
+	}
+	switch job.Status {
+	case NotificationFailed:
+		return true
+	case NotificationQueued:
+		return time.Since(job.UpdatedAt) > queuedNotificationTimeout
+	default:
+		return false
+	}
+}
+
+// resend returns the delivery of a job with the current state of its reservation or payment.
+// Receipts are sent again without the invoice, which stays available via the invoice API.
+func (t *NotificationTracker) resend(job NotificationJob) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if job.Type == NotificationReceipt {
+			pay, err := t.paymentService.GetPayment(ctx, job.PaymentID)
+			if err != nil {
+				return fmt.Errorf("failed to get payment: %w", err)
+			}
+			return t.notifier.SendPaymentReceipt(ctx, pay)
+		}
+
+		res, err := t.reservationService.GetReservation(ctx, reservation.ToReservationID(job.ReservationID))
+		if err != nil {
+			return fmt.Errorf("failed to get reservation: %w", err)
+		}
+		switch job.Type {
+		case NotificationConfirmation:
+			if res.Status == reservation.StatusCancelled {
+				return fmt.Errorf("reservation %s was cancelled", res.ID)
+			}
+			return t.notifier.SendReservationConfirmation(ctx, res)
+		case NotificationCancellation:
+			return t.notifier.SendCancellationNotice(ctx, res, job.Reason)
+		case NotificationNoShow:
+			return t.notifier.SendNoShowNotice(ctx, res, job.Fee)
+		default:
+			return ErrUnknownNotificationType
+		}
+	}
+}
+
+// deliver records the job as queued, sends it and records the outcome.
+// The job keeps the creation time and attempts of an earlier delivery of the
+// same notification. Recording is best effort and never fails a delivery.
+func (t *NotificationTracker) deliver(ctx context.Context, job *NotificationJob, send func(ctx context.Context) error) error {
+	storeCtx := context.WithoutCancel(ctx)
+
+	if existing, err := t.jobs.Read(storeCtx, job.ID); err == nil {
+		job.CreatedAt = existing.CreatedAt
+		job.Attempts = existing.Attempts
+	}
+	job.Status = NotificationQueued
+	job.UpdatedAt = time.Now()
+	t.save(storeCtx, *job)
+
+	err := send(ctx)
+	job.Attempts++
+	job.UpdatedAt = time.Now()
+	if err != nil {
+		job.Status = NotificationFailed
+		job.LastError = err.Error()
+	} else {
+		job.Status = NotificationSent
+		job.LastError = ""
+	}
+	t.save(storeCtx, *job)
+
+	return err
+}
+
+// save creates or updates the job.
+func (t *NotificationTracker) save(ctx context.Context, job NotificationJob) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, err := t.jobs.Read(ctx, job.ID)
+	if err != nil && err.Error() == resource.ErrorResourceNotFound {
+		_ = t.jobs.Create(ctx, job.ID, job)
+		return
+	}
+	_ = t.jobs.Update(ctx, job.ID, job)
+}
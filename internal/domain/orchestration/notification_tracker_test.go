@@ -0,0 +1,184 @@
+package orchestration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type mockGuestNotifier struct {
+	mockNotificationService
+	noShowsSent     int
+	remindersSent   int
+	preArrivalsSent int
+	upsellURL       string
+}
+
+func (m *mockGuestNotifier) SendNoShowNotice(ctx context.Context, r *reservation.Reservation, fee shared.Money) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.noShowsSent++
+	return nil
+}
+
+func (m *mockGuestNotifier) SendBalanceReminder(ctx context.Context, r *reservation.Reservation) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.remindersSent++
+	return nil
+}
+
+func (m *mockGuestNotifier) SendPreArrivalReminder(ctx context.Context, r *reservation.Reservation, instructions, upsellURL string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.preArrivalsSent++
+	m.upsellURL = upsellURL
+	return nil
+}
+
+func (m *mockGuestNotifier) SendReviewRequest(ctx context.Context, r *reservation.Reservation, link string) error {
+	return m.err
+}
+
+// createTestTracker returns a tracker with the confirmed reservation res-001.
+func createTestTracker(notifier *mockGuestNotifier) (*orchestration.NotificationTracker, *reservation.Reservation) {
+	svc := createTestServices()
+	res := createPreviewReservation()
+	svc.reservationRepo.reservations[res.ID] = *res
+	jobs := resource.NewInMemoryAccess[orchestration.NotificationJobID, orchestration.NotificationJob]()
+	return orchestration.NewNotificationTracker(svc.reservationService, svc.paymentService, notifier, jobs), res
+}
+
+// ============================================================================
+// NotificationTracker Tests
+// ============================================================================
+
+func Test_NotificationTracker_SendReservationConfirmation_Should_Record_Sent_Job(t *testing.T) {
+	// Arrange
+	notifier := &mockGuestNotifier{}
+	tracker, res := createTestTracker(notifier)
+	ctx := context.Background()
+
+	// Act
+	err := tracker.SendReservationConfirmation(ctx, res)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	jobs, _ := tracker.ListNotifications(ctx, "res-001")
+	assert.That(t, "one job must be recorded", len(jobs), 1)
+	assert.That(t, "job must be sent", jobs[0].Status, orchestration.NotificationSent)
+	assert.That(t, "job must be a confirmation", jobs[0].Type, orchestration.NotificationConfirmation)
+	assert.That(t, "one attempt must be recorded", jobs[0].Attempts, 1)
+}
+
+func Test_NotificationTracker_DeleteNotifications_Should_Remove_Jobs_Of_Reservations(t *testing.T) {
+	// Arrange
+	tracker, res := createTestTracker(&mockGuestNotifier{})
+	ctx := context.Background()
+	_ = tracker.SendReservationConfirmation(ctx, res)
+	_ = tracker.SendCancellationNotice(ctx, res, "guest request")
+
+	// Act
+	deleted, err := tracker.DeleteNotifications(ctx, []shared.ReservationID{"res-001"})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "two jobs must be deleted", deleted, 2)
+	jobs, _ := tracker.ListNotifications(ctx, "res-001")
+	assert.That(t, "no job must be left", len(jobs), 0)
+}
+
+func Test_NotificationTracker_RetryNotifications_Should_Send_Failed_Job_Again(t *testing.T) {
+	// Arrange
+	notifier := &mockGuestNotifier{mockNotificationService: mockNotificationService{err: errors.New("smtp down")}}
+	tracker, res := createTestTracker(notifier)
+	ctx := context.Background()
+	sendErr := tracker.SendCancellationNotice(ctx, res, "Cancelled by guest")
+	notifier.err = nil
+
+	// Act
+	sent, err := tracker.RetryNotifications(ctx)
+
+	// Assert
+	assert.That(t, "send error must be returned", sendErr != nil, true)
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "one notification must be sent", sent, 1)
+	assert.That(t, "cancellation must be sent", notifier.cancellationsSent, 1)
+	jobs, _ := tracker.ListNotifications(ctx, "res-001")
+	assert.That(t, "job must be sent", jobs[0].Status, orchestration.NotificationSent)
+	assert.That(t, "two attempts must be recorded", jobs[0].Attempts, 2)
+	assert.That(t, "reason must be kept", jobs[0].Reason, "Cancelled by guest")
+}
+
+func Test_NotificationTracker_RetryNotifications_Without_Attempts_Left_Should_Give_Up(t *testing.T) {
+	// Arrange
+	notifier := &mockGuestNotifier{mockNotificationService: mockNotificationService{err: errors.New("smtp down")}}
+	tracker, res := createTestTracker(notifier)
+	tracker.WithMaxAttempts(2)
+	ctx := context.Background()
+	_ = tracker.SendNoShowNotice(ctx, res, shared.NewMoney(10000, "USD"))
+	_, _ = tracker.RetryNotifications(ctx)
+	notifier.err = nil
+
+	// Act
+	sent, _ := tracker.RetryNotifications(ctx)
+
+	// Assert
+	assert.That(t, "nothing must be sent", sent, 0)
+	jobs, _ := tracker.ListNotifications(ctx, "res-001")
+	assert.That(t, "job must stay failed", jobs[0].Status, orchestration.NotificationFailed)
+	assert.That(t, "two attempts must be recorded", jobs[0].Attempts, 2)
+}
+
+func Test_NotificationTracker_RetryNotifications_Should_Skip_Balance_Reminders(t *testing.T) {
+	// Arrange
+	notifier := &mockGuestNotifier{mockNotificationService: mockNotificationService{err: errors.New("smtp down")}}
+	tracker, res := createTestTracker(notifier)
+	ctx := context.Background()
+	_ = tracker.SendBalanceReminder(ctx, res)
+	notifier.err = nil
+
+	// Act
+	sent, _ := tracker.RetryNotifications(ctx)
+
+	// Assert
+	assert.That(t, "nothing must be sent", sent, 0)
+	assert.That(t, "reminder must be left to the payment schedule", notifier.remindersSent, 0)
+}
+
+func Test_NotificationTracker_RetryNotifications_Confirmation_Of_Cancelled_Reservation_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	res := createPreviewReservation()
+	res.Status = reservation.StatusCancelled
+	svc.reservationRepo.reservations[res.ID] = *res
+	notifier := &mockGuestNotifier{}
+	jobs := resource.NewInMemoryAccess[orchestration.NotificationJobID, orchestration.NotificationJob]()
+	job := orchestration.NewNotificationJob("res-001", orchestration.NotificationConfirmation)
+	job.Status = orchestration.NotificationFailed
+	job.Attempts = 1
+	job.UpdatedAt = time.Now()
+	_ = jobs.Create(context.Background(), job.ID, *job)
+	tracker := orchestration.NewNotificationTracker(svc.reservationService, svc.paymentService, notifier, jobs)
+
+	// Act
+	sent, _ := tracker.RetryNotifications(context.Background())
+
+	// Assert
+	assert.That(t, "nothing must be sent", sent, 0)
+	assert.That(t, "confirmation must not be sent", notifier.confirmationsSent, 0)
+}
@@ -0,0 +1,147 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// PaymentScheduleService collects the balance of reservations paid in installments.
+// It runs as a scheduled job: the guest is reminded a while before the balance is
+// due, the balance is charged on its due date and charged again after a failure,
+// and a booking whose balance cannot be collected is cancelled. The deposit paid
+// at booking is kept under the terms of the payment plan.
+type PaymentScheduleService struct {
+	reservationService *reservation.Service
+	paymentService     *payment.Service
+	notifier           BalanceNotifier
+	ids                shared.IDGenerator
+	reminderBefore     time.Duration
+	retryAfter         time.Duration
+}
+
+// NewPaymentScheduleService creates a new payment schedule service.
+// Guests are reminded a week before the balance is due, and a failed balance
+// is charged again a day later.
+func NewPaymentScheduleService(reservationSvc *reservation.Service, paymentSvc *payment.Service, notifier BalanceNotifier) *PaymentScheduleService {
+	return &PaymentScheduleService{
+		reservationService: reservationSvc,
+		paymentService:     paymentSvc,
+		notifier:           notifier,
+		ids:                shared.NewUUIDv7Generator(),
+		reminderBefore:     7 * 24 * time.Hour,
+		retryAfter:         24 * time.Hour,
+	}
+}
+
+// WithIDGenerator sets the generator for new balance payment IDs (UUIDv7 by default).
+func (s *PaymentScheduleService) WithIDGenerator(ids shared.IDGenerator) *PaymentScheduleService {
+	s.ids = ids
+	return s
+}
+
+// WithReminderBefore sets how long before its due date the guest is reminded of the balance (default 7 days).
+func (s *PaymentScheduleService) WithReminderBefore(d time.Duration) *PaymentScheduleService {
+	s.reminderBefore = d
+	return s
+}
+
+// WithRetryAfter sets how long after a failed attempt the balance is charged again (default 24h).
+func (s *PaymentScheduleService) WithRetryAfter(d time.Duration) *PaymentScheduleService {
+	s.retryAfter = d
+	return s
+}
+
+// BalanceReport lists the reservations handled by a run of ProcessBalances.
+type BalanceReport struct {
+	Reminded  []reservation.ReservationID
+	Paid      []reservation.ReservationID
+	Cancelled []reservation.ReservationID
+}
+
+// ProcessBalances reminds guests of upcoming balances, charges the balances that
+// are due and cancels the bookings whose balance failed too often. A reservation
+// that fails is reported in the joined error, while the others are still processed.
+func (s *PaymentScheduleService) ProcessBalances(ctx context.Context, now time.Time) (BalanceReport, error) {
+	report := BalanceReport{
+		Reminded:  []reservation.ReservationID{},
+		Paid:      []reservation.ReservationID{},
+		Cancelled: []reservation.ReservationID{},
+	}
+
+	reservations, err := s.reservationService.ListReservations(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	var errs []error
+	for _, res := range reservations {
+		if res.Status != reservation.StatusConfirmed || !res.BalanceOpen() {
+			continue
+		}
+		if err := s.processBalance(ctx, res, now, &report); err != nil {
+			errs = append(errs, fmt.Errorf("reservation %s: %w", res.ID, err))
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// processBalance runs the reminder, charge and cancellation steps of a single balance.
+func (s *PaymentScheduleService) processBalance(ctx context.Context, res *reservation.Reservation, now time.Time, report *BalanceReport) error {
+	schedule := res.Schedule
+
+	// 1. Remind the guest ahead of the due date
+	if schedule.RemindedAt.IsZero() && !now.Before(schedule.BalanceDueAt.Add(-s.reminderBefore)) {
+		if err := s.notifier.SendBalanceReminder(ctx, res); err != nil {
+			return fmt.Errorf("failed to send balance reminder: %w", err)
+		}
+		if err := s.reservationService.MarkBalanceReminded(ctx, res.ID, now); err != nil {
+			return err
+		}
+		report.Reminded = append(report.Reminded, res.ID)
+	}
+	if now.Before(schedule.BalanceDueAt) {
+		return nil
+	}
+
+	// 2. Charge the balance to the guest's stored payment method on its due date,
+	// or again once the retry interval has passed
+	paymentID := payment.NewPaymentID(s.ids)
+	if balance, err := s.paymentService.GetBalanceByReservation(ctx, payment.ToReservationID(res.ID.Shared())); err == nil {
+		if balance.Status == payment.StatusFailed && balance.CanBeRetried() && now.Before(balance.UpdatedAt.Add(s.retryAfter)) {
+			return nil
+		}
+		paymentID = balance.ID
+	}
+	method := paymentMethodFor(ctx, s.paymentService, res.GuestID)
+	balance, chargeErr := s.paymentService.ChargeBalance(ctx, paymentID, payment.ToReservationID(res.ID.Shared()), schedule.Balance, method)
+	if chargeErr == nil {
+		if err := s.reservationService.MarkBalancePaid(ctx, res.ID, now); err != nil {
+			return err
+		}
+		report.Paid = append(report.Paid, res.ID)
+		return nil
+	}
+	if balance == nil || balance.CanBeRetried() {
+		return fmt.Errorf("failed to charge balance: %w", chargeErr)
+	}
+
+	// 3. Cancel the booking once the balance can no longer be retried
+	reason := "balance_payment_failed"
+	if err := s.reservationService.CancelReservation(ctx, res.ID, reason); err != nil {
+		return fmt.Errorf("failed to cancel reservation after failed balance payment: %w", err)
+	}
+	report.Cancelled = append(report.Cancelled, res.ID)
+
+	// 4. Notify the guest
+	if err := s.notifier.SendCancellationNotice(ctx, res, reason); err != nil {
+		return fmt.Errorf("failed to send cancellation notice: %w", err)
+	}
+	return nil
+}
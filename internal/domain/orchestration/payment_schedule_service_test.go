@@ -0,0 +1,201 @@
+package orchestration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type mockBalanceNotifier struct {
+	reminders     []reservation.ReservationID
+	cancellations []string
+}
+
+func (m *mockBalanceNotifier) SendBalanceReminder(ctx context.Context, r *reservation.Reservation) error {
+	m.reminders = append(m.reminders, r.ID)
+	return nil
+}
+
+func (m *mockBalanceNotifier) SendCancellationNotice(ctx context.Context, r *reservation.Reservation, reason string) error {
+	m.cancellations = append(m.cancellations, reason)
+	return nil
+}
+
+// seedScheduledReservation stores a confirmed reservation 10 days ahead whose
+// deposit of 300.00 USD is paid and whose balance of 700.00 USD is due in days.
+func seedScheduledReservation(svc *testServices, id string, balanceDueInDays int) {
+	checkIn := time.Now().AddDate(0, 0, 10).Truncate(24 * time.Hour)
+	svc.reservationRepo.reservations[reservation.ReservationID(id)] = reservation.Reservation{
+		ID:          reservation.ReservationID(id),
+		GuestID:     "guest-001",
+		RoomID:      "room-101",
+		DateRange:   reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2)),
+		Status:      reservation.StatusConfirmed,
+		TotalAmount: shared.NewMoney(100000, "USD"),
+		Guests:      validBookingGuests(),
+		Schedule: &reservation.PaymentSchedule{
+			Deposit:      shared.NewMoney(30000, "USD"),
+			Balance:      shared.NewMoney(70000, "USD"),
+			BalanceDueAt: time.Now().AddDate(0, 0, balanceDueInDays),
+		},
+	}
+	paymentID := payment.PaymentID("pay-" + id)
+	svc.paymentRepo.payments[paymentID] = payment.Payment{
+		ID:            paymentID,
+		ReservationID: payment.ReservationID(id),
+		Amount:        shared.NewMoney(30000, "USD"),
+		Status:        payment.StatusCaptured,
+		TransactionID: "tx-" + id,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// ============================================================================
+// ProcessBalances Tests
+// ============================================================================
+
+func Test_PaymentScheduleService_ProcessBalances_Before_Due_Date_Should_Send_Reminder_Once(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedScheduledReservation(svc, "res-001", 3)
+	notifier := &mockBalanceNotifier{}
+	schedules := orchestration.NewPaymentScheduleService(svc.reservationService, svc.paymentService, notifier)
+	_, _ = schedules.ProcessBalances(context.Background(), time.Now())
+
+	// Act
+	report, err := schedules.ProcessBalances(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "reminder must be sent once", notifier.reminders, []reservation.ReservationID{"res-001"})
+	assert.That(t, "no reminder must be reported on the second run", len(report.Reminded), 0)
+	assert.That(t, "balance must not be charged yet", svc.paymentGateway.authorizeCalls, 0)
+}
+
+func Test_PaymentScheduleService_ProcessBalances_On_Due_Date_Should_Charge_Balance(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedScheduledReservation(svc, "res-001", -1)
+	notifier := &mockBalanceNotifier{}
+	schedules := orchestration.NewPaymentScheduleService(svc.reservationService, svc.paymentService, notifier)
+
+	// Act
+	report, err := schedules.ProcessBalances(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "balance must be reported as paid", report.Paid, []reservation.ReservationID{"res-001"})
+	balance, _ := svc.paymentService.GetBalanceByReservation(context.Background(), "res-001")
+	assert.That(t, "balance must be captured", balance.Status, payment.StatusCaptured)
+	assert.That(t, "balance amount must match the schedule", balance.Amount, shared.NewMoney(70000, "USD"))
+	assert.That(t, "balance must be marked as paid", svc.reservationRepo.reservations["res-001"].Schedule.PaidAt.IsZero(), false)
+}
+
+func Test_PaymentScheduleService_ProcessBalances_With_Stored_Method_Should_Charge_It(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.paymentService.WithPaymentMethods(resource.NewInMemoryAccess[payment.PaymentMethodID, payment.PaymentMethod]())
+	_, _ = svc.paymentService.AddPaymentMethod(context.Background(), "pm-001", "guest-001", "tok_visa_4242", "visa", "4242", 12, time.Now().Year()+2)
+	seedScheduledReservation(svc, "res-001", -1)
+	schedules := orchestration.NewPaymentScheduleService(svc.reservationService, svc.paymentService, &mockBalanceNotifier{})
+
+	// Act
+	_, err := schedules.ProcessBalances(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	balance, _ := svc.paymentService.GetBalanceByReservation(context.Background(), "res-001")
+	assert.That(t, "stored method must be charged", balance.PaymentMethod, "pm-001")
+	assert.That(t, "token of the stored method must be charged", balance.MethodToken, "tok_visa_4242")
+}
+
+func Test_PaymentScheduleService_ProcessBalances_When_Declined_Should_Retry_After_Interval(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedScheduledReservation(svc, "res-001", -1)
+	svc.paymentGateway.authorizeErr = errors.New("card declined")
+	notifier := &mockBalanceNotifier{}
+	schedules := orchestration.NewPaymentScheduleService(svc.reservationService, svc.paymentService, notifier)
+	_, _ = schedules.ProcessBalances(context.Background(), time.Now())
+
+	// Act
+	_, err := schedules.ProcessBalances(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil while waiting for the retry", err == nil, true)
+	assert.That(t, "balance must be charged once", svc.paymentGateway.authorizeCalls, 1)
+	assert.That(t, "reservation must stay confirmed", svc.reservationRepo.reservations["res-001"].Status, reservation.StatusConfirmed)
+}
+
+func Test_PaymentScheduleService_ProcessBalances_When_Retries_Exhausted_Should_Cancel_Reservation(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedScheduledReservation(svc, "res-001", -1)
+	svc.paymentGateway.authorizeErr = errors.New("card declined")
+	notifier := &mockBalanceNotifier{}
+	schedules := orchestration.NewPaymentScheduleService(svc.reservationService, svc.paymentService, notifier).
+		WithRetryAfter(0)
+	_, _ = schedules.ProcessBalances(context.Background(), time.Now())
+	_, _ = schedules.ProcessBalances(context.Background(), time.Now())
+
+	// Act
+	report, err := schedules.ProcessBalances(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "reservation must be reported as cancelled", report.Cancelled, []reservation.ReservationID{"res-001"})
+	stored := svc.reservationRepo.reservations["res-001"]
+	assert.That(t, "reservation must be cancelled", stored.Status, reservation.StatusCancelled)
+	assert.That(t, "cancellation reason must be the failed balance", stored.CancellationReason, "balance_payment_failed")
+	assert.That(t, "guest must be notified", notifier.cancellations, []string{"balance_payment_failed"})
+	assert.That(t, "deposit must be kept", svc.paymentRepo.payments["pay-res-001"].Status, payment.StatusCaptured)
+}
+
+// ============================================================================
+// Booking Tests
+// ============================================================================
+
+func Test_BookingService_CompleteBooking_With_Payment_Plan_Should_Charge_Deposit(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.reservationService.WithPaymentPlan(reservation.PaymentPlan{DepositPercent: 30, BalanceDueBefore: 24 * time.Hour})
+
+	// Act
+	res, err := svc.bookingService.CompleteBooking(context.Background(), "res-001", "pay-001", "guest-001", "room-101",
+		validBookingDateRange(), validBookingMoney(), validBookingGuests(), "credit_card")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "reservation must be confirmed", res.Status, reservation.StatusConfirmed)
+	assert.That(t, "deposit must be charged", svc.paymentRepo.payments["pay-001"].Amount, res.Schedule.Deposit)
+	assert.That(t, "balance must be open", res.BalanceOpen(), true)
+}
+
+func Test_BookingService_CancelBookingWithRefund_With_Paid_Balance_Should_Refund_Balance(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedScheduledReservation(svc, "res-001", -1)
+	schedules := orchestration.NewPaymentScheduleService(svc.reservationService, svc.paymentService, &mockBalanceNotifier{})
+	_, _ = schedules.ProcessBalances(context.Background(), time.Now())
+
+	// Act
+	err := svc.bookingService.CancelBookingWithRefund(context.Background(), "res-001", "guest requested")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "deposit must be refunded", svc.paymentRepo.payments["pay-res-001"].Status, payment.StatusRefunded)
+	balance, _ := svc.paymentService.GetBalanceByReservation(context.Background(), "res-001")
+	assert.That(t, "balance must be refunded", balance.Status, payment.StatusRefunded)
+}
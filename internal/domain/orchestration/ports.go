@@ -3,8 +3,11 @@ package orchestration
 import (
 	"context"
 
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
 // NotificationService handles sending notifications to guests.
@@ -13,6 +16,74 @@ type NotificationService interface {
 	SendReservationConfirmation(ctx context.Context, r *reservation.Reservation) error
 	// SendCancellationNotice sends a cancellation notice to the guest
 	SendCancellationNotice(ctx context.Context, r *reservation.Reservation, reason string) error
-	// SendPaymentReceipt sends a payment receipt to the guest
-	SendPaymentReceipt(ctx context.Context, p *payment.Payment) error
+	// SendPaymentReceipt sends a payment receipt to the guest, e.g. with the invoice attached
+	SendPaymentReceipt(ctx context.Context, p *payment.Payment, attachments ...Attachment) error
 }
+
+// NotificationRenderer renders guest notifications without sending them.
+type NotificationRenderer interface {
+	// RenderNotification renders the template in the locale, or in the guest's preferred locale if it is empty
+	RenderNotification(ctx context.Context, notificationType NotificationType, data NotificationData, locale string) (*Notification, error)
+}
+
+// NoShowNotifier tells guests that they were recorded as no-show.
+type NoShowNotifier interface {
+	// SendNoShowNotice sends the no-show notice with the retained fee to the guest
+	SendNoShowNotice(ctx context.Context, r *reservation.Reservation, fee shared.Money) error
+}
+
+// BalanceNotifier tells guests about the balance of their payment schedule.
+type BalanceNotifier interface {
+	// SendBalanceReminder reminds the guest of the open balance and its due date
+	SendBalanceReminder(ctx context.Context, r *reservation.Reservation) error
+	// SendCancellationNotice sends a cancellation notice to the guest
+	SendCancellationNotice(ctx context.Context, r *reservation.Reservation, reason string) error
+}
+
+// PreArrivalNotifier reminds guests of their stay before arrival.
+type PreArrivalNotifier interface {
+	// SendPreArrivalReminder sends the reminder with the check-in instructions and the upsell link to the guest
+	SendPreArrivalReminder(ctx context.Context, r *reservation.Reservation, instructions, upsellURL string) error
+}
+
+// ReviewNotifier asks guests for a review of their stay.
+type ReviewNotifier interface {
+	// SendReviewRequest sends the link to the review form to the guest
+	SendReviewRequest(ctx context.Context, r *reservation.Reservation, link string) error
+}
+
+// GuestNotifier sends all guest notifications of the orchestration services.
+type GuestNotifier interface {
+	NotificationService
+	NoShowNotifier
+	BalanceNotifier
+	PreArrivalNotifier
+	ReviewNotifier
+}
+
+// Attachment is a file sent along with a notification.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// InvoiceGenerator renders and stores the invoice of a paid reservation.
+type InvoiceGenerator interface {
+	// GenerateInvoice returns the invoice document of the reservation
+	GenerateInvoice(ctx context.Context, reservationID shared.ReservationID) ([]byte, error)
+	// ContentType returns the media type of the invoice documents
+	ContentType() string
+}
+
+// CompensationQueue persists failed compensations for automatic retry.
+type CompensationQueue resource.Access[CompensationID, FailedCompensation]
+
+// NotificationJobRepository persists the delivery status of guest notifications.
+type NotificationJobRepository resource.Access[NotificationJobID, NotificationJob]
+
+// SagaStateRepository persists the progress of booking sagas.
+type SagaStateRepository resource.Access[shared.ReservationID, SagaState]
+
+// EventPublisher publishes orchestration events.
+type EventPublisher event.EventPublisher
@@ -0,0 +1,229 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// PreArrivalService reminds guests of their stay before arrival: a while before the
+// check-in date, the guest of a confirmed direct booking is sent the check-in instructions
+// and a link to book extras for the stay, unless the guest opted out of pre-arrival
+// reminders. Each guest is reminded once.
+//
+// With an event publisher, the confirmation of a booking publishes a booking.pre_arrival_due
+// event delivered at the reminder time (see shared.WithDeliverAt), which sends the reminder.
+// The scheduled SendReminders job catches the reservations whose event did not remind them,
+// e.g. because the stay was moved.
+type PreArrivalService struct {
+	reservationService *reservation.Service
+	notifier           PreArrivalNotifier
+	publisher          EventPublisher
+	now                func() time.Time
+	daysBefore         int
+	instructions       string
+	upsellURL          string
+}
+
+// NewPreArrivalService creates a new pre-arrival service.
+// Guests are reminded three days before check-in.
+func NewPreArrivalService(reservationSvc *reservation.Service, notifier PreArrivalNotifier) *PreArrivalService {
+	return &PreArrivalService{
+		reservationService: reservationSvc,
+		notifier:           notifier,
+		now:                time.Now,
+		daysBefore:         3,
+	}
+}
+
+// WithEventPublisher sets the publisher of the delayed booking.pre_arrival_due events.
+// Without one, the guests are reminded by the scheduled job only.
+func (s *PreArrivalService) WithEventPublisher(publisher EventPublisher) *PreArrivalService {
+	s.publisher = publisher
+	return s
+}
+
+// WithClock sets the clock that decides whether a reminder is due.
+func (s *PreArrivalService) WithClock(now func() time.Time) *PreArrivalService {
+	s.now = now
+	return s
+}
+
+// WithDaysBefore sets how many days before the check-in date the guest is reminded (default 3).
+func (s *PreArrivalService) WithDaysBefore(days int) *PreArrivalService {
+	s.daysBefore = days
+	return s
+}
+
+// WithInstructions sets the check-in instructions sent to the guests, e.g. the front desk hours.
+func (s *PreArrivalService) WithInstructions(instructions string) *PreArrivalService {
+	s.instructions = instructions
+	return s
+}
+
+// WithUpsellURL sets the link to the extras of a stay. "{reservation_id}" in the
+// link is replaced by the ID of the reservation.
+func (s *PreArrivalService) WithUpsellURL(url string) *PreArrivalService {
+	s.upsellURL = url
+	return s
+}
+
+// PreArrivalReport lists the reservations handled by a run of SendReminders.
+type PreArrivalReport struct {
+	Reminded []reservation.ReservationID
+	OptedOut []reservation.ReservationID
+}
+
+// NotificationData returns the input of the pre-arrival reminder of the reservation.
+func (s *PreArrivalService) NotificationData(res *reservation.Reservation) NotificationData {
+	return NotificationData{
+		Reservation:  res,
+		Instructions: s.instructions,
+		UpsellURL:    strings.ReplaceAll(s.upsellURL, "{reservation_id}", string(res.ID)),
+	}
+}
+
+// SendReminders reminds the guests whose check-in is due within the configured days.
+// Guests who opted out are skipped, but reminded if they opt in again before arrival.
+// A reservation that fails is reported in the joined error and reminded on the next
+// run, while the others are still processed.
+func (s *PreArrivalService) SendReminders(ctx context.Context, now time.Time) (PreArrivalReport, error) {
+	report := PreArrivalReport{
+		Reminded: []reservation.ReservationID{},
+		OptedOut: []reservation.ReservationID{},
+	}
+
+	reservations, err := s.reservationService.ListReservations(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	var errs []error
+	for _, res := range reservations {
+		if !s.due(res, now) {
+			continue
+		}
+
+		optedOut, err := s.remind(ctx, res, now)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("reservation %s: %w", res.ID, err))
+		case optedOut:
+			report.OptedOut = append(report.OptedOut, res.ID)
+		default:
+			report.Reminded = append(report.Reminded, res.ID)
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// RegisterHandlers subscribes to the confirmation of bookings, which schedules their
+// reminder, and to the booking.pre_arrival_due events, which send it.
+func (s *PreArrivalService) RegisterHandlers(ctx context.Context, dispatcher messaging.Dispatcher) error {
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicConfirmed, s.handleReservationConfirmed); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicConfirmed, err)
+	}
+	if err := dispatcher.Subscribe(ctx, EventTopicPreArrivalDue, s.handlePreArrivalDue); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", EventTopicPreArrivalDue, err)
+	}
+	return nil
+}
+
+// ScheduleReminder publishes the booking.pre_arrival_due event of a reservation,
+// delivered the configured days before its check-in date.
+func (s *PreArrivalService) ScheduleReminder(ctx context.Context, id reservation.ReservationID) error {
+	if s.publisher == nil {
+		return nil
+	}
+	res, err := s.reservationService.GetReservation(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if res.IsExternalHold() || res.Channel != "" {
+		return nil
+	}
+	at := res.DateRange.CheckIn.AddDate(0, 0, -s.daysBefore)
+	evt := NewEventPreArrivalDue().WithReservationID(res.ID.Shared())
+	if err := s.publisher.Publish(shared.WithDeliverAt(ctx, at), evt); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	return nil
+}
+
+// RemindGuest reminds the guest of a reservation if the reminder is due,
+// and reports whether the guest was reminded.
+func (s *PreArrivalService) RemindGuest(ctx context.Context, id reservation.ReservationID) (bool, error) {
+	res, err := s.reservationService.GetReservation(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	now := s.now()
+	if !s.due(res, now) {
+		return false, nil
+	}
+	optedOut, err := s.remind(ctx, res, now)
+	return err == nil && !optedOut, err
+}
+
+// handleReservationConfirmed schedules the reminder of a confirmed booking.
+func (s *PreArrivalService) handleReservationConfirmed(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventConfirmed
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	if err := s.ScheduleReminder(context.WithoutCancel(ctx), evt.ReservationID); err != nil {
+		return messaging.MessageStateFailed, err
+	}
+	return messaging.MessageStateCompleted, nil
+}
+
+// handlePreArrivalDue reminds the guest of the reservation of the event.
+func (s *PreArrivalService) handlePreArrivalDue(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	var evt EventPreArrivalDue
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	if _, err := s.RemindGuest(context.WithoutCancel(ctx), reservation.ToReservationID(evt.ReservationID)); err != nil {
+		return messaging.MessageStateFailed, err
+	}
+	return messaging.MessageStateCompleted, nil
+}
+
+// remind sends the reminder of a due reservation and records it, so the guest is
+// reminded once. It reports whether the guest opted out instead.
+func (s *PreArrivalService) remind(ctx context.Context, res *reservation.Reservation, now time.Time) (bool, error) {
+	// 1. Respect the guest's preferences
+	profile, err := s.reservationService.GetGuestProfile(ctx, res.GuestID)
+	if err != nil {
+		return false, err
+	}
+	if !profile.Notifications.Allows(string(NotificationPreArrival)) {
+		return true, nil
+	}
+
+	// 2. Remind the guest and record it
+	data := s.NotificationData(res)
+	if err := s.notifier.SendPreArrivalReminder(ctx, res, data.Instructions, data.UpsellURL); err != nil {
+		return false, fmt.Errorf("failed to send pre-arrival reminder: %w", err)
+	}
+	return false, s.reservationService.MarkPreArrivalSent(ctx, res.ID, now)
+}
+
+// due reports whether the guest of the reservation is to be reminded at now.
+// External holds and channel reservations are skipped, because their guests
+// are contacted by the platform they booked on.
+func (s *PreArrivalService) due(res *reservation.Reservation, now time.Time) bool {
+	if res.Status != reservation.StatusConfirmed || res.IsExternalHold() || res.Channel != "" || !res.PreArrivalSentAt.IsZero() {
+		return false
+	}
+	checkIn := res.DateRange.CheckIn
+	return !now.Before(checkIn.AddDate(0, 0, -s.daysBefore)) && now.Before(checkIn)
+}
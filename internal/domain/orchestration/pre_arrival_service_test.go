@@ -0,0 +1,165 @@
+package orchestration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type mockGuestProfiles struct {
+	profiles map[reservation.GuestID]reservation.GuestProfile
+}
+
+func (m *mockGuestProfiles) FindProfile(ctx context.Context, guestID reservation.GuestID) (*reservation.GuestProfile, error) {
+	profile, ok := m.profiles[guestID]
+	if !ok {
+		return nil, nil
+	}
+	return &profile, nil
+}
+
+func (m *mockGuestProfiles) SaveProfile(ctx context.Context, profile reservation.GuestProfile) error {
+	m.profiles[profile.GuestID] = profile
+	return nil
+}
+
+func (m *mockGuestProfiles) DeleteProfile(ctx context.Context, guestID reservation.GuestID) error {
+	delete(m.profiles, guestID)
+	return nil
+}
+
+// seedArrivingReservation stores a confirmed reservation of guest-001 that starts in days.
+func seedArrivingReservation(svc *testServices, id string, checkInInDays int) {
+	checkIn := time.Now().AddDate(0, 0, checkInInDays)
+	svc.reservationRepo.reservations[reservation.ReservationID(id)] = reservation.Reservation{
+		ID:          reservation.ReservationID(id),
+		GuestID:     "guest-001",
+		RoomID:      "room-101",
+		DateRange:   reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2)),
+		Status:      reservation.StatusConfirmed,
+		TotalAmount: shared.NewMoney(20000, "USD"),
+		Guests:      validBookingGuests(),
+	}
+}
+
+// ============================================================================
+// SendReminders Tests
+// ============================================================================
+
+func Test_PreArrivalService_SendReminders_Should_Remind_Arriving_Guest_Once(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedArrivingReservation(svc, "res-001", 2)
+	seedArrivingReservation(svc, "res-002", 10)
+	notifier := &mockGuestNotifier{}
+	preArrival := orchestration.NewPreArrivalService(svc.reservationService, notifier).
+		WithUpsellURL("https://hotel.example/extras?reservation={reservation_id}")
+	ctx := context.Background()
+
+	// Act
+	report, err := preArrival.SendReminders(ctx, time.Now())
+	_, _ = preArrival.SendReminders(ctx, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "only the arriving guest must be reminded", report.Reminded, []reservation.ReservationID{"res-001"})
+	assert.That(t, "guest must be reminded once", notifier.preArrivalsSent, 1)
+	assert.That(t, "upsell link must name the reservation", notifier.upsellURL, "https://hotel.example/extras?reservation=res-001")
+	assert.That(t, "reminder must be recorded", svc.reservationRepo.reservations["res-001"].PreArrivalSentAt.IsZero(), false)
+}
+
+func Test_PreArrivalService_SendReminders_With_Opt_Out_Should_Skip_Guest(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedArrivingReservation(svc, "res-001", 2)
+	prefs, _ := reservation.NotificationPreferences{}.WithOptOut([]string{string(orchestration.NotificationPreArrival)})
+	profiles := &mockGuestProfiles{profiles: map[reservation.GuestID]reservation.GuestProfile{
+		"guest-001": {GuestID: "guest-001", Notifications: prefs},
+	}}
+	svc.reservationService.WithGuestProfiles(profiles)
+	notifier := &mockGuestNotifier{}
+	preArrival := orchestration.NewPreArrivalService(svc.reservationService, notifier)
+
+	// Act
+	report, err := preArrival.SendReminders(context.Background(), time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "guest must be reported as opted out", report.OptedOut, []reservation.ReservationID{"res-001"})
+	assert.That(t, "no reminder must be sent", notifier.preArrivalsSent, 0)
+}
+
+func Test_PreArrivalService_SendReminders_With_Failed_Delivery_Should_Remind_Again(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedArrivingReservation(svc, "res-001", 2)
+	notifier := &mockGuestNotifier{}
+	notifier.err = errors.New("smtp down")
+	preArrival := orchestration.NewPreArrivalService(svc.reservationService, notifier)
+	ctx := context.Background()
+
+	// Act
+	_, err := preArrival.SendReminders(ctx, time.Now())
+	notifier.err = nil
+	report, _ := preArrival.SendReminders(ctx, time.Now())
+
+	// Assert
+	assert.That(t, "failure must be reported", err != nil, true)
+	assert.That(t, "guest must be reminded on the next run", report.Reminded, []reservation.ReservationID{"res-001"})
+}
+
+// ============================================================================
+// Delayed Reminder Tests
+// ============================================================================
+
+func Test_PreArrivalService_ScheduleReminder_Should_Publish_Event_Delivered_Before_Check_In(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedArrivingReservation(svc, "res-001", 10)
+	publisher := &mockEventPublisher{}
+	preArrival := orchestration.NewPreArrivalService(svc.reservationService, &mockGuestNotifier{}).
+		WithDaysBefore(3).
+		WithEventPublisher(publisher)
+
+	// Act
+	err := preArrival.ScheduleReminder(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "topic must be booking.pre_arrival_due", publisher.published[0].Topic(), orchestration.EventTopicPreArrivalDue)
+	checkIn := svc.reservationRepo.reservations["res-001"].DateRange.CheckIn
+	assert.That(t, "event must be delivered three days before check-in", publisher.deliverAts[0], checkIn.AddDate(0, 0, -3))
+}
+
+func Test_PreArrivalService_RemindGuest_Should_Remind_Due_Guest_Once(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	seedArrivingReservation(svc, "res-001", 2)
+	seedArrivingReservation(svc, "res-002", 10)
+	notifier := &mockGuestNotifier{}
+	preArrival := orchestration.NewPreArrivalService(svc.reservationService, notifier)
+	ctx := context.Background()
+
+	// Act
+	reminded, err := preArrival.RemindGuest(ctx, "res-001")
+	again, _ := preArrival.RemindGuest(ctx, "res-001")
+	early, _ := preArrival.RemindGuest(ctx, "res-002")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "due guest must be reminded", reminded, true)
+	assert.That(t, "guest must not be reminded twice", again, false)
+	assert.That(t, "guest arriving later must not be reminded yet", early, false)
+	assert.That(t, "one reminder must be sent", notifier.preArrivalsSent, 1)
+}
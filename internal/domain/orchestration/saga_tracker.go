@@ -0,0 +1,232 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// SagaTracker records the progress of booking sagas from the domain events of the
+// reservation and payment contexts and notifies watchers of every change.
+// It works for the event-driven and the synchronous saga, which publish the same events.
+type SagaTracker struct {
+	states SagaStateRepository
+
+	mu       sync.Mutex
+	watchers map[shared.ReservationID]map[chan SagaState]struct{}
+}
+
+// NewSagaTracker creates a new saga tracker.
+func NewSagaTracker(states SagaStateRepository) *SagaTracker {
+	return &SagaTracker{
+		states:   states,
+		watchers: make(map[shared.ReservationID]map[chan SagaState]struct{}),
+	}
+}
+
+// RegisterHandlers subscribes the tracker to the events of the booking saga.
+func (t *SagaTracker) RegisterHandlers(ctx context.Context, dispatcher messaging.Dispatcher) error {
+	handlers := map[string]func(msg messaging.Message) (messaging.MessageState, error){
+		reservation.EventTopicCreated:   t.handleReservationCreated,
+		payment.EventTopicAuthorized:    t.handlePaymentAuthorized,
+		payment.EventTopicCaptured:      t.handlePaymentCaptured,
+		payment.EventTopicFailed:        t.handlePaymentFailed,
+		reservation.EventTopicConfirmed: t.handleReservationConfirmed,
+		reservation.EventTopicCancelled: t.handleReservationCancelled,
+	}
+	for topic, handler := range handlers {
+		if err := dispatcher.Subscribe(ctx, topic, service.Wrap(handler)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// GetSagaState returns the progress of the reservation's saga.
+// Sagas without recorded events are returned with all steps pending.
+func (t *SagaTracker) GetSagaState(ctx context.Context, reservationID shared.ReservationID) (*SagaState, error) {
+	state, err := t.states.Read(ctx, reservationID)
+	if err != nil {
+		if err.Error() == resource.ErrorResourceNotFound {
+			return NewSagaState(reservationID), nil
+		}
+		return nil, fmt.Errorf("failed to read saga state: %w", err)
+	}
+	return state, nil
+}
+
+// ListSagaStates returns the recorded saga states.
+func (t *SagaTracker) ListSagaStates(ctx context.Context) ([]SagaState, error) {
+	states, err := t.states.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read saga states: %w", err)
+	}
+	return states, nil
+}
+
+// StartSaga records the mode of the reservation's saga and returns the recorded mode.
+// A saga that started already keeps its mode, so a redelivered event and the later
+// steps run the same way, even if the feature flag was switched in between.
+func (t *SagaTracker) StartSaga(ctx context.Context, reservationID shared.ReservationID, mode SagaMode) (SagaMode, error) {
+	var started SagaMode
+	err := t.update(ctx, reservationID, func(state *SagaState, now time.Time) {
+		if state.Mode == "" {
+			state.Mode = mode
+			state.UpdatedAt = now
+		}
+		started = state.Mode
+	})
+	return started, err
+}
+
+// Watch returns a channel that receives the saga state after every change.
+// Only the latest state is buffered for slow receivers. The channel is closed when ctx is done.
+func (t *SagaTracker) Watch(ctx context.Context, reservationID shared.ReservationID) <-chan SagaState {
+	ch := make(chan SagaState, 1)
+
+	t.mu.Lock()
+	if t.watchers[reservationID] == nil {
+		t.watchers[reservationID] = make(map[chan SagaState]struct{})
+	}
+	t.watchers[reservationID][ch] = struct{}{}
+	t.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.watchers[reservationID], ch)
+		if len(t.watchers[reservationID]) == 0 {
+			delete(t.watchers, reservationID)
+		}
+		close(ch)
+	}()
+
+	return ch
+}
+
+// update applies a change to the saga state, persists it and notifies the watchers.
+func (t *SagaTracker) update(ctx context.Context, reservationID shared.ReservationID, apply func(state *SagaState, now time.Time)) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, err := t.states.Read(ctx, reservationID)
+	exists := err == nil
+	if !exists {
+		state = NewSagaState(reservationID)
+	}
+
+	apply(state, time.Now())
+
+	if exists {
+		err = t.states.Update(ctx, reservationID, *state)
+	} else {
+		err = t.states.Create(ctx, reservationID, *state)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save saga state: %w", err)
+	}
+
+	for ch := range t.watchers[reservationID] {
+		// Replace a state the watcher has not received yet.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- *state
+	}
+	return nil
+}
+
+// handleReservationCreated completes the reservation step. Reservations imported
+// from a sales channel are paid through the channel and confirmed on creation.
+func (t *SagaTracker) handleReservationCreated(msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventCreated
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	return t.track(evt.ReservationID.Shared(), func(state *SagaState, now time.Time) {
+		state.complete(SagaStepReservation, now)
+		if evt.Channel != "" {
+			state.skip(SagaStepAuthorization, now)
+			state.skip(SagaStepCapture, now)
+			state.complete(SagaStepConfirmation, now)
+		}
+	})
+}
+
+// handlePaymentAuthorized completes the authorization step.
+func (t *SagaTracker) handlePaymentAuthorized(msg messaging.Message) (messaging.MessageState, error) {
+	var evt payment.EventAuthorized
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	return t.track(evt.ReservationID.Shared(), func(state *SagaState, now time.Time) {
+		state.complete(SagaStepAuthorization, now)
+	})
+}
+
+// handlePaymentCaptured completes the capture step.
+func (t *SagaTracker) handlePaymentCaptured(msg messaging.Message) (messaging.MessageState, error) {
+	var evt payment.EventCaptured
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	return t.track(evt.ReservationID.Shared(), func(state *SagaState, now time.Time) {
+		state.complete(SagaStepCapture, now)
+	})
+}
+
+// handlePaymentFailed fails the capture step for capture errors and the authorization step otherwise.
+func (t *SagaTracker) handlePaymentFailed(msg messaging.Message) (messaging.MessageState, error) {
+	var evt payment.EventFailed
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	step := SagaStepAuthorization
+	if evt.ErrorCode == "capture_failed" {
+		step = SagaStepCapture
+	}
+	return t.track(evt.ReservationID.Shared(), func(state *SagaState, now time.Time) {
+		state.fail(step, evt.ErrorMsg, now)
+	})
+}
+
+// handleReservationConfirmed completes the confirmation step.
+func (t *SagaTracker) handleReservationConfirmed(msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventConfirmed
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	return t.track(evt.ReservationID.Shared(), func(state *SagaState, now time.Time) {
+		state.complete(SagaStepConfirmation, now)
+	})
+}
+
+// handleReservationCancelled records the cancellation of the reservation.
+func (t *SagaTracker) handleReservationCancelled(msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventCancelled
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	return t.track(evt.ReservationID.Shared(), func(state *SagaState, now time.Time) {
+		state.cancel(evt.Reason, now)
+	})
+}
+
+// track applies an event to the saga state of the reservation.
+func (t *SagaTracker) track(reservationID shared.ReservationID, apply func(state *SagaState, now time.Time)) (messaging.MessageState, error) {
+	if err := t.update(context.Background(), reservationID, apply); err != nil {
+		return messaging.MessageStateFailed, err
+	}
+	return messaging.MessageStateCompleted, nil
+}
@@ -0,0 +1,211 @@
+package orchestration_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createSagaTracker(t *testing.T) (*orchestration.SagaTracker, *mockDispatcher) {
+	t.Helper()
+	tracker := orchestration.NewSagaTracker(resource.NewInMemoryAccess[shared.ReservationID, orchestration.SagaState]())
+	dispatcher := newMockDispatcher()
+	assert.That(t, "handlers must be registered", tracker.RegisterHandlers(context.Background(), dispatcher), nil)
+	return tracker, dispatcher
+}
+
+func triggerSagaEvent(t *testing.T, dispatcher *mockDispatcher, topic string, evt any) {
+	t.Helper()
+	data, _ := json.Marshal(evt)
+	state, err := dispatcher.triggerEvent(topic, data)
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+}
+
+func sagaStepStatuses(state *orchestration.SagaState) []orchestration.SagaStepStatus {
+	statuses := make([]orchestration.SagaStepStatus, 0, len(state.Steps))
+	for _, step := range state.Steps {
+		statuses = append(statuses, step.Status)
+	}
+	return statuses
+}
+
+// ============================================================================
+// SagaTracker Tests
+// ============================================================================
+
+func Test_SagaTracker_GetSagaState_Without_Events_Should_Return_Pending_Steps(t *testing.T) {
+	// Arrange
+	tracker, _ := createSagaTracker(t)
+
+	// Act
+	state, err := tracker.GetSagaState(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "all steps must be pending", sagaStepStatuses(state), []orchestration.SagaStepStatus{
+		orchestration.SagaStepPending, orchestration.SagaStepPending, orchestration.SagaStepPending, orchestration.SagaStepPending,
+	})
+	assert.That(t, "saga must not be done", state.Done(), false)
+}
+
+func Test_SagaTracker_With_Reservation_Created_Should_Start_Authorization(t *testing.T) {
+	// Arrange
+	tracker, dispatcher := createSagaTracker(t)
+
+	// Act
+	triggerSagaEvent(t, dispatcher, reservation.EventTopicCreated, reservation.EventCreated{ReservationID: "res-001"})
+
+	// Assert
+	state, _ := tracker.GetSagaState(context.Background(), "res-001")
+	assert.That(t, "steps must progress", sagaStepStatuses(state), []orchestration.SagaStepStatus{
+		orchestration.SagaStepCompleted, orchestration.SagaStepRunning, orchestration.SagaStepPending, orchestration.SagaStepPending,
+	})
+}
+
+func Test_SagaTracker_With_All_Events_Should_Complete_Saga(t *testing.T) {
+	// Arrange
+	tracker, dispatcher := createSagaTracker(t)
+
+	// Act
+	triggerSagaEvent(t, dispatcher, reservation.EventTopicCreated, reservation.EventCreated{ReservationID: "res-001"})
+	triggerSagaEvent(t, dispatcher, payment.EventTopicAuthorized, payment.EventAuthorized{ReservationID: "res-001"})
+	triggerSagaEvent(t, dispatcher, payment.EventTopicCaptured, payment.EventCaptured{ReservationID: "res-001"})
+	triggerSagaEvent(t, dispatcher, reservation.EventTopicConfirmed, reservation.EventConfirmed{ReservationID: "res-001"})
+
+	// Assert
+	state, _ := tracker.GetSagaState(context.Background(), "res-001")
+	assert.That(t, "all steps must be completed", sagaStepStatuses(state), []orchestration.SagaStepStatus{
+		orchestration.SagaStepCompleted, orchestration.SagaStepCompleted, orchestration.SagaStepCompleted, orchestration.SagaStepCompleted,
+	})
+	assert.That(t, "saga must be done", state.Done(), true)
+}
+
+func Test_SagaTracker_With_Events_Out_Of_Order_Should_Complete_Earlier_Steps(t *testing.T) {
+	// Arrange
+	tracker, dispatcher := createSagaTracker(t)
+
+	// Act
+	triggerSagaEvent(t, dispatcher, payment.EventTopicCaptured, payment.EventCaptured{ReservationID: "res-001"})
+
+	// Assert
+	state, _ := tracker.GetSagaState(context.Background(), "res-001")
+	assert.That(t, "earlier steps must be completed", sagaStepStatuses(state), []orchestration.SagaStepStatus{
+		orchestration.SagaStepCompleted, orchestration.SagaStepCompleted, orchestration.SagaStepCompleted, orchestration.SagaStepRunning,
+	})
+}
+
+func Test_SagaTracker_With_Capture_Failure_Should_Fail_Capture_And_Compensate(t *testing.T) {
+	// Arrange
+	tracker, dispatcher := createSagaTracker(t)
+	triggerSagaEvent(t, dispatcher, reservation.EventTopicCreated, reservation.EventCreated{ReservationID: "res-001"})
+	triggerSagaEvent(t, dispatcher, payment.EventTopicAuthorized, payment.EventAuthorized{ReservationID: "res-001"})
+
+	// Act
+	triggerSagaEvent(t, dispatcher, payment.EventTopicFailed, payment.EventFailed{ReservationID: "res-001", ErrorCode: "capture_failed", ErrorMsg: "gateway timeout"})
+	triggerSagaEvent(t, dispatcher, reservation.EventTopicCancelled, reservation.EventCancelled{ReservationID: "res-001", Reason: "payment failed"})
+
+	// Assert
+	state, _ := tracker.GetSagaState(context.Background(), "res-001")
+	assert.That(t, "capture must fail and reservation be compensated", sagaStepStatuses(state), []orchestration.SagaStepStatus{
+		orchestration.SagaStepCompensated, orchestration.SagaStepCompleted, orchestration.SagaStepFailed, orchestration.SagaStepPending,
+	})
+	assert.That(t, "error must be recorded", state.Steps[2].Error, "gateway timeout")
+	assert.That(t, "cancellation reason must be recorded", state.CancellationReason, "payment failed")
+	assert.That(t, "saga must be done", state.Done(), true)
+}
+
+func Test_SagaTracker_With_Authorization_Failure_Should_Fail_Authorization(t *testing.T) {
+	// Arrange
+	tracker, dispatcher := createSagaTracker(t)
+	triggerSagaEvent(t, dispatcher, reservation.EventTopicCreated, reservation.EventCreated{ReservationID: "res-001"})
+
+	// Act
+	triggerSagaEvent(t, dispatcher, payment.EventTopicFailed, payment.EventFailed{ReservationID: "res-001", ErrorCode: "gateway_error", ErrorMsg: "insufficient funds"})
+
+	// Assert
+	state, _ := tracker.GetSagaState(context.Background(), "res-001")
+	assert.That(t, "authorization must fail", state.Steps[1].Status, orchestration.SagaStepFailed)
+	assert.That(t, "saga must be failed", state.Failed(), true)
+}
+
+func Test_SagaTracker_With_Channel_Reservation_Should_Skip_Payment(t *testing.T) {
+	// Arrange
+	tracker, dispatcher := createSagaTracker(t)
+
+	// Act
+	triggerSagaEvent(t, dispatcher, reservation.EventTopicCreated, reservation.EventCreated{ReservationID: "booking.com-4711", Channel: "booking.com"})
+
+	// Assert
+	state, _ := tracker.GetSagaState(context.Background(), "booking.com-4711")
+	assert.That(t, "payment steps must be skipped", sagaStepStatuses(state), []orchestration.SagaStepStatus{
+		orchestration.SagaStepCompleted, orchestration.SagaStepSkipped, orchestration.SagaStepSkipped, orchestration.SagaStepCompleted,
+	})
+	assert.That(t, "saga must be done", state.Done(), true)
+}
+
+func Test_SagaTracker_StartSaga_Twice_Should_Keep_First_Mode(t *testing.T) {
+	// Arrange
+	tracker, _ := createSagaTracker(t)
+	ctx := context.Background()
+	_, _ = tracker.StartSaga(ctx, "res-001", orchestration.SagaModeEventDriven)
+
+	// Act
+	mode, err := tracker.StartSaga(ctx, "res-001", orchestration.SagaModeSynchronous)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "mode must be the first one", mode, orchestration.SagaModeEventDriven)
+	state, _ := tracker.GetSagaState(ctx, "res-001")
+	assert.That(t, "state must record the mode", state.Mode, orchestration.SagaModeEventDriven)
+}
+
+func Test_SagaTracker_Watch_Should_Receive_Updates(t *testing.T) {
+	// Arrange
+	tracker, dispatcher := createSagaTracker(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := tracker.Watch(ctx, "res-001")
+
+	// Act
+	triggerSagaEvent(t, dispatcher, reservation.EventTopicCreated, reservation.EventCreated{ReservationID: "res-001"})
+
+	// Assert
+	select {
+	case state := <-updates:
+		assert.That(t, "reservation step must be completed", state.Steps[0].Status, orchestration.SagaStepCompleted)
+	case <-time.After(time.Second):
+		t.Fatal("no update received")
+	}
+}
+
+func Test_SagaTracker_Watch_Should_Close_Channel_When_Context_Is_Done(t *testing.T) {
+	// Arrange
+	tracker, _ := createSagaTracker(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := tracker.Watch(ctx, "res-001")
+
+	// Act
+	cancel()
+
+	// Assert
+	select {
+	case _, ok := <-updates:
+		assert.That(t, "channel must be closed", ok, false)
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed")
+	}
+}
@@ -0,0 +1,205 @@
+package orchestration
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// confirmationTTL is how long the confirmation token of a booking summary is valid.
+const confirmationTTL = 15 * time.Minute
+
+var (
+	ErrGuestRequired        = errors.New("booking requires a signed-in guest")
+	ErrConfirmationRequired = errors.New("booking requires the confirmation_token of its summary")
+	ErrConfirmationInvalid  = errors.New("confirmation_token does not match the booking or has expired")
+)
+
+// guestIDContextKey is the context key of the signed-in guest of a tool call.
+type guestIDContextKey struct{}
+
+// WithGuestID returns a copy of ctx that carries the signed-in guest of a tool call.
+// The inbound adapter sets it from the verified token, like the UI uses the session.
+func WithGuestID(ctx context.Context, guestID reservation.GuestID) context.Context {
+	return context.WithValue(ctx, guestIDContextKey{}, guestID)
+}
+
+// GuestIDFromContext returns the signed-in guest added by WithGuestID.
+func GuestIDFromContext(ctx context.Context) (reservation.GuestID, bool) {
+	guestID, ok := ctx.Value(guestIDContextKey{}).(reservation.GuestID)
+	return guestID, ok && guestID != ""
+}
+
+// RegisterTools registers all booking assistant MCP tools with the server.
+// Together with the reservation tools (check_availability, get_reservation)
+// they allow an AI client to walk a guest through a booking conversationally.
+// Stays are priced from the room rates, and the secret signs the confirmation
+// tokens of booking summaries.
+func RegisterTools(server *mcp.Server, service *BookingService, rates *reservation.RoomRates, secret []byte) {
+	server.RegisterTool(newInitiateBookingTool(service, rates, secret))
+	server.RegisterTool(newCancelReservationTool(service))
+}
+
+// newCancelReservationTool creates a tool for cancelling reservations.
+// The booking is cancelled through the saga, so its payments are refunded.
+func newCancelReservationTool(service *BookingService) mcp.Tool {
+	return mcp.NewTool(
+		"cancel_reservation",
+		"Cancel a reservation and refund its payments. Requires a reason. Cannot cancel within 24 hours of check-in.",
+		mcp.NewObjectSchema(
+			map[string]mcp.Property{
+				"id":     mcp.NewStringProperty("The reservation ID"),
+				"reason": mcp.NewStringProperty("Reason for cancellation"),
+			},
+			[]string{"id", "reason"},
+		),
+		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			id, _ := params.Arguments["id"].(string)
+			reason, _ := params.Arguments["reason"].(string)
+			if err := service.CancelBookingWithRefund(ctx, shared.ReservationID(id), reason); err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			return mcp.ToolsCallResult{
+				Content: []mcp.ContentBlock{mcp.NewTextContent("Reservation cancelled successfully")},
+			}, nil
+		},
+	)
+}
+
+// newInitiateBookingTool creates a tool for starting the booking saga.
+// As a guardrail the tool only returns a summary with a confirmation token, and
+// books only if it is called again with confirm=true and that token, because a
+// confirmed booking triggers payment authorization and capture. The amount is
+// computed from the room rate, and the booking belongs to the signed-in guest.
+func newInitiateBookingTool(service *BookingService, rates *reservation.RoomRates, secret []byte) mcp.Tool {
+	return mcp.NewTool(
+		"initiate_booking",
+		"Book a room for the signed-in guest. Without confirm=true only a summary with the price and a confirmation_token is returned; "+
+			"ask the guest to confirm before calling again with the same details, confirm=true and the confirmation_token, which charges the payment.",
+		mcp.NewObjectSchema(
+			map[string]mcp.Property{
+				"room_id":            mcp.NewStringProperty("The room ID"),
+				"check_in":           mcp.NewStringProperty("Check-in date (RFC3339 format, e.g. 2024-01-15T14:00:00Z)"),
+				"check_out":          mcp.NewStringProperty("Check-out date (RFC3339 format, e.g. 2024-01-17T11:00:00Z)"),
+				"guest_name":         mcp.NewStringProperty("The guest's full name"),
+				"guest_email":        mcp.NewStringProperty("The guest's email address"),
+				"guest_phone":        mcp.NewStringProperty("The guest's phone number"),
+				"confirm":            mcp.NewBooleanProperty("Set to true only after the guest confirmed the summary"),
+				"confirmation_token": mcp.NewStringProperty("The confirmation_token of the summary the guest confirmed"),
+			},
+			[]string{"room_id", "check_in", "check_out", "guest_name", "guest_email"},
+		),
+		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			roomID, _ := params.Arguments["room_id"].(string)
+			checkInStr, _ := params.Arguments["check_in"].(string)
+			checkOutStr, _ := params.Arguments["check_out"].(string)
+			guestName, _ := params.Arguments["guest_name"].(string)
+			guestEmail, _ := params.Arguments["guest_email"].(string)
+			guestPhone, _ := params.Arguments["guest_phone"].(string)
+			confirm, _ := params.Arguments["confirm"].(bool)
+			token, _ := params.Arguments["confirmation_token"].(string)
+
+			guestID, ok := GuestIDFromContext(ctx)
+			if !ok {
+				return mcp.ToolsCallResult{}, ErrGuestRequired
+			}
+			checkIn, err := time.Parse(time.RFC3339, checkInStr)
+			if err != nil {
+				return mcp.ToolsCallResult{}, fmt.Errorf("invalid check_in date format: %w", err)
+			}
+			checkOut, err := time.Parse(time.RFC3339, checkOutStr)
+			if err != nil {
+				return mcp.ToolsCallResult{}, fmt.Errorf("invalid check_out date format: %w", err)
+			}
+			if guestName == "" || guestEmail == "" {
+				return mcp.ToolsCallResult{}, fmt.Errorf("guest_name and guest_email are required")
+			}
+			guest, err := reservation.NewGuestInfo(guestName, guestEmail, guestPhone)
+			if err != nil {
+				return mcp.ToolsCallResult{}, fmt.Errorf("invalid guest: %w", err)
+			}
+
+			dateRange := reservation.NewDateRange(checkIn, checkOut)
+			total, err := rates.Quote(reservation.RoomID(roomID), dateRange)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			summary := fmt.Sprintf("Room %s for %s (%s) from %s to %s, total %s",
+				roomID, guestName, guest.Email, checkInStr, checkOutStr, total.FormatAmount())
+			details := strings.Join([]string{
+				string(guestID), roomID, checkIn.Format(time.RFC3339), checkOut.Format(time.RFC3339),
+				guest.Name, string(guest.Email), string(guest.PhoneNumber), strconv.FormatInt(total.Amount, 10), total.Currency,
+			}, "|")
+
+			if !confirm {
+				return mcp.ToolsCallResult{
+					Content: []mcp.ContentBlock{mcp.NewTextContent(
+						"Booking not placed. Please confirm with the guest: " + summary +
+							". Call again with the same details, confirm=true and confirmation_token=" +
+							issueConfirmationToken(secret, details, time.Now()) + " to book and charge the payment.")},
+				}, nil
+			}
+			if token == "" {
+				return mcp.ToolsCallResult{}, ErrConfirmationRequired
+			}
+			if !verifyConfirmationToken(secret, token, details, time.Now()) {
+				return mcp.ToolsCallResult{}, ErrConfirmationInvalid
+			}
+
+			res, err := service.InitiateBooking(
+				ctx,
+				shared.NewReservationID(service.ids),
+				guestID,
+				reservation.RoomID(roomID),
+				dateRange,
+				total,
+				[]reservation.GuestInfo{guest},
+			)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+
+			result := fmt.Sprintf("Booking %s initiated: %s. Payment is processed automatically; use get_reservation to follow its status.",
+				res.ID, summary)
+			return mcp.ToolsCallResult{
+				Content: []mcp.ContentBlock{mcp.NewTextContent(result)},
+			}, nil
+		},
+	)
+}
+
+// issueConfirmationToken signs the details of a booking summary with its expiry.
+func issueConfirmationToken(secret []byte, details string, now time.Time) string {
+	expires := strconv.FormatInt(now.Add(confirmationTTL).Unix(), 10)
+	return expires + "." + signConfirmation(secret, details, expires)
+}
+
+// verifyConfirmationToken reports whether the token was issued for the details and is not expired.
+func verifyConfirmationToken(secret []byte, token, details string, now time.Time) bool {
+	expires, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !now.Before(time.Unix(unix, 0)) {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(signConfirmation(secret, details, expires)))
+}
+
+// signConfirmation returns the base64url-encoded HMAC-SHA256 of the details and expiry.
+func signConfirmation(secret []byte, details, expires string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(details + "|" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
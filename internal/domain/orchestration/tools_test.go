@@ -0,0 +1,378 @@
+package orchestration_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func findBookingTool(t *testing.T, server *mcp.Server, name string) mcp.Tool {
+	t.Helper()
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == name {
+			return tool
+		}
+	}
+	t.Fatalf("tool %s not registered", name)
+	return mcp.Tool{}
+}
+
+func registerBookingTools(server *mcp.Server, service *orchestration.BookingService) {
+	rates := reservation.NewRoomRates().WithRate("room-101", shared.NewMoney(9900, "USD"))
+	orchestration.RegisterTools(server, service, rates, []byte("secret"))
+}
+
+// guestContext returns the context of a tool call by the signed-in guest.
+func guestContext() context.Context {
+	return orchestration.WithGuestID(context.Background(), "john@example.com")
+}
+
+var confirmationTokenPattern = regexp.MustCompile(`confirmation_token=(\S+) `)
+
+// confirmedBookingToolArguments asks for the summary of the booking and returns
+// the arguments that confirm it with the token of the summary.
+func confirmedBookingToolArguments(t *testing.T, tool mcp.Tool) map[string]any {
+	t.Helper()
+	args := validBookingToolArguments()
+	result, err := tool.Handler(guestContext(), mcp.ToolsCallParams{Name: "initiate_booking", Arguments: args})
+	if err != nil {
+		t.Fatalf("failed to get the booking summary: %v", err)
+	}
+	match := confirmationTokenPattern.FindStringSubmatch(result.Content[0].Text)
+	if match == nil {
+		t.Fatal("summary must contain a confirmation token")
+	}
+	args["confirm"] = true
+	args["confirmation_token"] = match[1]
+	return args
+}
+
+func validBookingToolArguments() map[string]any {
+	checkIn := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
+	checkOut := checkIn.Add(72 * time.Hour)
+	return map[string]any{
+		"room_id":     "room-101",
+		"check_in":    checkIn.Format(time.RFC3339),
+		"check_out":   checkOut.Format(time.RFC3339),
+		"guest_name":  "John Doe",
+		"guest_email": "john@example.com",
+		"guest_phone": "+1234567890",
+	}
+}
+
+// ============================================================================
+// RegisterTools Tests
+// ============================================================================
+
+func Test_RegisterTools_Should_Register_Booking_Tools(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	server := mcp.NewServer("test-server", "1.0.0")
+
+	// Act
+	registerBookingTools(server, svc.bookingService)
+
+	// Assert
+	tools := server.Tools()
+	assert.That(t, "must register 2 tools", len(tools), 2)
+	toolNames := make(map[string]bool)
+	for _, tool := range tools {
+		toolNames[tool.Definition.Name] = true
+	}
+	assert.That(t, "initiate_booking must be registered", toolNames["initiate_booking"], true)
+	assert.That(t, "cancel_reservation must be registered", toolNames["cancel_reservation"], true)
+}
+
+// ============================================================================
+// InitiateBooking Tool Tests
+// ============================================================================
+
+func Test_InitiateBookingTool_Without_Confirm_Should_Not_Create_Reservation(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "initiate_booking")
+
+	// Act
+	result, err := tool.Handler(guestContext(), mcp.ToolsCallParams{
+		Name:      "initiate_booking",
+		Arguments: validBookingToolArguments(),
+	})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "result must ask for confirmation", strings.Contains(result.Content[0].Text, "confirm=true"), true)
+	assert.That(t, "result must contain a confirmation token", confirmationTokenPattern.MatchString(result.Content[0].Text), true)
+	assert.That(t, "result must contain the computed total", strings.Contains(result.Content[0].Text, "297.00"), true)
+	assert.That(t, "no reservation must be stored", len(svc.reservationRepo.reservations), 0)
+	assert.That(t, "no event must be published", len(svc.reservationPub.published), 0)
+}
+
+func Test_InitiateBookingTool_With_Confirmation_Token_Should_Create_Reservation(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "initiate_booking")
+	args := confirmedBookingToolArguments(t, tool)
+	args["amount"] = float64(1)
+
+	// Act
+	result, err := tool.Handler(guestContext(), mcp.ToolsCallParams{
+		Name:      "initiate_booking",
+		Arguments: args,
+	})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "result must report initiation", strings.Contains(result.Content[0].Text, "initiated"), true)
+	assert.That(t, "one reservation must be stored", len(svc.reservationRepo.reservations), 1)
+	for _, res := range svc.reservationRepo.reservations {
+		assert.That(t, "guest ID must be the signed-in guest", string(res.GuestID), "john@example.com")
+		assert.That(t, "amount must be computed from the room rate", res.TotalAmount, shared.NewMoney(29700, "USD"))
+	}
+}
+
+func Test_InitiateBookingTool_With_Confirm_Without_Token_Should_Return_ErrConfirmationRequired(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "initiate_booking")
+	args := validBookingToolArguments()
+	args["confirm"] = true
+
+	// Act
+	_, err := tool.Handler(guestContext(), mcp.ToolsCallParams{
+		Name:      "initiate_booking",
+		Arguments: args,
+	})
+
+	// Assert
+	assert.That(t, "error must be ErrConfirmationRequired", errors.Is(err, orchestration.ErrConfirmationRequired), true)
+	assert.That(t, "no reservation must be stored", len(svc.reservationRepo.reservations), 0)
+}
+
+func Test_InitiateBookingTool_With_Token_Of_Other_Booking_Should_Return_ErrConfirmationInvalid(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "initiate_booking")
+	args := confirmedBookingToolArguments(t, tool)
+	checkOut, _ := time.Parse(time.RFC3339, args["check_out"].(string))
+	args["check_out"] = checkOut.AddDate(0, 0, 1).Format(time.RFC3339)
+
+	// Act
+	_, err := tool.Handler(guestContext(), mcp.ToolsCallParams{
+		Name:      "initiate_booking",
+		Arguments: args,
+	})
+
+	// Assert
+	assert.That(t, "error must be ErrConfirmationInvalid", errors.Is(err, orchestration.ErrConfirmationInvalid), true)
+	assert.That(t, "no reservation must be stored", len(svc.reservationRepo.reservations), 0)
+}
+
+func Test_InitiateBookingTool_With_Token_Of_Other_Guest_Should_Return_ErrConfirmationInvalid(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "initiate_booking")
+	args := confirmedBookingToolArguments(t, tool)
+
+	// Act
+	_, err := tool.Handler(orchestration.WithGuestID(context.Background(), "jane@example.com"), mcp.ToolsCallParams{
+		Name:      "initiate_booking",
+		Arguments: args,
+	})
+
+	// Assert
+	assert.That(t, "error must be ErrConfirmationInvalid", errors.Is(err, orchestration.ErrConfirmationInvalid), true)
+	assert.That(t, "no reservation must be stored", len(svc.reservationRepo.reservations), 0)
+}
+
+func Test_InitiateBookingTool_Without_Signed_In_Guest_Should_Return_ErrGuestRequired(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "initiate_booking")
+
+	// Act
+	_, err := tool.Handler(context.Background(), mcp.ToolsCallParams{
+		Name:      "initiate_booking",
+		Arguments: validBookingToolArguments(),
+	})
+
+	// Assert
+	assert.That(t, "error must be ErrGuestRequired", errors.Is(err, orchestration.ErrGuestRequired), true)
+}
+
+func Test_InitiateBookingTool_With_Unknown_Room_Should_Return_ErrRoomNotPriced(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "initiate_booking")
+	args := validBookingToolArguments()
+	args["room_id"] = "room-999"
+
+	// Act
+	_, err := tool.Handler(guestContext(), mcp.ToolsCallParams{
+		Name:      "initiate_booking",
+		Arguments: args,
+	})
+
+	// Assert
+	assert.That(t, "error must be ErrRoomNotPriced", errors.Is(err, reservation.ErrRoomNotPriced), true)
+	assert.That(t, "no reservation must be stored", len(svc.reservationRepo.reservations), 0)
+}
+
+type fixedIDGenerator struct {
+	id string
+}
+
+func (g fixedIDGenerator) NewID() string { return g.id }
+
+func Test_InitiateBookingTool_With_Confirm_Should_Use_Injected_IDGenerator(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.bookingService.WithIDGenerator(fixedIDGenerator{id: "res-fixed"})
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "initiate_booking")
+	args := confirmedBookingToolArguments(t, tool)
+
+	// Act
+	result, err := tool.Handler(guestContext(), mcp.ToolsCallParams{
+		Name:      "initiate_booking",
+		Arguments: args,
+	})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "result must contain the generated ID", strings.Contains(result.Content[0].Text, "res-fixed"), true)
+	_, exists := svc.reservationRepo.reservations["res-fixed"]
+	assert.That(t, "reservation must use the generated ID", exists, true)
+}
+
+func Test_InitiateBookingTool_With_Invalid_Date_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "initiate_booking")
+	args := validBookingToolArguments()
+	args["check_in"] = "not-a-date"
+
+	// Act
+	_, err := tool.Handler(guestContext(), mcp.ToolsCallParams{
+		Name:      "initiate_booking",
+		Arguments: args,
+	})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_InitiateBookingTool_With_Invalid_Guest_Email_Should_Return_Field_Error(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "initiate_booking")
+	args := validBookingToolArguments()
+	args["guest_email"] = "john.example.com"
+	args["confirm"] = true
+
+	// Act
+	_, err := tool.Handler(guestContext(), mcp.ToolsCallParams{
+		Name:      "initiate_booking",
+		Arguments: args,
+	})
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidEmail", errors.Is(err, reservation.ErrInvalidEmail), true)
+	assert.That(t, "no reservation must be stored", len(svc.reservationRepo.reservations), 0)
+}
+
+func Test_InitiateBookingTool_When_Room_Unavailable_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.availabilityCheck.available = false
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "initiate_booking")
+	args := confirmedBookingToolArguments(t, tool)
+
+	// Act
+	_, err := tool.Handler(guestContext(), mcp.ToolsCallParams{
+		Name:      "initiate_booking",
+		Arguments: args,
+	})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+// ============================================================================
+// CancelReservation Tool Tests
+// ============================================================================
+
+func Test_CancelReservationTool_With_Captured_Payment_Should_Refund_Payment(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	completeTestBooking(t, svc)
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "cancel_reservation")
+	ctx := context.Background()
+
+	// Act
+	result, err := tool.Handler(ctx, mcp.ToolsCallParams{
+		Name:      "cancel_reservation",
+		Arguments: map[string]any{"id": "res-001", "reason": "guest requested"},
+	})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "content must confirm cancellation", strings.Contains(result.Content[0].Text, "cancelled"), true)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
+	storedPay, _ := svc.paymentRepo.Read(ctx, "pay-001")
+	assert.That(t, "payment must be refunded", storedPay.Status, payment.StatusRefunded)
+}
+
+func Test_CancelReservationTool_When_Not_Found_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	server := mcp.NewServer("test-server", "1.0.0")
+	registerBookingTools(server, svc.bookingService)
+	tool := findBookingTool(t, server, "cancel_reservation")
+
+	// Act
+	_, err := tool.Handler(context.Background(), mcp.ToolsCallParams{
+		Name:      "cancel_reservation",
+		Arguments: map[string]any{"id": "unknown", "reason": "guest requested"},
+	})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
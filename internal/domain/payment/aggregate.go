@@ -12,12 +12,31 @@ import (
 )
 
 // Type aliases for shared types
-type ReservationID = shared.ReservationID
 type Money = shared.Money
 
+// ReservationID is the reservation a payment belongs to, as seen by the Payment context.
+// Other contexts refer to reservations by shared.ReservationID and translate
+// at the boundary via ToReservationID and Shared.
+type ReservationID string
+
+// ToReservationID translates a shared kernel reservation ID into this context.
+func ToReservationID(id shared.ReservationID) ReservationID {
+	return ReservationID(id)
+}
+
+// Shared translates the ID into the shared kernel for other contexts.
+func (id ReservationID) Shared() shared.ReservationID {
+	return shared.ReservationID(id)
+}
+
 // PaymentID is a strongly-typed identifier for payments.
 type PaymentID string
 
+// NewPaymentID creates a new PaymentID using the given generator.
+func NewPaymentID(ids shared.IDGenerator) PaymentID {
+	return PaymentID(ids.NewID())
+}
+
 // PaymentStatus represents the state of a payment.
 type PaymentStatus string
 
@@ -27,19 +46,38 @@ const (
 	StatusCaptured   PaymentStatus = "captured"
 	StatusFailed     PaymentStatus = "failed"
 	StatusRefunded   PaymentStatus = "refunded"
+	StatusVoided     PaymentStatus = "voided"
+)
+
+// PaymentKind is what a payment is charged for.
+type PaymentKind string
+
+const (
+	KindBooking PaymentKind = "booking" // Amount due at booking, charged by the booking saga
+	KindDeposit PaymentKind = "deposit" // Security deposit held for incidentals
+	KindBalance PaymentKind = "balance" // Balance of a payment schedule, charged before check-in
+	KindAddOn   PaymentKind = "add_on"  // Add-on booked after the reservation was paid
 )
 
 // Payment is the aggregate root for payment processing.
+// It records a domain event for each status transition.
 type Payment struct {
-	ID            PaymentID
-	ReservationID ReservationID
-	Amount        Money
-	Status        PaymentStatus
-	PaymentMethod string
-	TransactionID string // External payment gateway transaction ID
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	Attempts      []PaymentAttempt
+	shared.Aggregate
+	ID             PaymentID
+	ReservationID  ReservationID
+	Amount         Money
+	Status         PaymentStatus
+	PaymentMethod  string
+	TransactionID  string      // External payment gateway transaction ID
+	MethodToken    string      // Gateway token of the stored payment method charged, empty otherwise
+	Kind           PaymentKind // What the payment is charged for; payments stored without a kind are booking payments
+	Incidentals    Money       // Charges against a deposit, captured when it is released
+	CapturedAmount Money       // Amount taken from the guest; less than Amount after a partial capture
+	RefundedAmount Money       // Amount returned to the guest; less than Amount after a partial refund
+	Dispute        *Dispute    // Latest chargeback raised by the guest's card issuer, nil if never disputed
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Attempts       []PaymentAttempt
 }
 
 // Payment errors.
@@ -51,8 +89,58 @@ var (
 	ErrNotCaptured              = errors.New("payment not captured")
 	ErrAlreadyRefunded          = errors.New("payment already refunded")
 	ErrCannotRefund             = errors.New("can only refund captured payments")
+	ErrInvalidRefundAmount      = errors.New("refund must be positive and not exceed the payment amount")
+	ErrInvalidCaptureAmount     = errors.New("capture must be positive and not exceed the payment amount")
+	ErrAlreadyVoided            = errors.New("payment already voided")
+	ErrCannotVoid               = errors.New("can only void authorized payments")
+	ErrNotDeposit               = errors.New("payment is not a deposit")
+	ErrIncidentalsExceedDeposit = errors.New("incidentals must be positive and not exceed the deposit")
+	ErrPaymentNotFound          = errors.New("payment not found")
+	ErrRetriesExhausted         = errors.New("payment failed too often to be retried")
 )
 
+// paymentStates holds the status transitions of payments.
+// Failed payments may be authorized again when the payment is retried.
+// Voiding releases an authorization without taking any funds, also after its capture failed.
+var paymentStates = shared.NewStateMachine[PaymentStatus](ErrInvalidPaymentTransition).
+	Allow(StatusPending, StatusAuthorized, StatusFailed).
+	Allow(StatusFailed, StatusAuthorized, StatusFailed, StatusVoided).
+	Allow(StatusAuthorized, StatusCaptured, StatusFailed, StatusVoided).
+	Allow(StatusCaptured, StatusRefunded).
+	Guard(StatusAuthorized, func(from PaymentStatus) error {
+		if from == StatusAuthorized {
+			return ErrAlreadyAuthorized
+		}
+		return nil
+	}).
+	Guard(StatusCaptured, func(from PaymentStatus) error {
+		switch from {
+		case StatusCaptured:
+			return ErrAlreadyCaptured
+		case StatusAuthorized:
+			return nil
+		}
+		return ErrNotAuthorized
+	}).
+	Guard(StatusRefunded, func(from PaymentStatus) error {
+		switch from {
+		case StatusRefunded:
+			return ErrAlreadyRefunded
+		case StatusCaptured:
+			return nil
+		}
+		return ErrCannotRefund
+	}).
+	Guard(StatusVoided, func(from PaymentStatus) error {
+		switch from {
+		case StatusVoided:
+			return ErrAlreadyVoided
+		case StatusAuthorized, StatusFailed:
+			return nil
+		}
+		return ErrCannotVoid
+	})
+
 // NewPayment creates a new payment in pending status.
 func NewPayment(id PaymentID, reservationID ReservationID, amount Money, method string) *Payment {
 	return &Payment{
@@ -61,77 +149,242 @@ func NewPayment(id PaymentID, reservationID ReservationID, amount Money, method
 		Amount:        amount,
 		Status:        StatusPending,
 		PaymentMethod: method,
+		Kind:          KindBooking,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 		Attempts:      []PaymentAttempt{},
 	}
 }
 
+// NewDeposit creates a new security deposit in pending status.
+func NewDeposit(id PaymentID, reservationID ReservationID, amount Money, method string) *Payment {
+	p := NewPayment(id, reservationID, amount, method)
+	p.Kind = KindDeposit
+	return p
+}
+
+// NewBalancePayment creates a new balance payment of a payment schedule in pending status.
+func NewBalancePayment(id PaymentID, reservationID ReservationID, amount Money, method string) *Payment {
+	p := NewPayment(id, reservationID, amount, method)
+	p.Kind = KindBalance
+	return p
+}
+
+// NewAddOnPayment creates a new payment of an add-on booked after the reservation was paid, in pending status.
+func NewAddOnPayment(id PaymentID, reservationID ReservationID, amount Money, method string) *Payment {
+	p := NewPayment(id, reservationID, amount, method)
+	p.Kind = KindAddOn
+	return p
+}
+
+// ChargeStoredMethod charges the payment to a stored payment method: the gateway
+// receives the method's token instead of card details.
+func (p *Payment) ChargeStoredMethod(method *PaymentMethod) {
+	p.PaymentMethod = string(method.ID)
+	p.MethodToken = method.Token
+}
+
 // Authorize transitions the payment to authorized status.
+// Deposits record their own event, so the hold does not drive the booking saga.
+// Balance and add-on payments are captured right away, so only their capture or failure is recorded.
 func (p *Payment) Authorize(transactionID string) error {
-	if p.Status == StatusAuthorized {
-		return ErrAlreadyAuthorized
-	}
-
-	if p.Status != StatusPending && p.Status != StatusFailed {
-		return fmt.Errorf("%w: cannot authorize from %s", ErrInvalidPaymentTransition, p.Status)
+	if err := paymentStates.Transition(p.Status, StatusAuthorized); err != nil {
+		return err
 	}
 
 	p.Status = StatusAuthorized
 	p.TransactionID = transactionID
 	p.UpdatedAt = time.Now()
 	p.addAttempt(StatusAuthorized, "", "")
+	switch p.Kind {
+	case KindBalance, KindAddOn:
+		// Captured right away, so only the capture or failure is recorded.
+	case KindDeposit:
+		p.RecordEvent(NewEventDepositHeld().
+			WithPaymentID(p.ID).
+			WithReservationID(p.ReservationID).
+			WithAmount(p.Amount).
+			WithTransactionID(transactionID))
+	default:
+		p.RecordEvent(NewEventAuthorized().
+			WithPaymentID(p.ID).
+			WithReservationID(p.ReservationID).
+			WithAmount(p.Amount).
+			WithTransactionID(transactionID))
+	}
 
 	return nil
 }
 
 // Capture transitions the payment to captured status (finalizes the payment).
 func (p *Payment) Capture() error {
-	if p.Status == StatusCaptured {
-		return ErrAlreadyCaptured
-	}
+	return p.CapturePartially(p.Amount)
+}
 
-	if p.Status != StatusAuthorized {
-		return ErrNotAuthorized
+// CapturePartially transitions the payment to captured status, taking only the
+// given amount from the guest. The rest of the authorization is released.
+// Deposits, balance and add-on payments record their own event, so the capture does not confirm a reservation.
+func (p *Payment) CapturePartially(amount Money) error {
+	if err := paymentStates.Transition(p.Status, StatusCaptured); err != nil {
+		return err
+	}
+	if amount.Currency != p.Amount.Currency || amount.Amount <= 0 || amount.Amount > p.Amount.Amount {
+		return ErrInvalidCaptureAmount
 	}
 
 	p.Status = StatusCaptured
+	p.CapturedAmount = amount
 	p.UpdatedAt = time.Now()
 	p.addAttempt(StatusCaptured, "", "")
+	switch p.Kind {
+	case KindAddOn:
+		p.RecordEvent(NewEventAddOnCaptured().
+			WithPaymentID(p.ID).
+			WithReservationID(p.ReservationID).
+			WithAmount(amount))
+	case KindBalance:
+		p.RecordEvent(NewEventBalanceCaptured().
+			WithPaymentID(p.ID).
+			WithReservationID(p.ReservationID).
+			WithAmount(amount))
+	case KindDeposit:
+		p.RecordEvent(NewEventDepositCaptured().
+			WithPaymentID(p.ID).
+			WithReservationID(p.ReservationID).
+			WithAmount(amount))
+	default:
+		p.RecordEvent(NewEventCaptured().
+			WithPaymentID(p.ID).
+			WithReservationID(p.ReservationID).
+			WithAmount(amount))
+	}
+
+	return nil
+}
+
+// CaptureAmount returns the amount taken from the guest by the capture.
+// Payments captured before partial captures existed took the full amount.
+func (p *Payment) CaptureAmount() Money {
+	if p.CapturedAmount == (Money{}) {
+		return p.Amount
+	}
+	return p.CapturedAmount
+}
+
+// Void releases an authorized payment without taking any funds. A payment whose
+// capture failed can be voided as well, but not one that was never authorized.
+func (p *Payment) Void() error {
+	if err := paymentStates.Transition(p.Status, StatusVoided); err != nil {
+		return err
+	}
+	if p.TransactionID == "" {
+		return ErrCannotVoid
+	}
+
+	p.Status = StatusVoided
+	p.UpdatedAt = time.Now()
+	p.addAttempt(StatusVoided, "", "")
+	p.RecordEvent(NewEventVoided().
+		WithPaymentID(p.ID).
+		WithReservationID(p.ReservationID).
+		WithAmount(p.Amount))
+
+	return nil
+}
+
+// AddIncidentals charges incidentals, such as minibar or damages, against an authorized deposit.
+// The charges are captured when the deposit is released and can never exceed the deposit.
+func (p *Payment) AddIncidentals(amount Money) error {
+	if p.Kind != KindDeposit {
+		return ErrNotDeposit
+	}
+	if p.Status != StatusAuthorized {
+		return fmt.Errorf("%w: deposit is %s", ErrNotAuthorized, p.Status)
+	}
+	total := p.Incidentals.Amount + amount.Amount
+	if amount.Currency != p.Amount.Currency || amount.Amount <= 0 || total > p.Amount.Amount {
+		return ErrIncidentalsExceedDeposit
+	}
 
+	p.Incidentals = shared.NewMoney(total, p.Amount.Currency)
+	p.UpdatedAt = time.Now()
 	return nil
 }
 
 // Fail marks the payment as failed with error details.
+// Failed balance and add-on payments record their own event, so they do not cancel the reservation.
 func (p *Payment) Fail(errorCode, errorMsg string) error {
-	if p.Status == StatusCaptured || p.Status == StatusRefunded {
-		return fmt.Errorf("%w: cannot fail from %s", ErrInvalidPaymentTransition, p.Status)
+	if err := paymentStates.Transition(p.Status, StatusFailed); err != nil {
+		return err
 	}
 
 	p.Status = StatusFailed
 	p.UpdatedAt = time.Now()
 	p.addAttempt(StatusFailed, errorCode, errorMsg)
+	switch p.Kind {
+	case KindAddOn:
+		p.RecordEvent(NewEventAddOnFailed().
+			WithPaymentID(p.ID).
+			WithReservationID(p.ReservationID).
+			WithErrorCode(errorCode).
+			WithErrorMsg(errorMsg))
+	case KindBalance:
+		p.RecordEvent(NewEventBalanceFailed().
+			WithPaymentID(p.ID).
+			WithReservationID(p.ReservationID).
+			WithErrorCode(errorCode).
+			WithErrorMsg(errorMsg))
+	default:
+		p.RecordEvent(NewEventFailed().
+			WithPaymentID(p.ID).
+			WithReservationID(p.ReservationID).
+			WithErrorCode(errorCode).
+			WithErrorMsg(errorMsg))
+	}
 
 	return nil
 }
 
 // Refund transitions the payment to refunded status.
 func (p *Payment) Refund() error {
-	if p.Status == StatusRefunded {
-		return ErrAlreadyRefunded
-	}
+	return p.RefundPartially(p.Amount)
+}
 
-	if p.Status != StatusCaptured {
-		return ErrCannotRefund
+// RefundPartially transitions the payment to refunded status, returning only
+// the given amount to the guest. The rest of the payment is kept, e.g. as a fee.
+// A disputed payment is not refunded: the issuer holds the funds until it decides.
+func (p *Payment) RefundPartially(amount Money) error {
+	if err := p.checkDispute(); err != nil {
+		return err
+	}
+	if err := paymentStates.Transition(p.Status, StatusRefunded); err != nil {
+		return err
+	}
+	if amount.Currency != p.Amount.Currency || amount.Amount <= 0 || amount.Amount > p.Amount.Amount {
+		return ErrInvalidRefundAmount
 	}
 
 	p.Status = StatusRefunded
+	p.RefundedAmount = amount
 	p.UpdatedAt = time.Now()
 	p.addAttempt(StatusRefunded, "", "")
+	p.RecordEvent(NewEventRefunded().
+		WithPaymentID(p.ID).
+		WithReservationID(p.ReservationID).
+		WithAmount(amount))
 
 	return nil
 }
 
+// RefundAmount returns the amount returned to the guest by the refund.
+// Payments refunded before partial refunds existed returned the full amount.
+func (p *Payment) RefundAmount() Money {
+	if p.RefundedAmount == (Money{}) {
+		return p.Amount
+	}
+	return p.RefundedAmount
+}
+
 // IsSuccessful returns true if the payment was successfully captured.
 func (p *Payment) IsSuccessful() bool {
 	return p.Status == StatusCaptured
@@ -139,7 +392,7 @@ func (p *Payment) IsSuccessful() bool {
 
 // CanBeRetried returns true if the payment can be retried.
 func (p *Payment) CanBeRetried() bool {
-	if p.Status != StatusFailed && p.Status != StatusPending {
+	if !paymentStates.Can(p.Status, StatusAuthorized) {
 		return false
 	}
 
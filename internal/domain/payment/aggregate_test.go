@@ -1,6 +1,7 @@
 package payment_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
@@ -127,6 +128,34 @@ func Test_Payment_Authorize_From_Failed_Should_Succeed(t *testing.T) {
 	assert.That(t, "status must be authorized", p.Status, payment.StatusAuthorized)
 }
 
+// ============================================================================
+// Domain Event Recording Tests
+// ============================================================================
+
+func Test_Payment_Transitions_Should_Record_Domain_Events(t *testing.T) {
+	// Arrange
+	pay := createValidPayment()
+
+	// Act
+	_ = pay.Authorize("tx-12345")
+	_ = pay.Capture()
+	_ = pay.Capture()
+	_ = pay.Refund()
+	events := pay.PullEvents()
+
+	// Assert
+	topics := make([]string, 0, len(events))
+	for _, evt := range events {
+		topics = append(topics, evt.Topic())
+	}
+	assert.That(t, "events of applied transitions must be recorded in order", topics, []string{
+		payment.EventTopicAuthorized, payment.EventTopicCaptured, payment.EventTopicRefunded,
+	})
+	authorized, _ := events[0].(*payment.EventAuthorized)
+	assert.That(t, "authorized event must carry the transaction", authorized.TransactionID, "tx-12345")
+	assert.That(t, "events must be pulled once", len(pay.PullEvents()), 0)
+}
+
 // ============================================================================
 // State Transition Tests - Capture
 // ============================================================================
@@ -331,6 +360,137 @@ func Test_Payment_Refund_When_Already_Refunded_Should_Return_Error(t *testing.T)
 	assert.That(t, "status must remain refunded", p.Status, payment.StatusRefunded)
 }
 
+func Test_Payment_RefundPartially_Should_Record_Refunded_Amount(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-12345")
+	_ = p.Capture()
+
+	// Act
+	err := p.RefundPartially(shared.NewMoney(7500, "USD"))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be refunded", p.Status, payment.StatusRefunded)
+	assert.That(t, "refund amount must be the partial amount", p.RefundAmount(), shared.NewMoney(7500, "USD"))
+}
+
+func Test_Payment_RefundPartially_With_More_Than_Amount_Should_Return_Error(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-12345")
+	_ = p.Capture()
+
+	// Act
+	err := p.RefundPartially(shared.NewMoney(10001, "USD"))
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidRefundAmount", errors.Is(err, payment.ErrInvalidRefundAmount), true)
+	assert.That(t, "status must remain captured", p.Status, payment.StatusCaptured)
+}
+
+func Test_Payment_CapturePartially_Should_Record_Captured_Amount(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-12345")
+
+	// Act
+	err := p.CapturePartially(shared.NewMoney(2500, "USD"))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be captured", p.Status, payment.StatusCaptured)
+	assert.That(t, "capture amount must be the partial amount", p.CaptureAmount(), shared.NewMoney(2500, "USD"))
+}
+
+func Test_Payment_Void_From_Authorized_Should_Succeed(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-12345")
+	_ = p.PullEvents()
+
+	// Act
+	err := p.Void()
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be voided", p.Status, payment.StatusVoided)
+	events := p.PullEvents()
+	assert.That(t, "one event must be recorded", len(events), 1)
+	assert.That(t, "event must be voided", events[0].Topic(), payment.EventTopicVoided)
+}
+
+func Test_Payment_Void_From_Captured_Should_Return_Error(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-12345")
+	_ = p.Capture()
+
+	// Act
+	err := p.Void()
+
+	// Assert
+	assert.That(t, "error must be ErrCannotVoid", errors.Is(err, payment.ErrCannotVoid), true)
+}
+
+// ============================================================================
+// Deposit Tests
+// ============================================================================
+
+func Test_Deposit_Authorize_Should_Record_Deposit_Held_Event(t *testing.T) {
+	// Arrange
+	p := payment.NewDeposit("dep-001", "res-001", shared.NewMoney(20000, "USD"), "credit_card")
+
+	// Act
+	err := p.Authorize("tx-12345")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	events := p.PullEvents()
+	assert.That(t, "one event must be recorded", len(events), 1)
+	assert.That(t, "event must not drive the booking saga", events[0].Topic(), payment.EventTopicDepositHeld)
+}
+
+func Test_Deposit_AddIncidentals_Should_Accumulate_Charges(t *testing.T) {
+	// Arrange
+	p := payment.NewDeposit("dep-001", "res-001", shared.NewMoney(20000, "USD"), "credit_card")
+	_ = p.Authorize("tx-12345")
+
+	// Act
+	_ = p.AddIncidentals(shared.NewMoney(1500, "USD"))
+	err := p.AddIncidentals(shared.NewMoney(2000, "USD"))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "incidentals must be accumulated", p.Incidentals, shared.NewMoney(3500, "USD"))
+}
+
+func Test_Deposit_AddIncidentals_Exceeding_Deposit_Should_Return_Error(t *testing.T) {
+	// Arrange
+	p := payment.NewDeposit("dep-001", "res-001", shared.NewMoney(20000, "USD"), "credit_card")
+	_ = p.Authorize("tx-12345")
+	_ = p.AddIncidentals(shared.NewMoney(15000, "USD"))
+
+	// Act
+	err := p.AddIncidentals(shared.NewMoney(5001, "USD"))
+
+	// Assert
+	assert.That(t, "error must be ErrIncidentalsExceedDeposit", errors.Is(err, payment.ErrIncidentalsExceedDeposit), true)
+	assert.That(t, "incidentals must be unchanged", p.Incidentals, shared.NewMoney(15000, "USD"))
+}
+
+func Test_Payment_AddIncidentals_Should_Return_ErrNotDeposit(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-12345")
+
+	// Act
+	err := p.AddIncidentals(shared.NewMoney(1500, "USD"))
+
+	// Assert
+	assert.That(t, "error must be ErrNotDeposit", errors.Is(err, payment.ErrNotDeposit), true)
+}
+
 // ============================================================================
 // Business Logic Tests
 // ============================================================================
@@ -0,0 +1,163 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// DisputeID is the payment gateway's identifier of a dispute.
+type DisputeID string
+
+// DisputeStatus represents the state of a dispute.
+type DisputeStatus string
+
+const (
+	DisputeOpen              DisputeStatus = "open"
+	DisputeEvidenceSubmitted DisputeStatus = "evidence_submitted"
+	DisputeWon               DisputeStatus = "won"
+	DisputeLost              DisputeStatus = "lost"
+)
+
+// Dispute is a chargeback the guest raised with their card issuer (entity within Payment aggregate).
+// While it is open, the disputed funds are held by the issuer and the payment cannot be refunded.
+type Dispute struct {
+	ID                  DisputeID
+	Reason              string // Reason code reported by the gateway, e.g. "fraudulent"
+	Amount              Money
+	Status              DisputeStatus
+	Evidence            string
+	EvidenceDueBy       time.Time // Deadline of the gateway for evidence, zero if unknown
+	EvidenceSubmittedAt time.Time
+	OpenedAt            time.Time
+	ResolvedAt          time.Time
+}
+
+// Dispute errors.
+var (
+	ErrNoDispute             = errors.New("payment is not disputed")
+	ErrAlreadyDisputed       = errors.New("payment already has an open dispute")
+	ErrDisputeResolved       = errors.New("dispute already resolved")
+	ErrInvalidDisputeAmount  = errors.New("dispute must be positive and not exceed the captured amount")
+	ErrInvalidDisputeOutcome = errors.New("dispute outcome must be won or lost")
+	ErrMissingEvidence       = errors.New("evidence must not be empty")
+	ErrRefundsFrozen         = errors.New("refunds are frozen while the payment is disputed")
+	ErrPaymentChargedBack    = errors.New("payment was charged back to the guest")
+	ErrCannotDispute         = errors.New("can only dispute captured payments")
+)
+
+// disputeStates holds the status transitions of disputes.
+// Evidence may be submitted again until the issuer decides the dispute.
+var disputeStates = shared.NewStateMachine[DisputeStatus](ErrDisputeResolved).
+	Allow(DisputeOpen, DisputeEvidenceSubmitted, DisputeWon, DisputeLost).
+	Allow(DisputeEvidenceSubmitted, DisputeEvidenceSubmitted, DisputeWon, DisputeLost)
+
+// IsOpen reports whether the issuer has not decided the dispute yet.
+func (d *Dispute) IsOpen() bool {
+	return d.Status == DisputeOpen || d.Status == DisputeEvidenceSubmitted
+}
+
+// OpenDispute records a chargeback on a captured payment. A payment can only
+// carry one open dispute; a resolved dispute is replaced by a new one.
+func (p *Payment) OpenDispute(id DisputeID, reason string, amount Money, evidenceDueBy time.Time) error {
+	if p.Status != StatusCaptured && p.Status != StatusRefunded {
+		return fmt.Errorf("%w: payment is %s", ErrCannotDispute, p.Status)
+	}
+	if p.Dispute != nil && p.Dispute.IsOpen() {
+		return ErrAlreadyDisputed
+	}
+	if amount.Currency != p.Amount.Currency || amount.Amount <= 0 || amount.Amount > p.CaptureAmount().Amount {
+		return ErrInvalidDisputeAmount
+	}
+
+	now := time.Now()
+	p.Dispute = &Dispute{
+		ID:            id,
+		Reason:        reason,
+		Amount:        amount,
+		Status:        DisputeOpen,
+		EvidenceDueBy: evidenceDueBy,
+		OpenedAt:      now,
+	}
+	p.UpdatedAt = now
+	p.RecordEvent(NewEventDisputed().
+		WithPaymentID(p.ID).
+		WithReservationID(p.ReservationID).
+		WithDisputeID(id).
+		WithReason(reason).
+		WithAmount(amount))
+
+	return nil
+}
+
+// SubmitDisputeEvidence records the evidence submitted to contest the dispute.
+// Submitting again replaces the evidence.
+func (p *Payment) SubmitDisputeEvidence(evidence string) error {
+	if p.Dispute == nil {
+		return ErrNoDispute
+	}
+	if evidence == "" {
+		return ErrMissingEvidence
+	}
+	if err := disputeStates.Transition(p.Dispute.Status, DisputeEvidenceSubmitted); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	p.Dispute.Status = DisputeEvidenceSubmitted
+	p.Dispute.Evidence = evidence
+	p.Dispute.EvidenceSubmittedAt = now
+	p.UpdatedAt = now
+	return nil
+}
+
+// ResolveDispute records the issuer's decision. A won dispute releases the
+// funds and refunds are possible again; a lost dispute returned the disputed
+// amount to the guest, so the payment is never refunded.
+func (p *Payment) ResolveDispute(outcome DisputeStatus) error {
+	if p.Dispute == nil {
+		return ErrNoDispute
+	}
+	if outcome != DisputeWon && outcome != DisputeLost {
+		return ErrInvalidDisputeOutcome
+	}
+	if err := disputeStates.Transition(p.Dispute.Status, outcome); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	p.Dispute.Status = outcome
+	p.Dispute.ResolvedAt = now
+	p.UpdatedAt = now
+	p.RecordEvent(NewEventDisputeResolved().
+		WithPaymentID(p.ID).
+		WithReservationID(p.ReservationID).
+		WithDisputeID(p.Dispute.ID).
+		WithOutcome(outcome).
+		WithAmount(p.Dispute.Amount))
+
+	return nil
+}
+
+// RefundsFrozen reports whether the payment has an open dispute.
+func (p *Payment) RefundsFrozen() bool {
+	return p.Dispute != nil && p.Dispute.IsOpen()
+}
+
+// ChargedBack reports whether the guest won a dispute and got the funds back from the issuer.
+func (p *Payment) ChargedBack() bool {
+	return p.Dispute != nil && p.Dispute.Status == DisputeLost
+}
+
+// checkDispute returns the reason a dispute does not allow refunding the payment.
+func (p *Payment) checkDispute() error {
+	if p.RefundsFrozen() {
+		return ErrRefundsFrozen
+	}
+	if p.ChargedBack() {
+		return ErrPaymentChargedBack
+	}
+	return nil
+}
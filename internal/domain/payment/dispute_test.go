@@ -0,0 +1,166 @@
+package payment_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createDisputedPayment(t *testing.T) *payment.Payment {
+	t.Helper()
+	p := createValidPayment()
+	_ = p.Authorize("tx-001")
+	_ = p.Capture()
+	if err := p.OpenDispute("dp-001", "fraudulent", validMoney(), time.Now().AddDate(0, 0, 14)); err != nil {
+		t.Fatalf("failed to open dispute: %v", err)
+	}
+	p.PullEvents()
+	return p
+}
+
+// ============================================================================
+// OpenDispute Tests
+// ============================================================================
+
+func Test_Payment_OpenDispute_Should_Record_Disputed_Event(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-001")
+	_ = p.Capture()
+	p.PullEvents()
+
+	// Act
+	err := p.OpenDispute("dp-001", "fraudulent", validMoney(), time.Time{})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "dispute must be open", p.Dispute.Status, payment.DisputeOpen)
+	assert.That(t, "refunds must be frozen", p.RefundsFrozen(), true)
+	events := p.PullEvents()
+	assert.That(t, "one event must be recorded", len(events), 1)
+	assert.That(t, "event must be payment disputed", events[0].Topic(), payment.EventTopicDisputed)
+}
+
+func Test_Payment_OpenDispute_Of_Authorized_Payment_Should_Return_Error(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-001")
+
+	// Act
+	err := p.OpenDispute("dp-001", "fraudulent", validMoney(), time.Time{})
+
+	// Assert
+	assert.That(t, "error must be ErrCannotDispute", errors.Is(err, payment.ErrCannotDispute), true)
+}
+
+func Test_Payment_OpenDispute_Exceeding_Capture_Should_Return_Error(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-001")
+	_ = p.CapturePartially(shared.NewMoney(5000, "USD"))
+
+	// Act
+	err := p.OpenDispute("dp-001", "fraudulent", validMoney(), time.Time{})
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidDisputeAmount", errors.Is(err, payment.ErrInvalidDisputeAmount), true)
+}
+
+func Test_Payment_OpenDispute_With_Open_Dispute_Should_Return_Error(t *testing.T) {
+	// Arrange
+	p := createDisputedPayment(t)
+
+	// Act
+	err := p.OpenDispute("dp-002", "duplicate", validMoney(), time.Time{})
+
+	// Assert
+	assert.That(t, "error must be ErrAlreadyDisputed", errors.Is(err, payment.ErrAlreadyDisputed), true)
+}
+
+// ============================================================================
+// Evidence and Resolution Tests
+// ============================================================================
+
+func Test_Payment_SubmitDisputeEvidence_Should_Record_Evidence(t *testing.T) {
+	// Arrange
+	p := createDisputedPayment(t)
+
+	// Act
+	err := p.SubmitDisputeEvidence("signed registration card")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "dispute must have evidence submitted", p.Dispute.Status, payment.DisputeEvidenceSubmitted)
+	assert.That(t, "evidence must be recorded", p.Dispute.Evidence, "signed registration card")
+	assert.That(t, "refunds must stay frozen", p.RefundsFrozen(), true)
+}
+
+func Test_Payment_SubmitDisputeEvidence_Without_Dispute_Should_Return_Error(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+
+	// Act
+	err := p.SubmitDisputeEvidence("signed registration card")
+
+	// Assert
+	assert.That(t, "error must be ErrNoDispute", errors.Is(err, payment.ErrNoDispute), true)
+}
+
+func Test_Payment_ResolveDispute_Won_Should_Unfreeze_Refunds(t *testing.T) {
+	// Arrange
+	p := createDisputedPayment(t)
+
+	// Act
+	err := p.ResolveDispute(payment.DisputeWon)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "refunds must not be frozen", p.RefundsFrozen(), false)
+	assert.That(t, "event must be dispute resolved", p.PullEvents()[0].Topic(), payment.EventTopicDisputeResolved)
+	assert.That(t, "payment must be refundable", p.Refund() == nil, true)
+}
+
+func Test_Payment_ResolveDispute_Lost_Should_Refuse_Refund(t *testing.T) {
+	// Arrange
+	p := createDisputedPayment(t)
+
+	// Act
+	err := p.ResolveDispute(payment.DisputeLost)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "payment must be charged back", p.ChargedBack(), true)
+	assert.That(t, "refund must be refused", errors.Is(p.Refund(), payment.ErrPaymentChargedBack), true)
+}
+
+func Test_Payment_ResolveDispute_When_Resolved_Should_Return_Error(t *testing.T) {
+	// Arrange
+	p := createDisputedPayment(t)
+	_ = p.ResolveDispute(payment.DisputeWon)
+
+	// Act
+	err := p.ResolveDispute(payment.DisputeLost)
+
+	// Assert
+	assert.That(t, "error must be ErrDisputeResolved", errors.Is(err, payment.ErrDisputeResolved), true)
+}
+
+func Test_Payment_Refund_With_Open_Dispute_Should_Return_Error(t *testing.T) {
+	// Arrange
+	p := createDisputedPayment(t)
+
+	// Act
+	err := p.Refund()
+
+	// Assert
+	assert.That(t, "error must be ErrRefundsFrozen", errors.Is(err, payment.ErrRefundsFrozen), true)
+	assert.That(t, "payment must stay captured", p.Status, payment.StatusCaptured)
+}
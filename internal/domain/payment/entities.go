@@ -19,3 +19,23 @@ func NewPaymentAttempt(status PaymentStatus, errorCode, errorMsg string) Payment
 		ErrorMsg:    errorMsg,
 	}
 }
+
+// CommandID identifies a command, e.g. after the event that triggered it.
+// A command redelivered with the same ID is handled only once.
+type CommandID string
+
+// ProcessedCommand records a handled command and the payment it produced.
+type ProcessedCommand struct {
+	ID          CommandID
+	PaymentID   PaymentID
+	ProcessedAt time.Time
+}
+
+// NewProcessedCommand creates a new record of a handled command.
+func NewProcessedCommand(id CommandID, paymentID PaymentID) ProcessedCommand {
+	return ProcessedCommand{
+		ID:          id,
+		PaymentID:   paymentID,
+		ProcessedAt: time.Now(),
+	}
+}
@@ -6,6 +6,22 @@ const (
 	EventTopicCaptured   = "payment.captured"
 	EventTopicFailed     = "payment.failed"
 	EventTopicRefunded   = "payment.refunded"
+	EventTopicVoided     = "payment.voided"
+
+	EventTopicDepositHeld     = "payment.deposit_held"
+	EventTopicDepositCaptured = "payment.deposit_captured"
+
+	EventTopicBalanceCaptured = "payment.balance_captured"
+	EventTopicBalanceFailed   = "payment.balance_failed"
+
+	EventTopicAddOnCaptured = "payment.addon_captured"
+	EventTopicAddOnFailed   = "payment.addon_failed"
+
+	EventTopicDisputed        = "payment.disputed"
+	EventTopicDisputeResolved = "payment.dispute_resolved"
+
+	EventTopicMethodAdded   = "payment.method_added"
+	EventTopicMethodRemoved = "payment.method_removed"
 )
 
 // EventAuthorized is published when a payment is authorized.
@@ -131,3 +147,354 @@ func (e *EventRefunded) WithAmount(m Money) *EventRefunded {
 	e.Amount = m
 	return e
 }
+
+// EventVoided is published when an authorization is released without taking any funds.
+type EventVoided struct {
+	PaymentID     PaymentID     `json:"payment_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	Amount        Money         `json:"amount"`
+}
+
+func NewEventVoided() *EventVoided {
+	return &EventVoided{}
+}
+
+func (e *EventVoided) Topic() string { return EventTopicVoided }
+
+func (e *EventVoided) WithPaymentID(id PaymentID) *EventVoided {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventVoided) WithReservationID(id ReservationID) *EventVoided {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventVoided) WithAmount(m Money) *EventVoided {
+	e.Amount = m
+	return e
+}
+
+// EventDepositHeld is published when a security deposit is authorized.
+type EventDepositHeld struct {
+	PaymentID     PaymentID     `json:"payment_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	TransactionID string        `json:"transaction_id"`
+	Amount        Money         `json:"amount"`
+}
+
+func NewEventDepositHeld() *EventDepositHeld {
+	return &EventDepositHeld{}
+}
+
+func (e *EventDepositHeld) Topic() string { return EventTopicDepositHeld }
+
+func (e *EventDepositHeld) WithPaymentID(id PaymentID) *EventDepositHeld {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventDepositHeld) WithReservationID(id ReservationID) *EventDepositHeld {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventDepositHeld) WithTransactionID(id string) *EventDepositHeld {
+	e.TransactionID = id
+	return e
+}
+
+func (e *EventDepositHeld) WithAmount(m Money) *EventDepositHeld {
+	e.Amount = m
+	return e
+}
+
+// EventDepositCaptured is published when incidentals are captured from a security deposit.
+type EventDepositCaptured struct {
+	PaymentID     PaymentID     `json:"payment_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	Amount        Money         `json:"amount"`
+}
+
+func NewEventDepositCaptured() *EventDepositCaptured {
+	return &EventDepositCaptured{}
+}
+
+func (e *EventDepositCaptured) Topic() string { return EventTopicDepositCaptured }
+
+func (e *EventDepositCaptured) WithPaymentID(id PaymentID) *EventDepositCaptured {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventDepositCaptured) WithReservationID(id ReservationID) *EventDepositCaptured {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventDepositCaptured) WithAmount(m Money) *EventDepositCaptured {
+	e.Amount = m
+	return e
+}
+
+// EventBalanceCaptured is published when the balance of a payment schedule is captured.
+type EventBalanceCaptured struct {
+	PaymentID     PaymentID     `json:"payment_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	Amount        Money         `json:"amount"`
+}
+
+func NewEventBalanceCaptured() *EventBalanceCaptured {
+	return &EventBalanceCaptured{}
+}
+
+func (e *EventBalanceCaptured) Topic() string { return EventTopicBalanceCaptured }
+
+func (e *EventBalanceCaptured) WithPaymentID(id PaymentID) *EventBalanceCaptured {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventBalanceCaptured) WithReservationID(id ReservationID) *EventBalanceCaptured {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventBalanceCaptured) WithAmount(m Money) *EventBalanceCaptured {
+	e.Amount = m
+	return e
+}
+
+// EventBalanceFailed is published when an attempt to charge the balance of a payment schedule fails.
+type EventBalanceFailed struct {
+	PaymentID     PaymentID     `json:"payment_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	ErrorCode     string        `json:"error_code"`
+	ErrorMsg      string        `json:"error_msg"`
+}
+
+func NewEventBalanceFailed() *EventBalanceFailed {
+	return &EventBalanceFailed{}
+}
+
+func (e *EventBalanceFailed) Topic() string { return EventTopicBalanceFailed }
+
+func (e *EventBalanceFailed) WithPaymentID(id PaymentID) *EventBalanceFailed {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventBalanceFailed) WithReservationID(id ReservationID) *EventBalanceFailed {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventBalanceFailed) WithErrorCode(code string) *EventBalanceFailed {
+	e.ErrorCode = code
+	return e
+}
+
+func (e *EventBalanceFailed) WithErrorMsg(msg string) *EventBalanceFailed {
+	e.ErrorMsg = msg
+	return e
+}
+
+// EventAddOnCaptured is published when an add-on booked after the reservation was paid is captured.
+type EventAddOnCaptured struct {
+	PaymentID     PaymentID     `json:"payment_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	Amount        Money         `json:"amount"`
+}
+
+func NewEventAddOnCaptured() *EventAddOnCaptured {
+	return &EventAddOnCaptured{}
+}
+
+func (e *EventAddOnCaptured) Topic() string { return EventTopicAddOnCaptured }
+
+func (e *EventAddOnCaptured) WithPaymentID(id PaymentID) *EventAddOnCaptured {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventAddOnCaptured) WithReservationID(id ReservationID) *EventAddOnCaptured {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventAddOnCaptured) WithAmount(m Money) *EventAddOnCaptured {
+	e.Amount = m
+	return e
+}
+
+// EventAddOnFailed is published when an add-on booked after the reservation was paid cannot be charged.
+type EventAddOnFailed struct {
+	PaymentID     PaymentID     `json:"payment_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	ErrorCode     string        `json:"error_code"`
+	ErrorMsg      string        `json:"error_msg"`
+}
+
+func NewEventAddOnFailed() *EventAddOnFailed {
+	return &EventAddOnFailed{}
+}
+
+func (e *EventAddOnFailed) Topic() string { return EventTopicAddOnFailed }
+
+func (e *EventAddOnFailed) WithPaymentID(id PaymentID) *EventAddOnFailed {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventAddOnFailed) WithReservationID(id ReservationID) *EventAddOnFailed {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventAddOnFailed) WithErrorCode(code string) *EventAddOnFailed {
+	e.ErrorCode = code
+	return e
+}
+
+func (e *EventAddOnFailed) WithErrorMsg(msg string) *EventAddOnFailed {
+	e.ErrorMsg = msg
+	return e
+}
+
+// EventDisputed is published when the guest's card issuer opens a dispute on a payment.
+type EventDisputed struct {
+	PaymentID     PaymentID     `json:"payment_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	DisputeID     DisputeID     `json:"dispute_id"`
+	Reason        string        `json:"reason"`
+	Amount        Money         `json:"amount"`
+}
+
+func NewEventDisputed() *EventDisputed {
+	return &EventDisputed{}
+}
+
+func (e *EventDisputed) Topic() string { return EventTopicDisputed }
+
+func (e *EventDisputed) WithPaymentID(id PaymentID) *EventDisputed {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventDisputed) WithReservationID(id ReservationID) *EventDisputed {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventDisputed) WithDisputeID(id DisputeID) *EventDisputed {
+	e.DisputeID = id
+	return e
+}
+
+func (e *EventDisputed) WithReason(reason string) *EventDisputed {
+	e.Reason = reason
+	return e
+}
+
+func (e *EventDisputed) WithAmount(m Money) *EventDisputed {
+	e.Amount = m
+	return e
+}
+
+// EventDisputeResolved is published when the issuer decides a dispute.
+type EventDisputeResolved struct {
+	PaymentID     PaymentID     `json:"payment_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	DisputeID     DisputeID     `json:"dispute_id"`
+	Outcome       DisputeStatus `json:"outcome"`
+	Amount        Money         `json:"amount"`
+}
+
+func NewEventDisputeResolved() *EventDisputeResolved {
+	return &EventDisputeResolved{}
+}
+
+func (e *EventDisputeResolved) Topic() string { return EventTopicDisputeResolved }
+
+func (e *EventDisputeResolved) WithPaymentID(id PaymentID) *EventDisputeResolved {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventDisputeResolved) WithReservationID(id ReservationID) *EventDisputeResolved {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventDisputeResolved) WithDisputeID(id DisputeID) *EventDisputeResolved {
+	e.DisputeID = id
+	return e
+}
+
+func (e *EventDisputeResolved) WithOutcome(outcome DisputeStatus) *EventDisputeResolved {
+	e.Outcome = outcome
+	return e
+}
+
+func (e *EventDisputeResolved) WithAmount(m Money) *EventDisputeResolved {
+	e.Amount = m
+	return e
+}
+
+// EventMethodAdded is published when a guest stores a payment method.
+// It never carries the gateway token.
+type EventMethodAdded struct {
+	PaymentMethodID PaymentMethodID `json:"payment_method_id"`
+	GuestID         GuestID         `json:"guest_id"`
+	Brand           string          `json:"brand"`
+	Last4           string          `json:"last4"`
+}
+
+func NewEventMethodAdded() *EventMethodAdded {
+	return &EventMethodAdded{}
+}
+
+func (e *EventMethodAdded) Topic() string { return EventTopicMethodAdded }
+
+func (e *EventMethodAdded) WithPaymentMethodID(id PaymentMethodID) *EventMethodAdded {
+	e.PaymentMethodID = id
+	return e
+}
+
+func (e *EventMethodAdded) WithGuestID(id GuestID) *EventMethodAdded {
+	e.GuestID = id
+	return e
+}
+
+func (e *EventMethodAdded) WithBrand(brand string) *EventMethodAdded {
+	e.Brand = brand
+	return e
+}
+
+func (e *EventMethodAdded) WithLast4(last4 string) *EventMethodAdded {
+	e.Last4 = last4
+	return e
+}
+
+// EventMethodRemoved is published when a guest removes a stored payment method.
+type EventMethodRemoved struct {
+	PaymentMethodID PaymentMethodID `json:"payment_method_id"`
+	GuestID         GuestID         `json:"guest_id"`
+}
+
+func NewEventMethodRemoved() *EventMethodRemoved {
+	return &EventMethodRemoved{}
+}
+
+func (e *EventMethodRemoved) Topic() string { return EventTopicMethodRemoved }
+
+func (e *EventMethodRemoved) WithPaymentMethodID(id PaymentMethodID) *EventMethodRemoved {
+	e.PaymentMethodID = id
+	return e
+}
+
+func (e *EventMethodRemoved) WithGuestID(id GuestID) *EventMethodRemoved {
+	e.GuestID = id
+	return e
+}
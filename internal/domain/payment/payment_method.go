@@ -0,0 +1,114 @@
+package payment
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// PaymentMethodID identifies a stored payment method.
+type PaymentMethodID string
+
+// GuestID is the guest a payment method belongs to, as seen by the Payment context.
+type GuestID string
+
+// Payment method errors.
+var (
+	ErrGuestRequired             = errors.New("guest is required")
+	ErrTokenRequired             = errors.New("gateway token is required")
+	ErrCardNumberNotAllowed      = errors.New("card numbers must not be stored, send the gateway token instead")
+	ErrInvalidLast4              = errors.New("last4 must be the last 4 digits of the card")
+	ErrInvalidExpiry             = errors.New("expiry month must be between 1 and 12")
+	ErrCardExpired               = errors.New("card is expired")
+	ErrPaymentMethodsUnavailable = errors.New("payment methods are not configured")
+	ErrPaymentMethodNotFound     = errors.New("payment method not found")
+)
+
+// PaymentMethod is the aggregate root for cards a guest stored for later payments,
+// e.g. to rebook with one click or to pay the balance of a payment schedule.
+// Only the token issued by the gateway and the details shown to the guest are
+// kept, never the card number (PAN), so any gateway that tokenizes cards works.
+type PaymentMethod struct {
+	shared.Aggregate
+	ID        PaymentMethodID
+	GuestID   GuestID
+	Token     string // Gateway token charged instead of the card
+	Brand     string // e.g. "visa"
+	Last4     string
+	ExpMonth  int
+	ExpYear   int
+	CreatedAt time.Time
+}
+
+// NewPaymentMethod creates a stored payment method with validation and records its added event.
+func NewPaymentMethod(id PaymentMethodID, guestID GuestID, token, brand, last4 string, expMonth, expYear int) (*PaymentMethod, error) {
+	if guestID == "" {
+		return nil, ErrGuestRequired
+	}
+	if token == "" {
+		return nil, ErrTokenRequired
+	}
+	if isCardNumber(token) {
+		return nil, ErrCardNumberNotAllowed
+	}
+	if len(last4) != 4 || strings.IndexFunc(last4, func(r rune) bool { return !unicode.IsDigit(r) }) >= 0 {
+		return nil, ErrInvalidLast4
+	}
+	if expMonth < 1 || expMonth > 12 {
+		return nil, ErrInvalidExpiry
+	}
+
+	m := &PaymentMethod{
+		ID:        id,
+		GuestID:   guestID,
+		Token:     token,
+		Brand:     strings.ToLower(brand),
+		Last4:     last4,
+		ExpMonth:  expMonth,
+		ExpYear:   expYear,
+		CreatedAt: time.Now(),
+	}
+	if m.Expired(m.CreatedAt) {
+		return nil, ErrCardExpired
+	}
+	m.RecordEvent(NewEventMethodAdded().
+		WithPaymentMethodID(m.ID).
+		WithGuestID(m.GuestID).
+		WithBrand(m.Brand).
+		WithLast4(m.Last4))
+	return m, nil
+}
+
+// Expired reports whether the card can no longer be charged.
+// Cards are valid until the end of their expiry month.
+func (m *PaymentMethod) Expired(now time.Time) bool {
+	validUntil := time.Date(m.ExpYear, time.Month(m.ExpMonth)+1, 1, 0, 0, 0, 0, time.UTC)
+	return !now.Before(validUntil)
+}
+
+// Remove records the removed event before the payment method is deleted.
+// Payments made with the method keep its token for refunds.
+func (m *PaymentMethod) Remove() {
+	m.RecordEvent(NewEventMethodRemoved().
+		WithPaymentMethodID(m.ID).
+		WithGuestID(m.GuestID))
+}
+
+// isCardNumber reports whether the value looks like a card number (PAN):
+// 12 to 19 digits, optionally grouped by spaces or dashes.
+func isCardNumber(value string) bool {
+	digits := 0
+	for _, r := range value {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case r == ' ' || r == '-':
+		default:
+			return false
+		}
+	}
+	return digits >= 12 && digits <= 19
+}
@@ -0,0 +1,76 @@
+package payment_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+)
+
+// ============================================================================
+// NewPaymentMethod Tests
+// ============================================================================
+
+func Test_NewPaymentMethod_Should_Record_Added_Event_Without_Token(t *testing.T) {
+	// Arrange
+	expYear := time.Now().Year() + 2
+
+	// Act
+	method, err := payment.NewPaymentMethod("pm-001", "guest@example.com", "tok_visa_4242", "Visa", "4242", 12, expYear)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "brand must be normalized", method.Brand, "visa")
+	events := method.PullEvents()
+	assert.That(t, "one event must be recorded", len(events), 1)
+	evt := events[0].(*payment.EventMethodAdded)
+	assert.That(t, "event must carry the last 4 digits", evt.Last4, "4242")
+}
+
+func Test_NewPaymentMethod_With_Card_Number_Should_Return_Error(t *testing.T) {
+	// Arrange
+	expYear := time.Now().Year() + 2
+
+	// Act
+	_, err := payment.NewPaymentMethod("pm-001", "guest@example.com", "4242 4242 4242 4242", "visa", "4242", 12, expYear)
+
+	// Assert
+	assert.That(t, "error must be ErrCardNumberNotAllowed", errors.Is(err, payment.ErrCardNumberNotAllowed), true)
+}
+
+func Test_NewPaymentMethod_With_Expired_Card_Should_Return_Error(t *testing.T) {
+	// Arrange
+	lastMonth := time.Now().AddDate(0, -1, 0)
+
+	// Act
+	_, err := payment.NewPaymentMethod("pm-001", "guest@example.com", "tok_visa_4242", "visa", "4242", int(lastMonth.Month()), lastMonth.Year())
+
+	// Assert
+	assert.That(t, "error must be ErrCardExpired", errors.Is(err, payment.ErrCardExpired), true)
+}
+
+func Test_NewPaymentMethod_With_Invalid_Last4_Should_Return_Error(t *testing.T) {
+	// Arrange
+	expYear := time.Now().Year() + 2
+
+	// Act
+	_, err := payment.NewPaymentMethod("pm-001", "guest@example.com", "tok_visa_4242", "visa", "42a2", 12, expYear)
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidLast4", errors.Is(err, payment.ErrInvalidLast4), true)
+}
+
+func Test_PaymentMethod_Expired_Should_Be_Valid_Until_End_Of_Month(t *testing.T) {
+	// Arrange
+	method := &payment.PaymentMethod{ExpMonth: 3, ExpYear: 2030}
+
+	// Act
+	lastDay := method.Expired(time.Date(2030, time.March, 31, 23, 0, 0, 0, time.UTC))
+	nextMonth := method.Expired(time.Date(2030, time.April, 1, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.That(t, "card must be valid on the last day of the month", lastDay, false)
+	assert.That(t, "card must be expired in the next month", nextMonth, true)
+}
@@ -8,7 +8,11 @@ import (
 )
 
 // PaymentRepository provides CRUD operations for payments.
-type PaymentRepository resource.Access[PaymentID, Payment]
+type PaymentRepository interface {
+	resource.Access[PaymentID, Payment]
+	// FindByReservationID returns all payments for the given reservation
+	FindByReservationID(ctx context.Context, reservationID ReservationID) ([]Payment, error)
+}
 
 // PaymentGateway handles payment processing with external providers.
 type PaymentGateway interface {
@@ -18,7 +22,21 @@ type PaymentGateway interface {
 	Capture(ctx context.Context, transactionID string, amount Money) error
 	// Refund returns funds to the customer
 	Refund(ctx context.Context, transactionID string, amount Money) error
+	// Void releases an authorization without capturing any funds
+	Void(ctx context.Context, transactionID string) error
+}
+
+// DisputeGateway forwards dispute evidence to the payment provider.
+type DisputeGateway interface {
+	// SubmitDisputeEvidence sends the evidence contesting a dispute to the card issuer
+	SubmitDisputeEvidence(ctx context.Context, disputeID DisputeID, evidence string) error
 }
 
+// PaymentMethodRepository provides CRUD operations for stored payment methods.
+type PaymentMethodRepository resource.Access[PaymentMethodID, PaymentMethod]
+
 // EventPublisher publishes domain events.
 type EventPublisher event.EventPublisher
+
+// ProcessedCommandStore remembers the commands that were already handled.
+type ProcessedCommandStore resource.Access[CommandID, ProcessedCommand]
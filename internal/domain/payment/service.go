@@ -3,8 +3,11 @@ package payment
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
@@ -13,6 +16,9 @@ type Service struct {
 	paymentRepo    PaymentRepository
 	paymentGateway PaymentGateway
 	publisher      event.EventPublisher
+	commands       ProcessedCommandStore
+	disputes       DisputeGateway
+	methods        PaymentMethodRepository
 }
 
 // NewService creates a new payment Service with dependencies.
@@ -28,7 +34,30 @@ func NewService(
 	}
 }
 
+// WithProcessedCommands remembers the commands handled by AuthorizePaymentForReservation,
+// so a redelivered command returns the payment it produced instead of charging again.
+func (s *Service) WithProcessedCommands(store ProcessedCommandStore) *Service {
+	s.commands = store
+	return s
+}
+
+// WithDisputeGateway forwards the evidence submitted for disputes to the payment provider.
+// Without it, evidence is only recorded on the payment.
+func (s *Service) WithDisputeGateway(gateway DisputeGateway) *Service {
+	s.disputes = gateway
+	return s
+}
+
+// WithPaymentMethods enables stored payment methods backed by the given repository.
+// A payment whose method names a stored payment method charges its gateway token.
+func (s *Service) WithPaymentMethods(repo PaymentMethodRepository) *Service {
+	s.methods = repo
+	return s
+}
+
 // AuthorizePayment creates a payment and authorizes it with the gateway.
+// The method is either a payment method name, e.g. "credit_card", or the ID of a
+// stored payment method, which is then charged by its gateway token.
 func (s *Service) AuthorizePayment(
 	ctx context.Context,
 	id PaymentID,
@@ -38,6 +67,9 @@ func (s *Service) AuthorizePayment(
 ) (*Payment, error) {
 	// 1. Create payment aggregate
 	payment := NewPayment(id, reservationID, amount, method)
+	if err := s.useStoredMethod(ctx, payment); err != nil {
+		return nil, err
+	}
 
 	// 2. Authorize with payment gateway
 	transactionID, err := s.paymentGateway.Authorize(ctx, payment)
@@ -45,19 +77,14 @@ func (s *Service) AuthorizePayment(
 		// Mark payment as failed
 		_ = payment.Fail("gateway_error", err.Error())
 
-		// Persist failed payment
+		// Persist failed payment (the recorded events are pulled first, so they are not stored)
+		events := payment.PullEvents()
 		if persistErr := s.paymentRepo.Create(ctx, id, *payment); persistErr != nil {
 			return nil, fmt.Errorf("failed to persist failed payment: %w", persistErr)
 		}
 
 		// Publish failure event
-		failEvt := NewEventFailed().
-			WithPaymentID(id).
-			WithReservationID(reservationID).
-			WithErrorCode("gateway_error").
-			WithErrorMsg(err.Error())
-
-		_ = s.publisher.Publish(ctx, failEvt)
+		_ = shared.PublishEvents(ctx, s.publisher, events)
 
 		return nil, fmt.Errorf("payment authorization failed: %w", err)
 	}
@@ -68,19 +95,14 @@ func (s *Service) AuthorizePayment(
 	}
 
 	// 4. Persist to repository
+	events := payment.PullEvents()
 	if err := s.paymentRepo.Create(ctx, id, *payment); err != nil {
 		return nil, fmt.Errorf("failed to persist payment: %w", err)
 	}
 
 	// 5. Publish success event
-	evt := NewEventAuthorized().
-		WithPaymentID(id).
-		WithReservationID(reservationID).
-		WithAmount(amount).
-		WithTransactionID(transactionID)
-
-	if err := s.publisher.Publish(ctx, evt); err != nil {
-		return nil, fmt.Errorf("failed to publish event: %w", err)
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
 	}
 
 	return payment, nil
@@ -98,16 +120,11 @@ func (s *Service) CapturePayment(ctx context.Context, id PaymentID) error {
 	if err := s.paymentGateway.Capture(ctx, payment.TransactionID, payment.Amount); err != nil {
 		// Mark as failed
 		_ = payment.Fail("capture_failed", err.Error())
+		events := payment.PullEvents()
 		_ = s.paymentRepo.Update(ctx, id, *payment)
 
 		// Publish failure event
-		failEvt := NewEventFailed().
-			WithPaymentID(id).
-			WithReservationID(payment.ReservationID).
-			WithErrorCode("capture_failed").
-			WithErrorMsg(err.Error())
-
-		_ = s.publisher.Publish(ctx, failEvt)
+		_ = shared.PublishEvents(ctx, s.publisher, events)
 
 		return fmt.Errorf("payment capture failed: %w", err)
 	}
@@ -118,21 +135,13 @@ func (s *Service) CapturePayment(ctx context.Context, id PaymentID) error {
 	}
 
 	// 4. Update repository
+	events := payment.PullEvents()
 	if err := s.paymentRepo.Update(ctx, id, *payment); err != nil {
 		return fmt.Errorf("failed to update payment: %w", err)
 	}
 
 	// 5. Publish success event
-	evt := NewEventCaptured().
-		WithPaymentID(id).
-		WithReservationID(payment.ReservationID).
-		WithAmount(payment.Amount)
-
-	if err := s.publisher.Publish(ctx, evt); err != nil {
-		return fmt.Errorf("failed to publish event: %w", err)
-	}
-
-	return nil
+	return shared.PublishEvents(ctx, s.publisher, events)
 }
 
 // RefundPayment processes a refund for a captured payment.
@@ -142,6 +151,9 @@ func (s *Service) RefundPayment(ctx context.Context, id PaymentID) error {
 	if err != nil {
 		return fmt.Errorf("failed to read payment: %w", err)
 	}
+	if err := payment.checkDispute(); err != nil {
+		return err
+	}
 
 	// 2. Refund with payment gateway
 	if err := s.paymentGateway.Refund(ctx, payment.TransactionID, payment.Amount); err != nil {
@@ -154,21 +166,57 @@ func (s *Service) RefundPayment(ctx context.Context, id PaymentID) error {
 	}
 
 	// 4. Update repository
+	events := payment.PullEvents()
 	if err := s.paymentRepo.Update(ctx, id, *payment); err != nil {
 		return fmt.Errorf("failed to update payment: %w", err)
 	}
 
 	// 5. Publish event
-	evt := NewEventRefunded().
-		WithPaymentID(id).
-		WithReservationID(payment.ReservationID).
-		WithAmount(payment.Amount)
+	return shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// RetainFee keeps a fee from a captured payment and refunds the remainder to the guest.
+// A fee that covers the whole payment is kept without a refund, so the payment stays captured.
+// It returns the refunded amount.
+func (s *Service) RetainFee(ctx context.Context, id PaymentID, fee Money) (Money, error) {
+	// 1. Load payment from repository
+	payment, err := s.paymentRepo.Read(ctx, id)
+	if err != nil {
+		return Money{}, fmt.Errorf("failed to read payment: %w", err)
+	}
+	if payment.Status != StatusCaptured {
+		return Money{}, fmt.Errorf("%w: payment is %s", ErrCannotRefund, payment.Status)
+	}
+	if fee.Currency != payment.Amount.Currency || fee.Amount < 0 {
+		return Money{}, fmt.Errorf("%w: fee of %s", ErrInvalidRefundAmount, fee.FormatAmount())
+	}
 
-	if err := s.publisher.Publish(ctx, evt); err != nil {
-		return fmt.Errorf("failed to publish event: %w", err)
+	refund := shared.NewMoney(payment.Amount.Amount-fee.Amount, payment.Amount.Currency)
+	if refund.Amount <= 0 {
+		return shared.NewMoney(0, payment.Amount.Currency), nil
+	}
+	if err := payment.checkDispute(); err != nil {
+		return Money{}, err
 	}
 
-	return nil
+	// 2. Refund the remainder with payment gateway
+	if err := s.paymentGateway.Refund(ctx, payment.TransactionID, refund); err != nil {
+		return Money{}, fmt.Errorf("payment refund failed: %w", err)
+	}
+
+	// 3. Update payment status
+	if err := payment.RefundPartially(refund); err != nil {
+		return Money{}, fmt.Errorf("failed to update payment status: %w", err)
+	}
+
+	// 4. Update repository
+	events := payment.PullEvents()
+	if err := s.paymentRepo.Update(ctx, id, *payment); err != nil {
+		return Money{}, fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	// 5. Publish event
+	return refund, shared.PublishEvents(ctx, s.publisher, events)
 }
 
 // GetPayment retrieves a payment by ID.
@@ -180,22 +228,696 @@ func (s *Service) GetPayment(ctx context.Context, id PaymentID) (*Payment, error
 	return payment, nil
 }
 
+// ListPayments retrieves all payments.
+func (s *Service) ListPayments(ctx context.Context) ([]Payment, error) {
+	payments, err := s.paymentRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payments: %w", err)
+	}
+	return payments, nil
+}
+
+// ListPaymentsByReservation retrieves all payments for a reservation.
+func (s *Service) ListPaymentsByReservation(ctx context.Context, reservationID ReservationID) ([]Payment, error) {
+	payments, err := s.paymentRepo.FindByReservationID(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payments: %w", err)
+	}
+	return payments, nil
+}
+
+// GetPaymentByReservation retrieves the most recent payment for a reservation.
+// Security deposits, the balance of a payment schedule and add-ons are not charged
+// by the booking saga and are skipped.
+func (s *Service) GetPaymentByReservation(ctx context.Context, reservationID ReservationID) (*Payment, error) {
+	payments, err := s.paymentRepo.FindByReservationID(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payments: %w", err)
+	}
+
+	var latest *Payment
+	for i := range payments {
+		switch payments[i].Kind {
+		case KindDeposit, KindBalance, KindAddOn:
+			continue
+		}
+		if latest == nil || payments[i].CreatedAt.After(latest.CreatedAt) {
+			latest = &payments[i]
+		}
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("%w: reservation %s", ErrPaymentNotFound, reservationID)
+	}
+	return latest, nil
+}
+
+// GetDepositByReservation retrieves the security deposit of a reservation.
+func (s *Service) GetDepositByReservation(ctx context.Context, reservationID ReservationID) (*Payment, error) {
+	payments, err := s.paymentRepo.FindByReservationID(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payments: %w", err)
+	}
+
+	for i := range payments {
+		if payments[i].Kind == KindDeposit {
+			return &payments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no deposit for reservation %s", ErrPaymentNotFound, reservationID)
+}
+
+// GetBalanceByReservation retrieves the balance payment of a reservation's payment schedule.
+func (s *Service) GetBalanceByReservation(ctx context.Context, reservationID ReservationID) (*Payment, error) {
+	payments, err := s.paymentRepo.FindByReservationID(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payments: %w", err)
+	}
+
+	for i := range payments {
+		if payments[i].Kind == KindBalance {
+			return &payments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no balance payment for reservation %s", ErrPaymentNotFound, reservationID)
+}
+
+// ListAddOnPaymentsByReservation retrieves the payments of the add-ons of a reservation.
+func (s *Service) ListAddOnPaymentsByReservation(ctx context.Context, reservationID ReservationID) ([]Payment, error) {
+	payments, err := s.ListPaymentsByReservation(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(payments, func(p Payment) bool { return p.Kind != KindAddOn }), nil
+}
+
+// ListHeldDeposits retrieves the security deposits that are still authorized.
+func (s *Service) ListHeldDeposits(ctx context.Context) ([]Payment, error) {
+	payments, err := s.paymentRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payments: %w", err)
+	}
+
+	deposits := []Payment{}
+	for _, p := range payments {
+		if p.Kind == KindDeposit && p.Status == StatusAuthorized {
+			deposits = append(deposits, p)
+		}
+	}
+	return deposits, nil
+}
+
+// HoldDeposit authorizes a security deposit with the gateway without capturing it.
+// A deposit that exists already is returned unchanged, so a redelivered event does
+// not hold the funds twice. A declined deposit is not stored, so it can be held again.
+func (s *Service) HoldDeposit(
+	ctx context.Context,
+	id PaymentID,
+	reservationID ReservationID,
+	amount Money,
+	method string,
+) (*Payment, error) {
+	// 1. Return a deposit that was held already
+	if deposit, err := s.paymentRepo.Read(ctx, id); err == nil {
+		return deposit, nil
+	}
+
+	// 2. Authorize with payment gateway
+	deposit := NewDeposit(id, reservationID, amount, method)
+	transactionID, err := s.paymentGateway.Authorize(ctx, deposit)
+	if err != nil {
+		return nil, fmt.Errorf("deposit authorization failed: %w", err)
+	}
+
+	// 3. Update deposit with transaction ID
+	if err := deposit.Authorize(transactionID); err != nil {
+		return nil, fmt.Errorf("failed to update payment status: %w", err)
+	}
+
+	// 4. Persist to repository
+	events := deposit.PullEvents()
+	if err := s.paymentRepo.Create(ctx, id, *deposit); err != nil {
+		return nil, fmt.Errorf("failed to persist deposit: %w", err)
+	}
+
+	// 5. Publish event
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
+	}
+	return deposit, nil
+}
+
+// ChargeIncidentals charges incidentals against a held deposit.
+func (s *Service) ChargeIncidentals(ctx context.Context, id PaymentID, amount Money) (*Payment, error) {
+	deposit, err := s.paymentRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payment: %w", err)
+	}
+
+	if err := deposit.AddIncidentals(amount); err != nil {
+		return nil, err
+	}
+
+	if err := s.paymentRepo.Update(ctx, id, *deposit); err != nil {
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+	return deposit, nil
+}
+
+// VoidPayment releases the authorization of a payment that was not captured, e.g.
+// after its capture failed, so the funds are not held until the authorization expires.
+// A payment that was never authorized or is voided already is left unchanged.
+func (s *Service) VoidPayment(ctx context.Context, id PaymentID) error {
+	// 1. Load payment from repository
+	payment, err := s.paymentRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read payment: %w", err)
+	}
+	if payment.Status == StatusVoided || payment.TransactionID == "" {
+		return nil
+	}
+	if err := paymentStates.Transition(payment.Status, StatusVoided); err != nil {
+		return err
+	}
+
+	// 2. Void with payment gateway
+	if err := s.paymentGateway.Void(ctx, payment.TransactionID); err != nil {
+		return fmt.Errorf("payment void failed: %w", err)
+	}
+
+	// 3. Update payment status
+	if err := payment.Void(); err != nil {
+		return fmt.Errorf("failed to update payment status: %w", err)
+	}
+
+	// 4. Update repository
+	events := payment.PullEvents()
+	if err := s.paymentRepo.Update(ctx, id, *payment); err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	// 5. Publish event
+	return shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// ReleaseDeposit settles a held deposit: the incidentals are captured and the
+// rest of the authorization is released, or the whole authorization is voided
+// if nothing was charged. A failed gateway call leaves the deposit held, so the
+// release can be tried again.
+func (s *Service) ReleaseDeposit(ctx context.Context, id PaymentID) (*Payment, error) {
+	// 1. Load deposit from repository
+	deposit, err := s.paymentRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payment: %w", err)
+	}
+	if deposit.Kind != KindDeposit {
+		return nil, fmt.Errorf("%w: %s", ErrNotDeposit, id)
+	}
+
+	// 2. Capture the incidentals or void the authorization with payment gateway
+	if deposit.Incidentals.Amount > 0 {
+		if err := s.paymentGateway.Capture(ctx, deposit.TransactionID, deposit.Incidentals); err != nil {
+			return nil, fmt.Errorf("deposit capture failed: %w", err)
+		}
+		err = deposit.CapturePartially(deposit.Incidentals)
+	} else {
+		if err := s.paymentGateway.Void(ctx, deposit.TransactionID); err != nil {
+			return nil, fmt.Errorf("deposit void failed: %w", err)
+		}
+		err = deposit.Void()
+	}
+
+	// 3. Update deposit status
+	if err != nil {
+		return nil, fmt.Errorf("failed to update payment status: %w", err)
+	}
+
+	// 4. Update repository
+	events := deposit.PullEvents()
+	if err := s.paymentRepo.Update(ctx, id, *deposit); err != nil {
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	// 5. Publish event
+	return deposit, shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// ChargeBalance charges the balance of a payment schedule: the amount is authorized
+// and captured in one go. A balance that was captured already is returned unchanged.
+// Every failed attempt is stored and published, and a failed balance is charged again
+// on the next call until it can no longer be retried (ErrRetriesExhausted).
+func (s *Service) ChargeBalance(
+	ctx context.Context,
+	id PaymentID,
+	reservationID ReservationID,
+	amount Money,
+	method string,
+) (*Payment, error) {
+	// 1. Load the balance of an earlier attempt
+	balance, err := s.paymentRepo.Read(ctx, id)
+	exists := err == nil
+	switch {
+	case !exists:
+		balance = NewBalancePayment(id, reservationID, amount, method)
+		if err := s.useStoredMethod(ctx, balance); err != nil {
+			return nil, err
+		}
+	case balance.Status == StatusCaptured:
+		return balance, nil
+	case !balance.CanBeRetried():
+		return balance, fmt.Errorf("%w: %s", ErrRetriesExhausted, id)
+	}
+
+	// 2. Authorize and capture with payment gateway
+	chargeErr := s.chargeAtOnce(ctx, balance)
+
+	// 3. Persist the outcome of the attempt
+	events := balance.PullEvents()
+	if exists {
+		err = s.paymentRepo.Update(ctx, id, *balance)
+	} else {
+		err = s.paymentRepo.Create(ctx, id, *balance)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to persist balance payment: %w", err)
+	}
+
+	// 4. Publish the captured or failed event
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
+	}
+	return balance, chargeErr
+}
+
+// ChargeAddOn charges an add-on booked after the reservation was paid: the amount
+// is authorized and captured in one go. A failed charge is stored and published,
+// and the add-on is not charged again.
+func (s *Service) ChargeAddOn(
+	ctx context.Context,
+	id PaymentID,
+	reservationID ReservationID,
+	amount Money,
+	method string,
+) (*Payment, error) {
+	// 1. Create the add-on payment
+	addOn := NewAddOnPayment(id, reservationID, amount, method)
+	if err := s.useStoredMethod(ctx, addOn); err != nil {
+		return nil, err
+	}
+
+	// 2. Authorize and capture with payment gateway
+	chargeErr := s.chargeAtOnce(ctx, addOn)
+
+	// 3. Persist the outcome
+	events := addOn.PullEvents()
+	if err := s.paymentRepo.Create(ctx, id, *addOn); err != nil {
+		return nil, fmt.Errorf("failed to create add-on payment: %w", err)
+	}
+
+	// 4. Publish the captured or failed event
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
+	}
+	return addOn, chargeErr
+}
+
+// chargeAtOnce authorizes and captures a balance or add-on payment with the gateway.
+// A declined authorization or capture fails the payment. After a failed capture the
+// authorization is voided on a best-effort basis, so a charge that is tried again
+// does not add another hold on the card.
+func (s *Service) chargeAtOnce(ctx context.Context, payment *Payment) error {
+	transactionID, err := s.paymentGateway.Authorize(ctx, payment)
+	if err != nil {
+		_ = payment.Fail("gateway_error", err.Error())
+		return fmt.Errorf("payment authorization failed: %w", err)
+	}
+	if err := payment.Authorize(transactionID); err != nil {
+		return fmt.Errorf("failed to update payment status: %w", err)
+	}
+
+	if err := s.paymentGateway.Capture(ctx, transactionID, payment.Amount); err != nil {
+		_ = s.paymentGateway.Void(context.WithoutCancel(ctx), transactionID)
+		_ = payment.Fail("capture_failed", err.Error())
+		return fmt.Errorf("payment capture failed: %w", err)
+	}
+	if err := payment.Capture(); err != nil {
+		return fmt.Errorf("failed to update payment status: %w", err)
+	}
+	return nil
+}
+
+// GetPaymentByTransaction retrieves a payment by its payment gateway transaction ID.
+func (s *Service) GetPaymentByTransaction(ctx context.Context, transactionID string) (*Payment, error) {
+	payments, err := s.paymentRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payments: %w", err)
+	}
+
+	for i := range payments {
+		if payments[i].TransactionID == transactionID {
+			return &payments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: transaction %s", ErrPaymentNotFound, transactionID)
+}
+
+// ListDisputes retrieves the payments with an open dispute.
+func (s *Service) ListDisputes(ctx context.Context) ([]Payment, error) {
+	payments, err := s.paymentRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payments: %w", err)
+	}
+
+	disputed := []Payment{}
+	for _, p := range payments {
+		if p.RefundsFrozen() {
+			disputed = append(disputed, p)
+		}
+	}
+	return disputed, nil
+}
+
+// OpenDispute records a chargeback reported by the payment gateway.
+// The gateway notifies at least once, so a dispute that is recorded already
+// returns the payment unchanged.
+func (s *Service) OpenDispute(
+	ctx context.Context,
+	id PaymentID,
+	disputeID DisputeID,
+	reason string,
+	amount Money,
+	evidenceDueBy time.Time,
+) (*Payment, error) {
+	return s.updateDispute(ctx, id, func(payment *Payment) error {
+		if payment.Dispute != nil && payment.Dispute.ID == disputeID {
+			return nil
+		}
+		return payment.OpenDispute(disputeID, reason, amount, evidenceDueBy)
+	})
+}
+
+// SubmitDisputeEvidence records the evidence contesting a dispute and forwards
+// it to the payment provider, if a dispute gateway is configured.
+func (s *Service) SubmitDisputeEvidence(ctx context.Context, id PaymentID, evidence string) (*Payment, error) {
+	return s.updateDispute(ctx, id, func(payment *Payment) error {
+		if err := payment.SubmitDisputeEvidence(evidence); err != nil {
+			return err
+		}
+		if s.disputes == nil {
+			return nil
+		}
+		if err := s.disputes.SubmitDisputeEvidence(ctx, payment.Dispute.ID, evidence); err != nil {
+			return fmt.Errorf("failed to submit evidence: %w", err)
+		}
+		return nil
+	})
+}
+
+// ResolveDispute records the issuer's decision on a dispute.
+// A dispute resolved with the same outcome already returns the payment unchanged.
+func (s *Service) ResolveDispute(ctx context.Context, id PaymentID, outcome DisputeStatus) (*Payment, error) {
+	return s.updateDispute(ctx, id, func(payment *Payment) error {
+		if payment.Dispute != nil && !payment.Dispute.IsOpen() && payment.Dispute.Status == outcome {
+			return nil
+		}
+		return payment.ResolveDispute(outcome)
+	})
+}
+
+// updateDispute loads a payment, applies a dispute change, and persists and publishes the result.
+func (s *Service) updateDispute(ctx context.Context, id PaymentID, change func(*Payment) error) (*Payment, error) {
+	// 1. Load payment from repository
+	payment, err := s.paymentRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrPaymentNotFound, id)
+	}
+
+	// 2. Apply the change to the dispute
+	if err := change(payment); err != nil {
+		return nil, err
+	}
+
+	// 3. Update repository
+	events := payment.PullEvents()
+	if err := s.paymentRepo.Update(ctx, id, *payment); err != nil {
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	// 4. Publish event
+	return payment, shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// AddPaymentMethod stores a tokenized card of a guest for later payments.
+func (s *Service) AddPaymentMethod(
+	ctx context.Context,
+	id PaymentMethodID,
+	guestID GuestID,
+	token, brand, last4 string,
+	expMonth, expYear int,
+) (*PaymentMethod, error) {
+	if s.methods == nil {
+		return nil, ErrPaymentMethodsUnavailable
+	}
+
+	method, err := NewPaymentMethod(id, guestID, token, brand, last4, expMonth, expYear)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment method: %w", err)
+	}
+
+	events := method.PullEvents()
+	if err := s.methods.Create(ctx, id, *method); err != nil {
+		return nil, fmt.Errorf("failed to persist payment method: %w", err)
+	}
+
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
+	}
+	return method, nil
+}
+
+// RemovePaymentMethod deletes a stored payment method of a guest.
+func (s *Service) RemovePaymentMethod(ctx context.Context, guestID GuestID, id PaymentMethodID) error {
+	if s.methods == nil {
+		return ErrPaymentMethodsUnavailable
+	}
+
+	method, err := s.methods.Read(ctx, id)
+	if err != nil {
+		if err.Error() == resource.ErrorResourceNotFound {
+			return fmt.Errorf("%w: %s", ErrPaymentMethodNotFound, id)
+		}
+		return fmt.Errorf("failed to read payment method: %w", err)
+	}
+	if method.GuestID != guestID {
+		return fmt.Errorf("%w: %s", ErrPaymentMethodNotFound, id)
+	}
+
+	method.Remove()
+	events := method.PullEvents()
+	if err := s.methods.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete payment method: %w", err)
+	}
+
+	return shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// RemoveGuestPaymentMethods deletes all stored payment methods of a guest,
+// e.g. on an erasure request. It returns the number of deleted methods.
+func (s *Service) RemoveGuestPaymentMethods(ctx context.Context, guestID GuestID) (int, error) {
+	if s.methods == nil {
+		return 0, nil
+	}
+
+	methods, err := s.ListPaymentMethods(ctx, guestID)
+	if err != nil {
+		return 0, err
+	}
+	for i, method := range methods {
+		if err := s.RemovePaymentMethod(ctx, guestID, method.ID); err != nil {
+			return i, err
+		}
+	}
+	return len(methods), nil
+}
+
+// ListPaymentMethods retrieves the stored payment methods of a guest, newest first.
+func (s *Service) ListPaymentMethods(ctx context.Context, guestID GuestID) ([]PaymentMethod, error) {
+	if s.methods == nil {
+		return nil, ErrPaymentMethodsUnavailable
+	}
+
+	all, err := s.methods.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payment methods: %w", err)
+	}
+
+	methods := []PaymentMethod{}
+	for _, m := range all {
+		if m.GuestID == guestID {
+			methods = append(methods, m)
+		}
+	}
+	slices.SortFunc(methods, func(a, b PaymentMethod) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return methods, nil
+}
+
+// PreferredPaymentMethod retrieves the newest stored payment method of a guest
+// that is not expired, which is charged for bookings and balances.
+func (s *Service) PreferredPaymentMethod(ctx context.Context, guestID GuestID) (*PaymentMethod, error) {
+	methods, err := s.ListPaymentMethods(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range methods {
+		if !methods[i].Expired(now) {
+			return &methods[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: guest %s", ErrPaymentMethodNotFound, guestID)
+}
+
+// useStoredMethod charges the payment to the stored payment method its method names.
+// Payments with a plain method name, e.g. "credit_card", are left unchanged.
+func (s *Service) useStoredMethod(ctx context.Context, payment *Payment) error {
+	if s.methods == nil {
+		return nil
+	}
+
+	method, err := s.methods.Read(ctx, PaymentMethodID(payment.PaymentMethod))
+	if err != nil {
+		if err.Error() == resource.ErrorResourceNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to read payment method: %w", err)
+	}
+	if method.Expired(time.Now()) {
+		return fmt.Errorf("%w: payment method %s", ErrCardExpired, method.ID)
+	}
+
+	payment.ChargeStoredMethod(method)
+	return nil
+}
+
 // AuthorizePaymentForReservation is called when a reservation.created event is received.
 // This initiates the payment authorization process for a new reservation.
+//
+// Events are delivered at least once, so the call is idempotent: a command that was
+// handled already, or a payment that exists already, is returned without authorizing
+// with the gateway again. Without a command ID, only the payment ID guards against duplicates.
 func (s *Service) AuthorizePaymentForReservation(
 	ctx context.Context,
+	commandID CommandID,
 	paymentID PaymentID,
 	reservationID ReservationID,
 	amount Money,
 	method string,
 ) (*Payment, error) {
-	return s.AuthorizePayment(ctx, paymentID, reservationID, amount, method)
+	// 1. Return the payment of a command that was handled already
+	if payment, ok := s.processedPayment(ctx, commandID); ok {
+		return payment, nil
+	}
+
+	// 2. The payment exists without a record of the command if the handler stopped
+	// in between, so its outcome may not have been published yet either
+	if payment, err := s.paymentRepo.Read(ctx, paymentID); err == nil {
+		if err := s.republishAuthorization(ctx, payment); err != nil {
+			return nil, err
+		}
+		if err := s.recordCommand(ctx, commandID, paymentID); err != nil {
+			return nil, err
+		}
+		return payment, nil
+	}
+
+	// 3. Authorize a new payment
+	payment, err := s.AuthorizePayment(ctx, paymentID, reservationID, amount, method)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordCommand(ctx, commandID, paymentID); err != nil {
+		return nil, err
+	}
+	return payment, nil
 }
 
 // CapturePaymentOnAuthorization is called when a payment.authorized event is received
 // by the orchestration layer to capture the authorized payment.
+// A captured payment is not captured again; only its event is published again.
 func (s *Service) CapturePaymentOnAuthorization(ctx context.Context, paymentID PaymentID) error {
-	return s.CapturePayment(ctx, paymentID)
+	payment, err := s.paymentRepo.Read(ctx, paymentID)
+	if err != nil {
+		return fmt.Errorf("failed to read payment: %w", err)
+	}
+
+	switch payment.Status {
+	case StatusCaptured:
+		evt := NewEventCaptured().
+			WithPaymentID(payment.ID).
+			WithReservationID(payment.ReservationID).
+			WithAmount(payment.Amount)
+		if err := s.publisher.Publish(ctx, evt); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	case StatusRefunded:
+		return nil
+	default:
+		return s.CapturePayment(ctx, paymentID)
+	}
+}
+
+// processedPayment returns the payment of a command that was handled already.
+func (s *Service) processedPayment(ctx context.Context, commandID CommandID) (*Payment, bool) {
+	if s.commands == nil || commandID == "" {
+		return nil, false
+	}
+	cmd, err := s.commands.Read(ctx, commandID)
+	if err != nil {
+		return nil, false
+	}
+	payment, err := s.paymentRepo.Read(ctx, cmd.PaymentID)
+	if err != nil {
+		return nil, false
+	}
+	return payment, true
+}
+
+// recordCommand remembers a handled command. A command that another replica
+// recorded in the meantime was handled for the same payment, so that is no error.
+func (s *Service) recordCommand(ctx context.Context, commandID CommandID, paymentID PaymentID) error {
+	if s.commands == nil || commandID == "" {
+		return nil
+	}
+	err := s.commands.Create(ctx, commandID, NewProcessedCommand(commandID, paymentID))
+	if err != nil && err.Error() != resource.ErrorResourceAlreadyExists {
+		return fmt.Errorf("failed to record command: %w", err)
+	}
+	return nil
+}
+
+// republishAuthorization publishes the payment.authorized event of an existing payment
+// again. Payments that were captured or refunded since need no event.
+func (s *Service) republishAuthorization(ctx context.Context, payment *Payment) error {
+	switch payment.Status {
+	case StatusAuthorized:
+		evt := NewEventAuthorized().
+			WithPaymentID(payment.ID).
+			WithReservationID(payment.ReservationID).
+			WithAmount(payment.Amount).
+			WithTransactionID(payment.TransactionID)
+		if err := s.publisher.Publish(ctx, evt); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	case StatusCaptured, StatusRefunded:
+		return nil
+	default:
+		return fmt.Errorf("%w: payment %s is %s", ErrInvalidPaymentTransition, payment.ID, payment.Status)
+	}
 }
 
 // NewMoney is a convenience function to create Money using the shared package.
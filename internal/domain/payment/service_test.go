@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
@@ -68,14 +70,31 @@ func (m *mockPaymentRepository) ReadAll(ctx context.Context) ([]payment.Payment,
 	return result, nil
 }
 
+func (m *mockPaymentRepository) FindByReservationID(ctx context.Context, reservationID payment.ReservationID) ([]payment.Payment, error) {
+	var result []payment.Payment
+	for _, p := range m.payments {
+		if p.ReservationID == reservationID {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
 type mockPaymentGateway struct {
 	authorizeTransactionID string
 	authorizeErr           error
 	captureErr             error
 	refundErr              error
+	voidErr                error
+	authorizeCalls         int
+	captureCalls           int
+	refundCalls            int
+	voidCalls              int
+	evidence               []string
 }
 
 func (m *mockPaymentGateway) Authorize(ctx context.Context, p *payment.Payment) (string, error) {
+	m.authorizeCalls++
 	if m.authorizeErr != nil {
 		return "", m.authorizeErr
 	}
@@ -83,13 +102,25 @@ func (m *mockPaymentGateway) Authorize(ctx context.Context, p *payment.Payment)
 }
 
 func (m *mockPaymentGateway) Capture(ctx context.Context, transactionID string, amount shared.Money) error {
+	m.captureCalls++
 	return m.captureErr
 }
 
 func (m *mockPaymentGateway) Refund(ctx context.Context, transactionID string, amount shared.Money) error {
+	m.refundCalls++
 	return m.refundErr
 }
 
+func (m *mockPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	m.voidCalls++
+	return m.voidErr
+}
+
+func (m *mockPaymentGateway) SubmitDisputeEvidence(ctx context.Context, disputeID payment.DisputeID, evidence string) error {
+	m.evidence = append(m.evidence, evidence)
+	return nil
+}
+
 type mockEventPublisher struct {
 	published []event.Event
 	err       error
@@ -343,6 +374,76 @@ func Test_Service_CapturePayment_When_Gateway_Fails_Should_Mark_Payment_Failed(t
 	assert.That(t, "status must be failed", storedPayment.Status, payment.StatusFailed)
 }
 
+// ============================================================================
+// VoidPayment Tests
+// ============================================================================
+
+func Test_Service_VoidPayment_After_Failed_Capture_Should_Release_Authorization(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{
+		authorizeTransactionID: "tx-12345",
+		captureErr:             errors.New("capture failed"),
+	}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+	id := payment.PaymentID("pay-001")
+
+	_, _ = service.AuthorizePayment(ctx, id, "res-001", paymentTestMoney(), "credit_card")
+	_ = service.CapturePayment(ctx, id)
+
+	// Act
+	err := service.VoidPayment(ctx, id)
+	errAgain := service.VoidPayment(ctx, id)
+
+	// Assert
+	assert.That(t, "errors must be nil", errors.Join(err, errAgain) == nil, true)
+	storedPayment, _ := repo.Read(ctx, id)
+	assert.That(t, "payment must be voided", storedPayment.Status, payment.StatusVoided)
+	assert.That(t, "gateway must void once", gateway.voidCalls, 1)
+	assert.That(t, "event must be voided", publisher.published[len(publisher.published)-1].Topic(), payment.EventTopicVoided)
+}
+
+func Test_Service_VoidPayment_Without_Authorization_Should_Do_Nothing(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeErr: errors.New("insufficient funds")}
+	service := createPaymentTestService(repo, gateway, &mockEventPublisher{})
+	ctx := context.Background()
+	id := payment.PaymentID("pay-001")
+
+	_, _ = service.AuthorizePayment(ctx, id, "res-001", paymentTestMoney(), "credit_card")
+
+	// Act
+	err := service.VoidPayment(ctx, id)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	storedPayment, _ := repo.Read(ctx, id)
+	assert.That(t, "payment must stay failed", storedPayment.Status, payment.StatusFailed)
+	assert.That(t, "gateway must not void", gateway.voidCalls, 0)
+}
+
+func Test_Service_VoidPayment_With_Captured_Payment_Should_Return_Error(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	service := createPaymentTestService(repo, gateway, &mockEventPublisher{})
+	ctx := context.Background()
+	id := payment.PaymentID("pay-001")
+
+	_, _ = service.AuthorizePayment(ctx, id, "res-001", paymentTestMoney(), "credit_card")
+	_ = service.CapturePayment(ctx, id)
+
+	// Act
+	err := service.VoidPayment(ctx, id)
+
+	// Assert
+	assert.That(t, "error must be ErrCannotVoid", errors.Is(err, payment.ErrCannotVoid), true)
+	assert.That(t, "gateway must not void", gateway.voidCalls, 0)
+}
+
 // ============================================================================
 // RefundPayment Tests
 // ============================================================================
@@ -436,6 +537,57 @@ func Test_Service_RefundPayment_When_Gateway_Fails_Should_Return_Error(t *testin
 	assert.That(t, "error must not be nil", err != nil, true)
 }
 
+// ============================================================================
+// RetainFee Tests
+// ============================================================================
+
+func Test_Service_RetainFee_Should_Refund_Remainder(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+
+	ctx := context.Background()
+	id := payment.PaymentID("pay-001")
+
+	_, _ = service.AuthorizePayment(ctx, id, "res-001", paymentTestMoney(), "credit_card")
+	_ = service.CapturePayment(ctx, id)
+
+	// Act
+	refunded, err := service.RetainFee(ctx, id, shared.NewMoney(2500, "USD"))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "remainder must be refunded", refunded, shared.NewMoney(7500, "USD"))
+	storedPayment, _ := repo.Read(ctx, id)
+	assert.That(t, "status must be refunded", storedPayment.Status, payment.StatusRefunded)
+	assert.That(t, "refunded amount must be stored", storedPayment.RefundedAmount, shared.NewMoney(7500, "USD"))
+}
+
+func Test_Service_RetainFee_With_Fee_Covering_Payment_Should_Keep_Payment_Captured(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+
+	ctx := context.Background()
+	id := payment.PaymentID("pay-001")
+
+	_, _ = service.AuthorizePayment(ctx, id, "res-001", paymentTestMoney(), "credit_card")
+	_ = service.CapturePayment(ctx, id)
+
+	// Act
+	refunded, err := service.RetainFee(ctx, id, paymentTestMoney())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "nothing must be refunded", refunded.Amount, int64(0))
+	storedPayment, _ := repo.Read(ctx, id)
+	assert.That(t, "status must remain captured", storedPayment.Status, payment.StatusCaptured)
+}
+
 // ============================================================================
 // GetPayment Tests
 // ============================================================================
@@ -478,6 +630,40 @@ func Test_Service_GetPayment_When_Not_Found_Should_Return_Error(t *testing.T) {
 	assert.That(t, "payment must be nil", p == nil, true)
 }
 
+func Test_Service_GetPaymentByReservation_Should_Return_Payment(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+
+	ctx := context.Background()
+	_, _ = service.AuthorizePayment(ctx, "pay-001", "res-001", paymentTestMoney(), "credit_card")
+	_, _ = service.AuthorizePayment(ctx, "pay-002", "res-002", paymentTestMoney(), "credit_card")
+
+	// Act
+	p, err := service.GetPaymentByReservation(ctx, "res-002")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "payment ID must match", p.ID, payment.PaymentID("pay-002"))
+}
+
+func Test_Service_GetPaymentByReservation_When_Not_Found_Should_Return_ErrPaymentNotFound(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+
+	// Act
+	p, err := service.GetPaymentByReservation(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "error must be ErrPaymentNotFound", errors.Is(err, payment.ErrPaymentNotFound), true)
+	assert.That(t, "payment must be nil", p == nil, true)
+}
+
 // ============================================================================
 // Event Handler Integration Tests
 // ============================================================================
@@ -494,7 +680,7 @@ func Test_Service_AuthorizePaymentForReservation_Should_Authorize(t *testing.T)
 	reservationID := payment.ReservationID("res-001")
 
 	// Act
-	p, err := service.AuthorizePaymentForReservation(ctx, id, reservationID, paymentTestMoney(), "credit_card")
+	p, err := service.AuthorizePaymentForReservation(ctx, "reservation.created/res-001", id, reservationID, paymentTestMoney(), "credit_card")
 
 	// Assert
 	assert.That(t, "error must be nil", err == nil, true)
@@ -522,3 +708,533 @@ func Test_Service_CapturePaymentOnAuthorization_Should_Capture(t *testing.T) {
 	storedPayment, _ := repo.Read(ctx, id)
 	assert.That(t, "status must be captured", storedPayment.Status, payment.StatusCaptured)
 }
+
+func Test_Service_AuthorizePaymentForReservation_When_Redelivered_Should_Authorize_Once(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	commands := resource.NewInMemoryAccess[payment.CommandID, payment.ProcessedCommand]()
+	service := createPaymentTestService(repo, gateway, publisher).WithProcessedCommands(commands)
+
+	ctx := context.Background()
+	commandID := payment.CommandID("reservation.created/res-001")
+	id := payment.PaymentID("pay-001")
+	_, _ = service.AuthorizePaymentForReservation(ctx, commandID, id, "res-001", paymentTestMoney(), "credit_card")
+
+	// Act
+	p, err := service.AuthorizePaymentForReservation(ctx, commandID, id, "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "payment must be the first one", p.TransactionID, "tx-12345")
+	assert.That(t, "gateway must be called once", gateway.authorizeCalls, 1)
+	assert.That(t, "event must be published once", len(publisher.published), 1)
+	cmd, _ := commands.Read(ctx, commandID)
+	assert.That(t, "command must be recorded", cmd.PaymentID, id)
+}
+
+func Test_Service_AuthorizePaymentForReservation_When_Recorded_Concurrently_Should_Succeed(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	commands := resource.NewInMemoryAccess[payment.CommandID, payment.ProcessedCommand]()
+	service := createPaymentTestService(repo, gateway, publisher).WithProcessedCommands(commands)
+
+	ctx := context.Background()
+	commandID := payment.CommandID("reservation.created/res-001")
+	id := payment.PaymentID("pay-001")
+	_ = commands.Create(ctx, commandID, payment.NewProcessedCommand(commandID, id))
+
+	// Act
+	p, err := service.AuthorizePaymentForReservation(ctx, commandID, id, "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be authorized", p.Status, payment.StatusAuthorized)
+	assert.That(t, "gateway must be called once", gateway.authorizeCalls, 1)
+}
+
+func Test_Service_AuthorizePaymentForReservation_When_Payment_Exists_Should_Publish_Event_Again(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+
+	ctx := context.Background()
+	id := payment.PaymentID("pay-001")
+	_, _ = service.AuthorizePayment(ctx, id, "res-001", paymentTestMoney(), "credit_card")
+
+	// Act
+	p, err := service.AuthorizePaymentForReservation(ctx, "reservation.created/res-001", id, "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be authorized", p.Status, payment.StatusAuthorized)
+	assert.That(t, "gateway must be called once", gateway.authorizeCalls, 1)
+	assert.That(t, "event must be published again", len(publisher.published), 2)
+	assert.That(t, "event must be authorized", publisher.published[1].Topic(), payment.EventTopicAuthorized)
+}
+
+func Test_Service_AuthorizePaymentForReservation_When_Payment_Failed_Should_Return_Error(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeErr: errors.New("card declined")}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+
+	ctx := context.Background()
+	id := payment.PaymentID("pay-001")
+	_, _ = service.AuthorizePayment(ctx, id, "res-001", paymentTestMoney(), "credit_card")
+
+	// Act
+	_, err := service.AuthorizePaymentForReservation(ctx, "reservation.created/res-001", id, "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must be invalid transition", errors.Is(err, payment.ErrInvalidPaymentTransition), true)
+	assert.That(t, "gateway must be called once", gateway.authorizeCalls, 1)
+}
+
+func Test_Service_CapturePaymentOnAuthorization_When_Captured_Should_Not_Capture_Again(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+
+	ctx := context.Background()
+	id := payment.PaymentID("pay-001")
+	_, _ = service.AuthorizePayment(ctx, id, "res-001", paymentTestMoney(), "credit_card")
+	_ = service.CapturePaymentOnAuthorization(ctx, id)
+
+	// Act
+	err := service.CapturePaymentOnAuthorization(ctx, id)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "gateway must capture once", gateway.captureCalls, 1)
+	assert.That(t, "captured event must be published again", publisher.published[len(publisher.published)-1].Topic(), payment.EventTopicCaptured)
+}
+
+// ============================================================================
+// Deposit Tests
+// ============================================================================
+
+func Test_Service_HoldDeposit_Twice_Should_Authorize_Once(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+
+	// Act
+	_, _ = service.HoldDeposit(ctx, "dep-001", "res-001", paymentTestMoney(), "credit_card")
+	deposit, err := service.HoldDeposit(ctx, "dep-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "deposit must be authorized", deposit.Status, payment.StatusAuthorized)
+	assert.That(t, "gateway must authorize once", gateway.authorizeCalls, 1)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "event must be deposit held", publisher.published[0].Topic(), payment.EventTopicDepositHeld)
+}
+
+func Test_Service_HoldDeposit_When_Declined_Should_Not_Store_Deposit(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeErr: errors.New("card declined")}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+
+	// Act
+	_, err := service.HoldDeposit(ctx, "dep-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	_, readErr := repo.Read(ctx, "dep-001")
+	assert.That(t, "deposit must not be stored", readErr != nil, true)
+	assert.That(t, "no event must be published", len(publisher.published), 0)
+}
+
+func Test_Service_ReleaseDeposit_With_Incidentals_Should_Capture_Incidentals(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+
+	_, _ = service.HoldDeposit(ctx, "dep-001", "res-001", paymentTestMoney(), "credit_card")
+	_, _ = service.ChargeIncidentals(ctx, "dep-001", shared.NewMoney(2500, "USD"))
+
+	// Act
+	deposit, err := service.ReleaseDeposit(ctx, "dep-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "deposit must be captured", deposit.Status, payment.StatusCaptured)
+	assert.That(t, "incidentals must be captured", deposit.CaptureAmount(), shared.NewMoney(2500, "USD"))
+	assert.That(t, "gateway must capture once", gateway.captureCalls, 1)
+	assert.That(t, "event must be deposit captured", publisher.published[len(publisher.published)-1].Topic(), payment.EventTopicDepositCaptured)
+}
+
+func Test_Service_ReleaseDeposit_Without_Incidentals_Should_Void_Deposit(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+
+	_, _ = service.HoldDeposit(ctx, "dep-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Act
+	deposit, err := service.ReleaseDeposit(ctx, "dep-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "deposit must be voided", deposit.Status, payment.StatusVoided)
+	assert.That(t, "gateway must not capture", gateway.captureCalls, 0)
+	assert.That(t, "event must be voided", publisher.published[len(publisher.published)-1].Topic(), payment.EventTopicVoided)
+}
+
+func Test_Service_ReleaseDeposit_With_Regular_Payment_Should_Return_ErrNotDeposit(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+
+	_, _ = service.AuthorizePayment(ctx, "pay-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Act
+	_, err := service.ReleaseDeposit(ctx, "pay-001")
+
+	// Assert
+	assert.That(t, "error must be ErrNotDeposit", errors.Is(err, payment.ErrNotDeposit), true)
+}
+
+// ============================================================================
+// Balance Tests
+// ============================================================================
+
+func Test_Service_ChargeBalance_Should_Capture_Balance(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+
+	// Act
+	balance, err := service.ChargeBalance(ctx, "bal-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "balance must be captured", balance.Status, payment.StatusCaptured)
+	assert.That(t, "balance must be marked as balance", balance.Kind, payment.KindBalance)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "event must be balance captured", publisher.published[0].Topic(), payment.EventTopicBalanceCaptured)
+}
+
+func Test_Service_ChargeBalance_When_Declined_Should_Store_Failed_Attempt(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeErr: errors.New("card declined")}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+
+	// Act
+	_, err := service.ChargeBalance(ctx, "bal-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	balance, readErr := repo.Read(ctx, "bal-001")
+	assert.That(t, "balance must be stored", readErr == nil, true)
+	assert.That(t, "balance must be failed", balance.Status, payment.StatusFailed)
+	assert.That(t, "event must be balance failed, not payment failed", publisher.published[0].Topic(), payment.EventTopicBalanceFailed)
+}
+
+func Test_Service_ChargeBalance_After_Three_Failures_Should_Return_ErrRetriesExhausted(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeErr: errors.New("card declined")}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+	for range 3 {
+		_, _ = service.ChargeBalance(ctx, "bal-001", "res-001", paymentTestMoney(), "credit_card")
+	}
+
+	// Act
+	_, err := service.ChargeBalance(ctx, "bal-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must be ErrRetriesExhausted", errors.Is(err, payment.ErrRetriesExhausted), true)
+	assert.That(t, "gateway must be called three times", gateway.authorizeCalls, 3)
+}
+
+func Test_Service_GetPaymentByReservation_Should_Skip_Balance(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+	_, _ = service.AuthorizePayment(ctx, "pay-001", "res-001", paymentTestMoney(), "credit_card")
+	_, _ = service.ChargeBalance(ctx, "bal-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Act
+	pay, err := service.GetPaymentByReservation(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "payment must be the booking payment", pay.ID, payment.PaymentID("pay-001"))
+}
+
+// ============================================================================
+// Add-On Tests
+// ============================================================================
+
+func Test_Service_ChargeAddOn_Should_Capture_Add_On(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+
+	// Act
+	addOn, err := service.ChargeAddOn(ctx, "addon-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "add-on must be captured", addOn.Status, payment.StatusCaptured)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "event must be add-on captured", publisher.published[0].Topic(), payment.EventTopicAddOnCaptured)
+	_, err = service.GetPaymentByReservation(ctx, "res-001")
+	assert.That(t, "add-on must not be the booking payment", errors.Is(err, payment.ErrPaymentNotFound), true)
+}
+
+func Test_Service_ChargeAddOn_When_Declined_Should_Publish_Add_On_Failed(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeErr: errors.New("card declined")}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+	ctx := context.Background()
+
+	// Act
+	addOn, err := service.ChargeAddOn(ctx, "addon-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "add-on must be failed", addOn.Status, payment.StatusFailed)
+	assert.That(t, "event must be add-on failed", publisher.published[0].Topic(), payment.EventTopicAddOnFailed)
+}
+
+// ============================================================================
+// Dispute Tests
+// ============================================================================
+
+// createCapturedPayment stores a captured payment with transaction ID "tx-12345".
+func createCapturedPayment(repo *mockPaymentRepository) {
+	p := payment.NewPayment("pay-001", "res-001", paymentTestMoney(), "credit_card")
+	_ = p.Authorize("tx-12345")
+	_ = p.Capture()
+	p.PullEvents()
+	repo.payments["pay-001"] = *p
+}
+
+func Test_Service_OpenDispute_Should_Publish_Disputed_Event(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, &mockPaymentGateway{}, publisher)
+	createCapturedPayment(repo)
+	ctx := context.Background()
+
+	// Act
+	disputed, err := service.OpenDispute(ctx, "pay-001", "dp-001", "fraudulent", paymentTestMoney(), time.Time{})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "dispute must be open", disputed.Dispute.Status, payment.DisputeOpen)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "event must be payment disputed", publisher.published[0].Topic(), payment.EventTopicDisputed)
+}
+
+func Test_Service_OpenDispute_When_Redelivered_Should_Publish_Once(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, &mockPaymentGateway{}, publisher)
+	createCapturedPayment(repo)
+	ctx := context.Background()
+	_, _ = service.OpenDispute(ctx, "pay-001", "dp-001", "fraudulent", paymentTestMoney(), time.Time{})
+
+	// Act
+	_, err := service.OpenDispute(ctx, "pay-001", "dp-001", "fraudulent", paymentTestMoney(), time.Time{})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+}
+
+func Test_Service_RefundPayment_With_Open_Dispute_Should_Not_Refund(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{}
+	service := createPaymentTestService(repo, gateway, &mockEventPublisher{})
+	createCapturedPayment(repo)
+	ctx := context.Background()
+	_, _ = service.OpenDispute(ctx, "pay-001", "dp-001", "fraudulent", paymentTestMoney(), time.Time{})
+
+	// Act
+	err := service.RefundPayment(ctx, "pay-001")
+
+	// Assert
+	assert.That(t, "error must be ErrRefundsFrozen", errors.Is(err, payment.ErrRefundsFrozen), true)
+	assert.That(t, "gateway must not refund", gateway.refundCalls, 0)
+	assert.That(t, "payment must stay captured", repo.payments["pay-001"].Status, payment.StatusCaptured)
+}
+
+func Test_Service_SubmitDisputeEvidence_With_Dispute_Gateway_Should_Forward_Evidence(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{}
+	service := createPaymentTestService(repo, gateway, &mockEventPublisher{}).WithDisputeGateway(gateway)
+	createCapturedPayment(repo)
+	ctx := context.Background()
+	_, _ = service.OpenDispute(ctx, "pay-001", "dp-001", "fraudulent", paymentTestMoney(), time.Time{})
+
+	// Act
+	disputed, err := service.SubmitDisputeEvidence(ctx, "pay-001", "signed registration card")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "dispute must have evidence submitted", disputed.Dispute.Status, payment.DisputeEvidenceSubmitted)
+	assert.That(t, "evidence must be forwarded", gateway.evidence, []string{"signed registration card"})
+}
+
+func Test_Service_ResolveDispute_Won_Should_Allow_Refund(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, &mockPaymentGateway{}, publisher)
+	createCapturedPayment(repo)
+	ctx := context.Background()
+	_, _ = service.OpenDispute(ctx, "pay-001", "dp-001", "fraudulent", paymentTestMoney(), time.Time{})
+
+	// Act
+	_, err := service.ResolveDispute(ctx, "pay-001", payment.DisputeWon)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "event must be dispute resolved", publisher.published[1].Topic(), payment.EventTopicDisputeResolved)
+	assert.That(t, "payment must be refundable", service.RefundPayment(ctx, "pay-001") == nil, true)
+}
+
+func Test_Service_ListDisputes_Should_Return_Open_Disputes(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	service := createPaymentTestService(repo, &mockPaymentGateway{}, &mockEventPublisher{})
+	createCapturedPayment(repo)
+	ctx := context.Background()
+	_, _ = service.OpenDispute(ctx, "pay-001", "dp-001", "fraudulent", paymentTestMoney(), time.Time{})
+
+	// Act
+	disputes, err := service.ListDisputes(ctx)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "one dispute must be listed", len(disputes), 1)
+}
+
+// ============================================================================
+// Payment Method Tests
+// ============================================================================
+
+func createPaymentMethodTestService(repo *mockPaymentRepository, publisher *mockEventPublisher) *payment.Service {
+	return createPaymentTestService(repo, &mockPaymentGateway{authorizeTransactionID: "tx-12345"}, publisher).
+		WithPaymentMethods(resource.NewInMemoryAccess[payment.PaymentMethodID, payment.PaymentMethod]())
+}
+
+func Test_Service_AuthorizePayment_With_Stored_Method_Should_Charge_Token(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	service := createPaymentMethodTestService(repo, &mockEventPublisher{})
+	ctx := context.Background()
+	_, _ = service.AddPaymentMethod(ctx, "pm-001", "guest@example.com", "tok_visa_4242", "visa", "4242", 12, time.Now().Year()+2)
+
+	// Act
+	pay, err := service.AuthorizePayment(ctx, "pay-001", "res-001", paymentTestMoney(), "pm-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "payment must be authorized", pay.Status, payment.StatusAuthorized)
+	assert.That(t, "token of the stored method must be charged", repo.payments["pay-001"].MethodToken, "tok_visa_4242")
+}
+
+func Test_Service_AuthorizePayment_With_Method_Name_Should_Not_Use_Stored_Method(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	service := createPaymentMethodTestService(repo, &mockEventPublisher{})
+	ctx := context.Background()
+
+	// Act
+	_, err := service.AuthorizePayment(ctx, "pay-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "no token must be charged", repo.payments["pay-001"].MethodToken, "")
+}
+
+func Test_Service_RemovePaymentMethod_Of_Other_Guest_Should_Return_ErrPaymentMethodNotFound(t *testing.T) {
+	// Arrange
+	service := createPaymentMethodTestService(newMockPaymentRepository(), &mockEventPublisher{})
+	ctx := context.Background()
+	_, _ = service.AddPaymentMethod(ctx, "pm-001", "guest@example.com", "tok_visa_4242", "visa", "4242", 12, time.Now().Year()+2)
+
+	// Act
+	err := service.RemovePaymentMethod(ctx, "other@example.com", "pm-001")
+
+	// Assert
+	assert.That(t, "error must be ErrPaymentMethodNotFound", errors.Is(err, payment.ErrPaymentMethodNotFound), true)
+	methods, _ := service.ListPaymentMethods(ctx, "guest@example.com")
+	assert.That(t, "method must be kept", len(methods), 1)
+}
+
+func Test_Service_RemovePaymentMethod_Should_Publish_Removed_Event(t *testing.T) {
+	// Arrange
+	publisher := &mockEventPublisher{}
+	service := createPaymentMethodTestService(newMockPaymentRepository(), publisher)
+	ctx := context.Background()
+	_, _ = service.AddPaymentMethod(ctx, "pm-001", "guest@example.com", "tok_visa_4242", "visa", "4242", 12, time.Now().Year()+2)
+
+	// Act
+	err := service.RemovePaymentMethod(ctx, "guest@example.com", "pm-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "event must be method removed", publisher.published[1].Topic(), payment.EventTopicMethodRemoved)
+	methods, _ := service.ListPaymentMethods(ctx, "guest@example.com")
+	assert.That(t, "method must be removed", len(methods), 0)
+}
+
+func Test_Service_AddPaymentMethod_Without_Repository_Should_Return_ErrPaymentMethodsUnavailable(t *testing.T) {
+	// Arrange
+	service := createPaymentTestService(newMockPaymentRepository(), &mockPaymentGateway{}, &mockEventPublisher{})
+
+	// Act
+	_, err := service.AddPaymentMethod(context.Background(), "pm-001", "guest@example.com", "tok_visa_4242", "visa", "4242", 12, time.Now().Year()+2)
+
+	// Assert
+	assert.That(t, "error must be ErrPaymentMethodsUnavailable", errors.Is(err, payment.ErrPaymentMethodsUnavailable), true)
+}
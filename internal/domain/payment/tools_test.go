@@ -70,11 +70,22 @@ func (m *toolsMockPaymentRepository) ReadAll(ctx context.Context) ([]payment.Pay
 	return result, nil
 }
 
+func (m *toolsMockPaymentRepository) FindByReservationID(ctx context.Context, reservationID payment.ReservationID) ([]payment.Payment, error) {
+	var result []payment.Payment
+	for _, p := range m.payments {
+		if p.ReservationID == reservationID {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
 type toolsMockPaymentGateway struct {
 	authorizeTransactionID string
 	authorizeErr           error
 	captureErr             error
 	refundErr              error
+	voidErr                error
 }
 
 func (m *toolsMockPaymentGateway) Authorize(ctx context.Context, p *payment.Payment) (string, error) {
@@ -92,6 +103,10 @@ func (m *toolsMockPaymentGateway) Refund(ctx context.Context, transactionID stri
 	return m.refundErr
 }
 
+func (m *toolsMockPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	return m.voidErr
+}
+
 type toolsMockEventPublisher struct {
 	published []event.Event
 	err       error
@@ -0,0 +1,30 @@
+package privacy
+
+import (
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// GuestDataExport bundles all data stored about a guest (right of access).
+type GuestDataExport struct {
+	GuestID      reservation.GuestID       `json:"guest_id"`
+	ExportedAt   time.Time                 `json:"exported_at"`
+	Profile      reservation.GuestProfile  `json:"profile"`
+	Reservations []reservation.Reservation `json:"reservations"`
+	Payments     []payment.Payment         `json:"payments"`
+}
+
+// ErasureReport summarizes an erasure request (right to erasure).
+// The guest profile, including the notification preferences, is deleted;
+// payments contain no personal data and are retained as financial records.
+// ErasedRecords counts the erased records of every registered store by its
+// name, so a store that was not erased does not appear in the report.
+type ErasureReport struct {
+	GuestID                reservation.GuestID         `json:"guest_id"`
+	ErasedAt               time.Time                   `json:"erased_at"`
+	AnonymizedReservations []reservation.ReservationID `json:"anonymized_reservations"`
+	RetainedPayments       []payment.PaymentID         `json:"retained_payments"`
+	ErasedRecords          map[string]int              `json:"erased_records"`
+}
@@ -0,0 +1,21 @@
+package privacy
+
+import (
+	"context"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// GuestDataEraser erases the personal data a store keeps about a guest.
+type GuestDataEraser interface {
+	// EraseGuestData deletes or anonymizes the records of the guest and the reservations, and returns their number
+	EraseGuestData(ctx context.Context, guestID reservation.GuestID, reservationIDs []reservation.ReservationID) (int, error)
+}
+
+// GuestDataEraserFunc adapts a function to a GuestDataEraser.
+type GuestDataEraserFunc func(ctx context.Context, guestID reservation.GuestID, reservationIDs []reservation.ReservationID) (int, error)
+
+// EraseGuestData calls f.
+func (f GuestDataEraserFunc) EraseGuestData(ctx context.Context, guestID reservation.GuestID, reservationIDs []reservation.ReservationID) (int, error) {
+	return f(ctx, guestID, reservationIDs)
+}
@@ -0,0 +1,118 @@
+// Package privacy contains the data subject request workflows (GDPR).
+// It exports and erases a guest's personal data across bounded contexts
+// while retaining financial records that must be kept by law.
+package privacy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// Service handles data subject requests.
+type Service struct {
+	reservationService *reservation.Service
+	paymentService     *payment.Service
+	erasers            []namedEraser
+}
+
+// namedEraser is an eraser with the name of its store in the ErasureReport.
+type namedEraser struct {
+	store  string
+	eraser GuestDataEraser
+}
+
+// NewService creates a new privacy service.
+func NewService(reservationSvc *reservation.Service, paymentSvc *payment.Service) *Service {
+	return &Service{
+		reservationService: reservationSvc,
+		paymentService:     paymentSvc,
+	}
+}
+
+// WithEraser registers the eraser of a further store that keeps personal data,
+// e.g. registration cards or reviews. Its records are counted under the store's
+// name in the ErasureReport.
+func (s *Service) WithEraser(store string, eraser GuestDataEraser) *Service {
+	s.erasers = append(s.erasers, namedEraser{store: store, eraser: eraser})
+	return s
+}
+
+// ExportGuestData returns the profile and all reservations and payments stored for a guest.
+func (s *Service) ExportGuestData(ctx context.Context, guestID reservation.GuestID) (*GuestDataExport, error) {
+	profile, err := s.reservationService.GetGuestProfile(ctx, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export profile: %w", err)
+	}
+
+	reservations, err := s.reservationService.ListReservationsByGuest(ctx, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export reservations: %w", err)
+	}
+
+	export := &GuestDataExport{
+		GuestID:      guestID,
+		ExportedAt:   time.Now(),
+		Profile:      *profile,
+		Reservations: make([]reservation.Reservation, 0, len(reservations)),
+		Payments:     []payment.Payment{},
+	}
+
+	for _, res := range reservations {
+		export.Reservations = append(export.Reservations, *res)
+
+		payments, err := s.paymentService.ListPaymentsByReservation(ctx, payment.ToReservationID(res.ID.Shared()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to export payments: %w", err)
+		}
+		export.Payments = append(export.Payments, payments...)
+	}
+
+	return export, nil
+}
+
+// EraseGuestData anonymizes the guest's personal data in all reservations, deletes the profile
+// and calls the erasers of the other stores in the order they were registered.
+// Payments only reference the reservation and are retained as financial records.
+// The request is rejected with reservation.ErrReservationOpen while a stay is still open.
+func (s *Service) EraseGuestData(ctx context.Context, guestID reservation.GuestID) (*ErasureReport, error) {
+	reservationIDs, err := s.reservationService.AnonymizeGuest(ctx, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase reservations: %w", err)
+	}
+
+	if err := s.reservationService.DeleteGuestProfile(ctx, guestID); err != nil {
+		return nil, fmt.Errorf("failed to erase profile: %w", err)
+	}
+
+	report := &ErasureReport{
+		GuestID:                guestID,
+		ErasedAt:               time.Now(),
+		AnonymizedReservations: reservationIDs,
+		RetainedPayments:       []payment.PaymentID{},
+		ErasedRecords:          make(map[string]int, len(s.erasers)),
+	}
+
+	for _, e := range s.erasers {
+		erased, err := e.eraser.EraseGuestData(ctx, guestID, reservationIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to erase %s: %w", e.store, err)
+		}
+		report.ErasedRecords[e.store] = erased
+	}
+
+	for _, id := range reservationIDs {
+		payments, err := s.paymentService.ListPaymentsByReservation(ctx, payment.ToReservationID(id.Shared()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list retained payments: %w", err)
+		}
+		for _, p := range payments {
+			report.RetainedPayments = append(report.RetainedPayments, p.ID)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,263 @@
+package privacy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/privacy"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockReservationRepository struct {
+	resource.Access[reservation.ReservationID, reservation.Reservation]
+}
+
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	all, _ := m.ReadAll(ctx)
+	var result []reservation.Reservation
+	for _, res := range all {
+		if res.GuestID == guestID {
+			result = append(result, res)
+		}
+	}
+	return result, nil
+}
+
+type mockGuestProfileRepository struct {
+	profiles map[reservation.GuestID]reservation.GuestProfile
+}
+
+func (m *mockGuestProfileRepository) FindProfile(ctx context.Context, guestID reservation.GuestID) (*reservation.GuestProfile, error) {
+	profile, ok := m.profiles[guestID]
+	if !ok {
+		return nil, nil
+	}
+	return &profile, nil
+}
+
+func (m *mockGuestProfileRepository) SaveProfile(ctx context.Context, profile reservation.GuestProfile) error {
+	m.profiles[profile.GuestID] = profile
+	return nil
+}
+
+func (m *mockGuestProfileRepository) DeleteProfile(ctx context.Context, guestID reservation.GuestID) error {
+	delete(m.profiles, guestID)
+	return nil
+}
+
+type mockPaymentRepository struct {
+	resource.Access[payment.PaymentID, payment.Payment]
+}
+
+func (m *mockPaymentRepository) FindByReservationID(ctx context.Context, reservationID payment.ReservationID) ([]payment.Payment, error) {
+	all, _ := m.ReadAll(ctx)
+	var result []payment.Payment
+	for _, p := range all {
+		if p.ReservationID == reservationID {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+type mockAvailabilityChecker struct{}
+
+func (m *mockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	return true, nil
+}
+
+func (m *mockAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockPaymentGateway struct{}
+
+func (m *mockPaymentGateway) Authorize(ctx context.Context, p *payment.Payment) (string, error) {
+	return "tx-001", nil
+}
+
+func (m *mockPaymentGateway) Capture(ctx context.Context, transactionID string, amount shared.Money) error {
+	return nil
+}
+
+func (m *mockPaymentGateway) Refund(ctx context.Context, transactionID string, amount shared.Money) error {
+	return nil
+}
+
+func (m *mockPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	return nil
+}
+
+type mockEraser struct {
+	erased         int
+	guestID        reservation.GuestID
+	reservationIDs []reservation.ReservationID
+}
+
+func (m *mockEraser) EraseGuestData(ctx context.Context, guestID reservation.GuestID, reservationIDs []reservation.ReservationID) (int, error) {
+	m.guestID = guestID
+	m.reservationIDs = reservationIDs
+	return m.erased, nil
+}
+
+type mockEventPublisher struct{}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, e event.Event) error {
+	return nil
+}
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type testServices struct {
+	reservationRepo    *mockReservationRepository
+	reservationService *reservation.Service
+	paymentService     *payment.Service
+	privacyService     *privacy.Service
+}
+
+func createTestServices() *testServices {
+	reservationRepo := &mockReservationRepository{resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()}
+	paymentRepo := &mockPaymentRepository{resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]()}
+	reservationService := reservation.NewService(reservationRepo, &mockAvailabilityChecker{}, &mockEventPublisher{}).
+		WithGuestProfiles(&mockGuestProfileRepository{profiles: make(map[reservation.GuestID]reservation.GuestProfile)})
+	paymentService := payment.NewService(paymentRepo, &mockPaymentGateway{}, &mockEventPublisher{})
+	return &testServices{
+		reservationRepo:    reservationRepo,
+		reservationService: reservationService,
+		paymentService:     paymentService,
+		privacyService:     privacy.NewService(reservationService, paymentService),
+	}
+}
+
+func createPaidReservation(t *testing.T, svc *testServices, id shared.ReservationID, guestID reservation.GuestID) {
+	t.Helper()
+	ctx := context.Background()
+	checkIn := time.Now().Add(72 * time.Hour).Truncate(24 * time.Hour)
+	guests := []reservation.GuestInfo{{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+15551234567"}}
+	amount := shared.NewMoney(10000, "USD")
+	_, err := svc.reservationService.CreateReservation(ctx, reservation.ToReservationID(id), guestID, "room-101", reservation.NewDateRange(checkIn, checkIn.Add(48*time.Hour)), amount, guests)
+	assert.That(t, "reservation must be created", err == nil, true)
+	_, err = svc.paymentService.AuthorizePayment(ctx, payment.PaymentID("pay-"+id), payment.ToReservationID(id), amount, "credit_card")
+	assert.That(t, "payment must be authorized", err == nil, true)
+}
+
+// ============================================================================
+// ExportGuestData Tests
+// ============================================================================
+
+func Test_Service_ExportGuestData_Should_Return_Reservations_And_Payments(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	createPaidReservation(t, svc, "res-001", "john@example.com")
+	createPaidReservation(t, svc, "res-002", "jane@example.com")
+	_, _ = svc.reservationService.UpdateGuestProfile(context.Background(), "john@example.com", "John Doe", "", "")
+
+	// Act
+	export, err := svc.privacyService.ExportGuestData(context.Background(), "john@example.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must export one reservation", len(export.Reservations), 1)
+	assert.That(t, "must export one payment", len(export.Payments), 1)
+	assert.That(t, "payment must belong to reservation", export.Payments[0].ReservationID, payment.ReservationID("res-001"))
+	assert.That(t, "must export profile", export.Profile.Name, "John Doe")
+}
+
+func Test_Service_ExportGuestData_For_Unknown_Guest_Should_Return_Empty_Bundle(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+
+	// Act
+	export, err := svc.privacyService.ExportGuestData(context.Background(), "nobody@example.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "reservations must be empty", len(export.Reservations), 0)
+	assert.That(t, "payments must be empty", len(export.Payments), 0)
+}
+
+// ============================================================================
+// EraseGuestData Tests
+// ============================================================================
+
+func Test_Service_EraseGuestData_Should_Anonymize_And_Retain_Payments(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	ctx := context.Background()
+	createPaidReservation(t, svc, "res-001", "john@example.com")
+	_ = svc.reservationService.CancelReservation(ctx, "res-001", "guest request")
+	_, _ = svc.reservationService.UpdateGuestProfile(ctx, "john@example.com", "John Doe", "+15551234567", "")
+
+	// Act
+	report, err := svc.privacyService.EraseGuestData(ctx, "john@example.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "reservation must be anonymized", report.AnonymizedReservations, []reservation.ReservationID{"res-001"})
+	assert.That(t, "payment must be retained", report.RetainedPayments, []payment.PaymentID{"pay-res-001"})
+	stored, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "guest email must be removed", stored.Guests[0].Email, reservation.Email(""))
+	export, _ := svc.privacyService.ExportGuestData(ctx, "john@example.com")
+	assert.That(t, "guest must have no data left", len(export.Reservations), 0)
+	assert.That(t, "profile must be deleted", export.Profile, reservation.GuestProfile{GuestID: "john@example.com"})
+}
+
+func Test_Service_EraseGuestData_Should_Call_Erasers_And_Report_Them(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	ctx := context.Background()
+	createPaidReservation(t, svc, "res-001", "john@example.com")
+	_ = svc.reservationService.CancelReservation(ctx, "res-001", "guest request")
+	registrations := &mockEraser{erased: 1}
+	reviews := &mockEraser{}
+	svc.privacyService.WithEraser("registrations", registrations).WithEraser("reviews", reviews)
+
+	// Act
+	report, err := svc.privacyService.EraseGuestData(ctx, "john@example.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "eraser must get the guest", registrations.guestID, reservation.GuestID("john@example.com"))
+	assert.That(t, "eraser must get the reservations", registrations.reservationIDs, []reservation.ReservationID{"res-001"})
+	assert.That(t, "report must count the erased records per store", report.ErasedRecords, map[string]int{"registrations": 1, "reviews": 0})
+}
+
+func Test_Service_EraseGuestData_With_Failing_Eraser_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	failure := errors.New("store unavailable")
+	svc.privacyService.WithEraser("reviews", privacy.GuestDataEraserFunc(func(ctx context.Context, guestID reservation.GuestID, ids []reservation.ReservationID) (int, error) {
+		return 0, failure
+	}))
+
+	// Act
+	_, err := svc.privacyService.EraseGuestData(context.Background(), "john@example.com")
+
+	// Assert
+	assert.That(t, "error must wrap the eraser's error", errors.Is(err, failure), true)
+}
+
+func Test_Service_EraseGuestData_With_Open_Reservation_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	createPaidReservation(t, svc, "res-001", "john@example.com")
+
+	// Act
+	_, err := svc.privacyService.EraseGuestData(context.Background(), "john@example.com")
+
+	// Assert
+	assert.That(t, "error must be ErrReservationOpen", errors.Is(err, reservation.ErrReservationOpen), true)
+}
@@ -0,0 +1,79 @@
+package reconciliation
+
+import (
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// SettlementType is the kind of money movement reported by the payment gateway.
+type SettlementType string
+
+const (
+	SettlementCapture SettlementType = "capture"
+	SettlementRefund  SettlementType = "refund"
+)
+
+// Settlement is a capture or refund the payment gateway has paid out or charged back.
+type Settlement struct {
+	TransactionID string         `json:"transaction_id"`
+	Type          SettlementType `json:"type"`
+	Amount        shared.Money   `json:"amount"`
+	SettledAt     time.Time      `json:"settled_at"`
+}
+
+// DiscrepancyID is a strongly-typed identifier for discrepancies.
+type DiscrepancyID string
+
+// DiscrepancyKind describes how a settlement and a payment disagree.
+type DiscrepancyKind string
+
+const (
+	// KindMissingPayment is a settlement without a payment of the same transaction.
+	KindMissingPayment DiscrepancyKind = "missing_payment"
+	// KindMissingSettlement is a captured or refunded payment the gateway did not settle.
+	KindMissingSettlement DiscrepancyKind = "missing_settlement"
+	// KindAmountMismatch is a settlement whose amount differs from the payment.
+	KindAmountMismatch DiscrepancyKind = "amount_mismatch"
+	// KindStatusMismatch is a settlement of a payment that was not captured or refunded.
+	KindStatusMismatch DiscrepancyKind = "status_mismatch"
+)
+
+// Discrepancy is a mismatch between the gateway's settlements and the payments.
+// It stays flagged until a later run finds the transaction in order.
+type Discrepancy struct {
+	ID             DiscrepancyID     `json:"id"`
+	Kind           DiscrepancyKind   `json:"kind"`
+	SettlementType SettlementType    `json:"settlement_type"`
+	TransactionID  string            `json:"transaction_id"`
+	PaymentID      payment.PaymentID `json:"payment_id,omitempty"`
+	Expected       *shared.Money     `json:"expected,omitempty"` // Amount of the payment
+	Settled        *shared.Money     `json:"settled,omitempty"`  // Amount of the settlement
+	Detail         string            `json:"detail"`
+	DetectedAt     time.Time         `json:"detected_at"`
+	LastSeenAt     time.Time         `json:"last_seen_at"`
+}
+
+// NewDiscrepancyID returns the ID of a discrepancy, which is the same on every run,
+// so a mismatch found again updates the flagged discrepancy instead of adding one.
+func NewDiscrepancyID(kind DiscrepancyKind, settlementType SettlementType, transactionID string) DiscrepancyID {
+	return DiscrepancyID(string(kind) + ":" + string(settlementType) + ":" + transactionID)
+}
+
+// Run is the result of reconciling one settlement window.
+type Run struct {
+	From          time.Time     `json:"from"`
+	To            time.Time     `json:"to"`
+	RunAt         time.Time     `json:"run_at"`
+	Settlements   int           `json:"settlements"`
+	Matched       int           `json:"matched"`
+	Resolved      int           `json:"resolved"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// Report is the reconciliation status: the last run and all flagged discrepancies.
+type Report struct {
+	LastRun       *Run          `json:"last_run"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
@@ -0,0 +1,17 @@
+package reconciliation
+
+import (
+	"context"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// SettlementProvider fetches settlement reports from the payment gateway.
+type SettlementProvider interface {
+	// FetchSettlements returns the settlements with a SettledAt in [from, to)
+	FetchSettlements(ctx context.Context, from, to time.Time) ([]Settlement, error)
+}
+
+// DiscrepancyRepository persists flagged discrepancies until they are resolved.
+type DiscrepancyRepository resource.Access[DiscrepancyID, Discrepancy]
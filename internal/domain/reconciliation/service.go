@@ -0,0 +1,247 @@
+// Package reconciliation compares the settlement reports of the payment gateway
+// with the captured and refunded payments. Mismatches are flagged as discrepancies
+// and kept until a later run finds the transaction in order.
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+)
+
+// Service reconciles settlements with payments.
+type Service struct {
+	paymentService  *payment.Service
+	settlements     SettlementProvider
+	discrepancies   DiscrepancyRepository
+	settlementDelay time.Duration
+
+	mu      sync.Mutex
+	lastRun *Run
+}
+
+// NewService creates a new reconciliation service.
+func NewService(paymentSvc *payment.Service, settlements SettlementProvider, discrepancies DiscrepancyRepository) *Service {
+	return &Service{
+		paymentService: paymentSvc,
+		settlements:    settlements,
+		discrepancies:  discrepancies,
+	}
+}
+
+// WithSettlementDelay sets how long the gateway may take to settle a capture or refund.
+// Payments completed within the delay before the end of a window are not expected
+// in its settlements yet.
+func (s *Service) WithSettlementDelay(delay time.Duration) *Service {
+	s.settlementDelay = delay
+	return s
+}
+
+// settlementKey identifies the capture or refund of a transaction.
+type settlementKey struct {
+	settlementType SettlementType
+	transactionID  string
+}
+
+// Reconcile matches the settlements of the window [from, to) with the payments
+// by transaction ID and flags every mismatch. Discrepancies of transactions that
+// are in order now are resolved.
+func (s *Service) Reconcile(ctx context.Context, from, to time.Time) (*Run, error) {
+	settlements, err := s.settlements.FetchSettlements(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch settlements: %w", err)
+	}
+
+	payments, err := s.paymentService.ListPayments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+	byTransaction := make(map[string]*payment.Payment, len(payments))
+	for i := range payments {
+		if payments[i].TransactionID != "" {
+			byTransaction[payments[i].TransactionID] = &payments[i]
+		}
+	}
+
+	now := time.Now()
+	run := &Run{From: from, To: to, RunAt: now, Settlements: len(settlements), Discrepancies: []Discrepancy{}}
+	examined := make(map[settlementKey]bool)
+
+	for _, st := range settlements {
+		examined[settlementKey{st.Type, st.TransactionID}] = true
+		if d := checkSettlement(st, byTransaction[st.TransactionID]); d != nil {
+			run.Discrepancies = append(run.Discrepancies, *d)
+			continue
+		}
+		run.Matched++
+	}
+
+	// Every capture and refund completed in the window must have been settled.
+	expectedBefore := to.Add(-s.settlementDelay)
+	for i := range payments {
+		p := &payments[i]
+		for _, settlementType := range []SettlementType{SettlementCapture, SettlementRefund} {
+			completed, ok := completedAt(p, settlementType)
+			if !ok || completed.Before(from) || !completed.Before(expectedBefore) {
+				continue
+			}
+			key := settlementKey{settlementType, p.TransactionID}
+			if examined[key] {
+				continue
+			}
+			examined[key] = true
+			expected := expectedAmount(p, settlementType)
+			run.Discrepancies = append(run.Discrepancies, Discrepancy{
+				ID:             NewDiscrepancyID(KindMissingSettlement, settlementType, p.TransactionID),
+				Kind:           KindMissingSettlement,
+				SettlementType: settlementType,
+				TransactionID:  p.TransactionID,
+				PaymentID:      p.ID,
+				Expected:       &expected,
+				Detail:         fmt.Sprintf("%s of %s at %s was not settled", settlementType, expected.FormatAmount(), completed.Format(time.RFC3339)),
+			})
+		}
+	}
+
+	resolved, err := s.flag(ctx, run, examined, now)
+	if err != nil {
+		return nil, err
+	}
+	run.Resolved = resolved
+
+	s.mu.Lock()
+	s.lastRun = run
+	s.mu.Unlock()
+
+	return run, nil
+}
+
+// Report returns the last run and all flagged discrepancies, oldest first.
+func (s *Service) Report(ctx context.Context) (*Report, error) {
+	discrepancies, err := s.discrepancies.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read discrepancies: %w", err)
+	}
+	sort.Slice(discrepancies, func(i, j int) bool {
+		if !discrepancies[i].DetectedAt.Equal(discrepancies[j].DetectedAt) {
+			return discrepancies[i].DetectedAt.Before(discrepancies[j].DetectedAt)
+		}
+		return discrepancies[i].ID < discrepancies[j].ID
+	})
+	if discrepancies == nil {
+		discrepancies = []Discrepancy{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &Report{LastRun: s.lastRun, Discrepancies: discrepancies}, nil
+}
+
+// flag stores the discrepancies of the run, keeping the time they were first detected,
+// and deletes the discrepancies of examined settlements that are in order now.
+func (s *Service) flag(ctx context.Context, run *Run, examined map[settlementKey]bool, now time.Time) (int, error) {
+	stored, err := s.discrepancies.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read discrepancies: %w", err)
+	}
+	existing := make(map[DiscrepancyID]Discrepancy, len(stored))
+	for _, d := range stored {
+		existing[d.ID] = d
+	}
+
+	found := make(map[DiscrepancyID]bool, len(run.Discrepancies))
+	for i := range run.Discrepancies {
+		d := &run.Discrepancies[i]
+		found[d.ID] = true
+		d.DetectedAt = now
+		d.LastSeenAt = now
+		if prev, ok := existing[d.ID]; ok {
+			d.DetectedAt = prev.DetectedAt
+			if err := s.discrepancies.Update(ctx, d.ID, *d); err != nil {
+				return 0, fmt.Errorf("failed to update discrepancy: %w", err)
+			}
+			continue
+		}
+		if err := s.discrepancies.Create(ctx, d.ID, *d); err != nil {
+			return 0, fmt.Errorf("failed to create discrepancy: %w", err)
+		}
+	}
+
+	resolved := 0
+	for _, d := range stored {
+		if found[d.ID] || !examined[settlementKey{d.SettlementType, d.TransactionID}] {
+			continue
+		}
+		if err := s.discrepancies.Delete(ctx, d.ID); err != nil {
+			return 0, fmt.Errorf("failed to resolve discrepancy: %w", err)
+		}
+		resolved++
+	}
+	return resolved, nil
+}
+
+// checkSettlement compares a settlement with the payment of its transaction
+// and returns the discrepancy, or nil if both agree.
+func checkSettlement(st Settlement, p *payment.Payment) *Discrepancy {
+	settled := st.Amount
+	d := &Discrepancy{
+		SettlementType: st.Type,
+		TransactionID:  st.TransactionID,
+		Settled:        &settled,
+	}
+
+	if p == nil {
+		d.Kind = KindMissingPayment
+		d.Detail = fmt.Sprintf("%s of %s has no payment", st.Type, st.Amount.FormatAmount())
+		d.ID = NewDiscrepancyID(d.Kind, d.SettlementType, d.TransactionID)
+		return d
+	}
+
+	expected := expectedAmount(p, st.Type)
+	d.PaymentID = p.ID
+	d.Expected = &expected
+	if _, ok := completedAt(p, st.Type); !ok {
+		d.Kind = KindStatusMismatch
+		d.Detail = fmt.Sprintf("%s was settled, but the payment is %s", st.Type, p.Status)
+	} else if st.Amount != expected {
+		d.Kind = KindAmountMismatch
+		d.Detail = fmt.Sprintf("%s of %s was settled, but the payment is %s", st.Type, st.Amount.FormatAmount(), expected.FormatAmount())
+	} else {
+		return nil
+	}
+
+	d.ID = NewDiscrepancyID(d.Kind, d.SettlementType, d.TransactionID)
+	return d
+}
+
+// expectedAmount returns the amount the gateway must settle for the payment:
+// the captured amount for the capture, the refunded amount for the refund.
+func expectedAmount(p *payment.Payment, settlementType SettlementType) payment.Money {
+	if settlementType == SettlementRefund {
+		return p.RefundAmount()
+	}
+	return p.CaptureAmount()
+}
+
+// completedAt returns when the payment was captured or refunded, according to the
+// settlement type. It reports false if the payment never reached that status.
+func completedAt(p *payment.Payment, settlementType SettlementType) (time.Time, bool) {
+	status := payment.StatusCaptured
+	if settlementType == SettlementRefund {
+		status = payment.StatusRefunded
+	}
+
+	var at time.Time
+	found := false
+	for _, attempt := range p.Attempts {
+		if attempt.Status == status {
+			at = attempt.AttemptedAt
+			found = true
+		}
+	}
+	return at, found
+}
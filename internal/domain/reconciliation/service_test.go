@@ -0,0 +1,369 @@
+package reconciliation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reconciliation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockPaymentRepository struct {
+	resource.Access[payment.PaymentID, payment.Payment]
+}
+
+func (m *mockPaymentRepository) FindByReservationID(ctx context.Context, reservationID payment.ReservationID) ([]payment.Payment, error) {
+	return nil, nil
+}
+
+type mockPaymentGateway struct{}
+
+func (m *mockPaymentGateway) Authorize(ctx context.Context, p *payment.Payment) (string, error) {
+	return "tx-" + string(p.ID), nil
+}
+
+func (m *mockPaymentGateway) Capture(ctx context.Context, transactionID string, amount shared.Money) error {
+	return nil
+}
+
+func (m *mockPaymentGateway) Refund(ctx context.Context, transactionID string, amount shared.Money) error {
+	return nil
+}
+
+func (m *mockPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	return nil
+}
+
+type mockEventPublisher struct{}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, e event.Event) error {
+	return nil
+}
+
+type mockSettlementProvider struct {
+	settlements []reconciliation.Settlement
+	err         error
+}
+
+func (m *mockSettlementProvider) FetchSettlements(ctx context.Context, from, to time.Time) ([]reconciliation.Settlement, error) {
+	return m.settlements, m.err
+}
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type testServices struct {
+	paymentService        *payment.Service
+	settlements           *mockSettlementProvider
+	discrepancies         reconciliation.DiscrepancyRepository
+	reconciliationService *reconciliation.Service
+}
+
+func createTestServices() *testServices {
+	paymentRepo := &mockPaymentRepository{resource.NewInMemoryAccess[payment.PaymentID, payment.Payment]()}
+	paymentService := payment.NewService(paymentRepo, &mockPaymentGateway{}, &mockEventPublisher{})
+	settlements := &mockSettlementProvider{}
+	discrepancies := resource.NewInMemoryAccess[reconciliation.DiscrepancyID, reconciliation.Discrepancy]()
+	return &testServices{
+		paymentService:        paymentService,
+		settlements:           settlements,
+		discrepancies:         discrepancies,
+		reconciliationService: reconciliation.NewService(paymentService, settlements, discrepancies),
+	}
+}
+
+func capturePayment(t *testing.T, svc *testServices, id payment.PaymentID, amount shared.Money) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := svc.paymentService.AuthorizePayment(ctx, id, payment.ReservationID("res-"+string(id)), amount, "credit_card"); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	if err := svc.paymentService.CapturePayment(ctx, id); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+}
+
+func settlement(transactionID string, settlementType reconciliation.SettlementType, amount shared.Money) reconciliation.Settlement {
+	return reconciliation.Settlement{TransactionID: transactionID, Type: settlementType, Amount: amount, SettledAt: time.Now()}
+}
+
+func window() (time.Time, time.Time) {
+	now := time.Now()
+	return now.Add(-time.Hour), now.Add(time.Hour)
+}
+
+// ============================================================================
+// Reconcile Tests
+// ============================================================================
+
+func Test_Service_Reconcile_With_Matching_Settlement_Should_Match(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	amount := shared.NewMoney(20000, "EUR")
+	capturePayment(t, svc, "pay-001", amount)
+	svc.settlements.settlements = []reconciliation.Settlement{settlement("tx-pay-001", reconciliation.SettlementCapture, amount)}
+	from, to := window()
+
+	// Act
+	run, err := svc.reconciliationService.Reconcile(context.Background(), from, to)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "settlements must be counted", run.Settlements, 1)
+	assert.That(t, "settlement must be matched", run.Matched, 1)
+	assert.That(t, "no discrepancies must be flagged", len(run.Discrepancies), 0)
+}
+
+func Test_Service_Reconcile_With_Unknown_Transaction_Should_Flag_Missing_Payment(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.settlements.settlements = []reconciliation.Settlement{settlement("tx-unknown", reconciliation.SettlementCapture, shared.NewMoney(5000, "EUR"))}
+	from, to := window()
+
+	// Act
+	run, err := svc.reconciliationService.Reconcile(context.Background(), from, to)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one discrepancy must be flagged", len(run.Discrepancies), 1)
+	assert.That(t, "kind must be missing payment", run.Discrepancies[0].Kind, reconciliation.KindMissingPayment)
+	assert.That(t, "settled amount must be recorded", *run.Discrepancies[0].Settled, shared.NewMoney(5000, "EUR"))
+}
+
+func Test_Service_Reconcile_With_Unsettled_Capture_Should_Flag_Missing_Settlement(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	capturePayment(t, svc, "pay-001", shared.NewMoney(20000, "EUR"))
+	from, to := window()
+
+	// Act
+	run, err := svc.reconciliationService.Reconcile(context.Background(), from, to)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one discrepancy must be flagged", len(run.Discrepancies), 1)
+	assert.That(t, "kind must be missing settlement", run.Discrepancies[0].Kind, reconciliation.KindMissingSettlement)
+	assert.That(t, "payment must be referenced", run.Discrepancies[0].PaymentID, payment.PaymentID("pay-001"))
+	assert.That(t, "settlement type must be capture", run.Discrepancies[0].SettlementType, reconciliation.SettlementCapture)
+}
+
+func Test_Service_Reconcile_With_Different_Amount_Should_Flag_Amount_Mismatch(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	capturePayment(t, svc, "pay-001", shared.NewMoney(20000, "EUR"))
+	svc.settlements.settlements = []reconciliation.Settlement{settlement("tx-pay-001", reconciliation.SettlementCapture, shared.NewMoney(19000, "EUR"))}
+	from, to := window()
+
+	// Act
+	run, err := svc.reconciliationService.Reconcile(context.Background(), from, to)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one discrepancy must be flagged", len(run.Discrepancies), 1)
+	assert.That(t, "kind must be amount mismatch", run.Discrepancies[0].Kind, reconciliation.KindAmountMismatch)
+	assert.That(t, "expected amount must be the payment", *run.Discrepancies[0].Expected, shared.NewMoney(20000, "EUR"))
+}
+
+func Test_Service_Reconcile_With_Refund_Of_Captured_Payment_Should_Flag_Status_Mismatch(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	amount := shared.NewMoney(20000, "EUR")
+	capturePayment(t, svc, "pay-001", amount)
+	svc.settlements.settlements = []reconciliation.Settlement{
+		settlement("tx-pay-001", reconciliation.SettlementCapture, amount),
+		settlement("tx-pay-001", reconciliation.SettlementRefund, amount),
+	}
+	from, to := window()
+
+	// Act
+	run, err := svc.reconciliationService.Reconcile(context.Background(), from, to)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "capture must be matched", run.Matched, 1)
+	assert.That(t, "one discrepancy must be flagged", len(run.Discrepancies), 1)
+	assert.That(t, "kind must be status mismatch", run.Discrepancies[0].Kind, reconciliation.KindStatusMismatch)
+	assert.That(t, "settlement type must be refund", run.Discrepancies[0].SettlementType, reconciliation.SettlementRefund)
+}
+
+func Test_Service_Reconcile_With_Refunded_Payment_Should_Expect_Both_Settlements(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	amount := shared.NewMoney(20000, "EUR")
+	capturePayment(t, svc, "pay-001", amount)
+	if err := svc.paymentService.RefundPayment(context.Background(), "pay-001"); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	svc.settlements.settlements = []reconciliation.Settlement{settlement("tx-pay-001", reconciliation.SettlementCapture, amount)}
+	from, to := window()
+
+	// Act
+	run, err := svc.reconciliationService.Reconcile(context.Background(), from, to)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one discrepancy must be flagged", len(run.Discrepancies), 1)
+	assert.That(t, "kind must be missing settlement", run.Discrepancies[0].Kind, reconciliation.KindMissingSettlement)
+	assert.That(t, "settlement type must be refund", run.Discrepancies[0].SettlementType, reconciliation.SettlementRefund)
+}
+
+func Test_Service_Reconcile_With_Partial_Refund_Should_Match_Refunded_Amount(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	amount := shared.NewMoney(20000, "EUR")
+	capturePayment(t, svc, "pay-001", amount)
+	refunded, err := svc.paymentService.RetainFee(context.Background(), "pay-001", shared.NewMoney(5000, "EUR"))
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	svc.settlements.settlements = []reconciliation.Settlement{
+		settlement("tx-pay-001", reconciliation.SettlementCapture, amount),
+		settlement("tx-pay-001", reconciliation.SettlementRefund, refunded),
+	}
+	from, to := window()
+
+	// Act
+	run, err := svc.reconciliationService.Reconcile(context.Background(), from, to)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "both settlements must match", run.Matched, 2)
+	assert.That(t, "no discrepancy must be flagged", len(run.Discrepancies), 0)
+}
+
+func Test_Service_Reconcile_With_Settlement_Delay_Should_Not_Expect_Recent_Captures(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.reconciliationService.WithSettlementDelay(2 * time.Hour)
+	capturePayment(t, svc, "pay-001", shared.NewMoney(20000, "EUR"))
+	from, to := window()
+
+	// Act
+	run, err := svc.reconciliationService.Reconcile(context.Background(), from, to)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "no discrepancies must be flagged", len(run.Discrepancies), 0)
+}
+
+func Test_Service_Reconcile_Should_Store_Discrepancies(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	capturePayment(t, svc, "pay-001", shared.NewMoney(20000, "EUR"))
+	from, to := window()
+	ctx := context.Background()
+
+	// Act
+	run, err := svc.reconciliationService.Reconcile(ctx, from, to)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	stored, readErr := svc.discrepancies.Read(ctx, run.Discrepancies[0].ID)
+	assert.That(t, "discrepancy must be stored", readErr, nil)
+	assert.That(t, "stored kind must match", stored.Kind, reconciliation.KindMissingSettlement)
+}
+
+func Test_Service_Reconcile_Again_Should_Keep_Detection_Time(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	capturePayment(t, svc, "pay-001", shared.NewMoney(20000, "EUR"))
+	from, to := window()
+	ctx := context.Background()
+	first, err := svc.reconciliationService.Reconcile(ctx, from, to)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	// Act
+	second, err := svc.reconciliationService.Reconcile(ctx, from, to)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	all, _ := svc.discrepancies.ReadAll(ctx)
+	assert.That(t, "discrepancy must be stored once", len(all), 1)
+	assert.That(t, "detection time must be kept", second.Discrepancies[0].DetectedAt.Equal(first.Discrepancies[0].DetectedAt), true)
+}
+
+func Test_Service_Reconcile_With_Late_Settlement_Should_Resolve_Discrepancy(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	amount := shared.NewMoney(20000, "EUR")
+	capturePayment(t, svc, "pay-001", amount)
+	from, to := window()
+	ctx := context.Background()
+	if _, err := svc.reconciliationService.Reconcile(ctx, from, to); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	svc.settlements.settlements = []reconciliation.Settlement{settlement("tx-pay-001", reconciliation.SettlementCapture, amount)}
+
+	// Act
+	run, err := svc.reconciliationService.Reconcile(ctx, from, to)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "discrepancy must be resolved", run.Resolved, 1)
+	all, _ := svc.discrepancies.ReadAll(ctx)
+	assert.That(t, "no discrepancies must remain", len(all), 0)
+}
+
+func Test_Service_Reconcile_With_Fetch_Error_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.settlements.err = errors.New("gateway unavailable")
+	from, to := window()
+
+	// Act
+	_, err := svc.reconciliationService.Reconcile(context.Background(), from, to)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+// ============================================================================
+// Report Tests
+// ============================================================================
+
+func Test_Service_Report_Before_First_Run_Should_Be_Empty(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+
+	// Act
+	report, err := svc.reconciliationService.Report(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "last run must be nil", report.LastRun == nil, true)
+	assert.That(t, "discrepancies must be empty", len(report.Discrepancies), 0)
+}
+
+func Test_Service_Report_Should_Return_Last_Run_And_Discrepancies(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	capturePayment(t, svc, "pay-001", shared.NewMoney(20000, "EUR"))
+	svc.settlements.settlements = []reconciliation.Settlement{settlement("tx-unknown", reconciliation.SettlementCapture, shared.NewMoney(5000, "EUR"))}
+	from, to := window()
+	ctx := context.Background()
+	if _, err := svc.reconciliationService.Reconcile(ctx, from, to); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	// Act
+	report, err := svc.reconciliationService.Report(ctx)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "last run must be set", report.LastRun != nil, true)
+	assert.That(t, "last run window must match", report.LastRun.From.Equal(from), true)
+	assert.That(t, "both discrepancies must be reported", len(report.Discrepancies), 2)
+}
@@ -0,0 +1,249 @@
+package reservation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// AddOnID identifies an add-on of a reservation.
+type AddOnID string
+
+// AddOnKind is an extra a guest can book for the stay.
+type AddOnKind string
+
+const (
+	AddOnBreakfast    AddOnKind = "breakfast"
+	AddOnParking      AddOnKind = "parking"
+	AddOnLateCheckout AddOnKind = "late_checkout"
+)
+
+// Add-on errors.
+var (
+	ErrInvalidAddOnKind     = errors.New("invalid add-on, expected breakfast, parking or late_checkout")
+	ErrAddOnsUnavailable    = errors.New("add-ons are not configured")
+	ErrAddOnNotPriced       = errors.New("add-on is not offered in the currency of the reservation")
+	ErrAddOnAlreadyBooked   = errors.New("add-on is already booked for the reservation")
+	ErrAddOnNotFound        = errors.New("add-on not found")
+	ErrAddOnsClosed         = errors.New("add-ons can only be changed before check-in")
+	ErrAddOnPaidWithBalance = errors.New("add-on was paid with the balance and can no longer be removed")
+)
+
+// ParseAddOnKind validates an add-on kind.
+func ParseAddOnKind(s string) (AddOnKind, error) {
+	switch kind := AddOnKind(s); kind {
+	case AddOnBreakfast, AddOnParking, AddOnLateCheckout:
+		return kind, nil
+	}
+	return "", ErrInvalidAddOnKind
+}
+
+// PriceLine is a position of the price of an add-on, e.g. the breakfasts of one night.
+type PriceLine struct {
+	Description string
+	Quantity    int
+	UnitPrice   Money
+}
+
+// Amount returns the price of the line.
+func (l PriceLine) Amount() Money {
+	return shared.NewMoney(l.UnitPrice.Amount*int64(l.Quantity), l.UnitPrice.Currency)
+}
+
+// AddOn is an extra booked for a reservation, priced by its own lines.
+// Add-ons booked while the balance of a payment schedule is open are paid with
+// the balance. Later ones are charged on their own, by the payment PaymentID.
+type AddOn struct {
+	ID        AddOnID
+	Kind      AddOnKind
+	Lines     []PriceLine
+	AddedAt   time.Time
+	InBalance bool   // Paid with the balance of the payment schedule
+	PaymentID string // Payment that charged the add-on, empty if not charged on its own
+}
+
+// Total returns the sum of the price lines.
+func (a AddOn) Total() Money {
+	var total int64
+	currency := ""
+	for _, line := range a.Lines {
+		amount := line.Amount()
+		total += amount.Amount
+		currency = amount.Currency
+	}
+	return shared.NewMoney(total, currency)
+}
+
+// AddOnPricing prices the add-ons of a stay from their unit prices: breakfast per
+// guest and night, parking per night and late checkout once per stay.
+type AddOnPricing struct {
+	prices map[AddOnKind]Money
+}
+
+// NewAddOnPricing creates an add-on pricing without any add-ons on offer.
+func NewAddOnPricing() *AddOnPricing {
+	return &AddOnPricing{prices: make(map[AddOnKind]Money)}
+}
+
+// WithPrice offers the add-on at the unit price.
+func (p *AddOnPricing) WithPrice(kind AddOnKind, unitPrice Money) *AddOnPricing {
+	p.prices[kind] = unitPrice
+	return p
+}
+
+// Quote returns the add-on of the kind priced for the stay of the reservation.
+func (p *AddOnPricing) Quote(id AddOnID, kind AddOnKind, r *Reservation, now time.Time) (AddOn, error) {
+	unitPrice, ok := p.prices[kind]
+	if !ok || unitPrice.Currency != r.TotalAmount.Currency {
+		return AddOn{}, ErrAddOnNotPriced
+	}
+
+	var lines []PriceLine
+	switch kind {
+	case AddOnBreakfast, AddOnParking:
+		quantity := 1
+		if kind == AddOnBreakfast {
+			quantity = len(r.Guests)
+		}
+		for night := range r.Nights() {
+			date := r.DateRange.CheckIn.AddDate(0, 0, night).Format("2006-01-02")
+			lines = append(lines, PriceLine{
+				Description: fmt.Sprintf("%s %s", kind, date),
+				Quantity:    quantity,
+				UnitPrice:   unitPrice,
+			})
+		}
+	case AddOnLateCheckout:
+		lines = []PriceLine{{Description: string(kind), Quantity: 1, UnitPrice: unitPrice}}
+	default:
+		return AddOn{}, ErrInvalidAddOnKind
+	}
+
+	return AddOn{ID: id, Kind: kind, Lines: lines, AddedAt: now}, nil
+}
+
+// RoomAmount returns the price of the stay in the room, without the add-ons.
+func (r *Reservation) RoomAmount() Money {
+	amount := r.TotalAmount.Amount
+	for _, addOn := range r.AddOns {
+		amount -= addOn.Total().Amount
+	}
+	return shared.NewMoney(amount, r.TotalAmount.Currency)
+}
+
+// FindAddOn returns the add-on of the reservation with the ID.
+func (r *Reservation) FindAddOn(id AddOnID) (*AddOn, error) {
+	for i := range r.AddOns {
+		if r.AddOns[i].ID == id {
+			return &r.AddOns[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrAddOnNotFound, id)
+}
+
+// AddAddOn books the add-on for the stay and adds its price to the total.
+// While the balance of the payment schedule is open, it is paid with the balance.
+func (r *Reservation) AddAddOn(addOn AddOn, now time.Time) error {
+	if err := r.checkAddOnsOpen(now); err != nil {
+		return err
+	}
+	for _, booked := range r.AddOns {
+		if booked.Kind == addOn.Kind {
+			return fmt.Errorf("%w: %s", ErrAddOnAlreadyBooked, addOn.Kind)
+		}
+	}
+	total := addOn.Total()
+	if total.Currency != r.TotalAmount.Currency {
+		return ErrAddOnNotPriced
+	}
+
+	totalAmount, err := r.TotalAmount.Add(total)
+	if err != nil {
+		return err
+	}
+
+	addOn.InBalance = r.BalanceOpen()
+	if addOn.InBalance {
+		balance, err := r.Schedule.Balance.Add(total)
+		if err != nil {
+			return err
+		}
+		r.Schedule.Balance = balance
+	}
+	r.TotalAmount = totalAmount
+	r.AddOns = append(r.AddOns, addOn)
+	r.UpdatedAt = now
+	r.RecordEvent(NewEventAddOnAdded().
+		WithReservationID(r.ID).
+		WithAddOnID(addOn.ID).
+		WithKind(addOn.Kind).
+		WithAmount(total).
+		WithTotalAmount(r.TotalAmount).
+		WithInBalance(addOn.InBalance))
+	return nil
+}
+
+// MarkAddOnCharged records the payment that charged the add-on on its own.
+func (r *Reservation) MarkAddOnCharged(id AddOnID, paymentID string) error {
+	addOn, err := r.FindAddOn(id)
+	if err != nil {
+		return err
+	}
+	addOn.PaymentID = paymentID
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// RemoveAddOn cancels the add-on and deducts its price from the total and the open balance.
+// It returns the removed add-on, so a payment that charged it can be refunded.
+func (r *Reservation) RemoveAddOn(id AddOnID, now time.Time) (AddOn, error) {
+	if err := r.checkAddOnsOpen(now); err != nil {
+		return AddOn{}, err
+	}
+	addOn, err := r.FindAddOn(id)
+	if err != nil {
+		return AddOn{}, err
+	}
+	if addOn.InBalance && !r.BalanceOpen() {
+		return AddOn{}, ErrAddOnPaidWithBalance
+	}
+
+	removed := *addOn
+	total := removed.Total()
+	totalAmount, err := r.TotalAmount.Subtract(total)
+	if err != nil {
+		return AddOn{}, err
+	}
+	if removed.InBalance {
+		balance, err := r.Schedule.Balance.Subtract(total)
+		if err != nil {
+			return AddOn{}, err
+		}
+		r.Schedule.Balance = balance
+	}
+	r.TotalAmount = totalAmount
+	r.AddOns = slices.DeleteFunc(r.AddOns, func(a AddOn) bool { return a.ID == id })
+	r.UpdatedAt = now
+	r.RecordEvent(NewEventAddOnRemoved().
+		WithReservationID(r.ID).
+		WithAddOnID(removed.ID).
+		WithKind(removed.Kind).
+		WithAmount(total).
+		WithTotalAmount(r.TotalAmount))
+	return removed, nil
+}
+
+// checkAddOnsOpen reports whether add-ons can be changed: the guest of a
+// confirmed reservation changes them until check-in.
+func (r *Reservation) checkAddOnsOpen(now time.Time) error {
+	if r.Status != StatusConfirmed || r.IsExternalHold() {
+		return fmt.Errorf("%w: add-ons are booked for confirmed reservations", ErrInvalidStateTransition)
+	}
+	if !now.Before(r.DateRange.CheckIn) {
+		return ErrAddOnsClosed
+	}
+	return nil
+}
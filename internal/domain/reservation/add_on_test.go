@@ -0,0 +1,200 @@
+package reservation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func addOnPricing() *reservation.AddOnPricing {
+	return reservation.NewAddOnPricing().
+		WithPrice(reservation.AddOnBreakfast, shared.NewMoney(1500, "USD")).
+		WithPrice(reservation.AddOnLateCheckout, shared.NewMoney(3000, "USD"))
+}
+
+func createConfirmedReservation(t *testing.T) *reservation.Reservation {
+	t.Helper()
+	res := createValidReservation(t)
+	if err := res.Confirm(); err != nil {
+		t.Fatalf("failed to confirm reservation: %v", err)
+	}
+	return res
+}
+
+// ============================================================================
+// AddOnPricing Tests
+// ============================================================================
+
+func Test_AddOnPricing_Quote_Breakfast_Should_Price_Each_Night_Per_Guest(t *testing.T) {
+	// Arrange
+	res := createConfirmedReservation(t)
+
+	// Act
+	addOn, err := addOnPricing().Quote("addon-001", reservation.AddOnBreakfast, res, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "one line per night", len(addOn.Lines), 3)
+	assert.That(t, "quantity must be the number of guests", addOn.Lines[0].Quantity, 1)
+	assert.That(t, "total must be the sum of the lines", addOn.Total(), shared.NewMoney(4500, "USD"))
+}
+
+func Test_AddOnPricing_Quote_Without_Price_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createConfirmedReservation(t)
+
+	// Act
+	_, err := addOnPricing().Quote("addon-001", reservation.AddOnParking, res, time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrAddOnNotPriced", errors.Is(err, reservation.ErrAddOnNotPriced), true)
+}
+
+// ============================================================================
+// AddAddOn Tests
+// ============================================================================
+
+func Test_Reservation_AddAddOn_Should_Recalculate_Total(t *testing.T) {
+	// Arrange
+	res := createConfirmedReservation(t)
+	addOn, _ := addOnPricing().Quote("addon-001", reservation.AddOnLateCheckout, res, time.Now())
+
+	// Act
+	err := res.AddAddOn(addOn, time.Now())
+	duplicate := res.AddAddOn(addOn, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "total must include the add-on", res.TotalAmount, shared.NewMoney(13000, "USD"))
+	assert.That(t, "add-on must be booked once", errors.Is(duplicate, reservation.ErrAddOnAlreadyBooked), true)
+}
+
+func Test_Reservation_AddAddOn_Should_Record_Event(t *testing.T) {
+	// Arrange
+	res := createConfirmedReservation(t)
+	_ = res.PullEvents()
+	addOn, _ := addOnPricing().Quote("addon-001", reservation.AddOnLateCheckout, res, time.Now())
+	now := time.Now().Add(time.Hour)
+
+	// Act
+	err := res.AddAddOn(addOn, now)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "updated at must be the time of the booking", res.UpdatedAt, now)
+	events := res.PullEvents()
+	assert.That(t, "one event must be recorded", len(events), 1)
+	added, ok := events[0].(*reservation.EventAddOnAdded)
+	assert.That(t, "event must be add-on added", ok, true)
+	assert.That(t, "event must carry the add-on", added.AddOnID, reservation.AddOnID("addon-001"))
+	assert.That(t, "event must carry the new total", added.TotalAmount, shared.NewMoney(13000, "USD"))
+}
+
+func Test_Reservation_AddAddOn_Should_Keep_Amount_Due_At_Booking(t *testing.T) {
+	// Arrange
+	res := createConfirmedReservation(t)
+	addOn, _ := addOnPricing().Quote("addon-001", reservation.AddOnLateCheckout, res, time.Now())
+	_ = res.AddAddOn(addOn, time.Now())
+
+	// Act
+	err := res.VerifyPayment(validMoney())
+
+	// Assert
+	assert.That(t, "amount authorized at booking must still be accepted", err, nil)
+}
+
+func Test_Reservation_AddAddOn_When_Pending_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	addOn, _ := addOnPricing().Quote("addon-001", reservation.AddOnLateCheckout, res, time.Now())
+
+	// Act
+	err := res.AddAddOn(addOn, time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidStateTransition", errors.Is(err, reservation.ErrInvalidStateTransition), true)
+}
+
+func Test_Reservation_AddAddOn_After_Check_In_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createConfirmedReservation(t)
+	addOn, _ := addOnPricing().Quote("addon-001", reservation.AddOnLateCheckout, res, time.Now())
+
+	// Act
+	err := res.AddAddOn(addOn, res.DateRange.CheckIn)
+
+	// Assert
+	assert.That(t, "error must be ErrAddOnsClosed", errors.Is(err, reservation.ErrAddOnsClosed), true)
+}
+
+// ============================================================================
+// RemoveAddOn Tests
+// ============================================================================
+
+func Test_Reservation_RemoveAddOn_Should_Deduct_From_Total_And_Balance(t *testing.T) {
+	// Arrange
+	res := createConfirmedReservation(t)
+	res.Schedule = &reservation.PaymentSchedule{
+		Deposit: shared.NewMoney(3000, "USD"),
+		Balance: shared.NewMoney(7000, "USD"),
+	}
+	addOn, _ := addOnPricing().Quote("addon-001", reservation.AddOnLateCheckout, res, time.Now())
+	_ = res.AddAddOn(addOn, time.Now())
+
+	// Act
+	removed, err := res.RemoveAddOn("addon-001", time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "removed add-on must be paid with the balance", removed.InBalance, true)
+	assert.That(t, "total must be restored", res.TotalAmount, validMoney())
+	assert.That(t, "balance must be restored", res.Schedule.Balance, shared.NewMoney(7000, "USD"))
+}
+
+func Test_Reservation_RemoveAddOn_After_Balance_Paid_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createConfirmedReservation(t)
+	res.Schedule = &reservation.PaymentSchedule{
+		Deposit: shared.NewMoney(3000, "USD"),
+		Balance: shared.NewMoney(7000, "USD"),
+	}
+	addOn, _ := addOnPricing().Quote("addon-001", reservation.AddOnLateCheckout, res, time.Now())
+	_ = res.AddAddOn(addOn, time.Now())
+	_ = res.MarkBalancePaid(time.Now())
+
+	// Act
+	_, err := res.RemoveAddOn("addon-001", time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrAddOnPaidWithBalance", errors.Is(err, reservation.ErrAddOnPaidWithBalance), true)
+}
+
+func Test_Reservation_RemoveAddOn_Should_Record_Event(t *testing.T) {
+	// Arrange
+	res := createConfirmedReservation(t)
+	addOn, _ := addOnPricing().Quote("addon-001", reservation.AddOnLateCheckout, res, time.Now())
+	_ = res.AddAddOn(addOn, time.Now())
+	_ = res.PullEvents()
+	now := time.Now().Add(time.Hour)
+
+	// Act
+	_, err := res.RemoveAddOn("addon-001", now)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "updated at must be the time of the removal", res.UpdatedAt, now)
+	events := res.PullEvents()
+	assert.That(t, "one event must be recorded", len(events), 1)
+	removed, ok := events[0].(*reservation.EventAddOnRemoved)
+	assert.That(t, "event must be add-on removed", ok, true)
+	assert.That(t, "event must carry the add-on price", removed.Amount, shared.NewMoney(3000, "USD"))
+	assert.That(t, "event must carry the new total", removed.TotalAmount, validMoney())
+}
@@ -12,9 +12,24 @@ import (
 )
 
 // Type aliases for shared types
-type ReservationID = shared.ReservationID
 type Money = shared.Money
 
+// ReservationID identifies a reservation within the Reservation context.
+// Other contexts refer to reservations by shared.ReservationID and translate
+// at the boundary via ToReservationID and Shared, so the compiler rejects IDs
+// that cross contexts untranslated.
+type ReservationID string
+
+// ToReservationID translates a shared kernel reservation ID into this context.
+func ToReservationID(id shared.ReservationID) ReservationID {
+	return ReservationID(id)
+}
+
+// Shared translates the ID into the shared kernel for other contexts.
+func (id ReservationID) Shared() shared.ReservationID {
+	return shared.ReservationID(id)
+}
+
 // Local ID types for this bounded context
 type GuestID string
 type RoomID string
@@ -28,10 +43,46 @@ const (
 	StatusActive    ReservationStatus = "active"
 	StatusCompleted ReservationStatus = "completed"
 	StatusCancelled ReservationStatus = "cancelled"
+	StatusNoShow    ReservationStatus = "no_show"
 )
 
+// reservationStates holds the status transitions of reservations.
+// A no-show is final: the guest did not arrive and the room was released.
+var reservationStates = shared.NewStateMachine[ReservationStatus](ErrInvalidStateTransition).
+	Allow(StatusPending, StatusConfirmed, StatusCancelled).
+	Allow(StatusConfirmed, StatusActive, StatusCancelled, StatusNoShow).
+	Allow(StatusActive, StatusCompleted).
+	Guard(StatusCancelled, func(from ReservationStatus) error {
+		switch from {
+		case StatusCancelled:
+			return ErrAlreadyCancelled
+		case StatusCompleted:
+			return ErrCannotCancelCompleted
+		case StatusActive:
+			return ErrCannotCancelActive
+		case StatusNoShow:
+			return ErrCannotCancelNoShow
+		}
+		return nil
+	}).
+	Guard(StatusNoShow, func(from ReservationStatus) error {
+		if from == StatusNoShow {
+			return ErrAlreadyNoShow
+		}
+		return nil
+	})
+
+// AnonymizedGuestID replaces the guest ID of reservations whose guest data was erased.
+const AnonymizedGuestID GuestID = "anonymized"
+
+// ExternalHoldGuestID is the guest ID of external holds, which block a room for
+// dates booked elsewhere (e.g. on Airbnb) and have no guest of their own.
+const ExternalHoldGuestID GuestID = "external-hold"
+
 // Reservation is the aggregate root for booking reservations.
+// It records a domain event for its creation and each status transition.
 type Reservation struct {
+	shared.Aggregate
 	ID                 ReservationID
 	GuestID            GuestID
 	RoomID             RoomID
@@ -39,9 +90,16 @@ type Reservation struct {
 	Status             ReservationStatus
 	TotalAmount        Money
 	CancellationReason string
+	RefundRequired     bool
+	Disputed           bool // A payment of the reservation is disputed by the guest's card issuer
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 	Guests             []GuestInfo
+	Channel            string           // Sales channel of imported reservations (e.g. "booking.com"), empty for direct bookings
+	ExternalRef        string           // Reference of an external hold in the feed it was imported from, empty otherwise
+	Schedule           *PaymentSchedule // Installments of bookings paid with a deposit and a balance, nil if paid in full at booking
+	PreArrivalSentAt   time.Time        // When the guest was sent the reminder before arrival, zero if not yet
+	AddOns             []AddOn          // Extras booked for the stay; their prices are included in TotalAmount
 }
 
 // Validation errors.
@@ -54,11 +112,48 @@ var (
 	ErrCannotCancelActive      = errors.New("cannot cancel active reservation")
 	ErrCannotCancelCompleted   = errors.New("cannot cancel completed reservation")
 	ErrAlreadyCancelled        = errors.New("reservation already cancelled")
+	ErrCannotCancelNoShow      = errors.New("cannot cancel no-show reservation")
+	ErrAlreadyNoShow           = errors.New("reservation already marked as no-show")
 	ErrNoGuests                = errors.New("at least one guest required")
+	ErrInvalidEmail            = errors.New("invalid email address")
+	ErrInvalidPhoneNumber      = errors.New("invalid phone number, expected international format like +15551234567")
+	ErrReservationOpen         = errors.New("reservation is still open")
+	ErrNameRequired            = errors.New("name is required")
+	ErrProfilesUnavailable     = errors.New("guest profiles are not configured")
+	ErrInvalidLocale           = errors.New("invalid locale, expected a language tag like en or de-DE")
+	ErrRoomUnavailable         = errors.New("room is not available for the selected dates")
+	ErrPaymentMismatch         = errors.New("payment does not match the reservation total")
 )
 
 // NewReservation creates a new reservation with validation.
 func NewReservation(id ReservationID, guestID GuestID, roomID RoomID, dateRange DateRange, amount Money, guests []GuestInfo) (*Reservation, error) {
+	return newReservation(id, guestID, roomID, dateRange, amount, guests, "", nil)
+}
+
+// NewScheduledReservation creates a reservation that is paid in installments.
+// Its created event carries the deposit as the amount due at booking.
+// A nil schedule creates a reservation that is paid in full at booking.
+func NewScheduledReservation(id ReservationID, guestID GuestID, roomID RoomID, dateRange DateRange, amount Money, guests []GuestInfo, schedule *PaymentSchedule) (*Reservation, error) {
+	return newReservation(id, guestID, roomID, dateRange, amount, guests, "", schedule)
+}
+
+// NewChannelReservation creates a reservation sold by an external channel (OTA).
+// The channel has already guaranteed the booking, so the reservation is confirmed
+// right away and its created event carries the channel, which skips payment processing.
+func NewChannelReservation(id ReservationID, guestID GuestID, roomID RoomID, dateRange DateRange, amount Money, guests []GuestInfo, channel string) (*Reservation, error) {
+	r, err := newReservation(id, guestID, roomID, dateRange, amount, guests, channel, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.Confirm(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func newReservation(id ReservationID, guestID GuestID, roomID RoomID, dateRange DateRange, amount Money, guests []GuestInfo, channel string, schedule *PaymentSchedule) (*Reservation, error) {
 	r := &Reservation{
 		ID:          id,
 		GuestID:     guestID,
@@ -69,75 +164,212 @@ func NewReservation(id ReservationID, guestID GuestID, roomID RoomID, dateRange
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 		Guests:      guests,
+		Channel:     channel,
+		Schedule:    schedule,
 	}
 
 	if err := r.validate(); err != nil {
 		return nil, err
 	}
 
+	r.recordCreated()
+	return r, nil
+}
+
+// NewExternalHold creates a confirmed reservation that blocks a room for dates
+// booked on an external platform. The source names the platform's calendar feed.
+// Holds have no guests and no amount; only the date range is validated.
+func NewExternalHold(id ReservationID, roomID RoomID, dateRange DateRange, source string) (*Reservation, error) {
+	r := &Reservation{
+		ID:        id,
+		GuestID:   ExternalHoldGuestID,
+		RoomID:    roomID,
+		DateRange: dateRange,
+		Status:    StatusConfirmed,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Channel:   source,
+	}
+
+	if err := r.validateDateRange(); err != nil {
+		return nil, err
+	}
+
+	r.recordCreated()
 	return r, nil
 }
 
+// IsExternalHold reports whether the reservation blocks dates booked elsewhere.
+func (r *Reservation) IsExternalHold() bool {
+	return r.GuestID == ExternalHoldGuestID
+}
+
+// Release cancels an external hold after the dates were freed on the external platform.
+// Unlike Cancel, it ignores the cancellation deadline, because the booking is managed elsewhere.
+func (r *Reservation) Release() error {
+	if !r.IsExternalHold() {
+		return fmt.Errorf("%w: only external holds can be released", ErrInvalidStateTransition)
+	}
+	return r.cancel("released by external calendar")
+}
+
 // Confirm transitions the reservation from pending to confirmed.
 func (r *Reservation) Confirm() error {
-	if r.Status != StatusPending {
-		return fmt.Errorf("%w: cannot confirm from %s", ErrInvalidStateTransition, r.Status)
+	if err := reservationStates.Transition(r.Status, StatusConfirmed); err != nil {
+		return err
 	}
 
 	r.Status = StatusConfirmed
 	r.UpdatedAt = time.Now()
+	r.RecordEvent(NewEventConfirmed().
+		WithReservationID(r.ID).
+		WithGuestID(r.GuestID))
+	return nil
+}
+
+// VerifyPayment checks that a payment covers exactly the amount due at booking,
+// so a mismatched event cannot charge or confirm the wrong amount.
+func (r *Reservation) VerifyPayment(amount Money) error {
+	if due := r.AmountDueAtBooking(); amount != due {
+		return fmt.Errorf("%w: paid %s, expected %s", ErrPaymentMismatch, amount.FormatAmount(), due.FormatAmount())
+	}
 	return nil
 }
 
 // Activate transitions the reservation to active (check-in).
 func (r *Reservation) Activate() error {
-	if r.Status != StatusConfirmed {
-		return fmt.Errorf("%w: cannot activate from %s", ErrInvalidStateTransition, r.Status)
+	if err := reservationStates.Transition(r.Status, StatusActive); err != nil {
+		return err
 	}
 
 	r.Status = StatusActive
 	r.UpdatedAt = time.Now()
+	r.RecordEvent(NewEventActivated().WithReservationID(r.ID))
 	return nil
 }
 
 // Complete transitions the reservation to completed (check-out).
 func (r *Reservation) Complete() error {
-	if r.Status != StatusActive {
-		return fmt.Errorf("%w: cannot complete from %s", ErrInvalidStateTransition, r.Status)
+	if err := reservationStates.Transition(r.Status, StatusCompleted); err != nil {
+		return err
 	}
 
 	r.Status = StatusCompleted
 	r.UpdatedAt = time.Now()
+	r.RecordEvent(NewEventCompleted().WithReservationID(r.ID))
 	return nil
 }
 
-// Cancel cancels the reservation with business rule validation.
-func (r *Reservation) Cancel(reason string) error {
-	if r.Status == StatusCancelled {
-		return ErrAlreadyCancelled
+// MarkNoShow transitions a confirmed reservation to no-show, because the guest
+// did not arrive. The room is released for the remaining nights.
+func (r *Reservation) MarkNoShow() error {
+	if err := reservationStates.Transition(r.Status, StatusNoShow); err != nil {
+		return err
 	}
 
-	if r.Status == StatusCompleted {
-		return ErrCannotCancelCompleted
-	}
+	r.Status = StatusNoShow
+	r.UpdatedAt = time.Now()
+	r.RecordEvent(NewEventNoShow().
+		WithReservationID(r.ID).
+		WithGuestID(r.GuestID).
+		WithRoomID(r.RoomID))
+	return nil
+}
 
-	if r.Status == StatusActive {
-		return ErrCannotCancelActive
+// MarkPreArrivalSent records that the guest of a confirmed reservation was sent
+// the reminder with the check-in instructions before arrival.
+func (r *Reservation) MarkPreArrivalSent(now time.Time) error {
+	if r.Status != StatusConfirmed {
+		return fmt.Errorf("%w: only confirmed reservations are reminded before arrival", ErrInvalidStateTransition)
 	}
+	r.PreArrivalSentAt = now
+	r.UpdatedAt = time.Now()
+	return nil
+}
 
-	if !r.CanBeCancelled() {
+// Cancel cancels the reservation with business rule validation.
+func (r *Reservation) Cancel(reason string) error {
+	if reservationStates.Can(r.Status, StatusCancelled) && !r.CanBeCancelled() {
 		return ErrCannotCancelNearCheckIn
 	}
 
+	return r.cancel(reason)
+}
+
+// Displace cancels a reservation whose room was blocked by the hotel.
+// Unlike Cancel, it ignores the cancellation deadline, because the hotel cancels, not the guest.
+func (r *Reservation) Displace(reason string) error {
+	return r.cancel(reason)
+}
+
+// cancel transitions the reservation to cancelled and records the cancelled event.
+func (r *Reservation) cancel(reason string) error {
+	if err := reservationStates.Transition(r.Status, StatusCancelled); err != nil {
+		return err
+	}
+
 	r.Status = StatusCancelled
 	r.CancellationReason = reason
 	r.UpdatedAt = time.Now()
+	r.RecordEvent(NewEventCancelled().
+		WithReservationID(r.ID).
+		WithGuestID(r.GuestID).
+		WithReason(reason))
 	return nil
 }
 
+// MarkRefundRequired flags a cancelled reservation whose payment could not be refunded.
+func (r *Reservation) MarkRefundRequired() error {
+	if r.Status != StatusCancelled {
+		return fmt.Errorf("%w: cannot flag refund for %s reservation", ErrInvalidStateTransition, r.Status)
+	}
+	r.RefundRequired = true
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// ClearRefundRequired removes the refund flag once the payment has been refunded.
+func (r *Reservation) ClearRefundRequired() {
+	r.RefundRequired = false
+	r.UpdatedAt = time.Now()
+}
+
+// MarkDisputed flags a reservation whose payment is disputed by the guest's card issuer.
+func (r *Reservation) MarkDisputed() {
+	r.Disputed = true
+	r.UpdatedAt = time.Now()
+}
+
+// ClearDisputed removes the dispute flag once the issuer has decided the dispute.
+func (r *Reservation) ClearDisputed() {
+	r.Disputed = false
+	r.UpdatedAt = time.Now()
+}
+
+// Anonymize removes the guest's personal data from a closed reservation.
+// Dates, room and amount are kept as financial record. Open reservations
+// still need the guest data to fulfil the booking and cannot be anonymized.
+func (r *Reservation) Anonymize() error {
+	if !r.IsClosed() {
+		return fmt.Errorf("%w: cannot anonymize %s reservation", ErrReservationOpen, r.Status)
+	}
+	r.GuestID = AnonymizedGuestID
+	for i := range r.Guests {
+		r.Guests[i] = GuestInfo{Name: string(AnonymizedGuestID)}
+	}
+	r.UpdatedAt = time.Now()
+	r.RecordEvent(NewEventAnonymized().WithReservationID(r.ID))
+	return nil
+}
+
+// IsClosed reports whether the reservation is over: completed, cancelled or a no-show.
+func (r *Reservation) IsClosed() bool {
+	return r.Status == StatusCompleted || r.Status == StatusCancelled || r.Status == StatusNoShow
+}
+
 // CanBeCancelled checks if the reservation can be cancelled based on business rules.
 func (r *Reservation) CanBeCancelled() bool {
-	if r.Status == StatusCancelled || r.Status == StatusCompleted || r.Status == StatusActive {
+	if !reservationStates.Can(r.Status, StatusCancelled) {
 		return false
 	}
 
@@ -152,7 +384,7 @@ func (r *Reservation) IsOverlapping(other *Reservation) bool {
 		return false
 	}
 
-	if r.Status == StatusCancelled || other.Status == StatusCancelled {
+	if !r.occupiesRoom() || !other.occupiesRoom() {
 		return false
 	}
 
@@ -160,6 +392,12 @@ func (r *Reservation) IsOverlapping(other *Reservation) bool {
 		r.DateRange.CheckOut.After(other.DateRange.CheckIn)
 }
 
+// occupiesRoom reports whether the reservation holds its room.
+// Cancelled and no-show reservations have released it.
+func (r *Reservation) occupiesRoom() bool {
+	return r.Status != StatusCancelled && r.Status != StatusNoShow
+}
+
 // DaysUntilCheckIn returns the number of days until check-in.
 func (r *Reservation) DaysUntilCheckIn() int {
 	now := time.Now().Truncate(24 * time.Hour)
@@ -174,6 +412,19 @@ func (r *Reservation) Nights() int {
 	return int(nights)
 }
 
+// recordCreated records the created event of a new reservation.
+func (r *Reservation) recordCreated() {
+	r.RecordEvent(NewEventCreated().
+		WithReservationID(r.ID).
+		WithGuestID(r.GuestID).
+		WithRoomID(r.RoomID).
+		WithCheckIn(r.DateRange.CheckIn).
+		WithCheckOut(r.DateRange.CheckOut).
+		WithTotalAmount(r.TotalAmount).
+		WithAmountDue(r.AmountDueAtBooking()).
+		WithChannel(r.Channel))
+}
+
 func (r *Reservation) validate() error {
 	if err := r.validateDateRange(); err != nil {
 		return err
@@ -183,6 +434,10 @@ func (r *Reservation) validate() error {
 		return ErrNoGuests
 	}
 
+	if err := r.TotalAmount.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package reservation_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -21,7 +22,7 @@ func validDateRange() reservation.DateRange {
 
 func validGuests() []reservation.GuestInfo {
 	return []reservation.GuestInfo{
-		reservation.NewGuestInfo("John Doe", "john@example.com", "+1234567890"),
+		{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+1234567890"},
 	}
 }
 
@@ -92,6 +93,22 @@ func Test_NewReservation_With_No_Guests_Should_Return_Error(t *testing.T) {
 	assert.That(t, "reservation must be nil", res == nil, true)
 }
 
+func Test_NewReservation_With_Unknown_Currency_Should_Return_ErrUnknownCurrency(t *testing.T) {
+	// Act
+	res, err := reservation.NewReservation(
+		"res-001",
+		"guest-001",
+		"room-101",
+		validDateRange(),
+		shared.NewMoney(10000, "XYZ"),
+		validGuests(),
+	)
+
+	// Assert
+	assert.That(t, "error must be ErrUnknownCurrency", errors.Is(err, shared.ErrUnknownCurrency), true)
+	assert.That(t, "reservation must be nil", res == nil, true)
+}
+
 func Test_NewReservation_With_CheckOut_Before_CheckIn_Should_Return_Error(t *testing.T) {
 	// Arrange
 	checkIn := time.Now().Add(48 * time.Hour)
@@ -197,6 +214,88 @@ func Test_Reservation_Confirm_From_Cancelled_Should_Return_Error(t *testing.T) {
 	assert.That(t, "status must remain cancelled", res.Status, reservation.StatusCancelled)
 }
 
+// ============================================================================
+// Domain Event Recording Tests
+// ============================================================================
+
+func Test_Reservation_Transitions_Should_Record_Domain_Events(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	_ = res.Confirm()
+	_ = res.Activate()
+	_ = res.Complete()
+	events := res.PullEvents()
+
+	// Assert
+	topics := make([]string, 0, len(events))
+	for _, evt := range events {
+		topics = append(topics, evt.Topic())
+	}
+	assert.That(t, "events must be recorded in order", topics, []string{
+		reservation.EventTopicCreated, reservation.EventTopicConfirmed, reservation.EventTopicActivated, reservation.EventTopicCompleted,
+	})
+	assert.That(t, "events must be pulled once", len(res.PullEvents()), 0)
+}
+
+func Test_Reservation_Refused_Transition_Should_Not_Record_Event(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.PullEvents()
+
+	// Act
+	_ = res.Complete()
+
+	// Assert
+	assert.That(t, "no event must be recorded", len(res.PullEvents()), 0)
+}
+
+func Test_NewChannelReservation_Should_Record_Created_Event_With_Channel_And_Confirm(t *testing.T) {
+	// Arrange
+	dateRange := validDateRange()
+
+	// Act
+	res, err := reservation.NewChannelReservation("booking.com-4711", "guest-001", "room-101", dateRange, validMoney(), validGuests(), "booking.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be confirmed", res.Status, reservation.StatusConfirmed)
+	events := res.PullEvents()
+	assert.That(t, "two events must be recorded", len(events), 2)
+	created, _ := events[0].(*reservation.EventCreated)
+	assert.That(t, "created event must carry the channel", created.Channel, "booking.com")
+	assert.That(t, "second event must be confirmed", events[1].Topic(), reservation.EventTopicConfirmed)
+}
+
+// ============================================================================
+// Payment Verification Tests
+// ============================================================================
+
+func Test_Reservation_VerifyPayment_With_Total_Should_Succeed(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	err := res.VerifyPayment(validMoney())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+}
+
+func Test_Reservation_VerifyPayment_With_Different_Amount_Or_Currency_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	amountErr := res.VerifyPayment(shared.NewMoney(9999, "USD"))
+	currencyErr := res.VerifyPayment(shared.NewMoney(10000, "EUR"))
+
+	// Assert
+	assert.That(t, "amount error must be ErrPaymentMismatch", errors.Is(amountErr, reservation.ErrPaymentMismatch), true)
+	assert.That(t, "currency error must be ErrPaymentMismatch", errors.Is(currencyErr, reservation.ErrPaymentMismatch), true)
+}
+
 // ============================================================================
 // State Transition Tests - Activate
 // ============================================================================
@@ -285,6 +384,66 @@ func Test_Reservation_Complete_From_Confirmed_Should_Return_Error(t *testing.T)
 	assert.That(t, "status must remain confirmed", res.Status, reservation.StatusConfirmed)
 }
 
+// ============================================================================
+// State Transition Tests - NoShow
+// ============================================================================
+
+func Test_Reservation_MarkNoShow_From_Confirmed_Should_Record_Event(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Confirm()
+	_ = res.PullEvents()
+
+	// Act
+	err := res.MarkNoShow()
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be no-show", res.Status, reservation.StatusNoShow)
+	events := res.PullEvents()
+	assert.That(t, "one event must be recorded", len(events), 1)
+	assert.That(t, "event must be no-show", events[0].Topic(), reservation.EventTopicNoShow)
+}
+
+func Test_Reservation_MarkNoShow_From_Active_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Confirm()
+	_ = res.Activate()
+
+	// Act
+	err := res.MarkNoShow()
+
+	// Assert
+	assert.That(t, "error must be invalid transition", errors.Is(err, reservation.ErrInvalidStateTransition), true)
+	assert.That(t, "status must remain active", res.Status, reservation.StatusActive)
+}
+
+func Test_Reservation_MarkPreArrivalSent_From_Pending_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	err := res.MarkPreArrivalSent(time.Now())
+
+	// Assert
+	assert.That(t, "error must be invalid transition", errors.Is(err, reservation.ErrInvalidStateTransition), true)
+	assert.That(t, "reminder must not be recorded", res.PreArrivalSentAt.IsZero(), true)
+}
+
+func Test_Reservation_Cancel_From_NoShow_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Confirm()
+	_ = res.MarkNoShow()
+
+	// Act
+	err := res.Displace("room blocked")
+
+	// Assert
+	assert.That(t, "error must be ErrCannotCancelNoShow", errors.Is(err, reservation.ErrCannotCancelNoShow), true)
+}
+
 // ============================================================================
 // State Transition Tests - Cancel
 // ============================================================================
@@ -317,6 +476,90 @@ func Test_Reservation_Cancel_From_Confirmed_Should_Succeed(t *testing.T) {
 	assert.That(t, "status must be cancelled", res.Status, reservation.StatusCancelled)
 }
 
+func Test_Reservation_Displace_Within_Cancellation_Deadline_Should_Succeed(t *testing.T) {
+	// Arrange
+	checkIn := time.Now().Add(2 * time.Hour)
+	res, _ := reservation.NewReservation("res-001", "guest-001", "room-101",
+		reservation.NewDateRange(checkIn, checkIn.Add(48*time.Hour)), validMoney(), validGuests())
+	_ = res.Confirm()
+
+	// Act
+	err := res.Displace("room_blocked: maintenance")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be cancelled", res.Status, reservation.StatusCancelled)
+	assert.That(t, "cancellation reason must match", res.CancellationReason, "room_blocked: maintenance")
+}
+
+func Test_Reservation_Displace_From_Active_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Confirm()
+	_ = res.Activate()
+
+	// Act
+	err := res.Displace("room_blocked: maintenance")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "status must remain active", res.Status, reservation.StatusActive)
+}
+
+func Test_Reservation_MarkRefundRequired_When_Cancelled_Should_Set_Flag(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Cancel("Guest requested cancellation")
+
+	// Act
+	err := res.MarkRefundRequired()
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "refund must be required", res.RefundRequired, true)
+}
+
+func Test_Reservation_MarkRefundRequired_When_Pending_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	err := res.MarkRefundRequired()
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidStateTransition", errors.Is(err, reservation.ErrInvalidStateTransition), true)
+	assert.That(t, "refund must not be required", res.RefundRequired, false)
+}
+
+func Test_Reservation_Anonymize_When_Cancelled_Should_Remove_Guest_Data(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Cancel("Guest requested cancellation")
+
+	// Act
+	err := res.Anonymize()
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "guest ID must be anonymized", res.GuestID, reservation.AnonymizedGuestID)
+	assert.That(t, "guest count must be kept", len(res.Guests), 1)
+	assert.That(t, "guest email must be removed", res.Guests[0].Email, reservation.Email(""))
+	assert.That(t, "guest phone must be removed", res.Guests[0].PhoneNumber, reservation.PhoneNumber(""))
+	assert.That(t, "amount must be kept", res.TotalAmount, validMoney())
+}
+
+func Test_Reservation_Anonymize_When_Pending_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	err := res.Anonymize()
+
+	// Assert
+	assert.That(t, "error must be ErrReservationOpen", errors.Is(err, reservation.ErrReservationOpen), true)
+	assert.That(t, "guest ID must be kept", res.GuestID, reservation.GuestID("guest-001"))
+}
+
 func Test_Reservation_Cancel_From_Active_Should_Return_Error(t *testing.T) {
 	// Arrange
 	res := createValidReservation(t)
@@ -465,6 +708,20 @@ func Test_Reservation_IsOverlapping_One_Cancelled_Should_Return_False(t *testing
 	assert.That(t, "should not be overlapping", overlapping, false)
 }
 
+func Test_Reservation_IsOverlapping_One_NoShow_Should_Return_False(t *testing.T) {
+	// Arrange
+	res1, _ := reservation.NewReservation("res-001", "guest-001", "room-101", validDateRange(), validMoney(), validGuests())
+	res2, _ := reservation.NewReservation("res-002", "guest-002", "room-101", validDateRange(), validMoney(), validGuests())
+	_ = res2.Confirm()
+	_ = res2.MarkNoShow()
+
+	// Act
+	overlapping := res1.IsOverlapping(res2)
+
+	// Assert
+	assert.That(t, "should not be overlapping", overlapping, false)
+}
+
 // ============================================================================
 // Value Object Tests - DateRange
 // ============================================================================
@@ -493,12 +750,120 @@ func Test_NewGuestInfo_Should_Create_Valid_GuestInfo(t *testing.T) {
 	phone := "+1234567890"
 
 	// Act
-	guest := reservation.NewGuestInfo(name, email, phone)
+	guest, err := reservation.NewGuestInfo(name, email, phone)
 
 	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
 	assert.That(t, "Name must match", guest.Name, name)
-	assert.That(t, "Email must match", guest.Email, email)
-	assert.That(t, "PhoneNumber must match", guest.PhoneNumber, phone)
+	assert.That(t, "Email must match", guest.Email, reservation.Email(email))
+	assert.That(t, "PhoneNumber must match", guest.PhoneNumber, reservation.PhoneNumber(phone))
+}
+
+func Test_NewGuestInfo_Without_PhoneNumber_Should_Succeed(t *testing.T) {
+	// Act
+	guest, err := reservation.NewGuestInfo("John Doe", "john@example.com", "")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "PhoneNumber must be empty", guest.PhoneNumber, reservation.PhoneNumber(""))
+}
+
+func Test_NewGuestInfo_With_Invalid_Fields_Should_Return_Field_Errors(t *testing.T) {
+	// Act
+	_, err := reservation.NewGuestInfo("John Doe", "not-an-email", "555-1234")
+
+	// Assert
+	var verrs reservation.ValidationErrors
+	assert.That(t, "error must be ValidationErrors", errors.As(err, &verrs), true)
+	assert.That(t, "both fields must be reported", len(verrs), 2)
+	assert.That(t, "first field must be email", verrs[0].Field, "email")
+	assert.That(t, "second field must be phone_number", verrs[1].Field, "phone_number")
+	assert.That(t, "error must match ErrInvalidEmail", errors.Is(err, reservation.ErrInvalidEmail), true)
+	assert.That(t, "error must match ErrInvalidPhoneNumber", errors.Is(err, reservation.ErrInvalidPhoneNumber), true)
+}
+
+// ============================================================================
+// Entity Tests - GuestProfile
+// ============================================================================
+
+func Test_NewGuestProfile_Should_Trim_Name_And_Normalize_Phone(t *testing.T) {
+	// Act
+	profile, err := reservation.NewGuestProfile("john@example.com", "  John Doe ", "+49 151 12345678", " de-DE ")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "GuestID must match", profile.GuestID, reservation.GuestID("john@example.com"))
+	assert.That(t, "Name must be trimmed", profile.Name, "John Doe")
+	assert.That(t, "PhoneNumber must be normalized", profile.PhoneNumber, reservation.PhoneNumber("+4915112345678"))
+	assert.That(t, "Locale must be trimmed", profile.Locale, "de-DE")
+}
+
+func Test_NewGuestProfile_With_Invalid_Fields_Should_Return_Field_Errors(t *testing.T) {
+	// Act
+	_, err := reservation.NewGuestProfile("john@example.com", " ", "555-1234", "en_US")
+
+	// Assert
+	var verrs reservation.ValidationErrors
+	assert.That(t, "error must be ValidationErrors", errors.As(err, &verrs), true)
+	assert.That(t, "all fields must be reported", len(verrs), 3)
+	assert.That(t, "first field must be name", verrs[0].Field, "name")
+	assert.That(t, "error must match ErrNameRequired", errors.Is(err, reservation.ErrNameRequired), true)
+	assert.That(t, "second field must be phone_number", verrs[1].Field, "phone_number")
+	assert.That(t, "third field must be locale", verrs[2].Field, "locale")
+	assert.That(t, "error must match ErrInvalidLocale", errors.Is(err, reservation.ErrInvalidLocale), true)
+}
+
+// ============================================================================
+// Value Object Tests - Email
+// ============================================================================
+
+func Test_ParseEmail_Should_Normalize_Domain(t *testing.T) {
+	// Act
+	email, err := reservation.ParseEmail("  John.Doe@Example.COM ")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "domain must be lower case", email, reservation.Email("John.Doe@example.com"))
+}
+
+func Test_ParseEmail_With_Invalid_Address_Should_Return_Error(t *testing.T) {
+	for _, input := range []string{"", "john", "john@", "John Doe <john@example.com>"} {
+		// Act
+		_, err := reservation.ParseEmail(input)
+
+		// Assert
+		assert.That(t, "error must be ErrInvalidEmail for "+input, errors.Is(err, reservation.ErrInvalidEmail), true)
+	}
+}
+
+// ============================================================================
+// Value Object Tests - PhoneNumber
+// ============================================================================
+
+func Test_ParsePhoneNumber_Should_Normalize_To_E164(t *testing.T) {
+	cases := map[string]reservation.PhoneNumber{
+		"+1 (555) 123-4567":  "+15551234567",
+		"0049 151 1234 5678": "+4915112345678",
+		"+44.20.7946.0958":   "+442079460958",
+	}
+	for input, expected := range cases {
+		// Act
+		phone, err := reservation.ParsePhoneNumber(input)
+
+		// Assert
+		assert.That(t, "error must be nil for "+input, err == nil, true)
+		assert.That(t, "phone must be E.164 for "+input, phone, expected)
+	}
+}
+
+func Test_ParsePhoneNumber_With_Invalid_Number_Should_Return_Error(t *testing.T) {
+	for _, input := range []string{"555-1234", "+0123456789", "+1 555 CALL NOW", "+1234567890123456", "+12345"} {
+		// Act
+		_, err := reservation.ParsePhoneNumber(input)
+
+		// Assert
+		assert.That(t, "error must be ErrInvalidPhoneNumber for "+input, errors.Is(err, reservation.ErrInvalidPhoneNumber), true)
+	}
 }
 
 // ============================================================================
@@ -529,6 +894,18 @@ func Test_Money_FormatAmount_Should_Return_Formatted_String(t *testing.T) {
 	assert.That(t, "formatted must be correct", formatted, "100.50 USD")
 }
 
+func Test_Money_FormatAmount_Should_Use_Currency_Minor_Units(t *testing.T) {
+	// Act
+	jpy := shared.NewMoney(1500, "JPY").FormatAmount()
+	kwd := shared.NewMoney(1250, "KWD").FormatAmount()
+	small := shared.NewMoney(-5, "USD").FormatAmount()
+
+	// Assert
+	assert.That(t, "yen must have no decimals", jpy, "1500 JPY")
+	assert.That(t, "dinar must have three decimals", kwd, "1.250 KWD")
+	assert.That(t, "small negative amount must be padded", small, "-0.05 USD")
+}
+
 // ============================================================================
 // Additional Coverage Tests
 // ============================================================================
@@ -628,3 +1005,57 @@ func Test_EventCancelled_Topic_Should_Return_Correct_Value(t *testing.T) {
 	// Assert
 	assert.That(t, "topic must be reservation.cancelled", topic, "reservation.cancelled")
 }
+
+// ============================================================================
+// External Hold Tests
+// ============================================================================
+
+func Test_NewExternalHold_Should_Create_Confirmed_Hold(t *testing.T) {
+	// Arrange
+	dateRange := validDateRange()
+
+	// Act
+	hold, err := reservation.NewExternalHold("hold-001", "room-101", dateRange, "airbnb")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "hold must be confirmed", hold.Status, reservation.StatusConfirmed)
+	assert.That(t, "hold must be an external hold", hold.IsExternalHold(), true)
+	assert.That(t, "channel must be the source", hold.Channel, "airbnb")
+}
+
+func Test_NewExternalHold_With_Invalid_Date_Range_Should_Return_Error(t *testing.T) {
+	// Arrange
+	checkIn := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
+	dateRange := reservation.NewDateRange(checkIn, checkIn)
+
+	// Act
+	_, err := reservation.NewExternalHold("hold-001", "room-101", dateRange, "airbnb")
+
+	// Assert
+	assert.That(t, "error must be ErrMinimumStay", errors.Is(err, reservation.ErrMinimumStay), true)
+}
+
+func Test_Reservation_Release_Should_Cancel_Hold_Near_Check_In(t *testing.T) {
+	// Arrange
+	checkIn := time.Now().Truncate(24 * time.Hour)
+	hold, _ := reservation.NewExternalHold("hold-001", "room-101", reservation.NewDateRange(checkIn, checkIn.Add(48*time.Hour)), "airbnb")
+
+	// Act
+	err := hold.Release()
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "hold must be cancelled", hold.Status, reservation.StatusCancelled)
+}
+
+func Test_Reservation_Release_Of_Guest_Reservation_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	err := res.Release()
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidStateTransition", errors.Is(err, reservation.ErrInvalidStateTransition), true)
+}
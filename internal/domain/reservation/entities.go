@@ -1,6 +1,10 @@
 package reservation
 
-import "time"
+import (
+	"net/mail"
+	"strings"
+	"time"
+)
 
 // DateRange represents a time period for a reservation.
 type DateRange struct {
@@ -16,18 +20,190 @@ func NewDateRange(checkIn, checkOut time.Time) DateRange {
 	}
 }
 
+// Email is a validated email address (value object).
+// The domain part is normalized to lower case.
+type Email string
+
+// ParseEmail parses a plain email address without display name.
+func ParseEmail(s string) (Email, error) {
+	s = strings.TrimSpace(s)
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s {
+		return "", ErrInvalidEmail
+	}
+	at := strings.LastIndex(s, "@")
+	return Email(s[:at] + strings.ToLower(s[at:])), nil
+}
+
+// PhoneNumber is a validated phone number in E.164 format, e.g. "+15551234567" (value object).
+type PhoneNumber string
+
+// ParsePhoneNumber parses an international phone number and normalizes it to E.164.
+// Spaces, dashes, dots, slashes and parentheses are removed and a leading "00" is
+// replaced by "+". Numbers without country code are rejected.
+func ParsePhoneNumber(s string) (PhoneNumber, error) {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(s) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case strings.ContainsRune(" -./()", r):
+		default:
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+
+	number := b.String()
+	if strings.HasPrefix(number, "00") {
+		number = "+" + number[2:]
+	}
+
+	// E.164 allows at most 15 digits and country codes never start with 0.
+	digits, ok := strings.CutPrefix(number, "+")
+	if !ok || len(digits) < 7 || len(digits) > 15 || digits[0] == '0' {
+		return "", ErrInvalidPhoneNumber
+	}
+	return PhoneNumber(number), nil
+}
+
+// FieldError describes why a single input field is invalid.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// Error returns the field name and the reason.
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying sentinel error.
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors collects all invalid fields of an input.
+type ValidationErrors []FieldError
+
+// Error joins the field errors.
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(v))
+	for _, e := range v {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the field errors, so errors.Is matches their sentinels.
+func (v ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(v))
+	for _, e := range v {
+		errs = append(errs, e)
+	}
+	return errs
+}
+
 // GuestInfo represents information about a guest (entity within Reservation aggregate).
 type GuestInfo struct {
 	Name        string
-	Email       string
-	PhoneNumber string
+	Email       Email
+	PhoneNumber PhoneNumber
 }
 
 // NewGuestInfo creates a GuestInfo entity.
-func NewGuestInfo(name, email, phoneNumber string) GuestInfo {
+// The email is required; the phone number is optional.
+// Invalid fields are reported together as ValidationErrors.
+func NewGuestInfo(name, email, phoneNumber string) (GuestInfo, error) {
+	var errs ValidationErrors
+
+	parsedEmail, err := ParseEmail(email)
+	if err != nil {
+		errs = append(errs, FieldError{Field: "email", Err: err})
+	}
+
+	var parsedPhone PhoneNumber
+	if strings.TrimSpace(phoneNumber) != "" {
+		parsedPhone, err = ParsePhoneNumber(phoneNumber)
+		if err != nil {
+			errs = append(errs, FieldError{Field: "phone_number", Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return GuestInfo{}, errs
+	}
+
 	return GuestInfo{
 		Name:        name,
-		Email:       email,
-		PhoneNumber: phoneNumber,
+		Email:       parsedEmail,
+		PhoneNumber: parsedPhone,
+	}, nil
+}
+
+// GuestProfile holds the contact details a guest maintains for future bookings.
+// It is keyed by the guest ID and lives outside the Reservation aggregate.
+type GuestProfile struct {
+	GuestID       GuestID
+	Name          string
+	PhoneNumber   PhoneNumber
+	Locale        string                  // Preferred language tag; empty uses the browser's languages
+	Notifications NotificationPreferences // Channels for notifications; empty uses email only
+	Theme         Theme                   // Color theme of the UI; empty follows the device
+}
+
+// NewGuestProfile creates a GuestProfile for the given guest.
+// The name is required; the phone number and locale are optional.
+// Invalid fields are reported together as ValidationErrors.
+func NewGuestProfile(guestID GuestID, name, phoneNumber, locale string) (GuestProfile, error) {
+	var errs ValidationErrors
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		errs = append(errs, FieldError{Field: "name", Err: ErrNameRequired})
+	}
+
+	var parsedPhone PhoneNumber
+	if strings.TrimSpace(phoneNumber) != "" {
+		var err error
+		parsedPhone, err = ParsePhoneNumber(phoneNumber)
+		if err != nil {
+			errs = append(errs, FieldError{Field: "phone_number", Err: err})
+		}
+	}
+
+	locale = strings.TrimSpace(locale)
+	if locale != "" && !isLanguageTag(locale) {
+		errs = append(errs, FieldError{Field: "locale", Err: ErrInvalidLocale})
+	}
+
+	if len(errs) > 0 {
+		return GuestProfile{}, errs
+	}
+
+	return GuestProfile{
+		GuestID:     guestID,
+		Name:        name,
+		PhoneNumber: parsedPhone,
+		Locale:      locale,
+	}, nil
+}
+
+// isLanguageTag reports whether s has the shape of a BCP 47 language tag:
+// a 2-3 letter language followed by optional 1-8 character alphanumeric subtags.
+// Whether the locale is supported is decided by the adapters that translate.
+func isLanguageTag(s string) bool {
+	for i, part := range strings.Split(s, "-") {
+		if i == 0 && (len(part) < 2 || len(part) > 3) || len(part) < 1 || len(part) > 8 {
+			return false
+		}
+		for _, r := range part {
+			isLetter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+			if !isLetter && (i == 0 || r < '0' || r > '9') {
+				return false
+			}
+		}
 	}
+	return true
 }
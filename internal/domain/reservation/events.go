@@ -4,11 +4,18 @@ import "time"
 
 // Event topics for Kafka.
 const (
-	EventTopicCreated   = "reservation.created"
-	EventTopicConfirmed = "reservation.confirmed"
-	EventTopicActivated = "reservation.activated"
-	EventTopicCompleted = "reservation.completed"
-	EventTopicCancelled = "reservation.cancelled"
+	EventTopicCreated       = "reservation.created"
+	EventTopicConfirmed     = "reservation.confirmed"
+	EventTopicActivated     = "reservation.activated"
+	EventTopicCompleted     = "reservation.completed"
+	EventTopicCheckedIn     = "reservation.checked_in"
+	EventTopicCancelled     = "reservation.cancelled"
+	EventTopicNoShow        = "reservation.no_show"
+	EventTopicRoomBlocked   = "reservation.room_blocked"
+	EventTopicRoomUnblocked = "reservation.room_unblocked"
+	EventTopicAddOnAdded    = "reservation.add_on_added"
+	EventTopicAddOnRemoved  = "reservation.add_on_removed"
+	EventTopicAnonymized    = "reservation.anonymized"
 )
 
 // EventCreated is published when a new reservation is created.
@@ -19,6 +26,8 @@ type EventCreated struct {
 	CheckIn       time.Time     `json:"check_in"`
 	CheckOut      time.Time     `json:"check_out"`
 	TotalAmount   Money         `json:"total_amount"`
+	AmountDue     Money         `json:"amount_due"` // Charged at booking: the deposit of a payment schedule, otherwise the total
+	Channel       string        `json:"channel,omitempty"`
 }
 
 func NewEventCreated() *EventCreated {
@@ -57,6 +66,25 @@ func (e *EventCreated) WithTotalAmount(m Money) *EventCreated {
 	return e
 }
 
+func (e *EventCreated) WithAmountDue(m Money) *EventCreated {
+	e.AmountDue = m
+	return e
+}
+
+// AmountDueAtBooking returns the amount to charge for the reservation.
+// Events published before payment schedules existed carry only the total.
+func (e *EventCreated) AmountDueAtBooking() Money {
+	if e.AmountDue == (Money{}) {
+		return e.TotalAmount
+	}
+	return e.AmountDue
+}
+
+func (e *EventCreated) WithChannel(channel string) *EventCreated {
+	e.Channel = channel
+	return e
+}
+
 // EventConfirmed is published when a reservation is confirmed.
 type EventConfirmed struct {
 	ReservationID ReservationID `json:"reservation_id"`
@@ -111,6 +139,34 @@ func (e *EventCompleted) WithReservationID(id ReservationID) *EventCompleted {
 	return e
 }
 
+// EventNoShow is published when a guest did not arrive for a confirmed reservation.
+type EventNoShow struct {
+	ReservationID ReservationID `json:"reservation_id"`
+	GuestID       GuestID       `json:"guest_id"`
+	RoomID        RoomID        `json:"room_id"`
+}
+
+func NewEventNoShow() *EventNoShow {
+	return &EventNoShow{}
+}
+
+func (e *EventNoShow) Topic() string { return EventTopicNoShow }
+
+func (e *EventNoShow) WithReservationID(id ReservationID) *EventNoShow {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventNoShow) WithGuestID(id GuestID) *EventNoShow {
+	e.GuestID = id
+	return e
+}
+
+func (e *EventNoShow) WithRoomID(id RoomID) *EventNoShow {
+	e.RoomID = id
+	return e
+}
+
 // EventCancelled is published when a reservation is cancelled.
 type EventCancelled struct {
 	ReservationID ReservationID `json:"reservation_id"`
@@ -138,3 +194,209 @@ func (e *EventCancelled) WithReason(reason string) *EventCancelled {
 	e.Reason = reason
 	return e
 }
+
+// EventRoomBlocked is published when a room is blocked for maintenance or renovation.
+type EventRoomBlocked struct {
+	BlockID  RoomBlockID `json:"block_id"`
+	RoomID   RoomID      `json:"room_id"`
+	CheckIn  time.Time   `json:"check_in"`
+	CheckOut time.Time   `json:"check_out"`
+	Reason   BlockReason `json:"reason"`
+}
+
+func NewEventRoomBlocked() *EventRoomBlocked {
+	return &EventRoomBlocked{}
+}
+
+func (e *EventRoomBlocked) Topic() string { return EventTopicRoomBlocked }
+
+func (e *EventRoomBlocked) WithBlockID(id RoomBlockID) *EventRoomBlocked {
+	e.BlockID = id
+	return e
+}
+
+func (e *EventRoomBlocked) WithRoomID(id RoomID) *EventRoomBlocked {
+	e.RoomID = id
+	return e
+}
+
+func (e *EventRoomBlocked) WithCheckIn(t time.Time) *EventRoomBlocked {
+	e.CheckIn = t
+	return e
+}
+
+func (e *EventRoomBlocked) WithCheckOut(t time.Time) *EventRoomBlocked {
+	e.CheckOut = t
+	return e
+}
+
+func (e *EventRoomBlocked) WithReason(reason BlockReason) *EventRoomBlocked {
+	e.Reason = reason
+	return e
+}
+
+// EventRoomUnblocked is published when a room block is removed.
+type EventRoomUnblocked struct {
+	BlockID RoomBlockID `json:"block_id"`
+	RoomID  RoomID      `json:"room_id"`
+}
+
+func NewEventRoomUnblocked() *EventRoomUnblocked {
+	return &EventRoomUnblocked{}
+}
+
+func (e *EventRoomUnblocked) Topic() string { return EventTopicRoomUnblocked }
+
+func (e *EventRoomUnblocked) WithBlockID(id RoomBlockID) *EventRoomUnblocked {
+	e.BlockID = id
+	return e
+}
+
+func (e *EventRoomUnblocked) WithRoomID(id RoomID) *EventRoomUnblocked {
+	e.RoomID = id
+	return e
+}
+
+// EventAddOnAdded is published when an add-on is booked for a reservation.
+type EventAddOnAdded struct {
+	ReservationID ReservationID `json:"reservation_id"`
+	AddOnID       AddOnID       `json:"add_on_id"`
+	Kind          AddOnKind     `json:"kind"`
+	Amount        Money         `json:"amount"`
+	TotalAmount   Money         `json:"total_amount"` // Reservation total including the add-on
+	InBalance     bool          `json:"in_balance"`
+}
+
+func NewEventAddOnAdded() *EventAddOnAdded {
+	return &EventAddOnAdded{}
+}
+
+func (e *EventAddOnAdded) Topic() string { return EventTopicAddOnAdded }
+
+func (e *EventAddOnAdded) WithReservationID(id ReservationID) *EventAddOnAdded {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventAddOnAdded) WithAddOnID(id AddOnID) *EventAddOnAdded {
+	e.AddOnID = id
+	return e
+}
+
+func (e *EventAddOnAdded) WithKind(kind AddOnKind) *EventAddOnAdded {
+	e.Kind = kind
+	return e
+}
+
+func (e *EventAddOnAdded) WithAmount(amount Money) *EventAddOnAdded {
+	e.Amount = amount
+	return e
+}
+
+func (e *EventAddOnAdded) WithTotalAmount(amount Money) *EventAddOnAdded {
+	e.TotalAmount = amount
+	return e
+}
+
+func (e *EventAddOnAdded) WithInBalance(inBalance bool) *EventAddOnAdded {
+	e.InBalance = inBalance
+	return e
+}
+
+// EventAddOnRemoved is published when an add-on of a reservation is cancelled.
+type EventAddOnRemoved struct {
+	ReservationID ReservationID `json:"reservation_id"`
+	AddOnID       AddOnID       `json:"add_on_id"`
+	Kind          AddOnKind     `json:"kind"`
+	Amount        Money         `json:"amount"`
+	TotalAmount   Money         `json:"total_amount"` // Reservation total without the add-on
+}
+
+func NewEventAddOnRemoved() *EventAddOnRemoved {
+	return &EventAddOnRemoved{}
+}
+
+func (e *EventAddOnRemoved) Topic() string { return EventTopicAddOnRemoved }
+
+func (e *EventAddOnRemoved) WithReservationID(id ReservationID) *EventAddOnRemoved {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventAddOnRemoved) WithAddOnID(id AddOnID) *EventAddOnRemoved {
+	e.AddOnID = id
+	return e
+}
+
+func (e *EventAddOnRemoved) WithKind(kind AddOnKind) *EventAddOnRemoved {
+	e.Kind = kind
+	return e
+}
+
+func (e *EventAddOnRemoved) WithAmount(amount Money) *EventAddOnRemoved {
+	e.Amount = amount
+	return e
+}
+
+func (e *EventAddOnRemoved) WithTotalAmount(amount Money) *EventAddOnRemoved {
+	e.TotalAmount = amount
+	return e
+}
+
+// EventCheckedIn is published when the staff checks a guest in with a registration card.
+// The document reference stays on the card and is not published.
+type EventCheckedIn struct {
+	ReservationID ReservationID `json:"reservation_id"`
+	RoomID        RoomID        `json:"room_id"`
+	DocumentType  DocumentType  `json:"document_type"`
+	CheckedInBy   string        `json:"checked_in_by"`
+	CheckedInAt   time.Time     `json:"checked_in_at"`
+}
+
+func NewEventCheckedIn() *EventCheckedIn {
+	return &EventCheckedIn{}
+}
+
+func (e *EventCheckedIn) Topic() string { return EventTopicCheckedIn }
+
+func (e *EventCheckedIn) WithReservationID(id ReservationID) *EventCheckedIn {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventCheckedIn) WithRoomID(id RoomID) *EventCheckedIn {
+	e.RoomID = id
+	return e
+}
+
+func (e *EventCheckedIn) WithDocumentType(documentType DocumentType) *EventCheckedIn {
+	e.DocumentType = documentType
+	return e
+}
+
+func (e *EventCheckedIn) WithCheckedInBy(staff string) *EventCheckedIn {
+	e.CheckedInBy = staff
+	return e
+}
+
+func (e *EventCheckedIn) WithCheckedInAt(t time.Time) *EventCheckedIn {
+	e.CheckedInAt = t
+	return e
+}
+
+// EventAnonymized is published when the guest data of a reservation was erased,
+// so read models holding guest data can remove it as well.
+type EventAnonymized struct {
+	ReservationID ReservationID `json:"reservation_id"`
+}
+
+func NewEventAnonymized() *EventAnonymized {
+	return &EventAnonymized{}
+}
+
+func (e *EventAnonymized) Topic() string { return EventTopicAnonymized }
+
+func (e *EventAnonymized) WithReservationID(id ReservationID) *EventAnonymized {
+	e.ReservationID = id
+	return e
+}
@@ -0,0 +1,114 @@
+package reservation
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// NotificationChannel is a way of delivering notifications to a guest.
+type NotificationChannel string
+
+const (
+	ChannelEmail NotificationChannel = "email"
+	ChannelSMS   NotificationChannel = "sms"
+	ChannelPush  NotificationChannel = "push"
+)
+
+// Notification preference errors.
+var (
+	ErrUnknownNotificationChannel   = errors.New("unknown notification channel, expected email, sms or push")
+	ErrDuplicateNotificationChannel = errors.New("notification channel listed twice")
+	ErrMessageTypeRequired          = errors.New("message type is required")
+	ErrDuplicateMessageType         = errors.New("message type listed twice")
+)
+
+// NotificationPreferences holds the channels a guest wants to be notified on,
+// in order of preference. Delivery falls back to the next channel if one fails.
+// ByType overrides the order for single message types (e.g. "balance_reminder").
+// OptOut lists the optional message types the guest does not want (e.g. "pre_arrival").
+type NotificationPreferences struct {
+	Channels []NotificationChannel
+	ByType   map[string][]NotificationChannel
+	OptOut   []string
+}
+
+// NewNotificationPreferences creates NotificationPreferences with validation.
+// Each list must hold known channels only, each at most once.
+// Invalid lists are reported together as ValidationErrors.
+func NewNotificationPreferences(channels []NotificationChannel, byType map[string][]NotificationChannel) (NotificationPreferences, error) {
+	var errs ValidationErrors
+
+	if err := validateChannels(channels); err != nil {
+		errs = append(errs, FieldError{Field: "channels", Err: err})
+	}
+
+	types := make([]string, 0, len(byType))
+	for messageType := range byType {
+		types = append(types, messageType)
+	}
+	slices.Sort(types)
+	for _, messageType := range types {
+		if strings.TrimSpace(messageType) == "" {
+			errs = append(errs, FieldError{Field: "by_type", Err: ErrMessageTypeRequired})
+			continue
+		}
+		if err := validateChannels(byType[messageType]); err != nil {
+			errs = append(errs, FieldError{Field: "by_type." + messageType, Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return NotificationPreferences{}, errs
+	}
+
+	return NotificationPreferences{
+		Channels: channels,
+		ByType:   byType,
+	}, nil
+}
+
+// WithOptOut returns the preferences with the message types the guest does not want.
+// Each type must be given at most once.
+func (p NotificationPreferences) WithOptOut(messageTypes []string) (NotificationPreferences, error) {
+	for i, messageType := range messageTypes {
+		if strings.TrimSpace(messageType) == "" {
+			return NotificationPreferences{}, ValidationErrors{{Field: "opt_out", Err: ErrMessageTypeRequired}}
+		}
+		if slices.Contains(messageTypes[:i], messageType) {
+			return NotificationPreferences{}, ValidationErrors{{Field: "opt_out", Err: ErrDuplicateMessageType}}
+		}
+	}
+	p.OptOut = messageTypes
+	return p, nil
+}
+
+// Allows reports whether the guest wants messages of the type, i.e. did not opt out of it.
+func (p NotificationPreferences) Allows(messageType string) bool {
+	return !slices.Contains(p.OptOut, messageType)
+}
+
+// validateChannels rejects unknown and repeated channels.
+func validateChannels(channels []NotificationChannel) error {
+	for i, channel := range channels {
+		if channel != ChannelEmail && channel != ChannelSMS && channel != ChannelPush {
+			return ErrUnknownNotificationChannel
+		}
+		if slices.Contains(channels[:i], channel) {
+			return ErrDuplicateNotificationChannel
+		}
+	}
+	return nil
+}
+
+// ChannelsFor returns the channels for the message type in order of preference:
+// the override of the type, else the general order, else email only.
+func (p NotificationPreferences) ChannelsFor(messageType string) []NotificationChannel {
+	if channels := p.ByType[messageType]; len(channels) > 0 {
+		return channels
+	}
+	if len(p.Channels) > 0 {
+		return p.Channels
+	}
+	return []NotificationChannel{ChannelEmail}
+}
@@ -0,0 +1,91 @@
+package reservation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// NotificationPreferences Tests
+// ============================================================================
+
+func Test_NewNotificationPreferences_With_Unknown_Channel_Should_Return_Error(t *testing.T) {
+	// Arrange
+	channels := []reservation.NotificationChannel{reservation.ChannelSMS, "fax"}
+
+	// Act
+	_, err := reservation.NewNotificationPreferences(channels, nil)
+
+	// Assert
+	assert.That(t, "error must match ErrUnknownNotificationChannel", errors.Is(err, reservation.ErrUnknownNotificationChannel), true)
+}
+
+func Test_NewNotificationPreferences_With_Duplicate_Channel_Should_Return_Error(t *testing.T) {
+	// Arrange
+	byType := map[string][]reservation.NotificationChannel{
+		"balance_reminder": {reservation.ChannelPush, reservation.ChannelPush},
+	}
+
+	// Act
+	_, err := reservation.NewNotificationPreferences(nil, byType)
+
+	// Assert
+	var errs reservation.ValidationErrors
+	assert.That(t, "error must be ValidationErrors", errors.As(err, &errs), true)
+	assert.That(t, "field must name the message type", errs[0].Field, "by_type.balance_reminder")
+	assert.That(t, "error must match ErrDuplicateNotificationChannel", errors.Is(err, reservation.ErrDuplicateNotificationChannel), true)
+}
+
+func Test_NotificationPreferences_ChannelsFor_Should_Prefer_Type_Override(t *testing.T) {
+	// Arrange
+	prefs, _ := reservation.NewNotificationPreferences(
+		[]reservation.NotificationChannel{reservation.ChannelEmail},
+		map[string][]reservation.NotificationChannel{"balance_reminder": {reservation.ChannelSMS, reservation.ChannelEmail}},
+	)
+
+	// Act
+	reminder := prefs.ChannelsFor("balance_reminder")
+	confirmation := prefs.ChannelsFor("confirmation")
+
+	// Assert
+	assert.That(t, "reminder must use the override", reminder, []reservation.NotificationChannel{reservation.ChannelSMS, reservation.ChannelEmail})
+	assert.That(t, "confirmation must use the general order", confirmation, []reservation.NotificationChannel{reservation.ChannelEmail})
+}
+
+func Test_NotificationPreferences_ChannelsFor_Without_Preferences_Should_Use_Email(t *testing.T) {
+	// Arrange
+	var prefs reservation.NotificationPreferences
+
+	// Act
+	channels := prefs.ChannelsFor("confirmation")
+
+	// Assert
+	assert.That(t, "email must be the default", channels, []reservation.NotificationChannel{reservation.ChannelEmail})
+}
+
+func Test_NotificationPreferences_WithOptOut_Should_Not_Allow_Type(t *testing.T) {
+	// Arrange
+	var prefs reservation.NotificationPreferences
+
+	// Act
+	prefs, err := prefs.WithOptOut([]string{"pre_arrival"})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "opted out type must not be allowed", prefs.Allows("pre_arrival"), false)
+	assert.That(t, "other types must be allowed", prefs.Allows("confirmation"), true)
+}
+
+func Test_NotificationPreferences_WithOptOut_With_Duplicate_Type_Should_Return_Error(t *testing.T) {
+	// Arrange
+	var prefs reservation.NotificationPreferences
+
+	// Act
+	_, err := prefs.WithOptOut([]string{"pre_arrival", "pre_arrival"})
+
+	// Assert
+	assert.That(t, "error must match ErrDuplicateMessageType", errors.Is(err, reservation.ErrDuplicateMessageType), true)
+}
@@ -0,0 +1,83 @@
+package reservation
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoPaymentSchedule is returned for balance operations on a reservation that is paid in full at booking.
+var ErrNoPaymentSchedule = errors.New("reservation has no payment schedule")
+
+// PaymentPlan splits the payment of bookings made well ahead of check-in into
+// a deposit, charged at booking, and the balance, charged before check-in.
+type PaymentPlan struct {
+	DepositPercent   int           // Share of the total charged at booking, in percent
+	BalanceDueBefore time.Duration // How long before check-in the balance is charged
+}
+
+// NewSchedule returns the payment schedule of a booking under the plan, or nil
+// if the booking is paid in full at booking: the plan is disabled, or the balance
+// would already be due. The deposit is rounded to the nearest minor unit.
+func (p PaymentPlan) NewSchedule(total Money, checkIn, now time.Time) *PaymentSchedule {
+	if p.DepositPercent <= 0 || p.DepositPercent >= 100 {
+		return nil
+	}
+
+	dueAt := checkIn.Add(-p.BalanceDueBefore)
+	if !dueAt.After(now) {
+		return nil
+	}
+
+	// The percent is in range, so the allocation cannot fail.
+	parts, _ := total.Allocate(p.DepositPercent, 100-p.DepositPercent)
+	return &PaymentSchedule{
+		Deposit:      parts[0],
+		Balance:      parts[1],
+		BalanceDueAt: dueAt,
+	}
+}
+
+// PaymentSchedule is the installment plan of a reservation: the deposit is
+// charged by the booking saga, the balance by a scheduled job on its due date.
+type PaymentSchedule struct {
+	Deposit      Money
+	Balance      Money
+	BalanceDueAt time.Time
+	RemindedAt   time.Time // When the guest was reminded of the balance, zero if not yet
+	PaidAt       time.Time // When the balance was captured, zero if still open
+}
+
+// AmountDueAtBooking returns the amount the booking saga charges: the deposit
+// of a payment schedule, or the price of the room. Add-ons booked later are paid
+// on their own, so the amount stays the one authorized at booking.
+func (r *Reservation) AmountDueAtBooking() Money {
+	if r.Schedule == nil {
+		return r.RoomAmount()
+	}
+	return r.Schedule.Deposit
+}
+
+// BalanceOpen reports whether the balance of the payment schedule is still to be paid.
+func (r *Reservation) BalanceOpen() bool {
+	return r.Schedule != nil && r.Schedule.PaidAt.IsZero()
+}
+
+// MarkBalanceReminded records that the guest was reminded of the open balance.
+func (r *Reservation) MarkBalanceReminded(now time.Time) error {
+	if r.Schedule == nil {
+		return ErrNoPaymentSchedule
+	}
+	r.Schedule.RemindedAt = now
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// MarkBalancePaid records that the balance of the payment schedule was captured.
+func (r *Reservation) MarkBalancePaid(now time.Time) error {
+	if r.Schedule == nil {
+		return ErrNoPaymentSchedule
+	}
+	r.Schedule.PaidAt = now
+	r.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,91 @@
+package reservation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// PaymentPlan Tests
+// ============================================================================
+
+func Test_PaymentPlan_NewSchedule_Should_Split_Total_Into_Deposit_And_Balance(t *testing.T) {
+	// Arrange
+	plan := reservation.PaymentPlan{DepositPercent: 30, BalanceDueBefore: 30 * 24 * time.Hour}
+	now := time.Now()
+	checkIn := now.AddDate(0, 0, 60)
+
+	// Act
+	schedule := plan.NewSchedule(shared.NewMoney(10001, "USD"), checkIn, now)
+
+	// Assert
+	assert.That(t, "schedule must not be nil", schedule != nil, true)
+	assert.That(t, "deposit must be 30 percent", schedule.Deposit, shared.NewMoney(3000, "USD"))
+	assert.That(t, "balance must be the rest", schedule.Balance, shared.NewMoney(7001, "USD"))
+	assert.That(t, "balance must be due 30 days before check-in", schedule.BalanceDueAt, checkIn.Add(-30*24*time.Hour))
+}
+
+func Test_PaymentPlan_NewSchedule_With_Balance_Already_Due_Should_Return_Nil(t *testing.T) {
+	// Arrange
+	plan := reservation.PaymentPlan{DepositPercent: 30, BalanceDueBefore: 30 * 24 * time.Hour}
+	now := time.Now()
+
+	// Act
+	schedule := plan.NewSchedule(validMoney(), now.AddDate(0, 0, 10), now)
+
+	// Assert
+	assert.That(t, "schedule must be nil", schedule == nil, true)
+}
+
+func Test_PaymentPlan_NewSchedule_Without_Deposit_Percent_Should_Return_Nil(t *testing.T) {
+	// Arrange
+	plan := reservation.PaymentPlan{BalanceDueBefore: 30 * 24 * time.Hour}
+	now := time.Now()
+
+	// Act
+	schedule := plan.NewSchedule(validMoney(), now.AddDate(0, 0, 60), now)
+
+	// Assert
+	assert.That(t, "schedule must be nil", schedule == nil, true)
+}
+
+// ============================================================================
+// Scheduled Reservation Tests
+// ============================================================================
+
+func Test_NewScheduledReservation_Should_Record_Deposit_As_Amount_Due(t *testing.T) {
+	// Arrange
+	schedule := &reservation.PaymentSchedule{
+		Deposit:      shared.NewMoney(3000, "USD"),
+		Balance:      shared.NewMoney(7000, "USD"),
+		BalanceDueAt: time.Now().Add(24 * time.Hour),
+	}
+
+	// Act
+	res, err := reservation.NewScheduledReservation("res-001", "guest-001", "room-101", validDateRange(), validMoney(), validGuests(), schedule)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "balance must be open", res.BalanceOpen(), true)
+	evt := res.PullEvents()[0].(*reservation.EventCreated)
+	assert.That(t, "event must carry the total", evt.TotalAmount, validMoney())
+	assert.That(t, "event must carry the deposit as amount due", evt.AmountDueAtBooking(), shared.NewMoney(3000, "USD"))
+	assert.That(t, "payment of the deposit must be verified", res.VerifyPayment(shared.NewMoney(3000, "USD")) == nil, true)
+	assert.That(t, "payment of the total must be refused", errors.Is(res.VerifyPayment(validMoney()), reservation.ErrPaymentMismatch), true)
+}
+
+func Test_Reservation_MarkBalancePaid_Without_Schedule_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	err := res.MarkBalancePaid(time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrNoPaymentSchedule", errors.Is(err, reservation.ErrNoPaymentSchedule), true)
+}
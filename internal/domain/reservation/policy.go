@@ -0,0 +1,91 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Policy errors.
+var (
+	ErrPolicyViolation      = errors.New("booking policy violated")
+	ErrBelowMinimumStay     = errors.New("stay is shorter than the minimum stay")
+	ErrTooManyGuests        = errors.New("too many guests for the room")
+	ErrOutsideBookingWindow = errors.New("check-in is too far in advance")
+	ErrBlackoutDates        = errors.New("stay overlaps blackout dates")
+)
+
+// BookingPolicy holds the booking rules a hotel configures for a room.
+// Rules with a zero value are disabled. The aggregate keeps the invariants that hold
+// for every hotel (e.g. check-out after check-in); the policy holds the rules that differ.
+type BookingPolicy struct {
+	MinNights      int         // Minimum number of nights per stay
+	MaxGuests      int         // Maximum number of guests per room
+	MaxAdvanceDays int         // Maximum days between today and check-in
+	BlackoutDates  []DateRange // Periods whose nights cannot be booked; CheckOut is exclusive
+}
+
+// Evaluate checks a stay against the policy and returns all violated rules,
+// keyed by the input field they concern. It returns nil if the stay complies.
+func (p BookingPolicy) Evaluate(dateRange DateRange, guests []GuestInfo, now time.Time) ValidationErrors {
+	var violations ValidationErrors
+
+	nights := int(dateRange.CheckOut.Sub(dateRange.CheckIn).Hours() / 24)
+	if p.MinNights > 0 && nights < p.MinNights {
+		violations = append(violations, FieldError{
+			Field: "check_out",
+			Err:   fmt.Errorf("%w: %d nights required", ErrBelowMinimumStay, p.MinNights),
+		})
+	}
+
+	if p.MaxGuests > 0 && len(guests) > p.MaxGuests {
+		violations = append(violations, FieldError{
+			Field: "guests",
+			Err:   fmt.Errorf("%w: at most %d guests", ErrTooManyGuests, p.MaxGuests),
+		})
+	}
+
+	today := now.Truncate(24 * time.Hour)
+	if p.MaxAdvanceDays > 0 && dateRange.CheckIn.Truncate(24*time.Hour).After(today.AddDate(0, 0, p.MaxAdvanceDays)) {
+		violations = append(violations, FieldError{
+			Field: "check_in",
+			Err:   fmt.Errorf("%w: at most %d days ahead", ErrOutsideBookingWindow, p.MaxAdvanceDays),
+		})
+	}
+
+	for _, blackout := range p.BlackoutDates {
+		if dateRange.CheckIn.Before(blackout.CheckOut) && dateRange.CheckOut.After(blackout.CheckIn) {
+			violations = append(violations, FieldError{
+				Field: "check_in",
+				Err: fmt.Errorf("%w: %s to %s", ErrBlackoutDates,
+					blackout.CheckIn.Format(time.DateOnly), blackout.CheckOut.Format(time.DateOnly)),
+			})
+			break
+		}
+	}
+
+	return violations
+}
+
+// BookingPolicies provides the booking policy that applies to a room.
+// Policies can be loaded from configuration or a repository.
+type BookingPolicies interface {
+	// PolicyFor returns the policy of the given room
+	PolicyFor(ctx context.Context, roomID RoomID) (BookingPolicy, error)
+}
+
+// StaticBookingPolicies is a fixed set of policies with optional overrides per room.
+// It is useful in tests and for hotels whose rules rarely change.
+type StaticBookingPolicies struct {
+	Default BookingPolicy
+	Rooms   map[RoomID]BookingPolicy
+}
+
+// PolicyFor returns the room's policy or the default policy if the room has none.
+func (p StaticBookingPolicies) PolicyFor(_ context.Context, roomID RoomID) (BookingPolicy, error) {
+	if policy, ok := p.Rooms[roomID]; ok {
+		return policy, nil
+	}
+	return p.Default, nil
+}
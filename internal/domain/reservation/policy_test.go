@@ -0,0 +1,97 @@
+package reservation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// BookingPolicy Tests
+// ============================================================================
+
+// policyNow is the evaluation time of the policy tests.
+var policyNow = time.Now().UTC()
+
+func policyDateRange(checkInDays, nights int) reservation.DateRange {
+	checkIn := policyNow.Truncate(24*time.Hour).AddDate(0, 0, checkInDays)
+	return reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, nights))
+}
+
+func Test_BookingPolicy_Evaluate_With_Zero_Policy_Should_Allow_Any_Stay(t *testing.T) {
+	// Arrange
+	policy := reservation.BookingPolicy{}
+
+	// Act
+	violations := policy.Evaluate(policyDateRange(400, 1), serviceValidGuests(), policyNow)
+
+	// Assert
+	assert.That(t, "violations must be empty", len(violations), 0)
+}
+
+func Test_BookingPolicy_Evaluate_With_Short_Stay_Should_Return_Minimum_Stay_Violation(t *testing.T) {
+	// Arrange
+	policy := reservation.BookingPolicy{MinNights: 3}
+
+	// Act
+	violations := policy.Evaluate(policyDateRange(2, 2), serviceValidGuests(), policyNow)
+
+	// Assert
+	assert.That(t, "one violation must be returned", len(violations), 1)
+	assert.That(t, "violation must concern the check-out", violations[0].Field, "check_out")
+	assert.That(t, "violation must be ErrBelowMinimumStay", errors.Is(violations, reservation.ErrBelowMinimumStay), true)
+}
+
+func Test_BookingPolicy_Evaluate_With_Too_Many_Guests_Should_Return_Violation(t *testing.T) {
+	// Arrange
+	policy := reservation.BookingPolicy{MaxGuests: 1}
+	guests := append(serviceValidGuests(), reservation.GuestInfo{Name: "Jane Doe", Email: "jane@example.com"})
+
+	// Act
+	violations := policy.Evaluate(policyDateRange(2, 2), guests, policyNow)
+
+	// Assert
+	assert.That(t, "violation must be ErrTooManyGuests", errors.Is(violations, reservation.ErrTooManyGuests), true)
+}
+
+func Test_BookingPolicy_Evaluate_With_Check_In_Beyond_Window_Should_Return_Violation(t *testing.T) {
+	// Arrange
+	policy := reservation.BookingPolicy{MaxAdvanceDays: 30}
+
+	// Act
+	inside := policy.Evaluate(policyDateRange(30, 2), serviceValidGuests(), policyNow)
+	outside := policy.Evaluate(policyDateRange(31, 2), serviceValidGuests(), policyNow)
+
+	// Assert
+	assert.That(t, "stay on the last day of the window must be allowed", len(inside), 0)
+	assert.That(t, "violation must be ErrOutsideBookingWindow", errors.Is(outside, reservation.ErrOutsideBookingWindow), true)
+}
+
+func Test_BookingPolicy_Evaluate_With_Blackout_Dates_Should_Reject_Overlapping_Stays(t *testing.T) {
+	// Arrange
+	policy := reservation.BookingPolicy{BlackoutDates: []reservation.DateRange{policyDateRange(10, 3)}}
+
+	// Act
+	overlapping := policy.Evaluate(policyDateRange(12, 2), serviceValidGuests(), policyNow)
+	before := policy.Evaluate(policyDateRange(8, 2), serviceValidGuests(), policyNow)
+	after := policy.Evaluate(policyDateRange(13, 2), serviceValidGuests(), policyNow)
+
+	// Assert
+	assert.That(t, "violation must be ErrBlackoutDates", errors.Is(overlapping, reservation.ErrBlackoutDates), true)
+	assert.That(t, "stay ending at the blackout must be allowed", len(before), 0)
+	assert.That(t, "stay starting at the blackout end must be allowed", len(after), 0)
+}
+
+func Test_BookingPolicy_Evaluate_Should_Return_All_Violations(t *testing.T) {
+	// Arrange
+	policy := reservation.BookingPolicy{MinNights: 3, MaxAdvanceDays: 7}
+
+	// Act
+	violations := policy.Evaluate(policyDateRange(10, 1), serviceValidGuests(), policyNow)
+
+	// Assert
+	assert.That(t, "two violations must be returned", len(violations), 2)
+}
@@ -8,7 +8,27 @@ import (
 )
 
 // ReservationRepository provides CRUD operations for reservations.
-type ReservationRepository resource.Access[ReservationID, Reservation]
+type ReservationRepository interface {
+	resource.Access[ReservationID, Reservation]
+	// FindByGuestID returns all reservations of the given guest
+	FindByGuestID(ctx context.Context, guestID GuestID) ([]Reservation, error)
+}
+
+// GuestProfileRepository stores guest profiles.
+type GuestProfileRepository interface {
+	// FindProfile returns the profile of the given guest, or nil if none was saved
+	FindProfile(ctx context.Context, guestID GuestID) (*GuestProfile, error)
+	// SaveProfile creates or replaces the profile of a guest
+	SaveProfile(ctx context.Context, profile GuestProfile) error
+	// DeleteProfile removes the profile of a guest if it exists
+	DeleteProfile(ctx context.Context, guestID GuestID) error
+}
+
+// RoomBlockRepository provides CRUD operations for room blocks.
+type RoomBlockRepository resource.Access[RoomBlockID, RoomBlock]
+
+// RegistrationRepository stores the registration cards of checked-in reservations.
+type RegistrationRepository resource.Access[ReservationID, Registration]
 
 // AvailabilityChecker validates room availability for reservations.
 type AvailabilityChecker interface {
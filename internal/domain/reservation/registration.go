@@ -0,0 +1,86 @@
+package reservation
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// DocumentType is the kind of identity document shown at check-in.
+type DocumentType string
+
+const (
+	DocumentPassport       DocumentType = "passport"
+	DocumentIDCard         DocumentType = "id_card"
+	DocumentDrivingLicence DocumentType = "driving_licence"
+)
+
+// Registration errors.
+var (
+	ErrInvalidDocumentType      = errors.New("invalid document type, expected passport, id_card or driving_licence")
+	ErrDocumentRefRequired      = errors.New("document reference is required")
+	ErrSignatureRequired        = errors.New("the guest must sign the registration card")
+	ErrRegistrationsUnavailable = errors.New("registration cards are not configured")
+)
+
+// RegistrationDetails are the details captured by the staff on the registration card.
+type RegistrationDetails struct {
+	DocumentType DocumentType
+	DocumentRef  string // Number of the document, or the key of its scan
+	Signed       bool   // The guest acknowledged the card; the signature itself is kept on paper
+}
+
+// Registration is the digital registration card of a checked-in reservation.
+// It is keyed by the reservation ID and lives outside the Reservation aggregate.
+type Registration struct {
+	shared.Aggregate
+	ReservationID ReservationID
+	RoomID        RoomID
+	DocumentType  DocumentType
+	DocumentRef   string
+	Signed        bool
+	CheckedInBy   string // Email of the staff member
+	CheckedInAt   time.Time
+}
+
+// NewRegistration creates the registration card of a reservation and records its checked-in event.
+// Invalid fields are reported together as ValidationErrors.
+func NewRegistration(res *Reservation, details RegistrationDetails, staff string, now time.Time) (*Registration, error) {
+	var errs ValidationErrors
+
+	switch details.DocumentType {
+	case DocumentPassport, DocumentIDCard, DocumentDrivingLicence:
+	default:
+		errs = append(errs, FieldError{Field: "document_type", Err: ErrInvalidDocumentType})
+	}
+	ref := strings.TrimSpace(details.DocumentRef)
+	if ref == "" {
+		errs = append(errs, FieldError{Field: "document_ref", Err: ErrDocumentRefRequired})
+	}
+	if !details.Signed {
+		errs = append(errs, FieldError{Field: "signed", Err: ErrSignatureRequired})
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	reg := &Registration{
+		ReservationID: res.ID,
+		RoomID:        res.RoomID,
+		DocumentType:  details.DocumentType,
+		DocumentRef:   ref,
+		Signed:        true,
+		CheckedInBy:   staff,
+		CheckedInAt:   now,
+	}
+	reg.RecordEvent(NewEventCheckedIn().
+		WithReservationID(reg.ReservationID).
+		WithRoomID(reg.RoomID).
+		WithDocumentType(reg.DocumentType).
+		WithCheckedInBy(reg.CheckedInBy).
+		WithCheckedInAt(reg.CheckedInAt))
+	return reg, nil
+}
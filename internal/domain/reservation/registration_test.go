@@ -0,0 +1,64 @@
+package reservation_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Registration Tests
+// ============================================================================
+
+func validRegistrationDetails() reservation.RegistrationDetails {
+	return reservation.RegistrationDetails{
+		DocumentType: reservation.DocumentPassport,
+		DocumentRef:  " C01X00T47 ",
+		Signed:       true,
+	}
+}
+
+func Test_NewRegistration_With_Valid_Details_Should_Record_CheckedIn_Event(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	now := time.Now()
+
+	// Act
+	reg, err := reservation.NewRegistration(res, validRegistrationDetails(), "staff@example.com", now)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "document reference must be trimmed", reg.DocumentRef, "C01X00T47")
+	assert.That(t, "staff must be recorded", reg.CheckedInBy, "staff@example.com")
+	events := reg.PullEvents()
+	assert.That(t, "one event must be recorded", len(events), 1)
+	evt, ok := events[0].(*reservation.EventCheckedIn)
+	assert.That(t, "event must be checked in", ok, true)
+	assert.That(t, "event must carry the room", evt.RoomID, res.RoomID)
+	assert.That(t, "event must carry the document type", evt.DocumentType, reservation.DocumentPassport)
+	assert.That(t, "event must carry the check-in time", evt.CheckedInAt, now)
+	encoded, _ := json.Marshal(evt)
+	assert.That(t, "event must not carry the document reference", strings.Contains(string(encoded), "C01X00T47"), false)
+}
+
+func Test_NewRegistration_With_Missing_Details_Should_Return_All_Field_Errors(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	details := reservation.RegistrationDetails{DocumentType: "library_card", DocumentRef: " "}
+
+	// Act
+	_, err := reservation.NewRegistration(res, details, "staff@example.com", time.Now())
+
+	// Assert
+	var verrs reservation.ValidationErrors
+	assert.That(t, "error must be ValidationErrors", errors.As(err, &verrs), true)
+	assert.That(t, "three fields must be invalid", len(verrs), 3)
+	assert.That(t, "error must match ErrInvalidDocumentType", errors.Is(err, reservation.ErrInvalidDocumentType), true)
+	assert.That(t, "error must match ErrDocumentRefRequired", errors.Is(err, reservation.ErrDocumentRefRequired), true)
+	assert.That(t, "error must match ErrSignatureRequired", errors.Is(err, reservation.ErrSignatureRequired), true)
+}
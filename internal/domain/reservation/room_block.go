@@ -0,0 +1,88 @@
+package reservation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// RoomBlockID identifies a room block.
+type RoomBlockID string
+
+// BlockReason is the reason a room is taken out of service.
+type BlockReason string
+
+const (
+	BlockMaintenance BlockReason = "maintenance"
+	BlockRenovation  BlockReason = "renovation"
+)
+
+// Room block errors.
+var (
+	ErrInvalidBlockReason    = errors.New("invalid block reason, expected maintenance or renovation")
+	ErrRoomRequired          = errors.New("room is required")
+	ErrRoomBlocksUnavailable = errors.New("room blocks are not configured")
+	ErrRoomBlockNotFound     = errors.New("room block not found")
+)
+
+// RoomBlock is the aggregate root for periods in which a room cannot be booked,
+// e.g. for maintenance or renovation. The availability checker treats blocks as
+// occupancy, so overlapping reservations are rejected. Reservations booked before
+// the block was placed are cancelled by the orchestration layer.
+type RoomBlock struct {
+	shared.Aggregate
+	ID        RoomBlockID
+	RoomID    RoomID
+	DateRange DateRange // CheckOut is the first night the room can be booked again
+	Reason    BlockReason
+	Note      string
+	CreatedAt time.Time
+}
+
+// NewRoomBlock creates a room block with validation and records its blocked event.
+func NewRoomBlock(id RoomBlockID, roomID RoomID, dateRange DateRange, reason BlockReason, note string) (*RoomBlock, error) {
+	if roomID == "" {
+		return nil, ErrRoomRequired
+	}
+	if reason != BlockMaintenance && reason != BlockRenovation {
+		return nil, ErrInvalidBlockReason
+	}
+
+	// Blocks share the date rules of reservations: at least one night, not in the past.
+	stay := Reservation{DateRange: dateRange}
+	if err := stay.validateDateRange(); err != nil {
+		return nil, err
+	}
+
+	b := &RoomBlock{
+		ID:        id,
+		RoomID:    roomID,
+		DateRange: dateRange,
+		Reason:    reason,
+		Note:      note,
+		CreatedAt: time.Now(),
+	}
+	b.RecordEvent(NewEventRoomBlocked().
+		WithBlockID(b.ID).
+		WithRoomID(b.RoomID).
+		WithCheckIn(b.DateRange.CheckIn).
+		WithCheckOut(b.DateRange.CheckOut).
+		WithReason(b.Reason))
+	return b, nil
+}
+
+// Overlaps reports whether the block covers a night of the stay in the room.
+func (b *RoomBlock) Overlaps(roomID RoomID, dateRange DateRange) bool {
+	return b.RoomID == roomID &&
+		b.DateRange.CheckIn.Before(dateRange.CheckOut) &&
+		b.DateRange.CheckOut.After(dateRange.CheckIn)
+}
+
+// Remove records the unblocked event before the block is deleted.
+// Reservations cancelled for the block are not restored.
+func (b *RoomBlock) Remove() {
+	b.RecordEvent(NewEventRoomUnblocked().
+		WithBlockID(b.ID).
+		WithRoomID(b.RoomID))
+}
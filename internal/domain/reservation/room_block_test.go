@@ -0,0 +1,69 @@
+package reservation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// RoomBlock Tests
+// ============================================================================
+
+func Test_NewRoomBlock_With_Valid_Data_Should_Record_Blocked_Event(t *testing.T) {
+	// Arrange
+	dateRange := validDateRange()
+
+	// Act
+	block, err := reservation.NewRoomBlock("block-001", "room-101", dateRange, reservation.BlockMaintenance, "Water damage")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "room must match", block.RoomID, reservation.RoomID("room-101"))
+	events := block.PullEvents()
+	assert.That(t, "one event must be recorded", len(events), 1)
+	assert.That(t, "event must be room blocked", events[0].Topic(), reservation.EventTopicRoomBlocked)
+}
+
+func Test_NewRoomBlock_With_Unknown_Reason_Should_Return_Error(t *testing.T) {
+	// Arrange
+	dateRange := validDateRange()
+
+	// Act
+	_, err := reservation.NewRoomBlock("block-001", "room-101", dateRange, "vacation", "")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidBlockReason", errors.Is(err, reservation.ErrInvalidBlockReason), true)
+}
+
+func Test_NewRoomBlock_With_Past_Dates_Should_Return_Error(t *testing.T) {
+	// Arrange
+	checkIn := time.Now().Add(-48 * time.Hour)
+	dateRange := reservation.NewDateRange(checkIn, checkIn.Add(24*time.Hour))
+
+	// Act
+	_, err := reservation.NewRoomBlock("block-001", "room-101", dateRange, reservation.BlockRenovation, "")
+
+	// Assert
+	assert.That(t, "error must be ErrCheckInPast", errors.Is(err, reservation.ErrCheckInPast), true)
+}
+
+func Test_RoomBlock_Overlaps_Should_Compare_Room_And_Nights(t *testing.T) {
+	// Arrange
+	dateRange := validDateRange()
+	block, _ := reservation.NewRoomBlock("block-001", "room-101", dateRange, reservation.BlockMaintenance, "")
+	following := reservation.NewDateRange(dateRange.CheckOut, dateRange.CheckOut.Add(24*time.Hour))
+
+	// Act
+	sameRoom := block.Overlaps("room-101", dateRange)
+	otherRoom := block.Overlaps("room-102", dateRange)
+	afterBlock := block.Overlaps("room-101", following)
+
+	// Assert
+	assert.That(t, "block must overlap the same nights", sameRoom, true)
+	assert.That(t, "block must not overlap another room", otherRoom, false)
+	assert.That(t, "block must not overlap the check-out night", afterBlock, false)
+}
@@ -0,0 +1,41 @@
+package reservation
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrRoomNotPriced = errors.New("room has no rate")
+	ErrInvalidNights = errors.New("stay must last at least one night")
+)
+
+// RoomRates prices stays from the nightly rates of the rooms, so the amount of a
+// booking is computed by the hotel and not taken from the caller.
+type RoomRates struct {
+	rates map[RoomID]Money
+}
+
+// NewRoomRates creates room rates without any room on offer.
+func NewRoomRates() *RoomRates {
+	return &RoomRates{rates: make(map[RoomID]Money)}
+}
+
+// WithRate offers the room at the nightly rate.
+func (r *RoomRates) WithRate(roomID RoomID, nightly Money) *RoomRates {
+	r.rates[roomID] = nightly
+	return r
+}
+
+// Quote returns the price of the stay in the room: its nightly rate times the nights.
+func (r *RoomRates) Quote(roomID RoomID, dateRange DateRange) (Money, error) {
+	nightly, ok := r.rates[roomID]
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %s", ErrRoomNotPriced, roomID)
+	}
+	nights := int64(dateRange.CheckOut.Sub(dateRange.CheckIn).Hours() / 24)
+	if nights < 1 {
+		return Money{}, ErrInvalidNights
+	}
+	return nightly.Multiply(nights)
+}
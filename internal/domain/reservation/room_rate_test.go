@@ -0,0 +1,52 @@
+package reservation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// RoomRates Tests
+// ============================================================================
+
+func Test_RoomRates_Quote_Should_Multiply_Nightly_Rate_By_Nights(t *testing.T) {
+	// Arrange
+	rates := reservation.NewRoomRates().WithRate("room-101", shared.NewMoney(9900, "USD"))
+	checkIn := time.Now().Add(48 * time.Hour)
+
+	// Act
+	total, err := rates.Quote("room-101", reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 3)))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "total must cover three nights", total, shared.NewMoney(29700, "USD"))
+}
+
+func Test_RoomRates_Quote_Unknown_Room_Should_Return_ErrRoomNotPriced(t *testing.T) {
+	// Arrange
+	rates := reservation.NewRoomRates().WithRate("room-101", shared.NewMoney(9900, "USD"))
+	checkIn := time.Now().Add(48 * time.Hour)
+
+	// Act
+	_, err := rates.Quote("room-999", reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 3)))
+
+	// Assert
+	assert.That(t, "err must be ErrRoomNotPriced", errors.Is(err, reservation.ErrRoomNotPriced), true)
+}
+
+func Test_RoomRates_Quote_Without_Night_Should_Return_ErrInvalidNights(t *testing.T) {
+	// Arrange
+	rates := reservation.NewRoomRates().WithRate("room-101", shared.NewMoney(9900, "USD"))
+	checkIn := time.Now().Add(48 * time.Hour)
+
+	// Act
+	_, err := rates.Quote("room-101", reservation.NewDateRange(checkIn, checkIn))
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidNights", errors.Is(err, reservation.ErrInvalidNights), true)
+}
@@ -3,9 +3,12 @@ package reservation
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
 // Service handles reservation workflows.
@@ -13,6 +16,12 @@ type Service struct {
 	reservationRepo     ReservationRepository
 	availabilityChecker AvailabilityChecker
 	publisher           event.EventPublisher
+	profiles            GuestProfileRepository
+	policies            BookingPolicies
+	blocks              RoomBlockRepository
+	registrations       RegistrationRepository
+	paymentPlan         PaymentPlan
+	addOnPricing        *AddOnPricing
 }
 
 // NewService creates a new reservation Service with dependencies.
@@ -28,7 +37,48 @@ func NewService(
 	}
 }
 
-// CreateReservation creates a new pending reservation after checking availability.
+// WithGuestProfiles enables guest profiles backed by the given repository.
+func (s *Service) WithGuestProfiles(repo GuestProfileRepository) *Service {
+	s.profiles = repo
+	return s
+}
+
+// WithPolicies enables the booking policies that CreateReservation enforces.
+func (s *Service) WithPolicies(policies BookingPolicies) *Service {
+	s.policies = policies
+	return s
+}
+
+// WithRoomBlocks enables room blocks backed by the given repository.
+// The availability checker must consult the same repository to reject overlapping reservations.
+func (s *Service) WithRoomBlocks(repo RoomBlockRepository) *Service {
+	s.blocks = repo
+	return s
+}
+
+// WithRegistrations enables the check-in with registration cards backed by the given repository.
+func (s *Service) WithRegistrations(repo RegistrationRepository) *Service {
+	s.registrations = repo
+	return s
+}
+
+// WithPaymentPlan lets CreateReservation split the payment of bookings into a deposit
+// and a balance charged before check-in. Bookings whose balance would already be due
+// are paid in full at booking.
+func (s *Service) WithPaymentPlan(plan PaymentPlan) *Service {
+	s.paymentPlan = plan
+	return s
+}
+
+// WithAddOnPricing enables the add-ons guests can book for their stay, priced by the given pricing.
+func (s *Service) WithAddOnPricing(pricing *AddOnPricing) *Service {
+	s.addOnPricing = pricing
+	return s
+}
+
+// CreateReservation creates a new pending reservation after checking the
+// booking policy of the room and its availability.
+// Policy violations are returned together as ValidationErrors wrapped in ErrPolicyViolation.
 func (s *Service) CreateReservation(
 	ctx context.Context,
 	id ReservationID,
@@ -37,6 +87,70 @@ func (s *Service) CreateReservation(
 	dateRange DateRange,
 	amount Money,
 	guests []GuestInfo,
+) (*Reservation, error) {
+	// 1. Check the booking policy of the room
+	if err := s.checkPolicy(ctx, roomID, dateRange, guests); err != nil {
+		return nil, err
+	}
+
+	// 2. Check room availability
+	available, err := s.availabilityChecker.IsRoomAvailable(ctx, roomID, dateRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+	if !available {
+		return nil, fmt.Errorf("%w: room %s", ErrRoomUnavailable, roomID)
+	}
+
+	// 3. Create reservation aggregate with the payment schedule of the plan, if any
+	schedule := s.paymentPlan.NewSchedule(amount, dateRange.CheckIn, time.Now())
+	reservation, err := NewScheduledReservation(id, guestID, roomID, dateRange, amount, guests, schedule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	// 4. Persist to repository (the recorded events are pulled first, so they are not stored)
+	events := reservation.PullEvents()
+	if err := s.reservationRepo.Create(ctx, id, *reservation); err != nil {
+		return nil, fmt.Errorf("failed to persist reservation: %w", err)
+	}
+
+	// 5. Publish the domain events recorded by the aggregate
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// checkPolicy evaluates the booking policy of the room, if policies are configured.
+func (s *Service) checkPolicy(ctx context.Context, roomID RoomID, dateRange DateRange, guests []GuestInfo) error {
+	if s.policies == nil {
+		return nil
+	}
+	policy, err := s.policies.PolicyFor(ctx, roomID)
+	if err != nil {
+		return fmt.Errorf("failed to load booking policy: %w", err)
+	}
+	if violations := policy.Evaluate(dateRange, guests, time.Now()); len(violations) > 0 {
+		return fmt.Errorf("%w: %w", ErrPolicyViolation, violations)
+	}
+	return nil
+}
+
+// ImportReservation stores a reservation sold by an external channel (OTA).
+// The channel has already guaranteed the booking, so the reservation is confirmed
+// right away and the created event carries the channel, which skips payment processing.
+// Booking policies are not checked, because the channel applies its own rules.
+func (s *Service) ImportReservation(
+	ctx context.Context,
+	id ReservationID,
+	guestID GuestID,
+	roomID RoomID,
+	dateRange DateRange,
+	amount Money,
+	guests []GuestInfo,
+	channel string,
 ) (*Reservation, error) {
 	// 1. Check room availability
 	available, err := s.availabilityChecker.IsRoomAvailable(ctx, roomID, dateRange)
@@ -44,31 +158,24 @@ func (s *Service) CreateReservation(
 		return nil, fmt.Errorf("failed to check availability: %w", err)
 	}
 	if !available {
-		return nil, fmt.Errorf("room %s is not available for the selected dates", roomID)
+		return nil, fmt.Errorf("%w: room %s", ErrRoomUnavailable, roomID)
 	}
 
-	// 2. Create reservation aggregate
-	reservation, err := NewReservation(id, guestID, roomID, dateRange, amount, guests)
+	// 2. Create confirmed reservation aggregate
+	reservation, err := NewChannelReservation(id, guestID, roomID, dateRange, amount, guests, channel)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create reservation: %w", err)
 	}
 
 	// 3. Persist to repository
+	events := reservation.PullEvents()
 	if err := s.reservationRepo.Create(ctx, id, *reservation); err != nil {
 		return nil, fmt.Errorf("failed to persist reservation: %w", err)
 	}
 
-	// 4. Publish domain event
-	evt := NewEventCreated().
-		WithReservationID(id).
-		WithGuestID(guestID).
-		WithRoomID(roomID).
-		WithCheckIn(dateRange.CheckIn).
-		WithCheckOut(dateRange.CheckOut).
-		WithTotalAmount(amount)
-
-	if err := s.publisher.Publish(ctx, evt); err != nil {
-		return nil, fmt.Errorf("failed to publish event: %w", err)
+	// 4. Publish the created and confirmed events
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
 	}
 
 	return reservation, nil
@@ -88,20 +195,13 @@ func (s *Service) ConfirmReservation(ctx context.Context, id ReservationID) erro
 	}
 
 	// 3. Update repository
+	events := reservation.PullEvents()
 	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
 
-	// 4. Publish domain event
-	evt := NewEventConfirmed().
-		WithReservationID(id).
-		WithGuestID(reservation.GuestID)
-
-	if err := s.publisher.Publish(ctx, evt); err != nil {
-		return fmt.Errorf("failed to publish event: %w", err)
-	}
-
-	return nil
+	// 4. Publish domain events
+	return shared.PublishEvents(ctx, s.publisher, events)
 }
 
 // CancelReservation cancels a reservation with business rule validation.
@@ -112,28 +212,140 @@ func (s *Service) CancelReservation(ctx context.Context, id ReservationID, reaso
 		return fmt.Errorf("failed to read reservation: %w", err)
 	}
 
-	guestID := reservation.GuestID
-
 	// 2. Cancel reservation (aggregate business logic validates rules)
 	if err := reservation.Cancel(reason); err != nil {
 		return fmt.Errorf("failed to cancel reservation: %w", err)
 	}
 
 	// 3. Update repository
+	events := reservation.PullEvents()
 	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
 
-	// 4. Publish domain event
-	evt := NewEventCancelled().
-		WithReservationID(id).
-		WithGuestID(guestID).
-		WithReason(reason)
+	// 4. Publish domain events
+	return shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// DisplaceReservation cancels a reservation whose room was blocked by the hotel,
+// regardless of the cancellation deadline.
+func (s *Service) DisplaceReservation(ctx context.Context, id ReservationID, reason string) error {
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	if err := reservation.Displace(reason); err != nil {
+		return fmt.Errorf("failed to displace reservation: %w", err)
+	}
+
+	events := reservation.PullEvents()
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	return shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// MarkRefundRequired flags a cancelled reservation whose refund failed.
+func (s *Service) MarkRefundRequired(ctx context.Context, id ReservationID) error {
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	if err := reservation.MarkRefundRequired(); err != nil {
+		return fmt.Errorf("failed to flag reservation: %w", err)
+	}
+
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	return nil
+}
+
+// ClearRefundRequired removes the refund flag from a reservation.
+func (s *Service) ClearRefundRequired(ctx context.Context, id ReservationID) error {
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	if !reservation.RefundRequired {
+		return nil
+	}
+	reservation.ClearRefundRequired()
+
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	return nil
+}
+
+// MarkDisputed flags a reservation whose payment is disputed.
+func (s *Service) MarkDisputed(ctx context.Context, id ReservationID) error {
+	return s.setDisputed(ctx, id, true)
+}
+
+// ClearDisputed removes the dispute flag from a reservation.
+func (s *Service) ClearDisputed(ctx context.Context, id ReservationID) error {
+	return s.setDisputed(ctx, id, false)
+}
+
+// setDisputed sets the dispute flag of a reservation. A reservation that is
+// flagged already is not updated again.
+func (s *Service) setDisputed(ctx context.Context, id ReservationID, disputed bool) error {
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	if reservation.Disputed == disputed {
+		return nil
+	}
+	if disputed {
+		reservation.MarkDisputed()
+	} else {
+		reservation.ClearDisputed()
+	}
+
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	return nil
+}
+
+// MarkBalanceReminded records that the guest was reminded of the open balance of a reservation.
+func (s *Service) MarkBalanceReminded(ctx context.Context, id ReservationID, now time.Time) error {
+	return s.updateReservation(ctx, id, func(reservation *Reservation) error {
+		return reservation.MarkBalanceReminded(now)
+	})
+}
+
+// MarkBalancePaid records that the balance of a reservation was captured.
+func (s *Service) MarkBalancePaid(ctx context.Context, id ReservationID, now time.Time) error {
+	return s.updateReservation(ctx, id, func(reservation *Reservation) error {
+		return reservation.MarkBalancePaid(now)
+	})
+}
+
+// updateReservation reads a reservation, applies the change and persists it.
+func (s *Service) updateReservation(ctx context.Context, id ReservationID, change func(*Reservation) error) error {
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation: %w", err)
+	}
 
-	if err := s.publisher.Publish(ctx, evt); err != nil {
-		return fmt.Errorf("failed to publish event: %w", err)
+	if err := change(reservation); err != nil {
+		return err
 	}
 
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
 	return nil
 }
 
@@ -148,16 +360,92 @@ func (s *Service) ActivateReservation(ctx context.Context, id ReservationID) err
 		return fmt.Errorf("failed to activate reservation: %w", err)
 	}
 
+	events := reservation.PullEvents()
 	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
 
-	evt := NewEventActivated().WithReservationID(id)
-	if err := s.publisher.Publish(ctx, evt); err != nil {
-		return fmt.Errorf("failed to publish event: %w", err)
+	return shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// CheckInReservation checks a guest in at the front desk: it saves the registration card,
+// activates the reservation and publishes the checked-in event with the captured details.
+// Invalid details are returned as ValidationErrors.
+func (s *Service) CheckInReservation(ctx context.Context, id ReservationID, details RegistrationDetails, staff string) (*Registration, error) {
+	if s.registrations == nil {
+		return nil, ErrRegistrationsUnavailable
 	}
 
-	return nil
+	// 1. Verify that the reservation is ready for check-in
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reservation: %w", err)
+	}
+	if !reservationStates.Can(reservation.Status, StatusActive) {
+		return nil, fmt.Errorf("%w: cannot check in %s reservation", ErrInvalidStateTransition, reservation.Status)
+	}
+
+	// 2. Fill in the registration card
+	registration, err := NewRegistration(reservation, details, staff, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Save the card before activating, so an active reservation always has one.
+	// A card left by a failed attempt is replaced.
+	events := registration.PullEvents()
+	err = s.registrations.Create(ctx, id, *registration)
+	if err != nil && err.Error() == resource.ErrorResourceAlreadyExists {
+		err = s.registrations.Update(ctx, id, *registration)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to persist registration: %w", err)
+	}
+
+	// 4. Activate the reservation
+	if err := s.ActivateReservation(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
+	}
+
+	return registration, nil
+}
+
+// GetRegistration retrieves the registration card of a checked-in reservation.
+func (s *Service) GetRegistration(ctx context.Context, id ReservationID) (*Registration, error) {
+	if s.registrations == nil {
+		return nil, ErrRegistrationsUnavailable
+	}
+
+	registration, err := s.registrations.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registration: %w", err)
+	}
+
+	return registration, nil
+}
+
+// DeleteRegistrations removes the registration cards of the reservations, e.g. on
+// a guest's erasure request. It returns the number of deleted cards.
+func (s *Service) DeleteRegistrations(ctx context.Context, ids []ReservationID) (int, error) {
+	if s.registrations == nil {
+		return 0, nil
+	}
+
+	deleted := 0
+	for _, id := range ids {
+		if err := s.registrations.Delete(ctx, id); err != nil {
+			if err.Error() == resource.ErrorResourceNotFound {
+				continue
+			}
+			return deleted, fmt.Errorf("failed to delete registration: %w", err)
+		}
+		deleted++
+	}
+	return deleted, nil
 }
 
 // CompleteReservation transitions a reservation to completed status (check-out).
@@ -171,18 +459,110 @@ func (s *Service) CompleteReservation(ctx context.Context, id ReservationID) err
 		return fmt.Errorf("failed to complete reservation: %w", err)
 	}
 
+	events := reservation.PullEvents()
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	return shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// MarkNoShow transitions a confirmed reservation to no-show (the guest did not arrive).
+func (s *Service) MarkNoShow(ctx context.Context, id ReservationID) error {
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	if err := reservation.MarkNoShow(); err != nil {
+		return fmt.Errorf("failed to mark reservation as no-show: %w", err)
+	}
+
+	events := reservation.PullEvents()
 	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
 
-	evt := NewEventCompleted().WithReservationID(id)
-	if err := s.publisher.Publish(ctx, evt); err != nil {
-		return fmt.Errorf("failed to publish event: %w", err)
+	return shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// MarkPreArrivalSent records that the guest of a reservation was sent the reminder before arrival.
+func (s *Service) MarkPreArrivalSent(ctx context.Context, id ReservationID, now time.Time) error {
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	if err := reservation.MarkPreArrivalSent(now); err != nil {
+		return err
 	}
 
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
 	return nil
 }
 
+// AddAddOn books an add-on of the kind for a reservation, priced by the add-on pricing,
+// and recalculates the reservation total.
+func (s *Service) AddAddOn(ctx context.Context, id ReservationID, addOnID AddOnID, kind AddOnKind, now time.Time) (*Reservation, error) {
+	if s.addOnPricing == nil {
+		return nil, ErrAddOnsUnavailable
+	}
+
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	addOn, err := s.addOnPricing.Quote(addOnID, kind, reservation, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := reservation.AddAddOn(addOn, now); err != nil {
+		return nil, err
+	}
+
+	events := reservation.PullEvents()
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return nil, fmt.Errorf("failed to update reservation: %w", err)
+	}
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// MarkAddOnCharged records the payment that charged an add-on of a reservation on its own.
+func (s *Service) MarkAddOnCharged(ctx context.Context, id ReservationID, addOnID AddOnID, paymentID string) error {
+	return s.updateReservation(ctx, id, func(reservation *Reservation) error {
+		return reservation.MarkAddOnCharged(addOnID, paymentID)
+	})
+}
+
+// RemoveAddOn cancels an add-on of a reservation and recalculates the reservation total.
+// It returns the removed add-on.
+func (s *Service) RemoveAddOn(ctx context.Context, id ReservationID, addOnID AddOnID, now time.Time) (*AddOn, error) {
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	removed, err := reservation.RemoveAddOn(addOnID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	events := reservation.PullEvents()
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return nil, fmt.Errorf("failed to update reservation: %w", err)
+	}
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
+	}
+	return &removed, nil
+}
+
 // GetReservation retrieves a reservation by ID.
 func (s *Service) GetReservation(ctx context.Context, id ReservationID) (*Reservation, error) {
 	reservation, err := s.reservationRepo.Read(ctx, id)
@@ -192,26 +572,310 @@ func (s *Service) GetReservation(ctx context.Context, id ReservationID) (*Reserv
 	return reservation, nil
 }
 
+// ListReservationsByRoom retrieves the reservations of a room that overlap the date range.
+// Cancelled and no-show reservations are not included.
+func (s *Service) ListReservationsByRoom(ctx context.Context, roomID RoomID, dateRange DateRange) ([]*Reservation, error) {
+	reservations, err := s.availabilityChecker.GetOverlappingReservations(ctx, roomID, dateRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+	return reservations, nil
+}
+
+// ListExternalHolds retrieves all external holds, including released ones.
+func (s *Service) ListExternalHolds(ctx context.Context) ([]*Reservation, error) {
+	return s.ListReservationsByGuest(ctx, ExternalHoldGuestID)
+}
+
+// PlaceExternalHold blocks a room for dates booked on an external platform.
+// The ref identifies the booking in the source, so the hold can be matched on the next import.
+// The created event carries the source as channel, so no payment is processed.
+func (s *Service) PlaceExternalHold(ctx context.Context, id ReservationID, roomID RoomID, dateRange DateRange, source, ref string) (*Reservation, error) {
+	// 1. Check room availability
+	available, err := s.availabilityChecker.IsRoomAvailable(ctx, roomID, dateRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+	if !available {
+		return nil, fmt.Errorf("%w: room %s", ErrRoomUnavailable, roomID)
+	}
+
+	// 2. Create hold
+	hold, err := NewExternalHold(id, roomID, dateRange, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hold: %w", err)
+	}
+	hold.ExternalRef = ref
+
+	// 3. Persist to repository
+	events := hold.PullEvents()
+	if err := s.reservationRepo.Create(ctx, id, *hold); err != nil {
+		return nil, fmt.Errorf("failed to persist hold: %w", err)
+	}
+
+	// 4. Publish domain events
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// ReleaseExternalHold frees the dates of an external hold.
+func (s *Service) ReleaseExternalHold(ctx context.Context, id ReservationID) error {
+	hold, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read hold: %w", err)
+	}
+
+	if err := hold.Release(); err != nil {
+		return fmt.Errorf("failed to release hold: %w", err)
+	}
+
+	events := hold.PullEvents()
+	if err := s.reservationRepo.Update(ctx, id, *hold); err != nil {
+		return fmt.Errorf("failed to update hold: %w", err)
+	}
+
+	return shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// BlockRoom takes a room out of service for the date range. The block is placed even
+// if the room is booked: the blocked event lets the orchestration layer cancel and
+// refund the overlapping reservations.
+func (s *Service) BlockRoom(ctx context.Context, id RoomBlockID, roomID RoomID, dateRange DateRange, reason BlockReason, note string) (*RoomBlock, error) {
+	if s.blocks == nil {
+		return nil, ErrRoomBlocksUnavailable
+	}
+
+	block, err := NewRoomBlock(id, roomID, dateRange, reason, note)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create room block: %w", err)
+	}
+
+	events := block.PullEvents()
+	if err := s.blocks.Create(ctx, id, *block); err != nil {
+		return nil, fmt.Errorf("failed to persist room block: %w", err)
+	}
+
+	if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// RemoveRoomBlock makes the dates of a room block bookable again.
+func (s *Service) RemoveRoomBlock(ctx context.Context, roomID RoomID, id RoomBlockID) error {
+	if s.blocks == nil {
+		return ErrRoomBlocksUnavailable
+	}
+
+	block, err := s.blocks.Read(ctx, id)
+	if err != nil {
+		if err.Error() == resource.ErrorResourceNotFound {
+			return fmt.Errorf("%w: %s", ErrRoomBlockNotFound, id)
+		}
+		return fmt.Errorf("failed to read room block: %w", err)
+	}
+	if block.RoomID != roomID {
+		return fmt.Errorf("%w: %s", ErrRoomBlockNotFound, id)
+	}
+
+	block.Remove()
+	events := block.PullEvents()
+	if err := s.blocks.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete room block: %w", err)
+	}
+
+	return shared.PublishEvents(ctx, s.publisher, events)
+}
+
+// ListRoomBlocks retrieves the blocks of a room, ordered by start date.
+func (s *Service) ListRoomBlocks(ctx context.Context, roomID RoomID) ([]RoomBlock, error) {
+	if s.blocks == nil {
+		return nil, ErrRoomBlocksUnavailable
+	}
+
+	blocks, err := s.blocks.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read room blocks: %w", err)
+	}
+
+	result := []RoomBlock{}
+	for _, block := range blocks {
+		if block.RoomID == roomID {
+			result = append(result, block)
+		}
+	}
+	slices.SortFunc(result, func(a, b RoomBlock) int {
+		return a.DateRange.CheckIn.Compare(b.DateRange.CheckIn)
+	})
+	return result, nil
+}
+
+// ListReservations retrieves all reservations.
+func (s *Service) ListReservations(ctx context.Context) ([]*Reservation, error) {
+	reservations, err := s.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	all := make([]*Reservation, 0, len(reservations))
+	for i := range reservations {
+		all = append(all, &reservations[i])
+	}
+
+	return all, nil
+}
+
 // ListReservationsByGuest retrieves all reservations for a guest.
 func (s *Service) ListReservationsByGuest(ctx context.Context, guestID GuestID) ([]*Reservation, error) {
-	allReservations, err := s.reservationRepo.ReadAll(ctx)
+	reservations, err := s.reservationRepo.FindByGuestID(ctx, guestID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list reservations: %w", err)
 	}
 
-	var guestReservations []*Reservation
-	for i := range allReservations {
-		if allReservations[i].GuestID == guestID {
-			guestReservations = append(guestReservations, &allReservations[i])
-		}
+	guestReservations := make([]*Reservation, 0, len(reservations))
+	for i := range reservations {
+		guestReservations = append(guestReservations, &reservations[i])
 	}
 
 	return guestReservations, nil
 }
 
+// AnonymizeGuest removes the personal data of a guest from all of their reservations.
+// Nothing is changed if any reservation is still open. It returns the IDs of the
+// anonymized reservations.
+func (s *Service) AnonymizeGuest(ctx context.Context, guestID GuestID) ([]ReservationID, error) {
+	reservations, err := s.reservationRepo.FindByGuestID(ctx, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	for _, r := range reservations {
+		if !r.IsClosed() {
+			return nil, fmt.Errorf("%w: reservation %s is %s", ErrReservationOpen, r.ID, r.Status)
+		}
+	}
+
+	ids := make([]ReservationID, 0, len(reservations))
+	for i := range reservations {
+		if err := reservations[i].Anonymize(); err != nil {
+			return ids, fmt.Errorf("failed to anonymize reservation: %w", err)
+		}
+		events := reservations[i].PullEvents()
+		if err := s.reservationRepo.Update(ctx, reservations[i].ID, reservations[i]); err != nil {
+			return ids, fmt.Errorf("failed to update reservation: %w", err)
+		}
+		if err := shared.PublishEvents(ctx, s.publisher, events); err != nil {
+			return ids, err
+		}
+		ids = append(ids, reservations[i].ID)
+	}
+
+	return ids, nil
+}
+
+// GetGuestProfile returns the profile of a guest.
+// Guests without a saved profile (or without configured profiles) get an empty one.
+func (s *Service) GetGuestProfile(ctx context.Context, guestID GuestID) (*GuestProfile, error) {
+	if s.profiles == nil {
+		return &GuestProfile{GuestID: guestID}, nil
+	}
+	profile, err := s.profiles.FindProfile(ctx, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read guest profile: %w", err)
+	}
+	if profile == nil {
+		return &GuestProfile{GuestID: guestID}, nil
+	}
+	return profile, nil
+}
+
+// UpdateGuestProfile validates and saves the profile of a guest.
+// The guest's notification preferences are kept.
+func (s *Service) UpdateGuestProfile(ctx context.Context, guestID GuestID, name, phoneNumber, locale string) (*GuestProfile, error) {
+	if s.profiles == nil {
+		return nil, ErrProfilesUnavailable
+	}
+	profile, err := NewGuestProfile(guestID, name, phoneNumber, locale)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.GetGuestProfile(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	profile.Notifications = existing.Notifications
+	profile.Theme = existing.Theme
+	if err := s.profiles.SaveProfile(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to save guest profile: %w", err)
+	}
+	return &profile, nil
+}
+
+// UpdateNotificationPreferences saves the channels the guest is notified on.
+// Guests without a saved profile get one with the preferences only.
+func (s *Service) UpdateNotificationPreferences(ctx context.Context, guestID GuestID, prefs NotificationPreferences) (*GuestProfile, error) {
+	if s.profiles == nil {
+		return nil, ErrProfilesUnavailable
+	}
+	profile, err := s.GetGuestProfile(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	profile.Notifications = prefs
+	if err := s.profiles.SaveProfile(ctx, *profile); err != nil {
+		return nil, fmt.Errorf("failed to save guest profile: %w", err)
+	}
+	return profile, nil
+}
+
+// UpdateTheme saves the color theme of the guest's UI.
+// Guests without a saved profile get one with the theme only.
+func (s *Service) UpdateTheme(ctx context.Context, guestID GuestID, theme Theme) (*GuestProfile, error) {
+	if s.profiles == nil {
+		return nil, ErrProfilesUnavailable
+	}
+	profile, err := s.GetGuestProfile(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	profile.Theme = theme
+	if err := s.profiles.SaveProfile(ctx, *profile); err != nil {
+		return nil, fmt.Errorf("failed to save guest profile: %w", err)
+	}
+	return profile, nil
+}
+
+// DeleteGuestProfile removes the profile of a guest, if any.
+func (s *Service) DeleteGuestProfile(ctx context.Context, guestID GuestID) error {
+	if s.profiles == nil {
+		return nil
+	}
+	if err := s.profiles.DeleteProfile(ctx, guestID); err != nil {
+		return fmt.Errorf("failed to delete guest profile: %w", err)
+	}
+	return nil
+}
+
 // ConfirmReservationOnPaymentCaptured handles the payment.captured event.
 // This is called by the event handler when a payment is successfully captured.
-func (s *Service) ConfirmReservationOnPaymentCaptured(ctx context.Context, reservationID ReservationID) error {
+// A reservation that was confirmed already is left as it is, so a redelivered
+// event does not fail the saga (and trigger its compensation). A captured amount
+// other than the reservation total refuses the confirmation with ErrPaymentMismatch.
+func (s *Service) ConfirmReservationOnPaymentCaptured(ctx context.Context, reservationID ReservationID, amount Money) error {
+	reservation, err := s.reservationRepo.Read(ctx, reservationID)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation: %w", err)
+	}
+	if err := reservation.VerifyPayment(amount); err != nil {
+		return err
+	}
+	if reservation.Status == StatusConfirmed {
+		return nil
+	}
 	return s.ConfirmReservation(ctx, reservationID)
 }
 
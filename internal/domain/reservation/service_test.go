@@ -8,6 +8,7 @@ import (
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
@@ -73,6 +74,16 @@ func (m *mockReservationRepository) ReadAll(ctx context.Context) ([]reservation.
 	return result, nil
 }
 
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	var result []reservation.Reservation
+	for _, res := range m.reservations {
+		if res.GuestID == guestID {
+			result = append(result, res)
+		}
+	}
+	return result, nil
+}
+
 type mockAvailabilityChecker struct {
 	available bool
 	err       error
@@ -106,6 +117,34 @@ func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error
 // Service Test Helpers
 // ============================================================================
 
+type mockGuestProfileRepository struct {
+	profiles map[reservation.GuestID]reservation.GuestProfile
+}
+
+func newMockGuestProfileRepository() *mockGuestProfileRepository {
+	return &mockGuestProfileRepository{
+		profiles: make(map[reservation.GuestID]reservation.GuestProfile),
+	}
+}
+
+func (m *mockGuestProfileRepository) FindProfile(ctx context.Context, guestID reservation.GuestID) (*reservation.GuestProfile, error) {
+	profile, ok := m.profiles[guestID]
+	if !ok {
+		return nil, nil
+	}
+	return &profile, nil
+}
+
+func (m *mockGuestProfileRepository) SaveProfile(ctx context.Context, profile reservation.GuestProfile) error {
+	m.profiles[profile.GuestID] = profile
+	return nil
+}
+
+func (m *mockGuestProfileRepository) DeleteProfile(ctx context.Context, guestID reservation.GuestID) error {
+	delete(m.profiles, guestID)
+	return nil
+}
+
 func createTestService(repo *mockReservationRepository, checker *mockAvailabilityChecker, publisher *mockEventPublisher) *reservation.Service {
 	return reservation.NewService(repo, checker, publisher)
 }
@@ -118,7 +157,7 @@ func serviceValidDateRange() reservation.DateRange {
 
 func serviceValidGuests() []reservation.GuestInfo {
 	return []reservation.GuestInfo{
-		reservation.NewGuestInfo("John Doe", "john@example.com", "+1234567890"),
+		{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+1234567890"},
 	}
 }
 
@@ -155,6 +194,24 @@ func Test_Service_CreateReservation_Should_Succeed(t *testing.T) {
 	assert.That(t, "reservation status must be pending", res.Status, reservation.StatusPending)
 }
 
+func Test_Service_CreateReservation_With_Payment_Plan_Should_Schedule_Balance(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher).
+		WithPaymentPlan(reservation.PaymentPlan{DepositPercent: 20, BalanceDueBefore: 24 * time.Hour})
+
+	// Act
+	res, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "schedule must not be nil", res.Schedule != nil, true)
+	assert.That(t, "amount due at booking must be the deposit", res.AmountDueAtBooking(), shared.NewMoney(2000, "USD"))
+	assert.That(t, "stored reservation must keep the schedule", repo.reservations["res-001"].Schedule != nil, true)
+}
+
 func Test_Service_CreateReservation_When_Room_Unavailable_Should_Return_Error(t *testing.T) {
 	// Arrange
 	repo := newMockReservationRepository()
@@ -271,6 +328,106 @@ func Test_Service_CreateReservation_When_Publisher_Fails_Should_Return_Error(t *
 	assert.That(t, "reservation must be nil", res == nil, true)
 }
 
+func Test_Service_CreateReservation_When_Policy_Violated_Should_Return_Violations(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher).WithPolicies(reservation.StaticBookingPolicies{
+		Rooms: map[reservation.RoomID]reservation.BookingPolicy{"room-101": {MinNights: 5}},
+	})
+
+	// Act
+	res, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101",
+		serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	var violations reservation.ValidationErrors
+	assert.That(t, "error must be ErrPolicyViolation", errors.Is(err, reservation.ErrPolicyViolation), true)
+	assert.That(t, "error must contain the violations", errors.As(err, &violations), true)
+	assert.That(t, "violation must concern the check-out", violations[0].Field, "check_out")
+	assert.That(t, "reservation must be nil", res == nil, true)
+	assert.That(t, "reservation must not be persisted", len(repo.reservations), 0)
+}
+
+func Test_Service_CreateReservation_When_Policy_Of_Other_Room_Should_Succeed(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher).WithPolicies(reservation.StaticBookingPolicies{
+		Rooms: map[reservation.RoomID]reservation.BookingPolicy{"room-202": {MinNights: 5}},
+	})
+
+	// Act
+	res, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101",
+		serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "reservation must not be nil", res != nil, true)
+}
+
+// ============================================================================
+// ImportReservation Tests
+// ============================================================================
+
+func Test_Service_ImportReservation_Should_Store_Confirmed_Reservation(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher)
+	ctx := context.Background()
+
+	// Act
+	res, err := service.ImportReservation(ctx, "booking.com-4711", "john@example.com", "room-101",
+		serviceValidDateRange(), serviceValidMoney(), serviceValidGuests(), "booking.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "reservation status must be confirmed", res.Status, reservation.StatusConfirmed)
+	assert.That(t, "reservation channel must match", res.Channel, "booking.com")
+	stored, _ := repo.Read(ctx, "booking.com-4711")
+	assert.That(t, "stored reservation must be confirmed", stored.Status, reservation.StatusConfirmed)
+}
+
+func Test_Service_ImportReservation_Should_Publish_Created_Event_With_Channel(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher)
+
+	// Act
+	_, err := service.ImportReservation(context.Background(), "booking.com-4711", "john@example.com", "room-101",
+		serviceValidDateRange(), serviceValidMoney(), serviceValidGuests(), "booking.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "two events must be published", len(publisher.published), 2)
+	created, ok := publisher.published[0].(*reservation.EventCreated)
+	assert.That(t, "first event must be created", ok, true)
+	assert.That(t, "created event must carry the channel", created.Channel, "booking.com")
+	assert.That(t, "second event must be confirmed", publisher.published[1].Topic(), reservation.EventTopicConfirmed)
+}
+
+func Test_Service_ImportReservation_When_Room_Unavailable_Should_Return_ErrRoomUnavailable(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: false}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher)
+
+	// Act
+	_, err := service.ImportReservation(context.Background(), "booking.com-4711", "john@example.com", "room-101",
+		serviceValidDateRange(), serviceValidMoney(), serviceValidGuests(), "booking.com")
+
+	// Assert
+	assert.That(t, "error must be ErrRoomUnavailable", errors.Is(err, reservation.ErrRoomUnavailable), true)
+	assert.That(t, "no event must be published", len(publisher.published), 0)
+}
+
 // ============================================================================
 // ConfirmReservation Tests
 // ============================================================================
@@ -454,6 +611,86 @@ func Test_Service_CompleteReservation_Should_Update_Status(t *testing.T) {
 	assert.That(t, "status must be completed", res.Status, reservation.StatusCompleted)
 }
 
+// ============================================================================
+// MarkNoShow Tests
+// ============================================================================
+
+func Test_Service_MarkNoShow_Should_Update_Status_And_Publish_Event(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher)
+
+	ctx := context.Background()
+	id := reservation.ReservationID("res-001")
+
+	_, _ = service.CreateReservation(ctx, id, "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_ = service.ConfirmReservation(ctx, id)
+	publisher.published = nil
+
+	// Act
+	err := service.MarkNoShow(ctx, id)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	res, _ := repo.Read(ctx, id)
+	assert.That(t, "status must be no-show", res.Status, reservation.StatusNoShow)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "event must be no-show", publisher.published[0].Topic(), reservation.EventTopicNoShow)
+}
+
+// ============================================================================
+// Add-On Tests
+// ============================================================================
+
+func Test_Service_AddAddOn_Should_Publish_Add_On_Added(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher).WithAddOnPricing(addOnPricing())
+
+	ctx := context.Background()
+	id := reservation.ReservationID("res-001")
+
+	_, _ = service.CreateReservation(ctx, id, "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_ = service.ConfirmReservation(ctx, id)
+	publisher.published = nil
+
+	// Act
+	_, err := service.AddAddOn(ctx, id, "addon-001", reservation.AddOnLateCheckout, time.Now())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "event must be add-on added", publisher.published[0].Topic(), reservation.EventTopicAddOnAdded)
+}
+
+func Test_Service_RemoveAddOn_Should_Publish_Add_On_Removed(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher).WithAddOnPricing(addOnPricing())
+
+	ctx := context.Background()
+	id := reservation.ReservationID("res-001")
+
+	_, _ = service.CreateReservation(ctx, id, "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_ = service.ConfirmReservation(ctx, id)
+	_, _ = service.AddAddOn(ctx, id, "addon-001", reservation.AddOnLateCheckout, time.Now())
+	publisher.published = nil
+
+	// Act
+	_, err := service.RemoveAddOn(ctx, id, "addon-001", time.Now())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "event must be add-on removed", publisher.published[0].Topic(), reservation.EventTopicAddOnRemoved)
+}
+
 // ============================================================================
 // GetReservation Tests
 // ============================================================================
@@ -522,6 +759,67 @@ func Test_Service_ListReservationsByGuest_Should_Return_Guest_Reservations(t *te
 	assert.That(t, "must have 2 reservations", len(reservations), 2)
 }
 
+func Test_Service_AnonymizeGuest_Should_Anonymize_Closed_Reservations(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	ctx := context.Background()
+	guestID := reservation.GuestID("guest-001")
+
+	_, _ = service.CreateReservation(ctx, "res-001", guestID, "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_, _ = service.CreateReservation(ctx, "res-002", "guest-002", "room-102", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_ = service.CancelReservation(ctx, "res-001", "guest request")
+
+	// Act
+	ids, err := service.AnonymizeGuest(ctx, guestID)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "one reservation must be anonymized", ids, []reservation.ReservationID{"res-001"})
+	stored, _ := repo.Read(ctx, "res-001")
+	assert.That(t, "guest ID must be anonymized", stored.GuestID, reservation.AnonymizedGuestID)
+	other, _ := repo.Read(ctx, "res-002")
+	assert.That(t, "other guests must be untouched", other.GuestID, reservation.GuestID("guest-002"))
+}
+
+func Test_Service_AnonymizeGuest_Should_Publish_Anonymized_Event(t *testing.T) {
+	// Arrange
+	publisher := &mockEventPublisher{}
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, publisher)
+	ctx := context.Background()
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_ = service.CancelReservation(ctx, "res-001", "guest request")
+	publisher.published = nil // reset
+
+	// Act
+	_, err := service.AnonymizeGuest(ctx, "guest-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "event must be anonymized", publisher.published[0].Topic(), reservation.EventTopicAnonymized)
+}
+
+func Test_Service_AnonymizeGuest_With_Open_Reservation_Should_Change_Nothing(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	ctx := context.Background()
+	guestID := reservation.GuestID("guest-001")
+
+	_, _ = service.CreateReservation(ctx, "res-001", guestID, "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_, _ = service.CreateReservation(ctx, "res-002", guestID, "room-102", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_ = service.CancelReservation(ctx, "res-001", "guest request")
+
+	// Act
+	_, err := service.AnonymizeGuest(ctx, guestID)
+
+	// Assert
+	assert.That(t, "error must be ErrReservationOpen", errors.Is(err, reservation.ErrReservationOpen), true)
+	stored, _ := repo.Read(ctx, "res-001")
+	assert.That(t, "closed reservation must be untouched", stored.GuestID, guestID)
+}
+
 // ============================================================================
 // Event Handler Integration Tests
 // ============================================================================
@@ -539,7 +837,7 @@ func Test_Service_ConfirmReservationOnPaymentCaptured_Should_Confirm(t *testing.
 	_, _ = service.CreateReservation(ctx, id, "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
 
 	// Act
-	err := service.ConfirmReservationOnPaymentCaptured(ctx, id)
+	err := service.ConfirmReservationOnPaymentCaptured(ctx, id, serviceValidMoney())
 
 	// Assert
 	assert.That(t, "error must be nil", err == nil, true)
@@ -547,6 +845,49 @@ func Test_Service_ConfirmReservationOnPaymentCaptured_Should_Confirm(t *testing.
 	assert.That(t, "status must be confirmed", res.Status, reservation.StatusConfirmed)
 }
 
+func Test_Service_ConfirmReservationOnPaymentCaptured_When_Confirmed_Should_Succeed(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher)
+
+	ctx := context.Background()
+	id := reservation.ReservationID("res-001")
+
+	_, _ = service.CreateReservation(ctx, id, "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_ = service.ConfirmReservation(ctx, id)
+	published := len(publisher.published)
+
+	// Act
+	err := service.ConfirmReservationOnPaymentCaptured(ctx, id, serviceValidMoney())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "no event must be published", len(publisher.published), published)
+}
+
+func Test_Service_ConfirmReservationOnPaymentCaptured_With_Mismatched_Amount_Should_Refuse(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher)
+
+	ctx := context.Background()
+	id := reservation.ReservationID("res-001")
+
+	_, _ = service.CreateReservation(ctx, id, "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Act
+	err := service.ConfirmReservationOnPaymentCaptured(ctx, id, shared.NewMoney(1, "USD"))
+
+	// Assert
+	assert.That(t, "error must be ErrPaymentMismatch", errors.Is(err, reservation.ErrPaymentMismatch), true)
+	res, _ := repo.Read(ctx, id)
+	assert.That(t, "status must remain pending", res.Status, reservation.StatusPending)
+}
+
 func Test_Service_CancelReservationOnPaymentFailed_Should_Cancel(t *testing.T) {
 	// Arrange
 	repo := newMockReservationRepository()
@@ -568,3 +909,241 @@ func Test_Service_CancelReservationOnPaymentFailed_Should_Cancel(t *testing.T) {
 	res, _ := repo.Read(ctx, id)
 	assert.That(t, "status must be cancelled", res.Status, reservation.StatusCancelled)
 }
+
+// ============================================================================
+// Guest Profile Tests
+// ============================================================================
+
+func Test_Service_GetGuestProfile_Without_Saved_Profile_Should_Return_Empty_Profile(t *testing.T) {
+	// Arrange
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).
+		WithGuestProfiles(newMockGuestProfileRepository())
+
+	// Act
+	profile, err := service.GetGuestProfile(context.Background(), "john@example.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "profile must be empty", *profile, reservation.GuestProfile{GuestID: "john@example.com"})
+}
+
+func Test_Service_UpdateGuestProfile_Should_Save_Profile(t *testing.T) {
+	// Arrange
+	profiles := newMockGuestProfileRepository()
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).
+		WithGuestProfiles(profiles)
+	ctx := context.Background()
+
+	// Act
+	_, err := service.UpdateGuestProfile(ctx, "john@example.com", "John Doe", "+15551234567", "de")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	profile, _ := service.GetGuestProfile(ctx, "john@example.com")
+	assert.That(t, "name must be saved", profile.Name, "John Doe")
+	assert.That(t, "phone must be saved", profile.PhoneNumber, reservation.PhoneNumber("+15551234567"))
+	assert.That(t, "locale must be saved", profile.Locale, "de")
+}
+
+func Test_Service_UpdateGuestProfile_With_Invalid_Phone_Should_Not_Save(t *testing.T) {
+	// Arrange
+	profiles := newMockGuestProfileRepository()
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).
+		WithGuestProfiles(profiles)
+
+	// Act
+	_, err := service.UpdateGuestProfile(context.Background(), "john@example.com", "John Doe", "555-1234", "")
+
+	// Assert
+	assert.That(t, "error must match ErrInvalidPhoneNumber", errors.Is(err, reservation.ErrInvalidPhoneNumber), true)
+	assert.That(t, "profile must not be saved", len(profiles.profiles), 0)
+}
+
+func Test_Service_UpdateGuestProfile_Should_Keep_Notification_Preferences(t *testing.T) {
+	// Arrange
+	profiles := newMockGuestProfileRepository()
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).
+		WithGuestProfiles(profiles)
+	ctx := context.Background()
+	prefs, _ := reservation.NewNotificationPreferences([]reservation.NotificationChannel{reservation.ChannelSMS}, nil)
+	_, _ = service.UpdateNotificationPreferences(ctx, "john@example.com", prefs)
+
+	// Act
+	_, err := service.UpdateGuestProfile(ctx, "john@example.com", "John Doe", "+15551234567", "")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	profile, _ := service.GetGuestProfile(ctx, "john@example.com")
+	assert.That(t, "name must be saved", profile.Name, "John Doe")
+	assert.That(t, "preferences must be kept", profile.Notifications.ChannelsFor("confirmation"), []reservation.NotificationChannel{reservation.ChannelSMS})
+}
+
+func Test_Service_UpdateGuestProfile_Should_Keep_Theme(t *testing.T) {
+	// Arrange
+	profiles := newMockGuestProfileRepository()
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).
+		WithGuestProfiles(profiles)
+	ctx := context.Background()
+	_, _ = service.UpdateTheme(ctx, "john@example.com", reservation.ThemeDark)
+
+	// Act
+	_, err := service.UpdateGuestProfile(ctx, "john@example.com", "John Doe", "", "")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	profile, _ := service.GetGuestProfile(ctx, "john@example.com")
+	assert.That(t, "theme must be kept", profile.Theme, reservation.ThemeDark)
+}
+
+func Test_Service_UpdateNotificationPreferences_Without_Repository_Should_Return_Error(t *testing.T) {
+	// Arrange
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+
+	// Act
+	_, err := service.UpdateNotificationPreferences(context.Background(), "john@example.com", reservation.NotificationPreferences{})
+
+	// Assert
+	assert.That(t, "error must be ErrProfilesUnavailable", errors.Is(err, reservation.ErrProfilesUnavailable), true)
+}
+
+func Test_Service_UpdateGuestProfile_Without_Repository_Should_Return_Error(t *testing.T) {
+	// Arrange
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+
+	// Act
+	_, err := service.UpdateGuestProfile(context.Background(), "john@example.com", "John Doe", "", "")
+
+	// Assert
+	assert.That(t, "error must be ErrProfilesUnavailable", errors.Is(err, reservation.ErrProfilesUnavailable), true)
+}
+
+// ============================================================================
+// Room Block Tests
+// ============================================================================
+
+func Test_Service_BlockRoom_Should_Save_Block_And_Publish_Event(t *testing.T) {
+	// Arrange
+	blocks := resource.NewInMemoryAccess[reservation.RoomBlockID, reservation.RoomBlock]()
+	publisher := &mockEventPublisher{}
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, publisher).
+		WithRoomBlocks(blocks)
+	ctx := context.Background()
+
+	// Act
+	_, err := service.BlockRoom(ctx, "block-001", "room-101", serviceValidDateRange(), reservation.BlockMaintenance, "")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	listed, _ := service.ListRoomBlocks(ctx, "room-101")
+	assert.That(t, "block must be saved", len(listed), 1)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	assert.That(t, "event must be room blocked", publisher.published[0].Topic(), reservation.EventTopicRoomBlocked)
+}
+
+func Test_Service_BlockRoom_Without_Repository_Should_Return_Error(t *testing.T) {
+	// Arrange
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+
+	// Act
+	_, err := service.BlockRoom(context.Background(), "block-001", "room-101", serviceValidDateRange(), reservation.BlockMaintenance, "")
+
+	// Assert
+	assert.That(t, "error must be ErrRoomBlocksUnavailable", errors.Is(err, reservation.ErrRoomBlocksUnavailable), true)
+}
+
+func Test_Service_RemoveRoomBlock_With_Other_Room_Should_Return_ErrRoomBlockNotFound(t *testing.T) {
+	// Arrange
+	blocks := resource.NewInMemoryAccess[reservation.RoomBlockID, reservation.RoomBlock]()
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).
+		WithRoomBlocks(blocks)
+	ctx := context.Background()
+	_, _ = service.BlockRoom(ctx, "block-001", "room-101", serviceValidDateRange(), reservation.BlockMaintenance, "")
+
+	// Act
+	err := service.RemoveRoomBlock(ctx, "room-102", "block-001")
+
+	// Assert
+	assert.That(t, "error must be ErrRoomBlockNotFound", errors.Is(err, reservation.ErrRoomBlockNotFound), true)
+	listed, _ := service.ListRoomBlocks(ctx, "room-101")
+	assert.That(t, "block must be kept", len(listed), 1)
+}
+
+func Test_Service_RemoveRoomBlock_Should_Delete_Block(t *testing.T) {
+	// Arrange
+	blocks := resource.NewInMemoryAccess[reservation.RoomBlockID, reservation.RoomBlock]()
+	publisher := &mockEventPublisher{}
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, publisher).
+		WithRoomBlocks(blocks)
+	ctx := context.Background()
+	_, _ = service.BlockRoom(ctx, "block-001", "room-101", serviceValidDateRange(), reservation.BlockMaintenance, "")
+	publisher.published = nil // reset
+
+	// Act
+	err := service.RemoveRoomBlock(ctx, "room-101", "block-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	listed, _ := service.ListRoomBlocks(ctx, "room-101")
+	assert.That(t, "block must be deleted", len(listed), 0)
+	assert.That(t, "event must be room unblocked", publisher.published[0].Topic(), reservation.EventTopicRoomUnblocked)
+}
+
+// ============================================================================
+// CheckInReservation Tests
+// ============================================================================
+
+func Test_Service_CheckInReservation_Should_Save_Card_Activate_And_Publish_Events(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	registrations := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Registration]()
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, publisher).
+		WithRegistrations(registrations)
+	ctx := context.Background()
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_ = service.ConfirmReservation(ctx, "res-001")
+	publisher.published = nil // reset
+	details := reservation.RegistrationDetails{DocumentType: reservation.DocumentIDCard, DocumentRef: "L01X00T47", Signed: true}
+
+	// Act
+	_, err := service.CheckInReservation(ctx, "res-001", details, "staff@example.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	res, _ := repo.Read(ctx, "res-001")
+	assert.That(t, "status must be active", res.Status, reservation.StatusActive)
+	card, _ := service.GetRegistration(ctx, "res-001")
+	assert.That(t, "card must be saved", card.DocumentRef, "L01X00T47")
+	assert.That(t, "two events must be published", len(publisher.published), 2)
+	assert.That(t, "first event must be activated", publisher.published[0].Topic(), reservation.EventTopicActivated)
+	assert.That(t, "second event must be checked in", publisher.published[1].Topic(), reservation.EventTopicCheckedIn)
+}
+
+func Test_Service_CheckInReservation_When_Pending_Should_Not_Save_Card(t *testing.T) {
+	// Arrange
+	registrations := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Registration]()
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).
+		WithRegistrations(registrations)
+	ctx := context.Background()
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	details := reservation.RegistrationDetails{DocumentType: reservation.DocumentPassport, DocumentRef: "C01X00T47", Signed: true}
+
+	// Act
+	_, err := service.CheckInReservation(ctx, "res-001", details, "staff@example.com")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidStateTransition", errors.Is(err, reservation.ErrInvalidStateTransition), true)
+	cards, _ := registrations.ReadAll(ctx)
+	assert.That(t, "card must not be saved", len(cards), 0)
+}
+
+func Test_Service_CheckInReservation_Without_Repository_Should_Return_Error(t *testing.T) {
+	// Arrange
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+
+	// Act
+	_, err := service.CheckInReservation(context.Background(), "res-001", reservation.RegistrationDetails{}, "staff@example.com")
+
+	// Assert
+	assert.That(t, "error must be ErrRegistrationsUnavailable", errors.Is(err, reservation.ErrRegistrationsUnavailable), true)
+}
@@ -0,0 +1,42 @@
+package reservation
+
+import (
+	"errors"
+	"strings"
+)
+
+// Theme is the color theme a guest sees the UI in.
+type Theme string
+
+const (
+	ThemeSystem Theme = "system" // Follows the color scheme of the device
+	ThemeLight  Theme = "light"
+	ThemeDark   Theme = "dark"
+)
+
+// ErrUnknownTheme is returned for a theme other than system, light or dark.
+var ErrUnknownTheme = errors.New("unknown theme, expected system, light or dark")
+
+// ParseTheme parses a theme. An empty value is the system theme.
+func ParseTheme(value string) (Theme, error) {
+	switch theme := Theme(strings.ToLower(strings.TrimSpace(value))); theme {
+	case "":
+		return ThemeSystem, nil
+	case ThemeSystem, ThemeLight, ThemeDark:
+		return theme, nil
+	default:
+		return "", ErrUnknownTheme
+	}
+}
+
+// Next returns the theme a toggle switches to: system, light, dark and system again.
+func (t Theme) Next() Theme {
+	switch t {
+	case ThemeLight:
+		return ThemeDark
+	case ThemeDark:
+		return ThemeSystem
+	default:
+		return ThemeLight
+	}
+}
@@ -0,0 +1,46 @@
+package reservation_test
+
+import (
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Theme Tests
+// ============================================================================
+
+func Test_ParseTheme_With_Empty_Value_Should_Return_System(t *testing.T) {
+	// Arrange
+	value := " "
+
+	// Act
+	theme, err := reservation.ParseTheme(value)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "theme must be system", theme, reservation.ThemeSystem)
+}
+
+func Test_ParseTheme_With_Unknown_Value_Should_Return_ErrUnknownTheme(t *testing.T) {
+	// Arrange
+	value := "sepia"
+
+	// Act
+	_, err := reservation.ParseTheme(value)
+
+	// Assert
+	assert.That(t, "err must be ErrUnknownTheme", err, reservation.ErrUnknownTheme)
+}
+
+func Test_Theme_Next_Should_Cycle_Through_Themes(t *testing.T) {
+	// Arrange
+	theme := reservation.ThemeSystem
+
+	// Act
+	next := []reservation.Theme{theme.Next(), theme.Next().Next(), theme.Next().Next().Next()}
+
+	// Assert
+	assert.That(t, "themes must cycle", next, []reservation.Theme{reservation.ThemeLight, reservation.ThemeDark, reservation.ThemeSystem})
+}
@@ -13,7 +13,6 @@ import (
 func RegisterTools(server *mcp.Server, service *Service, checker AvailabilityChecker) {
 	server.RegisterTool(newGetReservationTool(service))
 	server.RegisterTool(newListReservationsTool(service))
-	server.RegisterTool(newCancelReservationTool(service))
 	server.RegisterTool(newCheckAvailabilityTool(checker))
 }
 
@@ -67,32 +66,6 @@ func newListReservationsTool(service *Service) mcp.Tool {
 	)
 }
 
-// newCancelReservationTool creates a tool for canceling reservations.
-func newCancelReservationTool(service *Service) mcp.Tool {
-	return mcp.NewTool(
-		"cancel_reservation",
-		"Cancel a reservation. Requires a reason. Cannot cancel within 24 hours of check-in.",
-		mcp.NewObjectSchema(
-			map[string]mcp.Property{
-				"id":     mcp.NewStringProperty("The reservation ID"),
-				"reason": mcp.NewStringProperty("Reason for cancellation"),
-			},
-			[]string{"id", "reason"},
-		),
-		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
-			id, _ := params.Arguments["id"].(string)
-			reason, _ := params.Arguments["reason"].(string)
-			err := service.CancelReservation(ctx, ReservationID(id), reason)
-			if err != nil {
-				return mcp.ToolsCallResult{}, err
-			}
-			return mcp.ToolsCallResult{
-				Content: []mcp.ContentBlock{mcp.NewTextContent("Reservation cancelled successfully")},
-			}, nil
-		},
-	)
-}
-
 // newCheckAvailabilityTool creates a tool for checking room availability.
 func newCheckAvailabilityTool(checker AvailabilityChecker) mcp.Tool {
 	return mcp.NewTool(
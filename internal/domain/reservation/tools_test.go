@@ -71,6 +71,16 @@ func (m *toolsMockReservationRepository) ReadAll(ctx context.Context) ([]reserva
 	return result, nil
 }
 
+func (m *toolsMockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	var result []reservation.Reservation
+	for _, res := range m.reservations {
+		if res.GuestID == guestID {
+			result = append(result, res)
+		}
+	}
+	return result, nil
+}
+
 type toolsMockAvailabilityChecker struct {
 	available bool
 	err       error
@@ -116,7 +126,7 @@ func toolsValidDateRange() reservation.DateRange {
 
 func toolsValidGuests() []reservation.GuestInfo {
 	return []reservation.GuestInfo{
-		reservation.NewGuestInfo("John Doe", "john@example.com", "+1234567890"),
+		{Name: "John Doe", Email: "john@example.com", PhoneNumber: "+1234567890"},
 	}
 }
 
@@ -141,7 +151,7 @@ func Test_RegisterTools_Should_Register_All_Tools(t *testing.T) {
 
 	// Assert
 	tools := server.Tools()
-	assert.That(t, "must register 4 tools", len(tools), 4)
+	assert.That(t, "must register 3 tools", len(tools), 3)
 
 	// Verify tool names
 	toolNames := make(map[string]bool)
@@ -150,7 +160,6 @@ func Test_RegisterTools_Should_Register_All_Tools(t *testing.T) {
 	}
 	assert.That(t, "get_reservation must be registered", toolNames["get_reservation"], true)
 	assert.That(t, "list_reservations must be registered", toolNames["list_reservations"], true)
-	assert.That(t, "cancel_reservation must be registered", toolNames["cancel_reservation"], true)
 	assert.That(t, "check_availability must be registered", toolNames["check_availability"], true)
 }
 
@@ -273,84 +282,6 @@ func Test_ListReservationsTool_Should_Return_Guest_Reservations(t *testing.T) {
 	assert.That(t, "content must contain res-002", strings.Contains(result.Content[0].Text, "res-002"), true)
 }
 
-// ============================================================================
-// CancelReservation Tool Tests
-// ============================================================================
-
-func Test_CancelReservationTool_Should_Cancel_Reservation(t *testing.T) {
-	// Arrange
-	repo := newToolsMockReservationRepository()
-	checker := &toolsMockAvailabilityChecker{available: true}
-	publisher := &toolsMockEventPublisher{}
-	service := createToolsTestService(repo, checker, publisher)
-	server := mcp.NewServer("test-server", "1.0.0")
-	reservation.RegisterTools(server, service, checker)
-
-	ctx := context.Background()
-	id := reservation.ReservationID("res-001")
-
-	// Create a reservation first
-	_, _ = service.CreateReservation(ctx, id, "guest-001", "room-101", toolsValidDateRange(), toolsValidMoney(), toolsValidGuests())
-
-	tools := server.Tools()
-	var cancelTool mcp.Tool
-	for _, tool := range tools {
-		if tool.Definition.Name == "cancel_reservation" {
-			cancelTool = tool
-			break
-		}
-	}
-
-	params := mcp.ToolsCallParams{
-		Name:      "cancel_reservation",
-		Arguments: map[string]any{"id": "res-001", "reason": "Guest requested"},
-	}
-
-	// Act
-	result, err := cancelTool.Handler(ctx, params)
-
-	// Assert
-	assert.That(t, "error must be nil", err == nil, true)
-	assert.That(t, "must have 1 content block", len(result.Content), 1)
-	assert.That(t, "content must confirm cancellation", strings.Contains(result.Content[0].Text, "cancelled successfully"), true)
-
-	// Verify reservation is cancelled
-	res, _ := service.GetReservation(ctx, id)
-	assert.That(t, "status must be cancelled", res.Status, reservation.StatusCancelled)
-}
-
-func Test_CancelReservationTool_When_Not_Found_Should_Return_Error(t *testing.T) {
-	// Arrange
-	repo := newToolsMockReservationRepository()
-	checker := &toolsMockAvailabilityChecker{available: true}
-	publisher := &toolsMockEventPublisher{}
-	service := createToolsTestService(repo, checker, publisher)
-	server := mcp.NewServer("test-server", "1.0.0")
-	reservation.RegisterTools(server, service, checker)
-
-	ctx := context.Background()
-
-	tools := server.Tools()
-	var cancelTool mcp.Tool
-	for _, tool := range tools {
-		if tool.Definition.Name == "cancel_reservation" {
-			cancelTool = tool
-			break
-		}
-	}
-
-	params := mcp.ToolsCallParams{
-		Name:      "cancel_reservation",
-		Arguments: map[string]any{"id": "non-existent", "reason": "Test"},
-	}
-
-	// Act
-	_, err := cancelTool.Handler(ctx, params)
-
-	// Assert
-	assert.That(t, "error must not be nil", err != nil, true)
-}
-
 // ============================================================================
 // CheckAvailability Tool Tests
 // ============================================================================
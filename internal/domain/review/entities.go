@@ -0,0 +1,161 @@
+package review
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// MaxCommentLength is the maximum number of characters of a review comment.
+const MaxCommentLength = 2000
+
+// Review errors.
+var (
+	ErrReviewNotFound          = errors.New("review not found")
+	ErrInvalidReviewToken      = errors.New("invalid or expired review link")
+	ErrInvalidRating           = errors.New("invalid rating, expected 1 to 5 stars")
+	ErrCommentTooLong          = errors.New("review comment is too long")
+	ErrInvalidReviewTransition = errors.New("invalid review transition")
+	ErrInvalidReviewStatus     = errors.New("invalid review status, expected requested, pending, approved or rejected")
+	ErrInvalidModeration       = errors.New("invalid moderation, expected approved or rejected")
+)
+
+// ReviewID is a strongly-typed identifier for reviews.
+type ReviewID string
+
+// Status is the moderation status of a review.
+type Status string
+
+const (
+	// StatusRequested is a review the guest was asked for, but has not written yet.
+	StatusRequested Status = "requested"
+	// StatusPending is a submitted review that waits for moderation.
+	StatusPending Status = "pending"
+	// StatusApproved is a review that is published and counts towards the ratings.
+	StatusApproved Status = "approved"
+	// StatusRejected is a review that is not published.
+	StatusRejected Status = "rejected"
+)
+
+// ParseStatus parses a review status; the empty string matches every status.
+func ParseStatus(s string) (Status, error) {
+	switch status := Status(s); status {
+	case "", StatusRequested, StatusPending, StatusApproved, StatusRejected:
+		return status, nil
+	default:
+		return "", ErrInvalidReviewStatus
+	}
+}
+
+// ParseModeration parses the outcome of a moderation.
+func ParseModeration(s string) (Status, error) {
+	switch status := Status(s); status {
+	case StatusApproved, StatusRejected:
+		return status, nil
+	default:
+		return "", ErrInvalidModeration
+	}
+}
+
+// reviewStates holds the status transitions of reviews.
+// A moderator can revise the outcome of a moderation.
+var reviewStates = shared.NewStateMachine[Status](ErrInvalidReviewTransition).
+	Allow(StatusRequested, StatusPending).
+	Allow(StatusPending, StatusApproved, StatusRejected).
+	Allow(StatusApproved, StatusRejected).
+	Allow(StatusRejected, StatusApproved)
+
+// Review is the feedback of a guest on a completed stay. There is one review
+// per reservation, requested after check-out and written via the emailed link.
+type Review struct {
+	ID            ReviewID                  `json:"id"`
+	ReservationID reservation.ReservationID `json:"reservation_id"`
+	RoomID        reservation.RoomID        `json:"room_id"`
+	GuestID       reservation.GuestID       `json:"guest_id"`
+	Rating        int                       `json:"rating,omitempty"`
+	Comment       string                    `json:"comment,omitempty"`
+	Status        Status                    `json:"status"`
+	RequestedAt   time.Time                 `json:"requested_at"`
+	RequestSentAt time.Time                 `json:"request_sent_at"`
+	SubmittedAt   time.Time                 `json:"submitted_at"`
+	ModeratedAt   time.Time                 `json:"moderated_at"`
+}
+
+// NewReviewID creates a new ReviewID using the given generator.
+func NewReviewID(ids shared.IDGenerator) ReviewID {
+	return ReviewID(ids.NewID())
+}
+
+// NewReview creates the requested review of a reservation's stay.
+func NewReview(id ReviewID, res *reservation.Reservation, now time.Time) Review {
+	return Review{
+		ID:            id,
+		ReservationID: res.ID,
+		RoomID:        res.RoomID,
+		GuestID:       res.GuestID,
+		Status:        StatusRequested,
+		RequestedAt:   now,
+	}
+}
+
+// Submit records the rating and comment of the guest for moderation.
+func (r *Review) Submit(rating int, comment string, now time.Time) error {
+	if rating < 1 || rating > 5 {
+		return ErrInvalidRating
+	}
+	comment = strings.TrimSpace(comment)
+	if len([]rune(comment)) > MaxCommentLength {
+		return ErrCommentTooLong
+	}
+	if err := reviewStates.Transition(r.Status, StatusPending); err != nil {
+		return err
+	}
+
+	r.Rating = rating
+	r.Comment = comment
+	r.Status = StatusPending
+	r.SubmittedAt = now
+	return nil
+}
+
+// Moderate publishes or rejects the submitted review.
+func (r *Review) Moderate(status Status, now time.Time) error {
+	if _, err := ParseModeration(string(status)); err != nil {
+		return err
+	}
+	if err := reviewStates.Transition(r.Status, status); err != nil {
+		return err
+	}
+
+	r.Status = status
+	r.ModeratedAt = now
+	return nil
+}
+
+// Rating is the aggregate of the approved reviews of a room or the whole property.
+type Rating struct {
+	RoomID  reservation.RoomID `json:"room_id,omitempty"`
+	Average float64            `json:"average"`
+	Count   int                `json:"count"`
+}
+
+// NewRating aggregates the approved reviews; the average is rounded to one decimal.
+func NewRating(roomID reservation.RoomID, reviews []Review) Rating {
+	rating := Rating{RoomID: roomID}
+	sum := 0
+	for _, review := range reviews {
+		if review.Status != StatusApproved {
+			continue
+		}
+		sum += review.Rating
+		rating.Count++
+	}
+	if rating.Count > 0 {
+		rating.Average = math.Round(float64(sum)/float64(rating.Count)*10) / 10
+	}
+	return rating
+}
@@ -0,0 +1,17 @@
+package review
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ReviewRepository persists the reviews of the guests.
+type ReviewRepository resource.Access[ReviewID, Review]
+
+// RequestNotifier asks guests for a review of their stay.
+type RequestNotifier interface {
+	// SendReviewRequest sends the link to the review form to the guest
+	SendReviewRequest(ctx context.Context, r *reservation.Reservation, link string) error
+}
@@ -0,0 +1,307 @@
+// Package review collects the feedback of guests on their stays. It is a
+// downstream consumer of the event stream: a check-out requests a review, which
+// the guest writes via an emailed link. Staff moderate the submitted reviews,
+// and the approved ones make up the ratings of the rooms and the property.
+package review
+
+import (
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// RequestMessageType is the message type guests opt out of to get no review requests.
+const RequestMessageType = "review_request"
+
+// Service requests, collects and moderates reviews.
+type Service struct {
+	reservationService *reservation.Service
+	reviews            ReviewRepository
+	notifier           RequestNotifier
+	ids                shared.IDGenerator
+	secret             []byte
+	linkURL            string
+	ttl                time.Duration
+}
+
+// NewService creates a new review service. The links to the review form are
+// signed with the secret and valid for 30 days.
+func NewService(reservationSvc *reservation.Service, reviews ReviewRepository, notifier RequestNotifier, secret []byte) *Service {
+	return &Service{
+		reservationService: reservationSvc,
+		reviews:            reviews,
+		notifier:           notifier,
+		ids:                shared.NewUUIDv7Generator(),
+		secret:             secret,
+		linkURL:            "/api/reviews/{review_id}?token={token}",
+		ttl:                30 * 24 * time.Hour,
+	}
+}
+
+// WithIDGenerator sets the generator for new review IDs (UUIDv7 by default).
+func (s *Service) WithIDGenerator(ids shared.IDGenerator) *Service {
+	s.ids = ids
+	return s
+}
+
+// WithLinkURL sets the link to the review form. "{review_id}" and "{token}" in the
+// link are replaced by the ID of the review and the token that authorizes it.
+func (s *Service) WithLinkURL(url string) *Service {
+	s.linkURL = url
+	return s
+}
+
+// WithTTL sets how long after check-out the guest can write the review (default 30 days).
+func (s *Service) WithTTL(ttl time.Duration) *Service {
+	s.ttl = ttl
+	return s
+}
+
+// RegisterHandlers subscribes to the reservation events that request reviews.
+func (s *Service) RegisterHandlers(ctx context.Context, dispatcher messaging.Dispatcher) error {
+	// A check-out asks the guest for a review of the stay.
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCompleted, service.Wrap(s.handleReservationCompleted)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCompleted, err)
+	}
+
+	return nil
+}
+
+// RequestReview asks the guest of a completed stay for a review, unless the guest
+// opted out of review requests. Each guest is asked once per stay. Channel
+// reservations are skipped, because their guests review on the platform they booked on.
+func (s *Service) RequestReview(ctx context.Context, res *reservation.Reservation, now time.Time) error {
+	if res.Status != reservation.StatusCompleted || res.IsExternalHold() || res.Channel != "" {
+		return nil
+	}
+
+	// 1. Create the review once, so its link stays the same for a redelivered event
+	stored, err := s.reviewOf(ctx, res.ID)
+	if errors.Is(err, ErrReviewNotFound) {
+		review := NewReview(NewReviewID(s.ids), res, now)
+		if err := s.reviews.Create(ctx, review.ID, review); err != nil {
+			return fmt.Errorf("failed to create review: %w", err)
+		}
+		stored, err = &review, nil
+	}
+	if err != nil {
+		return err
+	}
+	if !stored.RequestSentAt.IsZero() {
+		return nil
+	}
+
+	// 2. Respect the guest's preferences
+	profile, err := s.reservationService.GetGuestProfile(ctx, res.GuestID)
+	if err != nil {
+		return err
+	}
+	if !profile.Notifications.Allows(RequestMessageType) {
+		return nil
+	}
+
+	// 3. Send the link and record it, so the guest is asked once
+	if err := s.notifier.SendReviewRequest(ctx, res, s.Link(stored)); err != nil {
+		return fmt.Errorf("failed to send review request: %w", err)
+	}
+	stored.RequestSentAt = now
+	if err := s.reviews.Update(ctx, stored.ID, *stored); err != nil {
+		return fmt.Errorf("failed to update review: %w", err)
+	}
+	return nil
+}
+
+// Link returns the link to the form of the review, authorized by its token.
+func (s *Service) Link(review *Review) string {
+	return strings.NewReplacer("{review_id}", string(review.ID), "{token}", s.token(review)).Replace(s.linkURL)
+}
+
+// GetReviewRequest returns the review the token authorizes, e.g. to show the form.
+// An unknown review is reported as an invalid link, so its ID cannot be probed.
+func (s *Service) GetReviewRequest(ctx context.Context, id ReviewID, token string, now time.Time) (*Review, error) {
+	review, err := s.getReview(ctx, id)
+	if errors.Is(err, ErrReviewNotFound) {
+		return nil, ErrInvalidReviewToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verify(review, token, now); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// SubmitReview records the rating and comment of the guest the token authorizes.
+// The review is published once a moderator approves it.
+func (s *Service) SubmitReview(ctx context.Context, id ReviewID, token string, rating int, comment string, now time.Time) (*Review, error) {
+	review, err := s.GetReviewRequest(ctx, id, token, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := review.Submit(rating, comment, now); err != nil {
+		return nil, err
+	}
+	if err := s.reviews.Update(ctx, id, *review); err != nil {
+		return nil, fmt.Errorf("failed to update review: %w", err)
+	}
+	return review, nil
+}
+
+// ModerateReview publishes or rejects a submitted review.
+func (s *Service) ModerateReview(ctx context.Context, id ReviewID, status Status, now time.Time) (*Review, error) {
+	review, err := s.getReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := review.Moderate(status, now); err != nil {
+		return nil, err
+	}
+	if err := s.reviews.Update(ctx, id, *review); err != nil {
+		return nil, fmt.Errorf("failed to update review: %w", err)
+	}
+	return review, nil
+}
+
+// ListReviews returns the reviews with the given status, most recently submitted first.
+// The empty status returns all reviews.
+func (s *Service) ListReviews(ctx context.Context, status Status) ([]Review, error) {
+	reviews, err := s.reviews.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reviews: %w", err)
+	}
+
+	result := []Review{}
+	for _, review := range reviews {
+		if status == "" || review.Status == status {
+			result = append(result, review)
+		}
+	}
+	slices.SortFunc(result, func(a, b Review) int {
+		if c := b.SubmittedAt.Compare(a.SubmittedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return result, nil
+}
+
+// AnonymizeReviews removes the guest and the comment from the reviews of the guest
+// or the reservations, e.g. on an erasure request. The ratings are kept for the
+// room and property ratings. It returns the number of anonymized reviews.
+func (s *Service) AnonymizeReviews(ctx context.Context, guestID reservation.GuestID, reservationIDs []reservation.ReservationID) (int, error) {
+	reviews, err := s.reviews.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read reviews: %w", err)
+	}
+
+	anonymized := 0
+	for _, review := range reviews {
+		if review.GuestID != guestID && !slices.Contains(reservationIDs, review.ReservationID) {
+			continue
+		}
+		review.GuestID = ""
+		review.Comment = ""
+		if err := s.reviews.Update(ctx, review.ID, review); err != nil {
+			return anonymized, fmt.Errorf("failed to update review: %w", err)
+		}
+		anonymized++
+	}
+	return anonymized, nil
+}
+
+// RoomRating returns the rating of a room from its approved reviews.
+func (s *Service) RoomRating(ctx context.Context, roomID reservation.RoomID) (Rating, error) {
+	reviews, err := s.ListReviews(ctx, StatusApproved)
+	if err != nil {
+		return Rating{}, err
+	}
+	reviews = slices.DeleteFunc(reviews, func(r Review) bool { return r.RoomID != roomID })
+	return NewRating(roomID, reviews), nil
+}
+
+// PropertyRating returns the rating of the property from the approved reviews of all rooms.
+func (s *Service) PropertyRating(ctx context.Context) (Rating, error) {
+	reviews, err := s.ListReviews(ctx, StatusApproved)
+	if err != nil {
+		return Rating{}, err
+	}
+	return NewRating("", reviews), nil
+}
+
+// getReview reads a review.
+func (s *Service) getReview(ctx context.Context, id ReviewID) (*Review, error) {
+	review, err := s.reviews.Read(ctx, id)
+	if err != nil {
+		if err.Error() == resource.ErrorResourceNotFound {
+			return nil, fmt.Errorf("%w: %s", ErrReviewNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to read review: %w", err)
+	}
+	return review, nil
+}
+
+// reviewOf reads the review of a reservation.
+func (s *Service) reviewOf(ctx context.Context, reservationID reservation.ReservationID) (*Review, error) {
+	reviews, err := s.reviews.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reviews: %w", err)
+	}
+	for i := range reviews {
+		if reviews[i].ReservationID == reservationID {
+			return &reviews[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: reservation %s", ErrReviewNotFound, reservationID)
+}
+
+// token returns the signature of the review's ID and request time, which
+// authorizes the guest to write the review without signing in.
+func (s *Service) token(review *Review) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(string(review.ID) + "." + strconv.FormatInt(review.RequestedAt.Unix(), 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks that the token authorizes the review and has not expired.
+func (s *Service) verify(review *Review, token string, now time.Time) error {
+	if !hmac.Equal([]byte(token), []byte(s.token(review))) || now.After(review.RequestedAt.Add(s.ttl)) {
+		return ErrInvalidReviewToken
+	}
+	return nil
+}
+
+// handleReservationCompleted requests the review of a checked-out reservation.
+func (s *Service) handleReservationCompleted(msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventCompleted
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	ctx := context.Background()
+	res, err := s.reservationService.GetReservation(ctx, evt.ReservationID)
+	if err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	if err := s.RequestReview(ctx, res, time.Now()); err != nil {
+		return messaging.MessageStateFailed, err
+	}
+
+	return messaging.MessageStateCompleted, nil
+}
@@ -0,0 +1,378 @@
+package review_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/review"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockReservationRepository struct {
+	resource.Access[reservation.ReservationID, reservation.Reservation]
+}
+
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockAvailabilityChecker struct{}
+
+func (m *mockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	return true, nil
+}
+
+func (m *mockAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockEventPublisher struct{}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error {
+	return nil
+}
+
+type mockGuestProfiles struct {
+	profiles map[reservation.GuestID]reservation.GuestProfile
+}
+
+func (m *mockGuestProfiles) FindProfile(ctx context.Context, guestID reservation.GuestID) (*reservation.GuestProfile, error) {
+	profile, ok := m.profiles[guestID]
+	if !ok {
+		return nil, nil
+	}
+	return &profile, nil
+}
+
+func (m *mockGuestProfiles) SaveProfile(ctx context.Context, profile reservation.GuestProfile) error {
+	m.profiles[profile.GuestID] = profile
+	return nil
+}
+
+func (m *mockGuestProfiles) DeleteProfile(ctx context.Context, guestID reservation.GuestID) error {
+	delete(m.profiles, guestID)
+	return nil
+}
+
+type mockRequestNotifier struct {
+	sent int
+	link string
+}
+
+func (m *mockRequestNotifier) SendReviewRequest(ctx context.Context, r *reservation.Reservation, link string) error {
+	m.sent++
+	m.link = link
+	return nil
+}
+
+type mockDispatcher struct {
+	subscriptions map[string]service.Function[messaging.Message, messaging.MessageState]
+}
+
+func (m *mockDispatcher) Subscribe(ctx context.Context, topic string, handler service.Function[messaging.Message, messaging.MessageState]) error {
+	m.subscriptions[topic] = handler
+	return nil
+}
+
+func (m *mockDispatcher) Publish(ctx context.Context, msg messaging.Message) error {
+	return nil
+}
+
+func (m *mockDispatcher) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (m *mockDispatcher) trigger(topic string, evt any) (messaging.MessageState, error) {
+	data, _ := json.Marshal(evt)
+	return m.subscriptions[topic](context.Background(), messaging.NewMessage(topic, data))
+}
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type reviewTestServices struct {
+	reservations       *mockReservationRepository
+	reservationService *reservation.Service
+	notifier           *mockRequestNotifier
+	dispatcher         *mockDispatcher
+	reviewService      *review.Service
+}
+
+// createReviewTestServices returns a review service whose links are the bare tokens.
+func createReviewTestServices(t *testing.T) *reviewTestServices {
+	t.Helper()
+	repo := &mockReservationRepository{Access: resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()}
+	reservationService := reservation.NewService(repo, &mockAvailabilityChecker{}, &mockEventPublisher{})
+	notifier := &mockRequestNotifier{}
+	reviewService := review.NewService(reservationService,
+		resource.NewInMemoryAccess[review.ReviewID, review.Review](), notifier, []byte("secret")).
+		WithLinkURL("{token}")
+	dispatcher := &mockDispatcher{subscriptions: make(map[string]service.Function[messaging.Message, messaging.MessageState])}
+	if err := reviewService.RegisterHandlers(context.Background(), dispatcher); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	return &reviewTestServices{
+		reservations:       repo,
+		reservationService: reservationService,
+		notifier:           notifier,
+		dispatcher:         dispatcher,
+		reviewService:      reviewService,
+	}
+}
+
+// storeCompletedStay stores a completed reservation of the room.
+func storeCompletedStay(t *testing.T, svc *reviewTestServices, id reservation.ReservationID, roomID reservation.RoomID) *reservation.Reservation {
+	t.Helper()
+	checkIn := time.Now().AddDate(0, 0, -3)
+	res := &reservation.Reservation{
+		ID:          id,
+		GuestID:     "guest-001",
+		RoomID:      roomID,
+		DateRange:   reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2)),
+		Status:      reservation.StatusCompleted,
+		TotalAmount: shared.NewMoney(30000, "USD"),
+		Guests:      []reservation.GuestInfo{{Name: "John Doe", Email: "john@example.com"}},
+	}
+	_ = svc.reservations.Create(context.Background(), res.ID, *res)
+	return res
+}
+
+// reviewIDOf returns the ID of the review requested for a reservation.
+func reviewIDOf(t *testing.T, svc *reviewTestServices, reservationID reservation.ReservationID) review.ReviewID {
+	t.Helper()
+	reviews, _ := svc.reviewService.ListReviews(context.Background(), "")
+	for _, rev := range reviews {
+		if rev.ReservationID == reservationID {
+			return rev.ID
+		}
+	}
+	t.Fatalf("no review requested for %s", reservationID)
+	return ""
+}
+
+// submitReview requests and submits the review of a completed stay in the room.
+func submitReview(t *testing.T, svc *reviewTestServices, id reservation.ReservationID, roomID reservation.RoomID, rating int) review.ReviewID {
+	t.Helper()
+	ctx := context.Background()
+	res := storeCompletedStay(t, svc, id, roomID)
+	if err := svc.reviewService.RequestReview(ctx, res, time.Now()); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	reviewID := reviewIDOf(t, svc, res.ID)
+	if _, err := svc.reviewService.SubmitReview(ctx, reviewID, svc.notifier.link, rating, "", time.Now()); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	return reviewID
+}
+
+// ============================================================================
+// Request Tests
+// ============================================================================
+
+func Test_Service_On_Reservation_Completed_Should_Request_Review_Once(t *testing.T) {
+	// Arrange
+	svc := createReviewTestServices(t)
+	res := storeCompletedStay(t, svc, "res-001", "room-101")
+	evt := reservation.NewEventCompleted().WithReservationID(res.ID)
+
+	// Act
+	_, _ = svc.dispatcher.trigger(reservation.EventTopicCompleted, evt)
+	state, err := svc.dispatcher.trigger(reservation.EventTopicCompleted, evt)
+
+	// Assert
+	assert.That(t, "redelivery error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	assert.That(t, "guest must be asked once", svc.notifier.sent, 1)
+	reviews, _ := svc.reviewService.ListReviews(context.Background(), review.StatusRequested)
+	assert.That(t, "review must be requested", len(reviews), 1)
+	assert.That(t, "review must concern the room", reviews[0].RoomID, reservation.RoomID("room-101"))
+}
+
+type fixedIDGenerator struct{ id string }
+
+func (g fixedIDGenerator) NewID() string { return g.id }
+
+func Test_Service_RequestReview_Should_Use_Injected_IDGenerator(t *testing.T) {
+	// Arrange
+	svc := createReviewTestServices(t)
+	svc.reviewService.WithIDGenerator(fixedIDGenerator{id: "018f3c2a-0000-7000-8000-000000000001"})
+	res := storeCompletedStay(t, svc, "res-001", "room-101")
+
+	// Act
+	err := svc.reviewService.RequestReview(context.Background(), res, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "review must use the generated ID", reviewIDOf(t, svc, "res-001"), review.ReviewID("018f3c2a-0000-7000-8000-000000000001"))
+}
+
+func Test_Service_RequestReview_With_Opt_Out_Should_Not_Send_Request(t *testing.T) {
+	// Arrange
+	svc := createReviewTestServices(t)
+	res := storeCompletedStay(t, svc, "res-001", "room-101")
+	prefs, _ := reservation.NotificationPreferences{}.WithOptOut([]string{review.RequestMessageType})
+	svc.reservationService.WithGuestProfiles(&mockGuestProfiles{profiles: map[reservation.GuestID]reservation.GuestProfile{
+		"guest-001": {GuestID: "guest-001", Notifications: prefs},
+	}})
+
+	// Act
+	err := svc.reviewService.RequestReview(context.Background(), res, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "no request must be sent", svc.notifier.sent, 0)
+}
+
+// ============================================================================
+// Submit Tests
+// ============================================================================
+
+func Test_Service_SubmitReview_With_Token_Should_Wait_For_Moderation(t *testing.T) {
+	// Arrange
+	svc := createReviewTestServices(t)
+	res := storeCompletedStay(t, svc, "res-001", "room-101")
+	ctx := context.Background()
+	_ = svc.reviewService.RequestReview(ctx, res, time.Now())
+
+	// Act
+	submitted, err := svc.reviewService.SubmitReview(ctx, reviewIDOf(t, svc, "res-001"), svc.notifier.link, 4, " Quiet room ", time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "review must wait for moderation", submitted.Status, review.StatusPending)
+	assert.That(t, "rating must be recorded", submitted.Rating, 4)
+	assert.That(t, "comment must be trimmed", submitted.Comment, "Quiet room")
+}
+
+func Test_Service_SubmitReview_With_Invalid_Token_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createReviewTestServices(t)
+	res := storeCompletedStay(t, svc, "res-001", "room-101")
+	ctx := context.Background()
+	_ = svc.reviewService.RequestReview(ctx, res, time.Now())
+
+	// Act
+	_, err := svc.reviewService.SubmitReview(ctx, reviewIDOf(t, svc, "res-001"), "forged", 5, "", time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidReviewToken", errors.Is(err, review.ErrInvalidReviewToken), true)
+}
+
+func Test_Service_SubmitReview_After_Link_Expired_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createReviewTestServices(t)
+	res := storeCompletedStay(t, svc, "res-001", "room-101")
+	ctx := context.Background()
+	_ = svc.reviewService.RequestReview(ctx, res, time.Now())
+
+	// Act
+	_, err := svc.reviewService.SubmitReview(ctx, reviewIDOf(t, svc, "res-001"), svc.notifier.link, 5, "", time.Now().AddDate(0, 0, 31))
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidReviewToken", errors.Is(err, review.ErrInvalidReviewToken), true)
+}
+
+func Test_Service_SubmitReview_With_Invalid_Rating_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createReviewTestServices(t)
+	res := storeCompletedStay(t, svc, "res-001", "room-101")
+	ctx := context.Background()
+	_ = svc.reviewService.RequestReview(ctx, res, time.Now())
+
+	// Act
+	_, err := svc.reviewService.SubmitReview(ctx, reviewIDOf(t, svc, "res-001"), svc.notifier.link, 6, "", time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidRating", errors.Is(err, review.ErrInvalidRating), true)
+}
+
+func Test_Service_SubmitReview_Twice_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createReviewTestServices(t)
+	submitReview(t, svc, "res-001", "room-101", 5)
+
+	// Act
+	_, err := svc.reviewService.SubmitReview(context.Background(), reviewIDOf(t, svc, "res-001"), svc.notifier.link, 1, "", time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidReviewTransition", errors.Is(err, review.ErrInvalidReviewTransition), true)
+}
+
+// ============================================================================
+// Rating Tests
+// ============================================================================
+
+func Test_Service_RoomRating_Should_Aggregate_Approved_Reviews_Only(t *testing.T) {
+	// Arrange
+	svc := createReviewTestServices(t)
+	ctx := context.Background()
+	first := submitReview(t, svc, "res-001", "room-101", 5)
+	second := submitReview(t, svc, "res-002", "room-101", 4)
+	submitReview(t, svc, "res-003", "room-101", 1)
+	other := submitReview(t, svc, "res-004", "room-102", 2)
+	_, _ = svc.reviewService.ModerateReview(ctx, first, review.StatusApproved, time.Now())
+	_, _ = svc.reviewService.ModerateReview(ctx, second, review.StatusApproved, time.Now())
+	_, _ = svc.reviewService.ModerateReview(ctx, other, review.StatusApproved, time.Now())
+
+	// Act
+	room, err := svc.reviewService.RoomRating(ctx, "room-101")
+	property, _ := svc.reviewService.PropertyRating(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "room rating must count the approved reviews", room.Count, 2)
+	assert.That(t, "room rating must average the approved reviews", room.Average, 4.5)
+	assert.That(t, "property rating must count all rooms", property.Count, 3)
+	assert.That(t, "property rating must be rounded to one decimal", property.Average, 3.7)
+}
+
+func Test_Service_AnonymizeReviews_Should_Remove_Guest_And_Comment_But_Keep_Rating(t *testing.T) {
+	// Arrange
+	svc := createReviewTestServices(t)
+	ctx := context.Background()
+	res := storeCompletedStay(t, svc, "res-001", "room-101")
+	_ = svc.reviewService.RequestReview(ctx, res, time.Now())
+	reviewID := reviewIDOf(t, svc, res.ID)
+	_, _ = svc.reviewService.SubmitReview(ctx, reviewID, svc.notifier.link, 5, "Lovely stay, John", time.Now())
+	_, _ = svc.reviewService.ModerateReview(ctx, reviewID, review.StatusApproved, time.Now())
+
+	// Act
+	anonymized, err := svc.reviewService.AnonymizeReviews(ctx, "guest-001", nil)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "one review must be anonymized", anonymized, 1)
+	reviews, _ := svc.reviewService.ListReviews(ctx, "")
+	assert.That(t, "guest must be removed", reviews[0].GuestID, reservation.GuestID(""))
+	assert.That(t, "comment must be removed", reviews[0].Comment, "")
+	rating, _ := svc.reviewService.RoomRating(ctx, "room-101")
+	assert.That(t, "rating must be kept", rating.Average, 5.0)
+}
+
+func Test_Service_ModerateReview_Before_Submission_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createReviewTestServices(t)
+	res := storeCompletedStay(t, svc, "res-001", "room-101")
+	ctx := context.Background()
+	_ = svc.reviewService.RequestReview(ctx, res, time.Now())
+
+	// Act
+	_, err := svc.reviewService.ModerateReview(ctx, reviewIDOf(t, svc, "res-001"), review.StatusApproved, time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidReviewTransition", errors.Is(err, review.ErrInvalidReviewTransition), true)
+}
@@ -0,0 +1,102 @@
+package search
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// Paging limits of a search.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ErrInvalidQuery is returned for searches with an invalid page or page size.
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// ReservationSummary is the searchable projection of a reservation.
+// It holds the guest data staff search for, so it is updated when the
+// guest data of the reservation is erased.
+type ReservationSummary struct {
+	ReservationID reservation.ReservationID
+	GuestID       reservation.GuestID
+	GuestNames    []string
+	GuestEmails   []string
+	RoomID        reservation.RoomID
+	CheckIn       time.Time
+	CheckOut      time.Time
+	Status        reservation.ReservationStatus
+	Channel       string
+	UpdatedAt     time.Time
+}
+
+// Summarize projects a reservation into its summary.
+func Summarize(res *reservation.Reservation) ReservationSummary {
+	summary := ReservationSummary{
+		ReservationID: res.ID,
+		GuestID:       res.GuestID,
+		GuestNames:    make([]string, 0, len(res.Guests)),
+		GuestEmails:   make([]string, 0, len(res.Guests)),
+		RoomID:        res.RoomID,
+		CheckIn:       res.DateRange.CheckIn,
+		CheckOut:      res.DateRange.CheckOut,
+		Status:        res.Status,
+		Channel:       res.Channel,
+		UpdatedAt:     res.UpdatedAt,
+	}
+	for _, guest := range res.Guests {
+		if guest.Name != "" {
+			summary.GuestNames = append(summary.GuestNames, guest.Name)
+		}
+		if guest.Email != "" {
+			summary.GuestEmails = append(summary.GuestEmails, string(guest.Email))
+		}
+	}
+	return summary
+}
+
+// Query is a free-text search for reservations, e.g. by guest name or email.
+// An empty text matches all reservations. Pages start at 1.
+type Query struct {
+	Text string
+	Page int
+	Size int
+}
+
+// Normalize trims the text and applies the default page and page size.
+func (q Query) Normalize() (Query, error) {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Page == 0 {
+		q.Page = 1
+	}
+	if q.Size == 0 {
+		q.Size = DefaultPageSize
+	}
+	if q.Page < 1 || q.Size < 1 || q.Size > MaxPageSize {
+		return q, ErrInvalidQuery
+	}
+	return q, nil
+}
+
+// Offset returns the number of hits skipped before the page.
+func (q Query) Offset() int {
+	return (q.Page - 1) * q.Size
+}
+
+// Hit is a reservation matching a search. Highlights holds the matching
+// fragments per field, with the matched terms wrapped in <em> tags.
+type Hit struct {
+	Summary    ReservationSummary
+	Highlights map[string][]string
+}
+
+// Result is a page of hits with the total number of matches.
+type Result struct {
+	Total int
+	Page  int
+	Size  int
+	Hits  []Hit
+}
@@ -0,0 +1,11 @@
+package search
+
+import "context"
+
+// SearchIndex stores reservation summaries in a full-text search engine (e.g. OpenSearch).
+type SearchIndex interface {
+	// Index adds or replaces the summary of a reservation
+	Index(ctx context.Context, summary ReservationSummary) error
+	// Search returns the page of summaries matching the normalized query
+	Search(ctx context.Context, query Query) (*Result, error)
+}
@@ -0,0 +1,118 @@
+// Package search maintains a full-text search projection of the reservations.
+// Staff search reservations by guest name, email or free text; the projection
+// is kept up to date from the reservation events.
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// indexedTopics are the events that change the summary of a reservation.
+var indexedTopics = []string{
+	reservation.EventTopicCreated,
+	reservation.EventTopicConfirmed,
+	reservation.EventTopicActivated,
+	reservation.EventTopicCheckedIn,
+	reservation.EventTopicCompleted,
+	reservation.EventTopicCancelled,
+	reservation.EventTopicNoShow,
+	reservation.EventTopicAnonymized,
+}
+
+// Service searches reservations and keeps the search index up to date.
+type Service struct {
+	reservationService *reservation.Service
+	index              SearchIndex
+}
+
+// NewService creates a new search service.
+func NewService(reservationSvc *reservation.Service, index SearchIndex) *Service {
+	return &Service{
+		reservationService: reservationSvc,
+		index:              index,
+	}
+}
+
+// RegisterHandlers subscribes to the reservation events that change a summary.
+func (s *Service) RegisterHandlers(ctx context.Context, dispatcher messaging.Dispatcher) error {
+	for _, topic := range indexedTopics {
+		if err := dispatcher.Subscribe(ctx, topic, service.Wrap(s.handleReservationChanged)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// SearchReservations returns a page of the reservations matching the query.
+func (s *Service) SearchReservations(ctx context.Context, query Query) (*Result, error) {
+	query, err := query.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.index.Search(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search reservations: %w", err)
+	}
+	return result, nil
+}
+
+// Rebuild indexes all stored reservations, e.g. after the index was created.
+// It returns the number of indexed reservations.
+func (s *Service) Rebuild(ctx context.Context) (int, error) {
+	reservations, err := s.reservationService.ListReservations(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	indexed := 0
+	for _, res := range reservations {
+		if res.IsExternalHold() {
+			continue
+		}
+		if err := s.index.Index(ctx, Summarize(res)); err != nil {
+			return indexed, fmt.Errorf("failed to index reservation %s: %w", res.ID, err)
+		}
+		indexed++
+	}
+	return indexed, nil
+}
+
+// IndexReservation replaces the summary of the reservation in the index.
+// External holds have no guest and are not indexed.
+func (s *Service) IndexReservation(ctx context.Context, res *reservation.Reservation) error {
+	if res.IsExternalHold() {
+		return nil
+	}
+	if err := s.index.Index(ctx, Summarize(res)); err != nil {
+		return fmt.Errorf("failed to index reservation: %w", err)
+	}
+	return nil
+}
+
+// handleReservationChanged indexes the current state of the reservation of the event.
+// The events carry only some fields, so the reservation is read.
+func (s *Service) handleReservationChanged(msg messaging.Message) (messaging.MessageState, error) {
+	var evt struct {
+		ReservationID reservation.ReservationID `json:"reservation_id"`
+	}
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	ctx := context.Background()
+	res, err := s.reservationService.GetReservation(ctx, evt.ReservationID)
+	if err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if err := s.IndexReservation(ctx, res); err != nil {
+		return messaging.MessageStateFailed, err
+	}
+	return messaging.MessageStateCompleted, nil
+}
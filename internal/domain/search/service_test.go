@@ -0,0 +1,231 @@
+package search_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/search"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockReservationRepository struct {
+	resource.Access[reservation.ReservationID, reservation.Reservation]
+}
+
+func (m *mockReservationRepository) FindByGuestID(ctx context.Context, guestID reservation.GuestID) ([]reservation.Reservation, error) {
+	all, _ := m.ReadAll(ctx)
+	var found []reservation.Reservation
+	for _, r := range all {
+		if r.GuestID == guestID {
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
+
+type mockAvailabilityChecker struct{}
+
+func (m *mockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	return true, nil
+}
+
+func (m *mockAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	return nil, nil
+}
+
+type mockEventPublisher struct{}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error {
+	return nil
+}
+
+type mockSearchIndex struct {
+	summaries map[reservation.ReservationID]search.ReservationSummary
+	queries   []search.Query
+}
+
+func (m *mockSearchIndex) Index(ctx context.Context, summary search.ReservationSummary) error {
+	m.summaries[summary.ReservationID] = summary
+	return nil
+}
+
+func (m *mockSearchIndex) Search(ctx context.Context, query search.Query) (*search.Result, error) {
+	m.queries = append(m.queries, query)
+	return &search.Result{Page: query.Page, Size: query.Size}, nil
+}
+
+type mockDispatcher struct {
+	subscriptions map[string]service.Function[messaging.Message, messaging.MessageState]
+}
+
+func (m *mockDispatcher) Subscribe(ctx context.Context, topic string, handler service.Function[messaging.Message, messaging.MessageState]) error {
+	m.subscriptions[topic] = handler
+	return nil
+}
+
+func (m *mockDispatcher) Publish(ctx context.Context, msg messaging.Message) error {
+	return nil
+}
+
+func (m *mockDispatcher) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (m *mockDispatcher) trigger(topic string, evt any) (messaging.MessageState, error) {
+	data, _ := json.Marshal(evt)
+	return m.subscriptions[topic](context.Background(), messaging.NewMessage(topic, data))
+}
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+type searchTestServices struct {
+	reservationService *reservation.Service
+	index              *mockSearchIndex
+	dispatcher         *mockDispatcher
+	searchService      *search.Service
+}
+
+func createSearchTestServices() *searchTestServices {
+	repo := &mockReservationRepository{Access: resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()}
+	reservationService := reservation.NewService(repo, &mockAvailabilityChecker{}, &mockEventPublisher{})
+	index := &mockSearchIndex{summaries: make(map[reservation.ReservationID]search.ReservationSummary)}
+	dispatcher := &mockDispatcher{subscriptions: make(map[string]service.Function[messaging.Message, messaging.MessageState])}
+	searchService := search.NewService(reservationService, index)
+	_ = searchService.RegisterHandlers(context.Background(), dispatcher)
+	return &searchTestServices{
+		reservationService: reservationService,
+		index:              index,
+		dispatcher:         dispatcher,
+		searchService:      searchService,
+	}
+}
+
+func createSearchTestReservation(t *testing.T, svc *searchTestServices, id reservation.ReservationID) {
+	t.Helper()
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	_, err := svc.reservationService.CreateReservation(context.Background(), id, "guest-001", "room-101",
+		reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2)),
+		shared.NewMoney(20000, "USD"),
+		[]reservation.GuestInfo{{Name: "Jane Doe", Email: "jane@example.com"}},
+	)
+	assert.That(t, "reservation must be created", err == nil, true)
+}
+
+// ============================================================================
+// Event Handler Tests
+// ============================================================================
+
+func Test_Service_Created_Event_Should_Index_Guest_Names_And_Emails(t *testing.T) {
+	// Arrange
+	svc := createSearchTestServices()
+	createSearchTestReservation(t, svc, "res-001")
+
+	// Act
+	state, err := svc.dispatcher.trigger(reservation.EventTopicCreated, reservation.NewEventCreated().WithReservationID("res-001"))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "message must be completed", state, messaging.MessageStateCompleted)
+	summary := svc.index.summaries["res-001"]
+	assert.That(t, "guest names must be indexed", summary.GuestNames, []string{"Jane Doe"})
+	assert.That(t, "guest emails must be indexed", summary.GuestEmails, []string{"jane@example.com"})
+	assert.That(t, "status must be indexed", summary.Status, reservation.StatusPending)
+}
+
+func Test_Service_Anonymized_Event_Should_Remove_Guest_Data_From_Index(t *testing.T) {
+	// Arrange
+	svc := createSearchTestServices()
+	ctx := context.Background()
+	createSearchTestReservation(t, svc, "res-001")
+	_, _ = svc.dispatcher.trigger(reservation.EventTopicCreated, reservation.NewEventCreated().WithReservationID("res-001"))
+	_ = svc.reservationService.CancelReservation(ctx, "res-001", "guest request")
+	_, _ = svc.reservationService.AnonymizeGuest(ctx, "guest-001")
+
+	// Act
+	_, err := svc.dispatcher.trigger(reservation.EventTopicAnonymized, reservation.NewEventAnonymized().WithReservationID("res-001"))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	summary := svc.index.summaries["res-001"]
+	assert.That(t, "guest ID must be anonymized", summary.GuestID, reservation.AnonymizedGuestID)
+	assert.That(t, "guest emails must be removed", len(summary.GuestEmails), 0)
+}
+
+func Test_Service_Created_Event_Of_External_Hold_Should_Not_Index(t *testing.T) {
+	// Arrange
+	svc := createSearchTestServices()
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	_, _ = svc.reservationService.PlaceExternalHold(context.Background(), "hold-001", "room-101",
+		reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2)), "airbnb", "evt-1@airbnb.com")
+
+	// Act
+	state, err := svc.dispatcher.trigger(reservation.EventTopicCreated, reservation.NewEventCreated().WithReservationID("hold-001"))
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "message must be completed", state, messaging.MessageStateCompleted)
+	assert.That(t, "hold must not be indexed", len(svc.index.summaries), 0)
+}
+
+// ============================================================================
+// SearchReservations Tests
+// ============================================================================
+
+func Test_Service_SearchReservations_Should_Apply_Default_Paging(t *testing.T) {
+	// Arrange
+	svc := createSearchTestServices()
+
+	// Act
+	result, err := svc.searchService.SearchReservations(context.Background(), search.Query{Text: "  jane  "})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "page must default to 1", result.Page, 1)
+	assert.That(t, "query must be trimmed and sized", svc.index.queries[0], search.Query{Text: "jane", Page: 1, Size: search.DefaultPageSize})
+}
+
+func Test_Service_SearchReservations_With_Too_Large_Page_Should_Return_ErrInvalidQuery(t *testing.T) {
+	// Arrange
+	svc := createSearchTestServices()
+
+	// Act
+	_, err := svc.searchService.SearchReservations(context.Background(), search.Query{Size: search.MaxPageSize + 1})
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidQuery", errors.Is(err, search.ErrInvalidQuery), true)
+	assert.That(t, "index must not be queried", len(svc.index.queries), 0)
+}
+
+// ============================================================================
+// Rebuild Tests
+// ============================================================================
+
+func Test_Service_Rebuild_Should_Index_All_Reservations(t *testing.T) {
+	// Arrange
+	svc := createSearchTestServices()
+	createSearchTestReservation(t, svc, "res-001")
+	createSearchTestReservation(t, svc, "res-002")
+
+	// Act
+	indexed, err := svc.searchService.Rebuild(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "two reservations must be indexed", indexed, 2)
+	assert.That(t, "index must contain both", len(svc.index.summaries), 2)
+}
@@ -0,0 +1,37 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/event"
+)
+
+// Aggregate collects the domain events an aggregate root records during its transitions.
+// Aggregate roots embed it, and services pull the recorded events after persisting
+// the aggregate and publish exactly those. The events are not persisted.
+type Aggregate struct {
+	events []event.Event
+}
+
+// RecordEvent records an event for the next call of PullEvents.
+func (a *Aggregate) RecordEvent(evt event.Event) {
+	a.events = append(a.events, evt)
+}
+
+// PullEvents returns the recorded events in order and forgets them.
+func (a *Aggregate) PullEvents() []event.Event {
+	events := a.events
+	a.events = nil
+	return events
+}
+
+// PublishEvents publishes the events in order and stops at the first failure.
+func PublishEvents(ctx context.Context, publisher event.EventPublisher, events []event.Event) error {
+	for _, evt := range events {
+		if err := publisher.Publish(ctx, evt); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	return nil
+}
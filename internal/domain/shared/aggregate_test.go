@@ -0,0 +1,71 @@
+package shared_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Aggregate Tests
+// ============================================================================
+
+type testEvent string
+
+func (e testEvent) Topic() string { return string(e) }
+
+type testPublisher struct {
+	published []event.Event
+	err       error
+}
+
+func (p *testPublisher) Publish(_ context.Context, evt event.Event) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, evt)
+	return nil
+}
+
+func Test_Aggregate_PullEvents_Should_Return_Recorded_Events_Once(t *testing.T) {
+	// Arrange
+	var aggregate shared.Aggregate
+	aggregate.RecordEvent(testEvent("created"))
+	aggregate.RecordEvent(testEvent("confirmed"))
+
+	// Act
+	events := aggregate.PullEvents()
+	again := aggregate.PullEvents()
+
+	// Assert
+	assert.That(t, "events must be returned in order", events, []event.Event{testEvent("created"), testEvent("confirmed")})
+	assert.That(t, "events must be forgotten", len(again), 0)
+}
+
+func Test_PublishEvents_Should_Publish_Events_In_Order(t *testing.T) {
+	// Arrange
+	publisher := &testPublisher{}
+	events := []event.Event{testEvent("created"), testEvent("confirmed")}
+
+	// Act
+	err := shared.PublishEvents(context.Background(), publisher, events)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "events must be published in order", publisher.published, events)
+}
+
+func Test_PublishEvents_When_Publisher_Fails_Should_Return_Error(t *testing.T) {
+	// Arrange
+	publisher := &testPublisher{err: errors.New("broker unavailable")}
+
+	// Act
+	err := shared.PublishEvents(context.Background(), publisher, []event.Event{testEvent("created")})
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
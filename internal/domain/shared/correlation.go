@@ -0,0 +1,19 @@
+package shared
+
+import "context"
+
+// correlationIDKey is the context key of the correlation ID.
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context with the correlation ID. It ties the request that
+// started a flow (e.g. a booking) to the events published along the way, so its complete
+// event trail can be inspected across bounded contexts.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID of the context, or an empty string.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
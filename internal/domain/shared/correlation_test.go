@@ -0,0 +1,35 @@
+package shared_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Correlation ID Tests
+// ============================================================================
+
+func Test_CorrelationID_Should_Return_ID_Of_Context(t *testing.T) {
+	// Arrange
+	ctx := shared.WithCorrelationID(context.Background(), "req-123")
+
+	// Act
+	id := shared.CorrelationID(ctx)
+
+	// Assert
+	assert.That(t, "correlation ID must match", id, "req-123")
+}
+
+func Test_CorrelationID_Without_ID_Should_Return_Empty_String(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	id := shared.CorrelationID(ctx)
+
+	// Assert
+	assert.That(t, "correlation ID must be empty", id, "")
+}
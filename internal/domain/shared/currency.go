@@ -0,0 +1,85 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency = errors.New("unknown currency code")
+	ErrInvalidAmount   = errors.New("invalid amount")
+)
+
+// Currency describes an ISO 4217 currency.
+type Currency struct {
+	Code       string // ISO 4217 alphabetic code (e.g., "USD")
+	MinorUnits int    // Digits after the decimal point (e.g., 2 for cents, 0 for JPY)
+}
+
+// currencies is the registry of supported currencies, keyed by code.
+var currencies = map[string]Currency{}
+
+func init() {
+	for _, code := range []string{
+		"AED", "AUD", "BRL", "CAD", "CHF", "CNY", "CZK", "DKK", "EUR", "GBP",
+		"HKD", "HUF", "ILS", "INR", "MXN", "NOK", "NZD", "PLN", "SAR", "SEK",
+		"SGD", "THB", "TRY", "USD", "ZAR",
+	} {
+		currencies[code] = Currency{Code: code, MinorUnits: 2}
+	}
+	for _, code := range []string{"CLP", "ISK", "JPY", "KRW", "VND"} {
+		currencies[code] = Currency{Code: code, MinorUnits: 0}
+	}
+	for _, code := range []string{"BHD", "IQD", "JOD", "KWD", "LYD", "OMR", "TND"} {
+		currencies[code] = Currency{Code: code, MinorUnits: 3}
+	}
+}
+
+// LookupCurrency returns the registered currency for a code (case-insensitive).
+func LookupCurrency(code string) (Currency, error) {
+	c, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+	return c, nil
+}
+
+// ParseAmount converts a decimal string in major units (e.g., "12.34")
+// into Money in the currency's smallest unit. More fraction digits than
+// the currency allows are rejected instead of rounded.
+func ParseAmount(amount, currency string) (Money, error) {
+	c, err := LookupCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	s := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	if len(fraction) > c.MinorUnits {
+		return Money{}, fmt.Errorf("%w: %s allows %d decimal places", ErrInvalidAmount, c.Code, c.MinorUnits)
+	}
+	fraction += strings.Repeat("0", c.MinorUnits-len(fraction))
+
+	var minor int64
+	for _, r := range whole + fraction {
+		if r < '0' || r > '9' {
+			return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+		}
+		if minor > (math.MaxInt64-int64(r-'0'))/10 {
+			return Money{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, amount)
+		}
+		minor = minor*10 + int64(r-'0')
+	}
+	if negative {
+		minor = -minor
+	}
+
+	return Money{Amount: minor, Currency: c.Code}, nil
+}
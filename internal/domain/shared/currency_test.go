@@ -0,0 +1,114 @@
+package shared_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// LookupCurrency Tests
+// ============================================================================
+
+func Test_LookupCurrency_Should_Return_Minor_Units(t *testing.T) {
+	// Act
+	usd, errUSD := shared.LookupCurrency("usd")
+	jpy, errJPY := shared.LookupCurrency("JPY")
+	kwd, errKWD := shared.LookupCurrency("KWD")
+
+	// Assert
+	assert.That(t, "errors must be nil", errors.Join(errUSD, errJPY, errKWD) == nil, true)
+	assert.That(t, "USD must have 2 minor units", usd, shared.Currency{Code: "USD", MinorUnits: 2})
+	assert.That(t, "JPY must have 0 minor units", jpy.MinorUnits, 0)
+	assert.That(t, "KWD must have 3 minor units", kwd.MinorUnits, 3)
+}
+
+func Test_LookupCurrency_With_Unknown_Code_Should_Return_ErrUnknownCurrency(t *testing.T) {
+	// Act
+	_, err := shared.LookupCurrency("XYZ")
+
+	// Assert
+	assert.That(t, "error must be ErrUnknownCurrency", errors.Is(err, shared.ErrUnknownCurrency), true)
+}
+
+// ============================================================================
+// ParseAmount Tests
+// ============================================================================
+
+func Test_ParseAmount_Should_Convert_To_Minor_Units(t *testing.T) {
+	// Act
+	usd, err := shared.ParseAmount("12.34", "USD")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "money must match", usd, shared.Money{Amount: 1234, Currency: "USD"})
+}
+
+func Test_ParseAmount_With_Fewer_Decimals_Should_Pad_Minor_Units(t *testing.T) {
+	// Act
+	eur, _ := shared.ParseAmount("12.5", "eur")
+	whole, _ := shared.ParseAmount("100", "USD")
+	negative, _ := shared.ParseAmount("-0.05", "USD")
+
+	// Assert
+	assert.That(t, "fraction must be padded", eur, shared.Money{Amount: 1250, Currency: "EUR"})
+	assert.That(t, "whole amount must be scaled", whole, shared.Money{Amount: 10000, Currency: "USD"})
+	assert.That(t, "negative amount must keep sign", negative, shared.Money{Amount: -5, Currency: "USD"})
+}
+
+func Test_ParseAmount_Should_Use_Currency_Minor_Units(t *testing.T) {
+	// Act
+	jpy, errJPY := shared.ParseAmount("1500", "JPY")
+	kwd, errKWD := shared.ParseAmount("1.234", "KWD")
+
+	// Assert
+	assert.That(t, "errors must be nil", errors.Join(errJPY, errKWD) == nil, true)
+	assert.That(t, "yen must have no minor units", jpy.Amount, int64(1500))
+	assert.That(t, "dinar must have three minor units", kwd.Amount, int64(1234))
+}
+
+func Test_ParseAmount_With_Too_Many_Decimals_Should_Return_ErrInvalidAmount(t *testing.T) {
+	// Act
+	_, errUSD := shared.ParseAmount("12.345", "USD")
+	_, errJPY := shared.ParseAmount("1.5", "JPY")
+
+	// Assert
+	assert.That(t, "USD error must be ErrInvalidAmount", errors.Is(errUSD, shared.ErrInvalidAmount), true)
+	assert.That(t, "JPY error must be ErrInvalidAmount", errors.Is(errJPY, shared.ErrInvalidAmount), true)
+}
+
+func Test_ParseAmount_With_Malformed_Input_Should_Return_ErrInvalidAmount(t *testing.T) {
+	// Act
+	_, errText := shared.ParseAmount("abc", "USD")
+	_, errEmpty := shared.ParseAmount("", "USD")
+	_, errGrouped := shared.ParseAmount("1,000.00", "USD")
+	_, errOverflow := shared.ParseAmount("99999999999999999999", "USD")
+
+	// Assert
+	assert.That(t, "text must be rejected", errors.Is(errText, shared.ErrInvalidAmount), true)
+	assert.That(t, "empty input must be rejected", errors.Is(errEmpty, shared.ErrInvalidAmount), true)
+	assert.That(t, "group separators must be rejected", errors.Is(errGrouped, shared.ErrInvalidAmount), true)
+	assert.That(t, "overflow must be rejected", errors.Is(errOverflow, shared.ErrInvalidAmount), true)
+}
+
+func Test_ParseAmount_With_Unknown_Currency_Should_Return_ErrUnknownCurrency(t *testing.T) {
+	// Act
+	_, err := shared.ParseAmount("10.00", "XYZ")
+
+	// Assert
+	assert.That(t, "error must be ErrUnknownCurrency", errors.Is(err, shared.ErrUnknownCurrency), true)
+}
+
+func Test_ParseAmount_Should_Round_Trip_With_FormatMajor(t *testing.T) {
+	// Arrange
+	m := shared.NewMoney(-123456, "KWD")
+
+	// Act
+	got, err := shared.ParseAmount(m.FormatMajor(), m.Currency)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "money must round-trip", got, m)
+}
@@ -0,0 +1,31 @@
+package shared
+
+import "context"
+
+// FeatureFlag identifies a feature that can be toggled per environment without a rebuild.
+type FeatureFlag string
+
+const (
+	// FlagSynchronousSaga runs the payment steps of the booking saga in a single
+	// synchronous call instead of chaining them through events.
+	FlagSynchronousSaga FeatureFlag = "synchronous-saga"
+)
+
+// FeatureFlags evaluates feature flags.
+// Implementations must not fail: if a flag cannot be evaluated, the default value is returned.
+type FeatureFlags interface {
+	// IsEnabled returns whether the flag is enabled or defaultValue if it is unknown
+	IsEnabled(ctx context.Context, flag FeatureFlag, defaultValue bool) bool
+}
+
+// StaticFeatureFlags is a fixed set of flag values.
+// It is the default for services and is useful in tests.
+type StaticFeatureFlags map[FeatureFlag]bool
+
+// IsEnabled returns the configured value of the flag or defaultValue if it is not set.
+func (f StaticFeatureFlags) IsEnabled(_ context.Context, flag FeatureFlag, defaultValue bool) bool {
+	if enabled, ok := f[flag]; ok {
+		return enabled
+	}
+	return defaultValue
+}
@@ -0,0 +1,35 @@
+package shared_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// StaticFeatureFlags Tests
+// ============================================================================
+
+func Test_StaticFeatureFlags_IsEnabled_With_Configured_Flag_Should_Return_Value(t *testing.T) {
+	// Arrange
+	flags := shared.StaticFeatureFlags{shared.FlagSynchronousSaga: true}
+
+	// Act
+	enabled := flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, false)
+
+	// Assert
+	assert.That(t, "flag must be enabled", enabled, true)
+}
+
+func Test_StaticFeatureFlags_IsEnabled_With_Unknown_Flag_Should_Return_Default(t *testing.T) {
+	// Arrange
+	flags := shared.StaticFeatureFlags{}
+
+	// Act
+	enabled := flags.IsEnabled(context.Background(), shared.FlagSynchronousSaga, true)
+
+	// Assert
+	assert.That(t, "default must be returned", enabled, true)
+}
@@ -0,0 +1,116 @@
+package shared
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// IDGenerator creates unique identifiers for aggregates.
+// Implementations generate time-ordered IDs so that lexical order matches creation order.
+type IDGenerator interface {
+	// NewID returns a new unique identifier
+	NewID() string
+}
+
+// NewReservationID creates a new ReservationID using the given generator.
+func NewReservationID(ids IDGenerator) ReservationID {
+	return ReservationID(ids.NewID())
+}
+
+// UUIDv7Generator creates RFC 9562 version 7 UUIDs (millisecond timestamp + random bits).
+type UUIDv7Generator struct {
+	now func() time.Time
+}
+
+// NewUUIDv7Generator creates a new UUIDv7 generator. This is the default IDGenerator.
+func NewUUIDv7Generator() *UUIDv7Generator {
+	return &UUIDv7Generator{now: time.Now}
+}
+
+// WithClock sets the clock used for the timestamp part of the ID.
+func (g *UUIDv7Generator) WithClock(now func() time.Time) *UUIDv7Generator {
+	g.now = now
+	return g
+}
+
+// NewID returns a new UUIDv7 in its canonical 36-character form.
+func (g *UUIDv7Generator) NewID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[6:])
+	putTimestamp(b[:6], g.now())
+	b[6] = (b[6] & 0x0f) | 0x70 // version 7
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 9562 variant
+
+	var out [36]byte
+	hex.Encode(out[0:8], b[0:4])
+	out[8] = '-'
+	hex.Encode(out[9:13], b[4:6])
+	out[13] = '-'
+	hex.Encode(out[14:18], b[6:8])
+	out[18] = '-'
+	hex.Encode(out[19:23], b[8:10])
+	out[23] = '-'
+	hex.Encode(out[24:], b[10:])
+	return string(out[:])
+}
+
+// ULIDGenerator creates ULIDs (millisecond timestamp + 80 random bits, Crockford base32).
+type ULIDGenerator struct {
+	now func() time.Time
+}
+
+// NewULIDGenerator creates a new ULID generator.
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{now: time.Now}
+}
+
+// WithClock sets the clock used for the timestamp part of the ID.
+func (g *ULIDGenerator) WithClock(now func() time.Time) *ULIDGenerator {
+	g.now = now
+	return g
+}
+
+// crockford is the Crockford base32 alphabet used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewID returns a new ULID in its canonical 26-character form.
+func (g *ULIDGenerator) NewID() string {
+	var b [16]byte
+	putTimestamp(b[:6], g.now())
+	_, _ = rand.Read(b[6:])
+
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+
+	// 128 bits are encoded as 26 base32 characters, the first carrying 3 bits.
+	var sb strings.Builder
+	sb.Grow(26)
+	for i := 25; i >= 0; i-- {
+		shift := uint(i * 5)
+		var v uint64
+		switch {
+		case shift >= 64:
+			v = hi >> (shift - 64)
+		case shift > 59:
+			v = lo>>shift | hi<<(64-shift)
+		default:
+			v = lo >> shift
+		}
+		sb.WriteByte(crockford[v&0x1f])
+	}
+	return sb.String()
+}
+
+// putTimestamp writes the Unix millisecond timestamp as 48-bit big endian.
+func putTimestamp(dst []byte, t time.Time) {
+	ms := uint64(t.UnixMilli())
+	dst[0] = byte(ms >> 40)
+	dst[1] = byte(ms >> 32)
+	dst[2] = byte(ms >> 24)
+	dst[3] = byte(ms >> 16)
+	dst[4] = byte(ms >> 8)
+	dst[5] = byte(ms)
+}
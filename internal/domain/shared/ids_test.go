@@ -0,0 +1,95 @@
+package shared_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// UUIDv7Generator Tests
+// ============================================================================
+
+func Test_UUIDv7Generator_NewID_Should_Return_Version_7_UUID(t *testing.T) {
+	// Arrange
+	ids := shared.NewUUIDv7Generator()
+
+	// Act
+	id := ids.NewID()
+
+	// Assert
+	assert.That(t, "length must be 36", len(id), 36)
+	assert.That(t, "version must be 7", id[14], byte('7'))
+	assert.That(t, "variant must be RFC 9562", strings.ContainsRune("89ab", rune(id[19])), true)
+}
+
+func Test_UUIDv7Generator_NewID_Should_Encode_Timestamp(t *testing.T) {
+	// Arrange
+	now := time.UnixMilli(0x0189_6f3c_1a2b)
+	ids := shared.NewUUIDv7Generator().WithClock(func() time.Time { return now })
+
+	// Act
+	id := ids.NewID()
+
+	// Assert
+	assert.That(t, "timestamp prefix must match", id[:13], "01896f3c-1a2b")
+}
+
+func Test_UUIDv7Generator_NewID_Should_Be_Sortable_By_Time(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	ids := shared.NewUUIDv7Generator().WithClock(func() time.Time { return now })
+	first := ids.NewID()
+	now = now.Add(time.Millisecond)
+
+	// Act
+	second := ids.NewID()
+
+	// Assert
+	assert.That(t, "later ID must sort after earlier ID", first < second, true)
+}
+
+// ============================================================================
+// ULIDGenerator Tests
+// ============================================================================
+
+func Test_ULIDGenerator_NewID_Should_Encode_Timestamp(t *testing.T) {
+	// Arrange
+	now := time.UnixMilli(1469918176385)
+	ids := shared.NewULIDGenerator().WithClock(func() time.Time { return now })
+
+	// Act
+	id := ids.NewID()
+
+	// Assert
+	assert.That(t, "length must be 26", len(id), 26)
+	assert.That(t, "timestamp prefix must match", id[:10], "01ARYZ6S41")
+}
+
+func Test_ULIDGenerator_NewID_Should_Be_Sortable_By_Time(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	ids := shared.NewULIDGenerator().WithClock(func() time.Time { return now })
+	first := ids.NewID()
+	now = now.Add(time.Millisecond)
+
+	// Act
+	second := ids.NewID()
+
+	// Assert
+	assert.That(t, "later ID must sort after earlier ID", first < second, true)
+}
+
+func Test_ULIDGenerator_NewID_Should_Be_Unique(t *testing.T) {
+	// Arrange
+	ids := shared.NewULIDGenerator()
+
+	// Act
+	first, second := ids.NewID(), ids.NewID()
+
+	// Assert
+	assert.That(t, "IDs must differ", first != second, true)
+}
@@ -0,0 +1,106 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Money arithmetic errors. Results out of the int64 range are reported as ErrInvalidAmount.
+var (
+	ErrCurrencyMismatch = errors.New("currencies do not match")
+	ErrInvalidRatios    = errors.New("invalid ratios, expected non-negative ratios with a positive sum")
+)
+
+// Add returns the sum of both amounts, which must be in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, fmt.Errorf("%w: %d + %d is out of range", ErrInvalidAmount, m.Amount, other.Amount)
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+// Subtract returns the difference of both amounts, which must be in the same currency.
+func (m Money) Subtract(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	difference := m.Amount - other.Amount
+	if (other.Amount > 0 && difference > m.Amount) || (other.Amount < 0 && difference < m.Amount) {
+		return Money{}, fmt.Errorf("%w: %d - %d is out of range", ErrInvalidAmount, m.Amount, other.Amount)
+	}
+	return Money{Amount: difference, Currency: m.Currency}, nil
+}
+
+// Multiply returns the amount multiplied by the factor, e.g. the price of a quantity.
+func (m Money) Multiply(factor int64) (Money, error) {
+	if m.Amount == 0 || factor == 0 {
+		return Money{Amount: 0, Currency: m.Currency}, nil
+	}
+	product := m.Amount * factor
+	if product/factor != m.Amount || (factor == -1 && m.Amount == math.MinInt64) {
+		return Money{}, fmt.Errorf("%w: %d * %d is out of range", ErrInvalidAmount, m.Amount, factor)
+	}
+	return Money{Amount: product, Currency: m.Currency}, nil
+}
+
+// Allocate splits the amount into parts proportional to the ratios without losing
+// a minor unit: each part is rounded down, and the units left over go one by one to
+// the parts with the largest remainders, the earlier part first on a tie. The parts
+// always add up to the amount, e.g. 100 allocated 1:1:1 is 34, 33 and 33.
+// Negative amounts are split like their absolute value.
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	var total uint64
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidRatios, ratio)
+		}
+		var carry uint64
+		total, carry = bits.Add64(total, uint64(ratio), 0)
+		if carry != 0 {
+			return nil, fmt.Errorf("%w: the sum is out of range", ErrInvalidRatios)
+		}
+	}
+	if total == 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	// Each share is amount * ratio / total in 128 bits, so it cannot overflow;
+	// it is at most the amount, because no ratio exceeds the total.
+	amount := absInt64(m.Amount)
+	shares := make([]uint64, len(ratios))
+	remainders := make([]uint64, len(ratios))
+	left := amount
+	for i, ratio := range ratios {
+		hi, lo := bits.Mul64(amount, uint64(ratio))
+		shares[i], remainders[i] = bits.Div64(hi, lo, total)
+		left -= shares[i]
+	}
+
+	// The rounded down shares miss less than one unit per part.
+	for ; left > 0; left-- {
+		largest := 0
+		for i := range remainders {
+			if remainders[i] > remainders[largest] {
+				largest = i
+			}
+		}
+		shares[largest]++
+		remainders[largest] = 0
+	}
+
+	parts := make([]Money, len(ratios))
+	for i, share := range shares {
+		// Two's complement negation also covers a share of math.MinInt64.
+		if m.Amount < 0 {
+			share = -share
+		}
+		parts[i] = Money{Amount: int64(share), Currency: m.Currency}
+	}
+	return parts, nil
+}
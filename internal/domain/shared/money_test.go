@@ -0,0 +1,239 @@
+package shared_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Add Tests
+// ============================================================================
+
+func Test_Money_Add_Should_Return_Sum(t *testing.T) {
+	// Act
+	sum, err := shared.NewMoney(1050, "USD").Add(shared.NewMoney(-50, "USD"))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "sum must match", sum, shared.NewMoney(1000, "USD"))
+}
+
+func Test_Money_Add_With_Other_Currency_Should_Return_ErrCurrencyMismatch(t *testing.T) {
+	// Act
+	_, err := shared.NewMoney(100, "USD").Add(shared.NewMoney(100, "EUR"))
+
+	// Assert
+	assert.That(t, "error must be ErrCurrencyMismatch", errors.Is(err, shared.ErrCurrencyMismatch), true)
+}
+
+func Test_Money_Add_Out_Of_Range_Should_Return_ErrInvalidAmount(t *testing.T) {
+	// Arrange
+	tests := []struct {
+		a, b int64
+	}{
+		{math.MaxInt64, 1},
+		{math.MaxInt64 - 5, 10},
+		{math.MinInt64, -1},
+		{-5, math.MinInt64},
+	}
+
+	// Act & Assert
+	for _, tc := range tests {
+		_, err := shared.NewMoney(tc.a, "USD").Add(shared.NewMoney(tc.b, "USD"))
+		assert.That(t, fmt.Sprintf("%d + %d must overflow", tc.a, tc.b), errors.Is(err, shared.ErrInvalidAmount), true)
+	}
+}
+
+func Test_Money_Add_At_Range_Limits_Should_Return_Sum(t *testing.T) {
+	// Act
+	upper, errUpper := shared.NewMoney(math.MaxInt64-1, "USD").Add(shared.NewMoney(1, "USD"))
+	lower, errLower := shared.NewMoney(math.MinInt64+1, "USD").Add(shared.NewMoney(-1, "USD"))
+
+	// Assert
+	assert.That(t, "errors must be nil", errors.Join(errUpper, errLower) == nil, true)
+	assert.That(t, "upper limit must be reached", upper.Amount, int64(math.MaxInt64))
+	assert.That(t, "lower limit must be reached", lower.Amount, int64(math.MinInt64))
+}
+
+// ============================================================================
+// Subtract Tests
+// ============================================================================
+
+func Test_Money_Subtract_Should_Return_Difference(t *testing.T) {
+	// Act
+	difference, err := shared.NewMoney(1050, "USD").Subtract(shared.NewMoney(50, "USD"))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "difference must match", difference, shared.NewMoney(1000, "USD"))
+}
+
+func Test_Money_Subtract_With_Other_Currency_Should_Return_ErrCurrencyMismatch(t *testing.T) {
+	// Act
+	_, err := shared.NewMoney(100, "USD").Subtract(shared.NewMoney(100, "EUR"))
+
+	// Assert
+	assert.That(t, "error must be ErrCurrencyMismatch", errors.Is(err, shared.ErrCurrencyMismatch), true)
+}
+
+func Test_Money_Subtract_Out_Of_Range_Should_Return_ErrInvalidAmount(t *testing.T) {
+	// Arrange
+	tests := []struct {
+		a, b int64
+	}{
+		{math.MinInt64, 1},
+		{math.MaxInt64, -1},
+		{0, math.MinInt64},
+		{-5, math.MaxInt64},
+	}
+
+	// Act & Assert
+	for _, tc := range tests {
+		_, err := shared.NewMoney(tc.a, "USD").Subtract(shared.NewMoney(tc.b, "USD"))
+		assert.That(t, fmt.Sprintf("%d - %d must overflow", tc.a, tc.b), errors.Is(err, shared.ErrInvalidAmount), true)
+	}
+}
+
+// ============================================================================
+// Multiply Tests
+// ============================================================================
+
+func Test_Money_Multiply_Should_Return_Product(t *testing.T) {
+	// Arrange
+	tests := []struct {
+		amount, factor, expected int64
+	}{
+		{1500, 3, 4500},
+		{-1500, 3, -4500},
+		{1500, -2, -3000},
+		{0, math.MaxInt64, 0},
+		{math.MaxInt64, 1, math.MaxInt64},
+		{math.MinInt64, 1, math.MinInt64},
+		{-1, math.MaxInt64, -math.MaxInt64},
+	}
+
+	// Act & Assert
+	for _, tc := range tests {
+		product, err := shared.NewMoney(tc.amount, "EUR").Multiply(tc.factor)
+		assert.That(t, fmt.Sprintf("%d * %d must not fail", tc.amount, tc.factor), err, nil)
+		assert.That(t, fmt.Sprintf("%d * %d must match", tc.amount, tc.factor), product, shared.NewMoney(tc.expected, "EUR"))
+	}
+}
+
+func Test_Money_Multiply_Out_Of_Range_Should_Return_ErrInvalidAmount(t *testing.T) {
+	// Arrange
+	tests := []struct {
+		amount, factor int64
+	}{
+		{math.MaxInt64, 2},
+		{math.MaxInt64/2 + 1, 2},
+		{math.MinInt64, -1},
+		{-1, math.MinInt64},
+		{1 << 32, 1 << 31},
+		{-(1 << 32), 1 << 31 << 1},
+	}
+
+	// Act & Assert
+	for _, tc := range tests {
+		_, err := shared.NewMoney(tc.amount, "USD").Multiply(tc.factor)
+		assert.That(t, fmt.Sprintf("%d * %d must overflow", tc.amount, tc.factor), errors.Is(err, shared.ErrInvalidAmount), true)
+	}
+}
+
+// ============================================================================
+// Allocate Tests
+// ============================================================================
+
+func Test_Money_Allocate_Should_Distribute_Remainder_By_Largest_Remainder(t *testing.T) {
+	// Arrange
+	tests := []struct {
+		amount   int64
+		ratios   []int
+		expected []int64
+	}{
+		{100, []int{1, 1, 1}, []int64{34, 33, 33}},
+		{5, []int{3, 7}, []int64{2, 3}},
+		{10001, []int{30, 70}, []int64{3000, 7001}},
+		{10002, []int{30, 70}, []int64{3001, 7001}},
+		{11900, []int{1900, 10000}, []int64{1900, 10000}},
+		{1, []int{1, 1}, []int64{1, 0}},
+		{7, []int{0, 1, 0}, []int64{0, 7, 0}},
+		{-100, []int{1, 1, 1}, []int64{-34, -33, -33}},
+		{0, []int{1, 2}, []int64{0, 0}},
+	}
+
+	// Act & Assert
+	for _, tc := range tests {
+		parts, err := shared.NewMoney(tc.amount, "USD").Allocate(tc.ratios...)
+		name := fmt.Sprintf("%d allocated %v", tc.amount, tc.ratios)
+		assert.That(t, name+" must not fail", err, nil)
+		amounts := make([]int64, len(parts))
+		for i, part := range parts {
+			amounts[i] = part.Amount
+			assert.That(t, name+" must keep the currency", part.Currency, "USD")
+		}
+		assert.That(t, name+" must match", amounts, tc.expected)
+	}
+}
+
+func Test_Money_Allocate_At_Range_Limits_Should_Not_Overflow(t *testing.T) {
+	// Act
+	upper, errUpper := shared.NewMoney(math.MaxInt64, "USD").Allocate(math.MaxInt32, math.MaxInt32, 1)
+	lower, errLower := shared.NewMoney(math.MinInt64, "USD").Allocate(1)
+
+	// Assert
+	assert.That(t, "errors must be nil", errors.Join(errUpper, errLower) == nil, true)
+	assert.That(t, "upper parts must add up", sumAmounts(upper).Cmp(big.NewInt(math.MaxInt64)), 0)
+	assert.That(t, "lower part must be the amount", lower[0].Amount, int64(math.MinInt64))
+}
+
+func Test_Money_Allocate_Should_Never_Lose_A_Minor_Unit(t *testing.T) {
+	// Arrange
+	ratioSets := [][]int{{1}, {1, 1}, {1, 2, 3}, {30, 70}, {1900, 10000}, {7, 0, 13, 1}, {3, 3, 3, 3, 3, 3, 3}}
+
+	// Act & Assert
+	for amount := int64(-250); amount <= 250; amount++ {
+		for _, ratios := range ratioSets {
+			parts, err := shared.NewMoney(amount, "USD").Allocate(ratios...)
+			name := fmt.Sprintf("%d allocated %v", amount, ratios)
+			assert.That(t, name+" must not fail", err, nil)
+			assert.That(t, name+" must add up", sumAmounts(parts).Cmp(big.NewInt(amount)), 0)
+
+			total := 0
+			for _, ratio := range ratios {
+				total += ratio
+			}
+			for i, part := range parts {
+				// Each part is at most one unit away from its exact share.
+				exact := float64(amount) * float64(ratios[i]) / float64(total)
+				assert.That(t, name+" must be fair", math.Abs(float64(part.Amount)-exact) < 1, true)
+			}
+		}
+	}
+}
+
+func Test_Money_Allocate_With_Invalid_Ratios_Should_Return_ErrInvalidRatios(t *testing.T) {
+	// Arrange
+	tests := [][]int{nil, {0}, {0, 0}, {1, -1}, {math.MaxInt, math.MaxInt, math.MaxInt}}
+
+	// Act & Assert
+	for _, ratios := range tests {
+		_, err := shared.NewMoney(100, "USD").Allocate(ratios...)
+		assert.That(t, fmt.Sprintf("%v must be rejected", ratios), errors.Is(err, shared.ErrInvalidRatios), true)
+	}
+}
+
+// sumAmounts adds up the amounts of the parts without overflow.
+func sumAmounts(parts []shared.Money) *big.Int {
+	sum := new(big.Int)
+	for _, part := range parts {
+		sum.Add(sum, big.NewInt(part.Amount))
+	}
+	return sum
+}
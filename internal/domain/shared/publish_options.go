@@ -0,0 +1,39 @@
+package shared
+
+import (
+	"context"
+	"time"
+)
+
+// priorityKey and deliverAtKey are the context keys of the publish options.
+type (
+	priorityKey  struct{}
+	deliverAtKey struct{}
+)
+
+// WithPriority returns a context whose events are published with priority, so that they
+// are handled before the events already waiting, e.g. a compensation that frees a room.
+// It is passed to the Publish method of the event.EventPublisher port.
+func WithPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, priorityKey{}, true)
+}
+
+// Priority reports whether the events of the context are published with priority.
+func Priority(ctx context.Context) bool {
+	priority, _ := ctx.Value(priorityKey{}).(bool)
+	return priority
+}
+
+// WithDeliverAt returns a context whose events are delivered not before the time,
+// e.g. a reminder the day before the arrival. The events are held back by the publisher,
+// so the handlers receive them at the time.
+func WithDeliverAt(ctx context.Context, at time.Time) context.Context {
+	return context.WithValue(ctx, deliverAtKey{}, at)
+}
+
+// DeliverAt returns the delivery time of the events of the context,
+// or the zero time if they are delivered at once.
+func DeliverAt(ctx context.Context) time.Time {
+	at, _ := ctx.Value(deliverAtKey{}).(time.Time)
+	return at
+}
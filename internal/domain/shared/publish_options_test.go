@@ -0,0 +1,39 @@
+package shared_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Publish Options Tests
+// ============================================================================
+
+func Test_Priority_Should_Return_Option_Of_Context(t *testing.T) {
+	// Arrange
+	ctx := shared.WithPriority(context.Background())
+
+	// Act
+	priority := shared.Priority(ctx)
+
+	// Assert
+	assert.That(t, "priority must be set", priority, true)
+	assert.That(t, "priority must not be set by default", shared.Priority(context.Background()), false)
+}
+
+func Test_DeliverAt_Should_Return_Time_Of_Context(t *testing.T) {
+	// Arrange
+	at := time.Now().Add(time.Hour)
+	ctx := shared.WithDeliverAt(context.Background(), at)
+
+	// Act
+	got := shared.DeliverAt(ctx)
+
+	// Assert
+	assert.That(t, "delivery time must match", got, at)
+	assert.That(t, "delivery time must be zero by default", shared.DeliverAt(context.Background()).IsZero(), true)
+}
@@ -0,0 +1,86 @@
+package shared
+
+import "fmt"
+
+// Guard vets a transition into a status before the transition table is consulted.
+// A non-nil error refuses the transition, so guards can report a more specific
+// error than the machine (e.g. "already captured" instead of "invalid transition").
+type Guard[S comparable] func(from S) error
+
+// TransitionHook observes every transition applied by a state machine.
+type TransitionHook[S comparable] func(from, to S)
+
+// StateMachine holds the allowed status transitions of an aggregate.
+// Aggregates share one machine per status type and call Transition before
+// changing their status, so the rules live in a single table.
+// Machines are configured once at package initialization and are read-only afterwards.
+type StateMachine[S comparable] struct {
+	invalid     error
+	transitions map[S]map[S]struct{}
+	guards      map[S][]Guard[S]
+	hooks       []TransitionHook[S]
+}
+
+// NewStateMachine creates a state machine that refuses transitions missing from
+// its table with the given error.
+func NewStateMachine[S comparable](invalid error) *StateMachine[S] {
+	return &StateMachine[S]{
+		invalid:     invalid,
+		transitions: make(map[S]map[S]struct{}),
+		guards:      make(map[S][]Guard[S]),
+	}
+}
+
+// Allow adds the transitions from a status into each of the target statuses.
+func (m *StateMachine[S]) Allow(from S, to ...S) *StateMachine[S] {
+	if m.transitions[from] == nil {
+		m.transitions[from] = make(map[S]struct{})
+	}
+	for _, target := range to {
+		m.transitions[from][target] = struct{}{}
+	}
+	return m
+}
+
+// Guard adds a guard for transitions into a status. Guards run in the order they were added.
+func (m *StateMachine[S]) Guard(to S, guard Guard[S]) *StateMachine[S] {
+	m.guards[to] = append(m.guards[to], guard)
+	return m
+}
+
+// OnTransition adds a hook that is called after every allowed transition.
+func (m *StateMachine[S]) OnTransition(hook TransitionHook[S]) *StateMachine[S] {
+	m.hooks = append(m.hooks, hook)
+	return m
+}
+
+// Can reports whether the transition is allowed.
+func (m *StateMachine[S]) Can(from, to S) bool {
+	return m.check(from, to) == nil
+}
+
+// Transition checks the transition against the guards and the table and notifies
+// the hooks if it is allowed. The caller applies the new status to its aggregate.
+func (m *StateMachine[S]) Transition(from, to S) error {
+	if err := m.check(from, to); err != nil {
+		return err
+	}
+	for _, hook := range m.hooks {
+		hook(from, to)
+	}
+	return nil
+}
+
+// check returns the error of the first guard refusing the transition,
+// or the machine's error if the table does not allow it.
+func (m *StateMachine[S]) check(from, to S) error {
+	for _, guard := range m.guards[to] {
+		if err := guard(from); err != nil {
+			return err
+		}
+	}
+	if _, ok := m.transitions[from][to]; !ok {
+		return fmt.Errorf("%w: cannot transition from %v to %v", m.invalid, from, to)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package shared_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// StateMachine Tests
+// ============================================================================
+
+var (
+	errTestInvalid = errors.New("invalid transition")
+	errTestAlready = errors.New("already closed")
+)
+
+func newTestStateMachine() *shared.StateMachine[string] {
+	return shared.NewStateMachine[string](errTestInvalid).
+		Allow("open", "closed", "archived").
+		Allow("closed", "archived").
+		Guard("closed", func(from string) error {
+			if from == "closed" {
+				return errTestAlready
+			}
+			return nil
+		})
+}
+
+func Test_StateMachine_Transition_With_Allowed_Transition_Should_Succeed(t *testing.T) {
+	// Arrange
+	machine := newTestStateMachine()
+
+	// Act
+	err := machine.Transition("open", "closed")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "transition must be possible", machine.Can("closed", "archived"), true)
+}
+
+func Test_StateMachine_Transition_With_Unknown_Transition_Should_Return_Error(t *testing.T) {
+	// Arrange
+	machine := newTestStateMachine()
+
+	// Act
+	err := machine.Transition("archived", "open")
+
+	// Assert
+	assert.That(t, "error must be the machine's error", errors.Is(err, errTestInvalid), true)
+	assert.That(t, "transition must not be possible", machine.Can("archived", "open"), false)
+}
+
+func Test_StateMachine_Transition_With_Refusing_Guard_Should_Return_Guard_Error(t *testing.T) {
+	// Arrange
+	machine := newTestStateMachine()
+
+	// Act
+	err := machine.Transition("closed", "closed")
+
+	// Assert
+	assert.That(t, "error must be the guard's error", errors.Is(err, errTestAlready), true)
+}
+
+func Test_StateMachine_Transition_Should_Notify_Hooks_Of_Allowed_Transitions(t *testing.T) {
+	// Arrange
+	var transitions []string
+	machine := newTestStateMachine().OnTransition(func(from, to string) {
+		transitions = append(transitions, from+"->"+to)
+	})
+
+	// Act
+	_ = machine.Transition("open", "closed")
+	_ = machine.Transition("archived", "open")
+
+	// Assert
+	assert.That(t, "only the allowed transition must be notified", transitions, []string{"open->closed"})
+}
@@ -4,22 +4,23 @@
 package shared
 
 import (
-	"fmt"
+	"strconv"
 	"strings"
 )
 
-// ReservationID is a strongly-typed identifier for reservations.
-// Shared because Payment needs to reference it.
+// ReservationID is the identifier of reservations exchanged between contexts.
+// The Reservation and Payment contexts have their own ID types and translate at the boundary.
 type ReservationID string
 
-// Money represents a monetary value in the smallest currency unit (cents).
+// Money represents a monetary value in the smallest currency unit.
 // Shared because both Reservation and Payment use it.
 type Money struct {
 	Currency string // ISO 4217 currency code (e.g., "USD", "EUR")
-	Amount   int64  // Amount in cents/smallest unit
+	Amount   int64  // Amount in the smallest unit (e.g., cents, or yen for JPY)
 }
 
-// NewMoney creates a Money value object with validation.
+// NewMoney creates a Money value object with a normalized currency code.
+// Use Validate or ParseAmount to reject unknown currencies.
 func NewMoney(amount int64, currency string) Money {
 	return Money{
 		Amount:   amount,
@@ -27,8 +28,50 @@ func NewMoney(amount int64, currency string) Money {
 	}
 }
 
-// FormatAmount returns a human-readable amount (converts cents to dollars).
+// Validate reports ErrUnknownCurrency if the currency is not registered.
+func (m Money) Validate() error {
+	_, err := LookupCurrency(m.Currency)
+	return err
+}
+
+// MinorUnits returns the number of decimal places of the currency.
+// Unknown currencies are assumed to have two.
+func (m Money) MinorUnits() int {
+	c, err := LookupCurrency(m.Currency)
+	if err != nil {
+		return 2
+	}
+	return c.MinorUnits
+}
+
+// FormatAmount returns a human-readable amount in major units (e.g., "100.50 USD", "1500 JPY").
 func (m Money) FormatAmount() string {
-	dollars := float64(m.Amount) / 100.0
-	return fmt.Sprintf("%.2f %s", dollars, m.Currency)
+	return m.FormatMajor() + " " + m.Currency
+}
+
+// FormatMajor returns the amount in major units without the currency code (e.g., "-1234.567").
+func (m Money) FormatMajor() string {
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(absInt64(amount), 10)
+
+	units := m.MinorUnits()
+	if units == 0 {
+		return sign + digits
+	}
+	if len(digits) <= units {
+		digits = strings.Repeat("0", units-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-units] + "." + digits[len(digits)-units:]
+}
+
+// absInt64 returns |n| as uint64, which also covers math.MinInt64.
+func absInt64(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
 }
@@ -0,0 +1,205 @@
+// Package i18n translates user-facing text and formats amounts and dates per locale.
+// Message catalogs are embedded JSON files under locales/, one per language.
+// It is used by the inbound adapters (templates) and the outbound adapters
+// (notifications), so it depends on the domain only for value objects.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"golang.org/x/text/language"
+)
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// DefaultLocale is used when no preference matches a supported locale.
+const DefaultLocale = "en"
+
+// catalog holds the messages and formatting rules of one locale.
+type catalog struct {
+	Name             string            `json:"name"`
+	DecimalSeparator string            `json:"decimal_separator"`
+	GroupSeparator   string            `json:"group_separator"`
+	MoneyPattern     string            `json:"money_pattern"` // e.g. "{currency} {amount}"
+	DateLayout       string            `json:"date_layout"`
+	DateTimeLayout   string            `json:"date_time_layout"`
+	Messages         map[string]string `json:"messages"`
+}
+
+// Option describes a supported locale, e.g. for a language selection.
+type Option struct {
+	Code string
+	Name string
+}
+
+var (
+	catalogs = mustLoadCatalogs()
+	tags     = supportedTags()
+	matcher  = language.NewMatcher(tags)
+)
+
+// mustLoadCatalogs parses the embedded catalogs. A broken catalog is a build defect.
+func mustLoadCatalogs() map[string]*catalog {
+	files, err := localesFS.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: could not read locales: %v", err))
+	}
+	loaded := make(map[string]*catalog, len(files))
+	for _, f := range files {
+		data, err := localesFS.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: could not read %s: %v", f.Name(), err))
+		}
+		var c catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			panic(fmt.Sprintf("i18n: could not parse %s: %v", f.Name(), err))
+		}
+		loaded[strings.TrimSuffix(f.Name(), ".json")] = &c
+	}
+	if _, ok := loaded[DefaultLocale]; !ok {
+		panic("i18n: default locale " + DefaultLocale + " is missing")
+	}
+	return loaded
+}
+
+// supportedTags returns the language tags of all catalogs, the default locale first.
+func supportedTags() []language.Tag {
+	result := []language.Tag{language.Make(DefaultLocale)}
+	for _, opt := range Supported() {
+		if opt.Code != DefaultLocale {
+			result = append(result, language.Make(opt.Code))
+		}
+	}
+	return result
+}
+
+// Supported returns the supported locales sorted by code.
+func Supported() []Option {
+	options := make([]Option, 0, len(catalogs))
+	for code, c := range catalogs {
+		options = append(options, Option{Code: code, Name: c.Name})
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i].Code < options[j].Code })
+	return options
+}
+
+// Negotiate returns a Localizer for the best supported locale.
+// Preferences are ordered by priority and may be single locales (e.g. from
+// the guest profile) or Accept-Language header values. Empty values are skipped.
+func Negotiate(preferences ...string) *Localizer {
+	var wanted []language.Tag
+	for _, pref := range preferences {
+		if strings.TrimSpace(pref) == "" {
+			continue
+		}
+		parsed, _, err := language.ParseAcceptLanguage(pref)
+		if err != nil {
+			continue
+		}
+		wanted = append(wanted, parsed...)
+	}
+	_, index, confidence := matcher.Match(wanted...)
+	if confidence == language.No {
+		index = 0
+	}
+	code := tags[index].String()
+	return &Localizer{code: code, catalog: catalogs[code]}
+}
+
+// Localizer translates messages and formats values for one locale.
+type Localizer struct {
+	code    string
+	catalog *catalog
+}
+
+// Lang returns the locale code, e.g. for the lang attribute of a page.
+func (l *Localizer) Lang() string {
+	return l.code
+}
+
+// T returns the translated message for key, formatted with args like fmt.Sprintf.
+// Missing keys fall back to the default locale and then to the key itself.
+func (l *Localizer) T(key string, args ...any) string {
+	msg, ok := l.catalog.Messages[key]
+	if !ok {
+		msg, ok = catalogs[DefaultLocale].Messages[key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Plural returns the translated message for key in the singular ("key.one")
+// or plural ("key.other") form, formatted with n.
+func (l *Localizer) Plural(key string, n int) string {
+	if n == 1 {
+		return l.T(key+".one", n)
+	}
+	return l.T(key+".other", n)
+}
+
+// Money formats an amount with the locale's separators and currency placement.
+// The number of decimal places follows the currency (e.g., none for JPY).
+func (l *Localizer) Money(m shared.Money) string {
+	major := m.FormatMajor()
+	sign := ""
+	if strings.HasPrefix(major, "-") {
+		sign = "-"
+		major = major[1:]
+	}
+	units, fraction, hasFraction := strings.Cut(major, ".")
+	formatted := sign + groupDigits(units, l.catalog.GroupSeparator)
+	if hasFraction {
+		formatted += l.catalog.DecimalSeparator + fraction
+	}
+
+	return strings.NewReplacer("{currency}", m.Currency, "{amount}", formatted).Replace(l.catalog.MoneyPattern)
+}
+
+// Date formats a calendar date.
+func (l *Localizer) Date(t time.Time) string {
+	return t.Format(l.catalog.DateLayout)
+}
+
+// DateTime formats a date with the time of day.
+func (l *Localizer) DateTime(t time.Time) string {
+	return t.Format(l.catalog.DateTimeLayout)
+}
+
+// DateRange formats a stay, e.g. "Jan 15, 2024 – Jan 18, 2024 (3 nights)".
+func (l *Localizer) DateRange(r reservation.DateRange) string {
+	nights := int(r.CheckOut.Sub(r.CheckIn).Hours() / 24)
+	return l.T("date_range", l.Date(r.CheckIn), l.Date(r.CheckOut), l.Plural("nights", nights))
+}
+
+// groupDigits inserts the separator between groups of three digits.
+func groupDigits(digits, separator string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(separator)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
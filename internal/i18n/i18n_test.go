@@ -0,0 +1,164 @@
+package i18n_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/i18n"
+)
+
+// ============================================================================
+// Negotiate Tests
+// ============================================================================
+
+func Test_Negotiate_Without_Preferences_Should_Return_Default_Locale(t *testing.T) {
+	// Act
+	loc := i18n.Negotiate()
+
+	// Assert
+	assert.That(t, "locale must be the default", loc.Lang(), i18n.DefaultLocale)
+}
+
+func Test_Negotiate_With_Accept_Language_Should_Return_Best_Match(t *testing.T) {
+	// Act
+	loc := i18n.Negotiate("fr-FR,de-DE;q=0.8,en;q=0.5")
+
+	// Assert
+	assert.That(t, "locale must be de", loc.Lang(), "de")
+}
+
+func Test_Negotiate_With_Profile_Locale_Should_Take_Precedence(t *testing.T) {
+	// Act
+	loc := i18n.Negotiate("de", "en-US,en;q=0.9")
+
+	// Assert
+	assert.That(t, "locale must be de", loc.Lang(), "de")
+}
+
+func Test_Negotiate_With_Unsupported_Locale_Should_Fall_Back_To_Next_Preference(t *testing.T) {
+	// Act
+	loc := i18n.Negotiate("ja", "de-AT")
+
+	// Assert
+	assert.That(t, "locale must be de", loc.Lang(), "de")
+}
+
+func Test_Negotiate_With_Invalid_Header_Should_Return_Default_Locale(t *testing.T) {
+	// Act
+	loc := i18n.Negotiate("not a language;;")
+
+	// Assert
+	assert.That(t, "locale must be the default", loc.Lang(), i18n.DefaultLocale)
+}
+
+func Test_Supported_Should_List_All_Catalogs(t *testing.T) {
+	// Act
+	options := i18n.Supported()
+
+	// Assert
+	assert.That(t, "options must be sorted by code", options, []i18n.Option{{Code: "de", Name: "Deutsch"}, {Code: "en", Name: "English"}})
+}
+
+// ============================================================================
+// Localizer Tests
+// ============================================================================
+
+func Test_Localizer_T_Should_Translate_Message(t *testing.T) {
+	// Act
+	en := i18n.Negotiate("en").T("nav.reservations")
+	de := i18n.Negotiate("de").T("nav.reservations")
+
+	// Assert
+	assert.That(t, "english message must match", en, "Reservations")
+	assert.That(t, "german message must match", de, "Buchungen")
+}
+
+func Test_Localizer_T_With_Args_Should_Format_Message(t *testing.T) {
+	// Act
+	msg := i18n.Negotiate("de").T("index.welcome", "Jane")
+
+	// Assert
+	assert.That(t, "message must be formatted", msg, "Willkommen, Jane!")
+}
+
+func Test_Localizer_T_With_Unknown_Key_Should_Return_Key(t *testing.T) {
+	// Act
+	msg := i18n.Negotiate("de").T("does.not.exist")
+
+	// Assert
+	assert.That(t, "message must be the key", msg, "does.not.exist")
+}
+
+func Test_Localizer_Plural_Should_Select_Form(t *testing.T) {
+	// Arrange
+	loc := i18n.Negotiate("en")
+
+	// Act
+	one := loc.Plural("nights", 1)
+	other := loc.Plural("nights", 3)
+
+	// Assert
+	assert.That(t, "singular must match", one, "1 night")
+	assert.That(t, "plural must match", other, "3 nights")
+}
+
+func Test_Localizer_Money_Should_Use_Locale_Separators(t *testing.T) {
+	// Arrange
+	m := shared.NewMoney(123456789, "EUR")
+
+	// Act
+	en := i18n.Negotiate("en").Money(m)
+	de := i18n.Negotiate("de").Money(m)
+
+	// Assert
+	assert.That(t, "english amount must match", en, "EUR 1,234,567.89")
+	assert.That(t, "german amount must match", de, "1.234.567,89 EUR")
+}
+
+func Test_Localizer_Money_With_Negative_Amount_Should_Keep_Sign(t *testing.T) {
+	// Act
+	formatted := i18n.Negotiate("en").Money(shared.NewMoney(-5005, "USD"))
+
+	// Assert
+	assert.That(t, "amount must be negative", formatted, "USD -50.05")
+}
+
+func Test_Localizer_Date_Should_Use_Locale_Layout(t *testing.T) {
+	// Arrange
+	date := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+
+	// Act
+	en := i18n.Negotiate("en").Date(date)
+	de := i18n.Negotiate("de").DateTime(date)
+
+	// Assert
+	assert.That(t, "english date must match", en, "Jan 15, 2024")
+	assert.That(t, "german date time must match", de, "15.01.2024 14:30")
+}
+
+func Test_Localizer_DateRange_Should_Include_Nights(t *testing.T) {
+	// Arrange
+	dr := reservation.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 18, 0, 0, 0, 0, time.UTC))
+
+	// Act
+	formatted := i18n.Negotiate("de").DateRange(dr)
+
+	// Assert
+	assert.That(t, "date range must match", formatted, "15.01.2024 – 18.01.2024 (3 Nächte)")
+}
+
+func Test_Localizer_Money_Should_Use_Currency_Minor_Units(t *testing.T) {
+	// Arrange
+	loc := i18n.Negotiate("de")
+
+	// Act
+	jpy := loc.Money(shared.NewMoney(1500000, "JPY"))
+	kwd := loc.Money(shared.NewMoney(1234567, "KWD"))
+
+	// Assert
+	assert.That(t, "yen must have no decimals", jpy, "1.500.000 JPY")
+	assert.That(t, "dinar must have three decimals", kwd, "1.234,567 KWD")
+}
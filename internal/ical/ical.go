@@ -0,0 +1,206 @@
+// Package ical reads and writes iCalendar feeds (RFC 5545) as used by booking
+// platforms such as Airbnb to exchange blocked dates. It only supports the
+// VEVENT properties needed for availability: UID, DTSTART, DTEND, SUMMARY and STATUS.
+// Like i18n, it sits outside the adapter layers, so both directions can use it.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCalendar is returned for input that is not an iCalendar stream.
+var ErrInvalidCalendar = errors.New("invalid iCalendar data")
+
+// Event is a calendar entry. All-day events start and end at midnight UTC;
+// the end date is exclusive, like the check-out date of a reservation.
+type Event struct {
+	UID       string
+	Start     time.Time
+	End       time.Time
+	AllDay    bool
+	Summary   string
+	Cancelled bool
+}
+
+// maxLineLength is the maximum length of a content line in octets, excluding CRLF.
+const maxLineLength = 75
+
+// Encode writes the events as an iCalendar stream with the given calendar name.
+func Encode(w io.Writer, name string, events []Event) error {
+	bw := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	writeLine(bw, "BEGIN:VCALENDAR")
+	writeLine(bw, "VERSION:2.0")
+	writeLine(bw, "PRODID:-//hotel-booking//room calendar//EN")
+	writeLine(bw, "CALSCALE:GREGORIAN")
+	writeLine(bw, "METHOD:PUBLISH")
+	writeLine(bw, "X-WR-CALNAME:"+escapeText(name))
+	for _, evt := range events {
+		writeLine(bw, "BEGIN:VEVENT")
+		writeLine(bw, "UID:"+escapeText(evt.UID))
+		writeLine(bw, "DTSTAMP:"+stamp)
+		if evt.AllDay {
+			writeLine(bw, "DTSTART;VALUE=DATE:"+evt.Start.Format("20060102"))
+			writeLine(bw, "DTEND;VALUE=DATE:"+evt.End.Format("20060102"))
+		} else {
+			writeLine(bw, "DTSTART:"+evt.Start.UTC().Format("20060102T150405Z"))
+			writeLine(bw, "DTEND:"+evt.End.UTC().Format("20060102T150405Z"))
+		}
+		if evt.Summary != "" {
+			writeLine(bw, "SUMMARY:"+escapeText(evt.Summary))
+		}
+		if evt.Cancelled {
+			writeLine(bw, "STATUS:CANCELLED")
+		}
+		writeLine(bw, "END:VEVENT")
+	}
+	writeLine(bw, "END:VCALENDAR")
+
+	return bw.Flush()
+}
+
+// Decode reads the events of an iCalendar stream.
+// Events without UID or DTSTART are skipped. A missing DTEND ends an all-day
+// event after one day and a timed event at its start.
+func Decode(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, ErrInvalidCalendar
+	}
+
+	var events []Event
+	var current *Event
+	var hasEnd bool
+	for _, line := range lines {
+		name, params, value, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current, hasEnd = &Event{}, false
+		case name == "END" && strings.EqualFold(value, "VEVENT") && current != nil:
+			if current.UID != "" && !current.Start.IsZero() {
+				if !hasEnd {
+					current.End = current.Start
+					if current.AllDay {
+						current.End = current.Start.AddDate(0, 0, 1)
+					}
+				}
+				events = append(events, *current)
+			}
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = unescapeText(value)
+		case name == "SUMMARY":
+			current.Summary = unescapeText(value)
+		case name == "STATUS":
+			current.Cancelled = strings.EqualFold(value, "CANCELLED")
+		case name == "DTSTART":
+			t, allDay, err := parseTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: DTSTART of %q: %v", ErrInvalidCalendar, current.UID, err)
+			}
+			current.Start, current.AllDay = t, allDay
+		case name == "DTEND":
+			t, _, err := parseTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: DTEND of %q: %v", ErrInvalidCalendar, current.UID, err)
+			}
+			current.End, hasEnd = t, true
+		}
+	}
+
+	return events, nil
+}
+
+// writeLine writes a content line, folded after 75 octets, with CRLF.
+// Lines are only split between UTF-8 characters.
+func writeLine(w *bufio.Writer, line string) {
+	for len(line) > maxLineLength {
+		cut := maxLineLength
+		for cut > 0 && (line[cut]&0xC0) == 0x80 {
+			cut--
+		}
+		_, _ = w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+	}
+	_, _ = w.WriteString(line + "\r\n")
+}
+
+// unfold joins folded content lines and drops empty lines.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// parseLine splits a content line into its upper-case name, parameters and value.
+func parseLine(line string) (name string, params map[string]string, value string, ok bool) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", nil, "", false
+	}
+	parts := strings.Split(head, ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		k, v, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, value, true
+}
+
+// parseTime parses a DATE or DATE-TIME value. Floating times without TZID are read as UTC.
+func parseTime(params map[string]string, value string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.Parse("20060102", value)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	loc := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// escapeText escapes a TEXT value.
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// unescapeText reverses escapeText.
+func unescapeText(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
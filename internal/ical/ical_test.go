@@ -0,0 +1,131 @@
+package ical_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/ical"
+)
+
+// ============================================================================
+// Encode Tests
+// ============================================================================
+
+func Test_Encode_With_All_Day_Event_Should_Write_Dates(t *testing.T) {
+	// Arrange
+	start := time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)
+	events := []ical.Event{{UID: "res-001", Start: start, End: start.AddDate(0, 0, 3), AllDay: true, Summary: "Reserved"}}
+	var buf bytes.Buffer
+
+	// Act
+	err := ical.Encode(&buf, "Room 101", events)
+
+	// Assert
+	out := buf.String()
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "calendar must start with BEGIN:VCALENDAR", strings.HasPrefix(out, "BEGIN:VCALENDAR\r\n"), true)
+	assert.That(t, "start must be a date", strings.Contains(out, "DTSTART;VALUE=DATE:20300501\r\n"), true)
+	assert.That(t, "end must be a date", strings.Contains(out, "DTEND;VALUE=DATE:20300504\r\n"), true)
+	assert.That(t, "summary must be written", strings.Contains(out, "SUMMARY:Reserved\r\n"), true)
+}
+
+func Test_Encode_With_Long_Line_Should_Fold_At_75_Octets(t *testing.T) {
+	// Arrange
+	start := time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)
+	events := []ical.Event{{UID: strings.Repeat("x", 100), Start: start, End: start.AddDate(0, 0, 1), AllDay: true}}
+	var buf bytes.Buffer
+
+	// Act
+	_ = ical.Encode(&buf, "Room 101", events)
+
+	// Assert
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		assert.That(t, "line must not exceed 75 octets", len(line) <= 75, true)
+	}
+}
+
+func Test_Encode_Then_Decode_Should_Round_Trip(t *testing.T) {
+	// Arrange
+	start := time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)
+	events := []ical.Event{{UID: "res-001@hotel", Start: start, End: start.AddDate(0, 0, 3), AllDay: true, Summary: "Blocked; airbnb, " + strings.Repeat("ü", 50)}}
+	var buf bytes.Buffer
+	_ = ical.Encode(&buf, "Room 101", events)
+
+	// Act
+	decoded, err := ical.Decode(&buf)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one event must be decoded", len(decoded), 1)
+	assert.That(t, "event must match", decoded[0], events[0])
+}
+
+// ============================================================================
+// Decode Tests
+// ============================================================================
+
+func Test_Decode_With_Airbnb_Feed_Should_Return_Events(t *testing.T) {
+	// Arrange
+	feed := "BEGIN:VCALENDAR\r\nPRODID:-//Airbnb Inc//Hosting Calendar 0.8.8//EN\r\nVERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\nDTEND;VALUE=DATE:20300510\r\nDTSTART;VALUE=DATE:20300507\r\n" +
+		"UID:1418fb94e984-c3bb3e2e43e4ddcf4c2f0a6fd4c0d2b4@airbnb.c\r\n om\r\nSUMMARY:Reserved\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	// Act
+	events, err := ical.Decode(strings.NewReader(feed))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one event must be decoded", len(events), 1)
+	assert.That(t, "folded UID must be joined", events[0].UID, "1418fb94e984-c3bb3e2e43e4ddcf4c2f0a6fd4c0d2b4@airbnb.com")
+	assert.That(t, "start must match", events[0].Start, time.Date(2030, 5, 7, 0, 0, 0, 0, time.UTC))
+	assert.That(t, "end must match", events[0].End, time.Date(2030, 5, 10, 0, 0, 0, 0, time.UTC))
+	assert.That(t, "event must be all-day", events[0].AllDay, true)
+}
+
+func Test_Decode_With_Timezone_Should_Convert_Time(t *testing.T) {
+	// Arrange
+	feed := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:evt-1\nDTSTART;TZID=Europe/Berlin:20300507T150000\nDTEND:20300510T100000Z\nEND:VEVENT\nEND:VCALENDAR\n"
+
+	// Act
+	events, err := ical.Decode(strings.NewReader(feed))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "start must be converted to UTC", events[0].Start.UTC(), time.Date(2030, 5, 7, 13, 0, 0, 0, time.UTC))
+	assert.That(t, "event must not be all-day", events[0].AllDay, false)
+}
+
+func Test_Decode_Without_End_Should_Last_One_Day(t *testing.T) {
+	// Arrange
+	feed := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:evt-1\nDTSTART;VALUE=DATE:20300507\nEND:VEVENT\nEND:VCALENDAR\n"
+
+	// Act
+	events, _ := ical.Decode(strings.NewReader(feed))
+
+	// Assert
+	assert.That(t, "end must be the next day", events[0].End, time.Date(2030, 5, 8, 0, 0, 0, 0, time.UTC))
+}
+
+func Test_Decode_With_Cancelled_Event_Should_Mark_It(t *testing.T) {
+	// Arrange
+	feed := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:evt-1\nDTSTART;VALUE=DATE:20300507\nSTATUS:CANCELLED\nEND:VEVENT\nEND:VCALENDAR\n"
+
+	// Act
+	events, _ := ical.Decode(strings.NewReader(feed))
+
+	// Assert
+	assert.That(t, "event must be cancelled", events[0].Cancelled, true)
+}
+
+func Test_Decode_With_HTML_Should_Return_ErrInvalidCalendar(t *testing.T) {
+	// Act
+	_, err := ical.Decode(strings.NewReader("<html><body>Not found</body></html>"))
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidCalendar", errors.Is(err, ical.ErrInvalidCalendar), true)
+}
@@ -11,3 +11,21 @@ CREATE TABLE IF NOT EXISTS kv_store (
 );
 
 CREATE INDEX IF NOT EXISTS idx_kv_store_key ON kv_store (key);
+
+-- Supports PaymentRepository.FindByReservationID lookups.
+CREATE INDEX IF NOT EXISTS idx_kv_store_reservation_id ON kv_store ((value::jsonb->>'ReservationID'));
+
+-- Commands handled by AuthorizePaymentForReservation (PostgresTableAccess), shared by
+-- all replicas. The command ID is the primary key, so a redelivered command is
+-- recorded only once even if two replicas handle it at the same time.
+CREATE TABLE IF NOT EXISTS processed_commands (
+    key TEXT PRIMARY KEY,
+    value JSONB NOT NULL
+);
+
+-- Cards stored by guests as gateway tokens (PostgresTableAccess), shared by all
+-- replicas, so a card stored on one of them can be charged on any other.
+CREATE TABLE IF NOT EXISTS payment_methods (
+    key TEXT PRIMARY KEY,
+    value JSONB NOT NULL
+);
@@ -11,3 +11,72 @@ CREATE TABLE IF NOT EXISTS kv_store (
 );
 
 CREATE INDEX IF NOT EXISTS idx_kv_store_key ON kv_store (key);
+
+-- Supports ReservationRepository.FindByGuestID lookups.
+CREATE INDEX IF NOT EXISTS idx_kv_store_guest_id ON kv_store ((value::jsonb->>'GuestID'));
+
+-- Login sessions for PostgresSessionStore (SESSION_STORE=postgres).
+CREATE TABLE IF NOT EXISTS sessions (
+    id TEXT PRIMARY KEY,
+    email TEXT NOT NULL,
+    claims JSONB NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_email ON sessions (email);
+CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON sessions (expires_at);
+
+-- Used magic link tokens and sign-in attempts for PostgresSessionStore,
+-- so the single use and the rate limit hold across replicas.
+CREATE TABLE IF NOT EXISTS login_nonces (
+    nonce TEXT PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+    key TEXT PRIMARY KEY,
+    window_start TIMESTAMPTZ NOT NULL,
+    count INT NOT NULL
+);
+
+-- Events held back until their delivery time by the DelayQueue (PostgresTableAccess),
+-- shared by all replicas, so the leader releases the events delayed on any of them.
+CREATE TABLE IF NOT EXISTS delayed_events (
+    key TEXT PRIMARY KEY,
+    value JSONB NOT NULL
+);
+
+-- Maintenance and renovation blocks of rooms (PostgresTableAccess), shared by all
+-- replicas, so the availability check of every replica sees them.
+CREATE TABLE IF NOT EXISTS room_blocks (
+    key TEXT PRIMARY KEY,
+    value JSONB NOT NULL
+);
+
+-- Failed saga compensations retried by the leader (PostgresTableAccess), shared by
+-- all replicas, so a compensation queued on any of them is retried.
+CREATE TABLE IF NOT EXISTS compensation_queue (
+    key TEXT PRIMARY KEY,
+    value JSONB NOT NULL
+);
+
+-- Progress of the booking sagas (PostgresTableAccess), shared by all replicas, so
+-- the status page shows the same state whichever replica handled the events.
+CREATE TABLE IF NOT EXISTS saga_states (
+    key TEXT PRIMARY KEY,
+    value JSONB NOT NULL
+);
+
+-- Delivery jobs of the guest notifications (PostgresTableAccess), shared by all
+-- replicas, so the leader retries the deliveries that failed on any of them.
+CREATE TABLE IF NOT EXISTS notification_jobs (
+    key TEXT PRIMARY KEY,
+    value JSONB NOT NULL
+);
+
+-- Guest profiles for PostgresGuestProfileRepository.
+-- Kept out of kv_store so reservation scans never see them.
+CREATE TABLE IF NOT EXISTS guest_profiles (
+    guest_id TEXT PRIMARY KEY,
+    profile TEXT NOT NULL
+);